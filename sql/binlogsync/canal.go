@@ -1,7 +1,6 @@
 package binlogsync
 
 import (
-	"bytes"
 	"context"
 	"crypto/tls"
 	"database/sql"
@@ -22,6 +21,7 @@ import (
 	"github.com/corestoreio/pkg/sync/singleflight"
 	"github.com/corestoreio/pkg/util/conv"
 	"github.com/go-sql-driver/mysql"
+	gomysql "github.com/siddontang/go-mysql/mysql"
 )
 
 // Use flavor for different MySQL versions,
@@ -39,12 +39,12 @@ const (
 	ConfigPathBinlogStartPosition = `sql/binlogsync/binlog_start_position`
 	ConfigPathBinlogSlaveID       = `sql/binlogsync/binlog_slave_id`
 	ConfigPathServerFlavor        = `sql/binlogsync/server_flavor`
+	ConfigPathBinlogStartGTID     = `sql/binlogsync/binlog_start_gtid`
 )
 
 // Canal can sync your MySQL data. MySQL must use the binlog format ROW.
 type Canal struct {
-	opts                      Options
-	configPathBackendPosition *config.Path
+	opts Options
 	// mclose acts only during the call to Close().
 	mclose sync.Mutex
 	// DSN contains the parsed DSN
@@ -56,18 +56,28 @@ type Canal struct {
 	masterMu           sync.RWMutex
 	masterStatus       ddl.MasterStatus
 	masterLastSaveTime time.Time
+	// gtidSet contains the executed GTIDs, only set if Options.UseGTID is
+	// true. pendingGTID gets added to gtidSet once the transaction has been
+	// processed. Only used by the syncer goroutine.
+	gtidSet     gomysql.GTIDSet
+	pendingGTID string
 
 	rsMu sync.RWMutex
 	// the empty map key declares event handler for all tables, filtered  by the regexes.
 	// Otherwise an event handler is only registered for a specific table.
-	rsHandlers map[string][]RowsEventHandler
+	rsHandlers  map[string][]RowsEventHandler
+	txHandlers  []TransactionHandler
+	ddlHandlers []DDLHandler
+	// txc collects the rows events of the current transaction. Only used by
+	// the syncer goroutine.
+	txc txCollector
 
 	// dbcp is a database connection pool
 	dbcp *dml.ConnPool
 
 	// Tables contains the overall SQL table cache. If a table gets modified
-	// during runtime of this program then somehow we must clear the cache to
-	// reload the table structures.
+	// during runtime of this program, the DDL statement in the binlog clears
+	// the table from the cache to reload the table structure.
 	tables *ddl.Tables
 	// tableSFG takes to only execute one SQL query per table in parallel
 	// situations. No need for a pointer because Canal is already a pointer. So
@@ -145,28 +155,61 @@ func withExcludeTables(regexes []string) func(c *Canal) error {
 }
 
 // withUpdateBinlogStart enables to start from a specific position or just start
-// from the current master position. See startSyncBinlog
+// from the current master position. The precedence is: a position loaded from
+// the PositionStore, Options BinlogStartFile and BinlogStartPosition,
+// Options.BinlogStartGTID and at last SHOW MASTER STATUS. The configured start
+// values apply only as long as the PositionStore has not saved a position,
+// otherwise each restart would replay the binlog from the static start. See
+// startSyncBinlog
 func withUpdateBinlogStart(c *Canal) error {
-
-	if c.opts.BinlogStartFile != "" && c.opts.BinlogStartPosition > 0 {
-		c.masterStatus.File = c.opts.BinlogStartFile
-		c.masterStatus.Position = uint(c.opts.BinlogStartPosition)
-		return nil
+	if c.opts.BinlogStartGTID != "" {
+		c.opts.UseGTID = true
 	}
-
 	if c.opts.MasterStatusQueryTimeout == 0 {
 		c.opts.MasterStatusQueryTimeout = time.Second * 20
 	}
 
-	var ms ddl.MasterStatus
 	ctx, cancel := context.WithTimeout(context.Background(), c.opts.MasterStatusQueryTimeout)
 	defer cancel()
-	if _, err := c.dbcp.WithQueryBuilder(&ms).Load(ctx, &ms); err != nil {
+
+	found, err := c.loadPosition(ctx)
+	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	c.masterStatus = ms
+	switch {
+	case found:
+		// resume from the saved position
+	case c.opts.BinlogStartFile != "" && c.opts.BinlogStartPosition > 0:
+		c.masterStatus.File = c.opts.BinlogStartFile
+		c.masterStatus.Position = uint(c.opts.BinlogStartPosition)
+		c.masterStatus.ExecutedGTIDSet = c.opts.BinlogStartGTID
+	case c.opts.BinlogStartGTID != "":
+		c.masterStatus.ExecutedGTIDSet = c.opts.BinlogStartGTID
+	default:
+		var ms ddl.MasterStatus
+		if _, err := c.dbcp.WithQueryBuilder(&ms).Load(ctx, &ms); err != nil {
+			return errors.WithStack(err)
+		}
+		c.masterStatus = ms
+		if c.opts.UseGTID && c.opts.Flavor == MariaDBFlavor {
+			// MariaDB does not return the GTID set in SHOW MASTER STATUS.
+			v := ddl.NewVariables("gtid_current_pos")
+			if _, err := c.dbcp.WithQueryBuilder(v).Load(ctx, v); err != nil {
+				return errors.WithStack(err)
+			}
+			c.masterStatus.ExecutedGTIDSet = v.Data["gtid_current_pos"]
+		}
+	}
 
+	if !c.opts.UseGTID {
+		return nil
+	}
+	gs, err := gomysql.ParseGTIDSet(c.opts.Flavor, c.masterStatus.ExecutedGTIDSet)
+	if err != nil {
+		return errors.NotValid.New(err, "[binlogsync] Failed to parse GTID set %q for flavor %q", c.masterStatus.ExecutedGTIDSet, c.opts.Flavor)
+	}
+	c.gtidSet = gs
 	return nil
 }
 
@@ -218,10 +261,19 @@ type Options struct {
 	// ConfigScoped defines the configuration to load the following fields from.
 	// If not set the data won't be loaded.
 	ConfigScoped config.Scoped
-	// ConfigSet used to persists the master position of the binlog stream.
+	// ConfigSet used to persists the master position of the binlog stream. If
+	// PositionStore is nil, a PositionConfig gets created with ConfigScoped and
+	// ConfigSet.
 	ConfigSet config.Setter
-	Log       log.Logger
-	TLSConfig *tls.Config // Needs some rework
+	// PositionStore persists the binlog position to resume the sync after a
+	// restart. Optional.
+	PositionStore PositionStore
+	// CheckpointInterval defines how often the position gets saved to the
+	// PositionStore. The position gets only saved between transactions and
+	// after all handlers have finished. Defaults to one second.
+	CheckpointInterval time.Duration
+	Log                log.Logger
+	TLSConfig          *tls.Config // Needs some rework
 	// IncludeTableRegex defines the regex which matches the allowed table
 	// names. Default state of WithIncludeTables is empty, this will include all
 	// tables.
@@ -231,9 +283,20 @@ type Options struct {
 	// includes all tables.
 	ExcludeTableRegex []string
 
+	// BinlogStartFile and BinlogStartPosition define the binlog position to
+	// start the sync from, when the PositionStore has no saved position yet.
 	BinlogStartFile     string
 	BinlogStartPosition uint64
-	BinlogSlaveId       uint64
+	// BinlogStartGTID defines the GTID set to start the sync from, when the
+	// PositionStore has no saved position yet. Setting this field enables
+	// UseGTID.
+	BinlogStartGTID string
+	BinlogSlaveId   uint64
+	// UseGTID starts the sync from a GTID set instead of the file and the
+	// position. The GTID set gets loaded from the PositionStore,
+	// BinlogStartGTID or from the master. The executed GTID set gets tracked and
+	// saved in the PositionStore.
+	UseGTID bool
 	// Flavor defines if `mariadb` or `mysql` should be used. Defaults to
 	// `mariadb`.
 	Flavor                   string
@@ -257,6 +320,9 @@ func (o *Options) loadFromConfigService() (err error) {
 	if o.Log == nil {
 		o.Log = log.BlackHole{}
 	}
+	if o.CheckpointInterval == 0 {
+		o.CheckpointInterval = time.Second
+	}
 
 	if !o.ConfigScoped.IsValid() {
 		return nil
@@ -292,6 +358,13 @@ func (o *Options) loadFromConfigService() (err error) {
 		v := o.ConfigScoped.Get(scope.Default, ConfigPathServerFlavor)
 		o.Flavor = v.UnsafeStr()
 	}
+	if o.BinlogStartGTID == "" {
+		v := o.ConfigScoped.Get(scope.Default, ConfigPathBinlogStartGTID)
+		o.BinlogStartGTID = v.UnsafeStr()
+	}
+	if o.PositionStore == nil && o.ConfigSet != nil {
+		o.PositionStore = NewPositionConfig(o.ConfigScoped, o.ConfigSet)
+	}
 
 	return nil
 }
@@ -312,11 +385,10 @@ func NewCanal(dsn string, db DBConFactory, opt *Options) (*Canal, error) {
 	}
 
 	c := &Canal{
-		opts:                      *opt,
-		configPathBackendPosition: config.MustNewPath(ConfigPathBackendPosition),
-		dsn:                       pDSN,
-		closed:                    new(int32),
-		tables:                    ddl.MustNewTables(),
+		opts:   *opt,
+		dsn:    pDSN,
+		closed: new(int32),
+		tables: ddl.MustNewTables(),
 	}
 
 	atomic.StoreInt32(c.closed, 0)
@@ -327,6 +399,9 @@ func NewCanal(dsn string, db DBConFactory, opt *Options) (*Canal, error) {
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	if err := c.tables.Options(ddl.WithConnPool(c.dbcp)); err != nil {
+		return nil, errors.WithStack(err)
+	}
 
 	initOptFn := [...]func(c *Canal) error{
 		withUpdateBinlogStart, withPrepareSyncer, withCheckBinlogRowFormat,
@@ -347,31 +422,72 @@ func NewCanal(dsn string, db DBConFactory, opt *Options) (*Canal, error) {
 	return c, nil
 }
 
-// TODO continue sync from last stored master position
+// loadPosition loads the position from the PositionStore. Returns false if
+// no store has been configured or no position has been saved.
+func (c *Canal) loadPosition(ctx context.Context) (bool, error) {
+	if c.opts.PositionStore == nil {
+		return false, nil
+	}
+	ms, err := c.opts.PositionStore.LoadPosition(ctx)
+	switch {
+	case errors.NotFound.Match(err):
+		return false, nil
+	case err != nil:
+		return false, errors.WithStack(err)
+	case c.opts.UseGTID && ms.ExecutedGTIDSet == "":
+		// Can't resume via GTID, load the GTID set from the master.
+		return false, nil
+	}
+	if c.opts.Log.IsInfo() {
+		c.opts.Log.Info("[binlogsync] Resume from saved position", log.Stringer("master_status", ms))
+	}
+	c.masterStatus = ms
+	return true, nil
+}
+
+// addPendingGTID adds the GTID of the last processed transaction to the
+// executed GTID set.
+func (c *Canal) addPendingGTID() error {
+	if c.gtidSet == nil || c.pendingGTID == "" {
+		return nil
+	}
+	gtid := c.pendingGTID
+	c.pendingGTID = ""
+	// masterSave and therefore Checkpoint read the GTID set under the same lock.
+	c.masterMu.Lock()
+	defer c.masterMu.Unlock()
+	if err := c.gtidSet.Update(gtid); err != nil {
+		return errors.NotValid.New(err, "[binlogsync] Failed to add GTID %q to the GTID set", gtid)
+	}
+	return nil
+}
 
-func (c *Canal) masterSave(fileName string, pos uint) error {
+// masterSave updates the current position and saves it to the PositionStore
+// if the CheckpointInterval has been elapsed or argument force is true.
+func (c *Canal) masterSave(ctx context.Context, pos ddl.MasterStatus, force bool) error {
 	c.masterMu.Lock()
 	defer c.masterMu.Unlock()
 
-	c.masterStatus.File = fileName
-	c.masterStatus.Position = pos
+	c.masterStatus.File = pos.File
+	c.masterStatus.Position = pos.Position
+	if c.gtidSet != nil {
+		c.masterStatus.ExecutedGTIDSet = c.gtidSet.String()
+	}
 
 	now := time.Now()
-	if now.Sub(c.masterLastSaveTime) < time.Second {
+	if !force && now.Sub(c.masterLastSaveTime) < c.opts.CheckpointInterval {
 		return nil
 	}
 
-	if c.opts.ConfigSet == nil {
+	if c.opts.PositionStore == nil {
 		if c.opts.Log.IsDebug() {
-			c.opts.Log.Debug("[binlogsync] Warning: Master Status cannot be saved because config.Setter is nil",
+			c.opts.Log.Debug("[binlogsync] Warning: Master Status cannot be saved because PositionStore is nil",
 				log.String("database", c.dsn.DBName), log.Stringer("master_status", c.masterStatus))
 		}
 		return nil
 	}
 
-	var buf bytes.Buffer
-	c.masterStatus.WriteTo(&buf)
-	if err := c.opts.ConfigSet.Set(c.configPathBackendPosition, buf.Bytes()); err != nil {
+	if err := c.opts.PositionStore.SavePosition(ctx, c.masterStatus); err != nil {
 		if c.opts.Log.IsInfo() {
 			c.opts.Log.Info("[binlogsync] Failed to store Master Status",
 				log.Time("master_last_save_time", c.masterLastSaveTime),
@@ -385,6 +501,11 @@ func (c *Canal) masterSave(fileName string, pos uint) error {
 	return nil
 }
 
+// Checkpoint saves the current synced position to the PositionStore.
+func (c *Canal) Checkpoint(ctx context.Context) error {
+	return errors.WithStack(c.masterSave(ctx, c.SyncedPosition(), true))
+}
+
 // SyncedPosition returns the current synced position as retrieved from the SQl
 // server.
 func (c *Canal) SyncedPosition() ddl.MasterStatus {
@@ -432,18 +553,23 @@ func (c *Canal) Close() error {
 
 	if c.syncer != nil {
 		c.syncer.Close()
-		c.syncer = nil
 	}
+	// Wait for the sync goroutine to stop before saving the final position,
+	// otherwise it might still update the master status and the GTID set.
+	c.wg.Wait()
+	c.syncer = nil
 
-	if err := c.opts.OnClose(c.dbcp); err != nil {
-		return errors.WithStack(err)
+	if err := c.Checkpoint(context.Background()); err != nil {
+		c.opts.Log.Info("[binlogsync] Close: Failed to save master position", log.Err(err))
 	}
 
-	if err := c.dbcp.Close(); err != nil {
-		return errors.WithStack(err)
+	if c.opts.OnClose != nil {
+		if err := c.opts.OnClose(c.dbcp); err != nil {
+			return errors.WithStack(err)
+		}
 	}
-	c.wg.Wait()
-	return nil
+
+	return errors.WithStack(c.dbcp.Close())
 }
 
 func (c *Canal) isTableAllowed(tblName string) bool {
@@ -511,7 +637,7 @@ func (c *Canal) FindTable(ctx context.Context, tableName string) (*ddl.Table, er
 	}
 
 	val, err, _ := c.tableSFG.Do(tableName, func() (interface{}, error) {
-		if err := c.tables.Options(ddl.WithCreateTable(ctx, c.dbcp.DB, tableName, "")); err != nil {
+		if err := c.tables.Options(ddl.WithCreateTable(ctx, tableName, "")); err != nil {
 			return nil, errors.WithStack(err)
 		}
 
@@ -529,9 +655,14 @@ func (c *Canal) FindTable(ctx context.Context, tableName string) (*ddl.Table, er
 	return val.(*ddl.Table), nil
 }
 
-// ClearTableCache clear table cache
+// ClearTableCache removes a table from the internal cache. The next rows event
+// of that table loads the current table structure from the database. Only
+// tables of the database in the DSN get cached.
 func (c *Canal) ClearTableCache(db string, table string) {
-	c.tables.DeleteAllFromCache()
+	if db != c.dsn.DBName {
+		return
+	}
+	c.tables.DeleteFromCache(table)
 }
 
 // CheckBinlogRowImage checks MySQL binlog row image, must be in FULL, MINIMAL, NOBLOB
@@ -0,0 +1,319 @@
+package binlogsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/sql/ddl"
+)
+
+// ChangeRecord describes the change of a single row. It gets created by the
+// CDCHandler from a rows event and encoded by a CDCEncoder.
+type ChangeRecord struct {
+	Schema string
+	Table  string
+	// Action is one of the constants UpdateAction, InsertAction or
+	// DeleteAction.
+	Action string
+	// PrimaryKey contains the primary key columns of the after image, or for
+	// deletes of the before image. Nil if the table has no primary key.
+	PrimaryKey map[string]interface{}
+	// Before contains the row before the change. Nil for inserts.
+	Before map[string]interface{}
+	// After contains the row after the change. Nil for deletes.
+	After map[string]interface{}
+	// Position contains the binlog position after the rows event. Multiple
+	// records can share the same position.
+	Position  ddl.MasterStatus
+	GTID      string
+	Timestamp time.Time
+}
+
+// Key returns the partition key of the record: the schema and table name
+// followed by the primary key values in column order.
+func (cr *ChangeRecord) Key(pkColumns ddl.Columns) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(cr.Schema)
+	buf.WriteByte('.')
+	buf.WriteString(cr.Table)
+	for i, c := range pkColumns {
+		if i == 0 {
+			buf.WriteByte('/')
+		} else {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, "%v", cr.PrimaryKey[c.Field])
+	}
+	return buf.Bytes()
+}
+
+// rowImage converts a row into a map with the column names as keys. Binary
+// values of non-binary columns get converted to strings. Values without a
+// matching column, for example after an ALTER TABLE, get the name @N.
+func rowImage(cols ddl.Columns, row []interface{}) map[string]interface{} {
+	img := make(map[string]interface{}, len(row))
+	for i, v := range row {
+		if i >= len(cols) {
+			img[fmt.Sprintf("@%d", i+1)] = v
+			continue
+		}
+		c := cols[i]
+		if b, ok := v.([]byte); ok && !isBinaryColumn(c) {
+			v = string(b)
+		}
+		img[c.Field] = v
+	}
+	return img
+}
+
+func isBinaryColumn(c *ddl.Column) bool {
+	dt := strings.ToLower(c.DataType)
+	return strings.Contains(dt, "blob") || strings.Contains(dt, "binary")
+}
+
+// NewChangeRecords converts the rows of a rows event into change records. For
+// the update action the rows must alternate between before and after image.
+func NewChangeRecords(action string, t *ddl.Table, rows [][]interface{}, ei EventInfo) ([]*ChangeRecord, error) {
+	schema := t.Schema
+	if schema == "" {
+		schema = ei.Schema
+	}
+	newRecord := func() *ChangeRecord {
+		return &ChangeRecord{
+			Schema:    schema,
+			Table:     t.Name,
+			Action:    action,
+			Position:  ei.Position,
+			GTID:      ei.GTID,
+			Timestamp: ei.Timestamp,
+		}
+	}
+
+	var recs []*ChangeRecord
+	switch action {
+	case InsertAction:
+		for _, row := range rows {
+			cr := newRecord()
+			cr.After = rowImage(t.Columns, row)
+			recs = append(recs, cr)
+		}
+	case DeleteAction:
+		for _, row := range rows {
+			cr := newRecord()
+			cr.Before = rowImage(t.Columns, row)
+			recs = append(recs, cr)
+		}
+	case UpdateAction:
+		if len(rows)%2 != 0 {
+			return nil, errors.NotValid.Newf("[binlogsync] Table %q: update rows event requires an even number of rows, got %d", t.Name, len(rows))
+		}
+		for i := 0; i < len(rows); i += 2 {
+			cr := newRecord()
+			cr.Before = rowImage(t.Columns, rows[i])
+			cr.After = rowImage(t.Columns, rows[i+1])
+			recs = append(recs, cr)
+		}
+	default:
+		return nil, errors.NotSupported.Newf("[binlogsync] Table %q: action %q not supported", t.Name, action)
+	}
+
+	pkCols := t.Columns.PrimaryKeys()
+	if len(pkCols) == 0 {
+		return recs, nil
+	}
+	for _, cr := range recs {
+		img := cr.After
+		if img == nil {
+			img = cr.Before
+		}
+		cr.PrimaryKey = make(map[string]interface{}, len(pkCols))
+		for _, c := range pkCols {
+			cr.PrimaryKey[c.Field] = img[c.Field]
+		}
+	}
+	return recs, nil
+}
+
+// CDCEncoder encodes a change record into the message payload.
+type CDCEncoder interface {
+	Encode(cr *ChangeRecord) ([]byte, error)
+}
+
+// CDCJSONEncoder encodes change records as JSON objects. Binary values get
+// encoded as base64 strings.
+type CDCJSONEncoder struct{}
+
+type cdcJSONRecord struct {
+	Schema     string                 `json:"schema"`
+	Table      string                 `json:"table"`
+	Action     string                 `json:"action"`
+	PrimaryKey map[string]interface{} `json:"primary_key,omitempty"`
+	Before     map[string]interface{} `json:"before,omitempty"`
+	After      map[string]interface{} `json:"after,omitempty"`
+	File       string                 `json:"binlog_file"`
+	Position   uint                   `json:"binlog_position"`
+	GTID       string                 `json:"gtid,omitempty"`
+	Timestamp  time.Time              `json:"timestamp"`
+}
+
+// Encode implements CDCEncoder.
+func (CDCJSONEncoder) Encode(cr *ChangeRecord) ([]byte, error) {
+	data, err := json.Marshal(cdcJSONRecord{
+		Schema:     cr.Schema,
+		Table:      cr.Table,
+		Action:     cr.Action,
+		PrimaryKey: cr.PrimaryKey,
+		Before:     cr.Before,
+		After:      cr.After,
+		File:       cr.Position.File,
+		Position:   cr.Position.Position,
+		GTID:       cr.GTID,
+		Timestamp:  cr.Timestamp.UTC(),
+	})
+	return data, errors.WithStack(err)
+}
+
+// CDCMessage gets published by a CDCPublisher.
+type CDCMessage struct {
+	// Key contains the schema, table and primary key values. Useful for
+	// partitioning, so all changes of a row end up in the same order.
+	Key []byte
+	// Value contains the encoded change record.
+	Value []byte
+	// Record contains the change record for publishers which need to inspect
+	// it. It must only be used for reading.
+	Record *ChangeRecord
+}
+
+// CDCPublisher delivers messages to a message stream.
+type CDCPublisher interface {
+	// Publish must deliver or persist all messages before it returns. An error
+	// stops the syncer, so the binlog position does not advance beyond the
+	// last published rows event.
+	Publish(ctx context.Context, msgs ...CDCMessage) error
+	// Flush gets called before a binlog rotation.
+	Flush(ctx context.Context) error
+}
+
+// CDCOptions sets optional arguments to create a new CDCHandler.
+type CDCOptions struct {
+	// Encoder defaults to CDCJSONEncoder.
+	Encoder CDCEncoder
+	// Checkpoint stores the position of the last published rows event. On
+	// creation the handler loads the checkpoint and skips all rows events up
+	// to that position, to reduce the duplicates after a restart. Optional.
+	Checkpoint PositionStore
+	// Log optional logger.
+	Log log.Logger
+}
+
+// CDCHandler implements RowsEventHandler and publishes change data capture
+// records. Delivery has at-least-once semantics: a failed publish stops the
+// canal with an Interrupted error before the binlog position gets saved, so
+// after a restart the events get published again. Consumers should
+// deduplicate by the binlog position of the record.
+type CDCHandler struct {
+	opts CDCOptions
+	pub  CDCPublisher
+
+	mu         sync.Mutex
+	checkpoint ddl.MasterStatus
+}
+
+// NewCDCHandler creates a new change data capture handler. Register it with
+// Canal.RegisterRowsEventHandler.
+func NewCDCHandler(ctx context.Context, pub CDCPublisher, opts CDCOptions) (*CDCHandler, error) {
+	if pub == nil {
+		return nil, errors.Empty.Newf("[binlogsync] NewCDCHandler requires a CDCPublisher")
+	}
+	if opts.Encoder == nil {
+		opts.Encoder = CDCJSONEncoder{}
+	}
+	if opts.Log == nil {
+		opts.Log = log.BlackHole{}
+	}
+	h := &CDCHandler{
+		opts: opts,
+		pub:  pub,
+	}
+	if opts.Checkpoint != nil {
+		ms, err := opts.Checkpoint.LoadPosition(ctx)
+		switch {
+		case err == nil:
+			h.checkpoint = ms
+		case !errors.NotFound.Match(err):
+			return nil, errors.WithStack(err)
+		}
+	}
+	return h, nil
+}
+
+// Checkpoint returns the position of the last published rows event.
+func (h *CDCHandler) Checkpoint() ddl.MasterStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.checkpoint
+}
+
+// Do implements RowsEventHandler.
+func (h *CDCHandler) Do(ctx context.Context, action string, t *ddl.Table, rows [][]interface{}) error {
+	ei, _ := EventInfoFromContext(ctx)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if ei.Position.File != "" && h.checkpoint.File != "" && ei.Position.Compare(h.checkpoint) <= 0 {
+		if h.opts.Log.IsDebug() {
+			h.opts.Log.Debug("binlogsync.CDCHandler.Do.skip_published", log.Stringer("position", ei.Position),
+				log.Stringer("checkpoint", h.checkpoint), log.String("table", t.Name))
+		}
+		return nil
+	}
+
+	recs, err := NewChangeRecords(action, t, rows, ei)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	pkCols := t.Columns.PrimaryKeys()
+	msgs := make([]CDCMessage, 0, len(recs))
+	for _, cr := range recs {
+		val, err := h.opts.Encoder.Encode(cr)
+		if err != nil {
+			return errors.Interrupted.New(err, "[binlogsync] CDCHandler failed to encode record of table %q at %s", t.Name, ei.Position)
+		}
+		msgs = append(msgs, CDCMessage{Key: cr.Key(pkCols), Value: val, Record: cr})
+	}
+	if err := h.pub.Publish(ctx, msgs...); err != nil {
+		return errors.Interrupted.New(err, "[binlogsync] CDCHandler failed to publish %d records of table %q at %s", len(msgs), t.Name, ei.Position)
+	}
+	if ei.Position.File == "" {
+		return nil
+	}
+	h.checkpoint = ei.Position
+	if h.opts.Checkpoint != nil {
+		if err := h.opts.Checkpoint.SavePosition(ctx, ei.Position); err != nil {
+			return errors.Interrupted.New(err, "[binlogsync] CDCHandler failed to save checkpoint %s", ei.Position)
+		}
+	}
+	return nil
+}
+
+// Complete implements RowsEventHandler and flushes the publisher.
+func (h *CDCHandler) Complete(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.pub.Flush(ctx); err != nil {
+		return errors.Interrupted.New(err, "[binlogsync] CDCHandler failed to flush the publisher")
+	}
+	return nil
+}
+
+// String implements RowsEventHandler.
+func (h *CDCHandler) String() string { return "binlogsync.CDCHandler" }
@@ -0,0 +1,95 @@
+package binlogsync
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/binlogsync/cdcpb"
+)
+
+// CDCProtoEncoder encodes change records as protocol buffers message
+// cdcpb.ChangeRecord as defined in the file cdcpb/cdc.proto.
+type CDCProtoEncoder struct{}
+
+// pbFields converts the row image sorted by column name into Field messages.
+func pbFields(img map[string]interface{}) []*cdcpb.Field {
+	if len(img) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(img))
+	for n := range img {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	fields := make([]*cdcpb.Field, len(names))
+	for i, n := range names {
+		fields[i] = pbField(n, img[n])
+	}
+	return fields
+}
+
+// pbField creates message Field. Exactly one of the value fields gets set.
+func pbField(name string, v interface{}) *cdcpb.Field {
+	f := &cdcpb.Field{Name: name}
+	switch v := v.(type) {
+	case nil:
+		f.Value = &cdcpb.Field_Null{Null: true}
+	case int:
+		f.Value = &cdcpb.Field_Int{Int: int64(v)}
+	case int8:
+		f.Value = &cdcpb.Field_Int{Int: int64(v)}
+	case int16:
+		f.Value = &cdcpb.Field_Int{Int: int64(v)}
+	case int32:
+		f.Value = &cdcpb.Field_Int{Int: int64(v)}
+	case int64:
+		f.Value = &cdcpb.Field_Int{Int: v}
+	case uint:
+		f.Value = &cdcpb.Field_Uint{Uint: uint64(v)}
+	case uint8:
+		f.Value = &cdcpb.Field_Uint{Uint: uint64(v)}
+	case uint16:
+		f.Value = &cdcpb.Field_Uint{Uint: uint64(v)}
+	case uint32:
+		f.Value = &cdcpb.Field_Uint{Uint: uint64(v)}
+	case uint64:
+		f.Value = &cdcpb.Field_Uint{Uint: v}
+	case float32:
+		f.Value = &cdcpb.Field_Double{Double: float64(v)}
+	case float64:
+		f.Value = &cdcpb.Field_Double{Double: v}
+	case string:
+		f.Value = &cdcpb.Field_String_{String_: v}
+	case []byte:
+		f.Value = &cdcpb.Field_Bytes{Bytes: v}
+	case bool:
+		f.Value = &cdcpb.Field_Bool{Bool: v}
+	case time.Time:
+		f.Value = &cdcpb.Field_String_{String_: v.Format(time.RFC3339Nano)}
+	default:
+		f.Value = &cdcpb.Field_String_{String_: fmt.Sprint(v)}
+	}
+	return f
+}
+
+// Encode implements CDCEncoder.
+func (CDCProtoEncoder) Encode(cr *ChangeRecord) ([]byte, error) {
+	pb := cdcpb.ChangeRecord{
+		Schema:         cr.Schema,
+		Table:          cr.Table,
+		Action:         cr.Action,
+		PrimaryKey:     pbFields(cr.PrimaryKey),
+		Before:         pbFields(cr.Before),
+		After:          pbFields(cr.After),
+		BinlogFile:     cr.Position.File,
+		BinlogPosition: uint64(cr.Position.Position),
+		GTID:           cr.GTID,
+	}
+	if !cr.Timestamp.IsZero() {
+		pb.TimestampUnixNano = cr.Timestamp.UnixNano()
+	}
+	data, err := pb.Marshal()
+	return data, errors.WithStack(err)
+}
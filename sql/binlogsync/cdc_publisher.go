@@ -0,0 +1,229 @@
+package binlogsync
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/corestoreio/errors"
+)
+
+// CDCMemoryPublisher stores all published messages in memory. Useful for
+// testing and for consumers running in the same process.
+type CDCMemoryPublisher struct {
+	mu   sync.Mutex
+	msgs []CDCMessage
+}
+
+// Publish implements CDCPublisher.
+func (mp *CDCMemoryPublisher) Publish(_ context.Context, msgs ...CDCMessage) error {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	mp.msgs = append(mp.msgs, msgs...)
+	return nil
+}
+
+// Flush implements CDCPublisher and does nothing.
+func (mp *CDCMemoryPublisher) Flush(_ context.Context) error { return nil }
+
+// Messages returns a copy of all published messages and removes them from the
+// publisher.
+func (mp *CDCMemoryPublisher) Messages() []CDCMessage {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	msgs := mp.msgs
+	mp.msgs = nil
+	return msgs
+}
+
+// DefaultCDCSegmentSize defines the default maximum size of a segment file of
+// the CDCFilePublisher.
+const DefaultCDCSegmentSize = 64 << 20
+
+const cdcSegmentExt = ".cdc"
+
+// CDCFilePublisher appends messages to segment files in a directory. Each
+// message gets written as the uvarint length of the key, the key, the uvarint
+// length of the value and the value. A new segment starts once the current one
+// exceeds the SegmentSize. The segment files get named by an ascending
+// sequence number, so consumers can process them in lexical order. Use
+// ReadCDCSegment to read a segment.
+type CDCFilePublisher struct {
+	dir         string
+	segmentSize int64
+
+	mu   sync.Mutex
+	seq  uint64
+	size int64
+	f    *os.File
+	w    *bufio.Writer
+}
+
+// NewCDCFilePublisher creates a new file segment publisher. It appends to the
+// last segment found in the directory. A segmentSize <= 0 applies
+// DefaultCDCSegmentSize.
+func NewCDCFilePublisher(dir string, segmentSize int64) (*CDCFilePublisher, error) {
+	if segmentSize <= 0 {
+		segmentSize = DefaultCDCSegmentSize
+	}
+	fp := &CDCFilePublisher{
+		dir:         dir,
+		segmentSize: segmentSize,
+	}
+	segs, err := CDCSegments(dir)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if l := len(segs); l > 0 {
+		if _, err := fmt.Sscanf(filepath.Base(segs[l-1]), "%020d"+cdcSegmentExt, &fp.seq); err != nil {
+			return nil, errors.NotValid.New(err, "[binlogsync] Invalid CDC segment file name %q", segs[l-1])
+		}
+	}
+	if err := fp.openSegment(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return fp, nil
+}
+
+// CDCSegments returns the sorted file names of all segment files in a
+// directory.
+func CDCSegments(dir string) ([]string, error) {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var segs []string
+	for _, fi := range fis {
+		if !fi.IsDir() && strings.HasSuffix(fi.Name(), cdcSegmentExt) {
+			segs = append(segs, filepath.Join(dir, fi.Name()))
+		}
+	}
+	sort.Strings(segs)
+	return segs, nil
+}
+
+func (fp *CDCFilePublisher) openSegment() error {
+	fn := filepath.Join(fp.dir, fmt.Sprintf("%020d"+cdcSegmentExt, fp.seq))
+	f, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return errors.WithStack(err)
+	}
+	fp.f = f
+	fp.w = bufio.NewWriter(f)
+	fp.size = fi.Size()
+	return nil
+}
+
+func (fp *CDCFilePublisher) sync() error {
+	if err := fp.w.Flush(); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(fp.f.Sync())
+}
+
+func (fp *CDCFilePublisher) rotate() error {
+	if err := fp.sync(); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := fp.f.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	fp.seq++
+	return errors.WithStack(fp.openSegment())
+}
+
+// Publish implements CDCPublisher. The messages are synced to disk before
+// Publish returns.
+func (fp *CDCFilePublisher) Publish(_ context.Context, msgs ...CDCMessage) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	if fp.f == nil {
+		return errors.AlreadyClosed.Newf("[binlogsync] CDCFilePublisher already closed")
+	}
+
+	var buf [binary.MaxVarintLen64]byte
+	for _, msg := range msgs {
+		if fp.size >= fp.segmentSize {
+			if err := fp.rotate(); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		for _, data := range [2][]byte{msg.Key, msg.Value} {
+			n := binary.PutUvarint(buf[:], uint64(len(data)))
+			if _, err := fp.w.Write(buf[:n]); err != nil {
+				return errors.WithStack(err)
+			}
+			if _, err := fp.w.Write(data); err != nil {
+				return errors.WithStack(err)
+			}
+			fp.size += int64(n + len(data))
+		}
+	}
+	return errors.WithStack(fp.sync())
+}
+
+// Flush implements CDCPublisher.
+func (fp *CDCFilePublisher) Flush(_ context.Context) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	if fp.f == nil {
+		return nil
+	}
+	return errors.WithStack(fp.sync())
+}
+
+// Close syncs and closes the current segment file.
+func (fp *CDCFilePublisher) Close() error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	if fp.f == nil {
+		return nil
+	}
+	err := fp.sync()
+	if cErr := fp.f.Close(); err == nil {
+		err = cErr
+	}
+	fp.f = nil
+	return errors.WithStack(err)
+}
+
+// ReadCDCSegment reads all messages of a segment and calls fn for each
+// message. The slices passed to fn are only valid during the call.
+func ReadCDCSegment(r io.Reader, fn func(key, value []byte) error) error {
+	br := bufio.NewReader(r)
+	var kv [2][]byte
+	for {
+		for i := range kv {
+			l, err := binary.ReadUvarint(br)
+			if err == io.EOF && i == 0 {
+				return nil
+			}
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if uint64(cap(kv[i])) < l {
+				kv[i] = make([]byte, l)
+			}
+			kv[i] = kv[i][:l]
+			if _, err := io.ReadFull(br, kv[i]); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		if err := fn(kv[0], kv[1]); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+}
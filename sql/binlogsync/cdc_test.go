@@ -0,0 +1,237 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/binlogsync/cdcpb"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+var (
+	_ RowsEventHandler = (*CDCHandler)(nil)
+	_ CDCPublisher     = (*CDCMemoryPublisher)(nil)
+	_ CDCPublisher     = (*CDCFilePublisher)(nil)
+	_ CDCEncoder       = CDCJSONEncoder{}
+	_ CDCEncoder       = CDCProtoEncoder{}
+)
+
+func newCDCTestTable() *ddl.Table {
+	return ddl.NewTable("sales_order",
+		&ddl.Column{Field: "entity_id", Pos: 1, DataType: "int", Key: "PRI"},
+		&ddl.Column{Field: "status", Pos: 2, DataType: "varchar"},
+		&ddl.Column{Field: "payload", Pos: 3, DataType: "blob"},
+	)
+}
+
+func newCDCTestEventInfo(pos uint) EventInfo {
+	return EventInfo{
+		Schema:    "shop",
+		Position:  ddl.MasterStatus{File: "mysql-bin.000001", Position: pos},
+		GTID:      "0-1-33",
+		Timestamp: time.Unix(1577836800, 0),
+	}
+}
+
+func TestNewChangeRecords(t *testing.T) {
+	t.Parallel()
+
+	tbl := newCDCTestTable()
+	ei := newCDCTestEventInfo(400)
+
+	t.Run("update", func(t *testing.T) {
+		recs, err := NewChangeRecords(UpdateAction, tbl, [][]interface{}{
+			{int32(1), []byte("pending"), []byte{0x01}},
+			{int32(1), []byte("complete"), []byte{0x02}, "extra"},
+		}, ei)
+		assert.NoError(t, err)
+		assert.Exactly(t, []*ChangeRecord{{
+			Schema:     "shop",
+			Table:      "sales_order",
+			Action:     UpdateAction,
+			PrimaryKey: map[string]interface{}{"entity_id": int32(1)},
+			Before:     map[string]interface{}{"entity_id": int32(1), "status": "pending", "payload": []byte{0x01}},
+			After:      map[string]interface{}{"entity_id": int32(1), "status": "complete", "payload": []byte{0x02}, "@4": "extra"},
+			Position:   ei.Position,
+			GTID:       "0-1-33",
+			Timestamp:  ei.Timestamp,
+		}}, recs)
+		assert.Exactly(t, "shop.sales_order/1", string(recs[0].Key(tbl.Columns.PrimaryKeys())))
+	})
+
+	t.Run("delete uses before image as key", func(t *testing.T) {
+		recs, err := NewChangeRecords(DeleteAction, tbl, [][]interface{}{{int32(2), "x", nil}, {int32(3), "y", nil}}, ei)
+		assert.NoError(t, err)
+		assert.Len(t, recs, 2)
+		assert.Nil(t, recs[1].After)
+		assert.Exactly(t, map[string]interface{}{"entity_id": int32(3)}, recs[1].PrimaryKey)
+	})
+
+	t.Run("odd update rows", func(t *testing.T) {
+		recs, err := NewChangeRecords(UpdateAction, tbl, [][]interface{}{{int32(1)}}, ei)
+		assert.Nil(t, recs)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+}
+
+func TestCDCJSONEncoder(t *testing.T) {
+	t.Parallel()
+
+	recs, err := NewChangeRecords(InsertAction, newCDCTestTable(), [][]interface{}{{int32(5), []byte("new"), []byte("\x00")}}, newCDCTestEventInfo(500))
+	assert.NoError(t, err)
+	data, err := CDCJSONEncoder{}.Encode(recs[0])
+	assert.NoError(t, err)
+	assert.Exactly(t,
+		`{"schema":"shop","table":"sales_order","action":"insert","primary_key":{"entity_id":5},"after":{"entity_id":5,"payload":"AA==","status":"new"},"binlog_file":"mysql-bin.000001","binlog_position":500,"gtid":"0-1-33","timestamp":"2020-01-01T00:00:00Z"}`,
+		string(data))
+}
+
+func TestCDCProtoEncoder(t *testing.T) {
+	t.Parallel()
+
+	data, err := CDCProtoEncoder{}.Encode(&ChangeRecord{
+		Table:      "t",
+		Action:     DeleteAction,
+		PrimaryKey: map[string]interface{}{"id": int64(-1)},
+		Before:     map[string]interface{}{"a": nil, "b": 1.5},
+		Position:   ddl.MasterStatus{File: "f", Position: 300},
+	})
+	assert.NoError(t, err)
+	assert.Exactly(t, []byte{
+		0x12, 1, 't', // table
+		0x1a, 6, 'd', 'e', 'l', 'e', 't', 'e', // action
+		0x22, 15, 0x0a, 2, 'i', 'd', 0x18, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, // primary_key id=-1
+		0x2a, 5, 0x0a, 1, 'a', 0x10, 1, // before a=NULL
+		0x2a, 12, 0x0a, 1, 'b', 0x29, 0, 0, 0, 0, 0, 0, 0xf8, 0x3f, // before b=1.5
+		0x3a, 1, 'f', // binlog_file
+		0x40, 0xac, 0x02, // binlog_position
+	}, data)
+
+	var pb cdcpb.ChangeRecord
+	assert.NoError(t, pb.Unmarshal(data))
+	assert.Exactly(t, DeleteAction, pb.Action)
+	assert.Exactly(t, []*cdcpb.Field{
+		{Name: "a", Value: &cdcpb.Field_Null{Null: true}},
+		{Name: "b", Value: &cdcpb.Field_Double{Double: 1.5}},
+	}, pb.Before)
+	assert.Exactly(t, uint64(300), pb.BinlogPosition)
+}
+
+type testCDCPublisher struct {
+	CDCMemoryPublisher
+	err error
+}
+
+func (p *testCDCPublisher) Publish(ctx context.Context, msgs ...CDCMessage) error {
+	if p.err != nil {
+		return p.err
+	}
+	return p.CDCMemoryPublisher.Publish(ctx, msgs...)
+}
+
+func TestCDCHandler(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "binlogsync_cdc")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	tbl := newCDCTestTable()
+	rows := [][]interface{}{{int32(1), "a", nil}}
+	cp := NewPositionFile(filepath.Join(dir, "checkpoint.txt"))
+
+	pub := &testCDCPublisher{}
+	h, err := NewCDCHandler(ctx, pub, CDCOptions{Checkpoint: cp})
+	assert.NoError(t, err)
+
+	assert.NoError(t, h.Do(withEventInfo(ctx, newCDCTestEventInfo(200)), InsertAction, tbl, rows))
+	assert.NoError(t, h.Do(withEventInfo(ctx, newCDCTestEventInfo(300)), DeleteAction, tbl, rows))
+	msgs := pub.Messages()
+	assert.Len(t, msgs, 2)
+	assert.Exactly(t, "shop.sales_order/1", string(msgs[0].Key))
+	assert.Exactly(t, DeleteAction, msgs[1].Record.Action)
+	assert.Exactly(t, newCDCTestEventInfo(300).Position, h.Checkpoint())
+
+	pub.err = errors.ConnectionFailed.Newf("broker down")
+	err = h.Do(withEventInfo(ctx, newCDCTestEventInfo(400)), InsertAction, tbl, rows)
+	assert.True(t, errors.Interrupted.Match(err), "%+v", err)
+	assert.Exactly(t, newCDCTestEventInfo(300).Position, h.Checkpoint())
+
+	// restart resumes from an older canal position and skips the already
+	// published events.
+	pub.err = nil
+	h, err = NewCDCHandler(ctx, pub, CDCOptions{Checkpoint: cp, Encoder: CDCProtoEncoder{}})
+	assert.NoError(t, err)
+	assert.NoError(t, h.Do(withEventInfo(ctx, newCDCTestEventInfo(200)), InsertAction, tbl, rows))
+	assert.NoError(t, h.Do(withEventInfo(ctx, newCDCTestEventInfo(300)), DeleteAction, tbl, rows))
+	assert.NoError(t, h.Do(withEventInfo(ctx, newCDCTestEventInfo(400)), InsertAction, tbl, rows))
+	assert.NoError(t, h.Complete(ctx))
+	msgs = pub.Messages()
+	assert.Len(t, msgs, 1)
+	assert.Exactly(t, uint(400), msgs[0].Record.Position.Position)
+}
+
+func TestCDCFilePublisher(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "binlogsync_cdc")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	fp, err := NewCDCFilePublisher(dir, 10)
+	assert.NoError(t, err)
+	assert.NoError(t, fp.Publish(ctx, CDCMessage{Key: []byte("k1"), Value: []byte("value1")}))
+	assert.NoError(t, fp.Publish(ctx, CDCMessage{Key: []byte("k2"), Value: []byte("value2")}, CDCMessage{Key: []byte("k3")}))
+	assert.NoError(t, fp.Close())
+	err = fp.Publish(ctx, CDCMessage{})
+	assert.True(t, errors.AlreadyClosed.Match(err), "%+v", err)
+
+	// reopening appends to the last segment
+	fp, err = NewCDCFilePublisher(dir, 10)
+	assert.NoError(t, err)
+	assert.NoError(t, fp.Publish(ctx, CDCMessage{Key: []byte("k4"), Value: []byte("v4")}))
+	assert.NoError(t, fp.Flush(ctx))
+	assert.NoError(t, fp.Close())
+
+	segs, err := CDCSegments(dir)
+	assert.NoError(t, err)
+	assert.Exactly(t, []string{
+		filepath.Join(dir, "00000000000000000000.cdc"),
+		filepath.Join(dir, "00000000000000000001.cdc"),
+		filepath.Join(dir, "00000000000000000002.cdc"),
+	}, segs)
+
+	var got []string
+	for _, seg := range segs {
+		f, err := os.Open(seg)
+		assert.NoError(t, err)
+		assert.NoError(t, ReadCDCSegment(f, func(key, value []byte) error {
+			got = append(got, string(key)+"="+string(value))
+			return nil
+		}))
+		assert.NoError(t, f.Close())
+	}
+	assert.Exactly(t, []string{"k1=value1", "k2=value2", "k3=", "k4=v4"}, got)
+}
@@ -0,0 +1,1343 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cdc.proto
+
+package cdcpb
+
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type ChangeRecord struct {
+	Schema string `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	Table  string `protobuf:"bytes,2,opt,name=table,proto3" json:"table,omitempty"`
+	// action: insert, update or delete
+	Action            string   `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	PrimaryKey        []*Field `protobuf:"bytes,4,rep,name=primary_key,json=primaryKey,proto3" json:"primary_key,omitempty"`
+	Before            []*Field `protobuf:"bytes,5,rep,name=before,proto3" json:"before,omitempty"`
+	After             []*Field `protobuf:"bytes,6,rep,name=after,proto3" json:"after,omitempty"`
+	BinlogFile        string   `protobuf:"bytes,7,opt,name=binlog_file,json=binlogFile,proto3" json:"binlog_file,omitempty"`
+	BinlogPosition    uint64   `protobuf:"varint,8,opt,name=binlog_position,json=binlogPosition,proto3" json:"binlog_position,omitempty"`
+	GTID              string   `protobuf:"bytes,9,opt,name=gtid,proto3" json:"gtid,omitempty"`
+	TimestampUnixNano int64    `protobuf:"varint,10,opt,name=timestamp_unix_nano,json=timestampUnixNano,proto3" json:"timestamp_unix_nano,omitempty"`
+}
+
+func (m *ChangeRecord) Reset()         { *m = ChangeRecord{} }
+func (m *ChangeRecord) String() string { return proto.CompactTextString(m) }
+func (*ChangeRecord) ProtoMessage()    {}
+func (*ChangeRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f0d2e9f7929c73d8, []int{0}
+}
+func (m *ChangeRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChangeRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChangeRecord.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChangeRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangeRecord.Merge(m, src)
+}
+func (m *ChangeRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChangeRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangeRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangeRecord proto.InternalMessageInfo
+
+// Field contains a column name and its value. Fields are sorted by name.
+type Field struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are valid to be assigned to Value:
+	//	*Field_Null
+	//	*Field_Int
+	//	*Field_Uint
+	//	*Field_Double
+	//	*Field_String_
+	//	*Field_Bytes
+	//	*Field_Bool
+	Value isField_Value `protobuf_oneof:"value"`
+}
+
+func (m *Field) Reset()         { *m = Field{} }
+func (m *Field) String() string { return proto.CompactTextString(m) }
+func (*Field) ProtoMessage()    {}
+func (*Field) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f0d2e9f7929c73d8, []int{1}
+}
+func (m *Field) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Field) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Field.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Field) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Field.Merge(m, src)
+}
+func (m *Field) XXX_Size() int {
+	return m.Size()
+}
+func (m *Field) XXX_DiscardUnknown() {
+	xxx_messageInfo_Field.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Field proto.InternalMessageInfo
+
+type isField_Value interface {
+	isField_Value()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type Field_Null struct {
+	Null bool `protobuf:"varint,2,opt,name=null,proto3,oneof" json:"null,omitempty"`
+}
+type Field_Int struct {
+	Int int64 `protobuf:"varint,3,opt,name=int,proto3,oneof" json:"int,omitempty"`
+}
+type Field_Uint struct {
+	Uint uint64 `protobuf:"varint,4,opt,name=uint,proto3,oneof" json:"uint,omitempty"`
+}
+type Field_Double struct {
+	Double float64 `protobuf:"fixed64,5,opt,name=double,proto3,oneof" json:"double,omitempty"`
+}
+type Field_String_ struct {
+	String_ string `protobuf:"bytes,6,opt,name=string,proto3,oneof" json:"string,omitempty"`
+}
+type Field_Bytes struct {
+	Bytes []byte `protobuf:"bytes,7,opt,name=bytes,proto3,oneof" json:"bytes,omitempty"`
+}
+type Field_Bool struct {
+	Bool bool `protobuf:"varint,8,opt,name=bool,proto3,oneof" json:"bool,omitempty"`
+}
+
+func (*Field_Null) isField_Value()    {}
+func (*Field_Int) isField_Value()     {}
+func (*Field_Uint) isField_Value()    {}
+func (*Field_Double) isField_Value()  {}
+func (*Field_String_) isField_Value() {}
+func (*Field_Bytes) isField_Value()   {}
+func (*Field_Bool) isField_Value()    {}
+
+func (m *Field) GetValue() isField_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Field) GetNull() bool {
+	if x, ok := m.GetValue().(*Field_Null); ok {
+		return x.Null
+	}
+	return false
+}
+
+func (m *Field) GetInt() int64 {
+	if x, ok := m.GetValue().(*Field_Int); ok {
+		return x.Int
+	}
+	return 0
+}
+
+func (m *Field) GetUint() uint64 {
+	if x, ok := m.GetValue().(*Field_Uint); ok {
+		return x.Uint
+	}
+	return 0
+}
+
+func (m *Field) GetDouble() float64 {
+	if x, ok := m.GetValue().(*Field_Double); ok {
+		return x.Double
+	}
+	return 0
+}
+
+func (m *Field) GetString_() string {
+	if x, ok := m.GetValue().(*Field_String_); ok {
+		return x.String_
+	}
+	return ""
+}
+
+func (m *Field) GetBytes() []byte {
+	if x, ok := m.GetValue().(*Field_Bytes); ok {
+		return x.Bytes
+	}
+	return nil
+}
+
+func (m *Field) GetBool() bool {
+	if x, ok := m.GetValue().(*Field_Bool); ok {
+		return x.Bool
+	}
+	return false
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Field) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Field_Null)(nil),
+		(*Field_Int)(nil),
+		(*Field_Uint)(nil),
+		(*Field_Double)(nil),
+		(*Field_String_)(nil),
+		(*Field_Bytes)(nil),
+		(*Field_Bool)(nil),
+	}
+}
+
+func init() {
+	proto.RegisterType((*ChangeRecord)(nil), "binlogsync.ChangeRecord")
+	proto.RegisterType((*Field)(nil), "binlogsync.Field")
+}
+
+func init() { proto.RegisterFile("cdc.proto", fileDescriptor_f0d2e9f7929c73d8) }
+
+var fileDescriptor_f0d2e9f7929c73d8 = []byte{
+	// 455 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0x4f, 0x6e, 0xd4, 0x30,
+	0x14, 0x87, 0xe3, 0xe6, 0x4f, 0x67, 0xde, 0x54, 0xa0, 0x9a, 0xaa, 0xb2, 0x10, 0x4a, 0xa3, 0xb2,
+	0x20, 0x2c, 0x48, 0xa5, 0x72, 0x83, 0x01, 0x95, 0x41, 0x48, 0x08, 0x59, 0xb0, 0x61, 0x33, 0x72,
+	0x12, 0x4f, 0xc6, 0x22, 0xb1, 0xa3, 0xc4, 0x41, 0xcd, 0x2d, 0x38, 0x15, 0xea, 0xb2, 0x4b, 0x56,
+	0x08, 0x66, 0xae, 0xc0, 0x01, 0x90, 0xed, 0x40, 0x57, 0xdd, 0xf9, 0x7b, 0xef, 0x8b, 0xdf, 0xcb,
+	0x2f, 0x81, 0x79, 0x51, 0x16, 0x59, 0xdb, 0x29, 0xad, 0x30, 0xe4, 0x42, 0xd6, 0xaa, 0xea, 0x47,
+	0x59, 0x3c, 0x7e, 0x51, 0x09, 0xbd, 0x1d, 0xf2, 0xac, 0x50, 0xcd, 0x45, 0xa5, 0x2a, 0x75, 0x61,
+	0x95, 0x7c, 0xd8, 0x58, 0xb2, 0x60, 0x4f, 0xee, 0xd1, 0xf3, 0x3f, 0x07, 0x70, 0xf4, 0x6a, 0xcb,
+	0x64, 0xc5, 0x29, 0x2f, 0x54, 0x57, 0xe2, 0x53, 0x88, 0xfa, 0x62, 0xcb, 0x1b, 0x46, 0x50, 0x82,
+	0xd2, 0x39, 0x9d, 0x08, 0x9f, 0x40, 0xa8, 0x59, 0x5e, 0x73, 0x72, 0x60, 0xcb, 0x0e, 0x8c, 0xcd,
+	0x0a, 0x2d, 0x94, 0x24, 0xbe, 0xb3, 0x1d, 0xe1, 0x4b, 0x58, 0xb4, 0x9d, 0x68, 0x58, 0x37, 0xae,
+	0xbf, 0xf0, 0x91, 0x04, 0x89, 0x9f, 0x2e, 0x2e, 0x8f, 0xb3, 0xbb, 0x3d, 0xb3, 0x2b, 0xc1, 0xeb,
+	0x92, 0xc2, 0x64, 0xbd, 0xe3, 0x23, 0x7e, 0x0e, 0x51, 0xce, 0x37, 0xaa, 0xe3, 0x24, 0xbc, 0x4f,
+	0x9f, 0x04, 0xfc, 0x0c, 0x42, 0xb6, 0xd1, 0xbc, 0x23, 0xd1, 0x7d, 0xa6, 0xeb, 0xe3, 0x33, 0x58,
+	0xb8, 0xd6, 0x7a, 0x23, 0x6a, 0x4e, 0x0e, 0xed, 0x92, 0x53, 0x5c, 0x57, 0xa2, 0x36, 0x37, 0x3d,
+	0x9c, 0x84, 0x56, 0xf5, 0xc2, 0xbe, 0xc9, 0x2c, 0x41, 0x69, 0x40, 0x1f, 0xb8, 0xf2, 0x87, 0xa9,
+	0x8a, 0x9f, 0x40, 0x50, 0x69, 0x51, 0x92, 0xb9, 0xb9, 0x62, 0x39, 0xdb, 0xfd, 0x3c, 0x0b, 0xde,
+	0x7c, 0x7c, 0xfb, 0x9a, 0xda, 0x2a, 0xce, 0xe0, 0x91, 0x16, 0x0d, 0xef, 0x35, 0x6b, 0xda, 0xf5,
+	0x20, 0xc5, 0xf5, 0x5a, 0x32, 0xa9, 0x08, 0x24, 0x28, 0xf5, 0xe9, 0xf1, 0xff, 0xd6, 0x27, 0x29,
+	0xae, 0xdf, 0x33, 0xa9, 0xce, 0xbf, 0x23, 0x08, 0xed, 0xa2, 0x18, 0x43, 0x20, 0x59, 0xc3, 0xa7,
+	0xb4, 0xed, 0x19, 0x9f, 0x40, 0x20, 0x87, 0xba, 0xb6, 0x51, 0xcf, 0x56, 0x1e, 0xb5, 0x84, 0x31,
+	0xf8, 0x42, 0x6a, 0x1b, 0xb4, 0xbf, 0xf2, 0xa8, 0x01, 0x63, 0x0e, 0xa6, 0x18, 0x98, 0x9d, 0x8d,
+	0x69, 0x08, 0x13, 0x88, 0x4a, 0x35, 0x98, 0x8f, 0x15, 0x26, 0x28, 0x45, 0x2b, 0x8f, 0x4e, 0x6c,
+	0x3a, 0xbd, 0xee, 0x84, 0xac, 0x48, 0x64, 0xe6, 0x99, 0x8e, 0x63, 0x7c, 0x0a, 0x61, 0x3e, 0x6a,
+	0xde, 0xdb, 0x8c, 0x8e, 0x56, 0x1e, 0x75, 0x68, 0x26, 0xe4, 0x4a, 0xd5, 0x64, 0xf6, 0x6f, 0x17,
+	0x43, 0xcb, 0x43, 0x08, 0xbf, 0xb2, 0x7a, 0xe0, 0xcb, 0xa7, 0x37, 0xbf, 0x63, 0xef, 0x66, 0x17,
+	0xa3, 0xdb, 0x5d, 0x8c, 0x7e, 0xed, 0x62, 0xf4, 0x6d, 0x1f, 0x7b, 0xb7, 0xfb, 0xd8, 0xfb, 0xb1,
+	0x8f, 0xbd, 0xcf, 0x61, 0x51, 0x16, 0x6d, 0x9e, 0x47, 0xf6, 0x5f, 0x7b, 0xf9, 0x77, 0x00, 0x97,
+	0xbb, 0x74, 0xe3, 0xb3, 0x02, 0x00, 0x00,
+}
+
+func (m *ChangeRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChangeRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChangeRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TimestampUnixNano != 0 {
+		i = encodeVarintCdc(dAtA, i, uint64(m.TimestampUnixNano))
+		i--
+		dAtA[i] = 0x50
+	}
+	if len(m.GTID) > 0 {
+		i -= len(m.GTID)
+		copy(dAtA[i:], m.GTID)
+		i = encodeVarintCdc(dAtA, i, uint64(len(m.GTID)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.BinlogPosition != 0 {
+		i = encodeVarintCdc(dAtA, i, uint64(m.BinlogPosition))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.BinlogFile) > 0 {
+		i -= len(m.BinlogFile)
+		copy(dAtA[i:], m.BinlogFile)
+		i = encodeVarintCdc(dAtA, i, uint64(len(m.BinlogFile)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.After) > 0 {
+		for iNdEx := len(m.After) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.After[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCdc(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Before) > 0 {
+		for iNdEx := len(m.Before) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Before[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCdc(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.PrimaryKey) > 0 {
+		for iNdEx := len(m.PrimaryKey) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PrimaryKey[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCdc(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Action) > 0 {
+		i -= len(m.Action)
+		copy(dAtA[i:], m.Action)
+		i = encodeVarintCdc(dAtA, i, uint64(len(m.Action)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Table) > 0 {
+		i -= len(m.Table)
+		copy(dAtA[i:], m.Table)
+		i = encodeVarintCdc(dAtA, i, uint64(len(m.Table)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Schema) > 0 {
+		i -= len(m.Schema)
+		copy(dAtA[i:], m.Schema)
+		i = encodeVarintCdc(dAtA, i, uint64(len(m.Schema)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Field) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Field) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Field) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		{
+			size := m.Value.Size()
+			i -= size
+			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintCdc(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Field_Null) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Field_Null) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i--
+	if m.Null {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i--
+	dAtA[i] = 0x10
+	return len(dAtA) - i, nil
+}
+func (m *Field_Int) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Field_Int) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintCdc(dAtA, i, uint64(m.Int))
+	i--
+	dAtA[i] = 0x18
+	return len(dAtA) - i, nil
+}
+func (m *Field_Uint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Field_Uint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintCdc(dAtA, i, uint64(m.Uint))
+	i--
+	dAtA[i] = 0x20
+	return len(dAtA) - i, nil
+}
+func (m *Field_Double) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Field_Double) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= 8
+	encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Double))))
+	i--
+	dAtA[i] = 0x29
+	return len(dAtA) - i, nil
+}
+func (m *Field_String_) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Field_String_) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= len(m.String_)
+	copy(dAtA[i:], m.String_)
+	i = encodeVarintCdc(dAtA, i, uint64(len(m.String_)))
+	i--
+	dAtA[i] = 0x32
+	return len(dAtA) - i, nil
+}
+func (m *Field_Bytes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Field_Bytes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Bytes != nil {
+		i -= len(m.Bytes)
+		copy(dAtA[i:], m.Bytes)
+		i = encodeVarintCdc(dAtA, i, uint64(len(m.Bytes)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Field_Bool) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Field_Bool) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i--
+	if m.Bool {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i--
+	dAtA[i] = 0x40
+	return len(dAtA) - i, nil
+}
+func encodeVarintCdc(dAtA []byte, offset int, v uint64) int {
+	offset -= sovCdc(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ChangeRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Schema)
+	if l > 0 {
+		n += 1 + l + sovCdc(uint64(l))
+	}
+	l = len(m.Table)
+	if l > 0 {
+		n += 1 + l + sovCdc(uint64(l))
+	}
+	l = len(m.Action)
+	if l > 0 {
+		n += 1 + l + sovCdc(uint64(l))
+	}
+	if len(m.PrimaryKey) > 0 {
+		for _, e := range m.PrimaryKey {
+			l = e.Size()
+			n += 1 + l + sovCdc(uint64(l))
+		}
+	}
+	if len(m.Before) > 0 {
+		for _, e := range m.Before {
+			l = e.Size()
+			n += 1 + l + sovCdc(uint64(l))
+		}
+	}
+	if len(m.After) > 0 {
+		for _, e := range m.After {
+			l = e.Size()
+			n += 1 + l + sovCdc(uint64(l))
+		}
+	}
+	l = len(m.BinlogFile)
+	if l > 0 {
+		n += 1 + l + sovCdc(uint64(l))
+	}
+	if m.BinlogPosition != 0 {
+		n += 1 + sovCdc(uint64(m.BinlogPosition))
+	}
+	l = len(m.GTID)
+	if l > 0 {
+		n += 1 + l + sovCdc(uint64(l))
+	}
+	if m.TimestampUnixNano != 0 {
+		n += 1 + sovCdc(uint64(m.TimestampUnixNano))
+	}
+	return n
+}
+
+func (m *Field) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovCdc(uint64(l))
+	}
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	return n
+}
+
+func (m *Field_Null) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 2
+	return n
+}
+func (m *Field_Int) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovCdc(uint64(m.Int))
+	return n
+}
+func (m *Field_Uint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovCdc(uint64(m.Uint))
+	return n
+}
+func (m *Field_Double) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 9
+	return n
+}
+func (m *Field_String_) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.String_)
+	n += 1 + l + sovCdc(uint64(l))
+	return n
+}
+func (m *Field_Bytes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Bytes != nil {
+		l = len(m.Bytes)
+		n += 1 + l + sovCdc(uint64(l))
+	}
+	return n
+}
+func (m *Field_Bool) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 2
+	return n
+}
+
+func sovCdc(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozCdc(x uint64) (n int) {
+	return sovCdc(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ChangeRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCdc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChangeRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChangeRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schema", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCdc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Schema = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Table", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCdc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Table = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCdc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Action = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrimaryKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCdc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrimaryKey = append(m.PrimaryKey, &Field{})
+			if err := m.PrimaryKey[len(m.PrimaryKey)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCdc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Before = append(m.Before, &Field{})
+			if err := m.Before[len(m.Before)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCdc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.After = append(m.After, &Field{})
+			if err := m.After[len(m.After)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BinlogFile", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCdc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BinlogFile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BinlogPosition", wireType)
+			}
+			m.BinlogPosition = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BinlogPosition |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GTID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCdc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GTID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimestampUnixNano", wireType)
+			}
+			m.TimestampUnixNano = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimestampUnixNano |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCdc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Field) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCdc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Field: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Field: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCdc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Null", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Value = &Field_Null{b}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Int", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Value = &Field_Int{v}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uint", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Value = &Field_Uint{v}
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Double", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Value = &Field_Double{float64(math.Float64frombits(v))}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field String_", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCdc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = &Field_String_{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCdc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := make([]byte, postIndex-iNdEx)
+			copy(v, dAtA[iNdEx:postIndex])
+			m.Value = &Field_Bytes{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bool", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Value = &Field_Bool{b}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCdc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCdc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipCdc(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowCdc
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCdc
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthCdc
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupCdc
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthCdc
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthCdc        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowCdc          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupCdc = fmt.Errorf("proto: unexpected end of group")
+)
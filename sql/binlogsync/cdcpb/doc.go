@@ -0,0 +1,20 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cdcpb contains the protocol buffer messages written by
+// binlogsync.CDCProtoEncoder. Consumers can use the file cdc.proto to decode
+// the change records in other languages.
+package cdcpb
+
+//go:generate protoc --gogo_out=. --proto_path=$GOPATH/src/:$GOPATH/src/github.com/gogo/protobuf/protobuf/:. cdc.proto
@@ -0,0 +1,386 @@
+package binlogsync
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"golang.org/x/sync/errgroup"
+)
+
+// DDL constants to figure out the type of a DDLEvent.
+const (
+	DDLCreateTable   = "create_table"
+	DDLAlterTable    = "alter_table"
+	DDLRenameTable   = "rename_table"
+	DDLDropTable     = "drop_table"
+	DDLTruncateTable = "truncate_table"
+)
+
+// DDLHandler gets called when a DDL statement in the binary log changes at
+// least one allowed table of the synced database. Before calling the handler,
+// the canal removes the affected tables from its internal cache, so the next
+// rows event loads the new table structure.
+type DDLHandler interface {
+	// OnDDL gets called once for each DDL statement. If it returns an error
+	// behaviour of "Interrupted", the canal type will stop the syncer. The
+	// OnDDL function will run in its own Goroutine.
+	OnDDL(ctx context.Context, e *DDLEvent) error
+	// OnTableChanged gets called after OnDDL for each affected table. A
+	// renamed table reports the old and the new name. Same error rules apply
+	// here like for function OnDDL().
+	OnTableChanged(ctx context.Context, schema, table string) error
+	// String returns the name of the handler
+	String() string
+}
+
+// DDLTable names a table affected by a DDL statement.
+type DDLTable struct {
+	Schema string
+	Table  string
+	// NewSchema and NewTable contain the new name if the table gets renamed,
+	// either via RENAME TABLE or ALTER TABLE ... RENAME TO.
+	NewSchema string
+	NewTable  string
+}
+
+// DDLEvent describes a DDL statement found in a QueryEvent.
+type DDLEvent struct {
+	// Action is one of the DDL* constants.
+	Action string
+	// Tables contains only the tables of the synced database which are
+	// allowed by the include and exclude regexes.
+	Tables []DDLTable
+	Query  string
+	// Position contains the binlog position after the DDL statement.
+	Position  ddl.MasterStatus
+	Timestamp time.Time
+}
+
+// RegisterDDLHandler adds a new DDL handler to the internal list.
+func (c *Canal) RegisterDDLHandler(h ...DDLHandler) {
+	c.rsMu.Lock()
+	defer c.rsMu.Unlock()
+	c.ddlHandlers = append(c.ddlHandlers, h...)
+}
+
+func (c *Canal) isSyncedTable(schema, table string) bool {
+	return table != "" && schema == c.dsn.DBName && c.isTableAllowed(table)
+}
+
+// handleDDL parses a query and, if it is a DDL statement, clears the affected
+// tables from the cache and calls the DDL handlers.
+func (c *Canal) handleDDL(ctx context.Context, schema, query []byte, pos ddl.MasterStatus, ts time.Time) error {
+	action, tables := parseDDLQuery(schema, query)
+	if action == "" {
+		return nil
+	}
+
+	de := &DDLEvent{
+		Action:    action,
+		Query:     string(query),
+		Position:  pos,
+		Timestamp: ts,
+	}
+	for _, t := range tables {
+		c.ClearTableCache(t.Schema, t.Table)
+		if t.NewTable != "" {
+			c.ClearTableCache(t.NewSchema, t.NewTable)
+		}
+		if c.isSyncedTable(t.Schema, t.Table) || c.isSyncedTable(t.NewSchema, t.NewTable) {
+			de.Tables = append(de.Tables, t)
+		}
+	}
+	if len(de.Tables) == 0 {
+		return nil
+	}
+	if c.opts.Log.IsInfo() {
+		c.opts.Log.Info("[binlogsync] Table structure changed, clear table cache",
+			log.String("action", action), log.String("query", de.Query), log.Stringer("position", pos))
+	}
+	return errors.WithStack(c.processDDLHandler(ctx, de))
+}
+
+func (c *Canal) processDDLHandler(ctx context.Context, de *DDLEvent) error {
+	c.rsMu.RLock()
+	defer c.rsMu.RUnlock()
+
+	erg, ctx := errgroup.WithContext(ctx)
+	for _, h := range c.ddlHandlers {
+		h := h
+		erg.Go(func() error {
+			err := h.OnDDL(ctx, de)
+			for _, t := range de.Tables {
+				if err != nil {
+					break
+				}
+				err = h.OnTableChanged(ctx, t.Schema, t.Table)
+				if err == nil && t.NewTable != "" {
+					err = h.OnTableChanged(ctx, t.NewSchema, t.NewTable)
+				}
+			}
+			if err != nil {
+				isInterr := errors.Is(err, errors.Interrupted)
+				c.opts.Log.Info("binlogsync.Canal.processDDLHandler.Go.OnDDL.error", log.Err(err), log.Stringer("handler_name", h),
+					log.Bool("is_interrupted", isInterr), log.String("action", de.Action), log.String("query", de.Query))
+				if isInterr {
+					return errors.WithStack(err)
+				}
+			}
+			return nil
+		})
+	}
+	return errors.WithStack(erg.Wait())
+}
+
+// parseDDLQuery detects CREATE, ALTER, RENAME, DROP and TRUNCATE TABLE
+// statements and returns the affected tables. Unqualified table names belong
+// to the default schema of the query. An empty action means the query is not
+// a supported DDL statement.
+func parseDDLQuery(schema, query []byte) (action string, tables []DDLTable) {
+	l := ddlLexer{query: query, schema: string(schema)}
+
+	switch {
+	case l.keyword("CREATE"):
+		l.keyword("TEMPORARY")
+		if !l.keyword("TABLE") {
+			return "", nil
+		}
+		l.keyword("IF", "NOT", "EXISTS")
+		t, ok := l.tableName()
+		if !ok {
+			return "", nil
+		}
+		return DDLCreateTable, []DDLTable{t}
+
+	case l.keyword("ALTER"):
+		l.keyword("ONLINE")
+		l.keyword("IGNORE")
+		if !l.keyword("TABLE") {
+			return "", nil
+		}
+		t, ok := l.tableName()
+		if !ok {
+			return "", nil
+		}
+		l.alterRename(&t)
+		return DDLAlterTable, []DDLTable{t}
+
+	case l.keyword("RENAME"):
+		if !l.keyword("TABLE") && !l.keyword("TABLES") {
+			return "", nil
+		}
+		for {
+			t, ok := l.tableName()
+			if !ok || !l.keyword("TO") {
+				return "", nil
+			}
+			nt, ok := l.tableName()
+			if !ok {
+				return "", nil
+			}
+			t.NewSchema, t.NewTable = nt.Schema, nt.Table
+			tables = append(tables, t)
+			if !l.char(',') {
+				return DDLRenameTable, tables
+			}
+		}
+
+	case l.keyword("DROP"):
+		l.keyword("TEMPORARY")
+		if !l.keyword("TABLE") && !l.keyword("TABLES") {
+			return "", nil
+		}
+		l.keyword("IF", "EXISTS")
+		for {
+			t, ok := l.tableName()
+			if !ok {
+				return "", nil
+			}
+			tables = append(tables, t)
+			if !l.char(',') {
+				return DDLDropTable, tables
+			}
+		}
+
+	case l.keyword("TRUNCATE"):
+		l.keyword("TABLE")
+		t, ok := l.tableName()
+		if !ok {
+			return "", nil
+		}
+		return DDLTruncateTable, []DDLTable{t}
+	}
+	return "", nil
+}
+
+// ddlLexer is a minimal tokenizer for the table names of DDL statements.
+type ddlLexer struct {
+	query  []byte
+	pos    int
+	schema string
+}
+
+func isIdentChar(b byte) bool {
+	return b == '_' || b == '$' || b >= 0x80 ||
+		(b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// skipSpace skips white spaces and comments.
+func (l *ddlLexer) skipSpace() {
+	for l.pos < len(l.query) {
+		switch rest := l.query[l.pos:]; {
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n' || rest[0] == '\r':
+			l.pos++
+		case bytes.HasPrefix(rest, []byte("/*")):
+			end := bytes.Index(rest[2:], []byte("*/"))
+			if end < 0 {
+				l.pos = len(l.query)
+				return
+			}
+			l.pos += end + 4
+		case rest[0] == '#' || bytes.HasPrefix(rest, []byte("-- ")):
+			end := bytes.IndexByte(rest, '\n')
+			if end < 0 {
+				l.pos = len(l.query)
+				return
+			}
+			l.pos += end + 1
+		default:
+			return
+		}
+	}
+}
+
+// word returns the next unquoted word without consuming it.
+func (l *ddlLexer) word() []byte {
+	l.skipSpace()
+	end := l.pos
+	for end < len(l.query) && isIdentChar(l.query[end]) {
+		end++
+	}
+	return l.query[l.pos:end]
+}
+
+// keyword consumes all keywords if they match in the given order.
+func (l *ddlLexer) keyword(kws ...string) bool {
+	start := l.pos
+	for _, kw := range kws {
+		w := l.word()
+		if !bytes.EqualFold(w, []byte(kw)) {
+			l.pos = start
+			return false
+		}
+		l.pos += len(w)
+	}
+	return true
+}
+
+// char consumes the next character if it matches.
+func (l *ddlLexer) char(c byte) bool {
+	l.skipSpace()
+	if l.pos < len(l.query) && l.query[l.pos] == c {
+		l.pos++
+		return true
+	}
+	return false
+}
+
+// ident reads a bare or back tick quoted identifier.
+func (l *ddlLexer) ident() (string, bool) {
+	if !l.char('`') {
+		w := l.word()
+		l.pos += len(w)
+		return string(w), len(w) > 0
+	}
+	var buf []byte
+	for l.pos < len(l.query) {
+		c := l.query[l.pos]
+		l.pos++
+		if c != '`' {
+			buf = append(buf, c)
+			continue
+		}
+		if l.pos < len(l.query) && l.query[l.pos] == '`' {
+			buf = append(buf, c)
+			l.pos++
+			continue
+		}
+		return string(buf), len(buf) > 0
+	}
+	return "", false
+}
+
+// tableName reads an optionally schema qualified table name.
+func (l *ddlLexer) tableName() (t DDLTable, ok bool) {
+	name, ok := l.ident()
+	if !ok {
+		return t, false
+	}
+	t.Schema, t.Table = l.schema, name
+	if l.char('.') {
+		t.Schema = name
+		t.Table, ok = l.ident()
+	}
+	return t, ok
+}
+
+// alterRename searches the remaining ALTER TABLE specification for a
+// RENAME [TO|AS] new_name clause, ignoring RENAME COLUMN, INDEX and KEY.
+func (l *ddlLexer) alterRename(t *DDLTable) {
+	depth := 0
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.query) {
+			return
+		}
+		switch c := l.query[l.pos]; {
+		case c == '(':
+			depth++
+			l.pos++
+		case c == ')':
+			depth--
+			l.pos++
+		case c == '\'' || c == '"':
+			l.skipString(c)
+		case c == '`':
+			l.ident()
+		case isIdentChar(c):
+			if depth == 0 && l.keyword("RENAME") {
+				if l.keyword("COLUMN") || l.keyword("INDEX") || l.keyword("KEY") {
+					continue
+				}
+				if !l.keyword("TO") {
+					l.keyword("AS")
+				}
+				if nt, ok := l.tableName(); ok {
+					t.NewSchema, t.NewTable = nt.Schema, nt.Table
+				}
+				return
+			}
+			l.pos += len(l.word())
+		default:
+			l.pos++
+		}
+	}
+}
+
+// skipString skips a quoted string literal including escaped quotes.
+func (l *ddlLexer) skipString(quote byte) {
+	l.pos++
+	for l.pos < len(l.query) {
+		c := l.query[l.pos]
+		l.pos++
+		switch {
+		case c == '\\':
+			l.pos++
+		case c == quote && l.pos < len(l.query) && l.query[l.pos] == quote:
+			l.pos++
+		case c == quote:
+			return
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestParseDDLQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query      string
+		wantAction string
+		wantTables []DDLTable
+	}{
+		{"CREATE TABLE `sales_order` (`id` int)", DDLCreateTable, []DDLTable{{Schema: "shop", Table: "sales_order"}}},
+		{"create table if not exists shop2.`sales order` like `sales_order`", DDLCreateTable, []DDLTable{{Schema: "shop2", Table: "sales order"}}},
+		{"CREATE TEMPORARY TABLE tmp_x SELECT 1", DDLCreateTable, []DDLTable{{Schema: "shop", Table: "tmp_x"}}},
+		{"/* ApplicationName=x */ ALTER TABLE `shop`.`catalog_product` ADD COLUMN `y` int", DDLAlterTable, []DDLTable{{Schema: "shop", Table: "catalog_product"}}},
+		{"ALTER ONLINE IGNORE TABLE cp ADD INDEX (`sku`)", DDLAlterTable, []DDLTable{{Schema: "shop", Table: "cp"}}},
+		{"ALTER TABLE cp RENAME COLUMN a TO b, ADD c varchar(10) DEFAULT 'RENAME TO x'", DDLAlterTable, []DDLTable{{Schema: "shop", Table: "cp"}}},
+		{"ALTER TABLE cp ADD x int, RENAME TO `shop2`.`cp2`", DDLAlterTable, []DDLTable{{Schema: "shop", Table: "cp", NewSchema: "shop2", NewTable: "cp2"}}},
+		{"ALTER TABLE cp RENAME cp2", DDLAlterTable, []DDLTable{{Schema: "shop", Table: "cp", NewSchema: "shop", NewTable: "cp2"}}},
+		{"RENAME TABLE a TO a_old, `b_new` TO shop2.b", DDLRenameTable, []DDLTable{
+			{Schema: "shop", Table: "a", NewSchema: "shop", NewTable: "a_old"},
+			{Schema: "shop", Table: "b_new", NewSchema: "shop2", NewTable: "b"},
+		}},
+		{"DROP TABLE IF EXISTS `a`,`shop2`.`b` /* generated by server */", DDLDropTable, []DDLTable{
+			{Schema: "shop", Table: "a"},
+			{Schema: "shop2", Table: "b"},
+		}},
+		{"DROP TEMPORARY TABLE `a``b`", DDLDropTable, []DDLTable{{Schema: "shop", Table: "a`b"}}},
+		{"TRUNCATE TABLE `sales_order`", DDLTruncateTable, []DDLTable{{Schema: "shop", Table: "sales_order"}}},
+		{"truncate sales_order", DDLTruncateTable, []DDLTable{{Schema: "shop", Table: "sales_order"}}},
+		{"CREATE DATABASE shop2", "", nil},
+		{"DROP INDEX idx ON sales_order", "", nil},
+		{"RENAME TABLE a", "", nil},
+		{"INSERT INTO sales_order VALUES (1)", "", nil},
+		{"", "", nil},
+	}
+	for _, test := range tests {
+		action, tables := parseDDLQuery([]byte("shop"), []byte(test.query))
+		assert.Exactly(t, test.wantAction, action, "%q", test.query)
+		assert.Exactly(t, test.wantTables, tables, "%q", test.query)
+	}
+}
+
+type testDDLHandler struct {
+	mu      sync.Mutex
+	events  []*DDLEvent
+	changed []string
+	err     error
+}
+
+func (h *testDDLHandler) OnDDL(_ context.Context, e *DDLEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, e)
+	return h.err
+}
+
+func (h *testDDLHandler) OnTableChanged(_ context.Context, schema, table string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.changed = append(h.changed, fmt.Sprintf("%s.%s", schema, table))
+	return nil
+}
+
+func (h *testDDLHandler) String() string { return "testDDLHandler" }
+
+func sortedTables(c *Canal) []string {
+	tns := c.tables.Tables()
+	sort.Strings(tns)
+	return tns
+}
+
+func TestCanal_HandleDDL(t *testing.T) {
+	t.Parallel()
+
+	newCanal := func() *Canal {
+		return &Canal{
+			opts:              Options{Log: log.BlackHole{}},
+			dsn:               &mysql.Config{DBName: "shop"},
+			tables:            ddl.MustNewTables(ddl.WithTable("sales_order"), ddl.WithTable("catalog_product"), ddl.WithTable("core_config_data")),
+			tableAllowedCache: map[string]bool{},
+			includeTableRegex: []*regexp.Regexp{regexp.MustCompile(".*")},
+			excludeTableRegex: []*regexp.Regexp{regexp.MustCompile("^core_")},
+		}
+	}
+	ctx := context.Background()
+	pos := ddl.MasterStatus{File: "mysql-bin.000001", Position: 4711}
+	ts := time.Unix(1577836800, 0)
+
+	t.Run("alter clears cache and calls handler", func(t *testing.T) {
+		c := newCanal()
+		h := &testDDLHandler{}
+		c.RegisterDDLHandler(h)
+
+		assert.NoError(t, c.handleDDL(ctx, []byte("shop"), []byte("ALTER TABLE sales_order ADD x int"), pos, ts))
+		assert.Exactly(t, []string{"catalog_product", "core_config_data"}, sortedTables(c))
+		assert.Exactly(t, []*DDLEvent{{
+			Action:    DDLAlterTable,
+			Tables:    []DDLTable{{Schema: "shop", Table: "sales_order"}},
+			Query:     "ALTER TABLE sales_order ADD x int",
+			Position:  pos,
+			Timestamp: ts,
+		}}, h.events)
+		assert.Exactly(t, []string{"shop.sales_order"}, h.changed)
+	})
+
+	t.Run("rename reports old and new name", func(t *testing.T) {
+		c := newCanal()
+		h := &testDDLHandler{}
+		c.RegisterDDLHandler(h)
+
+		assert.NoError(t, c.handleDDL(ctx, []byte("shop"), []byte("RENAME TABLE sales_order TO sales_order_old, catalog_product TO other.cp"), pos, ts))
+		assert.Exactly(t, []string{"core_config_data"}, sortedTables(c))
+		assert.Len(t, h.events, 1)
+		assert.Exactly(t, []string{"shop.sales_order", "shop.sales_order_old", "shop.catalog_product", "other.cp"}, h.changed)
+	})
+
+	t.Run("excluded and foreign tables", func(t *testing.T) {
+		c := newCanal()
+		h := &testDDLHandler{}
+		c.RegisterDDLHandler(h)
+
+		assert.NoError(t, c.handleDDL(ctx, []byte("shop"), []byte("TRUNCATE core_config_data"), pos, ts))
+		assert.NoError(t, c.handleDDL(ctx, []byte("shop"), []byte("DROP TABLE other.sales_order"), pos, ts))
+		assert.NoError(t, c.handleDDL(ctx, []byte("shop"), []byte("SAVEPOINT x"), pos, ts))
+		// the excluded table gets still removed from the cache
+		assert.Exactly(t, []string{"catalog_product", "sales_order"}, sortedTables(c))
+		assert.Len(t, h.events, 0)
+	})
+
+	t.Run("interrupted", func(t *testing.T) {
+		c := newCanal()
+		c.RegisterDDLHandler(&testDDLHandler{err: errors.Interrupted.Newf("stop")}, &testDDLHandler{err: errors.NotValid.Newf("ignored")})
+
+		err := c.handleDDL(ctx, []byte("shop"), []byte("DROP TABLE sales_order"), pos, ts)
+		assert.True(t, errors.Interrupted.Match(err), "%+v", err)
+	})
+}
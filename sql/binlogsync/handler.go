@@ -2,6 +2,7 @@ package binlogsync
 
 import (
 	"context"
+	"time"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/log"
@@ -9,10 +10,10 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-// TODO(CyS) investigate what would happen in case of transaction? should all
-// the events be gathered together once a transaction starts? because on
-// RollBack all events must be invalidated or better RowsEventHandler should not
-// be called at all.
+// A RowsEventHandler gets called for each rows event, even if the transaction
+// gets later rolled back, which happens only for non-transactional tables. Use
+// a TransactionHandler to receive all rows events of a committed transaction at
+// once.
 
 // RowsEventHandler calls your code when an event gets dispatched.
 type RowsEventHandler interface {
@@ -33,6 +34,31 @@ type RowsEventHandler interface {
 	String() string
 }
 
+// EventInfo contains the binlog coordinates of the rows event passed to
+// RowsEventHandler.Do. Use EventInfoFromContext to retrieve it.
+type EventInfo struct {
+	Schema string
+	// Position contains the binlog position after the rows event.
+	Position ddl.MasterStatus
+	// GTID contains the global transaction ID of the current transaction, if
+	// enabled on the server.
+	GTID      string
+	Timestamp time.Time
+}
+
+type ctxKeyEventInfo struct{}
+
+func withEventInfo(ctx context.Context, ei EventInfo) context.Context {
+	return context.WithValue(ctx, ctxKeyEventInfo{}, ei)
+}
+
+// EventInfoFromContext returns the binlog coordinates of the current rows
+// event. The context must be the one passed to RowsEventHandler.Do.
+func EventInfoFromContext(ctx context.Context) (EventInfo, bool) {
+	ei, ok := ctx.Value(ctxKeyEventInfo{}).(EventInfo)
+	return ei, ok
+}
+
 // RegisterRowsEventHandler adds a new event handler to the internal list. If a
 // table name gets provided the event handler is bound to that exact table name,
 // if the table has not been excluded via the global regexes. An empty tableName
@@ -0,0 +1,228 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+)
+
+// OnlineSchemaChangeHandler implements RowsEventHandler and
+// ddl.OnlineSchemaChangeSyncer. It applies the rows events of the original
+// table to the shadow table of an online schema change, instead of using
+// triggers. Register it with Canal.RegisterRowsEventHandler for the original
+// table and start the canal before calling ddl.Table.OnlineSchemaChange.
+// Writes to the original table between WaitSync and the cut over might get
+// lost, so pause the writes of the application for the cut over or use the
+// ddl.TriggerSyncer.
+type OnlineSchemaChangeHandler struct {
+	canal *Canal
+	db    dml.Execer
+	// waitTimeout defines how long WaitSync waits for the canal to catch up
+	// with the master position.
+	waitTimeout time.Duration
+
+	mu      sync.RWMutex
+	schema  string
+	table   string
+	shadow  string
+	columns map[string]bool
+}
+
+// NewOnlineSchemaChangeHandler creates a new handler which writes with the
+// connection of the canal into the shadow table. Argument waitTimeout
+// defaults to one minute.
+func NewOnlineSchemaChangeHandler(c *Canal, waitTimeout time.Duration) *OnlineSchemaChangeHandler {
+	if waitTimeout == 0 {
+		waitTimeout = time.Minute
+	}
+	return &OnlineSchemaChangeHandler{
+		canal:       c,
+		db:          c.dbcp.DB,
+		waitTimeout: waitTimeout,
+	}
+}
+
+// StartSync implements ddl.OnlineSchemaChangeSyncer and starts applying the
+// rows events of table t to the shadow table.
+func (h *OnlineSchemaChangeHandler) StartSync(_ context.Context, t *ddl.Table, shadow string, columns []string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.shadow != "" {
+		return errors.AlreadyInUse.Newf("[binlogsync] OnlineSchemaChangeHandler already syncs table %q into %q", h.table, h.shadow)
+	}
+	h.schema = h.schemaName(t)
+	h.table = t.Name
+	h.shadow = shadow
+	h.columns = make(map[string]bool, len(columns))
+	for _, c := range columns {
+		h.columns[c] = true
+	}
+	return nil
+}
+
+// WaitSync implements ddl.OnlineSchemaChangeSyncer and blocks until the canal
+// has processed all events up to the current master position.
+func (h *OnlineSchemaChangeHandler) WaitSync(context.Context, *ddl.Table, string) error {
+	return errors.WithStack(h.canal.CatchMasterPos(h.waitTimeout))
+}
+
+// StopSync implements ddl.OnlineSchemaChangeSyncer and stops applying the rows
+// events.
+func (h *OnlineSchemaChangeHandler) StopSync(context.Context, *ddl.Table, string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.schema, h.table, h.shadow, h.columns = "", "", "", nil
+	return nil
+}
+
+// schemaName returns the database of table t. An empty Schema refers to the
+// database of the canal.
+func (h *OnlineSchemaChangeHandler) schemaName(t *ddl.Table) string {
+	if t.Schema == "" && h.canal != nil && h.canal.dsn != nil {
+		return h.canal.dsn.DBName
+	}
+	return t.Schema
+}
+
+// Do implements RowsEventHandler. Inserts and updates get written with REPLACE
+// INTO, deletes with DELETE into the shadow table.
+func (h *OnlineSchemaChangeHandler) Do(ctx context.Context, action string, t *ddl.Table, rows [][]interface{}) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.shadow == "" || t.Name != h.table || h.schemaName(t) != h.schema {
+		return nil
+	}
+
+	switch action {
+	case InsertAction:
+		for _, row := range rows {
+			if err := h.replace(ctx, t, row); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	case DeleteAction:
+		for _, row := range rows {
+			if err := h.delete(ctx, t, row); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	case UpdateAction:
+		if len(rows)%2 != 0 {
+			return errors.NotValid.Newf("[binlogsync] Table %q: update rows event requires an even number of rows, got %d", t.Name, len(rows))
+		}
+		for i := 0; i < len(rows); i += 2 {
+			if pkChanged(t.Columns, rows[i], rows[i+1]) {
+				if err := h.delete(ctx, t, rows[i]); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+			if err := h.replace(ctx, t, rows[i+1]); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	default:
+		return errors.NotSupported.Newf("[binlogsync] Table %q: action %q not supported", t.Name, action)
+	}
+	return nil
+}
+
+func pkChanged(cols ddl.Columns, before, after []interface{}) bool {
+	for i, c := range cols {
+		if !c.IsPK() || i >= len(before) || i >= len(after) {
+			continue
+		}
+		b1, ok1 := before[i].([]byte)
+		b2, ok2 := after[i].([]byte)
+		switch {
+		case ok1 && ok2:
+			if !bytes.Equal(b1, b2) {
+				return true
+			}
+		case before[i] != after[i]:
+			return true
+		}
+	}
+	return false
+}
+
+func (h *OnlineSchemaChangeHandler) replace(ctx context.Context, t *ddl.Table, row []interface{}) error {
+	var buf bytes.Buffer
+	buf.WriteString("REPLACE INTO ")
+	dml.Quoter.WriteQualifierName(&buf, h.schema, h.shadow)
+	buf.WriteString(" (")
+	args := make([]interface{}, 0, len(h.columns))
+	for i, c := range t.Columns {
+		if !h.columns[c.Field] || i >= len(row) {
+			continue
+		}
+		if len(args) > 0 {
+			buf.WriteByte(',')
+		}
+		dml.Quoter.WriteIdentifier(&buf, c.Field)
+		args = append(args, row[i])
+	}
+	buf.WriteString(") VALUES (")
+	for i := range args {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('?')
+	}
+	buf.WriteByte(')')
+	return h.exec(ctx, t, buf.String(), args)
+}
+
+func (h *OnlineSchemaChangeHandler) delete(ctx context.Context, t *ddl.Table, row []interface{}) error {
+	var buf bytes.Buffer
+	buf.WriteString("DELETE FROM ")
+	dml.Quoter.WriteQualifierName(&buf, h.schema, h.shadow)
+	buf.WriteString(" WHERE ")
+	var args []interface{}
+	for i, c := range t.Columns {
+		if !c.IsPK() || i >= len(row) {
+			continue
+		}
+		if len(args) > 0 {
+			buf.WriteString(" AND ")
+		}
+		dml.Quoter.WriteIdentifier(&buf, c.Field)
+		buf.WriteString(" = ?")
+		args = append(args, row[i])
+	}
+	if len(args) == 0 {
+		return errors.NotSupported.Newf("[binlogsync] OnlineSchemaChangeHandler: table %q requires a primary key", t.Name)
+	}
+	return h.exec(ctx, t, buf.String(), args)
+}
+
+func (h *OnlineSchemaChangeHandler) exec(ctx context.Context, t *ddl.Table, qry string, args []interface{}) error {
+	if _, err := h.db.ExecContext(ctx, qry, args...); err != nil {
+		return errors.Interrupted.New(err, "[binlogsync] OnlineSchemaChangeHandler failed to apply rows event of table %q to %q", t.Name, h.shadow)
+	}
+	return nil
+}
+
+// Complete implements RowsEventHandler.
+func (h *OnlineSchemaChangeHandler) Complete(context.Context) error { return nil }
+
+// String implements RowsEventHandler.
+func (h *OnlineSchemaChangeHandler) String() string { return "binlogsync.OnlineSchemaChangeHandler" }
@@ -0,0 +1,113 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+var (
+	_ RowsEventHandler             = (*OnlineSchemaChangeHandler)(nil)
+	_ ddl.OnlineSchemaChangeSyncer = (*OnlineSchemaChangeHandler)(nil)
+)
+
+func TestOnlineSchemaChangeHandler(t *testing.T) {
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	tbl := ddl.NewTable("catalog_product_entity_varchar",
+		&ddl.Column{Field: "value_id", Pos: 1, DataType: "int", Key: "PRI"},
+		&ddl.Column{Field: "value", Pos: 2, DataType: "varchar"},
+		&ddl.Column{Field: "legacy_flag", Pos: 3, DataType: "tinyint"},
+	)
+	h := &OnlineSchemaChangeHandler{db: dbc.DB}
+	ctx := context.TODO()
+
+	// not yet started
+	assert.NoError(t, h.Do(ctx, InsertAction, tbl, [][]interface{}{{1, []byte("a"), 0}}))
+
+	assert.NoError(t, h.StartSync(ctx, tbl, "_cpev_new", []string{"value_id", "value"}))
+	assert.ErrorIsKind(t, errors.AlreadyInUse, h.StartSync(ctx, tbl, "_cpev_new", nil))
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("REPLACE INTO `_cpev_new` (`value_id`,`value`) VALUES (?,?)")).
+		WithArgs(1, []byte("a")).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, h.Do(ctx, InsertAction, tbl, [][]interface{}{{1, []byte("a"), 0}}))
+
+	// update without and with a changed primary key
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("REPLACE INTO `_cpev_new` (`value_id`,`value`) VALUES (?,?)")).
+		WithArgs(1, []byte("b")).WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `_cpev_new` WHERE `value_id` = ?")).
+		WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("REPLACE INTO `_cpev_new` (`value_id`,`value`) VALUES (?,?)")).
+		WithArgs(2, []byte("b")).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, h.Do(ctx, UpdateAction, tbl, [][]interface{}{
+		{1, []byte("a"), 0}, {1, []byte("b"), 0},
+		{1, []byte("b"), 0}, {2, []byte("b"), 0},
+	}))
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `_cpev_new` WHERE `value_id` = ?")).
+		WithArgs(2).WillReturnError(errors.New("connection lost"))
+	assert.ErrorIsKind(t, errors.Interrupted, h.Do(ctx, DeleteAction, tbl, [][]interface{}{{2, []byte("b"), 0}}))
+
+	// other tables get ignored
+	assert.NoError(t, h.Do(ctx, DeleteAction, ddl.NewTable("sales_order"), [][]interface{}{{2}}))
+
+	assert.NoError(t, h.StopSync(ctx, tbl, "_cpev_new"))
+	assert.NoError(t, h.Do(ctx, DeleteAction, tbl, [][]interface{}{{2, []byte("b"), 0}}))
+}
+
+func TestOnlineSchemaChangeHandler_Schema(t *testing.T) {
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	cols := ddl.Columns{
+		&ddl.Column{Field: "value_id", Pos: 1, DataType: "int", Key: "PRI"},
+		&ddl.Column{Field: "value", Pos: 2, DataType: "varchar"},
+	}
+	tbl := ddl.NewTable("catalog_product_entity_varchar", cols...)
+	tbl.Schema = "archive"
+	h := &OnlineSchemaChangeHandler{db: dbc.DB, canal: &Canal{dsn: &mysql.Config{DBName: "shop"}}}
+	ctx := context.TODO()
+
+	assert.NoError(t, h.StartSync(ctx, tbl, "_cpev_new", []string{"value_id", "value"}))
+
+	// same table name in the database of the canal gets ignored
+	assert.NoError(t, h.Do(ctx, InsertAction, ddl.NewTable("catalog_product_entity_varchar", cols...), [][]interface{}{{1, []byte("a")}}))
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("REPLACE INTO `archive`.`_cpev_new` (`value_id`,`value`) VALUES (?,?)")).
+		WithArgs(1, []byte("a")).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, h.Do(ctx, InsertAction, tbl, [][]interface{}{{1, []byte("a")}}))
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `archive`.`_cpev_new` WHERE `value_id` = ?")).
+		WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, h.Do(ctx, DeleteAction, tbl, [][]interface{}{{1, []byte("a")}}))
+	assert.NoError(t, h.StopSync(ctx, tbl, "_cpev_new"))
+
+	// an empty schema refers to the database of the canal
+	tblShop := ddl.NewTable("catalog_product_entity_varchar", cols...)
+	assert.NoError(t, h.StartSync(ctx, tblShop, "_cpev_new", []string{"value_id", "value"}))
+	assert.NoError(t, h.Do(ctx, InsertAction, tbl, [][]interface{}{{1, []byte("a")}}))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("REPLACE INTO `shop`.`_cpev_new` (`value_id`,`value`) VALUES (?,?)")).
+		WithArgs(2, []byte("b")).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, h.Do(ctx, InsertAction, tblShop, [][]interface{}{{2, []byte("b")}}))
+}
@@ -0,0 +1,176 @@
+package binlogsync
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/store/scope"
+)
+
+// PositionStore persists the binlog position of a Canal to resume the sync
+// after a restart. The saved ddl.MasterStatus contains the file name, the
+// position and, if GTIDs are enabled, the executed GTID set.
+type PositionStore interface {
+	// LoadPosition returns the last saved position. It returns an error with
+	// behaviour NotFound if no position has been saved yet.
+	LoadPosition(ctx context.Context) (ddl.MasterStatus, error)
+	// SavePosition persists the position.
+	SavePosition(ctx context.Context, ms ddl.MasterStatus) error
+}
+
+// PositionFile stores the position in a file. The file gets written atomically
+// by renaming a temporary file.
+type PositionFile struct {
+	FileName string
+}
+
+// NewPositionFile creates a new file based position store.
+func NewPositionFile(fileName string) *PositionFile {
+	return &PositionFile{FileName: fileName}
+}
+
+// LoadPosition implements PositionStore.
+func (pf *PositionFile) LoadPosition(_ context.Context) (ms ddl.MasterStatus, err error) {
+	data, err := ioutil.ReadFile(pf.FileName)
+	if os.IsNotExist(err) {
+		return ms, errors.NotFound.Newf("[binlogsync] PositionFile %q not found", pf.FileName)
+	}
+	if err != nil {
+		return ms, errors.WithStack(err)
+	}
+	err = ms.FromString(string(bytes.TrimSpace(data)))
+	return ms, errors.Wrapf(err, "[binlogsync] PositionFile %q", pf.FileName)
+}
+
+// SavePosition implements PositionStore.
+func (pf *PositionFile) SavePosition(_ context.Context, ms ddl.MasterStatus) error {
+	f, err := ioutil.TempFile(filepath.Dir(pf.FileName), filepath.Base(pf.FileName)+".tmp")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := ms.WriteTo(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return errors.WithStack(err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(f.Name(), pf.FileName))
+}
+
+// PositionConfig stores the position in the configuration, for example in
+// table core_config_data, using the path ConfigPathBackendPosition in the
+// default scope.
+type PositionConfig struct {
+	scoped config.Scoped
+	setter config.Setter
+	path   *config.Path
+}
+
+// NewPositionConfig creates a new position store backed by config.Service.
+// Argument cs gets used for reading and cfgSet for writing the position.
+func NewPositionConfig(cs config.Scoped, cfgSet config.Setter) *PositionConfig {
+	return &PositionConfig{
+		scoped: cs,
+		setter: cfgSet,
+		path:   config.MustNewPath(ConfigPathBackendPosition),
+	}
+}
+
+// LoadPosition implements PositionStore.
+func (pc *PositionConfig) LoadPosition(_ context.Context) (ms ddl.MasterStatus, err error) {
+	if !pc.scoped.IsValid() {
+		return ms, errors.NotFound.Newf("[binlogsync] PositionConfig has no valid config.Scoped")
+	}
+	str, ok, err := pc.scoped.Get(scope.Default, ConfigPathBackendPosition).Str()
+	if err != nil {
+		return ms, errors.WithStack(err)
+	}
+	if !ok || str == "" {
+		return ms, errors.NotFound.Newf("[binlogsync] PositionConfig path %q not found", ConfigPathBackendPosition)
+	}
+	err = ms.FromString(str)
+	return ms, errors.WithStack(err)
+}
+
+// SavePosition implements PositionStore.
+func (pc *PositionConfig) SavePosition(_ context.Context, ms ddl.MasterStatus) error {
+	if pc.setter == nil {
+		return errors.NotImplemented.Newf("[binlogsync] PositionConfig requires a config.Setter")
+	}
+	var buf bytes.Buffer
+	_, _ = ms.WriteTo(&buf)
+	return errors.WithStack(pc.setter.Set(pc.path, buf.Bytes()))
+}
+
+// DefaultPositionTable defines the default table name of PositionDB.
+const DefaultPositionTable = "binlogsync_position"
+
+// PositionDB stores the position in a dedicated database table. Multiple
+// Canals can share the table, each identified by its Name.
+type PositionDB struct {
+	DB *dml.ConnPool
+	// TableName defaults to DefaultPositionTable.
+	TableName string
+	// Name identifies the position of a Canal in the table.
+	Name string
+}
+
+// NewPositionDB creates a new position store backed by a database table. The
+// table gets created with function CreateTable.
+func NewPositionDB(db *dml.ConnPool, name string) *PositionDB {
+	return &PositionDB{
+		DB:        db,
+		TableName: DefaultPositionTable,
+		Name:      name,
+	}
+}
+
+// CreateTable creates the position table if it does not exist.
+func (pd *PositionDB) CreateTable(ctx context.Context) error {
+	_, err := pd.DB.DB.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+dml.Quoter.Name(pd.TableName)+` (
+  name varchar(64) NOT NULL,
+  binlog_file varchar(255) NOT NULL,
+  binlog_position bigint unsigned NOT NULL,
+  gtid_set text,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (name)
+)`)
+	return errors.Wrapf(err, "[binlogsync] PositionDB.CreateTable %q", pd.TableName)
+}
+
+// LoadPosition implements PositionStore.
+func (pd *PositionDB) LoadPosition(ctx context.Context) (ms ddl.MasterStatus, err error) {
+	var pos uint64
+	var gtidSet sql.NullString
+	err = pd.DB.DB.QueryRowContext(ctx, "SELECT `binlog_file`,`binlog_position`,`gtid_set` FROM "+
+		dml.Quoter.Name(pd.TableName)+" WHERE `name`=?", pd.Name).Scan(&ms.File, &pos, &gtidSet)
+	if err == sql.ErrNoRows {
+		return ms, errors.NotFound.Newf("[binlogsync] PositionDB %q not found in table %q", pd.Name, pd.TableName)
+	}
+	if err != nil {
+		return ms, errors.Wrapf(err, "[binlogsync] PositionDB.LoadPosition %q", pd.Name)
+	}
+	ms.Position = uint(pos)
+	ms.ExecutedGTIDSet = gtidSet.String
+	return ms, nil
+}
+
+// SavePosition implements PositionStore.
+func (pd *PositionDB) SavePosition(ctx context.Context, ms ddl.MasterStatus) error {
+	_, err := pd.DB.DB.ExecContext(ctx, "INSERT INTO "+dml.Quoter.Name(pd.TableName)+
+		" (`name`,`binlog_file`,`binlog_position`,`gtid_set`) VALUES (?,?,?,?)"+
+		" ON DUPLICATE KEY UPDATE `binlog_file`=VALUES(`binlog_file`),`binlog_position`=VALUES(`binlog_position`),`gtid_set`=VALUES(`gtid_set`)",
+		pd.Name, ms.File, uint64(ms.Position), ms.ExecutedGTIDSet)
+	return errors.Wrapf(err, "[binlogsync] PositionDB.SavePosition %q", pd.Name)
+}
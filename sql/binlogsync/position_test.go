@@ -0,0 +1,233 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/config"
+	"github.com/corestoreio/pkg/config/storage"
+	"github.com/corestoreio/pkg/sql/binlogsync"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+var (
+	_ binlogsync.PositionStore = (*binlogsync.PositionFile)(nil)
+	_ binlogsync.PositionStore = (*binlogsync.PositionConfig)(nil)
+	_ binlogsync.PositionStore = (*binlogsync.PositionDB)(nil)
+)
+
+var testPosition = ddl.MasterStatus{
+	File:            "mysql-bin.000004",
+	Position:        545460,
+	ExecutedGTIDSet: "0-1-4711",
+}
+
+func TestPositionFile(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "binlogsync")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	pf := binlogsync.NewPositionFile(filepath.Join(dir, "position.txt"))
+
+	_, err = pf.LoadPosition(ctx)
+	assert.True(t, errors.NotFound.Match(err), "%+v", err)
+
+	assert.NoError(t, pf.SavePosition(ctx, testPosition))
+	ms, err := pf.LoadPosition(ctx)
+	assert.NoError(t, err)
+	assert.Exactly(t, testPosition, ms)
+
+	data, err := ioutil.ReadFile(pf.FileName)
+	assert.NoError(t, err)
+	assert.Exactly(t, "mysql-bin.000004;545460;0-1-4711", string(data))
+}
+
+func TestPositionConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfgStorage := storage.NewMap()
+	pc := binlogsync.NewPositionConfig(config.NewFakeService(cfgStorage).Scoped(0, 0), cfgStorage)
+
+	_, err := pc.LoadPosition(ctx)
+	assert.True(t, errors.NotFound.Match(err), "%+v", err)
+
+	assert.NoError(t, pc.SavePosition(ctx, testPosition))
+	ms, err := pc.LoadPosition(ctx)
+	assert.NoError(t, err)
+	assert.Exactly(t, testPosition, ms)
+}
+
+func TestPositionDB(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	ctx := context.Background()
+	pd := binlogsync.NewPositionDB(dbc, "canal1")
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE IF NOT EXISTS `binlogsync_position` (")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.NoError(t, pd.CreateTable(ctx))
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `binlog_file`,`binlog_position`,`gtid_set` FROM `binlogsync_position` WHERE `name`=?")).
+		WithArgs("canal1").WillReturnRows(sqlmock.NewRows([]string{"binlog_file", "binlog_position", "gtid_set"}))
+	_, err := pd.LoadPosition(ctx)
+	assert.True(t, errors.NotFound.Match(err), "%+v", err)
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `binlogsync_position` (`name`,`binlog_file`,`binlog_position`,`gtid_set`) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE")).
+		WithArgs("canal1", "mysql-bin.000004", uint64(545460), "0-1-4711").WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, pd.SavePosition(ctx, testPosition))
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `binlog_file`,`binlog_position`,`gtid_set` FROM `binlogsync_position` WHERE `name`=?")).
+		WithArgs("canal1").WillReturnRows(sqlmock.NewRows([]string{"binlog_file", "binlog_position", "gtid_set"}).
+		AddRow("mysql-bin.000004", 545460, "0-1-4711"))
+	ms, err := pd.LoadPosition(ctx)
+	assert.NoError(t, err)
+	assert.Exactly(t, testPosition, ms)
+}
+
+type testPositionStore struct {
+	ms    ddl.MasterStatus
+	saved []ddl.MasterStatus
+}
+
+func (ps *testPositionStore) LoadPosition(_ context.Context) (ddl.MasterStatus, error) {
+	if ps.ms.File == "" {
+		return ps.ms, errors.NotFound.Newf("position not found")
+	}
+	return ps.ms, nil
+}
+
+func (ps *testPositionStore) SavePosition(_ context.Context, ms ddl.MasterStatus) error {
+	ps.saved = append(ps.saved, ms)
+	return nil
+}
+
+func expectBinlogRowFormat(dbMock sqlmock.Sqlmock) {
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SHOW VARIABLES WHERE (`Variable_name` LIKE 'binlog_format')")).
+		WithArgs().
+		WillReturnRows(
+			sqlmock.NewRows([]string{"Variable_name", "Value"}).
+				FromCSVString(`binlog_format,row`),
+		)
+}
+
+func TestNewCanal_ResumeFromPositionStore(t *testing.T) {
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	expectBinlogRowFormat(dbMock)
+
+	ps := &testPositionStore{ms: testPosition}
+	c, err := binlogsync.NewCanal(`root:@x'err(localhost:3306)/TestDB?allowNativePasswords=false&maxAllowedPacket=0`,
+		binlogsync.WithDB(dbc.DB), &binlogsync.Options{PositionStore: ps})
+	assert.NoError(t, err, "%+v", err)
+
+	// without UseGTID the GTID set gets loaded but not tracked.
+	assert.Exactly(t, testPosition, c.SyncedPosition())
+	assert.NoError(t, c.Checkpoint(context.Background()))
+	assert.Exactly(t, []ddl.MasterStatus{testPosition}, ps.saved)
+}
+
+func TestNewCanal_PositionStorePrecedence(t *testing.T) {
+	runner := func(ps *testPositionStore, want ddl.MasterStatus) func(*testing.T) {
+		return func(t *testing.T) {
+			dbc, dbMock := dmltest.MockDB(t)
+			defer dmltest.MockClose(t, dbc, dbMock)
+
+			expectBinlogRowFormat(dbMock)
+
+			c, err := binlogsync.NewCanal(`root:@x'err(localhost:3306)/TestDB?allowNativePasswords=false&maxAllowedPacket=0`,
+				binlogsync.WithDB(dbc.DB), &binlogsync.Options{
+					PositionStore:       ps,
+					BinlogStartFile:     "mysql-bin.000001",
+					BinlogStartPosition: 4,
+				})
+			assert.NoError(t, err, "%+v", err)
+			assert.Exactly(t, want, c.SyncedPosition())
+		}
+	}
+	t.Run("saved position wins", runner(&testPositionStore{ms: testPosition}, testPosition))
+	t.Run("start position without saved position", runner(&testPositionStore{},
+		ddl.MasterStatus{File: "mysql-bin.000001", Position: 4}))
+}
+
+func TestNewCanal_ResumeFromGTID(t *testing.T) {
+	t.Run("MariaDB from master", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectQuery(`SHOW MASTER STATUS`).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"File", "Position", "Binlog_Do_DB", "Binlog_Ignore_DB"}).
+					FromCSVString(`mysqlbin.log:0001,4711,,`),
+			)
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SHOW VARIABLES WHERE (`Variable_name` LIKE 'gtid_current_pos')")).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"Variable_name", "Value"}).
+					FromCSVString(`gtid_current_pos,0-1-33`),
+			)
+		expectBinlogRowFormat(dbMock)
+
+		ps := &testPositionStore{}
+		c, err := binlogsync.NewCanal(`root:@x'err(localhost:3306)/TestDB?allowNativePasswords=false&maxAllowedPacket=0`,
+			binlogsync.WithDB(dbc.DB), &binlogsync.Options{PositionStore: ps, UseGTID: true})
+		assert.NoError(t, err, "%+v", err)
+
+		assert.NoError(t, c.Checkpoint(context.Background()))
+		assert.Exactly(t, []ddl.MasterStatus{{
+			File:            "mysqlbin.log:0001",
+			Position:        4711,
+			ExecutedGTIDSet: "0-1-33",
+		}}, ps.saved)
+	})
+
+	t.Run("MySQL from option", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		expectBinlogRowFormat(dbMock)
+
+		const gtid = "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-23"
+		c, err := binlogsync.NewCanal(`root:@x'err(localhost:3306)/TestDB?allowNativePasswords=false&maxAllowedPacket=0`,
+			binlogsync.WithDB(dbc.DB), &binlogsync.Options{Flavor: binlogsync.MySQLFlavor, BinlogStartGTID: gtid})
+		assert.NoError(t, err, "%+v", err)
+		assert.Exactly(t, gtid, c.SyncedPosition().ExecutedGTIDSet)
+	})
+
+	t.Run("invalid GTID set", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		c, err := binlogsync.NewCanal(`root:@x'err(localhost:3306)/TestDB?allowNativePasswords=false&maxAllowedPacket=0`,
+			binlogsync.WithDB(dbc.DB), &binlogsync.Options{BinlogStartGTID: "0-1"})
+		assert.Nil(t, c)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+}
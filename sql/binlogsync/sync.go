@@ -2,7 +2,6 @@ package binlogsync
 
 import (
 	"context"
-	"regexp"
 	"time"
 
 	"github.com/corestoreio/errors"
@@ -19,49 +18,6 @@ const (
 	DeleteAction = "delete"
 )
 
-var (
-	expCreateTable = regexp.MustCompile("(?i)^CREATE\\sTABLE(\\sIF\\sNOT\\sEXISTS)?\\s`{0,1}(.*?)`{0,1}\\.{0,1}`{0,1}([^`\\.]+?)`{0,1}\\s.*")
-	expAlterTable  = regexp.MustCompile("(?i)^ALTER\\sTABLE\\s.*?`{0,1}(.*?)`{0,1}\\.{0,1}`{0,1}([^`\\.]+?)`{0,1}\\s.*")
-	expRenameTable = regexp.MustCompile("(?i)^RENAME\\sTABLE\\s.*?`{0,1}(.*?)`{0,1}\\.{0,1}`{0,1}([^`\\.]+?)`{0,1}\\s{1,}TO\\s.*?")
-	expDropTable   = regexp.MustCompile("(?i)^DROP\\sTABLE(\\sIF\\sEXISTS){0,1}\\s`{0,1}(.*?)`{0,1}\\.{0,1}`{0,1}([^`\\.]+?)`{0,1}(?:$|\\s)")
-	ddlExpressions = [...]*regexp.Regexp{expCreateTable, expAlterTable, expRenameTable, expDropTable}
-)
-
-func extractTableFromQueryEvent(schema, query []byte) (dbName, tableName string) {
-	var mb [][]byte
-
-	for _, reg := range ddlExpressions {
-		mb = reg.FindSubmatch(query)
-		if len(mb) != 0 {
-			break
-		}
-	}
-	mbLen := len(mb)
-	if mbLen == 0 {
-		return
-	}
-
-	// the first last is table name, the second last is database name(if exists)
-	if len(mb[mbLen-2]) == 0 {
-		dbName = string(schema)
-	} else {
-		dbName = string(mb[mbLen-2])
-	}
-	tableName = string(mb[mbLen-1])
-	return
-}
-
-func (c *Canal) clearTableCacheOnDDLStmt(schema, query []byte) {
-	defer log.WhenDone(c.opts.Log).Info("binlogsync.Canal.clearTableCacheOnDDLStmt")
-	if db, tbl := extractTableFromQueryEvent(schema, query); tbl != "" {
-		c.ClearTableCache(db, tbl)
-		if c.opts.Log.IsInfo() {
-			c.opts.Log.Info("[binlogsync] Table structure changed, clear table cache",
-				log.String("database", db), log.String("table", tbl), log.String("query", string(query)))
-		}
-	}
-}
-
 func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
 	if c.syncer == nil {
 		return errors.AlreadyClosed.Newf("[binlogsync] Canal already closed and myreplicator.BinlogSyncer is nil")
@@ -72,7 +28,13 @@ func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
 		c.opts.Log.Info("[binlogsync] Start syncing of binlog", log.Stringer("position", pos))
 	}
 
-	s, err := c.syncer.StartSync(pos)
+	var s *myreplicator.BinlogStreamer
+	var err error
+	if c.gtidSet != nil {
+		s, err = c.syncer.StartSyncGTID(c.gtidSet.Clone())
+	} else {
+		s, err = c.syncer.StartSync(pos)
+	}
 	if err != nil {
 		return errors.Fatal.Newf("[binlogsync] Start sync replication at %s error %v", pos, err)
 	}
@@ -94,7 +56,9 @@ func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
 		timeout = time.Second
 
 		//next binlog pos
+		beginPos := pos
 		pos.Position = uint(ev.Header.LogPos)
+		evTime := time.Unix(int64(ev.Header.Timestamp), 0)
 
 		switch e := ev.Event.(type) {
 		case *myreplicator.RotateEvent:
@@ -116,7 +80,7 @@ func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
 			// we only focus row based event.
 			// NotFound errors get ignores. For example table has been deleted
 			// and an old event pops in.
-			if err = c.handleRowsEvent(ctxArg, ev); err != nil {
+			if err = c.handleRowsEvent(ctxArg, ev, beginPos); err != nil {
 				isNotFound := errors.Is(err, errors.NotFound)
 				if c.opts.Log.IsInfo() {
 					c.opts.Log.Info("[binlogsync] Rotate binlog to a new position", log.Err(err), log.Stringer("position", pos), log.Bool("ignore_not_found_error", isNotFound))
@@ -131,14 +95,23 @@ func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
 			// if e.GSet != nil {
 			// 	c.master.UpdateGTIDSet(e.GSet)
 			// }
-
-			// call event OnXID(pos)
+			if err := c.processTransactionHandler(ctxArg, c.txc.commit(pos, evTime, e.XID)); err != nil {
+				return errors.WithStack(err)
+			}
 
 		case *myreplicator.MariadbGTIDEvent:
-			// call event OnGTID(gtid)
+			// A MariaDB GTID event replaces the BEGIN query event.
+			c.pendingGTID = e.GTID.String()
+			c.txc.setGTID(c.pendingGTID)
+			if e.Flags&myreplicator.MariadbGTIDFlagStandalone == 0 {
+				c.txc.begin(beginPos)
+			}
+			continue
 
 		case *myreplicator.GTIDEvent:
-			// call event OnGTID(gtid)
+			c.pendingGTID = e.GTIDNext()
+			c.txc.setGTID(c.pendingGTID)
+			continue
 
 		case *myreplicator.QueryEvent:
 			// TODO implement GTID set but review if it makes sense to import siddontang/go-mysql/mysql
@@ -146,11 +119,37 @@ func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
 			// 	c.master.UpdateGTIDSet(e.GSet)
 			// }
 
-			// handle alert table query
-			c.clearTableCacheOnDDLStmt(e.Schema, e.Query)
-
-			// For now really necessary.
-			// TODO: call two more event handlers: on OnTableChanged(db,table) and OnDDL(pos, e)
+			switch kind, xaID := parseTxQuery(e.Query); kind {
+			case txQueryBegin:
+				if !c.txc.inTransaction() {
+					c.txc.begin(beginPos)
+				}
+				continue
+			case txQueryCommit:
+				if err := c.processTransactionHandler(ctxArg, c.txc.commit(pos, evTime, 0)); err != nil {
+					return errors.WithStack(err)
+				}
+			case txQueryRollback:
+				c.txc.rollback()
+			case txQueryXAStart:
+				c.txc.xaStart(beginPos, xaID)
+				continue
+			case txQueryXAEnd:
+				c.txc.xaEnd(xaID)
+			case txQueryXACommit:
+				if err := c.processTransactionHandler(ctxArg, c.txc.xaCommit(pos, evTime, xaID)); err != nil {
+					return errors.WithStack(err)
+				}
+			case txQueryXARollback:
+				c.txc.xaRollback(xaID)
+			default:
+				// DDL statements change the table structure, so the cached
+				// tables must be reloaded.
+				if err := c.handleDDL(ctxArg, e.Schema, e.Query, pos, evTime); err != nil {
+					return errors.WithStack(err)
+				}
+				c.txc.endStatement()
+			}
 
 			// save master position, so no continue
 		case
@@ -162,7 +161,15 @@ func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
 			continue
 		}
 
-		if err := c.masterSave(pos.File, pos.Position); err != nil {
+		if c.txc.inTransaction() {
+			// Saving the position within a transaction would resume the
+			// sync in the middle of the transaction.
+			continue
+		}
+		if err := c.addPendingGTID(); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := c.masterSave(ctxArg, pos, false); err != nil {
 			c.opts.Log.Info("[binlogsync] startSyncBinlog: Failed to save master position", log.Err(err), log.Stringer("position", pos))
 		}
 	}
@@ -170,7 +177,8 @@ func (c *Canal) startSyncBinlog(ctxArg context.Context) error {
 
 // handleRowsEvent handles an event on the rows and calls all registered rows
 // event handler. can return different error behaviours.
-func (c *Canal) handleRowsEvent(ctx context.Context, e *myreplicator.BinlogEvent) error {
+// Argument pos contains the binlog position where the event starts.
+func (c *Canal) handleRowsEvent(ctx context.Context, e *myreplicator.BinlogEvent, pos ddl.MasterStatus) error {
 	defer log.WhenDone(c.opts.Log).Info("binlogsync.Canal.handleRowsEvent")
 	ev, ok := e.Event.(*myreplicator.RowsEvent)
 	if !ok {
@@ -215,7 +223,30 @@ func (c *Canal) handleRowsEvent(ctx context.Context, e *myreplicator.BinlogEvent
 	default:
 		return errors.NotSupported.Newf("[binlogsync] EventType %v not yet supported. Table %q.%q", e.Header.EventType, c.dsn.DBName, table)
 	}
-	return c.processRowsEventHandler(ctx, a, t, ev.Rows)
+	evTime := time.Unix(int64(e.Header.Timestamp), 0)
+	evPos := pos
+	evPos.Position = uint(e.Header.LogPos)
+	evCtx := withEventInfo(ctx, EventInfo{
+		Schema:    schemaName,
+		Position:  evPos,
+		GTID:      c.txc.currentGTID(),
+		Timestamp: evTime,
+	})
+	if err := c.processRowsEventHandler(evCtx, a, t, ev.Rows); err != nil {
+		return errors.WithStack(err)
+	}
+	if !c.hasTransactionHandlers() {
+		return nil
+	}
+	tx := c.txc.add(pos, evTime, TransactionRows{
+		Action: a,
+		Table:  t,
+		Rows:   ev.Rows,
+	})
+	if tx != nil {
+		tx.Position = evPos
+	}
+	return errors.WithStack(c.processTransactionHandler(ctx, tx))
 }
 
 func (c *Canal) GetMasterPos() (ms ddl.MasterStatus, err error) {
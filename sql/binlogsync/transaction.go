@@ -0,0 +1,287 @@
+package binlogsync
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"golang.org/x/sync/errgroup"
+)
+
+// TransactionHandler gets called once per committed transaction with all row
+// events of that transaction. Rolled back transactions never reach the
+// handler. Row events which are not part of a transaction, for example from
+// non-transactional storage engines without BEGIN, get delivered as a
+// transaction with a single event.
+type TransactionHandler interface {
+	// Commit handles a committed transaction. If it returns an error behaviour
+	// of "Interrupted", the canal type will stop the syncer. The Commit
+	// function will run in its own Goroutine. The provided argument must only
+	// be used for reading.
+	Commit(ctx context.Context, tx *Transaction) error
+	// String returns the name of the handler
+	String() string
+}
+
+// TransactionRows contains the rows of one rows event. For the update action
+// the rows alternate between the before and after image.
+type TransactionRows struct {
+	// Action is one of the constants UpdateAction, InsertAction or
+	// DeleteAction.
+	Action string
+	Table  *ddl.Table
+	Rows   [][]interface{}
+}
+
+// Transaction contains all row events between BEGIN and COMMIT, respectively
+// the XID event.
+type Transaction struct {
+	// GTID contains the global transaction ID of the transaction, if enabled
+	// on the server. The format for MySQL is `source_id:transaction_id` and for
+	// MariaDB `domain_id-server_id-sequence_number`.
+	GTID string
+	// XID contains the transaction ID of the storage engine. Zero for
+	// transactions committed with a COMMIT query event.
+	XID uint64
+	// XAID contains the ID of an XA transaction as written in the binary log,
+	// e.g. `X'7831',X'',1`. Empty for non-XA transactions.
+	XAID string
+	// BeginPosition contains the binlog position where the transaction has
+	// been started.
+	BeginPosition ddl.MasterStatus
+	// Position contains the binlog position after the commit of the
+	// transaction. A sync can resume from this position.
+	Position ddl.MasterStatus
+	// Timestamp when the transaction has been committed on the master.
+	Timestamp time.Time
+	Events    []TransactionRows
+}
+
+// RegisterTransactionHandler adds a new transaction handler to the internal
+// list. A transaction handler gets called for all allowed tables.
+func (c *Canal) RegisterTransactionHandler(h ...TransactionHandler) {
+	c.rsMu.Lock()
+	defer c.rsMu.Unlock()
+	c.txHandlers = append(c.txHandlers, h...)
+}
+
+func (c *Canal) hasTransactionHandlers() bool {
+	c.rsMu.RLock()
+	defer c.rsMu.RUnlock()
+	return len(c.txHandlers) > 0
+}
+
+func (c *Canal) processTransactionHandler(ctx context.Context, tx *Transaction) error {
+	if tx == nil || len(tx.Events) == 0 {
+		return nil
+	}
+	c.rsMu.RLock()
+	defer c.rsMu.RUnlock()
+
+	erg, ctx := errgroup.WithContext(ctx)
+	for _, h := range c.txHandlers {
+		h := h
+		erg.Go(func() error {
+			if err := h.Commit(ctx, tx); err != nil {
+				isInterr := errors.Is(err, errors.Interrupted)
+				c.opts.Log.Info("binlogsync.Canal.processTransactionHandler.Go.Commit.error", log.Err(err), log.Stringer("handler_name", h),
+					log.Bool("is_interrupted", isInterr), log.String("gtid", tx.GTID), log.Stringer("position", tx.Position))
+				if isInterr {
+					return errors.WithStack(err)
+				}
+			}
+			return nil
+		})
+	}
+	return errors.WithStack(erg.Wait())
+}
+
+// Transaction control statements as found in QueryEvents.
+const (
+	txQueryNone uint8 = iota
+	txQueryBegin
+	txQueryCommit
+	txQueryRollback
+	txQueryXAStart
+	txQueryXAEnd
+	txQueryXACommit
+	txQueryXARollback
+)
+
+var (
+	txPrefixBegin      = []byte("BEGIN")
+	txPrefixCommit     = []byte("COMMIT")
+	txPrefixRollback   = []byte("ROLLBACK")
+	txPrefixXAStart    = []byte("XA START ")
+	txPrefixXABegin    = []byte("XA BEGIN ")
+	txPrefixXAEnd      = []byte("XA END ")
+	txPrefixXACommit   = []byte("XA COMMIT ")
+	txPrefixXARollback = []byte("XA ROLLBACK ")
+	txSuffixOnePhase   = []byte(" ONE PHASE")
+)
+
+func hasPrefixFold(s, prefix []byte) bool {
+	return len(s) >= len(prefix) && bytes.EqualFold(s[:len(prefix)], prefix)
+}
+
+// parseTxQuery detects transaction control statements. For XA statements the
+// XA ID gets returned.
+func parseTxQuery(query []byte) (kind uint8, xaID string) {
+	query = bytes.TrimSpace(query)
+	xid := func(prefix []byte) string {
+		q := bytes.TrimSpace(query[len(prefix):])
+		if l := len(q) - len(txSuffixOnePhase); l > 0 && bytes.EqualFold(q[l:], txSuffixOnePhase) {
+			q = q[:l]
+		}
+		return string(bytes.TrimSpace(q))
+	}
+	switch {
+	case hasPrefixFold(query, txPrefixXAStart):
+		return txQueryXAStart, xid(txPrefixXAStart)
+	case hasPrefixFold(query, txPrefixXABegin):
+		return txQueryXAStart, xid(txPrefixXABegin)
+	case hasPrefixFold(query, txPrefixXAEnd):
+		return txQueryXAEnd, xid(txPrefixXAEnd)
+	case hasPrefixFold(query, txPrefixXACommit):
+		return txQueryXACommit, xid(txPrefixXACommit)
+	case hasPrefixFold(query, txPrefixXARollback):
+		return txQueryXARollback, xid(txPrefixXARollback)
+	case bytes.EqualFold(query, txPrefixBegin):
+		return txQueryBegin, ""
+	case bytes.EqualFold(query, txPrefixCommit):
+		return txQueryCommit, ""
+	case bytes.EqualFold(query, txPrefixRollback):
+		return txQueryRollback, ""
+	}
+	return txQueryNone, ""
+}
+
+// txCollector buffers the row events of a transaction. It gets only used by
+// the syncer goroutine and needs no locking.
+type txCollector struct {
+	current *Transaction
+	// gtid gets set by a GTID event before the transaction starts.
+	gtid string
+	// prepared contains XA transactions which have been ended but not yet
+	// committed or rolled back. The map key contains the XA ID.
+	prepared map[string]*Transaction
+}
+
+// inTransaction returns true if a transaction has been started and not yet
+// committed or rolled back.
+func (tc *txCollector) inTransaction() bool {
+	return tc.current != nil
+}
+
+// currentGTID returns the GTID of the current transaction or the GTID which
+// has been announced for the next rows event.
+func (tc *txCollector) currentGTID() string {
+	if tc.current != nil {
+		return tc.current.GTID
+	}
+	return tc.gtid
+}
+
+func (tc *txCollector) setGTID(gtid string) {
+	tc.gtid = gtid
+}
+
+// endStatement clears the announced GTID after a query event outside of a
+// transaction, for example a DDL statement, which consumes its own GTID.
+// Otherwise the GTID would get attached to the next transaction.
+func (tc *txCollector) endStatement() {
+	if tc.current == nil {
+		tc.gtid = ""
+	}
+}
+
+func (tc *txCollector) begin(pos ddl.MasterStatus) {
+	tc.current = &Transaction{
+		GTID:          tc.gtid,
+		BeginPosition: pos,
+	}
+	tc.gtid = ""
+}
+
+// add appends a rows event to the current transaction. Returns a transaction
+// if there is no current transaction, which then must be handled immediately.
+func (tc *txCollector) add(pos ddl.MasterStatus, ts time.Time, tr TransactionRows) *Transaction {
+	if tc.current == nil {
+		tx := &Transaction{
+			GTID:          tc.gtid,
+			BeginPosition: pos,
+			Position:      pos,
+			Timestamp:     ts,
+			Events:        []TransactionRows{tr},
+		}
+		tc.gtid = ""
+		return tx
+	}
+	tc.current.Events = append(tc.current.Events, tr)
+	return nil
+}
+
+// commit finishes the current transaction and returns it. Returns nil if
+// there is no current transaction.
+func (tc *txCollector) commit(pos ddl.MasterStatus, ts time.Time, xid uint64) *Transaction {
+	tx := tc.current
+	tc.current = nil
+	if tx == nil {
+		return nil
+	}
+	tx.XID = xid
+	tx.Position = pos
+	tx.Timestamp = ts
+	return tx
+}
+
+func (tc *txCollector) rollback() {
+	tc.current = nil
+	tc.gtid = ""
+}
+
+func (tc *txCollector) xaStart(pos ddl.MasterStatus, xaID string) {
+	tc.begin(pos)
+	tc.current.XAID = xaID
+}
+
+// xaEnd moves the current XA transaction into the prepared state. It waits
+// there for XA COMMIT or XA ROLLBACK.
+func (tc *txCollector) xaEnd(xaID string) {
+	tx := tc.current
+	tc.current = nil
+	if tx == nil {
+		return
+	}
+	if tc.prepared == nil {
+		tc.prepared = make(map[string]*Transaction)
+	}
+	tc.prepared[xaID] = tx
+}
+
+// xaCommit returns the prepared XA transaction. Returns nil if the prepared
+// transaction is unknown, for example it has been prepared before the sync
+// started.
+func (tc *txCollector) xaCommit(pos ddl.MasterStatus, ts time.Time, xaID string) *Transaction {
+	tx, ok := tc.prepared[xaID]
+	if !ok {
+		return nil
+	}
+	delete(tc.prepared, xaID)
+	tx.Position = pos
+	tx.Timestamp = ts
+	if tc.gtid != "" {
+		// the GTID of the XA COMMIT statement belongs to the final commit.
+		tx.GTID = tc.gtid
+		tc.gtid = ""
+	}
+	return tx
+}
+
+func (tc *txCollector) xaRollback(xaID string) {
+	delete(tc.prepared, xaID)
+	tc.gtid = ""
+}
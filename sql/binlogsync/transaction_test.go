@@ -0,0 +1,197 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogsync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestParseTxQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query    string
+		wantKind uint8
+		wantXAID string
+	}{
+		{"BEGIN", txQueryBegin, ""},
+		{" begin ", txQueryBegin, ""},
+		{"COMMIT", txQueryCommit, ""},
+		{"ROLLBACK", txQueryRollback, ""},
+		{"XA START X'7831',X'',1", txQueryXAStart, "X'7831',X'',1"},
+		{"XA BEGIN 'x1'", txQueryXAStart, "'x1'"},
+		{"XA END X'7831',X'',1", txQueryXAEnd, "X'7831',X'',1"},
+		{"XA COMMIT X'7831',X'',1", txQueryXACommit, "X'7831',X'',1"},
+		{"XA COMMIT X'7831',X'',1 ONE PHASE", txQueryXACommit, "X'7831',X'',1"},
+		{"xa rollback X'7831',X'',1", txQueryXARollback, "X'7831',X'',1"},
+		{"BEGIN NOT ATOMIC SELECT 1; END", txQueryNone, ""},
+		{"ALTER TABLE `sales_order` ADD COLUMN `x` int", txQueryNone, ""},
+	}
+	for _, test := range tests {
+		kind, xaID := parseTxQuery([]byte(test.query))
+		assert.Exactly(t, test.wantKind, kind, "%q", test.query)
+		assert.Exactly(t, test.wantXAID, xaID, "%q", test.query)
+	}
+}
+
+func TestTxCollector(t *testing.T) {
+	t.Parallel()
+
+	tbl := ddl.NewTable("sales_order")
+	pos := func(p uint) ddl.MasterStatus { return ddl.MasterStatus{File: "mysql-bin.000001", Position: p} }
+	ts := time.Unix(1577836800, 0)
+	rows := func(id int64) TransactionRows {
+		return TransactionRows{Action: InsertAction, Table: tbl, Rows: [][]interface{}{{id}}}
+	}
+
+	t.Run("BEGIN COMMIT", func(t *testing.T) {
+		var tc txCollector
+		tc.setGTID("3E11FA47-71CA-11E1-9E33-C80AA9429562:23")
+		tc.begin(pos(100))
+		assert.True(t, tc.inTransaction())
+		assert.Nil(t, tc.add(pos(200), ts, rows(1)))
+		assert.Nil(t, tc.add(pos(300), ts, rows(2)))
+		tx := tc.commit(pos(400), ts, 4711)
+		assert.False(t, tc.inTransaction())
+		assert.Exactly(t, &Transaction{
+			GTID:          "3E11FA47-71CA-11E1-9E33-C80AA9429562:23",
+			XID:           4711,
+			BeginPosition: pos(100),
+			Position:      pos(400),
+			Timestamp:     ts,
+			Events:        []TransactionRows{rows(1), rows(2)},
+		}, tx)
+		assert.Nil(t, tc.commit(pos(500), ts, 1), "no open transaction")
+	})
+
+	t.Run("GTID of a DDL statement", func(t *testing.T) {
+		var tc txCollector
+		tc.setGTID("3E11FA47-71CA-11E1-9E33-C80AA9429562:23")
+		tc.endStatement() // ALTER TABLE outside of a transaction
+		assert.Exactly(t, "", tc.currentGTID())
+
+		tc.setGTID("3E11FA47-71CA-11E1-9E33-C80AA9429562:24")
+		tc.begin(pos(100))
+		tc.endStatement() // a statement within the transaction keeps the GTID
+		tx := tc.commit(pos(200), ts, 1)
+		assert.Exactly(t, "3E11FA47-71CA-11E1-9E33-C80AA9429562:24", tx.GTID)
+
+		tc.begin(pos(300))
+		tx = tc.commit(pos(400), ts, 2)
+		assert.Exactly(t, "", tx.GTID)
+	})
+
+	t.Run("ROLLBACK", func(t *testing.T) {
+		var tc txCollector
+		tc.begin(pos(100))
+		assert.Nil(t, tc.add(pos(200), ts, rows(1)))
+		tc.rollback()
+		assert.False(t, tc.inTransaction())
+		assert.Nil(t, tc.commit(pos(300), ts, 0))
+	})
+
+	t.Run("without transaction", func(t *testing.T) {
+		var tc txCollector
+		tx := tc.add(pos(200), ts, rows(1))
+		assert.NotNil(t, tx)
+		assert.Len(t, tx.Events, 1)
+		assert.False(t, tc.inTransaction())
+	})
+
+	t.Run("XA COMMIT", func(t *testing.T) {
+		var tc txCollector
+		tc.setGTID("0-1-10")
+		tc.xaStart(pos(100), "X'31'")
+		assert.Nil(t, tc.add(pos(200), ts, rows(1)))
+		tc.xaEnd("X'31'")
+		assert.False(t, tc.inTransaction())
+
+		// another transaction between XA PREPARE and XA COMMIT
+		tc.begin(pos(300))
+		assert.Nil(t, tc.add(pos(400), ts, rows(2)))
+		assert.Len(t, tc.commit(pos(500), ts, 3).Events, 1)
+
+		assert.Nil(t, tc.xaCommit(pos(600), ts, "X'32'"), "unknown XA ID")
+		tc.setGTID("0-1-12")
+		tx := tc.xaCommit(pos(600), ts, "X'31'")
+		assert.Exactly(t, "X'31'", tx.XAID)
+		assert.Exactly(t, "0-1-12", tx.GTID)
+		assert.Exactly(t, []TransactionRows{rows(1)}, tx.Events)
+		assert.Exactly(t, pos(600), tx.Position)
+		assert.Nil(t, tc.xaCommit(pos(700), ts, "X'31'"), "already committed")
+	})
+
+	t.Run("XA ROLLBACK", func(t *testing.T) {
+		var tc txCollector
+		tc.xaStart(pos(100), "X'31'")
+		assert.Nil(t, tc.add(pos(200), ts, rows(1)))
+		tc.xaEnd("X'31'")
+		tc.xaRollback("X'31'")
+		assert.Nil(t, tc.xaCommit(pos(300), ts, "X'31'"))
+	})
+}
+
+type testTxHandler struct {
+	mu  sync.Mutex
+	txs []*Transaction
+	err error
+}
+
+func (h *testTxHandler) Commit(_ context.Context, tx *Transaction) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.txs = append(h.txs, tx)
+	return h.err
+}
+
+func (h *testTxHandler) String() string { return "testTxHandler" }
+
+func TestCanal_ProcessTransactionHandler(t *testing.T) {
+	t.Parallel()
+
+	tx := &Transaction{
+		Events: []TransactionRows{{Action: DeleteAction, Table: ddl.NewTable("sales_order")}},
+	}
+
+	t.Run("success and ignored error", func(t *testing.T) {
+		c := &Canal{opts: Options{Log: log.BlackHole{}}}
+		assert.False(t, c.hasTransactionHandlers())
+		h1 := &testTxHandler{}
+		h2 := &testTxHandler{err: errors.NotValid.Newf("ignored")}
+		c.RegisterTransactionHandler(h1, h2)
+		assert.True(t, c.hasTransactionHandlers())
+
+		assert.NoError(t, c.processTransactionHandler(context.Background(), tx))
+		assert.NoError(t, c.processTransactionHandler(context.Background(), nil))
+		assert.NoError(t, c.processTransactionHandler(context.Background(), &Transaction{}))
+		assert.Exactly(t, []*Transaction{tx}, h1.txs)
+		assert.Exactly(t, []*Transaction{tx}, h2.txs)
+	})
+
+	t.Run("interrupted", func(t *testing.T) {
+		c := &Canal{opts: Options{Log: log.BlackHole{}}}
+		c.RegisterTransactionHandler(&testTxHandler{err: errors.Interrupted.Newf("stop")})
+		err := c.processTransactionHandler(context.Background(), tx)
+		assert.True(t, errors.Interrupted.Match(err), "%+v", err)
+	})
+}
@@ -17,7 +17,6 @@ package ddl
 import (
 	"bytes"
 	"context"
-	"database/sql"
 	"fmt"
 	"strings"
 
@@ -77,16 +76,18 @@ type Column struct {
 
 // TODO check DB flavor: if MySQL or MariaDB, first one does not have column IS_GENERATED
 const (
-	selBaseSelect = `SELECT
+	selBaseSelectColumns = `SELECT
 	TABLE_NAME, COLUMN_NAME, ORDINAL_POSITION, COLUMN_DEFAULT, IS_NULLABLE,
 		DATA_TYPE, CHARACTER_MAXIMUM_LENGTH, NUMERIC_PRECISION, NUMERIC_SCALE,
 		COLUMN_TYPE, COLUMN_KEY, EXTRA, COLUMN_COMMENT, IS_GENERATED, GENERATION_EXPRESSION
-	 FROM information_schema.COLUMNS WHERE TABLE_SCHEMA=DATABASE()`
+	 FROM information_schema.COLUMNS WHERE `
+	selBaseSelect = selBaseSelectColumns + `TABLE_SCHEMA=DATABASE()`
 	// DMLLoadColumns specifies the data manipulation language for retrieving
 	// all columns in the current database for a specific table. TABLE_NAME is
 	// always lower case.
-	selTablesColumns    = selBaseSelect + ` AND TABLE_NAME IN ? ORDER BY TABLE_NAME, ORDINAL_POSITION`
-	selAllTablesColumns = selBaseSelect + ` ORDER BY TABLE_NAME, ORDINAL_POSITION`
+	selTablesColumns         = selBaseSelect + ` AND TABLE_NAME IN ? ORDER BY TABLE_NAME, ORDINAL_POSITION`
+	selAllTablesColumns      = selBaseSelect + ` ORDER BY TABLE_NAME, ORDINAL_POSITION`
+	selTablesColumnsBySchema = selBaseSelectColumns + `TABLE_SCHEMA=? AND TABLE_NAME IN ? ORDER BY TABLE_NAME, ORDINAL_POSITION`
 )
 
 // LoadColumns returns all columns from a list of table names in the current
@@ -94,25 +95,31 @@ const (
 // table is not available. All columns from all tables gets selected when you
 // don't provide the argument `tables`.
 func LoadColumns(ctx context.Context, db dml.Querier, tables ...string) (map[string]Columns, error) {
-	var rows *sql.Rows
-
 	if len(tables) == 0 {
-		var err error
-		rows, err = db.QueryContext(ctx, selAllTablesColumns)
-		if err != nil {
-			return nil, errors.Wrapf(err, "[ddl] LoadColumns QueryContext for tables %v", tables)
-		}
-	} else {
-		sqlStr, _, err := dml.Interpolate(selTablesColumns).Strs(tables...).ToSQL()
-		if err != nil {
-			return nil, errors.Wrapf(err, "[ddl] LoadColumns dml.ExpandPlaceHolders for tables %v", tables)
-		}
-		rows, err = db.QueryContext(ctx, sqlStr)
-		if err != nil {
-			return nil, errors.Wrapf(err, "[ddl] LoadColumns QueryContext for tables %v with WHERE clause", tables)
-		}
+		return loadColumns(ctx, db, selAllTablesColumns, tables)
+	}
+	sqlStr, _, err := dml.Interpolate(selTablesColumns).Strs(tables...).ToSQL()
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadColumns dml.ExpandPlaceHolders for tables %v", tables)
+	}
+	return loadColumns(ctx, db, sqlStr, tables)
+}
+
+// loadColumnsBySchema same as LoadColumns but loads the tables of database
+// `schema` instead of the current database.
+func loadColumnsBySchema(ctx context.Context, db dml.Querier, schema string, tables ...string) (map[string]Columns, error) {
+	sqlStr, _, err := dml.Interpolate(selTablesColumnsBySchema).Str(schema).Strs(tables...).ToSQL()
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadColumns dml.ExpandPlaceHolders for tables %v in schema %q", tables, schema)
+	}
+	return loadColumns(ctx, db, sqlStr, tables)
+}
+
+func loadColumns(ctx context.Context, db dml.Querier, sqlStr string, tables []string) (_ map[string]Columns, err error) {
+	rows, err := db.QueryContext(ctx, sqlStr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadColumns QueryContext for tables %v", tables)
 	}
-	var err error
 	defer func() {
 		// Not testable with the sqlmock package :-(
 		if err2 := rows.Close(); err2 != nil && err == nil {
@@ -550,6 +557,18 @@ func (c *Column) IsBlobDataType() bool {
 		strings.Contains(dt, "binary") || strings.Contains(dt, "json")
 }
 
+// IsSpatialDataType returns true if the columns data type is one of the
+// spatial types geometry, point, linestring, polygon or their multi and
+// collection variants.
+func (c *Column) IsSpatialDataType() bool {
+	switch strings.ToLower(c.DataType) {
+	case "geometry", "point", "linestring", "polygon", "multipoint", "multilinestring",
+		"multipolygon", "geometrycollection", "geomcollection":
+		return true
+	}
+	return false
+}
+
 // columnTypes looks ugly but ... refactor later.
 // the slices in this struct are only for reading. no mutex protection required.
 // which partial column name triggers a specific type in Go or MySQL.
@@ -573,3 +592,92 @@ var columnTypes = struct {
 		slices.String{"value", "price", "cost", "msrp"},
 	},
 }
+
+// dataType returns the DataType or extracts the data type from the ColumnType.
+func (c *Column) dataType() string {
+	if c.DataType != "" {
+		return strings.ToLower(c.DataType)
+	}
+	dt := c.ColumnType
+	if pos := strings.IndexAny(dt, "( "); pos > 0 {
+		dt = dt[:pos]
+	}
+	return strings.ToLower(dt)
+}
+
+// isNumericDataType returns true if the default value of the column does not
+// need to be quoted.
+func (c *Column) isNumericDataType() bool {
+	switch c.dataType() {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint", "decimal", "numeric", "float", "double", "real", "year":
+		return true
+	}
+	return false
+}
+
+// defaultSQL returns the default value quoted for a SQL statement. It unifies
+// the different representations of MySQL and MariaDB. The latter one returns
+// quoted strings and the string NULL.
+func (c *Column) defaultSQL() (string, bool) {
+	d := c.Default.String
+	switch {
+	case !c.Default.Valid, d == "NULL" && c.IsNull():
+		return "", false
+	case strings.HasPrefix(strings.ToUpper(d), columnCurrentTimestamp):
+		return columnCurrentTimestamp + strings.TrimPrefix(d[len(columnCurrentTimestamp):], "()"), true
+	case strings.HasPrefix(d, "'"), strings.HasPrefix(d, "b'"), strings.HasPrefix(d, "x'"):
+		return d, true
+	case c.isNumericDataType() && d != "":
+		return d, true
+	}
+	return "'" + strings.Replace(d, "'", "''", -1) + "'", true
+}
+
+// extraSQL returns the EXTRA field but removes all markers for generated
+// columns because those get written by writeDefinition.
+func (c *Column) extraSQL() string {
+	e := strings.ToUpper(c.Extra)
+	for _, gen := range [...]string{"DEFAULT_GENERATED", "VIRTUAL GENERATED", "STORED GENERATED", "PERSISTENT GENERATED"} {
+		e = strings.Replace(e, gen, "", -1)
+	}
+	e = strings.Replace(e, columnCurrentTimestamp+"()", columnCurrentTimestamp, -1)
+	return strings.Join(strings.Fields(e), " ")
+}
+
+// writeDefinition writes the column definition as used in CREATE TABLE or
+// ALTER TABLE statements. Columns used for system versioning can't be written.
+func (c *Column) writeDefinition(buf *bytes.Buffer) {
+	buf.WriteString(dml.Quoter.Name(c.Field))
+	buf.WriteByte(' ')
+	buf.WriteString(c.ColumnType)
+
+	if c.IsGenerated() {
+		buf.WriteString(" GENERATED ALWAYS AS (")
+		buf.WriteString(c.GenerationExpression.String)
+		buf.WriteString(") ")
+		if e := strings.ToUpper(c.Extra); strings.Contains(e, "STORED") || strings.Contains(e, "PERSISTENT") {
+			buf.WriteString("STORED")
+		} else {
+			buf.WriteString("VIRTUAL")
+		}
+	} else {
+		if c.IsNull() {
+			buf.WriteString(" NULL")
+		} else {
+			buf.WriteString(" NOT NULL")
+		}
+		if d, ok := c.defaultSQL(); ok {
+			buf.WriteString(" DEFAULT ")
+			buf.WriteString(d)
+		}
+		if e := c.extraSQL(); e != "" {
+			buf.WriteByte(' ')
+			buf.WriteString(e)
+		}
+	}
+	if c.Comment != "" {
+		buf.WriteString(" COMMENT '")
+		buf.WriteString(strings.Replace(c.Comment, "'", "''", -1))
+		buf.WriteByte('\'')
+	}
+}
@@ -261,3 +261,10 @@ func TestColumn_IsBlobDataType(t *testing.T) {
 	assert.True(t, adminUserColumns.ByField("extra").IsBlobDataType(), "extra")
 	assert.True(t, adminUserColumns.ByField("rp_token").IsBlobDataType(), "rp_token")
 }
+
+func TestColumn_IsSpatialDataType(t *testing.T) {
+	assert.False(t, adminUserColumns.ByField("extra").IsSpatialDataType(), "extra")
+	assert.True(t, (&ddl.Column{DataType: "point"}).IsSpatialDataType(), "point")
+	assert.True(t, (&ddl.Column{DataType: "MULTIPOLYGON"}).IsSpatialDataType(), "MULTIPOLYGON")
+	assert.True(t, (&ddl.Column{DataType: "geomcollection"}).IsSpatialDataType(), "geomcollection")
+}
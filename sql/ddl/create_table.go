@@ -0,0 +1,400 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/storage/null"
+)
+
+var regexpCreateTableName = regexp.MustCompile("(?i)CREATE\\s+TABLE\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?((?:`[^`]+`|[0-9a-zA-Z$_]+)(?:\\.(?:`[^`]+`|[0-9a-zA-Z$_]+))?)\\s*\\(")
+
+// ParseCreateTable parses all CREATE TABLE statements found in argument
+// `stmts`, for example the content of a SQL dump file, and returns the tables
+// with their columns, indexes and foreign keys. All other statements get
+// ignored. The parser understands the format as returned by SHOW CREATE TABLE.
+// The returned tables can be compared with the database via DiffTable or
+// Tables.SchemaDiff.
+func ParseCreateTable(stmts string) ([]*Table, error) {
+	var tables []*Table
+	for _, loc := range regexpCreateTableName.FindAllStringSubmatchIndex(stmts, -1) {
+		body, err := balancedParenthesis(stmts[loc[1]-1:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ddl] ParseCreateTable for statement %q", stmts[loc[0]:loc[1]])
+		}
+		t := &Table{
+			Indexes:     Indexes{},
+			ForeignKeys: ForeignKeys{},
+		}
+		t.Name = unquoteIdentifier(stmts[loc[2]:loc[3]])
+		if pos := strings.IndexByte(t.Name, '.'); pos > 0 {
+			t.Schema, t.Name = t.Name[:pos], t.Name[pos+1:]
+		}
+		for _, def := range splitTopLevel(body, ',') {
+			if err := t.parseCreateDefinition(def); err != nil {
+				return nil, errors.Wrapf(err, "[ddl] ParseCreateTable for table %q", t.Name)
+			}
+		}
+		t.applyIndexKeys()
+		tables = append(tables, t.update())
+	}
+	return tables, nil
+}
+
+func unquoteIdentifier(s string) string {
+	return strings.Replace(strings.TrimSpace(s), "`", "", -1)
+}
+
+// balancedParenthesis returns the content between the first opening
+// parenthesis in `s` and its matching closing parenthesis.
+func balancedParenthesis(s string) (string, error) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return s[strings.IndexByte(s, '(')+1 : i], nil
+			}
+		}
+	}
+	return "", errors.NotValid.Newf("[ddl] Unbalanced parenthesis or quotes")
+}
+
+// splitTopLevel splits `s` at `sep` but not within quotes or parenthesis. If
+// sep is a space, all white space characters act as separator. Empty parts get
+// dropped.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	isSep := func(c byte) bool {
+		if sep == ' ' {
+			return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+		}
+		return c == sep
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && isSep(c):
+			if p := strings.TrimSpace(s[start:i]); p != "" {
+				parts = append(parts, p)
+			}
+			start = i + 1
+		}
+	}
+	if p := strings.TrimSpace(s[start:]); p != "" {
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+// indexColumns parses a list of index columns like "(`a`,`b`(10))".
+func indexColumns(s string) []string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "("), ")")
+	cols := splitTopLevel(s, ',')
+	for i, c := range cols {
+		// drop the optional ordering
+		if f := splitTopLevel(c, ' '); len(f) > 0 {
+			c = f[0]
+		}
+		cols[i] = unquoteIdentifier(c)
+	}
+	return cols
+}
+
+func unquoteString(s string) string {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		s = s[1 : len(s)-1]
+		s = strings.Replace(s, "''", "'", -1)
+		s = strings.Replace(s, "\\'", "'", -1)
+	}
+	return s
+}
+
+func (t *Table) parseCreateDefinition(def string) error {
+	tokens := splitTopLevel(def, ' ')
+	upper := func(i int) string {
+		if i < len(tokens) {
+			return strings.ToUpper(tokens[i])
+		}
+		return ""
+	}
+	// cut off the optional CONSTRAINT [symbol]
+	name := ""
+	if upper(0) == "CONSTRAINT" {
+		if u := upper(1); u != "PRIMARY" && u != "UNIQUE" && u != "FOREIGN" && u != "CHECK" {
+			name = unquoteIdentifier(tokens[1])
+			tokens = tokens[2:]
+		} else {
+			tokens = tokens[1:]
+		}
+	}
+
+	switch u0 := upper(0); u0 {
+	case "PRIMARY":
+		for i, tok := range tokens {
+			if strings.HasPrefix(tok, "(") {
+				t.Indexes = append(t.Indexes, &Index{Name: IndexPrimary, Unique: true, Columns: indexColumns(tok)})
+				return t.parseIndexType(tokens[i:])
+			}
+		}
+		return errors.NotValid.Newf("[ddl] Missing columns in primary key definition %q", def)
+	case "UNIQUE", "KEY", "INDEX", "FULLTEXT", "SPATIAL":
+		idx := &Index{Unique: u0 == "UNIQUE"}
+		if u0 == "FULLTEXT" || u0 == "SPATIAL" {
+			idx.Type = u0
+		}
+		i := 1
+		if u := upper(i); u == "KEY" || u == "INDEX" {
+			i++
+		}
+		if i < len(tokens) && !strings.HasPrefix(tokens[i], "(") {
+			idx.Name = unquoteIdentifier(tokens[i])
+			i++
+		}
+		if i >= len(tokens) {
+			return errors.NotValid.Newf("[ddl] Missing columns in index definition %q", def)
+		}
+		idx.Columns = indexColumns(tokens[i])
+		if idx.Name == "" {
+			idx.Name = name
+		}
+		if idx.Name == "" {
+			idx.Name = t.defaultIndexName(idx.Columns[0])
+		}
+		t.Indexes = append(t.Indexes, idx)
+		return t.parseIndexType(tokens[i:])
+	case "FOREIGN":
+		return t.parseForeignKey(name, def, tokens)
+	case "CHECK":
+		return nil
+	}
+	return t.parseColumn(def, tokens)
+}
+
+// defaultIndexName names an unnamed index like MySQL does: after the first
+// column without its prefix length and with a suffix _2, _3, ... if the name
+// has already been taken.
+func (t *Table) defaultIndexName(column string) string {
+	if pos := strings.IndexByte(column, '('); pos > 0 {
+		column = column[:pos]
+	}
+	name := column
+	for i := 2; t.hasIndexName(name); i++ {
+		name = column + "_" + strconv.Itoa(i)
+	}
+	return name
+}
+
+func (t *Table) hasIndexName(name string) bool {
+	if strings.EqualFold(name, IndexPrimary) {
+		return true
+	}
+	for _, idx := range t.Indexes {
+		if strings.EqualFold(idx.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Table) parseIndexType(tokens []string) error {
+	for i, tok := range tokens {
+		if strings.EqualFold(tok, "USING") && i+1 < len(tokens) {
+			t.Indexes[len(t.Indexes)-1].Type = strings.ToUpper(tokens[i+1])
+		}
+	}
+	return nil
+}
+
+// parseForeignKey parses: FOREIGN KEY [index_name] (cols) REFERENCES tbl (cols)
+// [ON DELETE option] [ON UPDATE option]
+func (t *Table) parseForeignKey(name, def string, tokens []string) error {
+	fk := &ForeignKey{Name: name}
+	for i := 0; i < len(tokens); i++ {
+		switch u := strings.ToUpper(tokens[i]); {
+		case u == "REFERENCES" && i+2 < len(tokens):
+			fk.ReferencedTable = unquoteIdentifier(tokens[i+1])
+			fk.ReferencedColumns = indexColumns(tokens[i+2])
+			i += 2
+		case u == "ON" && i+2 < len(tokens):
+			action := strings.ToUpper(tokens[i+2])
+			n := 2
+			if (action == "SET" || action == "NO") && i+3 < len(tokens) {
+				action += " " + strings.ToUpper(tokens[i+3])
+				n = 3
+			}
+			if strings.EqualFold(tokens[i+1], "DELETE") {
+				fk.OnDelete = action
+			} else {
+				fk.OnUpdate = action
+			}
+			i += n
+		case strings.HasPrefix(u, "(") && fk.Columns == nil:
+			fk.Columns = indexColumns(tokens[i])
+		}
+	}
+	if len(fk.Columns) == 0 || fk.ReferencedTable == "" || len(fk.Columns) != len(fk.ReferencedColumns) {
+		return errors.NotValid.Newf("[ddl] Invalid foreign key definition %q", def)
+	}
+	if fk.Name == "" {
+		fk.Name = ForeignKeyName(t.Name, fk.Columns[0], fk.ReferencedTable, fk.ReferencedColumns[0])
+	}
+	t.ForeignKeys = append(t.ForeignKeys, fk)
+	return nil
+}
+
+func (t *Table) parseColumn(def string, tokens []string) error {
+	if len(tokens) < 2 {
+		return errors.NotValid.Newf("[ddl] Invalid column definition %q", def)
+	}
+	c := &Column{
+		Field: unquoteIdentifier(tokens[0]),
+		Pos:   uint64(len(t.Columns) + 1),
+		Null:  columnNull,
+	}
+	typ := tokens[1]
+	if pos := strings.IndexByte(typ, '('); pos > 0 {
+		c.DataType = strings.ToLower(typ[:pos])
+		c.ColumnType = c.DataType + typ[pos:]
+	} else {
+		c.DataType = strings.ToLower(typ)
+		c.ColumnType = c.DataType
+	}
+
+	var extra []string
+	for i := 2; i < len(tokens); i++ {
+		next := func() string {
+			if i+1 < len(tokens) {
+				i++
+				return tokens[i]
+			}
+			return ""
+		}
+		switch u := strings.ToUpper(tokens[i]); u {
+		case "UNSIGNED", "ZEROFILL":
+			c.ColumnType += " " + strings.ToLower(u)
+		case "NOT":
+			if strings.EqualFold(next(), "NULL") {
+				c.Null = "NO"
+			}
+		case "NULL":
+			c.Null = columnNull
+		case "DEFAULT":
+			if d := next(); strings.EqualFold(d, "NULL") {
+				c.Default = null.String{}
+			} else {
+				c.Default = null.MakeString(unquoteString(d))
+			}
+		case "AUTO_INCREMENT":
+			extra = append(extra, columnAutoIncrement)
+		case "ON":
+			if strings.EqualFold(next(), "UPDATE") {
+				extra = append(extra, "on update "+next())
+			}
+		case "COMMENT":
+			c.Comment = unquoteString(next())
+		case "CHARACTER", "CHARSET", "COLLATE":
+			if u == "CHARACTER" {
+				next() // SET
+			}
+			next()
+		case "GENERATED", "ALWAYS":
+		case "AS":
+			c.GenerationExpression = null.MakeString(strings.TrimSuffix(strings.TrimPrefix(next(), "("), ")"))
+			c.Generated = "ALWAYS"
+			extra = append(extra, "VIRTUAL GENERATED")
+		case "VIRTUAL":
+		case "STORED", "PERSISTENT":
+			if len(extra) > 0 {
+				extra[len(extra)-1] = "STORED GENERATED"
+			}
+		case "PRIMARY", "KEY":
+			if u == "PRIMARY" {
+				next() // KEY
+			}
+			c.Null = "NO"
+			t.Indexes = append(t.Indexes, &Index{Name: IndexPrimary, Unique: true, Columns: []string{c.Field}})
+		case "UNIQUE":
+			if i+1 < len(tokens) && strings.EqualFold(tokens[i+1], "KEY") {
+				next()
+			}
+			t.Indexes = append(t.Indexes, &Index{Name: c.Field, Unique: true, Columns: []string{c.Field}})
+		case "INVISIBLE", "VISIBLE", "COLUMN_FORMAT", "STORAGE", "FIXED", "DYNAMIC", "DISK", "MEMORY":
+		default:
+			return errors.NotSupported.Newf("[ddl] Unknown token %q in column definition %q", tokens[i], def)
+		}
+	}
+	c.Extra = strings.Join(extra, " ")
+	t.Columns = append(t.Columns, c)
+	return nil
+}
+
+// applyIndexKeys sets the Key field of the columns as MySQL would report it
+// in information_schema.COLUMNS.
+func (t *Table) applyIndexKeys() {
+	for _, idx := range t.Indexes {
+		if len(idx.Columns) == 0 {
+			continue
+		}
+		switch {
+		case idx.IsPrimary():
+			for _, cn := range idx.Columns {
+				if c := t.Columns.ByField(cn); c.Field != "" {
+					c.Key = columnPrimary
+				}
+			}
+		case idx.Unique && len(idx.Columns) == 1:
+			if c := t.Columns.ByField(idx.Columns[0]); c.Field != "" && c.Key == "" {
+				c.Key = columnUnique
+			}
+		default:
+			if c := t.Columns.ByField(idx.Columns[0]); c.Field != "" && c.Key == "" {
+				c.Key = "MUL"
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestParseCreateTable(t *testing.T) {
+	t.Parallel()
+
+	data, err := ioutil.ReadFile("testdata/testLoadForeignKeys_admin_user.sql")
+	assert.NoError(t, err)
+
+	tbls, err := ddl.ParseCreateTable(string(data))
+	assert.NoError(t, err, "%+v", err)
+	assert.Len(t, tbls, 2)
+
+	au := tbls[0]
+	assert.Exactly(t, "x859admin_user", au.Name)
+	assert.Exactly(t, []string{"user_id", "email", "username"}, au.Columns.FieldNames())
+	assert.Exactly(t, "int(10) unsigned", au.Columns[0].ColumnType)
+	assert.Exactly(t, "NO", au.Columns[0].Null)
+	assert.Exactly(t, "PRI", au.Columns[0].Key)
+	assert.Exactly(t, "auto_increment", au.Columns[0].Extra)
+	assert.Exactly(t, "User ID", au.Columns[0].Comment)
+	assert.Exactly(t, "YES", au.Columns[1].Null)
+	assert.False(t, au.Columns[1].Default.Valid)
+	assert.Exactly(t, "UNI", au.Columns[2].Key)
+	assert.Exactly(t, ddl.Indexes{
+		{Name: "PRIMARY", Unique: true, Columns: []string{"user_id"}},
+		{Name: "ADMIN_USER_USERNAME", Unique: true, Columns: []string{"username"}},
+	}, au.Indexes)
+	assert.Len(t, au.ForeignKeys, 0)
+
+	ap := tbls[1]
+	assert.Exactly(t, "x859admin_passwords", ap.Name)
+	assert.Exactly(t, null.MakeString("0"), ap.Columns.ByField("user_id").Default)
+	assert.Exactly(t, "MUL", ap.Columns.ByField("user_id").Key)
+	assert.Exactly(t, ddl.ForeignKeys{
+		{
+			Name:              "ADMIN_PASSWORDS_USER_ID_ADMIN_USER_USER_ID",
+			Columns:           []string{"user_id"},
+			ReferencedTable:   "x859admin_user",
+			ReferencedColumns: []string{"user_id"},
+			OnDelete:          "CASCADE",
+		},
+	}, ap.ForeignKeys)
+}
+
+func TestParseCreateTable_UnnamedIndexes(t *testing.T) {
+	t.Parallel()
+
+	tbls, err := ddl.ParseCreateTable("CREATE TABLE `a` (\n" +
+		"`sku` varchar(64) NOT NULL,\n" +
+		"`name` varchar(255) NOT NULL,\n" +
+		"KEY (`sku`(10)),\n" +
+		"UNIQUE KEY (`sku`,`name`),\n" +
+		"KEY `name_2` (`name`),\n" +
+		"KEY (`name`),\n" +
+		"KEY (`name`(20))\n" +
+		") ENGINE=InnoDB")
+	assert.NoError(t, err, "%+v", err)
+	assert.Len(t, tbls, 1)
+	assert.Exactly(t, ddl.Indexes{
+		{Name: "sku", Columns: []string{"sku(10)"}},
+		{Name: "sku_2", Unique: true, Columns: []string{"sku", "name"}},
+		{Name: "name_2", Columns: []string{"name"}},
+		{Name: "name", Columns: []string{"name"}},
+		{Name: "name_3", Columns: []string{"name(20)"}},
+	}, tbls[0].Indexes)
+}
+
+func TestParseCreateTable_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unbalanced", func(t *testing.T) {
+		_, err := ddl.ParseCreateTable("CREATE TABLE `a` ( `b` int(10) ")
+		assert.Error(t, err)
+	})
+	t.Run("unknown token", func(t *testing.T) {
+		_, err := ddl.ParseCreateTable("CREATE TABLE `a` ( `b` int(10) NOT NULL FANCY)")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,417 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/util/bufferpool"
+)
+
+// TableDiff contains the differences between the wanted and the current
+// definition of a table. Applying the statements of TableDiff to the current
+// table transforms it into the wanted table. Column renames can't be detected,
+// they result in a drop and an add. Indexes and foreign keys get only compared
+// if both tables have their Indexes or ForeignKeys fields set to non-nil.
+type TableDiff struct {
+	want *Table
+	have *Table
+	// Schema and Table contain the name of the table.
+	Schema string
+	Table  string
+	// Create is true when the table does not exists and must be created.
+	Create bool
+	// Drop is true when the table exists but is not wanted anymore.
+	Drop            bool
+	AddColumns      Columns
+	ModifyColumns   Columns
+	DropColumns     Columns
+	AddIndexes      Indexes
+	DropIndexes     Indexes
+	AddForeignKeys  ForeignKeys
+	DropForeignKeys ForeignKeys
+}
+
+func columnDefinition(buf *bytes.Buffer, c *Column) string {
+	buf.Reset()
+	c.writeDefinition(buf)
+	return buf.String()
+}
+
+// DiffTable compares the wanted with the current table definition. Argument
+// `have` might be nil to create the table and argument `want` might be nil to
+// drop the table. Views and system-versioned columns get ignored.
+func DiffTable(want, have *Table) *TableDiff {
+	d := &TableDiff{
+		want: want,
+		have: have,
+	}
+	switch {
+	case want == nil && have == nil:
+		return d
+	case want == nil:
+		d.Schema, d.Table, d.Drop = have.Schema, have.Name, !have.IsView
+		return d
+	case have == nil:
+		d.Schema, d.Table, d.Create = want.Schema, want.Name, !want.IsView
+		return d
+	}
+	d.Schema, d.Table = want.Schema, want.Name
+	if want.IsView || have.IsView {
+		return d
+	}
+
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	for _, wc := range want.Columns {
+		if wc.IsSystemVersioned() {
+			continue
+		}
+		if !have.Columns.Contains(wc.Field) {
+			d.AddColumns = append(d.AddColumns, wc)
+			continue
+		}
+		wantDef := columnDefinition(buf, wc)
+		if columnDefinition(buf, have.Columns.ByField(wc.Field)) != wantDef {
+			d.ModifyColumns = append(d.ModifyColumns, wc)
+		}
+	}
+	for _, hc := range have.Columns {
+		if !hc.IsSystemVersioned() && !want.Columns.Contains(hc.Field) {
+			d.DropColumns = append(d.DropColumns, hc)
+		}
+	}
+
+	if want.Indexes != nil && have.Indexes != nil {
+		for _, wi := range want.Indexes {
+			hi := have.Indexes.ByName(wi.Name)
+			switch {
+			case hi == nil:
+				d.AddIndexes = append(d.AddIndexes, wi)
+			case !hi.Equal(wi):
+				d.DropIndexes = append(d.DropIndexes, hi)
+				d.AddIndexes = append(d.AddIndexes, wi)
+			}
+		}
+		for _, hi := range have.Indexes {
+			if want.Indexes.ByName(hi.Name) == nil {
+				d.DropIndexes = append(d.DropIndexes, hi)
+			}
+		}
+	}
+
+	if want.ForeignKeys != nil && have.ForeignKeys != nil {
+		for _, wfk := range want.ForeignKeys {
+			hfk := have.ForeignKeys.ByName(wfk.Name)
+			switch {
+			case hfk == nil:
+				d.AddForeignKeys = append(d.AddForeignKeys, wfk)
+			case !hfk.Equal(wfk):
+				d.DropForeignKeys = append(d.DropForeignKeys, hfk)
+				d.AddForeignKeys = append(d.AddForeignKeys, wfk)
+			}
+		}
+		for _, hfk := range have.ForeignKeys {
+			if want.ForeignKeys.ByName(hfk.Name) == nil {
+				d.DropForeignKeys = append(d.DropForeignKeys, hfk)
+			}
+		}
+	}
+	return d
+}
+
+// IsEmpty returns true if both table definitions are equal.
+func (d *TableDiff) IsEmpty() bool {
+	return !d.Create && !d.Drop &&
+		len(d.AddColumns) == 0 && len(d.ModifyColumns) == 0 && len(d.DropColumns) == 0 &&
+		len(d.AddIndexes) == 0 && len(d.DropIndexes) == 0 &&
+		len(d.AddForeignKeys) == 0 && len(d.DropForeignKeys) == 0
+}
+
+// Reverse returns the difference in the opposite direction. For example to
+// generate the down migration.
+func (d *TableDiff) Reverse() *TableDiff {
+	return DiffTable(d.have, d.want)
+}
+
+func (d *TableDiff) writeAlterTable(buf *bytes.Buffer, i int) {
+	if i == 0 {
+		buf.WriteString("ALTER TABLE ")
+		buf.WriteString(dml.Quoter.QualifierName(d.Schema, d.Table))
+		buf.WriteByte(' ')
+		return
+	}
+	buf.WriteString(", ")
+}
+
+// Statements returns the SQL statements to transform the current into the
+// wanted table. Foreign keys get dropped in the first ALTER TABLE statement
+// and get added in the last statement, after the indexes have been created.
+// Returns nil if there are no differences.
+func (d *TableDiff) Statements() []string {
+	switch {
+	case d.Drop:
+		return []string{"DROP TABLE IF EXISTS " + dml.Quoter.QualifierName(d.Schema, d.Table)}
+	case d.Create:
+		return []string{d.want.createTableSQL()}
+	}
+
+	var stmts []string
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	for i, fk := range d.DropForeignKeys {
+		d.writeAlterTable(buf, i)
+		buf.WriteString("DROP FOREIGN KEY ")
+		buf.WriteString(dml.Quoter.Name(fk.Name))
+	}
+	if buf.Len() > 0 {
+		stmts = append(stmts, buf.String())
+		buf.Reset()
+	}
+
+	i := 0
+	for _, idx := range d.DropIndexes {
+		d.writeAlterTable(buf, i)
+		if idx.IsPrimary() {
+			buf.WriteString("DROP PRIMARY KEY")
+		} else {
+			buf.WriteString("DROP KEY ")
+			buf.WriteString(dml.Quoter.Name(idx.Name))
+		}
+		i++
+	}
+	for _, c := range d.DropColumns {
+		d.writeAlterTable(buf, i)
+		buf.WriteString("DROP COLUMN ")
+		buf.WriteString(dml.Quoter.Name(c.Field))
+		i++
+	}
+	for _, c := range d.AddColumns {
+		d.writeAlterTable(buf, i)
+		buf.WriteString("ADD COLUMN ")
+		c.writeDefinition(buf)
+		if prev := d.want.previousColumn(c.Field); prev != "" {
+			buf.WriteString(" AFTER ")
+			buf.WriteString(dml.Quoter.Name(prev))
+		} else {
+			buf.WriteString(" FIRST")
+		}
+		i++
+	}
+	for _, c := range d.ModifyColumns {
+		d.writeAlterTable(buf, i)
+		buf.WriteString("MODIFY COLUMN ")
+		c.writeDefinition(buf)
+		i++
+	}
+	for _, idx := range d.AddIndexes {
+		d.writeAlterTable(buf, i)
+		buf.WriteString("ADD ")
+		idx.writeDefinition(buf)
+		i++
+	}
+	if buf.Len() > 0 {
+		stmts = append(stmts, buf.String())
+		buf.Reset()
+	}
+
+	for i, fk := range d.AddForeignKeys {
+		d.writeAlterTable(buf, i)
+		buf.WriteString("ADD ")
+		fk.writeDefinition(buf)
+	}
+	if buf.Len() > 0 {
+		stmts = append(stmts, buf.String())
+	}
+	return stmts
+}
+
+// previousColumn returns the name of the column before column `field` or an
+// empty string if `field` is the first column.
+func (t *Table) previousColumn(field string) string {
+	prev := ""
+	for _, c := range t.Columns {
+		if c.Field == field {
+			return prev
+		}
+		if !c.IsSystemVersioned() {
+			prev = c.Field
+		}
+	}
+	return prev
+}
+
+// createTableSQL generates a CREATE TABLE statement from the columns, indexes
+// and foreign keys.
+func (t *Table) createTableSQL() string {
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	buf.WriteString("CREATE TABLE ")
+	buf.WriteString(dml.Quoter.QualifierName(t.Schema, t.Name))
+	buf.WriteString(" (\n")
+	i := 0
+	for _, c := range t.Columns {
+		if c.IsSystemVersioned() {
+			continue
+		}
+		if i > 0 {
+			buf.WriteString(",\n")
+		}
+		buf.WriteString("  ")
+		c.writeDefinition(buf)
+		i++
+	}
+	for _, idx := range t.Indexes {
+		buf.WriteString(",\n  ")
+		idx.writeDefinition(buf)
+	}
+	for _, fk := range t.ForeignKeys {
+		buf.WriteString(",\n  ")
+		fk.writeDefinition(buf)
+	}
+	buf.WriteString("\n)")
+	return buf.String()
+}
+
+// TableDiffs a list of table differences.
+type TableDiffs []*TableDiff
+
+// Statements returns the SQL statements of all table differences.
+func (ds TableDiffs) Statements() []string {
+	var stmts []string
+	for _, d := range ds {
+		stmts = append(stmts, d.Statements()...)
+	}
+	return stmts
+}
+
+// Reverse reverses each table difference.
+func (ds TableDiffs) Reverse() TableDiffs {
+	ret := make(TableDiffs, len(ds))
+	for i, d := range ds {
+		ret[i] = d.Reverse()
+	}
+	return ret
+}
+
+// LoadTables loads the columns, indexes and foreign keys of a list of table
+// names from the current database. Map key contains the table name. Tables
+// which do not exist are not part of the returned map. All tables gets loaded
+// when you don't provide the argument `tables`.
+func LoadTables(ctx context.Context, db dml.Querier, tables ...string) (map[string]*Table, error) {
+	return loadTablesBySchema(ctx, db, "", tables...)
+}
+
+// loadTablesBySchema same as LoadTables but loads the tables of database
+// `schema`. An empty schema refers to the current database. The Schema field
+// of the returned tables contains the argument `schema`.
+func loadTablesBySchema(ctx context.Context, db dml.Querier, schema string, tables ...string) (map[string]*Table, error) {
+	var tc map[string]Columns
+	var ic map[string]Indexes
+	var fc map[string]ForeignKeys
+	var err error
+	if schema == "" {
+		tc, err = LoadColumns(ctx, db, tables...)
+	} else {
+		tc, err = loadColumnsBySchema(ctx, db, schema, tables...)
+	}
+	if err != nil && !errors.NotFound.Match(err) {
+		return nil, errors.WithStack(err)
+	}
+	if schema == "" {
+		ic, err = LoadIndexes(ctx, db, tables...)
+	} else {
+		ic, err = loadIndexesBySchema(ctx, db, schema, tables...)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if schema == "" {
+		fc, err = LoadForeignKeys(ctx, db, tables...)
+	} else {
+		fc, err = loadForeignKeysBySchema(ctx, db, schema, tables...)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	ret := make(map[string]*Table, len(tc))
+	for tn, cols := range tc {
+		t := NewTable(tn, cols...)
+		t.Schema = schema
+		t.Indexes = ic[tn]
+		if t.Indexes == nil {
+			t.Indexes = Indexes{}
+		}
+		t.ForeignKeys = fc[tn]
+		if t.ForeignKeys == nil {
+			t.ForeignKeys = ForeignKeys{}
+		}
+		ret[tn] = t
+	}
+	return ret, nil
+}
+
+// SchemaDiff compares the tables with the current database schema. The
+// statements of the returned TableDiffs migrate the database to the table
+// definitions. The reversed TableDiffs migrate the table definitions to the
+// current database schema. Tables without differences are not part of the
+// returned slice. The slice is sorted by table name. An error with kind
+// Empty gets returned if no tables have been registered, otherwise all tables
+// of the database would get loaded.
+func (tm *Tables) SchemaDiff(ctx context.Context) (TableDiffs, error) {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	if len(tm.tm) == 0 {
+		return nil, errors.Empty.Newf("[ddl] SchemaDiff requires at least one registered table")
+	}
+	tblNames := make([]string, 0, len(tm.tm))
+	bySchema := make(map[string][]string) // schema => table names
+	for tn, t := range tm.tm {
+		tblNames = append(tblNames, tn)
+		bySchema[t.Schema] = append(bySchema[t.Schema], tn)
+	}
+	sort.Strings(tblNames)
+
+	// Each table gets loaded from the database of its Schema field.
+	dbTables := make(map[string]*Table, len(tblNames))
+	for schema, tns := range bySchema {
+		sort.Strings(tns)
+		tbls, err := loadTablesBySchema(ctx, tm.dcp.DB, schema, tns...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ddl] SchemaDiff for schema %q", schema)
+		}
+		for _, tn := range tns {
+			dbTables[tn] = tbls[tn]
+		}
+	}
+
+	var ret TableDiffs
+	for _, tn := range tblNames {
+		have := dbTables[tn]
+		if d := DiffTable(tm.tm[tn], have); !d.IsEmpty() {
+			ret = append(ret, d)
+		}
+	}
+	return ret, nil
+}
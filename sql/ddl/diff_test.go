@@ -0,0 +1,217 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func mustParseCreateTable(t *testing.T, stmt string) *ddl.Table {
+	tbls, err := ddl.ParseCreateTable(stmt)
+	assert.NoError(t, err, "%+v", err)
+	assert.Len(t, tbls, 1)
+	return tbls[0]
+}
+
+const diffHaveStmt = "CREATE TABLE `catalog_product_entity` (\n" +
+	"  `entity_id` int(10) unsigned NOT NULL AUTO_INCREMENT COMMENT 'Entity ID',\n" +
+	"  `attribute_set_id` smallint(5) unsigned NOT NULL DEFAULT 0,\n" +
+	"  `type_id` varchar(32) NOT NULL DEFAULT 'simple',\n" +
+	"  `sku` varchar(64) DEFAULT NULL,\n" +
+	"  `old_flag` tinyint(1) NOT NULL DEFAULT 0,\n" +
+	"  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),\n" +
+	"  PRIMARY KEY (`entity_id`),\n" +
+	"  KEY `CAT_PRD_ENTT_SKU` (`sku`),\n" +
+	"  KEY `CAT_PRD_ENTT_ATTR_SET_ID` (`attribute_set_id`),\n" +
+	"  CONSTRAINT `CAT_PRD_ENTT_ATTR_SET_ID_EAV_ATTR_SET_ATTR_SET_ID` FOREIGN KEY (`attribute_set_id`) REFERENCES `eav_attribute_set` (`attribute_set_id`)\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;"
+
+const diffWantStmt = "CREATE TABLE `catalog_product_entity` (\n" +
+	"  `entity_id` int(10) unsigned NOT NULL AUTO_INCREMENT COMMENT 'Entity ID',\n" +
+	"  `attribute_set_id` smallint(5) unsigned NOT NULL DEFAULT 0,\n" +
+	"  `type_id` varchar(32) NOT NULL DEFAULT 'simple',\n" +
+	"  `has_options` smallint(6) NOT NULL DEFAULT 0,\n" +
+	"  `sku` varchar(128) NOT NULL,\n" +
+	"  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+	"  PRIMARY KEY (`entity_id`),\n" +
+	"  UNIQUE KEY `CAT_PRD_ENTT_SKU` (`sku`),\n" +
+	"  KEY `CAT_PRD_ENTT_ATTR_SET_ID` (`attribute_set_id`),\n" +
+	"  CONSTRAINT `CAT_PRD_ENTT_ATTR_SET_ID_EAV_ATTR_SET_ATTR_SET_ID` FOREIGN KEY (`attribute_set_id`) REFERENCES `eav_attribute_set` (`attribute_set_id`) ON DELETE CASCADE\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;"
+
+func TestDiffTable(t *testing.T) {
+	t.Parallel()
+
+	have := mustParseCreateTable(t, diffHaveStmt)
+	want := mustParseCreateTable(t, diffWantStmt)
+
+	t.Run("equal", func(t *testing.T) {
+		d := ddl.DiffTable(have, mustParseCreateTable(t, diffHaveStmt))
+		assert.True(t, d.IsEmpty())
+		assert.Nil(t, d.Statements())
+	})
+
+	t.Run("up", func(t *testing.T) {
+		d := ddl.DiffTable(want, have)
+		assert.False(t, d.IsEmpty())
+		assert.Exactly(t, []string{"has_options"}, d.AddColumns.FieldNames())
+		assert.Exactly(t, []string{"sku"}, d.ModifyColumns.FieldNames())
+		assert.Exactly(t, []string{"old_flag"}, d.DropColumns.FieldNames())
+		assert.Exactly(t, []string{
+			"ALTER TABLE `catalog_product_entity` DROP FOREIGN KEY `CAT_PRD_ENTT_ATTR_SET_ID_EAV_ATTR_SET_ATTR_SET_ID`",
+			"ALTER TABLE `catalog_product_entity` DROP KEY `CAT_PRD_ENTT_SKU`, DROP COLUMN `old_flag`, " +
+				"ADD COLUMN `has_options` smallint(6) NOT NULL DEFAULT 0 AFTER `type_id`, " +
+				"MODIFY COLUMN `sku` varchar(128) NOT NULL, ADD UNIQUE KEY `CAT_PRD_ENTT_SKU` (`sku`)",
+			"ALTER TABLE `catalog_product_entity` ADD CONSTRAINT `CAT_PRD_ENTT_ATTR_SET_ID_EAV_ATTR_SET_ATTR_SET_ID` FOREIGN KEY (`attribute_set_id`) REFERENCES `eav_attribute_set` (`attribute_set_id`) ON DELETE CASCADE",
+		}, d.Statements())
+	})
+
+	t.Run("down", func(t *testing.T) {
+		d := ddl.DiffTable(want, have).Reverse()
+		assert.Exactly(t, []string{
+			"ALTER TABLE `catalog_product_entity` DROP FOREIGN KEY `CAT_PRD_ENTT_ATTR_SET_ID_EAV_ATTR_SET_ATTR_SET_ID`",
+			"ALTER TABLE `catalog_product_entity` DROP KEY `CAT_PRD_ENTT_SKU`, DROP COLUMN `has_options`, " +
+				"ADD COLUMN `old_flag` tinyint(1) NOT NULL DEFAULT 0 AFTER `sku`, " +
+				"MODIFY COLUMN `sku` varchar(64) NULL, ADD KEY `CAT_PRD_ENTT_SKU` (`sku`)",
+			"ALTER TABLE `catalog_product_entity` ADD CONSTRAINT `CAT_PRD_ENTT_ATTR_SET_ID_EAV_ATTR_SET_ATTR_SET_ID` FOREIGN KEY (`attribute_set_id`) REFERENCES `eav_attribute_set` (`attribute_set_id`)",
+		}, d.Statements())
+	})
+
+	t.Run("indexes and foreign keys ignored", func(t *testing.T) {
+		w := ddl.NewTable(want.Name, want.Columns...)
+		d := ddl.DiffTable(w, have)
+		assert.Len(t, d.AddIndexes, 0)
+		assert.Len(t, d.DropIndexes, 0)
+		assert.Len(t, d.AddForeignKeys, 0)
+		assert.Len(t, d.DropForeignKeys, 0)
+		assert.Len(t, d.Statements(), 1)
+	})
+
+	t.Run("create and drop", func(t *testing.T) {
+		d := ddl.DiffTable(have, nil)
+		assert.True(t, d.Create)
+		assert.Exactly(t, []string{"CREATE TABLE `catalog_product_entity` (\n" +
+			"  `entity_id` int(10) unsigned NOT NULL AUTO_INCREMENT COMMENT 'Entity ID',\n" +
+			"  `attribute_set_id` smallint(5) unsigned NOT NULL DEFAULT 0,\n" +
+			"  `type_id` varchar(32) NOT NULL DEFAULT 'simple',\n" +
+			"  `sku` varchar(64) NULL,\n" +
+			"  `old_flag` tinyint(1) NOT NULL DEFAULT 0,\n" +
+			"  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+			"  PRIMARY KEY (`entity_id`),\n" +
+			"  KEY `CAT_PRD_ENTT_SKU` (`sku`),\n" +
+			"  KEY `CAT_PRD_ENTT_ATTR_SET_ID` (`attribute_set_id`),\n" +
+			"  CONSTRAINT `CAT_PRD_ENTT_ATTR_SET_ID_EAV_ATTR_SET_ATTR_SET_ID` FOREIGN KEY (`attribute_set_id`) REFERENCES `eav_attribute_set` (`attribute_set_id`)\n" +
+			")"}, d.Statements())
+
+		d = d.Reverse()
+		assert.True(t, d.Drop)
+		assert.Exactly(t, []string{"DROP TABLE IF EXISTS `catalog_product_entity`"}, d.Statements())
+	})
+}
+
+func TestTables_SchemaDiff_NoTables(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	diffs, err := ddl.MustNewTables(ddl.WithDB(dbc.DB)).SchemaDiff(context.Background())
+	assert.True(t, errors.Empty.Match(err), "%+v", err)
+	assert.Nil(t, diffs)
+}
+
+func TestTables_SchemaDiff(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	tbls := ddl.MustNewTables(ddl.WithDB(dbc.DB))
+	assert.NoError(t, tbls.Upsert(mustParseCreateTable(t, "CREATE TABLE `core_config_data` (\n"+
+		"  `config_id` int(10) unsigned NOT NULL AUTO_INCREMENT,\n"+
+		"  `scope` varchar(8) NOT NULL DEFAULT 'default',\n"+
+		"  `path` varchar(255) NOT NULL DEFAULT 'general',\n"+
+		"  PRIMARY KEY (`config_id`),\n"+
+		"  UNIQUE KEY `CORE_CONFIG_DATA_SCOPE_PATH` (`scope`,`path`)\n"+
+		")")))
+
+	dbMock.ExpectQuery("SELECT.+FROM information_schema.COLUMNS WHERE").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "COLUMN_NAME", "ORDINAL_POSITION", "COLUMN_DEFAULT", "IS_NULLABLE", "DATA_TYPE", "COLUMN_TYPE", "COLUMN_KEY", "EXTRA"}).
+			FromCSVString(`"core_config_data","config_id",1,NULL,"NO","int","int(10) unsigned","PRI","auto_increment"
+"core_config_data","scope",2,"default","NO","varchar","varchar(8)","MUL",""
+"core_config_data","value",3,NULL,"YES","text","text","",""
+`))
+	dbMock.ExpectQuery("SELECT.+FROM information_schema.STATISTICS WHERE").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "INDEX_NAME", "NON_UNIQUE", "SEQ_IN_INDEX", "COLUMN_NAME", "SUB_PART", "INDEX_TYPE"}).
+			FromCSVString(`"core_config_data","PRIMARY",0,1,"config_id",NULL,"BTREE"
+"core_config_data","CORE_CONFIG_DATA_SCOPE",1,1,"scope",4,"BTREE"
+`))
+	dbMock.ExpectQuery("SELECT.+FROM information_schema.KEY_COLUMN_USAGE kcu.+").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "CONSTRAINT_NAME", "COLUMN_NAME", "REFERENCED_TABLE_NAME", "REFERENCED_COLUMN_NAME", "UPDATE_RULE", "DELETE_RULE"}))
+
+	diffs, err := tbls.SchemaDiff(context.Background())
+	assert.NoError(t, err, "%+v", err)
+	assert.Len(t, diffs, 1)
+	assert.Exactly(t, []string{
+		"ALTER TABLE `core_config_data` DROP KEY `CORE_CONFIG_DATA_SCOPE`, DROP COLUMN `value`, " +
+			"ADD COLUMN `path` varchar(255) NOT NULL DEFAULT 'general' AFTER `scope`, " +
+			"ADD UNIQUE KEY `CORE_CONFIG_DATA_SCOPE_PATH` (`scope`,`path`)",
+	}, diffs.Statements())
+	assert.Exactly(t, []string{
+		"ALTER TABLE `core_config_data` DROP KEY `CORE_CONFIG_DATA_SCOPE_PATH`, DROP COLUMN `path`, " +
+			"ADD COLUMN `value` text NULL AFTER `scope`, " +
+			"ADD KEY `CORE_CONFIG_DATA_SCOPE` (`scope`(4))",
+	}, diffs.Reverse().Statements())
+}
+
+func TestTables_SchemaDiff_Schema(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	tbl := mustParseCreateTable(t, "CREATE TABLE `core_config_data` (\n"+
+		"  `config_id` int(10) unsigned NOT NULL AUTO_INCREMENT,\n"+
+		"  `scope` varchar(8) NOT NULL DEFAULT 'default',\n"+
+		"  PRIMARY KEY (`config_id`)\n"+
+		")")
+	tbl.Schema = "shop"
+	tbls := ddl.MustNewTables(ddl.WithDB(dbc.DB))
+	assert.NoError(t, tbls.Upsert(tbl))
+
+	dbMock.ExpectQuery("SELECT.+FROM information_schema.COLUMNS WHERE TABLE_SCHEMA='shop' AND TABLE_NAME IN \\('core_config_data'\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "COLUMN_NAME", "ORDINAL_POSITION", "COLUMN_DEFAULT", "IS_NULLABLE", "DATA_TYPE", "COLUMN_TYPE", "COLUMN_KEY", "EXTRA"}).
+			FromCSVString(`"core_config_data","config_id",1,NULL,"NO","int","int(10) unsigned","PRI","auto_increment"
+`))
+	dbMock.ExpectQuery("SELECT.+FROM information_schema.STATISTICS WHERE TABLE_SCHEMA='shop' AND TABLE_NAME IN \\('core_config_data'\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "INDEX_NAME", "NON_UNIQUE", "SEQ_IN_INDEX", "COLUMN_NAME", "SUB_PART", "INDEX_TYPE"}).
+			FromCSVString(`"core_config_data","PRIMARY",0,1,"config_id",NULL,"BTREE"
+`))
+	dbMock.ExpectQuery("SELECT.+FROM information_schema.KEY_COLUMN_USAGE kcu.+WHERE kcu.TABLE_SCHEMA = 'shop' AND .+ AND kcu.TABLE_NAME IN \\('core_config_data'\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "CONSTRAINT_NAME", "COLUMN_NAME", "REFERENCED_TABLE_NAME", "REFERENCED_COLUMN_NAME", "UPDATE_RULE", "DELETE_RULE"}))
+
+	diffs, err := tbls.SchemaDiff(context.Background())
+	assert.NoError(t, err, "%+v", err)
+	assert.Exactly(t, []string{
+		"ALTER TABLE `shop`.`core_config_data` ADD COLUMN `scope` varchar(8) NOT NULL DEFAULT 'default' AFTER `config_id`",
+	}, diffs.Statements())
+}
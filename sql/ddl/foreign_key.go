@@ -15,9 +15,11 @@
 package ddl
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/pkg/sql/dml"
@@ -264,3 +266,171 @@ func LoadKeyColumnUsage(ctx context.Context, db dml.Querier, tables ...string) (
 	}
 	return
 }
+
+// ForeignKey represents a foreign key constraint of a table. A constraint can
+// span multiple columns.
+type ForeignKey struct {
+	// Name of the constraint.
+	Name              string
+	Columns           []string
+	ReferencedTable   string
+	ReferencedColumns []string
+	// OnDelete and OnUpdate contain the referential actions: RESTRICT,
+	// CASCADE, SET NULL or NO ACTION. Empty means RESTRICT.
+	OnDelete string
+	OnUpdate string
+}
+
+// ForeignKeys a list of foreign key constraints belonging to a table.
+type ForeignKeys []*ForeignKey
+
+func fkRule(rule string) string {
+	if rule == "" {
+		return "RESTRICT"
+	}
+	return strings.ToUpper(rule)
+}
+
+// Equal compares two foreign keys. The name does not get compared.
+func (fk *ForeignKey) Equal(o *ForeignKey) bool {
+	return fk.ReferencedTable == o.ReferencedTable &&
+		fkRule(fk.OnDelete) == fkRule(o.OnDelete) && fkRule(fk.OnUpdate) == fkRule(o.OnUpdate) &&
+		strings.Join(fk.Columns, ",") == strings.Join(o.Columns, ",") &&
+		strings.Join(fk.ReferencedColumns, ",") == strings.Join(o.ReferencedColumns, ",")
+}
+
+// writeDefinition writes the constraint definition as used in CREATE TABLE or
+// ALTER TABLE ADD statements.
+func (fk *ForeignKey) writeDefinition(buf *bytes.Buffer) {
+	buf.WriteString("CONSTRAINT ")
+	buf.WriteString(dml.Quoter.Name(fk.Name))
+	buf.WriteString(" FOREIGN KEY ")
+	writeIndexColumns(buf, fk.Columns)
+	buf.WriteString(" REFERENCES ")
+	buf.WriteString(dml.Quoter.Name(fk.ReferencedTable))
+	buf.WriteByte(' ')
+	writeIndexColumns(buf, fk.ReferencedColumns)
+	if r := fkRule(fk.OnDelete); r != "RESTRICT" {
+		buf.WriteString(" ON DELETE ")
+		buf.WriteString(r)
+	}
+	if r := fkRule(fk.OnUpdate); r != "RESTRICT" {
+		buf.WriteString(" ON UPDATE ")
+		buf.WriteString(r)
+	}
+}
+
+// ByName finds a foreign key by its constraint name. Returns nil if not found.
+func (fks ForeignKeys) ByName(name string) *ForeignKey {
+	for _, fk := range fks {
+		if fk.Name == name {
+			return fk
+		}
+	}
+	return nil
+}
+
+const (
+	selFKSelect = `SELECT kcu.TABLE_NAME, kcu.CONSTRAINT_NAME, kcu.COLUMN_NAME, kcu.REFERENCED_TABLE_NAME,
+	kcu.REFERENCED_COLUMN_NAME, rc.UPDATE_RULE, rc.DELETE_RULE
+	FROM information_schema.KEY_COLUMN_USAGE kcu
+	JOIN information_schema.REFERENTIAL_CONSTRAINTS rc ON rc.CONSTRAINT_SCHEMA = kcu.CONSTRAINT_SCHEMA
+		AND rc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME AND rc.TABLE_NAME = kcu.TABLE_NAME
+	WHERE `
+	selFKBase            = selFKSelect + `kcu.TABLE_SCHEMA = DATABASE() AND kcu.REFERENCED_TABLE_NAME IS NOT NULL`
+	selFKOrderBy         = ` ORDER BY kcu.TABLE_NAME, kcu.CONSTRAINT_NAME, kcu.ORDINAL_POSITION`
+	selTablesFKs         = selFKBase + ` AND kcu.TABLE_NAME IN ?` + selFKOrderBy
+	selAllTablesFKs      = selFKBase + selFKOrderBy
+	selTablesFKsBySchema = selFKSelect + `kcu.TABLE_SCHEMA = ? AND kcu.REFERENCED_TABLE_NAME IS NOT NULL AND kcu.TABLE_NAME IN ?` + selFKOrderBy
+)
+
+// LoadForeignKeys returns all foreign key constraints defined on a list of
+// table names in the current database. In contrast to LoadKeyColumnUsage the
+// map key contains the name of the table which owns the constraint. All
+// foreign keys from all tables gets selected when you don't provide the
+// argument `tables`.
+func LoadForeignKeys(ctx context.Context, db dml.Querier, tables ...string) (map[string]ForeignKeys, error) {
+	if len(tables) == 0 {
+		return loadForeignKeys(ctx, db, selAllTablesFKs, tables)
+	}
+	sqlStr, _, err := dml.Interpolate(selTablesFKs).Strs(tables...).ToSQL()
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadForeignKeys dml.ExpandPlaceHolders for tables %v", tables)
+	}
+	return loadForeignKeys(ctx, db, sqlStr, tables)
+}
+
+// loadForeignKeysBySchema same as LoadForeignKeys but loads the tables of
+// database `schema` instead of the current database.
+func loadForeignKeysBySchema(ctx context.Context, db dml.Querier, schema string, tables ...string) (map[string]ForeignKeys, error) {
+	sqlStr, _, err := dml.Interpolate(selTablesFKsBySchema).Str(schema).Strs(tables...).ToSQL()
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadForeignKeys dml.ExpandPlaceHolders for tables %v in schema %q", tables, schema)
+	}
+	return loadForeignKeys(ctx, db, sqlStr, tables)
+}
+
+func loadForeignKeys(ctx context.Context, db dml.Querier, sqlStr string, tables []string) (tc map[string]ForeignKeys, err error) {
+	rows, err := db.QueryContext(ctx, sqlStr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadForeignKeys QueryContext for tables %v", tables)
+	}
+	defer func() {
+		// Not testable with the sqlmock package :-(
+		if err2 := rows.Close(); err2 != nil && err == nil {
+			err = errors.Wrap(err2, "[ddl] LoadForeignKeys.Rows.Close")
+		}
+	}()
+
+	tc = make(map[string]ForeignKeys)
+	rc := new(dml.ColumnMap)
+	for rows.Next() {
+		if err = rc.Scan(rows); err != nil {
+			err = errors.Wrapf(err, "[ddl] LoadForeignKeys Scan Query for tables: %v", tables) // due to the defer
+			return
+		}
+		var tableName, constraintName, columnName, refTableName, refColumnName, updateRule, deleteRule string
+		for rc.Next() {
+			switch col := rc.Column(); col {
+			case "TABLE_NAME":
+				rc.String(&tableName)
+			case "CONSTRAINT_NAME":
+				rc.String(&constraintName)
+			case "COLUMN_NAME":
+				rc.String(&columnName)
+			case "REFERENCED_TABLE_NAME":
+				rc.String(&refTableName)
+			case "REFERENCED_COLUMN_NAME":
+				rc.String(&refColumnName)
+			case "UPDATE_RULE":
+				rc.String(&updateRule)
+			case "DELETE_RULE":
+				rc.String(&deleteRule)
+			default:
+				err = errors.NotSupported.Newf("[ddl] LoadForeignKeys Column %q not supported", col)
+				return
+			}
+		}
+		if err = rc.Err(); err != nil {
+			err = errors.WithStack(err)
+			return
+		}
+
+		fk := tc[tableName].ByName(constraintName)
+		if fk == nil {
+			fk = &ForeignKey{
+				Name:            constraintName,
+				ReferencedTable: refTableName,
+				OnDelete:        deleteRule,
+				OnUpdate:        updateRule,
+			}
+			tc[tableName] = append(tc[tableName], fk)
+		}
+		fk.Columns = append(fk.Columns, columnName)
+		fk.ReferencedColumns = append(fk.ReferencedColumns, refColumnName)
+	}
+	if err = rows.Err(); err != nil {
+		err = errors.Wrapf(err, "[ddl] LoadForeignKeys rows.Err Query")
+	}
+	return
+}
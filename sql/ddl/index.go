@@ -0,0 +1,232 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/null"
+)
+
+// IndexPrimary defines the name of the primary key index.
+const IndexPrimary = "PRIMARY"
+
+// Index represents a table index, retrieved from information_schema.STATISTICS
+// or parsed from a CREATE TABLE statement.
+type Index struct {
+	// Name of the index. The primary key is always named PRIMARY.
+	Name string
+	// Unique set to true for primary and unique keys.
+	Unique bool
+	// Type can be BTREE, HASH, FULLTEXT or SPATIAL. Empty means BTREE.
+	Type string
+	// Columns ordered by their sequence in the index. A column might contain
+	// the prefix length in parenthesis, like `sku(32)`.
+	Columns []string
+}
+
+// Indexes a list of indexes belonging to a table.
+type Indexes []*Index
+
+// IsPrimary returns true if the index represents the primary key.
+func (i *Index) IsPrimary() bool {
+	return i.Name == IndexPrimary
+}
+
+func (i *Index) indexType() string {
+	if i.Type == "" {
+		return "BTREE"
+	}
+	return strings.ToUpper(i.Type)
+}
+
+// Equal compares two indexes. The name does not get compared.
+func (i *Index) Equal(o *Index) bool {
+	if i.Unique != o.Unique || i.indexType() != o.indexType() || len(i.Columns) != len(o.Columns) {
+		return false
+	}
+	for idx, c := range i.Columns {
+		if c != o.Columns[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// writeIndexColumns writes the quoted column names surrounded by parenthesis.
+// The prefix length of a column does not get quoted.
+func writeIndexColumns(buf *bytes.Buffer, cols []string) {
+	buf.WriteByte('(')
+	for i, c := range cols {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if pos := strings.IndexByte(c, '('); pos > 0 {
+			buf.WriteString(dml.Quoter.Name(c[:pos]))
+			buf.WriteString(c[pos:])
+			continue
+		}
+		buf.WriteString(dml.Quoter.Name(c))
+	}
+	buf.WriteByte(')')
+}
+
+// writeDefinition writes the index definition as used in CREATE TABLE or ALTER
+// TABLE ADD statements.
+func (i *Index) writeDefinition(buf *bytes.Buffer) {
+	switch typ := i.indexType(); {
+	case i.IsPrimary():
+		buf.WriteString("PRIMARY KEY ")
+	case typ == "FULLTEXT" || typ == "SPATIAL":
+		buf.WriteString(typ)
+		buf.WriteString(" KEY ")
+	case i.Unique:
+		buf.WriteString("UNIQUE KEY ")
+	default:
+		buf.WriteString("KEY ")
+	}
+	if !i.IsPrimary() {
+		buf.WriteString(dml.Quoter.Name(i.Name))
+		buf.WriteByte(' ')
+	}
+	writeIndexColumns(buf, i.Columns)
+	if typ := i.indexType(); typ == "HASH" {
+		buf.WriteString(" USING HASH")
+	}
+}
+
+// ByName finds an index by its name. Returns nil if not found.
+func (is Indexes) ByName(name string) *Index {
+	for _, i := range is {
+		if i.Name == name {
+			return i
+		}
+	}
+	return nil
+}
+
+// Primary returns the primary key index or nil.
+func (is Indexes) Primary() *Index {
+	return is.ByName(IndexPrimary)
+}
+
+const (
+	selIndexSelect = `SELECT TABLE_NAME, INDEX_NAME, NON_UNIQUE, SEQ_IN_INDEX, COLUMN_NAME, SUB_PART, INDEX_TYPE
+	FROM information_schema.STATISTICS WHERE `
+	selIndexBase             = selIndexSelect + `TABLE_SCHEMA=DATABASE()`
+	selIndexOrderBy          = ` ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX`
+	selTablesIndexes         = selIndexBase + ` AND TABLE_NAME IN ?` + selIndexOrderBy
+	selAllTableIndexes       = selIndexBase + selIndexOrderBy
+	selTablesIndexesBySchema = selIndexSelect + `TABLE_SCHEMA=? AND TABLE_NAME IN ?` + selIndexOrderBy
+)
+
+// LoadIndexes returns all indexes from a list of table names in the current
+// database. Map key contains the table name. All indexes from all tables gets
+// selected when you don't provide the argument `tables`. Tables without
+// indexes are not part of the returned map.
+func LoadIndexes(ctx context.Context, db dml.Querier, tables ...string) (map[string]Indexes, error) {
+	if len(tables) == 0 {
+		return loadIndexes(ctx, db, selAllTableIndexes, tables)
+	}
+	sqlStr, _, err := dml.Interpolate(selTablesIndexes).Strs(tables...).ToSQL()
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadIndexes dml.ExpandPlaceHolders for tables %v", tables)
+	}
+	return loadIndexes(ctx, db, sqlStr, tables)
+}
+
+// loadIndexesBySchema same as LoadIndexes but loads the tables of database
+// `schema` instead of the current database.
+func loadIndexesBySchema(ctx context.Context, db dml.Querier, schema string, tables ...string) (map[string]Indexes, error) {
+	sqlStr, _, err := dml.Interpolate(selTablesIndexesBySchema).Str(schema).Strs(tables...).ToSQL()
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadIndexes dml.ExpandPlaceHolders for tables %v in schema %q", tables, schema)
+	}
+	return loadIndexes(ctx, db, sqlStr, tables)
+}
+
+func loadIndexes(ctx context.Context, db dml.Querier, sqlStr string, tables []string) (tc map[string]Indexes, err error) {
+	rows, err := db.QueryContext(ctx, sqlStr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadIndexes QueryContext for tables %v", tables)
+	}
+	defer func() {
+		// Not testable with the sqlmock package :-(
+		if err2 := rows.Close(); err2 != nil && err == nil {
+			err = errors.Wrap(err2, "[ddl] LoadIndexes.Rows.Close")
+		}
+	}()
+
+	tc = make(map[string]Indexes)
+	rc := new(dml.ColumnMap)
+	for rows.Next() {
+		if err = rc.Scan(rows); err != nil {
+			err = errors.Wrapf(err, "[ddl] LoadIndexes Scan Query for tables: %v", tables) // due to the defer
+			return
+		}
+		var tableName, indexName, columnName, indexType string
+		var nonUnique bool
+		var seq uint64
+		var subPart null.Int64
+		for rc.Next() {
+			switch col := rc.Column(); col {
+			case "TABLE_NAME":
+				rc.String(&tableName)
+			case "INDEX_NAME":
+				rc.String(&indexName)
+			case "NON_UNIQUE":
+				rc.Bool(&nonUnique)
+			case "SEQ_IN_INDEX":
+				rc.Uint64(&seq)
+			case "COLUMN_NAME":
+				rc.String(&columnName)
+			case "SUB_PART":
+				rc.NullInt64(&subPart)
+			case "INDEX_TYPE":
+				rc.String(&indexType)
+			default:
+				err = errors.NotSupported.Newf("[ddl] LoadIndexes Column %q not supported", col)
+				return
+			}
+		}
+		if err = rc.Err(); err != nil {
+			err = errors.WithStack(err)
+			return
+		}
+		if subPart.Valid {
+			columnName = columnName + "(" + strconv.FormatInt(subPart.Int64, 10) + ")"
+		}
+
+		idx := tc[tableName].ByName(indexName)
+		if idx == nil {
+			idx = &Index{
+				Name:   indexName,
+				Unique: !nonUnique,
+				Type:   indexType,
+			}
+			tc[tableName] = append(tc[tableName], idx)
+		}
+		idx.Columns = append(idx.Columns, columnName)
+	}
+	if err = rows.Err(); err != nil {
+		err = errors.Wrapf(err, "[ddl] LoadIndexes rows.Err Query")
+	}
+	return
+}
@@ -0,0 +1,547 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/util/bufferpool"
+)
+
+// OnlineSchemaChangeSyncer keeps the shadow table in sync with the original
+// table while the rows get copied. The default implementation TriggerSyncer
+// uses triggers. The binlogsync package provides an implementation based on
+// binary log row events.
+type OnlineSchemaChangeSyncer interface {
+	// StartSync gets called after the shadow table has been created and
+	// altered and before the first row gets copied. Argument columns contains
+	// the columns which exist in both tables.
+	StartSync(ctx context.Context, t *Table, shadow string, columns []string) error
+	// WaitSync gets called before the cut over and must block until all
+	// changes to the original table have been applied to the shadow table.
+	WaitSync(ctx context.Context, t *Table, shadow string) error
+	// StopSync gets called after the cut over or if the schema change fails.
+	StopSync(ctx context.Context, t *Table, shadow string) error
+}
+
+// OnlineSchemaChange defines the options to alter a table without locking it
+// for the duration of the ALTER TABLE statement.
+type OnlineSchemaChange struct {
+	// Alter contains the alter specifications without the ALTER TABLE prefix,
+	// e.g. "ADD COLUMN `is_default` tinyint(1) NOT NULL DEFAULT 0".
+	// Required.
+	Alter string
+	// ShadowTable defines the name of the table with the new definition. It
+	// gets created in the database of the table, Table.Schema, or in the
+	// current database if Schema is empty. Defaults to `_<table>_new`.
+	ShadowTable string
+	// ChunkSize defines the number of rows copied with one statement.
+	// Defaults to 1000.
+	ChunkSize uint64
+	// Syncer keeps the shadow table in sync. Defaults to TriggerSyncer.
+	Syncer OnlineSchemaChangeSyncer
+	// Replicas optional connections to replicas. Copying pauses as long as
+	// the lag of a replica exceeds MaxReplicaLag or the replication has been
+	// stopped.
+	Replicas []dml.Querier
+	// MaxReplicaLag defaults to 10s.
+	MaxReplicaLag time.Duration
+	// MaxLoad pauses copying as long as one of the global status variables
+	// exceeds its maximum value, e.g. {"Threads_running": 25}.
+	MaxLoad map[string]uint64
+	// ThrottleInterval defines the pause between two throttle checks.
+	// Defaults to 500ms.
+	ThrottleInterval time.Duration
+	// ThrottleHandler gets called when copying pauses, with the reason for
+	// the pause. Optional.
+	ThrottleHandler func(reason string)
+	// Progress gets called after each copied chunk with the total number of
+	// copied rows. Optional.
+	Progress func(copied uint64)
+	// KeepOldTable keeps the original table, which got renamed to the shadow
+	// table name, after the cut over.
+	KeepOldTable bool
+}
+
+func (osc *OnlineSchemaChange) setDefaults(tableName string) {
+	if osc.ShadowTable == "" {
+		osc.ShadowTable = TableName("_", tableName, "new")
+	}
+	if osc.ChunkSize == 0 {
+		osc.ChunkSize = 1000
+	}
+	if osc.Syncer == nil {
+		osc.Syncer = TriggerSyncer{}
+	}
+	if osc.MaxReplicaLag == 0 {
+		osc.MaxReplicaLag = 10 * time.Second
+	}
+	if osc.ThrottleInterval == 0 {
+		osc.ThrottleInterval = 500 * time.Millisecond
+	}
+}
+
+// OnlineSchemaChange alters the table in the same way as gh-ost or pt-osc do.
+// It creates a shadow table with the new definition, copies the rows in
+// chunks ordered by the primary key and keeps the shadow table in sync via
+// the Syncer. The cut over swaps both tables atomically with Swap. The table
+// requires a primary key and its Columns must be loaded. Copying gets
+// throttled based on the replica lag and the server load. If an error occurs
+// before the cut over, the shadow table gets dropped. To use a custom
+// connection, call WithDB before.
+func (t *Table) OnlineSchemaChange(ctx context.Context, osc OnlineSchemaChange) (err error) {
+	if err := dml.IsValidIdentifier(t.Name); err != nil {
+		return errors.WithStack(err)
+	}
+	if osc.Alter == "" {
+		return errors.Empty.Newf("[ddl] OnlineSchemaChange for table %q requires the Alter specification", t.Name)
+	}
+	if len(t.columnsPK) == 0 {
+		return errors.NotSupported.Newf("[ddl] OnlineSchemaChange for table %q requires a primary key", t.Name)
+	}
+	osc.setDefaults(t.Name)
+	if err := dml.IsValidIdentifier(osc.ShadowTable); err != nil {
+		return errors.WithStack(err)
+	}
+	shadow := osc.ShadowTable
+	qShadow := dml.Quoter.QualifierName(t.Schema, shadow)
+
+	if err := t.runExec(ctx, "CREATE TABLE "+qShadow+" LIKE "+dml.Quoter.QualifierName(t.Schema, t.Name)); err != nil {
+		return errors.WithStack(err)
+	}
+	var syncing, swapped bool
+	defer func() {
+		if err == nil || swapped {
+			return
+		}
+		// Cleanup with a fresh context because ctx might be the reason for
+		// the failure.
+		cctx := context.Background()
+		if syncing {
+			_ = osc.Syncer.StopSync(cctx, t, shadow)
+		}
+		_ = t.runExec(cctx, "DROP TABLE IF EXISTS "+qShadow)
+	}()
+
+	if err = t.runExec(ctx, "ALTER TABLE "+qShadow+" "+osc.Alter); err != nil {
+		return errors.WithStack(err)
+	}
+
+	columns, err := t.oscColumns(ctx, shadow)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err = osc.Syncer.StartSync(ctx, t, shadow, columns); err != nil {
+		return errors.Wrapf(err, "[ddl] OnlineSchemaChange StartSync for table %q", t.Name)
+	}
+	syncing = true
+
+	if err = t.oscCopyRows(ctx, &osc, columns); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err = osc.Syncer.WaitSync(ctx, t, shadow); err != nil {
+		return errors.Wrapf(err, "[ddl] OnlineSchemaChange WaitSync for table %q", t.Name)
+	}
+	if err = t.Swap(ctx, shadow); err != nil {
+		return errors.WithStack(err)
+	}
+	swapped = true
+
+	// From now on the shadow table name refers to the old table.
+	if err = osc.Syncer.StopSync(ctx, t, shadow); err != nil {
+		return errors.Wrapf(err, "[ddl] OnlineSchemaChange StopSync for table %q", t.Name)
+	}
+	if !osc.KeepOldTable {
+		return t.runExec(ctx, "DROP TABLE IF EXISTS "+qShadow)
+	}
+	return nil
+}
+
+// oscColumns returns the columns which exist in the original and in the
+// altered shadow table. Generated columns get skipped.
+func (t *Table) oscColumns(ctx context.Context, shadow string) ([]string, error) {
+	var tc map[string]Columns
+	var err error
+	if t.Schema != "" {
+		tc, err = loadColumnsBySchema(ctx, t.db(), t.Schema, shadow)
+	} else {
+		tc, err = LoadColumns(ctx, t.db(), shadow)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] OnlineSchemaChange failed to load columns of shadow table %q", shadow)
+	}
+	shadowCols := make(map[string]bool, len(tc[shadow]))
+	for _, c := range tc[shadow] {
+		if !c.IsGenerated() {
+			shadowCols[c.Field] = true
+		}
+	}
+	var columns []string
+	for _, c := range t.Columns {
+		if shadowCols[c.Field] && !c.IsGenerated() {
+			columns = append(columns, c.Field)
+		}
+	}
+	for _, pk := range t.columnsPK {
+		if !shadowCols[pk] {
+			return nil, errors.NotSupported.Newf("[ddl] OnlineSchemaChange: primary key column %q must exist in shadow table %q", pk, shadow)
+		}
+	}
+	return columns, nil
+}
+
+// writePKTuple writes `(pk1,pk2)` with an optional table qualifier or `(?,?)`.
+func (t *Table) writePKTuple(buf *bytes.Buffer, qualifier string, placeholder bool) {
+	buf.WriteByte('(')
+	for i, pk := range t.columnsPK {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if placeholder {
+			buf.WriteByte('?')
+		} else {
+			dml.Quoter.WriteQualifierName(buf, qualifier, pk)
+		}
+	}
+	buf.WriteByte(')')
+}
+
+// oscChunkEnd returns the primary key of the last row of the next chunk. It
+// returns nil if less than ChunkSize rows are left.
+func (t *Table) oscChunkEnd(ctx context.Context, chunkSize uint64, last []interface{}) (_ []interface{}, err error) {
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+	buf.WriteString("SELECT ")
+	for i, pk := range t.columnsPK {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		dml.Quoter.WriteIdentifier(buf, pk)
+	}
+	buf.WriteString(" FROM ")
+	dml.Quoter.WriteQualifierName(buf, t.Schema, t.Name)
+	if last != nil {
+		buf.WriteString(" WHERE ")
+		t.writePKTuple(buf, "", false)
+		buf.WriteString(" > ")
+		t.writePKTuple(buf, "", true)
+	}
+	buf.WriteString(" ORDER BY ")
+	for i, pk := range t.columnsPK {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		dml.Quoter.WriteIdentifier(buf, pk)
+	}
+	buf.WriteString(" LIMIT 1 OFFSET ")
+	buf.WriteString(strconv.FormatUint(chunkSize-1, 10))
+
+	rows, err := t.db().QueryContext(ctx, buf.String(), last...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] OnlineSchemaChange failed to query chunk of table %q", t.Name)
+	}
+	defer func() {
+		if err2 := rows.Close(); err2 != nil && err == nil {
+			err = errors.WithStack(err2)
+		}
+	}()
+	if !rows.Next() {
+		return nil, errors.WithStack(rows.Err())
+	}
+	raw := make([]sql.RawBytes, len(t.columnsPK))
+	dest := make([]interface{}, len(raw))
+	for i := range raw {
+		dest[i] = &raw[i]
+	}
+	if err = rows.Scan(dest...); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	end := make([]interface{}, len(raw))
+	for i, r := range raw {
+		end[i] = append([]byte(nil), r...) // RawBytes get reused by the next call to Next
+	}
+	return end, errors.WithStack(rows.Err())
+}
+
+// oscCopyRows copies the rows in primary key ordered chunks into the shadow
+// table. Rows already in the shadow table, written by the syncer, get
+// ignored.
+func (t *Table) oscCopyRows(ctx context.Context, osc *OnlineSchemaChange, columns []string) error {
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+	buf.WriteString("INSERT IGNORE INTO ")
+	dml.Quoter.WriteQualifierName(buf, t.Schema, osc.ShadowTable)
+	buf.WriteString(" (")
+	for i, c := range columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		dml.Quoter.WriteIdentifier(buf, c)
+	}
+	buf.WriteString(") SELECT ")
+	for i, c := range columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		dml.Quoter.WriteIdentifier(buf, c)
+	}
+	buf.WriteString(" FROM ")
+	dml.Quoter.WriteQualifierName(buf, t.Schema, t.Name)
+	prefix := buf.String()
+
+	var last []interface{}
+	var copied uint64
+	for {
+		if err := osc.throttle(ctx, t.db()); err != nil {
+			return errors.WithStack(err)
+		}
+		end, err := t.oscChunkEnd(ctx, osc.ChunkSize, last)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		buf.Reset()
+		buf.WriteString(prefix)
+		var args []interface{}
+		if last != nil {
+			buf.WriteString(" WHERE ")
+			t.writePKTuple(buf, "", false)
+			buf.WriteString(" > ")
+			t.writePKTuple(buf, "", true)
+			args = append(args, last...)
+		}
+		if end != nil {
+			if last != nil {
+				buf.WriteString(" AND ")
+			} else {
+				buf.WriteString(" WHERE ")
+			}
+			t.writePKTuple(buf, "", false)
+			buf.WriteString(" <= ")
+			t.writePKTuple(buf, "", true)
+			args = append(args, end...)
+		}
+		buf.WriteString(" LOCK IN SHARE MODE")
+
+		res, err := t.db().ExecContext(ctx, buf.String(), args...)
+		if err != nil {
+			return errors.Wrapf(err, "[ddl] OnlineSchemaChange failed to copy chunk of table %q", t.Name)
+		}
+		if ra, err := res.RowsAffected(); err == nil {
+			copied += uint64(ra)
+		}
+		if osc.Progress != nil {
+			osc.Progress(copied)
+		}
+		if end == nil {
+			return nil
+		}
+		last = end
+	}
+}
+
+// throttle blocks as long as a replica lags behind or the load of the server
+// is too high.
+func (osc *OnlineSchemaChange) throttle(ctx context.Context, db dml.QueryExecPreparer) error {
+	for {
+		reason, err := osc.throttleReason(ctx, db)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if reason == "" {
+			return nil
+		}
+		if osc.ThrottleHandler != nil {
+			osc.ThrottleHandler(reason)
+		}
+		select {
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		case <-time.After(osc.ThrottleInterval):
+		}
+	}
+}
+
+func (osc *OnlineSchemaChange) throttleReason(ctx context.Context, db dml.QueryExecPreparer) (string, error) {
+	for i, r := range osc.Replicas {
+		lag, ok, err := ReplicaLag(ctx, r)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		switch {
+		case !ok:
+			return "replica " + strconv.Itoa(i) + " does not replicate", nil
+		case lag > osc.MaxReplicaLag:
+			return "replica " + strconv.Itoa(i) + " lags " + lag.String() + " behind", nil
+		}
+	}
+	if len(osc.MaxLoad) == 0 {
+		return "", nil
+	}
+
+	names := make([]string, 0, len(osc.MaxLoad))
+	for name := range osc.MaxLoad {
+		names = append(names, name)
+	}
+	vs := NewStatus(names...)
+	if _, err := vs.Show.WithDB(db).WithArgs().Load(ctx, vs); err != nil {
+		return "", errors.Wrap(err, "[ddl] OnlineSchemaChange failed to load the server status")
+	}
+	for name, max := range osc.MaxLoad {
+		if val, ok := vs.Uint64(name); ok && val > max {
+			return "status " + name + " " + strconv.FormatUint(val, 10) + " exceeds " + strconv.FormatUint(max, 10), nil
+		}
+	}
+	return "", nil
+}
+
+// ReplicaLag returns the value of Seconds_Behind_Master of the SHOW SLAVE
+// STATUS statement. The returned bool is false if the server is not a replica
+// or the replication does not run.
+func ReplicaLag(ctx context.Context, db dml.Querier) (lag time.Duration, ok bool, err error) {
+	rows, err := db.QueryContext(ctx, "SHOW SLAVE STATUS")
+	if err != nil {
+		return 0, false, errors.Wrap(err, "[ddl] ReplicaLag QueryContext")
+	}
+	defer func() {
+		if err2 := rows.Close(); err2 != nil && err == nil {
+			err = errors.WithStack(err2)
+		}
+	}()
+
+	rc := new(dml.ColumnMap)
+	for rows.Next() {
+		if err = rc.Scan(rows); err != nil {
+			return 0, false, errors.Wrap(err, "[ddl] ReplicaLag Scan")
+		}
+		for rc.Next() {
+			if rc.Column() == "Seconds_Behind_Master" {
+				var sec null.Int64
+				rc.NullInt64(&sec)
+				lag, ok = time.Duration(sec.Int64)*time.Second, sec.Valid
+			}
+		}
+		if err = rc.Err(); err != nil {
+			return 0, false, errors.WithStack(err)
+		}
+	}
+	return lag, ok, errors.WithStack(rows.Err())
+}
+
+// TriggerSyncer implements OnlineSchemaChangeSyncer and keeps the shadow table
+// in sync with AFTER INSERT, AFTER UPDATE and AFTER DELETE triggers on the
+// original table. The trigger names get created with function TriggerName.
+// The table must not have other triggers for those events, if the server
+// does not support multiple triggers per event.
+type TriggerSyncer struct{}
+
+var oscTriggerEvents = [...]string{"insert", "update", "delete"}
+
+// StartSync creates the triggers.
+func (ts TriggerSyncer) StartSync(ctx context.Context, t *Table, shadow string, columns []string) error {
+	for _, event := range oscTriggerEvents {
+		if err := t.runExec(ctx, ts.createTrigger(t, shadow, event, columns)); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// WaitSync does nothing because triggers run within the same transaction.
+func (TriggerSyncer) WaitSync(context.Context, *Table, string) error { return nil }
+
+// StopSync drops the triggers.
+func (TriggerSyncer) StopSync(ctx context.Context, t *Table, _ string) error {
+	for _, event := range oscTriggerEvents {
+		qry := "DROP TRIGGER IF EXISTS " + dml.Quoter.QualifierName(t.Schema, TriggerName(t.Name, "after", event))
+		if err := t.runExec(ctx, qry); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func (TriggerSyncer) createTrigger(t *Table, shadow, event string, columns []string) string {
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	writeDeleteOld := func() {
+		buf.WriteString("DELETE IGNORE FROM ")
+		dml.Quoter.WriteQualifierName(buf, t.Schema, shadow)
+		buf.WriteString(" WHERE ")
+		for i, pk := range t.columnsPK {
+			if i > 0 {
+				buf.WriteString(" AND ")
+			}
+			dml.Quoter.WriteQualifierName(buf, shadow, pk)
+			buf.WriteString(" <=> ")
+			buf.WriteString("OLD.")
+			dml.Quoter.WriteIdentifier(buf, pk)
+		}
+	}
+	writeReplaceNew := func() {
+		buf.WriteString("REPLACE INTO ")
+		dml.Quoter.WriteQualifierName(buf, t.Schema, shadow)
+		buf.WriteString(" (")
+		for i, c := range columns {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			dml.Quoter.WriteIdentifier(buf, c)
+		}
+		buf.WriteString(") VALUES (")
+		for i, c := range columns {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString("NEW.")
+			dml.Quoter.WriteIdentifier(buf, c)
+		}
+		buf.WriteByte(')')
+	}
+
+	buf.WriteString("CREATE TRIGGER ")
+	dml.Quoter.WriteQualifierName(buf, t.Schema, TriggerName(t.Name, "after", event))
+	buf.WriteString(" AFTER ")
+	buf.WriteString(strings.ToUpper(event))
+	buf.WriteString(" ON ")
+	dml.Quoter.WriteQualifierName(buf, t.Schema, t.Name)
+	buf.WriteString(" FOR EACH ROW ")
+	switch event {
+	case "insert":
+		writeReplaceNew()
+	case "update":
+		// The primary key might have been changed, so the old row must be
+		// removed.
+		buf.WriteString("BEGIN ")
+		writeDeleteOld()
+		buf.WriteString("; ")
+		writeReplaceNew()
+		buf.WriteString("; END")
+	case "delete":
+		writeDeleteOld()
+	}
+	return buf.String()
+}
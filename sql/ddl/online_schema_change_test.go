@@ -0,0 +1,198 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func newOSCTable() *ddl.Table {
+	return ddl.NewTable("catalog_product_entity_varchar",
+		&ddl.Column{Field: "value_id", Key: "PRI", Extra: "auto_increment"},
+		&ddl.Column{Field: "store_id"},
+		&ddl.Column{Field: "value"},
+		&ddl.Column{Field: "legacy_flag"},
+	)
+}
+
+func expectOSCShadowColumns(dbMock sqlmock.Sqlmock, schemaCond string) {
+	dbMock.ExpectQuery("SELECT.+FROM information_schema.COLUMNS WHERE TABLE_SCHEMA=" + schemaCond + " AND TABLE_NAME.+").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "COLUMN_NAME", "ORDINAL_POSITION", "COLUMN_DEFAULT", "IS_NULLABLE", "DATA_TYPE", "CHARACTER_MAXIMUM_LENGTH", "NUMERIC_PRECISION", "NUMERIC_SCALE", "COLUMN_TYPE", "COLUMN_KEY", "EXTRA", "COLUMN_COMMENT"}).
+			FromCSVString(`"_catalog_product_entity_varchar_new","value_id",1,NULL,"NO","int",0,10,0,"int(10) unsigned","PRI","auto_increment",""
+"_catalog_product_entity_varchar_new","store_id",2,0,"NO","smallint",0,5,0,"smallint(5) unsigned","","",""
+"_catalog_product_entity_varchar_new","value",3,NULL,"YES","varchar",255,0,0,"varchar(255)","","",""
+"_catalog_product_entity_varchar_new","is_default",4,0,"NO","tinyint",0,3,0,"tinyint(1)","","",""
+`))
+}
+
+func TestTable_OnlineSchemaChange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("triggers and throttling", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+		replica, replicaMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, replica, replicaMock)
+
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE `_catalog_product_entity_varchar_new` LIKE `catalog_product_entity_varchar`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `_catalog_product_entity_varchar_new` ADD COLUMN `is_default` tinyint(1) NOT NULL DEFAULT 0, DROP COLUMN `legacy_flag`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		expectOSCShadowColumns(dbMock, "DATABASE\\(\\)")
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TRIGGER `catalog_product_entity_varchar_after_insert` AFTER INSERT ON `catalog_product_entity_varchar` FOR EACH ROW REPLACE INTO `_catalog_product_entity_varchar_new` (`value_id`,`store_id`,`value`) VALUES (NEW.`value_id`,NEW.`store_id`,NEW.`value`)")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TRIGGER `catalog_product_entity_varchar_after_update` AFTER UPDATE ON `catalog_product_entity_varchar` FOR EACH ROW BEGIN DELETE IGNORE FROM `_catalog_product_entity_varchar_new` WHERE `_catalog_product_entity_varchar_new`.`value_id` <=> OLD.`value_id`; REPLACE INTO `_catalog_product_entity_varchar_new` (`value_id`,`store_id`,`value`) VALUES (NEW.`value_id`,NEW.`store_id`,NEW.`value`); END")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TRIGGER `catalog_product_entity_varchar_after_delete` AFTER DELETE ON `catalog_product_entity_varchar` FOR EACH ROW DELETE IGNORE FROM `_catalog_product_entity_varchar_new` WHERE `_catalog_product_entity_varchar_new`.`value_id` <=> OLD.`value_id`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// first chunk: the replica lags behind, then the server is too busy.
+		replicaMock.ExpectQuery("SHOW SLAVE STATUS").
+			WillReturnRows(sqlmock.NewRows([]string{"Slave_IO_State", "Seconds_Behind_Master"}).AddRow("Waiting for master", 30))
+		replicaMock.ExpectQuery("SHOW SLAVE STATUS").
+			WillReturnRows(sqlmock.NewRows([]string{"Slave_IO_State", "Seconds_Behind_Master"}).AddRow("Waiting for master", 1))
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SHOW GLOBAL STATUS WHERE (`Variable_name` LIKE 'Threads_running')")).
+			WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow("Threads_running", "40"))
+		replicaMock.ExpectQuery("SHOW SLAVE STATUS").
+			WillReturnRows(sqlmock.NewRows([]string{"Slave_IO_State", "Seconds_Behind_Master"}).AddRow("Waiting for master", 0))
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SHOW GLOBAL STATUS WHERE (`Variable_name` LIKE 'Threads_running')")).
+			WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow("Threads_running", "3"))
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `value_id` FROM `catalog_product_entity_varchar` ORDER BY `value_id` LIMIT 1 OFFSET 1")).
+			WillReturnRows(sqlmock.NewRows([]string{"value_id"}).AddRow(2))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT IGNORE INTO `_catalog_product_entity_varchar_new` (`value_id`,`store_id`,`value`) SELECT `value_id`,`store_id`,`value` FROM `catalog_product_entity_varchar` WHERE (`value_id`) <= (?) LOCK IN SHARE MODE")).
+			WithArgs([]byte("2")).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		// second and last chunk
+		replicaMock.ExpectQuery("SHOW SLAVE STATUS").
+			WillReturnRows(sqlmock.NewRows([]string{"Slave_IO_State", "Seconds_Behind_Master"}).AddRow("Waiting for master", 0))
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SHOW GLOBAL STATUS WHERE (`Variable_name` LIKE 'Threads_running')")).
+			WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow("Threads_running", "3"))
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `value_id` FROM `catalog_product_entity_varchar` WHERE (`value_id`) > (?) ORDER BY `value_id` LIMIT 1 OFFSET 1")).
+			WithArgs([]byte("2")).
+			WillReturnRows(sqlmock.NewRows([]string{"value_id"}))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT IGNORE INTO `_catalog_product_entity_varchar_new` (`value_id`,`store_id`,`value`) SELECT `value_id`,`store_id`,`value` FROM `catalog_product_entity_varchar` WHERE (`value_id`) > (?) LOCK IN SHARE MODE")).
+			WithArgs([]byte("2")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectExec("RENAME TABLE `catalog_product_entity_varchar` TO `catalog_product_entity_varchar_[0-9]+`, `_catalog_product_entity_varchar_new` TO `catalog_product_entity_varchar`,`catalog_product_entity_varchar_[0-9]+` TO `_catalog_product_entity_varchar_new`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		for _, event := range []string{"insert", "update", "delete"} {
+			dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TRIGGER IF EXISTS `catalog_product_entity_varchar_after_" + event + "`")).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE IF EXISTS `_catalog_product_entity_varchar_new`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		var reasons []string
+		var progress []uint64
+		err := newOSCTable().WithDB(dbc.DB).OnlineSchemaChange(context.TODO(), ddl.OnlineSchemaChange{
+			Alter:            "ADD COLUMN `is_default` tinyint(1) NOT NULL DEFAULT 0, DROP COLUMN `legacy_flag`",
+			ChunkSize:        2,
+			Replicas:         []dml.Querier{replica.DB},
+			MaxLoad:          map[string]uint64{"Threads_running": 25},
+			ThrottleInterval: time.Millisecond,
+			ThrottleHandler:  func(reason string) { reasons = append(reasons, reason) },
+			Progress:         func(copied uint64) { progress = append(progress, copied) },
+		})
+		assert.NoError(t, err, "%+v", err)
+		assert.Exactly(t, []string{"replica 0 lags 30s behind", "status Threads_running 40 exceeds 25"}, reasons)
+		assert.Exactly(t, []uint64{2, 3}, progress)
+	})
+
+	t.Run("shadow table in the schema of the table", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE `shop`.`_catalog_product_entity_varchar_new` LIKE `shop`.`catalog_product_entity_varchar`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `shop`.`_catalog_product_entity_varchar_new` DROP COLUMN `legacy_flag`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		expectOSCShadowColumns(dbMock, "'shop'")
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TRIGGER `shop`.`catalog_product_entity_varchar_after_insert` AFTER INSERT ON `shop`.`catalog_product_entity_varchar` FOR EACH ROW REPLACE INTO `shop`.`_catalog_product_entity_varchar_new` (`value_id`,`store_id`,`value`) VALUES (NEW.`value_id`,NEW.`store_id`,NEW.`value`)")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TRIGGER `shop`.`catalog_product_entity_varchar_after_update` AFTER UPDATE ON `shop`.`catalog_product_entity_varchar` FOR EACH ROW BEGIN DELETE IGNORE FROM `shop`.`_catalog_product_entity_varchar_new` WHERE `_catalog_product_entity_varchar_new`.`value_id` <=> OLD.`value_id`; REPLACE INTO `shop`.`_catalog_product_entity_varchar_new`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TRIGGER `shop`.`catalog_product_entity_varchar_after_delete` AFTER DELETE ON `shop`.`catalog_product_entity_varchar` FOR EACH ROW DELETE IGNORE FROM `shop`.`_catalog_product_entity_varchar_new` WHERE")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `value_id` FROM `shop`.`catalog_product_entity_varchar` ORDER BY `value_id` LIMIT 1 OFFSET 999")).
+			WillReturnRows(sqlmock.NewRows([]string{"value_id"}))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT IGNORE INTO `shop`.`_catalog_product_entity_varchar_new` (`value_id`,`store_id`,`value`) SELECT `value_id`,`store_id`,`value` FROM `shop`.`catalog_product_entity_varchar` LOCK IN SHARE MODE")).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		dbMock.ExpectExec("RENAME TABLE `shop`.`catalog_product_entity_varchar` TO `shop`.`catalog_product_entity_varchar_[0-9]+`, `shop`.`_catalog_product_entity_varchar_new` TO `shop`.`catalog_product_entity_varchar`,`shop`.`catalog_product_entity_varchar_[0-9]+` TO `shop`.`_catalog_product_entity_varchar_new`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		for _, event := range []string{"insert", "update", "delete"} {
+			dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TRIGGER IF EXISTS `shop`.`catalog_product_entity_varchar_after_" + event + "`")).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE IF EXISTS `shop`.`_catalog_product_entity_varchar_new`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		tbl := newOSCTable()
+		tbl.Schema = "shop"
+		err := tbl.WithDB(dbc.DB).OnlineSchemaChange(context.TODO(), ddl.OnlineSchemaChange{
+			Alter: "DROP COLUMN `legacy_flag`",
+		})
+		assert.NoError(t, err, "%+v", err)
+	})
+
+	t.Run("ALTER fails drops shadow table", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE `cpev_tmp` LIKE `catalog_product_entity_varchar`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `cpev_tmp` ADD COLUMN")).
+			WillReturnError(errors.New("You have an error in your SQL syntax"))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE IF EXISTS `cpev_tmp`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := newOSCTable().WithDB(dbc.DB).OnlineSchemaChange(context.TODO(), ddl.OnlineSchemaChange{
+			Alter:       "ADD COLUMN",
+			ShadowTable: "cpev_tmp",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("requires primary key", func(t *testing.T) {
+		err := ddl.NewTable("catalog_product_entity_varchar", &ddl.Column{Field: "value"}).
+			OnlineSchemaChange(context.TODO(), ddl.OnlineSchemaChange{Alter: "ADD COLUMN x int"})
+		assert.ErrorIsKind(t, errors.NotSupported, err)
+	})
+}
+
+func TestReplicaLag(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectQuery("SHOW SLAVE STATUS").
+		WillReturnRows(sqlmock.NewRows([]string{"Slave_IO_State", "Seconds_Behind_Master"}).AddRow("", nil))
+
+	lag, ok, err := ddl.ReplicaLag(context.TODO(), dbc.DB)
+	assert.NoError(t, err)
+	assert.False(t, ok, "replication stopped")
+	assert.Exactly(t, time.Duration(0), lag)
+}
@@ -0,0 +1,576 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/null"
+)
+
+// Partition methods supported by MySQL and MariaDB.
+const (
+	PartitionRange        = "RANGE"
+	PartitionRangeColumns = "RANGE COLUMNS"
+	PartitionList         = "LIST"
+	PartitionListColumns  = "LIST COLUMNS"
+	PartitionHash         = "HASH"
+	PartitionKey          = "KEY"
+	// PartitionMaxValue defines the upper bound of the last RANGE partition
+	// which catches all remaining values.
+	PartitionMaxValue = "MAXVALUE"
+)
+
+// Partition defines a single partition of a table. The fields
+// OrdinalPosition, Expression, TableRows, DataLength and IndexLength get only
+// set when loaded from information_schema.PARTITIONS.
+type Partition struct {
+	Name string
+	// Method contains the partition method, see the constants Partition*. If
+	// empty and a Description has been set, RANGE gets used.
+	Method string
+	// Description contains for RANGE partitions the upper bound used in
+	// `VALUES LESS THAN (Description)`, like `TO_DAYS('2019-01-01')` or
+	// MAXVALUE. For LIST partitions the comma separated list of `VALUES IN
+	// (Description)`. Empty for HASH and KEY partitions.
+	Description     string
+	Comment         string
+	OrdinalPosition uint64
+	Expression      string
+	TableRows       uint64
+	DataLength      uint64
+	IndexLength     uint64
+}
+
+func (p *Partition) method(defaultMethod string) string {
+	switch {
+	case p.Method != "":
+		return strings.ToUpper(p.Method)
+	case defaultMethod != "":
+		return defaultMethod
+	case p.Description != "":
+		return PartitionRange
+	}
+	return ""
+}
+
+// writeDefinition writes the partition definition for a CREATE or ALTER
+// statement.
+func (p *Partition) writeDefinition(buf *bytes.Buffer, defaultMethod string) error {
+	if err := dml.IsValidIdentifier(p.Name); err != nil {
+		return errors.WithStack(err)
+	}
+	buf.WriteString("PARTITION ")
+	dml.Quoter.WriteIdentifier(buf, p.Name)
+	switch m := p.method(defaultMethod); m {
+	case PartitionRange, PartitionRangeColumns:
+		if p.Description == "" {
+			return errors.Empty.Newf("[ddl] %s partition %q requires a Description", m, p.Name)
+		}
+		buf.WriteString(" VALUES LESS THAN (")
+		buf.WriteString(p.Description)
+		buf.WriteByte(')')
+	case PartitionList, PartitionListColumns:
+		if p.Description == "" {
+			return errors.Empty.Newf("[ddl] %s partition %q requires a Description", m, p.Name)
+		}
+		buf.WriteString(" VALUES IN (")
+		buf.WriteString(p.Description)
+		buf.WriteByte(')')
+	}
+	if p.Comment != "" {
+		buf.WriteString(" COMMENT '")
+		buf.WriteString(strings.Replace(p.Comment, "'", "''", -1))
+		buf.WriteByte('\'')
+	}
+	return nil
+}
+
+// Partitions a list of partitions.
+type Partitions []*Partition
+
+// Names returns all partition names.
+func (ps Partitions) Names() []string {
+	names := make([]string, len(ps))
+	for i, p := range ps {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// ByName returns a partition by its name or nil.
+func (ps Partitions) ByName(name string) *Partition {
+	for _, p := range ps {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (ps Partitions) writeDefinitions(buf *bytes.Buffer, defaultMethod string) error {
+	buf.WriteByte('(')
+	for i, p := range ps {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if err := p.writeDefinition(buf, defaultMethod); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	buf.WriteByte(')')
+	return nil
+}
+
+// PartitionBy defines how a table gets partitioned.
+type PartitionBy struct {
+	// Method one of the constants Partition*.
+	Method string
+	// Expression contains the expression or, for the COLUMNS and KEY methods,
+	// the comma separated column list. Column names do NOT get quoted. E.g.
+	// `TO_DAYS(created_at)` or `store_id`.
+	Expression string
+	// Count sets the number of partitions for HASH and KEY methods if no
+	// Partitions have been defined.
+	Count int
+	// Partitions defines the partitions. The Method of a Partition defaults to
+	// the Method of PartitionBy.
+	Partitions Partitions
+}
+
+func (t *Table) alterPartitionSQL(fn func(buf *bytes.Buffer) error) (string, error) {
+	if err := dml.IsValidIdentifier(t.Name); err != nil {
+		return "", errors.WithStack(err)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	dml.Quoter.WriteQualifierName(&buf, t.Schema, t.Name)
+	buf.WriteByte(' ')
+	if err := fn(&buf); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return buf.String(), nil
+}
+
+func (t *Table) alterPartition(ctx context.Context, fn func(buf *bytes.Buffer) error) error {
+	if t.IsView {
+		return errors.NotSupported.Newf("[ddl] View %q cannot be partitioned", t.Name)
+	}
+	qry, err := t.alterPartitionSQL(fn)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return t.runExec(ctx, qry)
+}
+
+func writePartitionNames(buf *bytes.Buffer, names []string) error {
+	if len(names) == 0 {
+		return errors.Empty.Newf("[ddl] Partition names cannot be empty")
+	}
+	for i, n := range names {
+		if err := dml.IsValidIdentifier(n); err != nil {
+			return errors.WithStack(err)
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		dml.Quoter.WriteIdentifier(buf, n)
+	}
+	return nil
+}
+
+// PartitionBy partitions the table or changes the partitioning of an already
+// partitioned table. All rows get copied. To use a custom connection, call
+// WithDB before.
+func (t *Table) PartitionBy(ctx context.Context, pb PartitionBy) error {
+	return t.alterPartition(ctx, func(buf *bytes.Buffer) error {
+		m := strings.ToUpper(pb.Method)
+		switch m {
+		case PartitionRange, PartitionRangeColumns, PartitionList, PartitionListColumns, PartitionHash, PartitionKey:
+		default:
+			return errors.NotSupported.Newf("[ddl] Partition method %q not supported", pb.Method)
+		}
+		buf.WriteString("PARTITION BY ")
+		buf.WriteString(m)
+		buf.WriteString(" (")
+		buf.WriteString(pb.Expression)
+		buf.WriteByte(')')
+		switch {
+		case len(pb.Partitions) > 0:
+			buf.WriteByte(' ')
+			return pb.Partitions.writeDefinitions(buf, m)
+		case pb.Count > 0 && (m == PartitionHash || m == PartitionKey):
+			buf.WriteString(" PARTITIONS ")
+			buf.WriteString(strconv.Itoa(pb.Count))
+			return nil
+		}
+		return errors.Empty.Newf("[ddl] PartitionBy %s requires Partitions or a Count", m)
+	})
+}
+
+// RemovePartitioning removes the partitioning without affecting the data. To
+// use a custom connection, call WithDB before.
+func (t *Table) RemovePartitioning(ctx context.Context) error {
+	return t.alterPartition(ctx, func(buf *bytes.Buffer) error {
+		buf.WriteString("REMOVE PARTITIONING")
+		return nil
+	})
+}
+
+// AddPartitions adds new partitions. New RANGE partitions must have a higher
+// upper bound than the existing ones. If the table contains a MAXVALUE
+// partition use ReorganizePartitions. To use a custom connection, call WithDB
+// before.
+func (t *Table) AddPartitions(ctx context.Context, ps ...*Partition) error {
+	return t.alterPartition(ctx, func(buf *bytes.Buffer) error {
+		if len(ps) == 0 {
+			return errors.Empty.Newf("[ddl] AddPartitions requires at least one partition")
+		}
+		buf.WriteString("ADD PARTITION ")
+		return Partitions(ps).writeDefinitions(buf, "")
+	})
+}
+
+// DropPartitions drops the partitions and all their data. To use a custom
+// connection, call WithDB before.
+func (t *Table) DropPartitions(ctx context.Context, names ...string) error {
+	return t.alterPartition(ctx, func(buf *bytes.Buffer) error {
+		buf.WriteString("DROP PARTITION ")
+		return writePartitionNames(buf, names)
+	})
+}
+
+// TruncatePartitions removes all rows from the partitions. To use a custom
+// connection, call WithDB before.
+func (t *Table) TruncatePartitions(ctx context.Context, names ...string) error {
+	return t.alterPartition(ctx, func(buf *bytes.Buffer) error {
+		buf.WriteString("TRUNCATE PARTITION ")
+		return writePartitionNames(buf, names)
+	})
+}
+
+// ReorganizePartitions merges or splits the partitions `names` into the new
+// partitions `into` without losing data. To use a custom connection, call
+// WithDB before.
+func (t *Table) ReorganizePartitions(ctx context.Context, names []string, into ...*Partition) error {
+	return t.alterPartition(ctx, func(buf *bytes.Buffer) error {
+		if len(into) == 0 {
+			return errors.Empty.Newf("[ddl] ReorganizePartitions requires at least one new partition")
+		}
+		buf.WriteString("REORGANIZE PARTITION ")
+		if err := writePartitionNames(buf, names); err != nil {
+			return errors.WithStack(err)
+		}
+		buf.WriteString(" INTO ")
+		return Partitions(into).writeDefinitions(buf, "")
+	})
+}
+
+const (
+	selPartitionsBase = `SELECT PARTITION_NAME, PARTITION_ORDINAL_POSITION, PARTITION_METHOD,
+	PARTITION_EXPRESSION, PARTITION_DESCRIPTION, TABLE_ROWS, DATA_LENGTH, INDEX_LENGTH, PARTITION_COMMENT
+	FROM information_schema.PARTITIONS WHERE `
+	selPartitionsOrderBy  = ` AND TABLE_NAME = ? AND PARTITION_NAME IS NOT NULL ORDER BY PARTITION_ORDINAL_POSITION`
+	selPartitions         = selPartitionsBase + `TABLE_SCHEMA = DATABASE()` + selPartitionsOrderBy
+	selPartitionsBySchema = selPartitionsBase + `TABLE_SCHEMA = ?` + selPartitionsOrderBy
+)
+
+// LoadPartitions loads all partitions of the table from
+// information_schema.PARTITIONS ordered by their position. Returns an empty
+// slice if the table is not partitioned. To use a custom connection, call
+// WithDB before.
+func (t *Table) LoadPartitions(ctx context.Context) (_ Partitions, err error) {
+	var sqlStr string
+	if t.Schema != "" {
+		sqlStr, _, err = dml.Interpolate(selPartitionsBySchema).Str(t.Schema).Str(t.Name).ToSQL()
+	} else {
+		sqlStr, _, err = dml.Interpolate(selPartitions).Str(t.Name).ToSQL()
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadPartitions for table %q", t.Name)
+	}
+
+	var rows *sql.Rows
+	rows, err = t.db().QueryContext(ctx, sqlStr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ddl] LoadPartitions QueryContext for table %q", t.Name)
+	}
+	defer func() {
+		if err2 := rows.Close(); err2 != nil && err == nil {
+			err = errors.Wrap(err2, "[ddl] LoadPartitions.Rows.Close")
+		}
+	}()
+
+	var ps Partitions
+	rc := new(dml.ColumnMap)
+	for rows.Next() {
+		if err = rc.Scan(rows); err != nil {
+			return nil, errors.Wrapf(err, "[ddl] LoadPartitions Scan Query for table %q", t.Name)
+		}
+		p := new(Partition)
+		var method, expression, description null.String
+		var pos null.Uint64
+		for rc.Next() {
+			switch col := rc.Column(); col {
+			case "PARTITION_NAME":
+				rc.String(&p.Name)
+			case "PARTITION_ORDINAL_POSITION":
+				rc.NullUint64(&pos)
+			case "PARTITION_METHOD":
+				rc.NullString(&method)
+			case "PARTITION_EXPRESSION":
+				rc.NullString(&expression)
+			case "PARTITION_DESCRIPTION":
+				rc.NullString(&description)
+			case "TABLE_ROWS":
+				rc.Uint64(&p.TableRows)
+			case "DATA_LENGTH":
+				rc.Uint64(&p.DataLength)
+			case "INDEX_LENGTH":
+				rc.Uint64(&p.IndexLength)
+			case "PARTITION_COMMENT":
+				rc.String(&p.Comment)
+			default:
+				return nil, errors.NotSupported.Newf("[ddl] LoadPartitions Column %q not supported", col)
+			}
+		}
+		if err = rc.Err(); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		p.OrdinalPosition = pos.Uint64
+		p.Method = method.String
+		p.Expression = expression.String
+		p.Description = description.String
+		ps = append(ps, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return ps, nil
+}
+
+// PartitionRotation defines the rolling time based maintenance of RANGE
+// partitions. Each partition covers one period and gets named by the Prefix
+// and the start of the period, like `p20190101`. The upper bound of a
+// partition is the start of the next period.
+type PartitionRotation struct {
+	// Years, Months and Days define the length of the period of a partition.
+	// The start of a period gets aligned to the first day of a year, to the
+	// first day of a month or to midnight. Periods longer than one unit get
+	// aligned to a fixed epoch, hence each run creates the same periods: Years
+	// to the year zero, Months to January of the year zero and Days to
+	// 1970-01-01. Defaults to one month.
+	Years, Months, Days int
+	// Ahead defines the number of future partitions which get created
+	// additionally to the partition of the current period.
+	Ahead int
+	// Retention defines the number of past partitions to keep. Older
+	// partitions get dropped. Zero keeps all partitions.
+	Retention int
+	// Prefix of the partition name, defaults to `p`.
+	Prefix string
+	// NameLayout time layout of the partition name after the prefix, defaults
+	// to `20060102`.
+	NameLayout string
+	// LessThan returns the upper bound for `VALUES LESS THAN` of a partition
+	// which ends before the time argument. Defaults to `TO_DAYS('2006-01-02')`
+	// which matches a partition expression like `TO_DAYS(created_at)`.
+	LessThan func(time.Time) string
+	// Location defaults to UTC.
+	Location *time.Location
+	// Now returns the current time. Defaults to time.Now. Useful for testing.
+	Now func() time.Time
+	// Every defines the interval of RunPartitionMaintainer. Defaults to one
+	// hour.
+	Every time.Duration
+	// ErrorHandler gets called by RunPartitionMaintainer if the maintenance
+	// fails. If nil, RunPartitionMaintainer returns the error.
+	ErrorHandler func(error)
+}
+
+func (pr *PartitionRotation) setDefaults() {
+	if pr.Years <= 0 && pr.Months <= 0 && pr.Days <= 0 {
+		pr.Months = 1
+	}
+	if pr.Prefix == "" {
+		pr.Prefix = "p"
+	}
+	if pr.NameLayout == "" {
+		pr.NameLayout = "20060102"
+	}
+	if pr.LessThan == nil {
+		pr.LessThan = func(t time.Time) string {
+			return "TO_DAYS('" + t.Format("2006-01-02") + "')"
+		}
+	}
+	if pr.Location == nil {
+		pr.Location = time.UTC
+	}
+	if pr.Now == nil {
+		pr.Now = time.Now
+	}
+	if pr.Every <= 0 {
+		pr.Every = time.Hour
+	}
+}
+
+// periodStart returns the aligned start of the period which contains t and
+// adds n periods.
+func (pr *PartitionRotation) periodStart(t time.Time, n int) time.Time {
+	t = t.In(pr.Location)
+	y, m, d := t.Date()
+	switch {
+	case pr.Years > 0:
+		y -= floorMod(y, pr.Years)
+		m, d = time.January, 1
+	case pr.Months > 0:
+		months := y*12 + int(m-time.January)
+		months -= floorMod(months, pr.Months)
+		y, m, d = months/12, time.January+time.Month(months%12), 1
+	case pr.Days > 1:
+		days := int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400) // days since 1970-01-01
+		days -= floorMod(days, pr.Days)
+		y, m, d = time.Unix(int64(days)*86400, 0).UTC().Date()
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, pr.Location).AddDate(n*pr.Years, n*pr.Months, n*pr.Days)
+}
+
+// floorMod returns the non-negative remainder of a divided by b, also for
+// negative a.
+func floorMod(a, b int) int {
+	if r := a % b; r < 0 {
+		return r + b
+	}
+	return a % b
+}
+
+// parseName returns the start of the period of a partition managed by the
+// rotation.
+func (pr *PartitionRotation) parseName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, pr.Prefix) {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(pr.NameLayout, name[len(pr.Prefix):], pr.Location)
+	return t, err == nil
+}
+
+// MaintainPartitions creates the partitions for the current and the next
+// PartitionRotation.Ahead periods and drops the partitions older than
+// PartitionRotation.Retention periods. The table must already be partitioned
+// by RANGE. If the table has a MAXVALUE partition, it gets reorganized into
+// the new partitions. Partitions whose name does not match the prefix and the
+// layout stay untouched. It returns the names of the added and dropped
+// partitions. To use a custom connection, call WithDB before.
+func (t *Table) MaintainPartitions(ctx context.Context, pr PartitionRotation) (added, dropped []string, err error) {
+	pr.setDefaults()
+	ps, err := t.LoadPartitions(ctx)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	if len(ps) == 0 {
+		return nil, nil, errors.NotValid.Newf("[ddl] Table %q is not partitioned. Use PartitionBy first.", t.Name)
+	}
+
+	var maxValue *Partition
+	var lastEnd time.Time // end of the newest managed partition
+	var expired []string
+	now := pr.Now()
+	cutoff := pr.periodStart(now, -pr.Retention)
+	for _, p := range ps {
+		if strings.ToUpper(p.Description) == PartitionMaxValue {
+			maxValue = p
+			continue
+		}
+		start, ok := pr.parseName(p.Name)
+		if !ok {
+			continue
+		}
+		end := pr.periodStart(start, 1)
+		if end.After(lastEnd) {
+			lastEnd = end
+		}
+		if pr.Retention > 0 && !end.After(cutoff) {
+			expired = append(expired, p.Name)
+		}
+	}
+
+	var newPS Partitions
+	for i := 0; i <= pr.Ahead; i++ {
+		start := pr.periodStart(now, i)
+		if start.Before(lastEnd) {
+			continue
+		}
+		newPS = append(newPS, &Partition{
+			Name:        pr.Prefix + start.Format(pr.NameLayout),
+			Method:      PartitionRange,
+			Description: pr.LessThan(pr.periodStart(start, 1)),
+		})
+	}
+
+	switch {
+	case len(newPS) == 0:
+	case maxValue != nil:
+		err = t.ReorganizePartitions(ctx, []string{maxValue.Name}, append(newPS, &Partition{
+			Name:        maxValue.Name,
+			Method:      PartitionRange,
+			Description: PartitionMaxValue,
+			Comment:     maxValue.Comment,
+		})...)
+	default:
+		err = t.AddPartitions(ctx, newPS...)
+	}
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	added = newPS.Names()
+
+	if len(expired) > 0 {
+		if err = t.DropPartitions(ctx, expired...); err != nil {
+			return added, nil, errors.WithStack(err)
+		}
+	}
+	return added, expired, nil
+}
+
+// RunPartitionMaintainer calls MaintainPartitions immediately and then every
+// PartitionRotation.Every until the context gets cancelled. It blocks, so run
+// it in its own goroutine. Errors get passed to
+// PartitionRotation.ErrorHandler, if set, otherwise the first error gets
+// returned.
+func (t *Table) RunPartitionMaintainer(ctx context.Context, pr PartitionRotation) error {
+	pr.setDefaults()
+	ticker := time.NewTicker(pr.Every)
+	defer ticker.Stop()
+	for {
+		if _, _, err := t.MaintainPartitions(ctx, pr); err != nil {
+			if pr.ErrorHandler == nil {
+				return errors.WithStack(err)
+			}
+			pr.ErrorHandler(err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
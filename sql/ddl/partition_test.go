@@ -0,0 +1,288 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestTable_PartitionBy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RANGE", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` PARTITION BY RANGE (TO_DAYS(created_at)) (PARTITION `p2018` VALUES LESS THAN (TO_DAYS('2019-01-01')), PARTITION `pmax` VALUES LESS THAN (MAXVALUE) COMMENT 'Can''t touch')")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := ddl.NewTable("sales_order_archive").WithDB(dbc.DB).PartitionBy(context.TODO(), ddl.PartitionBy{
+			Method:     ddl.PartitionRange,
+			Expression: "TO_DAYS(created_at)",
+			Partitions: ddl.Partitions{
+				{Name: "p2018", Description: "TO_DAYS('2019-01-01')"},
+				{Name: "pmax", Description: ddl.PartitionMaxValue, Comment: "Can't touch"},
+			},
+		})
+		assert.NoError(t, err, "%+v", err)
+	})
+
+	t.Run("LIST COLUMNS", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` PARTITION BY LIST COLUMNS (store_id) (PARTITION `p_eu` VALUES IN (1,2), PARTITION `p_us` VALUES IN (3))")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := ddl.NewTable("sales_order_archive").WithDB(dbc.DB).PartitionBy(context.TODO(), ddl.PartitionBy{
+			Method:     ddl.PartitionListColumns,
+			Expression: "store_id",
+			Partitions: ddl.Partitions{
+				{Name: "p_eu", Description: "1,2"},
+				{Name: "p_us", Description: "3"},
+			},
+		})
+		assert.NoError(t, err, "%+v", err)
+	})
+
+	t.Run("HASH", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` PARTITION BY HASH (customer_id) PARTITIONS 8")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := ddl.NewTable("sales_order_archive").WithDB(dbc.DB).PartitionBy(context.TODO(), ddl.PartitionBy{
+			Method:     ddl.PartitionHash,
+			Expression: "customer_id",
+			Count:      8,
+		})
+		assert.NoError(t, err, "%+v", err)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tbl := ddl.NewTable("sales_order_archive")
+		err := tbl.PartitionBy(context.TODO(), ddl.PartitionBy{Method: "LINEAR"})
+		assert.ErrorIsKind(t, errors.NotSupported, err)
+
+		err = tbl.PartitionBy(context.TODO(), ddl.PartitionBy{Method: ddl.PartitionRange, Expression: "id"})
+		assert.ErrorIsKind(t, errors.Empty, err)
+
+		err = tbl.PartitionBy(context.TODO(), ddl.PartitionBy{
+			Method:     ddl.PartitionRange,
+			Expression: "id",
+			Partitions: ddl.Partitions{{Name: "p0"}},
+		})
+		assert.ErrorIsKind(t, errors.Empty, err)
+
+		tbl.IsView = true
+		err = tbl.RemovePartitioning(context.TODO())
+		assert.ErrorIsKind(t, errors.NotSupported, err)
+	})
+}
+
+func TestTable_AlterPartitions(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+	tbl := ddl.NewTable("sales_order_archive").WithDB(dbc.DB)
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` ADD PARTITION (PARTITION `p2019` VALUES LESS THAN (TO_DAYS('2020-01-01')))")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.NoError(t, tbl.AddPartitions(context.TODO(), &ddl.Partition{Name: "p2019", Description: "TO_DAYS('2020-01-01')"}))
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` REORGANIZE PARTITION `p2018`,`p2019` INTO (PARTITION `p2019` VALUES LESS THAN (TO_DAYS('2020-01-01')))")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.NoError(t, tbl.ReorganizePartitions(context.TODO(), []string{"p2018", "p2019"}, &ddl.Partition{Name: "p2019", Description: "TO_DAYS('2020-01-01')"}))
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` TRUNCATE PARTITION `p2019`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.NoError(t, tbl.TruncatePartitions(context.TODO(), "p2019"))
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` DROP PARTITION `p2017`,`p2018`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.NoError(t, tbl.DropPartitions(context.TODO(), "p2017", "p2018"))
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` REMOVE PARTITIONING")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.NoError(t, tbl.RemovePartitioning(context.TODO()))
+
+	assert.ErrorIsKind(t, errors.Empty, tbl.DropPartitions(context.TODO()))
+	assert.ErrorIsKind(t, errors.NotValid, tbl.DropPartitions(context.TODO(), "p2017™"))
+}
+
+var partitionColumns = []string{"PARTITION_NAME", "PARTITION_ORDINAL_POSITION", "PARTITION_METHOD",
+	"PARTITION_EXPRESSION", "PARTITION_DESCRIPTION", "TABLE_ROWS", "DATA_LENGTH", "INDEX_LENGTH", "PARTITION_COMMENT"}
+
+func partitionRow(name string, pos int, description string) []driver.Value {
+	return []driver.Value{name, pos, "RANGE", "to_days(`created_at`)", description, 10 * pos, 16384, 0, ""}
+}
+
+func TestTable_LoadPartitions(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectQuery("SELECT PARTITION_NAME.+FROM information_schema.PARTITIONS WHERE TABLE_SCHEMA = 'archive' AND TABLE_NAME = 'sales_order'").
+		WillReturnRows(sqlmock.NewRows(partitionColumns).
+			AddRow(partitionRow("p20190101", 1, "737425")...).
+			AddRow(partitionRow("pmax", 2, "MAXVALUE")...))
+
+	tbl := ddl.NewTable("sales_order").WithDB(dbc.DB)
+	tbl.Schema = "archive"
+	ps, err := tbl.LoadPartitions(context.TODO())
+	assert.NoError(t, err, "%+v", err)
+	assert.Exactly(t, []string{"p20190101", "pmax"}, ps.Names())
+	assert.Exactly(t, &ddl.Partition{
+		Name:            "pmax",
+		Method:          "RANGE",
+		Description:     "MAXVALUE",
+		OrdinalPosition: 2,
+		Expression:      "to_days(`created_at`)",
+		TableRows:       20,
+		DataLength:      16384,
+	}, ps.ByName("pmax"))
+	assert.Nil(t, ps.ByName("p2017"))
+}
+
+func TestTable_MaintainPartitions(t *testing.T) {
+	t.Parallel()
+
+	rotation := ddl.PartitionRotation{
+		Months:    1,
+		Ahead:     2,
+		Retention: 1,
+		Now: func() time.Time {
+			return time.Date(2019, 3, 15, 13, 14, 15, 0, time.UTC)
+		},
+	}
+	const selPartitions = "SELECT PARTITION_NAME.+FROM information_schema.PARTITIONS WHERE TABLE_SCHEMA = DATABASE\\(\\) AND TABLE_NAME = 'sales_order_archive'"
+
+	t.Run("reorganize MAXVALUE and drop expired", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectQuery(selPartitions).
+			WillReturnRows(sqlmock.NewRows(partitionColumns).
+				AddRow(partitionRow("p_legacy", 1, "737059")...).
+				AddRow(partitionRow("p20190101", 2, "737456")...).
+				AddRow(partitionRow("p20190201", 3, "737484")...).
+				AddRow(partitionRow("p20190301", 4, "737515")...).
+				AddRow(partitionRow("pmax", 5, "MAXVALUE")...))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` REORGANIZE PARTITION `pmax` INTO (PARTITION `p20190401` VALUES LESS THAN (TO_DAYS('2019-05-01')), PARTITION `p20190501` VALUES LESS THAN (TO_DAYS('2019-06-01')), PARTITION `pmax` VALUES LESS THAN (MAXVALUE))")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` DROP PARTITION `p20190101`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		added, dropped, err := ddl.NewTable("sales_order_archive").WithDB(dbc.DB).MaintainPartitions(context.TODO(), rotation)
+		assert.NoError(t, err, "%+v", err)
+		assert.Exactly(t, []string{"p20190401", "p20190501"}, added)
+		assert.Exactly(t, []string{"p20190101"}, dropped)
+	})
+
+	t.Run("add without MAXVALUE", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectQuery(selPartitions).
+			WillReturnRows(sqlmock.NewRows(partitionColumns).
+				AddRow(partitionRow("p_legacy", 1, "737059")...))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` ADD PARTITION (PARTITION `p20190301` VALUES LESS THAN (TO_DAYS('2019-04-01')), PARTITION `p20190401` VALUES LESS THAN (TO_DAYS('2019-05-01')), PARTITION `p20190501` VALUES LESS THAN (TO_DAYS('2019-06-01')))")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		added, dropped, err := ddl.NewTable("sales_order_archive").WithDB(dbc.DB).MaintainPartitions(context.TODO(), rotation)
+		assert.NoError(t, err, "%+v", err)
+		assert.Exactly(t, []string{"p20190301", "p20190401", "p20190501"}, added)
+		assert.Len(t, dropped, 0)
+	})
+
+	t.Run("days aligned to the epoch", func(t *testing.T) {
+		// Both days fall into the week starting on 2019-03-14, which is a
+		// multiple of seven days after 1970-01-01.
+		for _, now := range []time.Time{
+			time.Date(2019, 3, 15, 13, 14, 15, 0, time.UTC),
+			time.Date(2019, 3, 20, 23, 59, 59, 0, time.UTC),
+		} {
+			dbc, dbMock := dmltest.MockDB(t)
+
+			dbMock.ExpectQuery(selPartitions).
+				WillReturnRows(sqlmock.NewRows(partitionColumns).
+					AddRow(partitionRow("p_legacy", 1, "737059")...))
+			dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` ADD PARTITION (PARTITION `p20190314` VALUES LESS THAN (TO_DAYS('2019-03-21')), PARTITION `p20190321` VALUES LESS THAN (TO_DAYS('2019-03-28')))")).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			added, _, err := ddl.NewTable("sales_order_archive").WithDB(dbc.DB).MaintainPartitions(context.TODO(), ddl.PartitionRotation{
+				Days:  7,
+				Ahead: 1,
+				Now:   func() time.Time { return now },
+			})
+			assert.NoError(t, err, "%+v", err)
+			assert.Exactly(t, []string{"p20190314", "p20190321"}, added)
+			dmltest.MockClose(t, dbc, dbMock)
+		}
+	})
+
+	t.Run("months aligned to the year", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectQuery(selPartitions).
+			WillReturnRows(sqlmock.NewRows(partitionColumns).
+				AddRow(partitionRow("p_legacy", 1, "737059")...))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("ALTER TABLE `sales_order_archive` ADD PARTITION (PARTITION `p20190101` VALUES LESS THAN (TO_DAYS('2019-04-01')))")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		added, _, err := ddl.NewTable("sales_order_archive").WithDB(dbc.DB).MaintainPartitions(context.TODO(), ddl.PartitionRotation{
+			Months: 3,
+			Now:    rotation.Now,
+		})
+		assert.NoError(t, err, "%+v", err)
+		assert.Exactly(t, []string{"p20190101"}, added)
+	})
+
+	t.Run("not partitioned", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectQuery(selPartitions).WillReturnRows(sqlmock.NewRows(partitionColumns))
+
+		_, _, err := ddl.NewTable("sales_order_archive").WithDB(dbc.DB).MaintainPartitions(context.TODO(), rotation)
+		assert.ErrorIsKind(t, errors.NotValid, err)
+	})
+
+	t.Run("RunPartitionMaintainer", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectQuery(selPartitions).WillReturnRows(sqlmock.NewRows(partitionColumns))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		r := rotation
+		r.ErrorHandler = func(err error) {
+			assert.ErrorIsKind(t, errors.NotValid, err)
+			cancel()
+		}
+		assert.NoError(t, ddl.NewTable("sales_order_archive").WithDB(dbc.DB).RunPartitionMaintainer(ctx, r))
+	})
+}
@@ -87,22 +87,21 @@ func (ms MasterStatus) Compare(other MasterStatus) int {
 }
 
 // String converts the file name and the position to a string, separated by a
+// semi-colon. A non-empty ExecutedGTIDSet gets appended after another
 // semi-colon.
 func (ms MasterStatus) String() string {
 	if ms.File == "" {
 		return ""
 	}
 	var str strings.Builder
-	str.WriteString(ms.File)
-	str.WriteByte(';')
-	str.WriteString(strconv.FormatUint(uint64(ms.Position), 10))
+	_, _ = ms.WriteTo(&str)
 	return str.String()
 }
 
 var semicolon = []byte(`;`)
 
-// WriteTo implements io.WriterTo and writes the current position and file name
-// to w.
+// WriteTo implements io.WriterTo and writes the current position, file name
+// and the optional GTID set to w.
 func (ms MasterStatus) WriteTo(w io.Writer) (n int64, err error) {
 	if ms.File == "" {
 		return
@@ -116,22 +115,36 @@ func (ms MasterStatus) WriteTo(w io.Writer) (n int64, err error) {
 	var buf [16]byte
 	n2, _ = w.Write(strconv.AppendUint(buf[:0], uint64(ms.Position), 10))
 	n += int64(n2)
+
+	if ms.ExecutedGTIDSet != "" {
+		n2, _ = w.Write(semicolon)
+		n += int64(n2)
+		n2, _ = w.Write([]byte(ms.ExecutedGTIDSet))
+		n += int64(n2)
+	}
 	return
 }
 
 // FromString parses as string in the format: mysql-bin.000002;236423 means
-// filename;position.
+// filename;position. An optional third part contains the GTID set, e.g.
+// mysql-bin.000002;236423;0-1-4711.
 func (ms *MasterStatus) FromString(str string) error {
 	c := strings.IndexByte(str, ';')
 	if c < 1 {
 		return errors.NotFound.Newf("[ddl] MasterStatus FromString: Delimiter semi-colon not found.")
 	}
 
-	pos, err := strconv.ParseUint(str[c+1:], 10, 32)
+	posStr, gtidSet := str[c+1:], ""
+	if c2 := strings.IndexByte(posStr, ';'); c2 >= 0 {
+		posStr, gtidSet = posStr[:c2], posStr[c2+1:]
+	}
+
+	pos, err := strconv.ParseUint(posStr, 10, 32)
 	if err != nil {
 		return errors.NotValid.Newf("[ddl] MasterStatus FromString: %s", err)
 	}
 	ms.File = str[:c]
 	ms.Position = uint(pos)
+	ms.ExecutedGTIDSet = gtidSet
 	return nil
 }
@@ -81,6 +81,8 @@ func TestMasterStatus_FromString(t *testing.T) {
 		wantString   string
 	}{
 		{"mysql-bin.000004;545460", "mysql-bin.000004", 545460, errors.NoKind, "mysql-bin.000004;545460"},
+		{"mysql-bin.000004;545460;0-1-4711,1-2-12", "mysql-bin.000004", 545460, errors.NoKind, "mysql-bin.000004;545460;0-1-4711,1-2-12"},
+		{"mysql-bin.000004;;0-1-4711", "", 0, errors.NotValid, ""},
 		{"mysql-bin.000004;", "", 0, errors.NotValid, ""},
 		{"mysql-bin.000004", "", 0, errors.NotFound, ""},
 	}
@@ -39,9 +39,16 @@ type Table struct {
 	Schema string
 	// Name of the table
 	Name string
-	// Columns all table columns. They do not get used to create or alter a
-	// table.
+	// Columns all table columns. They only get used to create or alter a table
+	// when calculating the difference to the database schema with DiffTable.
 	Columns Columns
+	// Indexes optional list of all indexes. If nil, indexes get ignored when
+	// calculating the difference to the database schema.
+	Indexes Indexes
+	// ForeignKeys optional list of all foreign key constraints. If nil,
+	// foreign keys get ignored when calculating the difference to the database
+	// schema.
+	ForeignKeys ForeignKeys
 	// IsView set to true to mark if the table is a view.
 	IsView bool
 	// optimized column selection for specific DML operations.
@@ -141,9 +148,24 @@ func (t *Table) DeleteByPK() *dml.Delete {
 }
 
 // UpdateByPK creates a new `UPDATE table SET ... WHERE id = ?`. The SET clause
-// contains all non primary columns.
-func (t *Table) UpdateByPK() *dml.Update {
-	u := t.dcp.Update(t.Name).AddColumns(t.columnsUpsert...)
+// contains all non primary columns. If argument columns has been provided, the
+// SET clause contains only those columns which are neither primary keys nor
+// generated columns. For example the ChangedFields of an entity generated by
+// dmlgen writes only the modified columns. An entity without changes must not
+// be updated because an empty columns argument selects all columns. If none of
+// the provided columns can be updated, the SET clause stays empty and building
+// the query returns an Empty error.
+func (t *Table) UpdateByPK(columns ...string) *dml.Update {
+	upsert := t.columnsUpsert
+	if len(columns) > 0 {
+		upsert = make([]string, 0, len(columns))
+		for _, c := range columns {
+			if col := t.Columns.ByField(c); col.Field != "" && !col.IsPK() && colIsNotGeneratedNonPK(col) {
+				upsert = append(upsert, c)
+			}
+		}
+	}
+	u := t.dcp.Update(t.Name).AddColumns(upsert...)
 	u.Wheres = t.whereByPK(dml.Equal)
 	if t.customDB != nil {
 		u.DB = t.customDB
@@ -161,15 +183,19 @@ func (t *Table) whereByPK(op dml.Op) dml.Conditions {
 	return cnds
 }
 
-func (t *Table) runExec(ctx context.Context, qry string) error {
-	var db dml.QueryExecPreparer
-	if t.dcp != nil {
-		db = t.dcp.DB
-	}
+// db returns the custom connection, if set, or the connection pool.
+func (t *Table) db() dml.QueryExecPreparer {
 	if t.customDB != nil {
-		db = t.customDB
+		return t.customDB
 	}
-	if _, err := db.ExecContext(ctx, qry); err != nil {
+	if t.dcp != nil {
+		return t.dcp.DB
+	}
+	return nil
+}
+
+func (t *Table) runExec(ctx context.Context, qry string) error {
+	if _, err := t.db().ExecContext(ctx, qry); err != nil {
 		return errors.Wrapf(err, "[ddl] failed to exec %q", qry) // please do change this return signature, saves an alloc
 	}
 	return nil
@@ -207,7 +233,8 @@ func (t *Table) Rename(ctx context.Context, new string) error {
 // Swap swaps the current table with the other table of the same structure.
 // Renaming is an atomic operation in the database. Note: indexes won't get
 // swapped! As long as two databases are on the same file system, you can use
-// RENAME TABLE to move a table from one database to another. To use a custom
+// RENAME TABLE to move a table from one database to another. The other table
+// must be in the same database as the current table. To use a custom
 // connection, call WithDB before.
 func (t *Table) Swap(ctx context.Context, other string) error {
 	if err := dml.IsValidIdentifier(t.Name); err != nil {
@@ -224,15 +251,15 @@ func (t *Table) Swap(ctx context.Context, other string) error {
 	buf.WriteString("RENAME TABLE ")
 	dml.Quoter.WriteQualifierName(buf, t.Schema, t.Name)
 	buf.WriteString(" TO ")
-	dml.Quoter.WriteIdentifier(buf, tmp)
+	dml.Quoter.WriteQualifierName(buf, t.Schema, tmp)
 	buf.WriteString(", ")
-	dml.Quoter.WriteIdentifier(buf, other)
+	dml.Quoter.WriteQualifierName(buf, t.Schema, other)
 	buf.WriteString(" TO ")
 	dml.Quoter.WriteQualifierName(buf, t.Schema, t.Name)
 	buf.WriteByte(',')
-	dml.Quoter.WriteIdentifier(buf, tmp)
+	dml.Quoter.WriteQualifierName(buf, t.Schema, tmp)
 	buf.WriteString(" TO ")
-	dml.Quoter.WriteIdentifier(buf, other)
+	dml.Quoter.WriteQualifierName(buf, t.Schema, other)
 	return t.runExec(ctx, buf.String())
 }
 
@@ -376,6 +376,32 @@ func TestTable_Artisan_Methods(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Exactly(t, int64(1), id)
 	})
+
+	t.Run("UpdateByPK with columns", func(t *testing.T) {
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("UPDATE `admin_user` SET `first_name`=? WHERE (`user_id` = ?)")).
+			WithArgs("Franz", int64(3)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// user_id gets ignored because it is the primary key and
+		// unknown_column does not exist.
+		res, err := tblAdmUser.UpdateByPK("user_id", "first_name", "unknown_column").WithArgs().
+			String("Franz").
+			Int64(3).
+			ExecContext(context.Background())
+		assert.NoError(t, err)
+		id, err := res.RowsAffected()
+		assert.NoError(t, err)
+		assert.Exactly(t, int64(1), id)
+	})
+
+	t.Run("UpdateByPK without updatable columns", func(t *testing.T) {
+		// No statement gets sent to the mock, otherwise MockClose would fail.
+		upd := tblAdmUser.UpdateByPK("user_id", "unknown_column")
+		assert.Len(t, upd.SetClauses, 0)
+		res, err := upd.WithArgs().Int64(3).ExecContext(context.Background())
+		assert.Nil(t, res)
+		assert.ErrorIsKind(t, errors.Empty, err)
+	})
 }
 
 func TestTable_GeneratedColumns(t *testing.T) {
@@ -401,6 +401,12 @@ func (tm *Tables) Upsert(tNew *Table) error {
 	if len(tNew.Columns) == 0 {
 		tNew.Columns = tOld.Columns
 	}
+	if tNew.Indexes == nil {
+		tNew.Indexes = tOld.Indexes
+	}
+	if tNew.ForeignKeys == nil {
+		tNew.ForeignKeys = tOld.ForeignKeys
+	}
 
 	tm.tm[tNew.Name] = tNew.update()
 	return nil
@@ -426,7 +432,7 @@ func (tm *Tables) DeleteAllFromCache() {
 
 // Validate validates the table names and their column against the current
 // database schema. The context is used to maybe cancel the "Load Columns"
-// query.
+// query. Use SchemaDiff to generate the statements which fix a mismatch.
 func (tm *Tables) Validate(ctx context.Context) error {
 	tm.mu.RLock()
 	defer tm.mu.RUnlock()
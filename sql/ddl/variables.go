@@ -32,9 +32,21 @@ type Variables struct {
 // passed, the SQL query will load the all variables matching the names.
 // Empty argument loads all variables.
 func NewVariables(names ...string) *Variables {
+	return newVariables(dml.NewShow().Variable(), names)
+}
+
+// NewStatus creates a new collection of global server status variables, like
+// Threads_running or Threads_connected. If the argument names gets passed, the
+// SQL query will load all status variables matching the names. Empty argument
+// loads all status variables.
+func NewStatus(names ...string) *Variables {
+	return newVariables(dml.NewShow().Global().Status(), names)
+}
+
+func newVariables(show *dml.Show, names []string) *Variables {
 	vs := &Variables{
 		Data: make(map[string]string),
-		Show: dml.NewShow().Variable(),
+		Show: show,
 	}
 	if len(names) > 1 {
 		vs.Show.Where(dml.Column("Variable_name").In().Strs(names...))
@@ -150,7 +162,7 @@ func (vs *Variables) MapColumns(rc *dml.ColumnMap) error {
 		case "Value":
 			rc.String(&value)
 		default:
-			return errors.NotFound.Newf("[ddl] Column %q not found in SHOW VARIABLES", col)
+			return errors.NotFound.Newf("[ddl] Column %q not found in SHOW VARIABLES or SHOW STATUS", col)
 		}
 	}
 	vs.Data[name] = value
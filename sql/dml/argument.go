@@ -930,9 +930,25 @@ func iFaceToArgs(args arguments, values ...interface{}) (arguments, error) {
 		case *time.Time:
 			if v != nil {
 				args = args.add(*v)
+			} else {
+				args = args.add(nil)
 			}
+		case uint:
+			args = args.add(uint64(v))
+		case uint64:
+			args = args.add(v)
+		case []int, []int64, []uint64, []float64, []bool, []string, [][]byte, []time.Time:
+			args = args.add(v)
 		case nil:
 			args = args.add(nil)
+		case driver.Valuer:
+			dv, err := v.Value()
+			if err != nil {
+				return nil, errors.Fatal.New(err, "[dml] iFaceToArgs driver.Value error for %#v", v)
+			}
+			if args, err = iFaceToArgs(args, dv); err != nil {
+				return nil, errors.WithStack(err)
+			}
 		default:
 			return nil, errors.NotSupported.Newf("[dml] iFaceToArgs type %#v not yet supported", v)
 		}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -204,23 +205,19 @@ func (a *Artisan) prepareArgs(extArgs ...interface{}) (_ string, _ []interface{}
 		return buf.String(), extArgs, nil
 	}
 
-	if !a.isPrepared && a.hasNamedArgs == 0 {
-		var found bool
+	if a.hasNamedArgs == 0 {
+		// The named place holders have already been replaced in the SQL
+		// string and their names got stored in the qualified columns.
 		a.hasNamedArgs = 1
-		cachedSQL, a.base.qualifiedColumns, found = extractReplaceNamedArgs(cachedSQL, a.base.qualifiedColumns)
-		if found {
-			a.base.cachedSQLUpsert(a.base.CacheKey, cachedSQL)
+		for _, arg := range a.arguments {
+			if arg.name != "" {
+				a.hasNamedArgs = 2
+				break
+			}
 		}
-
-		switch la := len(a.arguments); true {
-		case found:
-			a.hasNamedArgs = 2
-		case !found && len(a.recs) == 0 && la > 0:
-			for _, arg := range a.arguments {
-				if arg.name != "" {
-					a.hasNamedArgs = 2
-					break
-				}
+		for i := 0; i < len(a.base.qualifiedColumns) && len(a.recs) > 0 && a.hasNamedArgs == 1; i++ {
+			if _, ok := cutNamedArgStartStr(a.base.qualifiedColumns[i]); ok {
+				a.hasNamedArgs = 2
 			}
 		}
 	}
@@ -314,40 +311,51 @@ func (a *Artisan) appendConvertedRecordsToArguments(collectedArgs arguments) (ar
 		// appear in the SQL string.
 		for _, identifier := range qualifiedColumns {
 			// identifier can be either: column or qualifier.column or :column
+			// or :qualifier.column or empty for a positional place holder in
+			// a raw SQL string.
+			identifier, isNamedArg := cutNamedArgStartStr(identifier) // removes the colon for named arguments
+			if identifier == "" {
+				if pArg, ok := a.nextUnnamedArg(); ok {
+					cm.arguments = append(cm.arguments, pArg)
+				}
+				continue
+			}
+			if isNamedArg {
+				if arg, ok := a.namedArg(identifier); ok {
+					cm.arguments = append(cm.arguments, arg)
+					continue
+				}
+			}
+
 			qualifier, column := splitColumn(identifier)
 			// a.base.defaultQualifier is empty in case of INSERT statements
+			cm.columns[0] = column // length is always one, as created in NewColumnMap
 
-			column, isNamedArg := cutNamedArgStartStr(column) // removes the colon for named arguments
-			cm.columns[0] = column                            // length is always one, as created in NewColumnMap
-
-			if isNamedArg && len(a.arguments) > 0 {
-				// if the colon : cannot be found then a simple place holder ? has been detected
-				if err := a.MapColumns(cm); err != nil {
-					return collectedArgs, errors.WithStack(err)
+			found := false
+			for _, qRec := range a.recs {
+				if qRec.Qualifier == "" && qualifier != "" {
+					qRec.Qualifier = a.base.defaultQualifier
+				}
+				if qRec.Qualifier != "" && qualifier == "" {
+					qualifier = a.base.defaultQualifier
 				}
-			} else {
-				found := false
-				for _, qRec := range a.recs {
-					if qRec.Qualifier == "" && qualifier != "" {
-						qRec.Qualifier = a.base.defaultQualifier
-					}
-					if qRec.Qualifier != "" && qualifier == "" {
-						qualifier = a.base.defaultQualifier
-					}
 
-					if qRec.Qualifier == qualifier {
-						if err := qRec.Record.MapColumns(cm); err != nil {
-							return collectedArgs, errors.WithStack(err)
-						}
-						found = true
+				if qRec.Qualifier == qualifier {
+					if err := qRec.Record.MapColumns(cm); err != nil {
+						return collectedArgs, errors.WithStack(err)
 					}
+					found = true
 				}
-				if !found {
-					// If the argument cannot be found in the records then we assume the argument
-					// has a numerical position and we grab just the next unnamed argument.
-					if pArg, ok := a.nextUnnamedArg(); ok {
-						cm.arguments = append(cm.arguments, pArg)
-					}
+			}
+			if !found {
+				// If the argument cannot be found in the records then we assume the argument
+				// has a numerical position and we grab just the next unnamed argument.
+				pArg, ok := a.nextUnnamedArg()
+				switch {
+				case ok:
+					cm.arguments = append(cm.arguments, pArg)
+				case isNamedArg:
+					return collectedArgs, errors.NotFound.Newf("[dml] Named argument %q not found in arguments or records", identifier)
 				}
 			}
 		}
@@ -460,6 +468,17 @@ func (a *Artisan) nextUnnamedArg() (argument, bool) {
 	return argument{}, false
 }
 
+// namedArg returns the argument with the name n. The colon prefix of the
+// argument names gets ignored.
+func (a *Artisan) namedArg(n string) (argument, bool) {
+	for _, arg := range a.arguments {
+		if an, _ := cutNamedArgStartStr(arg.name); an != "" && an == n {
+			return arg, true
+		}
+	}
+	return argument{}, false
+}
+
 // MapColumns allows to merge one argument slice with another depending on the
 // matched columns. Each argument in the slice must be a named argument.
 // Implements interface ColumnMapper.
@@ -473,12 +492,8 @@ func (a *Artisan) MapColumns(cm *ColumnMap) error {
 		// access, but first benchmark it. This for loop can be the 3rd one in the
 		// overall chain.
 		c := cm.Column()
-		for _, arg := range a.arguments {
-			// Case sensitive comparison
-			if c != "" && arg.name == c {
-				cm.arguments = append(cm.arguments, arg)
-				break
-			}
+		if arg, ok := a.namedArg(c); ok { // Case sensitive comparison
+			cm.arguments = append(cm.arguments, arg)
 		}
 	}
 	return cm.Err()
@@ -549,6 +564,53 @@ func (a *Artisan) Name(n string) *Artisan {
 	return a
 }
 
+// Named adds the NamedArgs as named arguments. The names must match the named
+// place holders, e.g. `:entityID`, of the SQL string. The colon prefix of a
+// name is optional. Named arguments can be mixed with positional arguments.
+func (a *Artisan) Named(nArgs ...sql.NamedArg) *Artisan {
+	for _, na := range nArgs {
+		if a.base.ärgErr != nil {
+			return a
+		}
+		a.arguments = append(a.arguments, argument{name: na.Name})
+		a.arguments, a.base.ärgErr = iFaceToArgs(a.arguments, na.Value)
+	}
+	return a
+}
+
+// Map adds the map entries as named arguments. The keys must match the named
+// place holders of the SQL string. The arguments get added in the sorted
+// order of the keys.
+func (a *Artisan) Map(m map[string]interface{}) *Artisan {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if a.base.ärgErr != nil {
+			return a
+		}
+		a.arguments = append(a.arguments, argument{name: k})
+		a.arguments, a.base.ärgErr = iFaceToArgs(a.arguments, m[k])
+	}
+	return a
+}
+
+// Struct adds the exported fields of a struct, or a pointer to a struct, as a
+// record with the optional qualifier. The column name of a field gets read
+// from the struct tag `db`, like generated by dmlgen, otherwise the field name
+// gets used. A tag `db:"-"` ignores the field. Named place holders and columns
+// of the statement get bound to the fields with the same name.
+func (a *Artisan) Struct(qualifier string, v interface{}) *Artisan {
+	rec, err := newStructRecord(v)
+	if err != nil {
+		a.base.ärgErr = errors.WithStack(err)
+		return a
+	}
+	return a.Record(qualifier, rec)
+}
+
 // Reset resets the internal slices for new usage retaining the already
 // allocated memory. Reset gets called automatically in many Load* functions. In
 // case of an INSERT statement, Reset triggers a new build of the VALUES part.
@@ -561,6 +623,7 @@ func (a *Artisan) Reset() *Artisan {
 	a.recs = a.recs[:0]
 	a.arguments = a.arguments[:0]
 	a.raw = a.raw[:0]
+	a.hasNamedArgs = 0
 	a.nextUnnamedArgPos = 0
 	a.insertIsBuildValues = false
 	a.insertCachedSQL = a.insertCachedSQL[:0]
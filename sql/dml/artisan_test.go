@@ -16,6 +16,7 @@ package dml
 
 import (
 	"bytes"
+	"database/sql"
 	"fmt"
 	"testing"
 
@@ -315,6 +316,60 @@ func TestArguments_HasNamedArgs(t *testing.T) {
 	})
 }
 
+func TestArtisan_NamedArgs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Map with repeated name", func(t *testing.T) {
+		a := NewSelect("a").From("c").Where(
+			Column("id").Greater().NamedArg("id"),
+			Column("email").Like().NamedArg("email"),
+			Column("parent_id").Less().NamedArg("id"),
+		).WithArgs().Map(map[string]interface{}{
+			"email": "a@b.c",
+			"id":    int64(7),
+		})
+		compareToSQL(t, a, errors.NoKind,
+			"SELECT `a` FROM `c` WHERE (`id` > ?) AND (`email` LIKE ?) AND (`parent_id` < ?)",
+			"SELECT `a` FROM `c` WHERE (`id` > 7) AND (`email` LIKE 'a@b.c') AND (`parent_id` < 7)",
+			int64(7), "a@b.c", int64(7),
+		)
+	})
+	t.Run("Named in expression and positional in condition", func(t *testing.T) {
+		a := NewSelect().AddColumnsConditions(
+			Expr("CONCAT(:name, '-', :name)").Alias("n"),
+		).From("c").Where(
+			Column("id").Equal().PlaceHolder(),
+		).WithArgs().Named(sql.Named(":name", "x")).Int64(3)
+		compareToSQL(t, a, errors.NoKind,
+			"SELECT CONCAT(?, '-', ?) AS `n` FROM `c` WHERE (`id` = ?)",
+			"SELECT CONCAT('x', '-', 'x') AS `n` FROM `c` WHERE (`id` = 3)",
+			"x", "x", int64(3),
+		)
+	})
+	t.Run("Struct", func(t *testing.T) {
+		type customer struct {
+			ID       int64  `db:"entity_id"`
+			Email    string `db:"email,omitempty"`
+			Password string `db:"-"`
+		}
+		a := NewUpdate("customer").AddColumns("email").Where(
+			Column("entity_id").Equal().PlaceHolder(),
+		).WithArgs().Struct("", &customer{ID: 33, Email: "d@e.f", Password: "secret"})
+		compareToSQL(t, a, errors.NoKind,
+			"UPDATE `customer` SET `email`=? WHERE (`entity_id` = ?)",
+			"UPDATE `customer` SET `email`='d@e.f' WHERE (`entity_id` = 33)",
+			"d@e.f", int64(33),
+		)
+	})
+	t.Run("not found", func(t *testing.T) {
+		a := NewSelect("a").From("c").Where(
+			Column("id").Greater().NamedArg("id"),
+		).WithArgs().Map(map[string]interface{}{"ID": 7})
+		_, _, err := a.ToSQL()
+		assert.ErrorIsKind(t, errors.NotFound, err)
+	})
+}
+
 func TestArguments_MapColumns(t *testing.T) {
 	t.Parallel()
 
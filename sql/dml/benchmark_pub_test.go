@@ -147,6 +147,64 @@ func BenchmarkSelectExcessConditions(b *testing.B) {
 	}
 }
 
+func BenchmarkSelectTemplate(b *testing.B) {
+	listing := func() *dml.Select {
+		return dml.NewSelect("e.entity_id", "e.sku", "si.qty").FromAlias("catalog_product_entity", "e").
+			Join(dml.MakeIdentifier("cataloginventory_stock_item").Alias("si"),
+				dml.Column("e.entity_id").Column("si.product_id"),
+			).
+			Where(
+				dml.Column("e.type_id").Str("simple"),
+				dml.Column("e.store_id").PlaceHolder(),
+			)
+	}
+
+	b.Run("Select", func(b *testing.B) {
+		var err error
+		for i := 0; i < b.N; i++ {
+			benchmarkSelectStr, benchmarkGlobalVals, err = listing().
+				Where(dml.Column("si.qty").Greater().PlaceHolder()).
+				OrderByDesc("si.qty").
+				Limit(40, 20).
+				WithArgs().Int64(1).Int64(0).ToSQL()
+			if err != nil {
+				b.Fatalf("%+v", err)
+			}
+		}
+	})
+	b.Run("Template", func(b *testing.B) {
+		tpl := listing().Template()
+		b.ResetTimer()
+		var err error
+		for i := 0; i < b.N; i++ {
+			benchmarkSelectStr, benchmarkGlobalVals, err = tpl.NewQuery().
+				Where(dml.Column("si.qty").Greater().PlaceHolder()).
+				OrderByDesc("si.qty").
+				Limit(40, 20).
+				WithArgs().Int64(1).Int64(0).ToSQL()
+			if err != nil {
+				b.Fatalf("%+v", err)
+			}
+		}
+	})
+	b.Run("Template_CacheKey", func(b *testing.B) {
+		tpl := listing().Template()
+		b.ResetTimer()
+		var err error
+		for i := 0; i < b.N; i++ {
+			benchmarkSelectStr, benchmarkGlobalVals, err = tpl.NewQuery().
+				WithCacheKey("qty_desc").
+				Where(dml.Column("si.qty").Greater().PlaceHolder()).
+				OrderByDesc("si.qty").
+				Limit(40, 20).
+				WithArgs().Int64(1).Int64(0).ToSQL()
+			if err != nil {
+				b.Fatalf("%+v", err)
+			}
+		}
+	})
+}
+
 func BenchmarkSelectFullSQL(b *testing.B) {
 
 	sqlObj := dml.NewSelect("a", "b", "z", "y", "x").From("c").
@@ -180,6 +180,13 @@ func (bb *BuilderBase) buildToSQL(qb queryBuilder) (string, error) {
 			return "", errors.WithStack(err)
 		}
 		rawSQL = buf.String()
+		// Named place holders `:name` in expressions get replaced with `?`
+		// and inserted at their position into the qualified columns. The
+		// qualified columns of an INSERT statement contain the column names
+		// and not the place holders.
+		if ins, ok := qb.(*Insert); !ok || ins.Select != nil {
+			rawSQL, qualifiedColumns, _ = extractReplaceNamedArgs(rawSQL, qualifiedColumns)
+		}
 		bb.qualifiedColumns = qualifiedColumns
 		bb.cachedSQLUpsert(bb.CacheKey, rawSQL)
 	}
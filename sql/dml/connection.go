@@ -546,13 +546,15 @@ func (c *ConnPool) WithRawSQL(query string) *Artisan {
 	if l != nil {
 		l = l.With(log.String("conn_pool_raw_sql_id", id), log.String("query", query))
 	}
+	query, qualifiedColumns := extractRawNamedArgs(query)
 	var args [defaultArgumentsCapacity]argument
 	return &Artisan{
 		base: builderCommon{
-			cachedSQL: map[string]string{"": query},
-			Log:       l,
-			id:        id,
-			DB:        c.DB,
+			cachedSQL:        map[string]string{"": query},
+			qualifiedColumns: qualifiedColumns,
+			Log:              l,
+			id:               id,
+			DB:               c.DB,
 		},
 		arguments: args[:0],
 	}
@@ -571,15 +573,17 @@ func (c *ConnPool) WithPrepare(ctx context.Context, query string) *Artisan {
 		l = l.With(log.String("conn_pool_prepare_sql_id", id), log.String("query", query))
 	}
 
+	query, qualifiedColumns := extractRawNamedArgs(query)
 	stmt, err := c.DB.PrepareContext(ctx, query)
 
 	var args [defaultArgumentsCapacity]argument
 	a := &Artisan{
 		base: builderCommon{
-			id:     id,
-			ärgErr: err,
-			Log:    l,
-			DB:     stmtWrapper{stmt: stmt},
+			id:               id,
+			ärgErr:           err,
+			Log:              l,
+			DB:               stmtWrapper{stmt: stmt},
+			qualifiedColumns: qualifiedColumns,
 		},
 		arguments:  args[:0],
 		isPrepared: true,
@@ -703,14 +707,16 @@ func (c *Conn) WithQueryBuilder(qb QueryBuilder) *Artisan {
 	if l != nil {
 		l = l.With(log.String("query_builder_id", id), log.String("sql", sql))
 	}
+	sql, qualifiedColumns := extractRawNamedArgs(sql)
 	var args [defaultArgumentsCapacity]argument
 	return &Artisan{
 		base: builderCommon{
-			cachedSQL: map[string]string{"": sql},
-			Log:       l,
-			id:        id,
-			DB:        c.DB,
-			ärgErr:    errors.WithStack(err),
+			cachedSQL:        map[string]string{"": sql},
+			qualifiedColumns: qualifiedColumns,
+			Log:              l,
+			id:               id,
+			DB:               c.DB,
+			ärgErr:           errors.WithStack(err),
 		},
 		raw:       argsRaw,
 		arguments: args[:0],
@@ -725,13 +731,15 @@ func (c *Conn) WithRawSQL(sql string) *Artisan {
 	if l != nil {
 		l = l.With(log.String("conn_pool_raw_sql_id", id), log.String("sql", sql))
 	}
+	sql, qualifiedColumns := extractRawNamedArgs(sql)
 	var args [defaultArgumentsCapacity]argument
 	return &Artisan{
 		base: builderCommon{
-			cachedSQL: map[string]string{"": sql},
-			Log:       l,
-			id:        id,
-			DB:        c.DB,
+			cachedSQL:        map[string]string{"": sql},
+			qualifiedColumns: qualifiedColumns,
+			Log:              l,
+			id:               id,
+			DB:               c.DB,
 		},
 		arguments: args[:0],
 	}
@@ -745,13 +753,15 @@ func (tx *Tx) WithRawSQL(sql string) *Artisan {
 	if l != nil {
 		l = l.With(log.String("tx_raw_sql_id", id), log.String("sql", sql))
 	}
+	sql, qualifiedColumns := extractRawNamedArgs(sql)
 	var args [defaultArgumentsCapacity]argument
 	return &Artisan{
 		base: builderCommon{
-			cachedSQL: map[string]string{"": sql},
-			Log:       l,
-			id:        id,
-			DB:        tx.DB,
+			cachedSQL:        map[string]string{"": sql},
+			qualifiedColumns: qualifiedColumns,
+			Log:              l,
+			id:               id,
+			DB:               tx.DB,
 		},
 		arguments: args[:0],
 	}
@@ -770,15 +780,17 @@ func (tx *Tx) WithPrepare(ctx context.Context, query string) *Artisan {
 		l = l.With(log.String("tx_prepare_sql_id", id), log.String("query", query))
 	}
 
+	query, qualifiedColumns := extractRawNamedArgs(query)
 	stmt, err := tx.DB.PrepareContext(ctx, query)
 
 	var args [defaultArgumentsCapacity]argument
 	a := &Artisan{
 		base: builderCommon{
-			id:     id,
-			ärgErr: err,
-			Log:    l,
-			DB:     stmtWrapper{stmt: stmt},
+			id:               id,
+			ärgErr:           err,
+			Log:              l,
+			DB:               stmtWrapper{stmt: stmt},
+			qualifiedColumns: qualifiedColumns,
 		},
 		arguments:  args[:0],
 		isPrepared: true,
@@ -16,6 +16,7 @@ package dml_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 
@@ -322,6 +323,19 @@ func TestConnPool_WithPrepare(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestConnPool_WithPrepare_NamedArgs(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectPrepare(dmltest.SQLMockQuoteMeta("UPDATE tabA SET a = ? WHERE b = ? OR c = ?")).
+		ExpectExec().WithArgs(3, "x", 3).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	a := dbc.WithPrepare(context.TODO(), "UPDATE tabA SET a = :id WHERE b = ? OR c = :id")
+	_, err := a.Named(sql.Named("id", 3)).String("x").ExecContext(context.TODO())
+	assert.NoError(t, err)
+}
+
 func TestTx_WithPrepare(t *testing.T) {
 	t.Parallel()
 	dbc, dbMock := dmltest.MockDB(t)
@@ -141,9 +141,19 @@ func (in *ip) ToSQL() (_ string, alwaysNil []interface{}, _ error) {
 	if in.ärgErr != nil {
 		return "", nil, in.ärgErr
 	}
+	query, args := in.queryCache, in.args
+	for _, arg := range args {
+		if arg.name != "" {
+			var err error
+			if query, args, err = bindNamedArgs(query, args); err != nil {
+				return "", nil, errors.WithStack(err)
+			}
+			break
+		}
+	}
 	buf := bufferpool.Get()
 	defer bufferpool.Put(buf)
-	if err := writeInterpolate(buf, in.queryCache, in.args); err != nil {
+	if err := writeInterpolate(buf, query, args); err != nil {
 		return "", nil, errors.WithStack(err)
 	}
 	return buf.String(), nil, nil
@@ -214,30 +224,39 @@ func (in *ip) Arguments(args *Artisan) *ip {
 	return in
 }
 
-// Named uses the NamedArg for string replacement. Replaces the names with place
-// holder character.
-func (in *ip) Named(nArgs ...sql.NamedArg) *ip {
-	// Slices in NamedArg.Value won't be supported.
-	// for now this unoptimized version with a stupid string replacement and
-	// converting between bytes and string.
+// Name sets the name for the following argument. The SQL string must contain
+// the name as named place holder with the colon prefix, e.g. `:entityID`.
+func (in *ip) Name(n string) *ip {
+	n, _ = cutNamedArgStartStr(n)
+	in.args = append(in.args, argument{name: n})
+	return in
+}
 
+// Named adds the NamedArgs as named arguments. The SQL string must contain the
+// names as named place holders with the colon prefix, e.g. `:ArgX`. A name can
+// occur multiple times and named arguments can be mixed with positional
+// arguments. A name with a different prefix, like `@ArgY`, gets replaced in the
+// SQL string. Slices in NamedArg.Value won't be supported.
+func (in *ip) Named(nArgs ...sql.NamedArg) *ip {
 	for _, na := range nArgs {
-		in.queryCache = strings.Replace(in.queryCache, na.Name, "?", -1) // TODO optimize
-		// BUG: depending on the position of the named argument the append function below creates the bug for not
-		// positioning correctly the arguments.
 		if in.ärgErr != nil {
 			return in
 		}
+		name, hasColon := cutNamedArgStartStr(na.Name)
+		if r, w := utf8.DecodeRuneInString(name); !hasColon && w > 0 && isNotNamedArgSeperator(r) {
+			in.queryCache = strings.Replace(in.queryCache, name, namedArgStartStr+name[w:], -1)
+			name = name[w:]
+		}
+		in.args = append(in.args, argument{name: name})
 		in.args, in.ärgErr = iFaceToArgs(in.args, na.Value)
 	}
 	return in
 }
 
 // writeInterpolate merges `args` into `sql` and writes the result into `buf`. `sql`
-// stays unchanged.
+// stays unchanged. Named place holders must have been replaced before with
+// bindNamedArgs.
 func writeInterpolate(buf *bytes.Buffer, sql string, args arguments) error {
-	// TODO support :name identifier and the name field in argument
-
 	phCount, argCount := strings.Count(sql, placeHolderStr), len(args)
 	if argCount > 0 && phCount != argCount {
 		return errors.Mismatch.Newf("[dml] Number of place holders (%d) vs number of arguments (%d) do not match.", phCount, argCount)
@@ -329,54 +348,155 @@ func writeInterpolateBytes(buf *bytes.Buffer, sql []byte, args arguments) error
 	return nil
 }
 
-// extractReplaceNamedArgs extracts all occurrences of a pattern `:[^\s]+` and
-// replaces them with a ? placeholder. It does not remove duplicates because
-// those are needed for the amount of arguments to get. The extracted strings
-// get appended to qualifiedColumns argument.
-func extractReplaceNamedArgs(sql string, qualifiedColumns []string) (_ string, _ []string, found bool) {
-	if strings.IndexByte(sql, namedArgStartByte) == -1 {
-		return sql, qualifiedColumns, found
-	}
-	lSQL := len(sql)
-	foundColon := false
-	buf := bufferpool.Get()
-	newSQL := bytes.NewBuffer(make([]byte, 0, lSQL))
-	quoteStart := false
+// walkPlaceHolders iterates over the SQL string and calls `text` for each
+// fragment without a place holder, `positional` for each place holder `?` and
+// `named` for each named place holder `:name`. The name gets passed without the
+// colon. Quoted strings and identifiers get skipped. A colon which is not
+// followed by a valid name character, like in the assignment operator `:=`,
+// gets treated as text.
+func walkPlaceHolders(sql string, text func(string), positional func(), named func(name string)) {
+	last := 0
 	pos := 0
 	for pos < len(sql) {
 		r, w := utf8.DecodeRuneInString(sql[pos:])
-		pos += w
-
 		switch {
-		case r == '\'' && !quoteStart:
-			quoteStart = true
-			newSQL.WriteRune(r)
-		case quoteStart:
-			// do nothing
-			newSQL.WriteRune(r)
-		case r == '\'' && quoteStart:
-			quoteStart = false
-			newSQL.WriteRune(r)
+		case r == '\'', r == '"', r == '`':
+			pos += w
+			for pos < len(sql) {
+				c := sql[pos]
+				pos++
+				if c == '\\' && r != '`' {
+					pos++ // skip escaped character
+					continue
+				}
+				if rune(c) == r {
+					break
+				}
+			}
+			if pos > len(sql) {
+				pos = len(sql)
+			}
+		case r == placeHolderRune:
+			text(sql[last:pos])
+			positional()
+			pos += w
+			last = pos
 		case r == namedArgStartByte:
-			foundColon = true
-			buf.WriteByte(namedArgStartByte)
-			newSQL.WriteByte(placeHolderRune)
-		case isNotNamedArgSeperator(r) && foundColon: // character class can be changed to allow more, like emojis
-			foundColon = false
-			if s := buf.String(); buf.Len() > 1 {
-				qualifiedColumns = append(qualifiedColumns, s)
-				found = true
+			end := pos + w
+			for end < len(sql) {
+				nr, nw := utf8.DecodeRuneInString(sql[end:])
+				if isNotNamedArgSeperator(nr) {
+					break
+				}
+				end += nw
 			}
-			buf.Reset()
-			newSQL.WriteRune(r)
-		case foundColon:
-			buf.WriteRune(r)
+			if end == pos+w {
+				pos += w // a single colon
+				continue
+			}
+			text(sql[last:pos])
+			named(sql[pos+w : end])
+			pos = end
+			last = pos
 		default:
-			newSQL.WriteRune(r)
+			pos += w
 		}
 	}
-	bufferpool.Put(buf)
-	return newSQL.String(), qualifiedColumns, found
+	text(sql[last:])
+}
+
+// extractReplaceNamedArgs replaces all named place holders `:name` with the
+// place holder `?`. Argument qualifiedColumns must contain one entry for each
+// already existing place holder `?`. The names, including the colon, get
+// inserted into qualifiedColumns at the position of their occurrence in the
+// SQL string. Duplicates won't get removed because each occurrence requires
+// its own argument. If the number of entries in qualifiedColumns does not
+// match the number of place holders, the names get appended.
+func extractReplaceNamedArgs(sql string, qualifiedColumns []string) (_ string, _ []string, found bool) {
+	if strings.IndexByte(sql, namedArgStartByte) == -1 {
+		return sql, qualifiedColumns, false
+	}
+	var newSQL strings.Builder
+	newSQL.Grow(len(sql))
+	newQC := make([]string, 0, len(qualifiedColumns)+4)
+	var names []string
+	phCount := 0
+	walkPlaceHolders(sql, func(s string) {
+		newSQL.WriteString(s)
+	}, func() {
+		newSQL.WriteByte(placeHolderRune)
+		if phCount < len(qualifiedColumns) {
+			newQC = append(newQC, qualifiedColumns[phCount])
+		}
+		phCount++
+	}, func(name string) {
+		newSQL.WriteByte(placeHolderRune)
+		newQC = append(newQC, namedArgStartStr+name)
+		names = append(names, namedArgStartStr+name)
+	})
+	if len(names) == 0 {
+		return sql, qualifiedColumns, false
+	}
+	if phCount != len(qualifiedColumns) {
+		newQC = append(qualifiedColumns, names...)
+	}
+	return newSQL.String(), newQC, true
+}
+
+// extractRawNamedArgs replaces the named place holders of a hand written SQL
+// string. The returned qualified columns contain an entry for each place
+// holder, an empty one for a positional place holder. It returns nil qualified
+// columns if there are no named place holders.
+func extractRawNamedArgs(sql string) (string, []string) {
+	if strings.IndexByte(sql, namedArgStartByte) == -1 {
+		return sql, nil
+	}
+	phCount := 0
+	walkPlaceHolders(sql, func(string) {}, func() { phCount++ }, func(string) {})
+	sql, qualifiedColumns, found := extractReplaceNamedArgs(sql, make([]string, phCount))
+	if !found {
+		return sql, nil
+	}
+	return sql, qualifiedColumns
+}
+
+// bindNamedArgs replaces the named place holders in `sql` with `?` and orders
+// the arguments as the place holders occur. Named place holders get bound to
+// the argument with the same name, positional place holders to the next
+// argument without a name. A name can occur multiple times.
+func bindNamedArgs(sql string, args arguments) (string, arguments, error) {
+	var newSQL strings.Builder
+	newSQL.Grow(len(sql))
+	newArgs := make(arguments, 0, len(args))
+	var err error
+	unnamedPos := 0
+	walkPlaceHolders(sql, func(s string) {
+		newSQL.WriteString(s)
+	}, func() {
+		newSQL.WriteByte(placeHolderRune)
+		for ; unnamedPos < len(args); unnamedPos++ {
+			if args[unnamedPos].name == "" {
+				newArgs = append(newArgs, args[unnamedPos])
+				unnamedPos++
+				return
+			}
+		}
+		if err == nil {
+			err = errors.Mismatch.Newf("[dml] Missing argument for place holder %d in %q", len(newArgs)+1, sql)
+		}
+	}, func(name string) {
+		newSQL.WriteByte(placeHolderRune)
+		for _, arg := range args {
+			if n, _ := cutNamedArgStartStr(arg.name); n == name && n != "" {
+				newArgs = append(newArgs, arg)
+				return
+			}
+		}
+		if err == nil {
+			err = errors.NotFound.Newf("[dml] Named argument %q not found in %q", name, sql)
+		}
+	})
+	return newSQL.String(), newArgs, err
 }
 
 func isNamedArg(placeHolder string) (ret bool) {
@@ -394,7 +514,7 @@ func isNamedArg(placeHolder string) (ret bool) {
 }
 
 func isNotNamedArgSeperator(r rune) bool {
-	return !unicode.IsLetter(r) && !isEmoji(r) && !unicode.IsDigit(r) && r != '.'
+	return !unicode.IsLetter(r) && !isEmoji(r) && !unicode.IsDigit(r) && r != '.' && r != '_'
 }
 
 // isEmoji represents one of the most important functions in this project.
@@ -256,6 +256,25 @@ func TestInterpolate_Int64(t *testing.T) {
 			"SELECT * FROM x WHERE a = (3) AND b > 3.14159",
 		)
 	})
+	t.Run("repeated named params mixed with positional", func(t *testing.T) {
+		compareToSQL2(t,
+			Interpolate("SELECT * FROM x WHERE a = :id AND b = ? AND c = :id AND d = :name_1").
+				Int64(5).
+				Named(
+					sql.Named("name_1", "Go"),
+					sql.Named("id", 3),
+				),
+			errors.NoKind,
+			"SELECT * FROM x WHERE a = 3 AND b = 5 AND c = 3 AND d = 'Go'",
+		)
+	})
+	t.Run("named param not found", func(t *testing.T) {
+		compareToSQL2(t,
+			Interpolate("SELECT * FROM x WHERE a = :id").Name("ID").Int64(3),
+			errors.NotFound,
+			"",
+		)
+	})
 	t.Run("equal", func(t *testing.T) {
 		compareToSQL2(t,
 			Interpolate("SELECT * FROM x WHERE a = ? AND b = ? AND c = ?").
@@ -583,6 +602,21 @@ func TestInterpolate_MultipleSingleQuotes(t *testing.T) {
 	assert.Exactly(t, "DELETE FROM `tableA` WHERE (`colA` >= 3.14159) AND (`colB` IN (3.1,2.4)) AND (`colC` = 'He\\'llo') ORDER BY `id` LIMIT 10", str)
 }
 
+func TestExtractRawNamedArgs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("positional and named", func(t *testing.T) {
+		gotSQL, qualifiedColumns := extractRawNamedArgs("SELECT :a, ?, :b, ':c', :a")
+		assert.Exactly(t, "SELECT ?, ?, ?, ':c', ?", gotSQL)
+		assert.Exactly(t, []string{namedArgStartStr + "a", "", namedArgStartStr + "b", namedArgStartStr + "a"}, qualifiedColumns)
+	})
+	t.Run("positional only", func(t *testing.T) {
+		gotSQL, qualifiedColumns := extractRawNamedArgs("SELECT ?, '1:2'")
+		assert.Exactly(t, "SELECT ?, '1:2'", gotSQL)
+		assert.Nil(t, qualifiedColumns)
+	})
+}
+
 func TestExtractNamedArgs(t *testing.T) {
 	t.Parallel()
 
@@ -634,7 +668,22 @@ func TestExtractNamedArgs(t *testing.T) {
 	))
 	t.Run("colon only", runner(
 		"SELECT : AS `n`",
-		"SELECT ? AS `n`",
+		"SELECT : AS `n`",
+	))
+	t.Run("assignment operator", runner(
+		"SELECT @a := :val",
+		"SELECT @a := ?",
+		namedArgStartStr+"val",
+	))
+	t.Run("quoted colons", runner(
+		"SELECT ':not' AS `x:y`, :yes FROM t WHERE c = \"1:2\"",
+		"SELECT ':not' AS `x:y`, ? FROM t WHERE c = \"1:2\"",
+		namedArgStartStr+"yes",
+	))
+	t.Run("same name twice", runner(
+		"SELECT :a, :b, :a",
+		"SELECT ?, ?, ?",
+		namedArgStartStr+"a", namedArgStartStr+"b", namedArgStartStr+"a",
 	))
 	t.Run("with number", runner(
 		"SELECT (:x32)",
@@ -17,6 +17,7 @@ package dml
 import (
 	"database/sql"
 	"encoding"
+	"encoding/json"
 	"fmt"
 	"math"
 	"strconv"
@@ -239,6 +240,7 @@ func (s *scannedColumn) Scan(src interface{}) (err error) {
 		s.time = val
 	case nil:
 		s.field = 'n'
+		s.byte = nil // do not leak the bytes of a previous row
 	default:
 		err = errors.NotSupported.Newf("[dml] ColumnMap.Scan does not yet support type %T with value: %#v", val, val)
 	}
@@ -1169,6 +1171,55 @@ func (b *ColumnMap) Binary(enc interface {
 	return b
 }
 
+// JSON encodes the value of ptr as JSON when arguments are requested and
+// decodes the JSON document retrieved from the server into ptr. A
+// *json.RawMessage gets assigned without decoding and a nil json.RawMessage
+// represents a NULL value. For all other types a NULL value leaves ptr
+// untouched. Use this function for MySQL JSON columns or text columns
+// containing JSON.
+func (b *ColumnMap) JSON(ptr interface{}) *ColumnMap {
+	if b.scanErr != nil {
+		return b
+	}
+	raw, isRaw := ptr.(*json.RawMessage)
+	if b.shouldCollectArgs() {
+		switch {
+		case isRaw && (raw == nil || *raw == nil):
+			b.arguments = b.arguments.add(nil)
+		case isRaw:
+			b.arguments = b.arguments.add([]byte(*raw))
+		default:
+			var data []byte
+			data, b.scanErr = json.Marshal(ptr)
+			b.arguments = b.arguments.add(data)
+		}
+		return b
+	}
+
+	var data []byte
+	switch v := b.scanCol[b.index]; v.field {
+	case 'y', 'n':
+		data = v.byte
+	case 's':
+		data = []byte(v.string)
+	default:
+		b.scanErr = errors.NotSupported.Newf("[dml] Column %q does not support field type: %q", b.Column(), v.field)
+		return b
+	}
+
+	switch {
+	case isRaw && data == nil:
+		*raw = nil
+	case isRaw:
+		*raw = append((*raw)[:0], data...)
+	case data != nil:
+		if b.scanErr = json.Unmarshal(data, ptr); b.scanErr != nil {
+			b.scanErr = errors.BadEncoding.New(b.scanErr, "[dml] Column %q", b.Column())
+		}
+	}
+	return b
+}
+
 // String reads a string value and appends it to the arguments slice or assigns
 // the string value stored in sql.RawBytes to the pointer. See the documentation
 // for function Scan.
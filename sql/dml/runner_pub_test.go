@@ -54,6 +54,88 @@ func TestColumnMap_BinaryText(t *testing.T) {
 
 }
 
+func TestColumnMap_JSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("arguments", func(t *testing.T) {
+		cm := dml.NewColumnMap(3)
+		assert.NoError(t, cm.JSON(&jsonTestData{ID: 3, Name: "Gopher"}).Err())
+		raw := json.RawMessage(`[1,2]`)
+		assert.NoError(t, cm.JSON(&raw).Err())
+		var rawNull json.RawMessage
+		assert.NoError(t, cm.JSON(&rawNull).Err())
+		assert.Exactly(t, "dml.MakeArgs(3).Bytes([]byte{0x7b, 0x22, 0x69, 0x64, 0x22, 0x3a, 0x33, 0x2c, 0x22, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x22, 0x47, 0x6f, 0x70, 0x68, 0x65, 0x72, 0x22, 0x7d}).Bytes([]byte{0x5b, 0x31, 0x2c, 0x32, 0x5d}).Null()", cm.GoString())
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectQuery("SELECT \\* FROM `test`").WillReturnRows(
+			sqlmock.NewRows([]string{"data", "raw"}).
+				AddRow([]byte(`{"id":4,"name":"Gopher"}`), []byte(`{"a":1}`)).
+				AddRow(nil, nil),
+		)
+		jc := new(jsonTestCollection)
+		rc, err := dbc.WithQueryBuilder(jc).Load(context.TODO(), jc)
+		assert.NoError(t, err)
+		assert.Exactly(t, uint64(2), rc)
+		assert.Exactly(t, jsonTestData{ID: 4, Name: "Gopher"}, jc.Data[0].Data)
+		assert.Exactly(t, `{"a":1}`, string(jc.Data[0].Raw))
+		assert.Exactly(t, jsonTestData{}, jc.Data[1].Data)
+		assert.Nil(t, jc.Data[1].Raw)
+	})
+
+	t.Run("scan invalid JSON", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		dbMock.ExpectQuery("SELECT \\* FROM `test`").WillReturnRows(
+			sqlmock.NewRows([]string{"data", "raw"}).AddRow([]byte(`{"id":`), nil),
+		)
+		jc := new(jsonTestCollection)
+		_, err := dbc.WithQueryBuilder(jc).Load(context.TODO(), jc)
+		assert.ErrorIsKind(t, errors.BadEncoding, err)
+	})
+}
+
+type jsonTestData struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type jsonTestRow struct {
+	Data jsonTestData
+	Raw  json.RawMessage
+}
+
+type jsonTestCollection struct {
+	Data []*jsonTestRow
+}
+
+func (jc *jsonTestCollection) ToSQL() (string, []interface{}, error) {
+	return "SELECT * FROM `test`", nil, nil
+}
+
+func (jc *jsonTestCollection) MapColumns(cm *dml.ColumnMap) error {
+	if cm.Count == 0 {
+		jc.Data = jc.Data[:0]
+	}
+	r := new(jsonTestRow)
+	for cm.Next() {
+		switch c := cm.Column(); c {
+		case "data":
+			cm.JSON(&r.Data)
+		case "raw":
+			cm.JSON(&r.Raw)
+		default:
+			return errors.NotFound.Newf("[dml_test] jsonTestRow Column %q not found", c)
+		}
+	}
+	jc.Data = append(jc.Data, r)
+	return cm.Err()
+}
+
 type textBinaryEncoder struct {
 	data []byte
 }
@@ -35,9 +35,8 @@ type Select struct {
 	// http://stackoverflow.com/questions/3639861/why-is-select-considered-harmful
 	Columns ids
 
-	//TODO: create a possibility of the Select type which has a half-pre-rendered
-	// SQL statement where a developer can only modify or append WHERE clauses.
-	// especially useful during code generation
+	// For a half-pre-rendered SQL statement where only WHERE, ORDER BY and
+	// LIMIT clauses can be appended, see function Template.
 
 	GroupBys             ids
 	Havings              Conditions
@@ -434,6 +433,35 @@ func (b *Select) WithCacheKey(key string, args ...interface{}) *Select {
 // ToSQL serialized the Select to a SQL string
 // It returns the string with placeholders and a slice of query arguments
 func (b *Select) toSQL(w *bytes.Buffer, placeHolders []string) (_ []string, err error) {
+	if placeHolders, err = b.writeHead(w, placeHolders); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if placeHolders, err = b.Wheres.write(w, 'w', placeHolders); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if placeHolders, err = b.writeGroupByHaving(w, placeHolders); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	switch {
+	case b.IsOrderByDeactivated:
+		w.WriteString(" ORDER BY NULL")
+	case b.IsOrderByRand:
+		w.WriteString(" ORDER BY RAND()")
+	default:
+		sqlWriteOrderBy(w, b.OrderBys, false)
+	}
+
+	sqlWriteLimitOffset(w, b.LimitValid, true, b.OffsetCount, b.LimitCount)
+
+	b.writeLock(w)
+	return placeHolders, err
+}
+
+// writeHead writes the SELECT part including the FROM and JOIN clauses.
+func (b *Select) writeHead(w *bytes.Buffer, placeHolders []string) (_ []string, err error) {
 	b.source = dmlSourceSelect
 	b.defaultQualifier = b.Table.qualifier()
 
@@ -506,10 +534,11 @@ func (b *Select) toSQL(w *bytes.Buffer, placeHolders []string) (_ []string, err
 		}
 	}
 
-	if placeHolders, err = b.Wheres.write(w, 'w', placeHolders); err != nil {
-		return nil, errors.WithStack(err)
-	}
+	return placeHolders, nil
+}
 
+// writeGroupByHaving writes the GROUP BY and HAVING clauses.
+func (b *Select) writeGroupByHaving(w *bytes.Buffer, placeHolders []string) (_ []string, err error) {
 	if len(b.GroupBys) > 0 {
 		w.WriteString(" GROUP BY ")
 		for i, c := range b.GroupBys {
@@ -525,25 +554,16 @@ func (b *Select) toSQL(w *bytes.Buffer, placeHolders []string) (_ []string, err
 	if placeHolders, err = b.Havings.write(w, 'h', placeHolders); err != nil {
 		return nil, errors.WithStack(err)
 	}
+	return placeHolders, nil
+}
 
-	switch {
-	case b.IsOrderByDeactivated:
-		w.WriteString(" ORDER BY NULL")
-	case b.IsOrderByRand:
-		w.WriteString(" ORDER BY RAND()")
-	default:
-		sqlWriteOrderBy(w, b.OrderBys, false)
-	}
-
-	sqlWriteLimitOffset(w, b.LimitValid, true, b.OffsetCount, b.LimitCount)
-
+func (b *Select) writeLock(w *bytes.Buffer) {
 	switch {
 	case b.IsLockInShareMode:
 		w.WriteString(" LOCK IN SHARE MODE")
 	case b.IsForUpdate:
 		w.WriteString(" FOR UPDATE")
 	}
-	return placeHolders, err
 }
 
 // Prepare executes the statement represented by the Select to create a prepared
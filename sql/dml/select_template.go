@@ -0,0 +1,326 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/bufferpool"
+)
+
+// SelectTemplate contains a half-pre-rendered SELECT statement. The static
+// parts, the columns, FROM, JOIN, WHERE, GROUP BY and HAVING clauses, get
+// rendered only once. Each query created with NewQuery can append further
+// WHERE conditions and replace the ORDER BY and LIMIT clauses. This saves the
+// allocations of building the whole statement again in hot code paths and it
+// is useful during code generation. A SelectTemplate is safe for concurrent
+// use.
+type SelectTemplate struct {
+	base builderCommon
+
+	head     string // SELECT ... FROM ... JOIN ...
+	headPH   []string
+	where    string // static WHERE conditions without the keyword
+	wherePH  []string
+	tail     string // GROUP BY ... HAVING ...
+	tailPH   []string
+	orderBy  string
+	lock     string
+	limitOK  bool
+	offset   uint64
+	limit    uint64
+	rwmu     sync.RWMutex
+	rendered map[string]*templateSQL
+}
+
+// templateSQL contains a final SQL string. All fields are read-only and get
+// shared between Artisans.
+type templateSQL struct {
+	cachedSQL        map[string]string
+	qualifiedColumns []string
+	// slots contains the rendered slots of the query which has been used to
+	// create the cached SQL string. Only set when a cache key has been used.
+	slots string
+}
+
+// Template renders the static parts of the Select into a SelectTemplate.
+// Further changes to the Select do not affect the returned template. An error
+// gets returned when calling ToSQL or any of the Artisan functions of a query
+// created by the template. A Select using OrderByRandom cannot be used as a
+// template because its WHERE clause gets used in a sub select.
+func (b *Select) Template() *SelectTemplate {
+	b.rwmu.Lock()
+	defer b.rwmu.Unlock()
+
+	t := &SelectTemplate{
+		base:    b.builderCommon,
+		limitOK: b.LimitValid,
+		offset:  b.OffsetCount,
+		limit:   b.LimitCount,
+	}
+	t.base.cachedSQL = nil
+	t.base.CacheKey = ""
+	t.base.source = dmlSourceSelect
+	if t.base.ärgErr != nil {
+		return t
+	}
+	if b.OrderByRandColumnName != "" {
+		t.base.ärgErr = errors.NotSupported.Newf("[dml] SelectTemplate: OrderByRandom is not supported for table %q", b.Table.Name)
+		return t
+	}
+
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	var err error
+	if t.headPH, err = b.writeHead(buf, nil); err != nil {
+		t.base.ärgErr = errors.WithStack(err)
+		return t
+	}
+	t.head = buf.String()
+	t.base.defaultQualifier = b.defaultQualifier
+
+	buf.Reset()
+	if t.wherePH, err = b.Wheres.write(buf, 0, nil); err != nil {
+		t.base.ärgErr = errors.WithStack(err)
+		return t
+	}
+	t.where = buf.String()
+
+	buf.Reset()
+	if t.tailPH, err = b.writeGroupByHaving(buf, nil); err != nil {
+		t.base.ärgErr = errors.WithStack(err)
+		return t
+	}
+	t.tail = buf.String()
+
+	buf.Reset()
+	switch {
+	case b.IsOrderByDeactivated:
+		buf.WriteString(" ORDER BY NULL")
+	case b.IsOrderByRand:
+		buf.WriteString(" ORDER BY RAND()")
+	default:
+		sqlWriteOrderBy(buf, b.OrderBys, false)
+	}
+	t.orderBy = buf.String()
+
+	buf.Reset()
+	b.writeLock(buf)
+	t.lock = buf.String()
+	return t
+}
+
+// NewQuery creates a new query based on the template. The query is not safe
+// for concurrent use, each goroutine must create its own query.
+func (t *SelectTemplate) NewQuery() *SelectTemplateQuery {
+	return &SelectTemplateQuery{tpl: t}
+}
+
+// render returns the final SQL string of the query. If the query has a cache
+// key, the SQL string gets rendered only once per key.
+func (t *SelectTemplate) render(q *SelectTemplateQuery) (*templateSQL, error) {
+	if t.base.ärgErr != nil {
+		return nil, errors.WithStack(t.base.ärgErr)
+	}
+
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	if q.cacheKey != "" {
+		t.rwmu.RLock()
+		ts, ok := t.rendered[q.cacheKey]
+		t.rwmu.RUnlock()
+		if ok {
+			if err := q.writeSlots(buf); err != nil {
+				return nil, errors.WithStack(err)
+			}
+			if string(buf.Bytes()) != ts.slots {
+				return nil, errors.Mismatch.Newf("[dml] SelectTemplate: The slots %q of the query differ from the slots %q of the cached SQL string with key %q", buf.String(), ts.slots, q.cacheKey)
+			}
+			return ts, nil
+		}
+	}
+
+	qualifiedColumns, err := t.write(buf, q)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	rawSQL, qualifiedColumns, _ := extractReplaceNamedArgs(buf.String(), qualifiedColumns)
+	ts := &templateSQL{
+		cachedSQL:        map[string]string{"": rawSQL},
+		qualifiedColumns: qualifiedColumns,
+	}
+
+	if q.cacheKey != "" {
+		buf.Reset()
+		if err := q.writeSlots(buf); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		ts.slots = buf.String()
+		t.rwmu.Lock()
+		if t.rendered == nil {
+			t.rendered = make(map[string]*templateSQL, 8)
+		}
+		t.rendered[q.cacheKey] = ts
+		t.rwmu.Unlock()
+	}
+	return ts, nil
+}
+
+func (t *SelectTemplate) write(w *bytes.Buffer, q *SelectTemplateQuery) (placeHolders []string, err error) {
+	placeHolders = make([]string, 0, len(t.headPH)+len(t.wherePH)+len(t.tailPH)+len(q.Wheres))
+	w.WriteString(t.head)
+	placeHolders = append(placeHolders, t.headPH...)
+
+	// Both condition lists get wrapped in brackets, otherwise an OR condition
+	// in one of them would change the meaning of the other.
+	switch hasStatic, hasSlot := t.where != "", len(q.Wheres) > 0; {
+	case hasStatic && hasSlot:
+		w.WriteString(" WHERE (")
+		w.WriteString(t.where)
+		w.WriteString(") AND (")
+		placeHolders = append(placeHolders, t.wherePH...)
+		if placeHolders, err = q.Wheres.write(w, 0, placeHolders); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		w.WriteByte(')')
+	case hasStatic:
+		w.WriteString(" WHERE ")
+		w.WriteString(t.where)
+		placeHolders = append(placeHolders, t.wherePH...)
+	case hasSlot:
+		if placeHolders, err = q.Wheres.write(w, 'w', placeHolders); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	w.WriteString(t.tail)
+	placeHolders = append(placeHolders, t.tailPH...)
+
+	if len(q.OrderBys) > 0 {
+		sqlWriteOrderBy(w, q.OrderBys, false)
+	} else {
+		w.WriteString(t.orderBy)
+	}
+	if q.LimitValid {
+		sqlWriteLimitOffset(w, true, true, q.OffsetCount, q.LimitCount)
+	} else {
+		sqlWriteLimitOffset(w, t.limitOK, true, t.offset, t.limit)
+	}
+	w.WriteString(t.lock)
+	return placeHolders, nil
+}
+
+// writeSlots writes the WHERE conditions, the ORDER BY and the LIMIT clause of
+// the query. Used to detect whether two queries with the same cache key differ.
+func (q *SelectTemplateQuery) writeSlots(w *bytes.Buffer) error {
+	if _, err := q.Wheres.write(w, 0, nil); err != nil {
+		return errors.WithStack(err)
+	}
+	sqlWriteOrderBy(w, q.OrderBys, false)
+	sqlWriteLimitOffset(w, q.LimitValid, true, q.OffsetCount, q.LimitCount)
+	return nil
+}
+
+// SelectTemplateQuery contains the slots of a SelectTemplate which can be
+// changed for each query.
+type SelectTemplateQuery struct {
+	tpl      *SelectTemplate
+	cacheKey string
+	// Wheres get appended with AND to the WHERE conditions of the template.
+	Wheres Conditions
+	// OrderBys replace the ORDER BY clause of the template, if set.
+	OrderBys ids
+	// LimitValid if true, the LIMIT clause of the template gets replaced.
+	LimitValid  bool
+	OffsetCount uint64
+	LimitCount  uint64
+	// IsUnsafe see BuilderBase.IsUnsafe.
+	IsUnsafe bool
+}
+
+// Where appends WHERE conditions to the conditions of the template. To benefit
+// from WithCacheKey, the conditions should use place holders instead of
+// values.
+func (q *SelectTemplateQuery) Where(wf ...*Condition) *SelectTemplateQuery {
+	q.Wheres = append(q.Wheres, wf...)
+	return q
+}
+
+// OrderBy appends columns to the ORDER BY statement for ascending sorting. Any
+// ORDER BY clause of the template gets replaced.
+func (q *SelectTemplateQuery) OrderBy(columns ...string) *SelectTemplateQuery {
+	q.OrderBys = q.OrderBys.AppendColumns(q.IsUnsafe, columns...)
+	return q
+}
+
+// OrderByDesc appends columns to the ORDER BY statement for descending
+// sorting. Any ORDER BY clause of the template gets replaced.
+func (q *SelectTemplateQuery) OrderByDesc(columns ...string) *SelectTemplateQuery {
+	q.OrderBys = q.OrderBys.AppendColumns(q.IsUnsafe, columns...).applySort(len(columns), sortDescending)
+	return q
+}
+
+// Limit sets a LIMIT clause for the statement and replaces the LIMIT clause of
+// the template.
+func (q *SelectTemplateQuery) Limit(offset uint64, limit uint64) *SelectTemplateQuery {
+	q.OffsetCount = offset
+	q.LimitCount = limit
+	q.LimitValid = true
+	return q
+}
+
+// WithCacheKey caches the rendered SQL string in the template under the key.
+// Any later query with the same key uses the cached SQL string, so the key must
+// identify the conditions, the ORDER BY and the LIMIT clause. A query whose
+// slots differ from the slots of the cached SQL string returns an error of kind
+// Mismatch. Without a cache key, the SQL string gets rendered each time. If the
+// `args` argument contains values, then fmt.Sprintf gets used.
+func (q *SelectTemplateQuery) WithCacheKey(key string, args ...interface{}) *SelectTemplateQuery {
+	var bc builderCommon
+	bc.withCacheKey(key, args...)
+	q.cacheKey = bc.CacheKey
+	return q
+}
+
+// ToSQL returns the SQL string of the template with the applied slots.
+func (q *SelectTemplateQuery) ToSQL() (string, []interface{}, error) {
+	ts, err := q.tpl.render(q)
+	if err != nil {
+		return "", nil, errors.WithStack(err)
+	}
+	return ts.cachedSQL[""], nil, nil
+}
+
+// WithArgs returns a new Artisan to collect the arguments and to execute the
+// query. See Select.WithArgs.
+func (q *SelectTemplateQuery) WithArgs() *Artisan {
+	var args [defaultArgumentsCapacity]argument
+	a := &Artisan{
+		base:      q.tpl.base,
+		arguments: args[:0],
+	}
+	ts, err := q.tpl.render(q)
+	if err != nil {
+		a.base.ärgErr = errors.WithStack(err)
+		return a
+	}
+	a.base.cachedSQL = ts.cachedSQL
+	a.base.qualifiedColumns = ts.qualifiedColumns
+	return a
+}
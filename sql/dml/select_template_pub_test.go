@@ -0,0 +1,134 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func newTemplateSelect() *dml.Select {
+	return dml.NewSelect("e.entity_id", "e.sku").FromAlias("catalog_product_entity", "e").
+		Join(dml.MakeIdentifier("cataloginventory_stock_item").Alias("si"), dml.Column("e.entity_id").Equal().Column("si.product_id")).
+		Where(
+			dml.Column("e.type_id").Str("simple"),
+			dml.Column("e.store_id").PlaceHolder(),
+		).
+		GroupBy("e.entity_id").
+		OrderBy("e.sku").
+		Limit(0, 20)
+}
+
+func TestSelectTemplate(t *testing.T) {
+	t.Parallel()
+
+	sel := newTemplateSelect()
+	tpl := sel.Template()
+
+	t.Run("without slots equals Select", func(t *testing.T) {
+		selSQL, _, err := sel.ToSQL()
+		assert.NoError(t, err)
+		compareToSQL(t, tpl.NewQuery(), errors.NoKind, selSQL, "")
+	})
+
+	t.Run("append WHERE, replace ORDER BY and LIMIT", func(t *testing.T) {
+		q := tpl.NewQuery().
+			Where(
+				dml.Column("si.qty").Greater().PlaceHolder(),
+				dml.Column("e.sku").Like().Str("a%").Or(),
+			).
+			OrderByDesc("si.qty").
+			Limit(40, 20)
+		compareToSQL(t, q.WithArgs().Int(1).Int(0), errors.NoKind,
+			"SELECT `e`.`entity_id`, `e`.`sku` FROM `catalog_product_entity` AS `e` INNER JOIN `cataloginventory_stock_item` AS `si` ON (`e`.`entity_id` = `si`.`product_id`) WHERE ((`e`.`type_id` = 'simple') AND (`e`.`store_id` = ?)) AND ((`si`.`qty` > ?) OR (`e`.`sku` LIKE 'a%')) GROUP BY `e`.`entity_id` ORDER BY `si`.`qty` DESC LIMIT 40,20",
+			"SELECT `e`.`entity_id`, `e`.`sku` FROM `catalog_product_entity` AS `e` INNER JOIN `cataloginventory_stock_item` AS `si` ON (`e`.`entity_id` = `si`.`product_id`) WHERE ((`e`.`type_id` = 'simple') AND (`e`.`store_id` = 1)) AND ((`si`.`qty` > 0) OR (`e`.`sku` LIKE 'a%')) GROUP BY `e`.`entity_id` ORDER BY `si`.`qty` DESC LIMIT 40,20",
+			int64(1), int64(0),
+		)
+	})
+
+	t.Run("template without WHERE", func(t *testing.T) {
+		tpl := dml.NewSelect("entity_id").From("customer_entity").ForUpdate().Template()
+		compareToSQL(t, tpl.NewQuery().Where(dml.Column("email").NamedArg("email")), errors.NoKind,
+			"SELECT `entity_id` FROM `customer_entity` WHERE (`email` = ?) FOR UPDATE", "",
+		)
+		compareToSQL(t, tpl.NewQuery().Where(dml.Column("email").NamedArg("email")).WithArgs().Name("email").String("a@b.c"), errors.NoKind,
+			"SELECT `entity_id` FROM `customer_entity` WHERE (`email` = ?) FOR UPDATE",
+			"SELECT `entity_id` FROM `customer_entity` WHERE (`email` = 'a@b.c') FOR UPDATE",
+			"a@b.c",
+		)
+	})
+
+	t.Run("changes to Select do not affect the template", func(t *testing.T) {
+		sel := newTemplateSelect()
+		tpl := sel.Template()
+		sel.Where(dml.Column("e.has_options").Int(1))
+		q := tpl.NewQuery()
+		compareToSQL(t, q, errors.NoKind,
+			"SELECT `e`.`entity_id`, `e`.`sku` FROM `catalog_product_entity` AS `e` INNER JOIN `cataloginventory_stock_item` AS `si` ON (`e`.`entity_id` = `si`.`product_id`) WHERE (`e`.`type_id` = 'simple') AND (`e`.`store_id` = ?) GROUP BY `e`.`entity_id` ORDER BY `e`.`sku` LIMIT 0,20", "",
+		)
+	})
+
+	t.Run("cache key", func(t *testing.T) {
+		tpl := dml.NewSelect("entity_id").From("customer_entity").Template()
+		compareToSQL(t, tpl.NewQuery().WithCacheKey("byGroup").Where(dml.Column("group_id").PlaceHolder()), errors.NoKind,
+			"SELECT `entity_id` FROM `customer_entity` WHERE (`group_id` = ?)", "",
+		)
+		compareToSQL(t, tpl.NewQuery().WithCacheKey("byGroup").Where(dml.Column("group_id").PlaceHolder()), errors.NoKind,
+			"SELECT `entity_id` FROM `customer_entity` WHERE (`group_id` = ?)", "",
+		)
+		// Same key but different slots.
+		compareToSQL(t, tpl.NewQuery().WithCacheKey("byGroup").Where(dml.Column("website_id").PlaceHolder()), errors.Mismatch, "", "")
+		compareToSQL(t, tpl.NewQuery().WithCacheKey("byGroup").Where(dml.Column("group_id").PlaceHolder()).Limit(0, 10), errors.Mismatch, "", "")
+		_, _, err := tpl.NewQuery().WithCacheKey("byGroup").Where(dml.Column("group_id").Int(3)).WithArgs().ToSQL()
+		assert.ErrorIsKind(t, errors.Mismatch, err)
+		compareToSQL(t, tpl.NewQuery().WithCacheKey("by%s", "Website").Where(dml.Column("website_id").PlaceHolder()), errors.NoKind,
+			"SELECT `entity_id` FROM `customer_entity` WHERE (`website_id` = ?)", "",
+		)
+	})
+
+	t.Run("OrderByRandom not supported", func(t *testing.T) {
+		tpl := dml.NewSelect("entity_id").From("customer_entity").OrderByRandom("entity_id", 10).Template()
+		compareToSQL(t, tpl.NewQuery(), errors.NotSupported, "", "")
+		_, _, err := tpl.NewQuery().WithArgs().ToSQL()
+		assert.ErrorIsKind(t, errors.NotSupported, err)
+	})
+}
+
+func TestSelectTemplate_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	tpl := newTemplateSelect().Template()
+	const want = "SELECT `e`.`entity_id`, `e`.`sku` FROM `catalog_product_entity` AS `e` INNER JOIN `cataloginventory_stock_item` AS `si` ON (`e`.`entity_id` = `si`.`product_id`) WHERE ((`e`.`type_id` = 'simple') AND (`e`.`store_id` = 2)) AND ((`si`.`qty` > 10)) GROUP BY `e`.`entity_id` ORDER BY `e`.`sku` LIMIT 0,20"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				sqlStr, args, err := tpl.NewQuery().WithCacheKey("qty").Where(dml.Column("si.qty").Greater().PlaceHolder()).
+					WithArgs().Interpolate().Int(2).Int(10).ToSQL()
+				assert.NoError(t, err)
+				assert.Nil(t, args)
+				assert.Exactly(t, want, sqlStr)
+			}
+		}()
+	}
+	wg.Wait()
+}
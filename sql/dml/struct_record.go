@@ -0,0 +1,118 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dml
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/corestoreio/errors"
+)
+
+// structFields caches the column names and field indexes of a struct type.
+var structFields sync.Map // map[reflect.Type]*structFieldMap
+
+type structFieldMap struct {
+	columns []string
+	index   map[string]int
+}
+
+func loadStructFieldMap(typ reflect.Type) *structFieldMap {
+	if sfm, ok := structFields.Load(typ); ok {
+		return sfm.(*structFieldMap)
+	}
+	sfm := &structFieldMap{index: make(map[string]int, typ.NumField())}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+		name := f.Name
+		if tag := f.Tag.Get("db"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			if c := strings.IndexByte(tag, ','); c >= 0 {
+				tag = tag[:c]
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		sfm.columns = append(sfm.columns, name)
+		sfm.index[name] = i
+	}
+	structFields.Store(typ, sfm)
+	return sfm
+}
+
+// structRecord implements ColumnMapper for any struct with the help of
+// reflection. It supports only the collection of arguments and not the
+// scanning of rows.
+type structRecord struct {
+	val    reflect.Value
+	fields *structFieldMap
+}
+
+func newStructRecord(v interface{}) (*structRecord, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.NotSupported.Newf("[dml] Struct requires a struct or a pointer to a struct, got %T", v)
+	}
+	return &structRecord{val: rv, fields: loadStructFieldMap(rv.Type())}, nil
+}
+
+func (sr *structRecord) appendField(cm *ColumnMap, i int) (err error) {
+	fv := sr.val.Field(i)
+	for fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			cm.arguments = cm.arguments.add(nil)
+			return nil
+		}
+		fv = fv.Elem()
+	}
+	cm.arguments, err = iFaceToArgs(cm.arguments, fv.Interface())
+	return errors.WithStack(err)
+}
+
+// MapColumns implements interface ColumnMapper.
+func (sr *structRecord) MapColumns(cm *ColumnMap) error {
+	switch cm.Mode() {
+	case ColumnMapScan:
+		return errors.NotSupported.Newf("[dml] Struct %s does not support scanning of rows", sr.val.Type())
+	case ColumnMapEntityReadAll:
+		for _, c := range sr.fields.columns {
+			if err := sr.appendField(cm, sr.fields.index[c]); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		return cm.Err()
+	}
+	for cm.Next() {
+		c := cm.Column()
+		i, ok := sr.fields.index[c]
+		if !ok {
+			return errors.NotFound.Newf("[dml] Column %q not found in struct %s", c, sr.val.Type())
+		}
+		if err := sr.appendField(cm, i); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return cm.Err()
+}
@@ -0,0 +1,365 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/objcache"
+	"github.com/corestoreio/pkg/sync/singleflight"
+)
+
+const (
+	defaultKeyPrefix = "dmlcache_"
+	// entryVersion gets written as first byte of each cached result set to
+	// detect incompatible entries after an update.
+	entryVersion byte = 1
+)
+
+// Options applied to a QueryCache.
+type Options struct {
+	// Expires defines the time to live of a cached result set. Zero uses the
+	// default expiration of the objcache.Service.
+	Expires time.Duration
+	// KeyPrefix gets prepended to all keys. Defaults to "dmlcache_".
+	KeyPrefix string
+	// Codec encodes and decodes a ColumnMapper which neither implements
+	// Marshal/Unmarshal nor the encoding.BinaryMarshaler and
+	// encoding.BinaryUnmarshaler interfaces.
+	Codec objcache.Codecer
+	// Log optional logger for errors which cannot be returned, for example a
+	// failed invalidation in the DriverCallBack or a failed cache write after
+	// a successful query.
+	Log log.Logger
+}
+
+// QueryCache caches the results of read queries in an objcache.Service. A
+// cached result set is tagged with the tables of its query. Each table has a
+// version which becomes part of the cache key, so invalidating a table makes
+// all cached result sets of the table unreachable at once; they expire later.
+// Writes invalidate the tables either via DriverCallBack, when writing through
+// the same ConnPool, or via the binlogsync.RowsEventHandler implementation.
+// QueryCache is safe for concurrent use.
+type QueryCache struct {
+	svc        *objcache.Service
+	o          Options
+	sf         singleflight.Group
+	versionSeq uint64 // atomic
+}
+
+// New creates a new QueryCache which stores its data in svc.
+func New(svc *objcache.Service, o Options) *QueryCache {
+	if o.KeyPrefix == "" {
+		o.KeyPrefix = defaultKeyPrefix
+	}
+	return &QueryCache{svc: svc, o: o}
+}
+
+// Load returns the cached result set of the query in dst or runs the query with
+// Artisan.Load and caches the result set. The cache key gets derived from the
+// generated SQL string, which depends on the cache key of the builder, the
+// arguments and the versions of the tables used in the query. Concurrent
+// misses for the same key run the query only once; all callers must use the
+// same type for dst. The returned rowCount is the one from the original query.
+func (qc *QueryCache) Load(ctx context.Context, a *dml.Artisan, dst dml.ColumnMapper, args ...interface{}) (rowCount uint64, err error) {
+	sqlStr, qArgs, err := a.ToSQL()
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	key, err := qc.key(ctx, sqlStr, append(qArgs, args...))
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	e := &entry{codec: qc.o.Codec, dst: dst}
+	if err = qc.svc.Get(ctx, key, e); err != nil && !errors.NotFound.Match(err) {
+		return 0, errors.WithStack(err)
+	}
+	if e.found {
+		return e.rowCount, nil
+	}
+
+	var isLeader bool
+	v, err, _ := qc.sf.Do(key, func() (interface{}, error) {
+		isLeader = true
+		rc, err := a.Load(ctx, dst, args...)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		rowCount = rc
+		data, err := (&entry{codec: qc.o.Codec, dst: dst, rowCount: rc}).Marshal()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		// The query succeeded and dst contains the result set, hence a failed
+		// cache write only gets logged.
+		if err := qc.svc.Set(ctx, key, rawBytes(data), qc.o.Expires); err != nil && qc.o.Log != nil {
+			qc.o.Log.Info("dmlcache.QueryCache.Load.Set.error", log.Err(err), log.String("key", key))
+		}
+		return data, nil
+	})
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if isLeader {
+		return rowCount, nil
+	}
+	if err := e.Unmarshal(v.([]byte)); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return e.rowCount, nil
+}
+
+// key hashes the SQL string, the arguments and the versions of the tables.
+func (qc *QueryCache) key(ctx context.Context, sqlStr string, args []interface{}) (string, error) {
+	tables := tableNames(sqlStr)
+	versions, err := qc.versions(ctx, tables)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	h := sha256.New()
+	_, _ = io.WriteString(h, sqlStr)
+	for _, arg := range args {
+		_, _ = fmt.Fprintf(h, "\x00%T\x00%v", arg, arg)
+	}
+	for i, t := range tables {
+		_, _ = fmt.Fprintf(h, "\x01%s\x00%s", t, versions[i])
+	}
+	return qc.o.KeyPrefix + hex.EncodeToString(h.Sum(nil)[:16]), nil
+}
+
+func (qc *QueryCache) versionKey(table string) string {
+	return qc.o.KeyPrefix + "tbl_" + table
+}
+
+func (qc *QueryCache) newVersion() tableVersion {
+	return tableVersion(strconv.FormatInt(time.Now().UnixNano(), 36) + "." +
+		strconv.FormatUint(atomic.AddUint64(&qc.versionSeq, 1), 36))
+}
+
+// versions returns the current version of each table. Tables without a version
+// get a new one, otherwise an evicted version could make outdated entries
+// reachable again.
+func (qc *QueryCache) versions(ctx context.Context, tables []string) ([]tableVersion, error) {
+	if len(tables) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, len(tables))
+	versions := make([]tableVersion, len(tables))
+	dst := make([]interface{}, len(tables))
+	for i, t := range tables {
+		keys[i] = qc.versionKey(t)
+		dst[i] = &versions[i]
+	}
+	if err := qc.svc.GetMulti(ctx, keys, dst); err != nil && !errors.NotFound.Match(err) {
+		return nil, errors.WithStack(err)
+	}
+
+	var newKeys []string
+	var newVersions []interface{}
+	for i, v := range versions {
+		if v == "" {
+			versions[i] = qc.newVersion()
+			newKeys = append(newKeys, keys[i])
+			newVersions = append(newVersions, versions[i])
+		}
+	}
+	if len(newKeys) > 0 {
+		if err := qc.svc.SetMulti(ctx, newKeys, newVersions, nil); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return versions, nil
+}
+
+// Invalidate makes all cached result sets of the tables unreachable. The table
+// names are case insensitive and must not contain the database name.
+func (qc *QueryCache) Invalidate(ctx context.Context, tables ...string) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	keys := make([]string, len(tables))
+	versions := make([]interface{}, len(tables))
+	for i, t := range tables {
+		keys[i] = qc.versionKey(toLower(t))
+		versions[i] = qc.newVersion()
+	}
+	return errors.WithStack(qc.svc.SetMulti(ctx, keys, versions, nil))
+}
+
+// InvalidateSQL invalidates all tables used in the SQL statement, if it is a
+// write statement like INSERT, UPDATE, DELETE or ALTER.
+func (qc *QueryCache) InvalidateSQL(ctx context.Context, sqlStr string) error {
+	if !isWriteStatement(sqlStr) {
+		return nil
+	}
+	return qc.Invalidate(ctx, tableNames(sqlStr)...)
+}
+
+// DriverCallBack returns a call back for dml.WithDSN which invalidates the
+// tables of each successful write statement sent through the connection pool.
+// Statements running in a transaction invalidate when they get executed and
+// not when the transaction commits; the binlogsync.RowsEventHandler
+// implementation does not have this limitation.
+func (qc *QueryCache) DriverCallBack() dml.DriverCallBack {
+	passThrough := func(err error, _ string, _ []driver.NamedValue) error { return err }
+	return func(fnName string) func(error, string, []driver.NamedValue) error {
+		switch fnName {
+		case "Conn.ExecContext", "Stmt.ExecContext", "Stmt.Exec":
+		default:
+			return passThrough
+		}
+		return func(err error, query string, _ []driver.NamedValue) error {
+			if err != nil {
+				return err
+			}
+			if errI := qc.InvalidateSQL(context.Background(), query); errI != nil && qc.o.Log != nil {
+				qc.o.Log.Info("dmlcache.QueryCache.DriverCallBack.Invalidate.error", log.Err(errI), log.String("query", query))
+			}
+			return nil
+		}
+	}
+}
+
+// Do implements binlogsync.RowsEventHandler and invalidates the table of the
+// rows event.
+func (qc *QueryCache) Do(ctx context.Context, _ string, t *ddl.Table, _ [][]interface{}) error {
+	return errors.WithStack(qc.Invalidate(ctx, t.Name))
+}
+
+// Complete implements binlogsync.RowsEventHandler.
+func (qc *QueryCache) Complete(context.Context) error { return nil }
+
+// String implements binlogsync.RowsEventHandler.
+func (qc *QueryCache) String() string { return "dmlcache.QueryCache" }
+
+func toLower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+// tableVersion gets stored unchanged in the objcache.Service.
+type tableVersion string
+
+func (tv tableVersion) Marshal() ([]byte, error) { return []byte(tv), nil }
+
+func (tv *tableVersion) Unmarshal(data []byte) error {
+	*tv = tableVersion(data)
+	return nil
+}
+
+// rawBytes gets stored unchanged in the objcache.Service.
+type rawBytes []byte
+
+func (rb rawBytes) Marshal() ([]byte, error) { return rb, nil }
+
+type marshaler interface {
+	Marshal() ([]byte, error)
+}
+
+type unmarshaler interface {
+	Unmarshal([]byte) error
+}
+
+// entry wraps a cached result set to detect a cache miss, because the
+// objcache.Service decodes an empty value for a missing key.
+type entry struct {
+	codec    objcache.Codecer
+	dst      interface{}
+	rowCount uint64
+	found    bool
+}
+
+// Marshal writes the version, the row count and the encoded dst.
+func (e *entry) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(entryVersion)
+	var p [binary.MaxVarintLen64]byte
+	buf.Write(p[:binary.PutUvarint(p[:], e.rowCount)])
+
+	var data []byte
+	var err error
+	switch dst := e.dst.(type) {
+	case marshaler:
+		data, err = dst.Marshal()
+	case encoding.BinaryMarshaler:
+		data, err = dst.MarshalBinary()
+	default:
+		if e.codec == nil {
+			return nil, errors.NotImplemented.Newf("[dmlcache] Type %T does not implement Marshal or Options.Codec not set", e.dst)
+		}
+		err = e.codec.NewEncoder(&buf).Encode(e.dst)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "[dmlcache] Failed to encode type %T", e.dst)
+	}
+	buf.Write(data)
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes the data into dst. Empty data marks a cache miss.
+func (e *entry) Unmarshal(data []byte) error {
+	e.found = false
+	if len(data) == 0 {
+		return nil
+	}
+	if data[0] != entryVersion {
+		return nil // treat as miss, gets overwritten
+	}
+	rc, n := binary.Uvarint(data[1:])
+	if n <= 0 {
+		return errors.NotValid.Newf("[dmlcache] Invalid row count in cache entry")
+	}
+	e.rowCount = rc
+	data = data[1+n:]
+
+	var err error
+	switch dst := e.dst.(type) {
+	case unmarshaler:
+		err = dst.Unmarshal(data)
+	case encoding.BinaryUnmarshaler:
+		err = dst.UnmarshalBinary(data)
+	default:
+		if e.codec == nil {
+			return errors.NotImplemented.Newf("[dmlcache] Type %T does not implement Unmarshal or Options.Codec not set", e.dst)
+		}
+		err = e.codec.NewDecoder(bytes.NewReader(data)).Decode(e.dst)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "[dmlcache] Failed to decode type %T", e.dst)
+	}
+	e.found = true
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlcache_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log/logw"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmlcache"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/storage/objcache"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+type customer struct {
+	ID    int64
+	Email string
+}
+
+type customers struct {
+	Data []customer
+}
+
+func (cc *customers) MapColumns(cm *dml.ColumnMap) error {
+	switch m := cm.Mode(); m {
+	case dml.ColumnMapScan:
+		if cm.Count == 0 {
+			cc.Data = cc.Data[:0]
+		}
+		var c customer
+		for cm.Next() {
+			switch col := cm.Column(); col {
+			case "entity_id":
+				cm.Int64(&c.ID)
+			case "email":
+				cm.String(&c.Email)
+			default:
+				return errors.NotFound.Newf("[dmlcache_test] Column %q not found", col)
+			}
+		}
+		cc.Data = append(cc.Data, c)
+	default:
+		return errors.NotSupported.Newf("[dmlcache_test] Unknown Mode: %q", string(m))
+	}
+	return cm.Err()
+}
+
+func (cc *customers) Marshal() ([]byte, error) { return json.Marshal(cc.Data) }
+
+func (cc *customers) Unmarshal(data []byte) error { return json.Unmarshal(data, &cc.Data) }
+
+const customerSQL = "SELECT `entity_id`, `email` FROM `customer_entity` WHERE (`entity_id` > ?)"
+
+func expectCustomers(dbMock sqlmock.Sqlmock) {
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(customerSQL)).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"entity_id", "email"}).
+			AddRow(4, "a@b.c").
+			AddRow(5, "d@e.f"))
+}
+
+func newQueryCache(t *testing.T) *dmlcache.QueryCache {
+	svc, err := objcache.NewService(nil, objcache.NewCacheSimpleInmemory, nil)
+	assert.NoError(t, err)
+	return dmlcache.New(svc, dmlcache.Options{})
+}
+
+var wantCustomers = []customer{{ID: 4, Email: "a@b.c"}, {ID: 5, Email: "d@e.f"}}
+
+func TestQueryCache_Load(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+	qc := newQueryCache(t)
+	ctx := context.TODO()
+
+	load := func(arg int) []customer {
+		var cc customers
+		rowCount, err := qc.Load(ctx, dbc.WithRawSQL(customerSQL), &cc, arg)
+		assert.NoError(t, err)
+		assert.Exactly(t, uint64(len(cc.Data)), rowCount)
+		return cc.Data
+	}
+
+	expectCustomers(dbMock)
+	assert.Exactly(t, wantCustomers, load(3))
+	assert.Exactly(t, wantCustomers, load(3), "cache hit without query")
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(customerSQL)).WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"entity_id", "email"}).AddRow(5, "d@e.f"))
+	assert.Exactly(t, wantCustomers[1:], load(4), "other argument")
+
+	t.Run("Invalidate", func(t *testing.T) {
+		assert.NoError(t, qc.Invalidate(ctx, "Customer_Entity"))
+		expectCustomers(dbMock)
+		assert.Exactly(t, wantCustomers, load(3))
+		assert.Exactly(t, wantCustomers, load(3))
+	})
+	t.Run("DriverCallBack", func(t *testing.T) {
+		cb := qc.DriverCallBack()
+		// reads and failed writes do not invalidate
+		assert.NoError(t, cb("Conn.QueryContext")(nil, "UPDATE `customer_entity` SET email=?", nil))
+		assert.ErrorIsKind(t, errors.AlreadyClosed, cb("Conn.ExecContext")(errors.AlreadyClosed.Newf("closed"), "UPDATE `customer_entity` SET email=?", nil))
+		assert.NoError(t, cb("Conn.ExecContext")(nil, "SELECT 1 FROM `customer_entity`", nil))
+		assert.Exactly(t, wantCustomers, load(3))
+
+		assert.NoError(t, cb("Stmt.ExecContext")(nil, "UPDATE `customer_entity` SET email=?", nil))
+		expectCustomers(dbMock)
+		assert.Exactly(t, wantCustomers, load(3))
+	})
+	t.Run("RowsEventHandler", func(t *testing.T) {
+		assert.NoError(t, qc.Do(ctx, "update", &ddl.Table{Name: "customer_entity"}, nil))
+		assert.NoError(t, qc.Complete(ctx))
+		expectCustomers(dbMock)
+		assert.Exactly(t, wantCustomers, load(3))
+	})
+}
+
+func TestQueryCache_Load_Singleflight(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+	qc := newQueryCache(t)
+
+	const loaders = 10
+	start := make(chan struct{})
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta(customerSQL)).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"entity_id", "email"}).AddRow(4, "a@b.c").AddRow(5, "d@e.f")).
+		WillDelayFor(50 * time.Millisecond) // keeps the first query running while the others wait
+
+	var wg sync.WaitGroup
+	wg.Add(loaders)
+	for i := 0; i < loaders; i++ {
+		go func() {
+			defer wg.Done()
+			<-start
+			var cc customers
+			rowCount, err := qc.Load(context.TODO(), dbc.WithRawSQL(customerSQL), &cc, 3)
+			assert.NoError(t, err)
+			assert.Exactly(t, uint64(2), rowCount)
+			assert.Exactly(t, wantCustomers, cc.Data)
+		}()
+	}
+	close(start)
+	wg.Wait()
+}
+
+func TestQueryCache_Load_NotImplemented(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+	qc := newQueryCache(t)
+
+	expectCustomers(dbMock)
+	var nm struct{ dml.ColumnMapper }
+	nm.ColumnMapper = &customers{}
+	_, err := qc.Load(context.TODO(), dbc.WithRawSQL(customerSQL), nm, 3)
+	assert.ErrorIsKind(t, errors.NotImplemented, err)
+}
+
+// setErrorStorage fails to write result sets into the cache. The versions of
+// the tables get written.
+type setErrorStorage struct {
+	objcache.Storager
+}
+
+func (s setErrorStorage) Set(ctx context.Context, keys []string, values [][]byte, expirations []time.Duration) error {
+	for _, k := range keys {
+		if !strings.HasPrefix(k, "dmlcache_tbl_") {
+			return errors.ConnectionFailed.Newf("[dmlcache_test] cache down")
+		}
+	}
+	return s.Storager.Set(ctx, keys, values, expirations)
+}
+
+func TestQueryCache_Load_SetError(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	svc, err := objcache.NewService(nil, func() (objcache.Storager, error) {
+		s, err := objcache.NewCacheSimpleInmemory()
+		return setErrorStorage{Storager: s}, err
+	}, nil)
+	assert.NoError(t, err)
+	buf := new(bytes.Buffer)
+	qc := dmlcache.New(svc, dmlcache.Options{
+		Log: logw.NewLog(logw.WithLevel(logw.LevelInfo), logw.WithWriter(buf), logw.WithFlag(0)),
+	})
+
+	// Each Load queries the database because nothing gets cached.
+	for i := 0; i < 2; i++ {
+		expectCustomers(dbMock)
+		var cc customers
+		rowCount, err := qc.Load(context.TODO(), dbc.WithRawSQL(customerSQL), &cc, 3)
+		assert.NoError(t, err)
+		assert.Exactly(t, uint64(2), rowCount)
+		assert.Exactly(t, wantCustomers, cc.Data)
+	}
+	assert.Exactly(t, 2, strings.Count(buf.String(), "dmlcache.QueryCache.Load.Set.error"), buf.String())
+}
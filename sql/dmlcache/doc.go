@@ -0,0 +1,33 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dmlcache caches the result sets of dml queries in an objcache.Service.
+//
+// The cache is opt-in per query: only queries loaded via QueryCache.Load get
+// cached. The cache key derives from the SQL string, which contains the cache
+// key of the builder, and from the arguments. Each cached result set depends on
+// a version of every table used in the query. Invalidating a table sets a new
+// version and all cached result sets of that table become unreachable.
+//
+//	qc := dmlcache.New(objcacheService, dmlcache.Options{Expires: time.Minute})
+//	dbc, err := dml.NewConnPool(dml.WithDSN(dsn, qc.DriverCallBack()))
+//	// ...
+//	var customers CustomerCollection // implements Marshal and Unmarshal
+//	_, err = qc.Load(ctx, dbc.WithQueryBuilder(sel), &customers, 42)
+//
+// Writes through the connection pool invalidate the tables via the
+// DriverCallBack. Writes from other applications can be tracked with the
+// binlogsync package, because QueryCache implements its RowsEventHandler.
+// Concurrent cache misses for the same key run the query only once.
+package dmlcache
@@ -0,0 +1,206 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlcache
+
+import (
+	"strings"
+)
+
+// sqlTokenizer splits a SQL string into words, quoted identifiers and single
+// character punctuation. Comments get skipped and string literals get returned
+// as a single quote.
+type sqlTokenizer struct {
+	sql string
+	pos int
+}
+
+// next returns the next token. Quoted identifiers get returned without the
+// back ticks and with isIdent set to true. An empty token means end of input.
+func (t *sqlTokenizer) next() (tok string, isIdent bool) {
+	for t.pos < len(t.sql) {
+		c := t.sql[t.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			t.pos++
+		case c == '#' || (c == '-' && strings.HasPrefix(t.sql[t.pos:], "-- ")):
+			if i := strings.IndexByte(t.sql[t.pos:], '\n'); i >= 0 {
+				t.pos += i + 1
+			} else {
+				t.pos = len(t.sql)
+			}
+		case c == '/' && strings.HasPrefix(t.sql[t.pos:], "/*"):
+			if i := strings.Index(t.sql[t.pos+2:], "*/"); i >= 0 {
+				t.pos += i + 4
+			} else {
+				t.pos = len(t.sql)
+			}
+		case c == '\'' || c == '"':
+			t.pos++
+			for t.pos < len(t.sql) && t.sql[t.pos] != c {
+				if t.sql[t.pos] == '\\' {
+					t.pos++
+				}
+				t.pos++
+			}
+			t.pos++
+			return "'", false // literals are irrelevant, only their position counts
+		case c == '`':
+			end := strings.IndexByte(t.sql[t.pos+1:], '`')
+			if end < 0 {
+				tok, t.pos = t.sql[t.pos+1:], len(t.sql)
+				return tok, true
+			}
+			tok = t.sql[t.pos+1 : t.pos+1+end]
+			t.pos += end + 2
+			return tok, true
+		case isWordByte(c):
+			start := t.pos
+			for t.pos < len(t.sql) && isWordByte(t.sql[t.pos]) {
+				t.pos++
+			}
+			return t.sql[start:t.pos], false
+		default:
+			t.pos++
+			return string(c), false
+		}
+	}
+	return "", false
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// tableListKeywords are followed by one or more table references.
+var tableListKeywords = map[string]bool{
+	"FROM": true, "JOIN": true, "STRAIGHT_JOIN": true, "UPDATE": true,
+	"INTO": true, "TABLE": true, "REPLACE": true, "INSERT": true, "TRUNCATE": true,
+}
+
+// tableModifiers may stand between a table list keyword and the table name.
+var tableModifiers = map[string]bool{
+	"LOW_PRIORITY": true, "HIGH_PRIORITY": true, "DELAYED": true, "IGNORE": true,
+	"QUICK": true, "INTO": true, "TEMPORARY": true, "IF": true, "NOT": true, "EXISTS": true,
+}
+
+// aliasStopWords end a table reference and are never an alias.
+var aliasStopWords = map[string]bool{
+	"WHERE": true, "SET": true, "JOIN": true, "ON": true, "USING": true, "LEFT": true,
+	"RIGHT": true, "INNER": true, "CROSS": true, "NATURAL": true, "OUTER": true,
+	"STRAIGHT_JOIN": true, "GROUP": true, "ORDER": true, "LIMIT": true, "HAVING": true,
+	"VALUES": true, "VALUE": true, "SELECT": true, "PARTITION": true, "USE": true,
+	"FORCE": true, "IGNORE": true, "UNION": true, "WINDOW": true, "FOR": true,
+	"LOCK": true, "PROCEDURE": true, "INTO": true, "AS": true, "WITH": true,
+	"LIKE": true, "ADD": true, "DROP": true, "MODIFY": true, "CHANGE": true,
+	"RENAME": true, "ENGINE": true, "TO": true,
+}
+
+// tableNames returns the lower case names of all tables referenced in the SQL
+// statement, without the database qualifier and in order of their first
+// appearance. It is a heuristic and does not validate the statement. Derived
+// tables and CTE names might be returned as well, which leads at most to
+// superfluous invalidations.
+func tableNames(sql string) []string {
+	var (
+		t     = sqlTokenizer{sql: sql}
+		names []string
+		seen  = map[string]bool{}
+	)
+	add := func(name string) {
+		name = strings.ToLower(name)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	tok, isIdent := t.next()
+	for tok != "" {
+		if isIdent || !tableListKeywords[strings.ToUpper(tok)] {
+			tok, isIdent = t.next()
+			continue
+		}
+		// read a comma separated list of table references
+		for {
+			tok, isIdent = t.next()
+			for !isIdent && tableModifiers[strings.ToUpper(tok)] {
+				tok, isIdent = t.next()
+			}
+			if tok == "" || tok == "(" || tok == "'" || (!isIdent && !isWordByte(tok[0])) {
+				break // sub query or end
+			}
+			if !isIdent && (aliasStopWords[strings.ToUpper(tok)] || tableListKeywords[strings.ToUpper(tok)]) {
+				break
+			}
+			name := tok
+			tok, isIdent = t.next()
+			for tok == "." { // database qualifier
+				if tok, isIdent = t.next(); tok == "" {
+					break
+				}
+				name = tok
+				tok, isIdent = t.next()
+			}
+			add(name)
+
+			if !isIdent && strings.ToUpper(tok) == "AS" {
+				t.next() // alias
+				tok, isIdent = t.next()
+			} else if isIdent || (tok != "" && isWordByte(tok[0]) && !aliasStopWords[strings.ToUpper(tok)] && !tableListKeywords[strings.ToUpper(tok)]) {
+				tok, isIdent = t.next() // alias without AS
+			}
+			if tok != "," {
+				break
+			}
+		}
+	}
+	return names
+}
+
+// writeKeywords start statements which modify the data of a table.
+var writeKeywords = map[string]bool{
+	"INSERT": true, "REPLACE": true, "UPDATE": true, "DELETE": true, "TRUNCATE": true,
+	"DROP": true, "ALTER": true, "RENAME": true, "LOAD": true, "CREATE": true,
+}
+
+// isWriteStatement reports if the first keyword of the SQL statement, after
+// comments and a WITH clause, modifies data.
+func isWriteStatement(sql string) bool {
+	t := sqlTokenizer{sql: sql}
+	tok, _ := t.next()
+	if tok == "(" {
+		return false
+	}
+	if strings.EqualFold(tok, "WITH") {
+		// WITH ... UPDATE/DELETE: look for the first write keyword on level 0.
+		depth := 0
+		for tok != "" {
+			switch tok {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			default:
+				if depth == 0 && writeKeywords[strings.ToUpper(tok)] {
+					return true
+				}
+			}
+			tok, _ = t.next()
+		}
+		return false
+	}
+	return writeKeywords[strings.ToUpper(tok)]
+}
@@ -0,0 +1,55 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlcache
+
+import (
+	"testing"
+
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestTableNames(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		sql  string
+		want []string
+	}{
+		{"SELECT * FROM `customer_entity`", []string{"customer_entity"}},
+		{"SELECT a.* FROM `db1`.`customer` AS `a` LEFT JOIN address b ON a.id=b.cid WHERE a.id IN (SELECT id FROM Store)", []string{"customer", "address", "store"}},
+		{"SELECT 1 FROM a, `b` x, c AS y WHERE a.name='FROM fake'", []string{"a", "b", "c"}},
+		{"/* FROM comment */ SELECT 1 FROM t1 STRAIGHT_JOIN t2 -- JOIN t3\n", []string{"t1", "t2"}},
+		{"INSERT IGNORE INTO `sales_order` (`a`) VALUES (?)", []string{"sales_order"}},
+		{"REPLACE INTO sales_order SELECT * FROM quote", []string{"sales_order", "quote"}},
+		{"UPDATE LOW_PRIORITY `catalog` SET x=1", []string{"catalog"}},
+		{"DELETE FROM `core_config_data` WHERE path=?", []string{"core_config_data"}},
+		{"TRUNCATE TABLE `log`", []string{"log"}},
+		{"ALTER TABLE `customer` ADD COLUMN x INT", []string{"customer"}},
+		{"SELECT 1", nil},
+	}
+	for _, test := range tests {
+		assert.Exactly(t, test.want, tableNames(test.sql), "%q", test.sql)
+	}
+}
+
+func TestIsWriteStatement(t *testing.T) {
+	t.Parallel()
+	assert.True(t, isWriteStatement("  update a set b=1"))
+	assert.True(t, isWriteStatement("/* x */ INSERT INTO a VALUES (1)"))
+	assert.True(t, isWriteStatement("WITH c AS (SELECT id FROM b) DELETE FROM a WHERE id IN (SELECT id FROM c)"))
+	assert.False(t, isWriteStatement("WITH c AS (SELECT id FROM b) SELECT * FROM c"))
+	assert.False(t, isWriteStatement("SELECT * FROM `update`"))
+	assert.False(t, isWriteStatement("(SELECT 1) UNION (SELECT 2)"))
+	assert.False(t, isWriteStatement("SHOW TABLES"))
+}
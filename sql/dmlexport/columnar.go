@@ -0,0 +1,361 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlexport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"time"
+
+	"github.com/corestoreio/errors"
+)
+
+// The columnar format stores the rows in groups. Within a group all values of
+// a column get written one after another, which compresses well and allows a
+// reader to decode a column with a single type switch.
+//
+//	file   = magic version header group* end
+//	magic  = "DMLC"
+//	header = uvarint(len(json)) json([]Column)
+//	group  = uvarint(rowCount) column*
+//	column = null bitmap of (rowCount+7)/8 bytes, then the not-NULL values
+//	end    = uvarint(0)
+//
+// Values of KindInt get written as zig-zag varint, KindUint as uvarint,
+// KindFloat as 8 byte little endian IEEE 754, KindBool as one byte, KindTime
+// as varint Unix seconds plus uvarint nanoseconds in UTC and all others as
+// uvarint length plus bytes.
+const (
+	columnarMagic   = "DMLC"
+	columnarVersion = 1
+	// columnarMaxHeader limits the size of the JSON header to detect corrupt
+	// files.
+	columnarMaxHeader = 1 << 24
+)
+
+type columnarEncoder struct {
+	w        *bufio.Writer
+	rowGroup int
+	columns  []Column
+	rows     [][]interface{}
+	buf      []byte
+}
+
+func newColumnarEncoder(w io.Writer, o Options) *columnarEncoder {
+	return &columnarEncoder{
+		w:        bufio.NewWriter(w),
+		rowGroup: o.ColumnarRowGroup,
+		rows:     make([][]interface{}, 0, o.ColumnarRowGroup),
+	}
+}
+
+func (e *columnarEncoder) WriteHeader(columns []Column) error {
+	e.columns = columns
+	hdr, err := json.Marshal(columns)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	buf := append(e.buf[:0], columnarMagic...)
+	buf = append(buf, columnarVersion)
+	buf = appendUvarint(buf, uint64(len(hdr)))
+	buf = append(buf, hdr...)
+	e.buf = buf
+	_, err = e.w.Write(buf)
+	return errors.WithStack(err)
+}
+
+func (e *columnarEncoder) WriteRow(values []interface{}) error {
+	if len(values) != len(e.columns) {
+		return errors.Mismatch.Newf("[dmlexport] Columnar row has %d values but %d columns", len(values), len(e.columns))
+	}
+	e.rows = append(e.rows, values)
+	if len(e.rows) < e.rowGroup {
+		return nil
+	}
+	return e.writeGroup()
+}
+
+func (e *columnarEncoder) writeGroup() error {
+	if len(e.rows) == 0 {
+		return nil
+	}
+	buf := appendUvarint(e.buf[:0], uint64(len(e.rows)))
+	bitmapLen := (len(e.rows) + 7) / 8
+	for ci := range e.columns {
+		c := &e.columns[ci]
+		pos := len(buf)
+		buf = append(buf, make([]byte, bitmapLen)...)
+		for ri, row := range e.rows {
+			v := row[ci]
+			if v == nil {
+				buf[pos+ri/8] |= 1 << uint(ri%8)
+				continue
+			}
+			var ok bool
+			switch c.Kind {
+			case KindInt:
+				var i int64
+				if i, ok = v.(int64); ok {
+					buf = appendVarint(buf, i)
+				}
+			case KindUint:
+				var u uint64
+				if u, ok = v.(uint64); ok {
+					buf = appendUvarint(buf, u)
+				}
+			case KindFloat:
+				var f float64
+				if f, ok = v.(float64); ok {
+					var p [8]byte
+					binary.LittleEndian.PutUint64(p[:], math.Float64bits(f))
+					buf = append(buf, p[:]...)
+				}
+			case KindBool:
+				var b bool
+				if b, ok = v.(bool); ok {
+					if b {
+						buf = append(buf, 1)
+					} else {
+						buf = append(buf, 0)
+					}
+				}
+			case KindTime:
+				var t time.Time
+				if t, ok = v.(time.Time); ok {
+					buf = appendVarint(buf, t.Unix())
+					buf = appendUvarint(buf, uint64(t.Nanosecond()))
+				}
+			case KindBytes:
+				var p []byte
+				if p, ok = v.([]byte); ok {
+					buf = appendUvarint(buf, uint64(len(p)))
+					buf = append(buf, p...)
+				}
+			default:
+				var s string
+				if s, ok = v.(string); ok {
+					buf = appendUvarint(buf, uint64(len(s)))
+					buf = append(buf, s...)
+				}
+			}
+			if !ok {
+				return errors.NotSupported.Newf("[dmlexport] Columnar column %q of kind %s does not support type %T", c.Name, c.Kind, v)
+			}
+		}
+	}
+	e.buf = buf
+	for i := range e.rows {
+		e.rows[i] = nil // release the values to the GC
+	}
+	e.rows = e.rows[:0]
+	_, err := e.w.Write(buf)
+	return errors.WithStack(err)
+}
+
+// Flush writes the current row group, even if it is not full, to the
+// underlying writer.
+func (e *columnarEncoder) Flush() error {
+	if err := e.writeGroup(); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(e.w.Flush())
+}
+
+func (e *columnarEncoder) Close() error {
+	if err := e.writeGroup(); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := e.w.WriteByte(0); err != nil { // end marker uvarint(0)
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(e.w.Flush())
+}
+
+func appendUvarint(buf []byte, u uint64) []byte {
+	var p [binary.MaxVarintLen64]byte
+	return append(buf, p[:binary.PutUvarint(p[:], u)]...)
+}
+
+func appendVarint(buf []byte, i int64) []byte {
+	var p [binary.MaxVarintLen64]byte
+	return append(buf, p[:binary.PutVarint(p[:], i)]...)
+}
+
+// ColumnarReader reads a file written in the Columnar format. The values of a
+// row have the Go types int64, uint64, float64, bool, time.Time, string or
+// []byte depending on the Kind of the column, or are nil.
+//
+//	cr, err := dmlexport.NewColumnarReader(f)
+//	for cr.Next() {
+//		row := cr.Row()
+//	}
+//	err = cr.Err()
+type ColumnarReader struct {
+	r       *bufio.Reader
+	columns []Column
+	// group contains the values of the current row group row by row.
+	group [][]interface{}
+	index int
+	done  bool
+	err   error
+}
+
+// NewColumnarReader reads the header of the columnar format from r.
+func NewColumnarReader(r io.Reader) (*ColumnarReader, error) {
+	cr := &ColumnarReader{r: bufio.NewReader(r)}
+
+	var magic [len(columnarMagic) + 1]byte
+	if _, err := io.ReadFull(cr.r, magic[:]); err != nil {
+		return nil, errors.NotValid.New(err, "[dmlexport] Columnar file too short")
+	}
+	if !bytes.Equal(magic[:len(columnarMagic)], []byte(columnarMagic)) {
+		return nil, errors.NotValid.Newf("[dmlexport] Columnar file has an invalid magic %q", magic[:len(columnarMagic)])
+	}
+	if v := magic[len(columnarMagic)]; v != columnarVersion {
+		return nil, errors.NotSupported.Newf("[dmlexport] Columnar file version %d not supported", v)
+	}
+
+	hdrLen, err := binary.ReadUvarint(cr.r)
+	if err != nil {
+		return nil, errors.NotValid.New(err, "[dmlexport] Columnar file header length")
+	}
+	if hdrLen > columnarMaxHeader {
+		return nil, errors.NotValid.Newf("[dmlexport] Columnar file header length %d too large", hdrLen)
+	}
+	hdr := make([]byte, hdrLen)
+	if _, err := io.ReadFull(cr.r, hdr); err != nil {
+		return nil, errors.NotValid.New(err, "[dmlexport] Columnar file header")
+	}
+	if err := json.Unmarshal(hdr, &cr.columns); err != nil {
+		return nil, errors.NotValid.New(err, "[dmlexport] Columnar file header")
+	}
+	return cr, nil
+}
+
+// Columns returns the columns of the file. The slice must not be modified.
+func (cr *ColumnarReader) Columns() []Column { return cr.columns }
+
+// Next advances to the next row and returns false at the end of the file or
+// on error.
+func (cr *ColumnarReader) Next() bool {
+	if cr.err != nil || cr.done {
+		return false
+	}
+	cr.index++
+	if cr.index < len(cr.group) {
+		return true
+	}
+	if cr.err = cr.readGroup(); cr.err != nil {
+		return false
+	}
+	cr.index = 0
+	return !cr.done
+}
+
+// Row returns the values of the current row. The slice stays valid after the
+// next call to Next.
+func (cr *ColumnarReader) Row() []interface{} { return cr.group[cr.index] }
+
+// Err returns the first error which occurred while reading.
+func (cr *ColumnarReader) Err() error { return cr.err }
+
+func (cr *ColumnarReader) readGroup() error {
+	rowCount, err := binary.ReadUvarint(cr.r)
+	if err != nil {
+		return errors.NotValid.New(err, "[dmlexport] Columnar row group length")
+	}
+	if rowCount == 0 {
+		cr.done = true
+		cr.group = nil
+		return nil
+	}
+	if rowCount > math.MaxInt32 {
+		return errors.NotValid.Newf("[dmlexport] Columnar row group length %d too large", rowCount)
+	}
+
+	group := make([][]interface{}, rowCount)
+	values := make([]interface{}, int(rowCount)*len(cr.columns))
+	for ri := range group {
+		group[ri] = values[ri*len(cr.columns) : (ri+1)*len(cr.columns) : (ri+1)*len(cr.columns)]
+	}
+
+	bitmap := make([]byte, (rowCount+7)/8)
+	for ci := range cr.columns {
+		c := &cr.columns[ci]
+		if _, err := io.ReadFull(cr.r, bitmap); err != nil {
+			return errors.NotValid.New(err, "[dmlexport] Columnar null bitmap of column %q", c.Name)
+		}
+		for ri := range group {
+			if bitmap[ri/8]&(1<<uint(ri%8)) != 0 {
+				continue
+			}
+			v, err := cr.readValue(c)
+			if err != nil {
+				return errors.NotValid.New(err, "[dmlexport] Columnar value of column %q in row %d", c.Name, ri)
+			}
+			group[ri][ci] = v
+		}
+	}
+	cr.group = group
+	return nil
+}
+
+func (cr *ColumnarReader) readValue(c *Column) (interface{}, error) {
+	switch c.Kind {
+	case KindInt:
+		return binary.ReadVarint(cr.r)
+	case KindUint:
+		return binary.ReadUvarint(cr.r)
+	case KindFloat:
+		var p [8]byte
+		if _, err := io.ReadFull(cr.r, p[:]); err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(p[:])), nil
+	case KindBool:
+		b, err := cr.r.ReadByte()
+		return b != 0, err
+	case KindTime:
+		sec, err := binary.ReadVarint(cr.r)
+		if err != nil {
+			return nil, err
+		}
+		nsec, err := binary.ReadUvarint(cr.r)
+		if err != nil {
+			return nil, err
+		}
+		return time.Unix(sec, int64(nsec)).UTC(), nil
+	}
+
+	l, err := binary.ReadUvarint(cr.r)
+	if err != nil {
+		return nil, err
+	}
+	if l > math.MaxInt32 {
+		return nil, errors.NotValid.Newf("[dmlexport] Columnar value length %d too large", l)
+	}
+	p := make([]byte, l)
+	if _, err := io.ReadFull(cr.r, p); err != nil {
+		return nil, err
+	}
+	if c.Kind == KindBytes {
+		return p, nil
+	}
+	return string(p), nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlexport
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/corestoreio/errors"
+)
+
+// csvEncoder writes the values in the format which MySQL uses for its text
+// protocol, so LOAD DATA INFILE and dmltest.LoadCSV can read the file.
+type csvEncoder struct {
+	w       *csv.Writer
+	o       Options
+	columns []Column
+	record  []string
+}
+
+func newCSVEncoder(w io.Writer, o Options) *csvEncoder {
+	cw := csv.NewWriter(w)
+	cw.Comma = o.CSVComma
+	return &csvEncoder{w: cw, o: o}
+}
+
+func (e *csvEncoder) WriteHeader(columns []Column) error {
+	e.columns = columns
+	e.record = make([]string, len(columns))
+	if e.o.CSVNoHeader {
+		return nil
+	}
+	for i, c := range columns {
+		e.record[i] = c.Name
+	}
+	return errors.WithStack(e.w.Write(e.record))
+}
+
+func (e *csvEncoder) WriteRow(values []interface{}) error {
+	for i, v := range values {
+		switch val := v.(type) {
+		case nil:
+			e.record[i] = e.o.CSVNull
+		case int64:
+			e.record[i] = strconv.FormatInt(val, 10)
+		case uint64:
+			e.record[i] = strconv.FormatUint(val, 10)
+		case float64:
+			e.record[i] = strconv.FormatFloat(val, 'f', -1, 64)
+		case bool:
+			e.record[i] = "0"
+			if val {
+				e.record[i] = "1"
+			}
+		case time.Time:
+			if e.columns[i].DatabaseType == "DATE" {
+				e.record[i] = val.Format(mysqlDateLayout)
+			} else {
+				e.record[i] = val.Format(mysqlDateTimeLayout)
+			}
+		case string:
+			e.record[i] = val
+		case []byte:
+			e.record[i] = string(val)
+		default:
+			return errors.NotSupported.Newf("[dmlexport] CSV does not support type %T of column %q", v, e.columns[i].Name)
+		}
+	}
+	return errors.WithStack(e.w.Write(e.record))
+}
+
+func (e *csvEncoder) Flush() error {
+	e.w.Flush()
+	return errors.WithStack(e.w.Error())
+}
+
+func (e *csvEncoder) Close() error { return e.Flush() }
@@ -0,0 +1,30 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dmlexport streams the result set of a dml query into a file.
+//
+// Supported formats are CSV, JSON-lines and a compact binary columnar format.
+// The column type metadata gets read from the result set, so numbers stay
+// numbers in JSON and the columnar format stores each column with its own
+// encoding. The rows get read in a separate goroutine and a bounded buffer
+// between the database and the encoder limits the memory usage: a slow
+// writer, for example a download client, slows down the reading of the rows.
+//
+//	a := dbc.WithRawSQL("SELECT * FROM sales_order WHERE created_at > ?")
+//	rowCount, err := dmlexport.Export(ctx, file, a, dmlexport.CSV,
+//		dmlexport.Options{Gzip: true}, "2018-01-01")
+//
+// Type Handler wraps an export into an http.Handler for download endpoints.
+// A columnar file can be read again with a ColumnarReader.
+package dmlexport
@@ -0,0 +1,511 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlexport
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/util/gzippool"
+)
+
+const (
+	defaultBuffer           = 512
+	defaultColumnarRowGroup = 1024
+	// defaultCSVNull gets written into a CSV file for a NULL value.
+	// dmltest.LoadCSV converts it back to NULL.
+	defaultCSVNull = "null"
+)
+
+// Format defines the file format of an export.
+type Format uint8
+
+// Supported export formats.
+const (
+	CSV Format = iota + 1
+	JSONLines
+	Columnar
+)
+
+func (f Format) String() string {
+	switch f {
+	case CSV:
+		return "CSV"
+	case JSONLines:
+		return "JSONLines"
+	case Columnar:
+		return "Columnar"
+	}
+	return "Format(" + strconv.Itoa(int(f)) + ")"
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case JSONLines:
+		return "application/x-ndjson"
+	}
+	return "application/octet-stream"
+}
+
+// Extension returns the file name extension of the format including the dot.
+func (f Format) Extension() string {
+	switch f {
+	case CSV:
+		return ".csv"
+	case JSONLines:
+		return ".jsonl"
+	case Columnar:
+		return ".dmlc"
+	}
+	return ""
+}
+
+// Options applied to an export. The zero value is ready to use.
+type Options struct {
+	// Buffer defines the maximum number of rows which have been read from the
+	// database but not yet been encoded. If the buffer is full, reading from
+	// the database pauses. Defaults to 512.
+	Buffer int
+	// FlushRows flushes the encoder, the gzip writer and the destination, if
+	// it implements http.Flusher or has a `Flush() error` method, after each
+	// n-th row. Zero flushes only at the end.
+	FlushRows int
+	// Gzip compresses the output with a pooled gzip writer.
+	Gzip bool
+	// CSVComma defines the field delimiter. Defaults to a comma.
+	CSVComma rune
+	// CSVNull gets written for a NULL value. Defaults to `null` which
+	// dmltest.LoadCSV and dmlfixture understand.
+	CSVNull string
+	// CSVNoHeader suppresses the first line with the column names.
+	CSVNoHeader bool
+	// ColumnarRowGroup defines how many rows get encoded into one group of the
+	// columnar format. Defaults to 1024.
+	ColumnarRowGroup int
+}
+
+func (o *Options) setDefaults() {
+	if o.Buffer < 1 {
+		o.Buffer = defaultBuffer
+	}
+	if o.CSVComma == 0 {
+		o.CSVComma = ','
+	}
+	if o.CSVNull == "" {
+		o.CSVNull = defaultCSVNull
+	}
+	if o.ColumnarRowGroup < 1 {
+		o.ColumnarRowGroup = defaultColumnarRowGroup
+	}
+}
+
+// Encoder writes the rows of a result set in a specific file format.
+// WriteHeader gets called once before the first row. The values passed to
+// WriteRow have already been converted to the type of the Kind of their
+// column or are nil; WriteRow may retain the slice. Close writes outstanding
+// data but does not close the underlying writer.
+type Encoder interface {
+	WriteHeader(columns []Column) error
+	WriteRow(values []interface{}) error
+	Flush() error
+	Close() error
+}
+
+// NewEncoder creates a new encoder for the format which writes to w.
+func NewEncoder(f Format, w io.Writer, o Options) (Encoder, error) {
+	o.setDefaults()
+	switch f {
+	case CSV:
+		return newCSVEncoder(w, o), nil
+	case JSONLines:
+		return newJSONLinesEncoder(w), nil
+	case Columnar:
+		return newColumnarEncoder(w, o), nil
+	}
+	return nil, errors.NotSupported.Newf("[dmlexport] Format %s not supported", f)
+}
+
+// Export runs the query and streams the result set in format f into w. It
+// returns the number of written rows. If the context gets canceled or the
+// encoding fails, reading from the database stops.
+func Export(ctx context.Context, w io.Writer, a *dml.Artisan, f Format, o Options, args ...interface{}) (rowCount uint64, err error) {
+	o.setDefaults()
+
+	rows, err := a.QueryContext(ctx, args...)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	defer func() {
+		if err2 := rows.Close(); err2 != nil && err == nil {
+			err = errors.WithStack(err2)
+		}
+	}()
+
+	cts, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	columns := makeColumns(cts)
+
+	out := w
+	if o.Gzip {
+		zw := gzippool.GetWriter(w)
+		defer func() {
+			if err != nil {
+				// Do not write the gzip footer after a failed export and do
+				// not leave a sticky error which gzippool.PutWriter would
+				// panic on.
+				zw.Reset(ioutil.Discard)
+			}
+			if err2 := zw.Close(); err2 != nil {
+				zw.Reset(ioutil.Discard)
+				if err == nil {
+					err = errors.WithStack(err2)
+				}
+			}
+			gzippool.PutWriter(zw)
+		}()
+		out = zw
+	}
+
+	enc, err := NewEncoder(f, out, o)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if err = enc.WriteHeader(columns); err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	flush := func() error {
+		if err := enc.Flush(); err != nil {
+			return errors.WithStack(err)
+		}
+		if out != w {
+			if err := flushWriter(out); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		return errors.WithStack(flushWriter(w))
+	}
+
+	if rowCount, err = encodeRows(ctx, rows, columns, enc, o, flush); err != nil {
+		return rowCount, errors.WithStack(err)
+	}
+	if err = enc.Close(); err != nil {
+		return rowCount, errors.WithStack(err)
+	}
+	if o.Gzip {
+		return rowCount, nil // the deferred close of the gzip writer writes the rest
+	}
+	return rowCount, errors.WithStack(flushWriter(w))
+}
+
+// encodeRows reads the rows in a separate goroutine and sends them through a
+// buffered channel to the encoder. The channel capacity provides the back
+// pressure.
+func encodeRows(ctx context.Context, rows *sql.Rows, columns []Column, enc Encoder, o Options, flush func() error) (rowCount uint64, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rowChan := make(chan []interface{}, o.Buffer)
+	errChan := make(chan error, 1)
+	go func() {
+		defer close(rowChan)
+		errChan <- readRows(ctx, rows, columns, rowChan)
+	}()
+
+	for row := range rowChan {
+		if err = enc.WriteRow(row); err != nil {
+			break
+		}
+		rowCount++
+		if o.FlushRows > 0 && rowCount%uint64(o.FlushRows) == 0 {
+			if err = flush(); err != nil {
+				break
+			}
+		}
+	}
+	cancel() // stops the reading goroutine in case of an encoder error
+	if errR := <-errChan; err == nil {
+		// The channel got closed by the reading goroutine, so errR contains
+		// the real reason why the reading stopped.
+		err = errR
+	}
+	return rowCount, errors.WithStack(err)
+}
+
+func readRows(ctx context.Context, rows *sql.Rows, columns []Column, rowChan chan<- []interface{}) error {
+	scanArgs := make([]interface{}, len(columns))
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		for i := range values {
+			scanArgs[i] = &values[i]
+		}
+		if err := rows.Scan(scanArgs...); err != nil {
+			return errors.WithStack(err)
+		}
+		for i := range values {
+			v, err := convertValue(&columns[i], values[i])
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			values[i] = v
+		}
+		select {
+		case rowChan <- values:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return errors.WithStack(rows.Err())
+}
+
+func flushWriter(w io.Writer) error {
+	switch fw := w.(type) {
+	case interface{ Flush() error }:
+		return fw.Flush()
+	case http.Flusher:
+		fw.Flush()
+	}
+	return nil
+}
+
+// Kind defines how the values of a column get converted and encoded.
+type Kind uint8
+
+// Supported kinds. Unknown database types are of KindString.
+const (
+	KindString Kind = iota
+	KindInt
+	KindUint
+	KindFloat
+	KindDecimal
+	KindBool
+	KindTime
+	KindBytes
+)
+
+var kindNames = [...]string{"string", "int", "uint", "float", "decimal", "bool", "time", "bytes"}
+
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k Kind) MarshalText() ([]byte, error) {
+	if int(k) >= len(kindNames) {
+		return nil, errors.NotSupported.Newf("[dmlexport] Kind %d not supported", k)
+	}
+	return []byte(kindNames[k]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (k *Kind) UnmarshalText(text []byte) error {
+	for i, n := range kindNames {
+		if n == string(text) {
+			*k = Kind(i)
+			return nil
+		}
+	}
+	return errors.NotSupported.Newf("[dmlexport] Kind %q not supported", text)
+}
+
+// kindOf maps the database type name of a column, as returned by the MySQL
+// driver, to a Kind.
+func kindOf(databaseType string) Kind {
+	dt := strings.ToUpper(databaseType)
+	unsigned := strings.HasPrefix(dt, "UNSIGNED ")
+	dt = strings.TrimPrefix(dt, "UNSIGNED ")
+	switch dt {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT", "YEAR":
+		if unsigned {
+			return KindUint
+		}
+		return KindInt
+	case "FLOAT", "DOUBLE", "REAL":
+		return KindFloat
+	case "DECIMAL", "NUMERIC":
+		return KindDecimal
+	case "BOOL", "BOOLEAN":
+		return KindBool
+	case "DATE", "DATETIME", "TIMESTAMP":
+		return KindTime
+	case "BINARY", "VARBINARY", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB", "BIT", "GEOMETRY":
+		return KindBytes
+	}
+	return KindString
+}
+
+// Column describes a column of the result set.
+type Column struct {
+	Name string `json:"name"`
+	// DatabaseType as reported by the driver, e.g. VARCHAR or UNSIGNED INT.
+	DatabaseType string `json:"database_type,omitempty"`
+	Kind         Kind   `json:"kind"`
+	Nullable     bool   `json:"nullable,omitempty"`
+	// Length of variable length types like VARCHAR or BLOB.
+	Length    int64 `json:"length,omitempty"`
+	Precision int64 `json:"precision,omitempty"`
+	Scale     int64 `json:"scale,omitempty"`
+}
+
+func makeColumns(cts []*sql.ColumnType) []Column {
+	columns := make([]Column, len(cts))
+	for i, ct := range cts {
+		c := Column{
+			Name:         ct.Name(),
+			DatabaseType: ct.DatabaseTypeName(),
+		}
+		c.Kind = kindOf(c.DatabaseType)
+		if n, ok := ct.Nullable(); ok {
+			c.Nullable = n
+		} else {
+			c.Nullable = true
+		}
+		if l, ok := ct.Length(); ok {
+			c.Length = l
+		}
+		if p, s, ok := ct.DecimalSize(); ok {
+			c.Precision, c.Scale = p, s
+		}
+		columns[i] = c
+	}
+	return columns
+}
+
+const (
+	mysqlDateLayout     = "2006-01-02"
+	mysqlDateTimeLayout = "2006-01-02 15:04:05.999999999"
+)
+
+func parseTime(s string) (time.Time, error) {
+	if strings.HasPrefix(s, "0000-00-00") {
+		return time.Time{}, nil // MySQL zero date
+	}
+	layout := mysqlDateTimeLayout
+	if len(s) == len(mysqlDateLayout) {
+		layout = mysqlDateLayout
+	}
+	t, err := time.ParseInLocation(layout, s, time.UTC)
+	return t, errors.WithStack(err)
+}
+
+// convertValue converts a driver value into the Go type of the column kind:
+// int64, uint64, float64, bool, time.Time, []byte or string.
+func convertValue(c *Column, v interface{}) (_ interface{}, err error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch c.Kind {
+	case KindInt:
+		switch val := v.(type) {
+		case int64:
+			return val, nil
+		case int:
+			return int64(val), nil
+		case []byte:
+			return strconv.ParseInt(string(val), 10, 64)
+		case string:
+			return strconv.ParseInt(val, 10, 64)
+		}
+	case KindUint:
+		switch val := v.(type) {
+		case int64:
+			if val >= 0 {
+				return uint64(val), nil
+			}
+		case uint64:
+			return val, nil
+		case []byte:
+			return strconv.ParseUint(string(val), 10, 64)
+		case string:
+			return strconv.ParseUint(val, 10, 64)
+		}
+	case KindFloat:
+		switch val := v.(type) {
+		case float64:
+			return val, nil
+		case float32:
+			return float64(val), nil
+		case int64:
+			return float64(val), nil
+		case []byte:
+			return strconv.ParseFloat(string(val), 64)
+		case string:
+			return strconv.ParseFloat(val, 64)
+		}
+	case KindBool:
+		switch val := v.(type) {
+		case bool:
+			return val, nil
+		case int64:
+			return val != 0, nil
+		case []byte:
+			return strconv.ParseBool(string(val))
+		case string:
+			return strconv.ParseBool(val)
+		}
+	case KindTime:
+		switch val := v.(type) {
+		case time.Time:
+			return val, nil
+		case []byte:
+			return parseTime(string(val))
+		case string:
+			return parseTime(val)
+		}
+	case KindBytes:
+		switch val := v.(type) {
+		case []byte:
+			return val, nil
+		case string:
+			return []byte(val), nil
+		}
+	default: // KindString, KindDecimal
+		switch val := v.(type) {
+		case []byte:
+			return string(val), nil
+		case string:
+			return val, nil
+		case int64:
+			return strconv.FormatInt(val, 10), nil
+		case int:
+			return strconv.Itoa(val), nil
+		case uint64:
+			return strconv.FormatUint(val, 10), nil
+		case float64:
+			return strconv.FormatFloat(val, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(val), nil
+		case time.Time:
+			return val.Format(mysqlDateTimeLayout), nil
+		}
+	}
+	return nil, errors.NotSupported.Newf("[dmlexport] Column %q of kind %s does not support value %#v of type %T", c.Name, c.Kind, v, v)
+}
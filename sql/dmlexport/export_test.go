@@ -0,0 +1,262 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlexport_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmlexport"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func mockRows(dbMock sqlmock.Sqlmock) {
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT * FROM `customer`")).
+		WillReturnRows(sqlmock.NewRows([]string{"entity_id", "email"}).
+			AddRow(1, []byte(`a@b.c`)).
+			AddRow(2, nil).
+			AddRow(3, []byte(`"quoted", email`)))
+}
+
+func TestExport_CSV(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+	mockRows(dbMock)
+
+	var buf bytes.Buffer
+	rowCount, err := dmlexport.Export(context.TODO(), &buf, dbc.WithRawSQL("SELECT * FROM `customer`"), dmlexport.CSV, dmlexport.Options{Buffer: 1})
+	assert.NoError(t, err)
+	assert.Exactly(t, uint64(3), rowCount)
+	assert.Exactly(t, "entity_id,email\n1,a@b.c\n2,null\n3,\"\"\"quoted\"\", email\"\n", buf.String())
+}
+
+func TestExport_JSONLinesGzip(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+	mockRows(dbMock)
+
+	var buf bytes.Buffer
+	rowCount, err := dmlexport.Export(context.TODO(), &buf, dbc.WithRawSQL("SELECT * FROM `customer`"), dmlexport.JSONLines, dmlexport.Options{Gzip: true, FlushRows: 2})
+	assert.NoError(t, err)
+	assert.Exactly(t, uint64(3), rowCount)
+
+	zr, err := gzip.NewReader(&buf)
+	assert.NoError(t, err)
+	data, err := ioutil.ReadAll(zr)
+	assert.NoError(t, err)
+	assert.Exactly(t, `{"entity_id":"1","email":"a@b.c"}
+{"entity_id":"2","email":null}
+{"entity_id":"3","email":"\"quoted\", email"}
+`, string(data))
+}
+
+func TestExport_Columnar(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+	mockRows(dbMock)
+
+	var buf bytes.Buffer
+	rowCount, err := dmlexport.Export(context.TODO(), &buf, dbc.WithRawSQL("SELECT * FROM `customer`"), dmlexport.Columnar, dmlexport.Options{ColumnarRowGroup: 2})
+	assert.NoError(t, err)
+	assert.Exactly(t, uint64(3), rowCount)
+
+	cr, err := dmlexport.NewColumnarReader(&buf)
+	assert.NoError(t, err)
+	assert.Exactly(t, "entity_id", cr.Columns()[0].Name)
+	assert.Exactly(t, "email", cr.Columns()[1].Name)
+	var rows [][]interface{}
+	for cr.Next() {
+		rows = append(rows, cr.Row())
+	}
+	assert.NoError(t, cr.Err())
+	assert.Exactly(t, [][]interface{}{
+		{"1", "a@b.c"},
+		{"2", nil},
+		{"3", `"quoted", email`},
+	}, rows)
+}
+
+type failingWriter struct{ n int }
+
+func (fw *failingWriter) Write(p []byte) (int, error) {
+	fw.n++
+	if fw.n > 1 {
+		return 0, errors.WriteFailed.Newf("disk full")
+	}
+	return len(p), nil
+}
+
+func TestExport_WriteError(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	rows := sqlmock.NewRows([]string{"entity_id"})
+	for i := 0; i < 100; i++ {
+		rows.AddRow(i)
+	}
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `entity_id` FROM `customer`")).WillReturnRows(rows)
+
+	rowCount, err := dmlexport.Export(context.TODO(), &failingWriter{}, dbc.WithRawSQL("SELECT `entity_id` FROM `customer`"), dmlexport.CSV,
+		dmlexport.Options{Buffer: 1, FlushRows: 1})
+	assert.ErrorIsKind(t, errors.WriteFailed, err)
+	assert.True(t, rowCount < 100, "rowCount %d", rowCount)
+}
+
+func TestExport_QueryError(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT * FROM `customer`")).WillReturnError(errors.ConnectionFailed.Newf("ups"))
+
+	var buf bytes.Buffer
+	_, err := dmlexport.Export(context.TODO(), &buf, dbc.WithRawSQL("SELECT * FROM `customer`"), dmlexport.CSV, dmlexport.Options{Gzip: true})
+	assert.ErrorIsKind(t, errors.ConnectionFailed, err)
+	assert.Exactly(t, 0, buf.Len())
+}
+
+var (
+	allKindsColumns = []dmlexport.Column{
+		{Name: "i", Kind: dmlexport.KindInt},
+		{Name: "u", Kind: dmlexport.KindUint},
+		{Name: "f", Kind: dmlexport.KindFloat},
+		{Name: "d", Kind: dmlexport.KindDecimal},
+		{Name: "b", Kind: dmlexport.KindBool},
+		{Name: "t", Kind: dmlexport.KindTime},
+		{Name: "y", Kind: dmlexport.KindBytes},
+		{Name: "s", Kind: dmlexport.KindString},
+	}
+	allKindsRows = [][]interface{}{
+		{int64(-3), uint64(18446744073709551615), 2.5, "1.10", true, time.Date(2018, 1, 2, 3, 4, 5, 6, time.UTC), []byte("\x00\x01"), "Ö\n"},
+		{nil, nil, nil, nil, nil, nil, nil, nil},
+		{int64(7), uint64(0), -0.125, "-99.00", false, time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC), []byte{}, ""},
+	}
+)
+
+func TestEncoder_JSONLines(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	enc, err := dmlexport.NewEncoder(dmlexport.JSONLines, &buf, dmlexport.Options{})
+	assert.NoError(t, err)
+	assert.NoError(t, enc.WriteHeader(allKindsColumns))
+	for _, row := range allKindsRows {
+		assert.NoError(t, enc.WriteRow(row))
+	}
+	assert.NoError(t, enc.Close())
+	assert.Exactly(t, `{"i":-3,"u":18446744073709551615,"f":2.5,"d":"1.10","b":true,"t":"2018-01-02T03:04:05.000000006Z","y":"AAE=","s":"Ö\n"}
+{"i":null,"u":null,"f":null,"d":null,"b":null,"t":null,"y":null,"s":null}
+{"i":7,"u":0,"f":-0.125,"d":"-99.00","b":false,"t":"1000-01-01T00:00:00Z","y":"","s":""}
+`, buf.String())
+}
+
+func TestColumnarReader(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	enc, err := dmlexport.NewEncoder(dmlexport.Columnar, &buf, dmlexport.Options{ColumnarRowGroup: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, enc.WriteHeader(allKindsColumns))
+	for _, row := range allKindsRows {
+		assert.NoError(t, enc.WriteRow(row))
+	}
+	assert.NoError(t, enc.Close())
+
+	t.Run("round trip", func(t *testing.T) {
+		cr, err := dmlexport.NewColumnarReader(bytes.NewReader(buf.Bytes()))
+		assert.NoError(t, err)
+		assert.Exactly(t, allKindsColumns, cr.Columns())
+		var rows [][]interface{}
+		for cr.Next() {
+			rows = append(rows, cr.Row())
+		}
+		assert.NoError(t, cr.Err())
+		assert.Exactly(t, allKindsRows, rows)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		cr, err := dmlexport.NewColumnarReader(bytes.NewReader(buf.Bytes()[:buf.Len()-5]))
+		assert.NoError(t, err)
+		for cr.Next() {
+		}
+		assert.ErrorIsKind(t, errors.NotValid, cr.Err())
+	})
+	t.Run("invalid magic", func(t *testing.T) {
+		_, err := dmlexport.NewColumnarReader(bytes.NewReader([]byte("PK\x03\x04\x00")))
+		assert.ErrorIsKind(t, errors.NotValid, err)
+	})
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("gzip download", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+		mockRows(dbMock)
+
+		h := dmlexport.Handler{
+			Format:   dmlexport.CSV,
+			Options:  dmlexport.Options{Gzip: true},
+			Filename: "customers",
+			Query: func(r *http.Request) (*dml.Artisan, []interface{}, error) {
+				return dbc.WithRawSQL("SELECT * FROM `customer`"), nil, nil
+			},
+		}
+		req := httptest.NewRequest("GET", "/export", nil)
+		req.Header.Set("Accept-Encoding", "deflate, gzip")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Exactly(t, http.StatusOK, rec.Code)
+		assert.Exactly(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Exactly(t, "attachment; filename=customers.csv", rec.Header().Get("Content-Disposition"))
+		assert.Exactly(t, "gzip", rec.Header().Get("Content-Encoding"))
+		zr, err := gzip.NewReader(rec.Body)
+		assert.NoError(t, err)
+		data, err := ioutil.ReadAll(zr)
+		assert.NoError(t, err)
+		assert.Exactly(t, "entity_id,email\n1,a@b.c\n2,null\n3,\"\"\"quoted\"\", email\"\n", string(data))
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT * FROM `customer`")).WillReturnError(errors.ConnectionFailed.Newf("ups"))
+
+		h := dmlexport.Handler{
+			Format: dmlexport.JSONLines,
+			Query: func(r *http.Request) (*dml.Artisan, []interface{}, error) {
+				return dbc.WithRawSQL("SELECT * FROM `customer`"), nil, nil
+			},
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/export", nil))
+		assert.Exactly(t, http.StatusInternalServerError, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Disposition"))
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlexport
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/corestoreio/pkg/sql/dml"
+)
+
+// Handler streams the result set of a query as a file download. The response
+// gets gzip compressed if Options.Gzip has been enabled and the client accepts
+// it. An error before the first byte has been written calls the
+// ErrorHandler. An error afterwards aborts the response, so the client does
+// not receive a truncated file as complete download.
+type Handler struct {
+	Format  Format
+	Options Options
+	// Filename of the download without the extension. Defaults to "export".
+	Filename string
+	// Query returns the query and its arguments for the request. Required.
+	Query func(r *http.Request) (*dml.Artisan, []interface{}, error)
+	// ErrorHandler gets called if the query cannot be created or executed.
+	// Defaults to a 500 Internal Server Error.
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// ServeHTTP implements http.Handler.
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a, args, err := h.Query(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	o := h.Options
+	o.Gzip = o.Gzip && acceptsGzip(r)
+	fn := h.Filename
+	if fn == "" {
+		fn = "export"
+	}
+	dw := &downloadWriter{
+		ResponseWriter: w,
+		contentType:    h.Format.ContentType(),
+		disposition:    mime.FormatMediaType("attachment", map[string]string{"filename": fn + h.Format.Extension()}),
+		gzip:           o.Gzip,
+	}
+
+	if _, err = Export(r.Context(), dw, a, h.Format, o, args...); err != nil {
+		if !dw.wroteHeader {
+			h.handleError(w, r, err)
+			return
+		}
+		panic(http.ErrAbortHandler)
+	}
+	dw.writeHeader() // empty result set without header row
+}
+
+func (h Handler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	if h.ErrorHandler != nil {
+		h.ErrorHandler(w, r, err)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		if enc = strings.TrimSpace(enc); enc == "gzip" || strings.HasPrefix(enc, "gzip;") {
+			return !strings.HasSuffix(strings.Replace(enc, " ", "", -1), ";q=0")
+		}
+	}
+	return false
+}
+
+// downloadWriter writes the headers lazily with the first byte, so an error
+// of the query can still be reported with a proper status code.
+type downloadWriter struct {
+	http.ResponseWriter
+	contentType string
+	disposition string
+	gzip        bool
+	wroteHeader bool
+}
+
+func (dw *downloadWriter) writeHeader() {
+	if dw.wroteHeader {
+		return
+	}
+	dw.wroteHeader = true
+	hdr := dw.Header()
+	hdr.Set("Content-Type", dw.contentType)
+	hdr.Set("Content-Disposition", dw.disposition)
+	hdr.Add("Vary", "Accept-Encoding")
+	if dw.gzip {
+		hdr.Set("Content-Encoding", "gzip")
+	}
+	dw.WriteHeader(http.StatusOK)
+}
+
+func (dw *downloadWriter) Write(p []byte) (int, error) {
+	dw.writeHeader()
+	return dw.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher.
+func (dw *downloadWriter) Flush() {
+	if !dw.wroteHeader {
+		return
+	}
+	if f, ok := dw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlexport
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/corestoreio/errors"
+)
+
+// jsonLinesEncoder writes each row as a JSON object in its own line. Numbers
+// and booleans stay JSON numbers and booleans, decimals get written as strings
+// to not lose precision, times in RFC 3339 format and binary data base64
+// encoded like encoding/json does.
+type jsonLinesEncoder struct {
+	w       *bufio.Writer
+	columns []Column
+	// keys contains the quoted column names including the colon.
+	keys [][]byte
+	buf  []byte
+}
+
+func newJSONLinesEncoder(w io.Writer) *jsonLinesEncoder {
+	return &jsonLinesEncoder{w: bufio.NewWriter(w)}
+}
+
+func (e *jsonLinesEncoder) WriteHeader(columns []Column) error {
+	e.columns = columns
+	e.keys = make([][]byte, len(columns))
+	for i, c := range columns {
+		e.keys[i] = append(appendJSONString(nil, c.Name), ':')
+	}
+	return nil
+}
+
+func (e *jsonLinesEncoder) WriteRow(values []interface{}) error {
+	buf := append(e.buf[:0], '{')
+	for i, v := range values {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, e.keys[i]...)
+		switch val := v.(type) {
+		case nil:
+			buf = append(buf, "null"...)
+		case int64:
+			buf = strconv.AppendInt(buf, val, 10)
+		case uint64:
+			buf = strconv.AppendUint(buf, val, 10)
+		case float64:
+			if math.IsNaN(val) || math.IsInf(val, 0) {
+				buf = append(buf, "null"...)
+			} else {
+				buf = strconv.AppendFloat(buf, val, 'g', -1, 64)
+			}
+		case bool:
+			buf = strconv.AppendBool(buf, val)
+		case time.Time:
+			buf = append(buf, '"')
+			buf = val.AppendFormat(buf, time.RFC3339Nano)
+			buf = append(buf, '"')
+		case string:
+			buf = appendJSONString(buf, val)
+		case []byte:
+			buf = append(buf, '"')
+			n := len(buf)
+			buf = append(buf, make([]byte, base64.StdEncoding.EncodedLen(len(val)))...)
+			base64.StdEncoding.Encode(buf[n:], val)
+			buf = append(buf, '"')
+		default:
+			return errors.NotSupported.Newf("[dmlexport] JSONLines does not support type %T of column %q", v, e.columns[i].Name)
+		}
+	}
+	buf = append(buf, '}', '\n')
+	e.buf = buf
+	_, err := e.w.Write(buf)
+	return errors.WithStack(err)
+}
+
+func (e *jsonLinesEncoder) Flush() error { return errors.WithStack(e.w.Flush()) }
+
+func (e *jsonLinesEncoder) Close() error { return e.Flush() }
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s as quoted JSON string. Invalid UTF-8 gets
+// replaced with the Unicode replacement character.
+func appendJSONString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				buf = append(buf, '\\', b)
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, "\\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
@@ -0,0 +1,31 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dmlfixture generates test data for database tables.
+//
+// A Generator reads the columns, indexes and foreign keys of a ddl.Table and
+// fills rows with fake data from the util/pseudo package. Column types,
+// lengths, nullability, unique keys and foreign keys get respected. The same
+// seed always generates the same rows.
+//
+// A generated Fixture can be inserted with bulk INSERT statements or written
+// as CSV file, which dmltest.FakeDB.LoadCSV understands, or as SQL dump.
+//
+//	g, err := dmlfixture.NewGenerator(dmlfixture.Options{Seed: 4711})
+//	tables, err := ddl.ParseCreateTable(createStatements)
+//	fixtures, err := g.GenerateAll(100, tables...)
+//	for _, f := range fixtures {
+//		err = f.Insert(ctx, dbc)
+//	}
+package dmlfixture
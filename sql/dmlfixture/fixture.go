@@ -0,0 +1,843 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlfixture
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/util/conv"
+	"github.com/corestoreio/pkg/util/pseudo"
+)
+
+const (
+	defaultMaxUniqueAttempts = 100
+	defaultBatchSize         = 500
+	// defaultTextLength limits the length of generated values for the text and
+	// blob types which can store much more.
+	defaultTextLength = 1024
+	// csvNull gets written into a CSV file for a NULL value. dmltest.LoadCSV
+	// converts it back to NULL.
+	csvNull = "null"
+)
+
+// Options applied to a Generator.
+type Options struct {
+	// Seed initializes the random source. The same seed, the same tables and
+	// the same order of the Generate calls produce always the same rows. A zero
+	// Seed uses the current time.
+	Seed uint64
+	// PseudoOptions optional options for the pseudo.Service.
+	PseudoOptions *pseudo.Options
+	// NullPercent defines the probability in percent between 0 and 100 that a
+	// nullable column gets a NULL value. Zero never generates NULL values.
+	NullPercent int
+	// ColumnTags maps a column to a pseudo tag like `email`, `city` or
+	// `first_name`. The key can be "table.column" or only "column", the former
+	// has precedence. If a column is not listed, string columns use their name
+	// as tag, if the pseudo.Service knows it.
+	ColumnTags map[string]string
+	// ColumnFuncs maps a column to a custom function which generates the value.
+	// The key format equals the ColumnTags field. ColumnFuncs have precedence
+	// over ColumnTags. The maxLen argument contains the maximum length of a
+	// string column.
+	ColumnFuncs map[string]pseudo.FakeFunc
+	// MaxUniqueAttempts defines how often a row gets generated again if it
+	// violates a primary or unique key. Defaults to 100.
+	MaxUniqueAttempts int
+	// BatchSize defines the number of rows per INSERT statement. Defaults to
+	// 500.
+	BatchSize int
+}
+
+// Generator creates fixtures for tables. A Generator remembers the generated
+// fixtures to look up the values of foreign keys, hence referenced tables must
+// be generated first. Not safe for concurrent use.
+type Generator struct {
+	o    Options
+	fake *pseudo.Service
+	// fixtures contains the last generated fixture of a table.
+	fixtures map[string]*Fixture
+	// autoInc contains the last auto_increment value of a table.
+	autoInc map[string]int64
+	// uniques contains the unique keys of all generated rows of a table and
+	// index name.
+	uniques map[string]map[string]map[string]struct{}
+}
+
+// NewGenerator creates a new fixture generator.
+func NewGenerator(o Options) (*Generator, error) {
+	if o.NullPercent < 0 || o.NullPercent > 100 {
+		return nil, errors.NotValid.Newf("[dmlfixture] NullPercent must be between 0 and 100, have %d", o.NullPercent)
+	}
+	if o.MaxUniqueAttempts < 1 {
+		o.MaxUniqueAttempts = defaultMaxUniqueAttempts
+	}
+	if o.BatchSize < 1 {
+		o.BatchSize = defaultBatchSize
+	}
+	fake, err := pseudo.NewService(o.Seed, o.PseudoOptions)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &Generator{
+		o:        o,
+		fake:     fake,
+		fixtures: make(map[string]*Fixture),
+		autoInc:  make(map[string]int64),
+		uniques:  make(map[string]map[string]map[string]struct{}),
+	}, nil
+}
+
+// Fixture returns the last generated fixture of a table or nil.
+func (g *Generator) Fixture(tableName string) *Fixture {
+	return g.fixtures[tableName]
+}
+
+// GenerateAll generates rowCount rows for each table. The tables get sorted by
+// their foreign keys, so referenced tables get generated before the
+// referencing tables. The returned fixtures are in the same order and can be
+// inserted in that order.
+func (g *Generator) GenerateAll(rowCount int, tables ...*ddl.Table) ([]*Fixture, error) {
+	fs := make([]*Fixture, 0, len(tables))
+	for _, t := range sortByForeignKeys(tables) {
+		f, err := g.Generate(t, rowCount)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		fs = append(fs, f)
+	}
+	return fs, nil
+}
+
+// sortByForeignKeys sorts the tables topologically. Tables in a cycle keep
+// their original order.
+func sortByForeignKeys(tables []*ddl.Table) []*ddl.Table {
+	pending := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		pending[t.Name] = true
+	}
+	sorted := make([]*ddl.Table, 0, len(tables))
+	remaining := append([]*ddl.Table(nil), tables...)
+	for len(remaining) > 0 {
+		next := remaining[:0]
+		for _, t := range remaining {
+			if dependsOnPending(t, pending) {
+				next = append(next, t)
+				continue
+			}
+			sorted = append(sorted, t)
+			delete(pending, t.Name)
+		}
+		if len(next) == len(remaining) { // cycle
+			sorted = append(sorted, next[0])
+			delete(pending, next[0].Name)
+			next = next[1:]
+		}
+		remaining = next
+	}
+	return sorted
+}
+
+func dependsOnPending(t *ddl.Table, pending map[string]bool) bool {
+	for _, fk := range t.ForeignKeys {
+		if fk.ReferencedTable != t.Name && pending[fk.ReferencedTable] {
+			return true
+		}
+	}
+	return false
+}
+
+type valueKind uint8
+
+const (
+	kindUnsupported valueKind = iota
+	kindInt
+	kindDecimal
+	kindFloat
+	kindDate
+	kindDateTime
+	kindTime
+	kindYear
+	kindString
+	kindBinary
+	kindEnum
+	kindSet
+	kindJSON
+	kindSpatial
+)
+
+// column contains the precalculated generation rules of a column.
+type column struct {
+	*ddl.Column
+	kind          valueKind
+	maxLen        int
+	maxInt        int
+	precision     int
+	scale         int
+	values        []string // enum and set values
+	fn            pseudo.FakeFunc
+	autoIncrement bool
+	foreignKey    bool
+}
+
+// foreignKey contains the positions of the columns of a foreign key in the
+// current and in the referenced fixture.
+type foreignKey struct {
+	parent     *Fixture
+	table      string
+	pos        []int
+	parentPos  []int
+	isNullable bool
+}
+
+// uniqueKey contains the positions of the columns of a primary or unique key.
+type uniqueKey struct {
+	name string
+	pos  []int
+}
+
+// Generate generates rowCount rows for a table. Generated and system versioned
+// columns get skipped. Auto increment columns get sequential values, which
+// continue with each call to Generate for the same table. Foreign key columns
+// get the values of a random row of the referenced table, which must have been
+// generated before.
+func (g *Generator) Generate(t *ddl.Table, rowCount int) (*Fixture, error) {
+	cols, err := g.columns(t)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	f := &Fixture{
+		Table:     t,
+		Columns:   make([]string, len(cols)),
+		Rows:      make([][]interface{}, 0, rowCount),
+		batchSize: g.o.BatchSize,
+	}
+	for i, c := range cols {
+		f.Columns[i] = c.Field
+	}
+
+	fks, err := g.foreignKeys(t, f, cols)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	uks := uniqueKeys(t, f)
+	seen := g.uniques[t.Name]
+	if seen == nil {
+		seen = make(map[string]map[string]struct{}, len(uks))
+		g.uniques[t.Name] = seen
+	}
+	for _, uk := range uks {
+		if seen[uk.name] == nil {
+			seen[uk.name] = make(map[string]struct{}, rowCount)
+		}
+	}
+
+	for i := 0; i < rowCount; i++ {
+		row, err := g.row(t, cols, fks, uks, seen)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[dmlfixture] Generate table %q row %d", t.Name, i)
+		}
+		f.Rows = append(f.Rows, row)
+	}
+	g.fixtures[t.Name] = f
+	return f, nil
+}
+
+func (g *Generator) row(t *ddl.Table, cols []*column, fks []*foreignKey, uks []uniqueKey, seen map[string]map[string]struct{}) ([]interface{}, error) {
+	var autoIncVal int64
+	for attempt := 0; attempt < g.o.MaxUniqueAttempts; attempt++ {
+		row := make([]interface{}, len(cols))
+		for i, c := range cols {
+			switch {
+			case c.autoIncrement:
+				if autoIncVal == 0 {
+					g.autoInc[t.Name]++
+					autoIncVal = g.autoInc[t.Name]
+				}
+				row[i] = autoIncVal
+			case c.foreignKey:
+				// gets set below
+			default:
+				v, err := g.value(t, c, attempt)
+				if err != nil {
+					return nil, errors.Wrapf(err, "[dmlfixture] Column %q", c.Field)
+				}
+				row[i] = v
+			}
+		}
+		for _, fk := range fks {
+			if err := g.foreignValues(row, fk); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+
+		keys := make([]string, len(uks))
+		duplicate := false
+		for i, uk := range uks {
+			keys[i] = uniqueKeyValue(row, uk.pos)
+			if _, ok := seen[uk.name][keys[i]]; ok && keys[i] != "" {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+		for i, uk := range uks {
+			if keys[i] != "" {
+				seen[uk.name][keys[i]] = struct{}{}
+			}
+		}
+		return row, nil
+	}
+	return nil, errors.Duplicated.Newf("[dmlfixture] Cannot generate a unique row for table %q after %d attempts", t.Name, g.o.MaxUniqueAttempts)
+}
+
+// uniqueKeyValue returns the key of the values of a unique index or an empty
+// string if one of the values is NULL. MySQL allows multiple NULL values in a
+// unique index. Strings get compared case insensitive like the default
+// collations do.
+func uniqueKeyValue(row []interface{}, pos []int) string {
+	var buf strings.Builder
+	for _, p := range pos {
+		switch v := row[p].(type) {
+		case nil:
+			return ""
+		case string:
+			buf.WriteString(strings.ToLower(strings.TrimRight(v, " ")))
+		case []byte:
+			buf.Write(v)
+		default:
+			fmt.Fprint(&buf, v)
+		}
+		buf.WriteByte(0)
+	}
+	return buf.String()
+}
+
+func (g *Generator) foreignValues(row []interface{}, fk *foreignKey) error {
+	var parentRows [][]interface{}
+	if fk.parent != nil {
+		parentRows = fk.parent.Rows
+	}
+	isNull := fk.isNullable && g.o.NullPercent > 0 && g.fake.Intn(100) < g.o.NullPercent
+	if !isNull && len(parentRows) == 0 {
+		if !fk.isNullable {
+			return errors.NotFound.Newf("[dmlfixture] Referenced table %q has no generated rows. It must be generated first.", fk.table)
+		}
+		isNull = true
+	}
+	var parentRow []interface{}
+	if !isNull {
+		parentRow = parentRows[g.fake.Intn(len(parentRows))]
+	}
+	for i, p := range fk.pos {
+		if isNull {
+			row[p] = nil
+		} else {
+			row[p] = parentRow[fk.parentPos[i]]
+		}
+	}
+	return nil
+}
+
+func (g *Generator) foreignKeys(t *ddl.Table, f *Fixture, cols []*column) ([]*foreignKey, error) {
+	fks := make([]*foreignKey, 0, len(t.ForeignKeys))
+	for _, tfk := range t.ForeignKeys {
+		fk := &foreignKey{
+			parent:     g.fixtures[tfk.ReferencedTable],
+			table:      tfk.ReferencedTable,
+			isNullable: true,
+		}
+		if tfk.ReferencedTable == t.Name {
+			fk.parent = f
+		}
+		for i, cn := range tfk.Columns {
+			pos := f.columnPos(cn)
+			if pos < 0 {
+				return nil, errors.NotFound.Newf("[dmlfixture] Column %q of foreign key %q not found in table %q", cn, tfk.Name, t.Name)
+			}
+			cols[pos].foreignKey = true
+			fk.isNullable = fk.isNullable && cols[pos].IsNull()
+			fk.pos = append(fk.pos, pos)
+
+			if fk.parent != nil && i < len(tfk.ReferencedColumns) {
+				ppos := fk.parent.columnPos(tfk.ReferencedColumns[i])
+				if ppos < 0 {
+					return nil, errors.NotFound.Newf("[dmlfixture] Referenced column %q of foreign key %q not found in table %q", tfk.ReferencedColumns[i], tfk.Name, tfk.ReferencedTable)
+				}
+				fk.parentPos = append(fk.parentPos, ppos)
+			}
+		}
+		if fk.parent != nil && len(fk.parentPos) != len(fk.pos) {
+			return nil, errors.Mismatch.Newf("[dmlfixture] Foreign key %q in table %q has a different number of referenced columns", tfk.Name, t.Name)
+		}
+		fks = append(fks, fk)
+	}
+	return fks, nil
+}
+
+func uniqueKeys(t *ddl.Table, f *Fixture) []uniqueKey {
+	var uks []uniqueKey
+idxLoop:
+	for _, idx := range t.Indexes {
+		if !idx.Unique {
+			continue
+		}
+		uk := uniqueKey{name: idx.Name}
+		for _, cn := range idx.Columns {
+			if pos := strings.IndexByte(cn, '('); pos > 0 {
+				cn = cn[:pos] // cut off the prefix length
+			}
+			pos := f.columnPos(cn)
+			if pos < 0 {
+				continue idxLoop // contains a generated column
+			}
+			uk.pos = append(uk.pos, pos)
+		}
+		uks = append(uks, uk)
+	}
+	return uks
+}
+
+func (g *Generator) columns(t *ddl.Table) ([]*column, error) {
+	cols := make([]*column, 0, len(t.Columns))
+	for _, tc := range t.Columns {
+		if tc.IsGenerated() || tc.IsSystemVersioned() {
+			continue
+		}
+		c := &column{
+			Column:        tc,
+			autoIncrement: tc.IsAutoIncrement(),
+		}
+		c.setKind()
+
+		key := t.Name + "." + tc.Field
+		fn, ok := g.o.ColumnFuncs[key]
+		if !ok {
+			fn, ok = g.o.ColumnFuncs[tc.Field]
+		}
+		if !ok {
+			tag, hasTag := g.o.ColumnTags[key]
+			if !hasTag {
+				tag, hasTag = g.o.ColumnTags[tc.Field]
+			}
+			switch {
+			case hasTag:
+				if fn, ok = g.fake.FakeFuncByTag(tag); !ok {
+					return nil, errors.NotFound.Newf("[dmlfixture] Pseudo tag %q for column %q.%q not found", tag, t.Name, tc.Field)
+				}
+			case c.kind == kindString:
+				fn, _ = g.fake.FakeFuncByTag(tc.Field)
+			}
+		}
+		c.fn = fn
+		cols = append(cols, c)
+	}
+	return cols, nil
+}
+
+// setKind derives the generation rules from the data type.
+func (c *column) setKind() {
+	dt := strings.ToLower(c.DataType)
+	switch dt {
+	case "bit":
+		c.kind, c.maxInt = kindInt, 2
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint":
+		c.kind = kindInt
+		c.maxInt = intMax(dt, c.IsUnsigned())
+		if c.IsBool() {
+			c.maxInt = 2
+		}
+	case "decimal", "numeric":
+		c.kind = kindDecimal
+		c.precision, c.scale = 10, 0
+		if p, _ := typeArgs(c.ColumnType); len(p) > 0 {
+			c.precision = p[0]
+			if len(p) > 1 {
+				c.scale = p[1]
+			}
+		}
+		if c.Precision.Valid {
+			c.precision = int(c.Precision.Int64)
+		}
+		if c.Scale.Valid {
+			c.scale = int(c.Scale.Int64)
+		}
+	case "float", "double", "real":
+		c.kind = kindFloat
+	case "date":
+		c.kind = kindDate
+	case "datetime", "timestamp":
+		c.kind = kindDateTime
+	case "time":
+		c.kind = kindTime
+	case "year":
+		c.kind = kindYear
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext":
+		c.kind = kindString
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		c.kind = kindBinary
+	case "enum":
+		c.kind = kindEnum
+		_, c.values = typeArgs(c.ColumnType)
+	case "set":
+		c.kind = kindSet
+		_, c.values = typeArgs(c.ColumnType)
+	case "json":
+		c.kind = kindJSON
+	default:
+		if c.IsSpatialDataType() {
+			c.kind = kindSpatial
+		}
+	}
+
+	switch {
+	case c.CharMaxLength.Valid && c.CharMaxLength.Int64 > 0:
+		c.maxLen = int(c.CharMaxLength.Int64)
+	case dt == "tinytext" || dt == "tinyblob":
+		c.maxLen = 255
+	case c.kind == kindString || c.kind == kindBinary:
+		c.maxLen = defaultTextLength
+		if p, _ := typeArgs(c.ColumnType); len(p) > 0 {
+			c.maxLen = p[0]
+		}
+	}
+	if c.maxLen > defaultTextLength {
+		c.maxLen = defaultTextLength
+	}
+}
+
+// intMax returns the exclusive upper bound for generated integers. bigint
+// gets limited to the int range.
+func intMax(dataType string, unsigned bool) int {
+	var m int64
+	switch dataType {
+	case "tinyint":
+		m = math.MaxInt8
+	case "smallint":
+		m = math.MaxInt16
+	case "mediumint":
+		m = 1<<23 - 1
+	default:
+		m = math.MaxInt32
+	}
+	if unsigned {
+		m = m*2 + 1
+	}
+	return int(m)
+}
+
+// typeArgs parses the arguments of a column type like `varchar(255)`,
+// `decimal(12,4)` or `enum('a','b')`. It returns the integer arguments and the
+// unquoted string arguments.
+func typeArgs(columnType string) (ints []int, strs []string) {
+	start := strings.IndexByte(columnType, '(')
+	end := strings.LastIndexByte(columnType, ')')
+	if start < 0 || end < start {
+		return nil, nil
+	}
+	args := columnType[start+1 : end]
+	var cur strings.Builder
+	inQuote := false
+	for i := 0; i < len(args); i++ {
+		switch ch := args[i]; {
+		case ch == '\'' && inQuote && i+1 < len(args) && args[i+1] == '\'':
+			cur.WriteByte('\'')
+			i++
+		case ch == '\'':
+			inQuote = !inQuote
+		case ch == '\\' && inQuote && i+1 < len(args):
+			i++
+			cur.WriteByte(args[i])
+		case ch == ',' && !inQuote:
+			strs = append(strs, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(ch)
+		}
+	}
+	strs = append(strs, cur.String())
+	for _, s := range strs {
+		if i, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+			ints = append(ints, i)
+		}
+	}
+	return ints, strs
+}
+
+// value generates a new value for a column. A value is either nil, int64,
+// float64, string or []byte. The attempt argument greater zero indicates that
+// a previous value violated a unique key, then strings get a numeric suffix.
+func (g *Generator) value(t *ddl.Table, c *column, attempt int) (interface{}, error) {
+	if c.IsNull() && g.o.NullPercent > 0 && g.fake.Intn(100) < g.o.NullPercent {
+		return nil, nil
+	}
+	if c.fn != nil {
+		v, err := c.fn(c.maxLen)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if v != nil {
+			return g.convert(c, v, attempt)
+		}
+		if c.IsNull() {
+			return nil, nil
+		}
+	}
+
+	switch c.kind {
+	case kindInt:
+		return int64(g.fake.Intn(c.maxInt)), nil
+	case kindDecimal:
+		return g.decimal(c), nil
+	case kindFloat:
+		return math.Round(g.fake.Float64()*100000) / 100, nil
+	case kindDate, kindDateTime, kindTime:
+		return g.convert(c, g.fake.Time(), attempt)
+	case kindYear:
+		return int64(g.fake.Year(1900, 2155)), nil
+	case kindString:
+		if c.maxLen <= 255 {
+			return g.convert(c, g.fake.Words(c.maxLen), attempt)
+		}
+		return g.convert(c, g.fake.Paragraph(c.maxLen), attempt)
+	case kindBinary:
+		return g.convert(c, []byte(g.fake.Words(c.maxLen)), attempt)
+	case kindEnum, kindSet:
+		if len(c.values) == 0 {
+			return nil, errors.NotValid.Newf("[dmlfixture] Cannot find the values of column type %q", c.ColumnType)
+		}
+		return c.values[g.fake.Intn(len(c.values))], nil
+	case kindJSON:
+		data, err := json.Marshal(map[string]string{g.fake.Word(0): g.fake.Sentence(64)})
+		return string(data), errors.WithStack(err)
+	case kindSpatial:
+		if c.IsNull() {
+			return nil, nil
+		}
+	}
+	return nil, errors.NotSupported.Newf("[dmlfixture] Data type %q of column %q.%q not supported", c.DataType, t.Name, c.Field)
+}
+
+func (g *Generator) decimal(c *column) string {
+	intDigits := c.precision - c.scale
+	if intDigits > 9 {
+		intDigits = 9
+	}
+	v := strconv.Itoa(g.fake.Intn(pow10(intDigits)))
+	if c.scale > 0 {
+		fracDigits := c.scale
+		if fracDigits > 9 {
+			fracDigits = 9
+		}
+		v += "." + fmt.Sprintf("%0*d", c.scale, g.fake.Intn(pow10(fracDigits)))
+	}
+	return v
+}
+
+func pow10(n int) int {
+	p := 1
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
+
+// convert converts a value generated by a pseudo function into the type of the
+// column.
+func (g *Generator) convert(c *column, v interface{}, attempt int) (interface{}, error) {
+	switch c.kind {
+	case kindInt, kindYear:
+		i, err := conv.ToInt64E(v)
+		return i, errors.WithStack(err)
+	case kindFloat:
+		f, err := conv.ToFloat64E(v)
+		return f, errors.WithStack(err)
+	case kindDecimal:
+		f, err := conv.ToFloat64E(v)
+		return strconv.FormatFloat(f, 'f', c.scale, 64), errors.WithStack(err)
+	case kindDate, kindDateTime, kindTime:
+		if t, ok := v.(time.Time); ok {
+			switch c.kind {
+			case kindDate:
+				return t.Format("2006-01-02"), nil
+			case kindTime:
+				return t.Format("15:04:05"), nil
+			}
+			return t.Format("2006-01-02 15:04:05"), nil
+		}
+	case kindBinary:
+		if b, ok := v.([]byte); ok {
+			return []byte(g.truncate(c, string(b), attempt)), nil
+		}
+		s, err := conv.ToStringE(v)
+		return []byte(g.truncate(c, s, attempt)), errors.WithStack(err)
+	case kindString:
+		if b, ok := v.([]byte); ok {
+			return g.truncate(c, string(b), attempt), nil
+		}
+		s, err := conv.ToStringE(v)
+		return g.truncate(c, s, attempt), errors.WithStack(err)
+	}
+	s, err := conv.ToStringE(v)
+	return s, errors.WithStack(err)
+}
+
+// truncate cuts the string to the maximum length of the column. The length of
+// string columns gets measured in characters and of binary columns in bytes. If
+// attempt is greater zero, a random numeric suffix gets appended to generate a
+// new unique value. The suffix always fits into the maximum length.
+func (g *Generator) truncate(c *column, s string, attempt int) string {
+	var suffix string
+	if attempt > 0 {
+		suffix = g.fake.DigitsN(2 + attempt/10)
+	}
+	if c.maxLen <= 0 {
+		return s + suffix
+	}
+	if len(suffix) >= c.maxLen { // the digits are single byte characters
+		return suffix[len(suffix)-c.maxLen:]
+	}
+	maxLen := c.maxLen - len(suffix)
+	switch {
+	case c.kind == kindBinary && len(s) > maxLen:
+		s = s[:maxLen]
+	case c.kind != kindBinary && utf8.RuneCountInString(s) > maxLen:
+		s = strings.TrimRight(string([]rune(s)[:maxLen]), " ")
+	}
+	return s + suffix
+}
+
+// Fixture contains the generated rows of a table.
+type Fixture struct {
+	Table *ddl.Table
+	// Columns contains the names of the generated columns. Generated and
+	// system versioned columns are not included.
+	Columns []string
+	// Rows contains the values of each row in the order of Columns. A value
+	// is either nil, int64, float64, string or []byte.
+	Rows      [][]interface{}
+	batchSize int
+}
+
+func (f *Fixture) columnPos(name string) int {
+	for i, c := range f.Columns {
+		if c == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *Fixture) batches(fn func(rows [][]interface{}) error) error {
+	for start := 0; start < len(f.Rows); start += f.batchSize {
+		end := start + f.batchSize
+		if end > len(f.Rows) {
+			end = len(f.Rows)
+		}
+		if err := fn(f.Rows[start:end]); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// Insert inserts all rows with bulk INSERT statements into the database.
+func (f *Fixture) Insert(ctx context.Context, dbc *dml.ConnPool) error {
+	return f.batches(func(rows [][]interface{}) error {
+		args := make([]interface{}, 0, len(rows)*len(f.Columns))
+		for _, row := range rows {
+			args = append(args, row...)
+		}
+		_, err := dbc.InsertInto(f.Table.Name).AddColumns(f.Columns...).SetRowCount(len(rows)).
+			WithArgs().ExecContext(ctx, args...)
+		return errors.Wrapf(err, "[dmlfixture] Insert into table %q", f.Table.Name)
+	})
+}
+
+// WriteSQL writes the rows as INSERT statements into w. Each statement
+// contains at maximum Options.BatchSize rows.
+func (f *Fixture) WriteSQL(w io.Writer) error {
+	return f.batches(func(rows [][]interface{}) error {
+		rawSQL, _, err := dml.NewInsert(f.Table.Name).AddColumns(f.Columns...).SetRowCount(len(rows)).BuildValues().ToSQL()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		ip := dml.Interpolate(rawSQL)
+		for _, row := range rows {
+			for _, v := range row {
+				if v == nil {
+					ip.Null()
+				} else {
+					ip.Unsafe(v)
+				}
+			}
+		}
+		rawSQL, _, err = ip.ToSQL()
+		if err != nil {
+			return errors.Wrapf(err, "[dmlfixture] WriteSQL for table %q", f.Table.Name)
+		}
+		_, err = io.WriteString(w, rawSQL+";\n")
+		return errors.WithStack(err)
+	})
+}
+
+// WriteCSV writes the rows as CSV into w. The first line contains the column
+// names. NULL values get written as `null`, which dmltest.LoadCSV
+// understands.
+func (f *Fixture) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(f.Columns); err != nil {
+		return errors.WithStack(err)
+	}
+	rec := make([]string, len(f.Columns))
+	for _, row := range f.Rows {
+		for i, v := range row {
+			switch v := v.(type) {
+			case nil:
+				rec[i] = csvNull
+			case int64:
+				rec[i] = strconv.FormatInt(v, 10)
+			case float64:
+				rec[i] = strconv.FormatFloat(v, 'f', -1, 64)
+			case []byte:
+				rec[i] = string(v)
+			case string:
+				rec[i] = v
+			default:
+				rec[i] = fmt.Sprint(v)
+			}
+		}
+		if err := cw.Write(rec); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	cw.Flush()
+	return errors.WithStack(cw.Error())
+}
@@ -0,0 +1,249 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmlfixture_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dmlfixture"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/corestoreio/pkg/util/pseudo"
+)
+
+const createTables = "CREATE TABLE `customer_entity` (\n" +
+	"  `entity_id` int(10) unsigned NOT NULL AUTO_INCREMENT,\n" +
+	"  `email` varchar(255) DEFAULT NULL,\n" +
+	"  `firstname` varchar(8) NOT NULL,\n" +
+	"  `group_id` smallint(5) unsigned NOT NULL DEFAULT '0',\n" +
+	"  `is_active` smallint(5) unsigned NOT NULL DEFAULT '1',\n" +
+	"  `gender` enum('male','female','other') NOT NULL,\n" +
+	"  `balance` decimal(12,4) DEFAULT NULL,\n" +
+	"  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+	"  PRIMARY KEY (`entity_id`),\n" +
+	"  UNIQUE KEY `CUSTOMER_ENTITY_EMAIL` (`email`)\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8;\n" +
+	"CREATE TABLE `customer_address_entity` (\n" +
+	"  `entity_id` int(10) unsigned NOT NULL AUTO_INCREMENT,\n" +
+	"  `parent_id` int(10) unsigned NOT NULL,\n" +
+	"  `city` varchar(255) NOT NULL,\n" +
+	"  `code` char(2) NOT NULL,\n" +
+	"  PRIMARY KEY (`entity_id`),\n" +
+	"  UNIQUE KEY `CUSTOMER_ADDRESS_PARENT_CODE` (`parent_id`,`code`),\n" +
+	"  CONSTRAINT `CUSTOMER_ADDRESS_PARENT_ID` FOREIGN KEY (`parent_id`) REFERENCES `customer_entity` (`entity_id`) ON DELETE CASCADE\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8;"
+
+func parseTables(t *testing.T) []*ddl.Table {
+	tables, err := ddl.ParseCreateTable(createTables)
+	assert.NoError(t, err)
+	assert.Len(t, tables, 2)
+	return tables
+}
+
+func generateAll(t *testing.T, o dmlfixture.Options, rowCount int) []*dmlfixture.Fixture {
+	g, err := dmlfixture.NewGenerator(o)
+	assert.NoError(t, err)
+	tables := parseTables(t)
+	// reversed order to test the sorting by foreign keys
+	fs, err := g.GenerateAll(rowCount, tables[1], tables[0])
+	assert.NoError(t, err)
+	assert.Len(t, fs, 2)
+	return fs
+}
+
+func TestGenerator_GenerateAll(t *testing.T) {
+	o := dmlfixture.Options{Seed: 4711, NullPercent: 20}
+	fs := generateAll(t, o, 60)
+	customers, addresses := fs[0], fs[1]
+	assert.Exactly(t, "customer_entity", customers.Table.Name)
+	assert.Exactly(t, "customer_address_entity", addresses.Table.Name)
+	assert.Len(t, customers.Rows, 60)
+
+	t.Run("reproducible", func(t *testing.T) {
+		fs2 := generateAll(t, o, 60)
+		assert.Exactly(t, customers.Rows, fs2[0].Rows)
+		assert.Exactly(t, addresses.Rows, fs2[1].Rows)
+	})
+
+	t.Run("customer columns", func(t *testing.T) {
+		emails := map[string]bool{}
+		var nullEmails int
+		for i, row := range customers.Rows {
+			assert.Exactly(t, int64(i+1), row[0], "auto increment")
+			if row[1] == nil {
+				nullEmails++
+			} else {
+				email := strings.ToLower(row[1].(string))
+				assert.False(t, emails[email], "duplicate email %q", email)
+				emails[email] = true
+			}
+			assert.True(t, utf8.RuneCountInString(row[2].(string)) <= 8, "firstname %q too long", row[2])
+			assert.True(t, row[3].(int64) >= 0 && row[3].(int64) < 65535, "group_id %d", row[3])
+			assert.True(t, row[4].(int64) == 0 || row[4].(int64) == 1, "is_active must be a bool %d", row[4])
+			assert.Contains(t, []string{"male", "female", "other"}, row[5])
+			assert.Len(t, row[7].(string), len("2006-01-02 15:04:05"))
+		}
+		assert.True(t, nullEmails > 0, "NullPercent should generate NULL values")
+	})
+
+	t.Run("address foreign keys", func(t *testing.T) {
+		ids := map[int64]bool{}
+		for _, row := range customers.Rows {
+			ids[row[0].(int64)] = true
+		}
+		keys := map[string]bool{}
+		for _, row := range addresses.Rows {
+			assert.True(t, ids[row[1].(int64)], "parent_id %d not found", row[1])
+			assert.True(t, len(row[3].(string)) <= 2, "code %q too long", row[3])
+			key := fmt.Sprintf("%d|%s", row[1], strings.ToLower(row[3].(string)))
+			assert.False(t, keys[key], "duplicate unique key %q", key)
+			keys[key] = true
+		}
+	})
+}
+
+func TestGenerator_Generate_Errors(t *testing.T) {
+	tables := parseTables(t)
+
+	t.Run("missing referenced table", func(t *testing.T) {
+		g, err := dmlfixture.NewGenerator(dmlfixture.Options{Seed: 1})
+		assert.NoError(t, err)
+		_, err = g.Generate(tables[1], 1)
+		assert.ErrorIsKind(t, errors.NotFound, err)
+	})
+
+	t.Run("unique key exhausted", func(t *testing.T) {
+		tbl, err := ddl.ParseCreateTable("CREATE TABLE `flags` (`flag` tinyint(1) NOT NULL, `is_on` tinyint(1) NOT NULL, UNIQUE KEY `FLAG_IS_ON` (`is_on`))")
+		assert.NoError(t, err)
+		g, err := dmlfixture.NewGenerator(dmlfixture.Options{Seed: 1, MaxUniqueAttempts: 10})
+		assert.NoError(t, err)
+		_, err = g.Generate(tbl[0], 3)
+		assert.ErrorIsKind(t, errors.Duplicated, err)
+	})
+
+	t.Run("unknown tag", func(t *testing.T) {
+		g, err := dmlfixture.NewGenerator(dmlfixture.Options{
+			Seed:       1,
+			ColumnTags: map[string]string{"customer_entity.email": "not_existent"},
+		})
+		assert.NoError(t, err)
+		_, err = g.Generate(tables[0], 1)
+		assert.ErrorIsKind(t, errors.NotFound, err)
+	})
+}
+
+func TestGenerator_ColumnTagsAndFuncs(t *testing.T) {
+	g, err := dmlfixture.NewGenerator(dmlfixture.Options{
+		Seed:       2,
+		ColumnTags: map[string]string{"city": "country"},
+		ColumnFuncs: map[string]pseudo.FakeFunc{
+			"customer_address_entity.code": func(maxLen int) (interface{}, error) { return "DE", nil },
+		},
+	})
+	assert.NoError(t, err)
+	tables := parseTables(t)
+	fs, err := g.GenerateAll(3, tables...)
+	assert.NoError(t, err)
+	for i, row := range fs[1].Rows {
+		assert.True(t, row[2].(string) != "", "city/country must not be empty")
+		// All rows of a parent_id have the code DE, hence the unique key
+		// forces retries and suffixes which get truncated to two characters.
+		assert.Len(t, row[3].(string), 2, "Row %d", i)
+	}
+}
+
+func TestGenerator_TruncateMultiByte(t *testing.T) {
+	tbl, err := ddl.ParseCreateTable("CREATE TABLE `tags` (`name` varchar(4) NOT NULL, UNIQUE KEY `TAGS_NAME` (`name`))")
+	assert.NoError(t, err)
+	g, err := dmlfixture.NewGenerator(dmlfixture.Options{
+		Seed: 3,
+		ColumnFuncs: map[string]pseudo.FakeFunc{
+			"tags.name": func(maxLen int) (interface{}, error) { return "€€€€€", nil },
+		},
+	})
+	assert.NoError(t, err)
+	f, err := g.Generate(tbl[0], 20)
+	assert.NoError(t, err)
+
+	seen := map[string]bool{}
+	for i, row := range f.Rows {
+		v := row[0].(string)
+		assert.True(t, utf8.ValidString(v), "Row %d: %q", i, v)
+		assert.True(t, utf8.RuneCountInString(v) <= 4, "Row %d: %q", i, v)
+		// The first row needs no suffix, all others keep two characters
+		// and append a two digit suffix.
+		assert.True(t, strings.HasPrefix(v, "€€"), "Row %d: %q", i, v)
+		assert.False(t, seen[v], "Row %d: %q duplicated", i, v)
+		seen[v] = true
+	}
+	assert.Exactly(t, "€€€€", f.Rows[0][0])
+}
+
+func TestFixture_Insert_WriteCSV_WriteSQL(t *testing.T) {
+	fs := generateAll(t, dmlfixture.Options{Seed: 99, NullPercent: 10, BatchSize: 7}, 25)
+	dbc, fdb := dmltest.MustConnectFakeDB(t)
+	defer dmltest.Close(t, dbc)
+	assert.NoError(t, fdb.Exec(createTables))
+
+	countRows := func(table string) (cnt int64) {
+		assert.NoError(t, dbc.DB.QueryRow("SELECT COUNT(*) FROM "+table).Scan(&cnt))
+		return cnt
+	}
+
+	t.Run("Insert", func(t *testing.T) {
+		for _, f := range fs {
+			assert.NoError(t, f.Insert(context.TODO(), dbc))
+		}
+		assert.Exactly(t, int64(25), countRows("customer_entity"))
+		assert.Exactly(t, int64(25), countRows("customer_address_entity"))
+	})
+
+	t.Run("WriteSQL", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, fs[0].WriteSQL(&buf))
+		assert.Exactly(t, 4, strings.Count(buf.String(), "INSERT INTO `customer_entity`"))
+
+		assert.NoError(t, fdb.Exec("TRUNCATE TABLE `customer_entity`"))
+		for _, stmt := range strings.Split(strings.TrimSpace(buf.String()), ";\n") {
+			assert.NoError(t, fdb.Exec(stmt))
+		}
+		assert.Exactly(t, int64(25), countRows("customer_entity"))
+	})
+
+	t.Run("WriteCSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, fs[1].WriteCSV(&buf))
+		assert.True(t, strings.HasPrefix(buf.String(), "entity_id,parent_id,city,code\n"), "%q", buf.String())
+
+		tmp, err := ioutil.TempFile("", "dmlfixture")
+		assert.NoError(t, err)
+		defer os.Remove(tmp.Name())
+		_, err = tmp.Write(buf.Bytes())
+		assert.NoError(t, err)
+		assert.NoError(t, tmp.Close())
+
+		assert.NoError(t, fdb.LoadCSV("customer_address_csv", dmltest.WithFile(tmp.Name())))
+		assert.Exactly(t, int64(25), countRows("customer_address_csv"))
+	})
+}
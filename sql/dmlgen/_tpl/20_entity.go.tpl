@@ -1,3 +1,129 @@
+{{- range $ct := EnumSetTypes .Columns}}{{if eq .Kind "enum"}}
+// {{.GoType}} represents the allowed values of an ENUM column. An empty value
+// gets treated as NULL. Auto generated.
+type {{.GoType}} string
+
+// Allowed values of type {{.GoType}}. Auto generated.
+const (
+{{- range $i, $v := .Values}}
+	{{index $ct.Consts $i}} {{$ct.GoType}} = {{printf "%q" $v}}
+{{- end}}
+)
+
+var {{.ValuesVar}} = [...]{{.GoType}}{ {{- range .Consts}}{{.}}, {{end -}} }
+
+// Values returns all allowed values. Auto generated.
+func ({{.GoType}}) Values() []{{.GoType}} {
+	return append([]{{.GoType}}(nil), {{.ValuesVar}}[:]...)
+}
+
+// Validate returns an error with Kind NotValid if the value is not allowed.
+// Auto generated.
+func (e {{.GoType}}) Validate() error {
+	if e == "" {
+		return nil
+	}
+	for _, v := range {{.ValuesVar}} {
+		if v == e {
+			return nil
+		}
+	}
+	return errors.NotValid.Newf("[{{$.Package}}] {{.GoType}} value %q not allowed", string(e))
+}
+
+// String implements fmt.Stringer. Auto generated.
+func (e {{.GoType}}) String() string { return string(e) }
+
+// MarshalText implements encoding.TextMarshaler. An empty value returns nil,
+// which represents NULL. Auto generated.
+func (e {{.GoType}}) MarshalText() ([]byte, error) {
+	if err := e.Validate(); err != nil || e == "" {
+		return nil, err
+	}
+	return []byte(e), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Auto generated.
+func (e *{{.GoType}}) UnmarshalText(text []byte) error {
+	v := {{.GoType}}(text)
+	if err := v.Validate(); err != nil {
+		return errors.WithStack(err)
+	}
+	*e = v
+	return nil
+}
+{{else}}
+// {{.GoType}} represents the members of a SET column as a bitset. Auto generated.
+type {{.GoType}} uint64
+
+// Members of type {{.GoType}}. Auto generated.
+const (
+{{- range $i, $c := .Consts}}
+	{{$c}} {{if eq $i 0}}{{$ct.GoType}} = 1 << iota{{end}}
+{{- end}}
+)
+
+const {{.MaskConst}} {{.GoType}} = 1<<{{len .Values}} - 1
+
+var {{.ValuesVar}} = [...]string{ {{- range .Values}}{{printf "%q" .}}, {{end -}} }
+
+// Has returns true if all members of v are set. Auto generated.
+func (s {{.GoType}}) Has(v {{.GoType}}) bool { return s&v == v }
+
+// Validate returns an error with Kind NotValid if unknown bits are set. Auto
+// generated.
+func (s {{.GoType}}) Validate() error {
+	if s&^{{.MaskConst}} != 0 {
+		return errors.NotValid.Newf("[{{$.Package}}] {{.GoType}} value %d contains unknown members", uint64(s))
+	}
+	return nil
+}
+
+// String implements fmt.Stringer and returns the comma separated list of
+// members. Auto generated.
+func (s {{.GoType}}) String() string {
+	var buf strings.Builder
+	for i, v := range {{.ValuesVar}} {
+		if s&(1<<uint(i)) != 0 {
+			if buf.Len() > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(v)
+		}
+	}
+	return buf.String()
+}
+
+// MarshalText implements encoding.TextMarshaler. An empty set returns a non-nil
+// empty slice. Auto generated.
+func (s {{.GoType}}) MarshalText() ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return append(make([]byte, 0, 16), s.String()...), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Auto generated.
+func (s *{{.GoType}}) UnmarshalText(text []byte) error {
+	var v {{.GoType}}
+	if len(text) > 0 {
+		for _, member := range strings.Split(string(text), ",") {
+			found := false
+			for i, m := range {{.ValuesVar}} {
+				if m == member {
+					v |= 1 << uint(i)
+					found = true
+				}
+			}
+			if !found {
+				return errors.NotValid.Newf("[{{$.Package}}] {{.GoType}} member %q not allowed", member)
+			}
+		}
+	}
+	*s = v
+	return nil
+}
+{{end}}{{end}}
 // {{.Entity}} represents a single row for DB table `{{.TableName}}`.
 // Auto generated.{{with .Comment}}
 {{. -}}{{end}}{{- if .HasEasyJsonMarshaler }}
@@ -6,6 +132,9 @@ type {{.Entity}} struct {
 {{range .Columns}}{{GoCamelMaybePrivate .Field}} {{GoTypeNull .}}
 		{{- if ne .StructTag "" -}}`{{.StructTag}}`{{- end}} {{.GoComment}}
 {{end}} {{range .ReferencedCollections }} {{.}}
+{{end}} {{- range .Relations}}{{.Name}} *{{if .IsMany}}{{.TargetCollection}}{{else}}{{.TargetEntity}}{{end}} `faker:"-"` // {{.Kind}} relation via {{.Column}}
+{{end}} {{- if .HasChangeTracking}}
+	original *{{.Entity}} // data as loaded from the database
 {{end}} }
 
 // AssignLastInsertID updates the increment ID field with the last inserted ID
@@ -42,8 +171,62 @@ func (e *{{.Entity}}) MapColumns(cm *dml.ColumnMap) error {
 				return errors.NotFound.Newf("[{{.Package}}] {{.Entity}} Column %q not found", c)
 		}
 	}
+	{{- if .HasChangeTracking}}
+	if cm.Mode() == dml.ColumnMapScan && cm.Err() == nil {
+		e.ResetChanges()
+	}
+	{{- end}}
 	return errors.WithStack(cm.Err())
 }
+{{- if .HasChangeTracking}}
+
+// ResetChanges marks the current data as loaded from the database, for
+// example after a successful INSERT or UPDATE. Afterwards IsDirty returns
+// false. Loading data via MapColumns calls ResetChanges automatically. The name
+// differs from Reset because protocol buffer messages implement Reset. Auto
+// generated.
+func (e *{{.Entity}}) ResetChanges() *{{.Entity}} {
+	o := *e
+	o.original = nil
+	e.original = &o
+	return e
+}
+
+// Original returns a copy of the data as loaded from the database or nil if
+// the entity has not been loaded. Auto generated.
+func (e *{{.Entity}}) Original() *{{.Entity}} {
+	if e.original == nil {
+		return nil
+	}
+	o := *e.original
+	return &o
+}
+
+// IsDirty returns true if the entity has not been loaded from the database or
+// at least one field has been changed. Auto generated.
+func (e *{{.Entity}}) IsDirty() bool {
+	return len(e.ChangedFields()) > 0
+}
+
+// ChangedFields returns the column names of the changed fields. If the entity
+// has not been loaded from the database, all column names get returned. The
+// result can be used with ddl.Table.UpdateByPK or dml.Update.AddColumns in
+// conjunction with Artisan.Record. Skip the update if the result is empty,
+// because UpdateByPK without columns writes all columns. Auto generated.
+func (e *{{.Entity}}) ChangedFields() []string {
+	if e.original == nil {
+		return []string{ {{- range $i, $c := .Columns}}{{if $i}}, {{end}}"{{.Field}}"{{end -}} }
+	}
+	o := e.original
+	var cols []string
+	{{- range .Columns}}{{$f := GoCamelMaybePrivate .Field}}
+	if {{GoNotEqual . (print "e." $f) (print "o." $f)}} {
+		cols = append(cols, "{{.Field}}")
+	}
+	{{- end}}
+	return cols
+}
+{{- end}}
 
 // Empty empties all the fields of the current object. Also known as Reset.
 func (e *{{.Entity}}) Empty() *{{.Entity}} { *e = {{.Entity}}{}; return e }
@@ -108,7 +291,7 @@ func (cc *{{.Collection}}) MapColumns(cm *dml.ColumnMap) error {
 	case dml.ColumnMapCollectionReadSet:
 		for cm.Next() {
 			switch c := cm.Column(); c {
-			{{- range .Columns.UniqueColumns -}}
+			{{- range .Columns.UniqueColumns}}
 			case "{{.Field}}"{{range .Aliases}},"{{.}}"{{end}}:
 				cm = cm.{{GoFuncNull .}}s(cc.{{GoCamel .Field}}s()...)
 			{{- end}}
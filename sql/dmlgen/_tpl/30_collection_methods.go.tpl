@@ -23,9 +23,10 @@ func (cc *{{.Collection}}) Each(f func(*{{.Entity}})) *{{.Collection}} {
 {{ range .Columns.UniqueColumns -}}
 func (cc *{{$.Collection}}) SortBy{{GoCamel .Field}}() {
 	sort.Slice(cc.Data,func(i, j int) bool {
-		return cc.Data[i].{{GoCamel .Field}} < cc.Data[j].{{GoCamel .Field}}
+		return cc.Data[i].{{GoPrimitiveNull .}} < cc.Data[j].{{GoPrimitiveNull .}}
 	})
-} {{- end}}
+}
+{{ end}}
 
 // Cut will remove items i through j-1.
 // Auto generated via dmlgen.
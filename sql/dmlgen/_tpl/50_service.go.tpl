@@ -0,0 +1,237 @@
+{{- $pks := .Columns.PrimaryKeys -}}
+// condition checks the filter against the columns of DB table
+// `{{.TableName}}` and converts it into a WHERE condition. Supported operators
+// are: = (default), !=, <, <=, >, >=, like, not like, in, not in, null and not
+// null. Auto generated.
+func (f *{{.Entity}}ListFilter) condition(tbl *ddl.Table) (*dml.Condition, error) {
+	if !tbl.Columns.Contains(f.Column) {
+		return nil, errors.NotAllowed.Newf("[{{.Package}}] {{.Entity}}ListFilter Column %q not allowed", f.Column)
+	}
+	c := dml.Column(f.Column)
+	switch op := strings.ToLower(f.Operator); op {
+	case "null":
+		return c.Null(), nil
+	case "not null":
+		return c.NotNull(), nil
+	case "in", "not in":
+		if len(f.Values) == 0 {
+			return nil, errors.NotValid.Newf("[{{.Package}}] {{.Entity}}ListFilter Column %q requires at least one value", f.Column)
+		}
+		if op == "in" {
+			return c.In().Strs(f.Values...), nil
+		}
+		return c.NotIn().Strs(f.Values...), nil
+	case "", "=":
+		c = c.Equal()
+	case "!=":
+		c = c.NotEqual()
+	case "<":
+		c = c.Less()
+	case "<=":
+		c = c.LessOrEqual()
+	case ">":
+		c = c.Greater()
+	case ">=":
+		c = c.GreaterOrEqual()
+	case "like":
+		c = c.Like()
+	case "not like":
+		c = c.NotLike()
+	default:
+		return nil, errors.NotSupported.Newf("[{{.Package}}] {{.Entity}}ListFilter Operator %q not supported", f.Operator)
+	}
+	if len(f.Values) != 1 {
+		return nil, errors.NotValid.Newf("[{{.Package}}] {{.Entity}}ListFilter Column %q requires exactly one value", f.Column)
+	}
+	return c.Str(f.Values[0]), nil
+}
+
+// {{.Entity}}Server implements the CRUD service for DB table `{{.TableName}}`
+// as defined in the protocol buffer service {{.Entity}}Service. The request
+// and response types get generated from the protocol buffer messages. It can
+// be registered with a gRPC server or wrapped by a Twirp server. The name
+// differs from the service because the Twirp plugin generates the
+// {{.Entity}}Service interface. Auto generated.
+type {{.Entity}}Server struct {
+	Tables *ddl.Tables
+	// MaxPageSize limits the rows returned by List. Defaults to 100.
+	MaxPageSize uint32
+}
+
+func (s *{{.Entity}}Server) primaryKey(e *{{.Entity}}) *{{.Entity}}PrimaryKey {
+	return &{{.Entity}}PrimaryKey{ {{- range $pks}}
+		{{GoCamel .Field}}: e.{{GoCamelMaybePrivate .Field}},
+	{{- end}}
+	}
+}
+
+func (s *{{.Entity}}Server) entity(pk *{{.Entity}}PrimaryKey) *{{.Entity}} {
+	return &{{.Entity}}{ {{- range $pks}}
+		{{GoCamelMaybePrivate .Field}}: pk.{{GoCamel .Field}},
+	{{- end}}
+	}
+}
+
+// Get loads a single row by its primary key. Returns a NotFound error if the
+// row does not exist. Auto generated.
+func (s *{{.Entity}}Server) Get(ctx context.Context, pk *{{.Entity}}PrimaryKey) (*{{.Entity}}, error) {
+	tbl, err := s.Tables.Table(TableName{{.Entity}})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	e := s.entity(pk)
+	rowCount, err := tbl.SelectByPK().WithArgs().Record("", e).Load(ctx, e)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if rowCount == 0 {
+		return nil, errors.NotFound.Newf("[{{.Package}}] {{.Entity}}Server.Get: Row %#v not found", pk)
+	}
+	return e, nil
+}
+
+// List loads a page of rows ordered by the primary key and restricted by the
+// filters. Pagination uses the primary key of the last row (keyset
+// pagination), hence the page token stays valid while rows get inserted or
+// deleted. Auto generated.
+func (s *{{.Entity}}Server) List(ctx context.Context, req *{{.Entity}}ListRequest) (*{{.Entity}}ListResponse, error) {
+	tbl, err := s.Tables.Table(TableName{{.Entity}})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	maxPageSize := s.MaxPageSize
+	if maxPageSize == 0 {
+		maxPageSize = 100
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	sel := tbl.Select("*").OrderBy({{range $i, $c := $pks}}{{if $i}}, {{end}}"{{.Field}}"{{end}}).Limit(0, uint64(pageSize)+1)
+	for _, f := range req.Filters {
+		c, err := f.condition(tbl)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		sel.Where(c)
+	}
+
+	var last *{{.Entity}}
+	if req.PageToken != "" {
+		data, err := base64.RawURLEncoding.DecodeString(req.PageToken)
+		if err != nil {
+			return nil, errors.BadEncoding.New(err, "[{{.Package}}] {{.Entity}}Server.List: Invalid page token %q", req.PageToken)
+		}
+		var pk {{.Entity}}PrimaryKey
+		if err := json.Unmarshal(data, &pk); err != nil {
+			return nil, errors.BadEncoding.New(err, "[{{.Package}}] {{.Entity}}Server.List: Invalid page token %q", req.PageToken)
+		}
+		sel.Where(
+			dml.ParenthesisOpen(),
+			{{- range $i, $c := $pks}}
+			dml.ParenthesisOpen(){{if $i}}.Or(){{end}},
+			{{- range $j, $p := $pks}}{{if lt $j $i}}
+			dml.Column("{{$p.Field}}").Equal().PlaceHolder(),
+			{{- end}}{{end}}
+			dml.Column("{{$c.Field}}").Greater().PlaceHolder(),
+			dml.ParenthesisClose(),
+			{{- end}}
+			dml.ParenthesisClose(),
+		)
+		last = s.entity(&pk)
+	}
+
+	a := sel.WithArgs()
+	if last != nil {
+		a = a.Record("", last)
+	}
+	cc := New{{.Collection}}()
+	if _, err := a.Load(ctx, cc); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	res := &{{.Entity}}ListResponse{Data: cc.Data}
+	if len(cc.Data) > int(pageSize) {
+		res.Data = cc.Data[:pageSize]
+		data, err := json.Marshal(s.primaryKey(res.Data[pageSize-1]))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		res.NextPageToken = base64.RawURLEncoding.EncodeToString(data)
+	}
+	return res, nil
+}
+
+// Create inserts a new row and returns it as stored in the database. An auto
+// increment primary key gets assigned to `e`. Auto generated.
+func (s *{{.Entity}}Server) Create(ctx context.Context, e *{{.Entity}}) (*{{.Entity}}, error) {
+	tbl, err := s.Tables.Table(TableName{{.Entity}})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if _, err := tbl.Insert().WithArgs().Record("", e).ExecContext(ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return s.Get(ctx, s.primaryKey(e))
+}
+
+{{if .HasChangeTracking -}}
+// Update compares `e` with the stored row, writes only the changed non primary
+// key columns and returns the row as stored in the database. If nothing has
+// changed, no UPDATE statement gets executed. Auto generated.
+func (s *{{.Entity}}Server) Update(ctx context.Context, e *{{.Entity}}) (*{{.Entity}}, error) {
+	tbl, err := s.Tables.Table(TableName{{.Entity}})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	cur, err := s.Get(ctx, s.primaryKey(e))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	rec := *e // ExecContext would assign the last insert ID, which is zero, to e.
+	rec.original = cur.original
+	cols := rec.ChangedFields()
+	if len(cols) == 0 {
+		return cur, nil // UpdateByPK without columns would write all columns
+	}
+	upd := tbl.UpdateByPK(cols...)
+	if len(upd.SetClauses) == 0 {
+		return cur, nil // only primary key or generated columns have changed
+	}
+	if _, err := upd.WithArgs().Record("", &rec).ExecContext(ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return s.Get(ctx, s.primaryKey(e))
+}
+{{else -}}
+// Update writes all non primary key columns of `e` and returns the row as
+// stored in the database. Auto generated.
+func (s *{{.Entity}}Server) Update(ctx context.Context, e *{{.Entity}}) (*{{.Entity}}, error) {
+	tbl, err := s.Tables.Table(TableName{{.Entity}})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	rec := *e // ExecContext would assign the last insert ID, which is zero, to e.
+	if _, err := tbl.UpdateByPK().WithArgs().Record("", &rec).ExecContext(ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return s.Get(ctx, s.primaryKey(e))
+}
+{{- end}}
+
+// Delete removes a single row by its primary key. Auto generated.
+func (s *{{.Entity}}Server) Delete(ctx context.Context, pk *{{.Entity}}PrimaryKey) (*{{.Entity}}DeleteResponse, error) {
+	tbl, err := s.Tables.Table(TableName{{.Entity}})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	res, err := tbl.DeleteByPK().WithArgs().Record("", s.entity(pk)).ExecContext(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &{{.Entity}}DeleteResponse{RowsAffected: uint64(rowsAffected)}, nil
+}
@@ -0,0 +1,76 @@
+{{- range .Relations}}
+// Load{{.Name}} loads the {{.Kind}} relation {{.Name}} from DB table
+// `{{.TargetTable}}` via column `{{.Column}}`. Auto generated.
+func (e *{{$.Entity}}) Load{{.Name}}(ctx context.Context, tbls *ddl.Tables) error {
+	return (&{{$.Collection}}{Data: []*{{$.Entity}}{e}}).Load{{.Name}}(ctx, tbls)
+}
+
+// Load{{.Name}} loads the {{.Kind}} relation {{.Name}} of all entities with
+// the query `SELECT * FROM {{.TargetTable}} WHERE {{.TargetColumn}} IN (...)`.
+// The query runs once per 1000 distinct keys to limit the number of place
+// holders. Entities whose column `{{.Column}}` is NULL get skipped. Auto
+// generated.
+func (cc *{{$.Collection}}) Load{{.Name}}(ctx context.Context, tbls *ddl.Tables) error {
+	idx := make(map[{{.KeyType}}][]*{{$.Entity}}, len(cc.Data))
+	args := make([]{{.KeyType}}, 0, len(cc.Data))
+	for _, e := range cc.Data {
+		{{- if .IsMany}}
+		e.{{.Name}} = New{{.TargetCollection}}()
+		{{- else}}
+		e.{{.Name}} = nil
+		{{- end}}
+		{{- if .KeyValid}}
+		if !{{.KeyValid}} {
+			continue
+		}
+		{{- end}}
+		k := {{.Key}}
+		if _, ok := idx[k]; !ok {
+			args = append(args, k)
+		}
+		idx[k] = append(idx[k], e)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	tbl, err := tbls.Table(TableName{{.TargetEntity}})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	const maxArgs = 1000
+	sel := tbl.Select("*").Where(
+		dml.Column("{{.TargetColumn}}").In().PlaceHolder(),
+	).WithArgs().ExpandPlaceHolders()
+	var children []*{{.TargetEntity}}
+	for len(args) > 0 {
+		n := len(args)
+		if n > maxArgs {
+			n = maxArgs
+		}
+		// A collection drops its data when loading again, hence a new one
+		// per chunk.
+		chunk := New{{.TargetCollection}}()
+		if _, err := sel.Reset().{{.ArgsFunc}}(args[:n]...).Load(ctx, chunk); err != nil {
+			return errors.WithStack(err)
+		}
+		children = append(children, chunk.Data...)
+		args = args[n:]
+	}
+	for _, c := range children {
+		{{- if .TargetKeyValid}}
+		if !{{.TargetKeyValid}} {
+			continue
+		}
+		{{- end}}
+		for _, e := range idx[{{.TargetKey}}] {
+			{{- if .IsMany}}
+			e.{{.Name}}.Data = append(e.{{.Name}}.Data, c)
+			{{- else}}
+			e.{{.Name}} = c
+			{{- end}}
+		}
+	}
+	return nil
+}
+{{end}}
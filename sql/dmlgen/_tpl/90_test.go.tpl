@@ -4,7 +4,7 @@ func TestNewTables(t *testing.T) {
 
 	{{with .TestSQLDumpGlobPath}}defer dmltest.SQLDumpLoad(t, "{{.}}", &dmltest.SQLDumpOptions{
 		SkipDBCleanup: true,
-	})(){{end}}
+	}).Deferred(){{end}}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*2)
 	defer cancel()
@@ -46,6 +46,20 @@ func TestNewTables(t *testing.T) {
 				t.Errorf("IDX[%d]: %+v", i, err)
 				return
 			}
+			{{- range $col := $table.Columns }}
+				{{- if CustomType $col }}{{ with CustomType $col }}{{ if eq .Kind "enum" }}
+			entityIn.{{$table.GoCamelMaybePrivate $col.Field}} = {{.ValuesVar}}[ps.Intn(len({{.ValuesVar}}))]
+				{{- else if eq .Kind "set" }}
+			entityIn.{{$table.GoCamelMaybePrivate $col.Field}} = {{.GoType}}(ps.Intn({{.TestIntnMax}}))
+				{{- end }}{{ end }}
+				{{- else if eq (GoTypeNull $col) "json.RawMessage" }}
+			entityIn.{{$table.GoCamelMaybePrivate $col.Field}} = json.RawMessage(`{"id": 1}`)
+				{{- else if or (eq $col.DataType "point") (eq $col.DataType "geometry") }}
+			entityIn.{{$table.GoCamelMaybePrivate $col.Field}} = null.MakePoint(0, float64(ps.Intn(180)), float64(ps.Intn(90)))
+				{{- else if $col.IsSpatialDataType }}
+			entityIn.{{$table.GoCamelMaybePrivate $col.Field}} = null.Geometry{}
+				{{- end }}
+			{{- end }}
 
 			lID := dmltest.CheckLastInsertID(t, "Error: TestNewTables.{{GoCamel .TableName}}_Entity")(insArtisan.Record("", entityIn).ExecContext(ctx))
 			insArtisan.Reset()
@@ -62,6 +76,23 @@ func TestNewTables(t *testing.T) {
 					assert.Exactly(t, entityIn.{{$table.GoCamelMaybePrivate $col.Field}}, entityOut.{{$table.GoCamelMaybePrivate $col.Field}}, "IDX%d: {{$table.GoCamelMaybePrivate $col.Field}} should match", lID)
 				{{- end}}
 			{{- end}}
+			{{- if $table.HasChangeTracking }}
+
+			assert.True(t, entityIn.IsDirty(), "IDX%d: Entity not loaded from the database should be dirty", lID)
+			assert.False(t, entityOut.IsDirty(), "IDX%d: Entity loaded from the database should not be dirty", lID)
+			assert.Len(t, entityOut.ChangedFields(), 0, "IDX%d: Entity loaded from the database should not have changed fields", lID)
+			entityOrg := entityOut.Original()
+			assert.NotNil(t, entityOrg, "IDX%d: Original data should be available", lID)
+			assert.Nil(t, entityOrg.Original(), "IDX%d: Copy of the original data should not have an original", lID)
+			assert.Exactly(t, []string{ {{- range $i, $c := $table.Columns}}{{if $i}}, {{end}}"{{.Field}}"{{end -}} }, entityOrg.ChangedFields(), "IDX%d: Copy of the original data should report all fields", lID)
+			assert.False(t, entityOrg.ResetChanges().IsDirty(), "IDX%d: Entity should not be dirty after ResetChanges", lID)
+			{{- end}}
+			{{- range $table.Relations }}
+			assert.NoError(t, entityOut.Load{{.Name}}(ctx, tbls), "IDX%d: Load{{.Name}}", lID)
+			{{- if .IsMany }}
+			assert.NotNil(t, entityOut.{{.Name}}, "IDX%d: {{.Name}} should be initialized", lID)
+			{{- end}}
+			{{- end}}
 		}
 	})
 	{{- end}}
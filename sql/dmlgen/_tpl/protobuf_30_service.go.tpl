@@ -0,0 +1,48 @@
+
+// {{.Entity}}PrimaryKey identifies a single row of DB table `{{.TableName}}`. Auto generated.
+message {{.Entity}}PrimaryKey {
+	option (gogoproto.typedecl) = true;
+	{{- range .Columns.PrimaryKeys}}
+	{{SerializerType .}} {{.Field}} = {{.Pos}} [(gogoproto.customname)="{{GoCamel .Field}}" {{- SerializerCustomType .}}];
+	{{- end}}
+}
+
+// {{.Entity}}ListFilter restricts the rows returned by {{.Entity}}Service.List.
+// Supported operators are: = (default), !=, <, <=, >, >=, like, not like, in,
+// not in, null and not null. Auto generated.
+message {{.Entity}}ListFilter {
+	option (gogoproto.typedecl) = true;
+	string column = 1 [(gogoproto.customname)="Column"];
+	string operator = 2 [(gogoproto.customname)="Operator"];
+	repeated string values = 3 [(gogoproto.customname)="Values"];
+}
+
+// {{.Entity}}ListRequest requests a page of rows from DB table `{{.TableName}}`. Auto generated.
+message {{.Entity}}ListRequest {
+	option (gogoproto.typedecl) = true;
+	repeated {{.Entity}}ListFilter filters = 1 [(gogoproto.customname)="Filters"];
+	uint32 page_size = 2 [(gogoproto.customname)="PageSize"];
+	string page_token = 3 [(gogoproto.customname)="PageToken"];
+}
+
+// {{.Entity}}ListResponse contains a page of rows from DB table `{{.TableName}}`. Auto generated.
+message {{.Entity}}ListResponse {
+	option (gogoproto.typedecl) = true;
+	repeated {{.Entity}} data = 1 [(gogoproto.customname)="Data"];
+	string next_page_token = 2 [(gogoproto.customname)="NextPageToken"];
+}
+
+// {{.Entity}}DeleteResponse reports the result of {{.Entity}}Service.Delete. Auto generated.
+message {{.Entity}}DeleteResponse {
+	option (gogoproto.typedecl) = true;
+	uint64 rows_affected = 1 [(gogoproto.customname)="RowsAffected"];
+}
+
+// {{.Entity}}Service provides CRUD operations for DB table `{{.TableName}}`. Auto generated.
+service {{.Entity}}Service {
+	rpc Get({{.Entity}}PrimaryKey) returns ({{.Entity}});
+	rpc List({{.Entity}}ListRequest) returns ({{.Entity}}ListResponse);
+	rpc Create({{.Entity}}) returns ({{.Entity}});
+	rpc Update({{.Entity}}) returns ({{.Entity}});
+	rpc Delete({{.Entity}}PrimaryKey) returns ({{.Entity}}DeleteResponse);
+}
@@ -4,11 +4,14 @@
 // _tpl/20_entity.go.tpl
 // _tpl/30_collection_methods.go.tpl
 // _tpl/40_binary.go.tpl
+// _tpl/50_service.go.tpl
+// _tpl/60_relations.go.tpl
 // _tpl/90_test.go.tpl
 // _tpl/fbs_10_header.go.tpl
 // _tpl/fbs_20_message.go.tpl
 // _tpl/protobuf_10_header.go.tpl
 // _tpl/protobuf_20_message.go.tpl
+// _tpl/protobuf_30_service.go.tpl
 
 package dmlgen
 
@@ -87,20 +90,18 @@ func (fi bindataFileInfo) Sys() interface{} {
 }
 
 var _bindataTpl10tablesgotpl = []byte(
-	"\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\x41\x8f\xd3\x30\x10\x85\xcf\xf6\xaf\x78\x54\x1c\x12\x64\xdc\x7b" +
-	"\x51\x0e\x6c\x17\x71\x61\x17\x56\x8b\xc4\xd9\x4d\x26\xad\x45\x62\x57\x63\x17\x5a\x45\xfe\xef\x68\x92\x6a\xb7\xb7" +
-	"\x95\x38\xc5\xf9\x3c\x7e\xf3\x66\x5e\x1b\x43\xca\xa8\xf4\x34\x7d\x04\xbb\xb0\x27\xbc\xcf\x6e\x37\x10\x36\x0d\xec" +
-	"\x4f\x39\x25\x94\xa2\xd5\x7c\x7c\x74\x23\x4d\xd3\xd7\xb8\x75\x23\x0d\xd7\x6b\x61\xa5\xa0\xc1\x6a\x9a\x6e\xc9\x6a" +
-	"\x96\xa4\xd0\x95\xa2\x6b\xad\xd7\x6b\x3c\xd2\xdf\xab\x20\x53\x3e\x71\x48\x70\xd8\x47\xdf\x7b\xea\xf0\x87\x38\xf9" +
-	"\x18\x10\x7b\xe4\x03\xe1\xe1\xf2\xfc\xf4\x6d\xfd\xe0\xd8\xbb\xfb\x3b\x2c\x86\x52\x7b\xa0\xd1\xa1\x8f\x2c\x25\x22" +
-	"\x38\xf3\xb4\xc1\x6d\xe3\x54\x8a\x5c\x7d\x3e\xe5\x88\x3d\x05\x62\x97\xa9\xc3\xee\x82\x6e\x1c\xf6\x14\xac\xee\x4f" +
-	"\xa1\x7d\xb5\x52\xb5\xf9\x8c\x36\x86\x4c\xe7\x6c\xb7\xcb\xd7\xa0\xdb\x49\xb9\x7d\x3a\x11\x5f\xbe\x9c\xa9\xfd\xc1" +
-	"\x74\x74\x4c\x6c\x10\x8f\x39\xc1\x5a\xdb\x75\xc3\xd2\xf3\xfb\x31\xfb\x18\x6a\x54\x79\xc4\x87\x17\x9a\x0c\x31\x83" +
-	"\x98\x23\xd7\x98\xb4\xf2\x3d\xf2\x68\x04\xa0\x81\x54\xbd\x1a\xd0\x4a\x09\xf8\xe5\xf3\x61\xcb\xe4\x32\xcd\x5c\x7c" +
-	"\x89\x0f\x83\xb7\x82\x51\x78\x23\x1a\x83\xd5\xca\x68\xa5\x5e\xe2\xa8\xcd\x4d\xcf\xfb\xbb\xaa\xdb\x09\xa9\x3f\xcd" +
-	"\xf6\xde\x35\x08\x7e\x10\xcf\x6a\x49\x49\x7e\xcd\x32\x4a\x9a\x5f\x3c\x67\xd7\xfe\xae\x88\xb9\xd6\xaa\xcc\xa3\xc9" +
-	"\xbb\x4d\x83\x3c\xda\x65\x1b\xa9\x92\x35\x59\x6b\xff\x57\xf3\x5a\x25\x2b\x0b\x7e\xd0\x45\xff\x0b\x00\x00\xff\xff" +
-	"\x1c\x29\xb4\xc0\xa6\x02\x00\x00")
+	"\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x51\xb1\x4e\xc3\x30\x14\x9c\xed\xaf\x38\x22\x86\x04\x85\x74\x2f\xca" +
+	"\x00\x45\x62\xa1\x45\xa8\x48\xcc\x6e\xf2\xd2\x46\x24\x76\x64\xbb\xd0\x2a\xca\xbf\xf3\x9c\x54\x6d\xb7\x0e\x96\xfd" +
+	"\xce\xe7\xbb\x7b\x7e\x85\xd1\xce\x23\x96\x7d\xff\x08\xab\xf4\x96\x70\xef\xd5\xa6\x21\xcc\x73\x64\x5f\xe1\xe4\x30" +
+	"\x0c\x52\x8c\xc7\x95\x6a\xa9\xef\xdf\xcc\x82\xf7\xe6\x74\x1d\xb0\x61\x40\x8e\xa8\xef\xaf\x91\x68\x94\x24\x5d\xf2" +
+	"\xeb\x44\xca\xd9\x0c\x2b\xfa\x3b\x09\x5a\xf2\x7b\xab\x1d\x14\xb6\xa6\xae\x6a\x2a\xf1\x4b\xd6\xd5\x46\xc3\x54\xf0" +
+	"\x3b\xc2\xf2\xb8\xfe\x7c\x9f\x2d\x95\xad\xd5\xeb\x0b\xa6\x40\xae\xd8\x51\xab\x50\x19\x1b\x28\x41\x70\xc4\xdd\x1c" +
+	"\xd7\xc6\x8e\xed\xf8\xea\x79\xef\x0d\xb6\xa4\xc9\x2a\xcf\xf2\x9b\x23\xca\xb6\xe1\x3a\x93\xd5\x5e\x17\x97\x28\x71" +
+	"\xe1\x0f\x28\x8c\xf6\x74\xf0\xd9\x62\xda\x53\x98\xce\x3b\x64\x59\x56\x96\xcd\xa4\xfc\xd1\x79\x4e\x97\x20\xf6\x2d" +
+	"\x1e\xce\xa8\x4b\xc9\x5a\xf0\x32\x36\x41\x2f\x45\xcd\xe1\xdb\x34\x00\xfc\x1d\x81\x75\xb1\x91\x42\xa8\xae\xe3\xdf" +
+	"\x88\x83\x76\xca\xa5\x08\x84\xef\xda\xef\x16\x96\x38\xe3\xc8\x0b\x69\x52\xdc\x1a\x85\x10\xb8\x31\x8d\x14\x51\x34" +
+	"\x7a\x9c\x47\x90\x84\x32\xe1\x96\xa4\x48\x9e\xc6\x84\x77\x39\x74\xdd\x84\xd8\x62\x1a\x47\x28\xd3\xa9\x1b\x37\x06" +
+	"\x5b\x7b\x55\xfc\xc4\x0c\x24\x52\xb0\xed\x89\x15\x3a\x64\xa6\x1c\xe4\x3f\x8d\x00\xf2\x86\x3b\x02\x00\x00")
 
 func bindataTpl10tablesgotplBytes() ([]byte, error) {
 	return bindataRead(
@@ -119,10 +120,10 @@ func bindataTpl10tablesgotpl() (*asset, error) {
 
 	info := bindataFileInfo{
 		name: "_tpl/10_tables.go.tpl",
-		size: 678,
+		size: 571,
 		md5checksum: "",
 		mode: os.FileMode(420),
-		modTime: time.Unix(1547575483, 0),
+		modTime: time.Unix(1792336843, 0),
 	}
 
 	a := &asset{bytes: bytes, info: info}
@@ -131,73 +132,129 @@ func bindataTpl10tablesgotpl() (*asset, error) {
 }
 
 var _bindataTpl20entitygotpl = []byte(
-	"\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x58\x5f\x73\xdb\xb8\x11\x7f\x26\x3f\xc5\x9e\xe6\xee\x4a\x7a\x64\x28" +
-	"\x0f\x6d\x1f\x9c\xf1\x83\xcf\x76\x52\xb7\xb5\x2f\x13\xfb\xa6\x0f\x1e\x4f\x0d\x83\x4b\x11\x67\x10\x60\x00\x30\x8a" +
-	"\x8e\xc3\xef\xde\x59\x90\x94\x44\x49\xfe\x93\x7a\x3a\xcd\x4c\x94\x88\x20\xf6\xef\x6f\xf7\xb7\xab\xd9\x0c\x9a\x86" +
-	"\x9d\x6b\x2f\xfd\xb2\x6d\xc1\x62\x65\xd1\xa1\xf6\x0e\x38\x38\xa9\xe7\x0a\xc1\x9a\x05\xe4\xc6\xc2\xd9\x2f\xe0\xf9" +
-	"\x83\x42\xb8\x6f\x1a\x76\x43\xff\xbb\xe2\x25\xb6\xed\x3d\x8b\x67\x33\x38\xa9\xbd\x81\x39\x6a\xb4\xdc\x63\xc6\x9a" +
-	"\x66\x21\x7d\x01\xec\xd4\x94\x25\x6a\xdf\xb6\x71\xd3\x30\x38\x6c\xdb\xa6\x41\x9d\xd1\x3f\x87\x20\x73\x60\x7f\xe3" +
-	"\xee\x9c\xbb\xe5\xdf\x9d\xd1\x97\xdc\xba\x82\x2b\xb4\xd0\xb6\xf1\x6c\x86\xdc\x2d\x7f\x77\x46\x1f\xd1\x47\x7f\x2b" +
-	"\xf6\xcb\x0a\x47\x06\x3b\x6f\x6b\xe1\xa1\x89\x9b\xc6\x72\x3d\x47\xd2\xa8\xea\x52\x3b\x52\xf1\xd1\x9c\xf2\x12\xd5" +
-	"\x25\x5f\x3e\xe0\x27\x2b\xbf\x72\x8f\xc0\x3e\x48\x54\x59\xdb\x02\x1d\xdf\x2c\x2b\xbc\xaa\x95\x02\xd6\xb6\x71\x14" +
-	"\xf5\x46\x69\x04\x76\x1d\xe4\xde\xf0\x39\x4c\x26\x64\x36\xf9\xbc\x7a\x16\xbe\x1e\x42\x30\x89\xac\xf9\x68\x36\xdd" +
-	"\x1c\x1e\xf7\xf6\x7c\xc6\x1c\x2d\x6a\x81\xd9\xa9\x51\x0a\x85\x97\x46\x3b\xe8\x2e\x6e\xbc\xdf\xc6\x21\x8a\xce\xc9" +
-	"\xb9\xfe\x27\x77\xfe\x42\x3b\xb4\xfe\xe2\x0c\xea\x2a\xe3\x1e\x1d\xf8\x02\x41\x6a\x61\x91\x14\xc1\xc5\x19\xe4\xe4" +
-	"\x08\x84\x30\xd3\x99\xe2\xce\x83\x0c\xb7\x30\x83\x8b\x33\x12\x97\x5b\x53\x02\xd7\x70\x71\x75\x7d\xfe\xf9\x06\x4c" +
-	"\x45\xd9\x91\x46\x33\xb8\x28\x2b\x15\x24\x39\xc8\x4a\xc5\x06\x6d\x9d\x7e\xb4\x6c\x3b\x9f\x71\x5e\x6b\x01\x09\xc2" +
-	"\xc1\x46\xf4\xd3\x3d\xf6\x26\x32\x03\xa9\xfd\x5f\xff\x9c\x42\x13\x47\xfb\xb2\x22\x73\xe0\x3a\x03\x76\xe1\x3e\xfd" +
-	"\x83\x3e\x49\xd3\xc5\xe0\x58\xdb\x02\xb2\x17\x32\x77\xbc\xca\x1d\xe5\x2d\x91\x59\x4a\x8a\x7a\x58\xa1\xce\x28\x5f" +
-	"\x71\x1b\xc7\x94\xa3\x5d\xf5\x94\xe1\x0b\x17\x64\x8d\x25\x77\xb0\x83\x6b\xf4\x2b\xfd\x6b\x9d\x0e\x7d\x97\x82\x8c" +
-	"\x7b\x1e\xca\x81\x43\xd5\x5f\x27\x6f\x1c\x8a\xda\x4a\xbf\x04\x87\xda\x49\x2f\xbf\x62\x88\x3f\xdd\x1e\xc5\xee\xc7" +
-	"\x8d\xe0\xed\xd5\x94\x64\x3b\xc8\x4c\xc7\x17\x29\xae\xaf\x88\x51\x16\x47\x16\x7d\x6d\x35\x60\xdc\x81\xeb\xe3\x5e" +
-	"\xcf\xba\x97\x36\x9d\x0b\xa0\x79\xb3\x77\x7b\xb5\x25\xe9\x8e\x77\xe4\xce\x60\xe8\x0b\x5e\xc5\x7d\xb9\xc0\x90\xe8" +
-	"\x2e\x63\x97\xbc\xea\xf3\x0b\x72\x0d\x6b\xa9\x3d\xda\x9c\x0b\x84\xee\xf0\x92\x57\x15\x5a\x30\x5a\x2d\xa1\xe2\xd6" +
-	"\x4b\xae\xd4\xf2\xb5\x38\x5f\xab\x48\x44\x09\x07\x54\x32\x2b\xa9\x29\xa0\xb5\xc6\x92\x1f\x32\x07\x51\xb2\x4b\x93" +
-	"\x61\x92\xc2\xf1\x31\x8c\xde\xeb\xa4\x7d\x46\x9e\x9d\x28\x45\x6f\x0f\x6e\x8b\x72\xb7\x4c\x42\x24\x3e\xd4\x5a\x0c" +
-	"\x61\x4a\x7e\x7e\x29\x3a\x69\x5f\x03\xec\xdc\xda\x24\x8d\xa3\x36\x8e\x08\xa7\xa2\x64\x57\xf8\xcd\x27\xa1\x1e\x23" +
-	"\xb7\x90\x5e\x14\x20\xe0\xe8\x98\x4e\x3a\x7d\x49\xfa\x1e\x04\x34\xb0\x6b\x46\x1c\x45\x91\xe0\x0e\x61\xd2\x34\x83" +
-	"\x9e\xc9\xea\xb5\x13\x25\xb9\x43\xd7\xb6\xd3\x49\x68\x66\x93\xde\x82\x23\xba\x16\x89\xf2\x0d\x4e\x90\x84\x0c\x73" +
-	"\x5e\x2b\xdf\x49\x1b\x20\x42\xa1\x76\xec\xca\xf8\x0f\xa6\xd6\x19\xbb\xc2\x45\x9e\x4c\x6e\x9b\x86\x7d\xe2\xe2\x91" +
-	"\xcf\xb1\x6d\xef\x46\x04\xd1\xb9\x02\x3f\x7d\x01\x6d\x3c\xe4\x74\x69\x32\x05\x91\xc6\x11\x05\xa8\x8d\xb7\x04\xff" +
-	"\x4b\xfa\xe2\xda\x73\xf1\x98\x88\xb2\x0b\x64\xda\x57\xcf\x79\x59\xf9\x25\x60\x59\x79\x89\x0e\xb8\x52\xa1\x62\x42" +
-	"\x0d\x38\x30\x79\xf8\x26\x6a\x6b\xa9\x3b\x9b\x87\xdf\x51\x78\x06\x27\xca\x19\x78\xd4\x66\xa1\x81\x3b\xf8\x8c\x0e" +
-	"\xfd\x53\x08\x0b\xe2\x93\x74\xf4\x14\x1a\x38\xc0\xd0\xee\x56\x8f\x9a\xf6\x3d\x0c\x16\xf7\x9c\xd1\x34\x6c\xcd\x2c" +
-	"\xdb\x1c\x2e\x56\x27\x10\xb8\x73\x44\xe4\x63\x1e\x27\x59\x57\xc6\x83\x2f\x2c\xf2\x0c\x1c\xcf\x71\xa7\x3c\xfe\xc7" +
-	"\xb4\x3e\xf2\x63\x45\xed\xd1\x19\xb5\xa5\xf5\x9f\x28\xba\xbd\x1b\x85\xe9\x3e\x08\x9c\x50\xf7\x9a\x9a\x52\x7a\x4a" +
-	"\xd2\x72\x72\x1f\x47\xbf\x60\x6e\x2c\xae\x8b\x37\xa2\xd8\x27\x75\xe0\xa7\xe9\x56\x02\xba\x22\xee\x45\x1d\xd2\xed" +
-	"\x93\xdc\xa3\xdd\x68\x2e\xdf\x75\xbb\xcb\xcd\x15\x2e\xb6\xdd\x12\x16\x03\x9f\x73\xd0\xb8\x00\xa9\x25\x75\x22\xf9" +
-	"\x07\x66\x1b\xb9\x7a\xa2\x2d\xed\x4a\xeb\x01\x33\x52\x10\x68\x77\x76\x10\x47\xd1\xcd\xaf\x67\xbf\x26\x32\x43\x9e" +
-	"\x1e\x41\xed\x10\x38\xcc\x95\x79\xe0\x0a\x2a\x63\x14\x2c\x0a\x49\x9d\x80\x6b\xb0\x38\x97\xce\xa3\x0d\xe8\x40\x2e" +
-	"\x8a\x0e\x2b\xbe\xc0\x38\x8a\x1e\x42\x0c\x67\x9c\x82\x01\x25\xaf\x44\x57\x50\x64\x50\x80\x95\x33\xe0\x0b\xee\x3b" +
-	"\xfe\xc0\xaf\x90\x19\x74\xa1\xd6\x34\x62\x06\xde\x00\x0f\x33\x42\x1c\x45\x9d\x64\x59\x22\x03\x5f\x48\xfd\x48\x48" +
-	"\x91\xfe\x4f\x0e\x16\xc6\xfa\x02\x5c\x2d\x0a\xe2\x1d\xee\x3d\x5a\xcd\xe2\xe8\x60\x16\x98\x7c\xa8\xd0\x9f\xb7\x1c" +
-	"\xa5\x76\x46\xc8\x38\x82\x92\x3f\x62\x32\xc6\xc4\x14\xde\x4d\xe1\x2f\xe9\x94\x4a\xbc\x8d\xfb\x9a\x13\x62\x27\x5a" +
-	"\x29\x38\xc1\xf5\x93\xbd\x7d\x3a\x2e\xd3\x29\xc8\xec\x1b\xd4\xfd\x7c\xb3\xd9\xf6\xd1\xda\xd0\x52\x05\xdb\xc6\x5c" +
-	"\x22\xb3\x6f\x53\xc0\xf4\x7d\x78\xe7\x87\x63\xd0\x72\xd4\xfd\x77\x1a\x0f\x5a\xdb\x75\xef\xb5\x58\x64\x23\x02\x7a" +
-	"\x93\x2c\x21\xd8\x16\xb0\xff\x7b\x0b\xfb\x17\xb4\x54\x3d\xde\xf7\x53\xf1\x28\xa4\x44\xc2\x2b\x6e\x7e\x8a\x7f\xf7" +
-	"\x66\xea\x95\x24\xdc\x53\x5c\xd9\x53\x5c\xc7\xc6\xef\xa1\xa4\xb3\xc0\x65\x4f\xb3\xf2\xf4\xa9\xb3\x6b\x0c\x34\x44" +
-	"\xf5\x21\xa7\x80\x24\xba\xe3\x40\x21\x58\xe8\x4e\x14\xae\x71\x90\xc7\xb8\x9a\x02\x4e\x7b\xe0\x24\x32\xdd\x0d\xf5" +
-	"\x4b\xc1\x0e\x6c\x45\x7f\x77\x3d\xb8\x16\x5c\x93\x71\xdd\xf0\x71\x6a\x6a\xed\x69\xf6\x78\xd7\xc9\x1d\x0c\x3c\x1e" +
-	"\x4c\xbd\x3d\x7a\x77\xd7\x89\x8a\x82\x1f\x1a\x17\xc9\x66\x2b\x8b\x5f\xf6\x63\x50\xb3\xc7\x8d\x17\xbc\x20\xad\x6b" +
-	"\x8b\x08\x0c\x3a\x4b\xfa\x07\x04\xc1\x7d\xfe\xad\x41\xb0\x95\x89\xf1\x64\xf3\xec\x68\x43\xe7\xbb\xeb\x00\xfb\x4d" +
-	"\xcb\x2f\x35\x0e\x88\x3d\x7c\xeb\xbc\x03\x41\xf1\xf6\xd0\xe3\xc8\xc1\xdd\x51\xd8\x25\x29\x63\x2c\x1d\x2c\x5b\x4d" +
-	"\x3d\xd4\xbb\xe1\x29\x5b\x65\x2e\xc3\x1e\xd9\x4d\x67\x51\x34\x9b\x81\x36\x40\x2d\x9e\x9a\x69\xe8\xc3\xd2\x85\xce" +
-	"\x8b\xd9\xff\xd9\x17\x38\x98\xbd\x7d\x8c\x1b\x11\xdb\x33\xa3\xdc\x50\x1d\x1b\xba\x76\x34\x5d\xd7\x55\x65\x68\x39" +
-	"\xde\xaf\xed\x37\xdd\x0d\x6a\xd4\x2f\x8e\xe0\xa7\x2f\x93\x29\x4d\x20\x52\xcf\x93\x32\x1d\x75\xbb\x61\x30\x0c\xdb" +
-	"\xc8\x73\x80\xea\x46\xaa\x3d\xd1\x5a\xed\x5c\x1c\x9c\x92\x02\x81\xd6\xc9\x50\x0b\x2e\x10\x66\xff\x94\x66\xcc\xaf" +
-	"\x5c\xd5\xe8\xf6\xfd\xc2\x32\xea\x94\x3f\x6e\xb5\xca\x7d\x29\xb2\xe8\x81\x31\xb6\xbb\x5d\xde\xde\xed\xdb\xc9\x64" +
-	"\x4e\x66\x52\x27\xd9\xa0\x03\x38\x1e\xc8\x76\xfb\x4a\x20\x5c\x85\x7a\xa8\xe6\x74\xbd\x81\xfc\xfb\xc9\x8e\xd9\x49" +
-	"\xec\xdb\x80\x45\x3f\xdd\x5c\x01\xd7\x3b\xc1\x66\xf4\x2d\xfa\xb8\x85\x61\x70\xdc\xb3\xe3\xef\x2b\x94\xa7\xf2\xf0" +
-	"\x80\xca\xe8\x79\x08\x7a\x18\xdf\x3b\x80\xdd\xaf\xeb\xe5\x9e\x6e\xd2\x06\xfc\xba\x8c\x85\xe5\xb2\x0e\x18\xe8\x33" +
-	"\xd7\x6d\x06\xdc\xf7\xb2\x43\x22\x6f\x8a\xd5\xe9\x42\x2a\x05\x0f\xb4\x46\x28\x8f\x16\xb3\x8e\x19\x35\xad\xa6\x20" +
-	"\x35\x70\xf8\x68\x68\xe6\x62\x70\x65\x68\x6c\xff\x52\xa3\x5d\xc2\x1c\xbd\x23\x39\xf8\x0d\x45\x4d\x50\xf8\x2e\x68" +
-	"\x74\x10\x7d\x05\x40\xb6\xc1\xf1\x9d\xc0\x78\x06\x14\x1b\xe3\xe9\x11\x70\xb0\x58\xbb\xb0\x8f\x94\xbc\x0a\xc1\xa6" +
-	"\x59\x35\x93\x79\xf8\xdd\xcc\x03\x57\x73\x63\xa5\x2f\x4a\x07\x19\x52\xc8\xa5\x9e\x03\xed\x33\x05\x82\x93\x7f\xd0" +
-	"\x8c\x3a\xac\x5f\x3d\xb4\x42\x32\x18\x5c\x9b\x12\x69\xdc\xa4\xac\xe5\xc6\xce\x08\x8b\xca\x98\x0a\x6c\xad\x1d\xe4" +
-	"\x3c\x8c\xbc\xbe\xe0\x14\x67\x0a\x32\x4d\x9c\xd4\xb2\xb2\xba\x3a\x2d\x50\x3c\x12\x66\x83\x53\x25\xaf\x6e\x37\xdd" +
-	"\xba\x7b\x30\x46\xed\x38\xf6\x3c\xd4\x65\x0e\x3f\x0c\x82\x6f\x3b\x94\x7f\xb2\xb2\x0c\x3f\xa6\x0c\x35\x74\xb7\xe2" +
-	"\xd0\xbd\x55\xb1\xf3\x7e\xe8\x7c\x2f\x0b\x3d\x06\x6f\x6b\xdc\x5e\x78\xc7\x85\xf4\x9f\x00\x00\x00\xff\xff\xd0\x54" +
-	"\x53\xc8\x08\x16\x00\x00")
+	"\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xc5\x5a\x5b\x53\xe3\x46\x16\x7e\xb6\x7f\x45\xc7\x45\x88\x45\x19\x31\xa9" +
+	"\xda\xcd\x03\x19\xb6\x8a\x19\xc8\x84\xcd\x40\xa6\x06\x48\x1e\x28\x76\x69\x4b\x6d\xac\xa0\x8b\x47\x2d\x99\x61\x1d" +
+	"\xff\xf7\xfd\xce\xe9\xd6\xd5\xb2\xf1\x30\x9b\xda\x7d\xc8\x8e\x5b\xad\x73\x3f\xdf\xb9\x88\xc5\x62\x5f\xa4\x32\xbe" +
+	"\x57\x62\xc7\xcb\xc4\xe1\x91\x38\x8d\xf3\xe8\x52\x65\x57\x4f\x33\xa5\x85\xfb\x36\x09\xf3\x28\xd6\xcb\xe5\x62\x11" +
+	"\x4c\x84\xfa\x24\xdc\x5f\x82\xd8\x17\x03\x85\x5b\x83\xe5\xb2\x7f\x70\x20\x16\x0b\xf7\x5d\x42\xd7\x97\x4b\x91\xaa" +
+	"\x59\xaa\xb4\x8a\x33\x2d\xb2\xa9\x12\x32\x0c\x93\x47\xe5\x8b\xb9\x0c\x73\x50\x4b\x26\x42\xc6\xe2\xf4\xe2\xfa\x5c" +
+	"\x78\x4c\xd7\x15\xc7\xb1\x50\xd1\x2c\x7b\x32\x57\x88\xdc\xbd\xa2\x97\x53\x25\x33\xbc\x28\xb5\xb8\xb8\x7e\xff\x1e" +
+	"\xf7\xf2\x2c\xc1\xa3\x58\xa5\x74\xee\xf6\x33\xf0\x6b\x70\xd6\x59\x1a\xc4\xf7\x7d\xa2\x70\xbc\xc2\xb5\x7d\x7b\x95" +
+	"\x9e\x97\xc4\x3a\x13\xc3\xfe\xa2\x32\x47\x30\x12\x3b\x73\xb2\x88\xfb\x1b\x13\x82\xb6\x3d\x58\x21\xf6\xd5\x67\xb2" +
+	"\x15\x4c\x83\x57\x34\xee\x81\xfb\x62\x41\x27\xa5\x30\x47\x38\x98\x41\x9e\x6c\x22\x06\xdf\x7e\x1a\x80\x0e\x5e\x26" +
+	"\xd2\x2a\xf6\xf1\x2f\xa7\xdf\x9f\xcb\x94\x04\x32\x94\x7f\x93\x29\xbf\x74\xe3\xba\xee\x6d\x4d\xcc\x85\xa8\xc4\xb1" +
+	"\xec\xc8\x11\xee\x72\x39\xc2\x13\xd0\x12\xfb\x78\x6f\xc9\x4a\x1b\x4a\x70\x40\x96\xa7\xb1\x26\xcb\xb7\xac\xbf\xaa" +
+	"\xf3\x24\x8f\x3d\x31\xac\xf1\x73\x2c\x95\xa1\x23\x6e\xea\x72\x88\x45\xbf\x67\x08\x0b\x39\x9b\x81\xef\xb0\xf1\x78" +
+	"\x18\x07\xa1\x33\x6a\xa9\x73\x73\x78\x0b\x6d\x9c\x7e\x29\x5d\xe0\x83\x6b\x25\x1f\xfc\x9e\xa6\x49\x2a\x1e\x83\x6c" +
+	"\x2a\x38\xa6\x2e\x92\x8c\xaf\x09\x04\x1a\x05\x0f\x8b\x2d\x02\x2d\xe2\x24\x2b\x74\x71\xd9\xbf\x9d\x7a\x34\x1c\xec" +
+	"\x94\x1c\xa1\x8b\x61\x04\x1d\x28\x82\xc5\xd1\x91\x18\x0c\xe8\x57\xa1\x12\xa4\xef\xf7\xe0\xdb\x09\x2e\xfd\x7b\x24" +
+	"\xd8\xe5\xc6\xe6\x2d\x07\xd1\x3b\x20\x31\x27\x12\x8a\x7f\x35\x48\x10\x8d\x65\x69\x28\x66\xaa\xdd\x42\x29\xf7\x42" +
+	"\x3d\x4e\x86\x83\x1b\x04\x8a\xfb\x41\x7a\x0f\xf2\x1e\x52\xde\x36\x22\xd8\xe8\xfb\xed\xa7\xba\xbe\x83\x91\x0d\xec" +
+	"\xa1\x72\x0a\x5b\x5e\xf2\x81\x08\xa2\x59\xa8\x22\x4e\xb5\x49\x94\xb9\xe6\x54\xa5\xee\x76\xe6\x31\xd7\x61\x1c\x43" +
+	"\x5e\x2c\xac\x67\x2a\x76\x36\xae\xce\x65\xaa\xa7\x32\xbc\x52\x9f\xb3\x3a\x4b\x15\x7b\x89\x8f\x8b\x2e\x3d\xb0\x77" +
+	"\x98\x79\x23\x9f\x4b\x77\xc3\x40\x23\xa2\xf6\x38\x0d\xbc\x69\x1d\x25\xba\xb3\xbb\x53\xe4\x9a\x24\x90\x1b\x21\x38" +
+	"\x7e\xca\xd4\xc8\x18\xda\x29\xdc\x9b\xa6\xe4\x3e\xe5\x56\xee\xff\x91\x0f\xbf\x39\x22\x19\xc4\x9f\x7f\xae\x89\x00" +
+	"\xa6\x53\xf7\x9f\x21\x0f\x3b\x8c\xd8\xbb\xc6\x18\xd7\x71\xb4\x85\x39\xca\x5b\x9b\xbc\xb1\xd7\xd0\xad\x41\x78\x98" +
+	"\x11\x75\x23\x40\x2d\x7a\x39\x30\xeb\x69\x47\xd7\x9c\xba\xda\xf3\x75\x6a\xd7\x74\xb5\x71\xf9\x3b\xd2\xee\x32\x43" +
+	"\x20\x0e\x71\xe0\xb0\xe2\x7b\xb0\x8c\x98\xf7\xeb\x31\x4d\xa0\xa5\x42\xad\x9e\x05\xfa\x48\x45\x63\x95\x1a\x84\x17" +
+	"\x97\xa7\x57\x16\xdf\x09\xbf\xa5\x18\x07\x99\x56\xd9\x16\x18\x9e\x03\x31\x7f\xf8\x9b\x09\xbb\x8a\xe0\x8b\xc1\xdb" +
+	"\x63\xf0\x2e\x70\x93\xc0\x7b\xc7\x63\xac\x36\x95\x6c\x27\x10\xaf\x08\x4e\x5b\xc8\xfd\xbd\x78\xfd\x5a\x04\x49\x26" +
+	"\x19\x61\xdb\xb8\x6d\x58\x41\x9a\x73\xa9\x1f\x98\x34\x53\x6c\x10\x78\xfd\x7a\xb1\x08\x55\x5c\xd5\x0d\xb1\x2f\xbe" +
+	"\xdf\x04\xf9\x26\xe7\x1a\x68\x5f\xbc\xda\xac\x23\x9d\xc8\xff\xb3\xac\x60\x3f\x4b\x09\x32\x27\x0c\xff\x35\x9f\xcc" +
+	"\x85\x4c\x95\xe8\x74\x82\x89\x47\xdd\x4c\x35\x90\x1c\xce\x9b\x47\xe3\x24\x09\x6b\x28\xb1\xcb\x28\x38\x17\x2f\x83" +
+	"\xf7\x3c\x7e\x88\x93\xc7\x98\x23\xa3\x29\x9a\xe9\x00\x36\x4b\xb7\x06\xda\xf5\xee\xbf\x5a\x7e\x41\xf4\xbf\xea\x88" +
+	"\xfd\x2f\xc7\x64\x1f\xf1\x1c\x67\x32\x80\x66\x85\xe8\xd6\xba\x00\x68\x13\xb5\x43\xed\x38\x75\xfc\xa8\x40\x63\x33" +
+	"\x5e\xc3\x54\x7e\xe5\x3e\xa4\x92\x97\x44\x91\x84\x41\x66\x92\x6d\x20\xc2\x00\x01\x97\x4c\x88\x92\xe5\xb9\xa5\x13" +
+	"\x57\x20\x1e\x10\x82\x10\x1c\xe7\x13\x7b\xa2\xdd\x37\x79\x10\xfa\x2a\x35\xb5\x2f\xd8\xa2\xf6\xe9\xdd\x21\xc2\x9b" +
+	"\x34\x1e\x06\x8e\x53\x19\x98\x9e\x81\xb0\xfb\x5e\xc5\x60\xf8\x8f\xe2\xb4\x47\x67\xbf\xa7\x41\xa6\xde\x10\x98\x7e" +
+	"\x37\xfa\xce\xa1\xe3\x65\xbf\xfe\xc8\xca\x39\x77\xda\x25\x94\x6e\x14\x4a\xf4\xbf\xae\x1a\x21\xbe\xaa\xd8\x44\x81" +
+	"\x8d\xf7\xc9\x3f\x20\x68\x1f\x87\x81\xa7\xb6\x34\xeb\x17\x94\x21\xbd\x05\x1e\x77\xd5\x1e\xdb\x64\x45\xf2\x41\x95" +
+	"\xf4\x5f\x8d\xc4\xf7\x3f\xa0\x18\xe9\xd2\x24\xd4\x5e\xfd\x25\xc5\x49\xbf\xa4\x38\x21\xb2\x1a\x88\xc1\x76\x00\x0a" +
+	"\x9a\x2a\x55\x86\x84\x6d\xb2\x4c\x24\x57\xd1\x56\x04\xe4\xe5\x2c\x0c\xb2\xa1\xed\x41\xf8\xcd\x91\x18\x8c\x06\x8e" +
+	"\x09\xa7\x49\x92\x23\x59\xf0\xd2\x44\xa2\x28\x99\x13\x8e\xdb\x68\x63\xdc\x72\x74\x46\x84\x57\x96\xad\x39\x44\x41" +
+	"\xfd\xd3\xc2\xbd\x0d\x68\x73\x6c\xb8\x1c\x31\x96\xf2\xc9\xb2\x8c\x59\xd0\xf9\xc6\x3c\x36\x24\x5e\x8c\x2b\x56\x90" +
+	"\x95\x66\xcf\x9c\x97\x49\x62\xd2\x61\x4f\x77\x17\x66\xaa\x49\x45\x91\x32\xe5\xf9\x34\xce\x82\xec\xa9\x59\x9e\x81" +
+	"\x25\x30\x66\x08\x6c\x4e\x1e\x05\xd9\xeb\xe4\x8d\xc8\xe4\x18\x07\x77\x78\xe3\x8a\xfe\x75\x21\x23\x08\x75\xd7\xd5" +
+	"\x5c\x2f\x16\x8c\xe0\xa8\xa2\x11\x05\x13\x97\x43\x97\x8a\x4f\xc9\x7f\x9f\x00\xdd\x45\xc5\x38\x95\xfa\xe9\x9f\x3a" +
+	"\x89\xcb\xfc\x13\x2c\x97\xc2\xf1\x1f\x38\x3e\xa4\xff\x14\xe2\x16\x35\xbd\x14\x18\x2e\xcf\x31\x7f\x2e\x40\xbe\x9c" +
+	"\x77\xca\xc9\xf3\x5d\xf2\x16\x12\x86\xe7\xf2\x69\xac\x3e\xa4\xc1\x9c\x2a\x8d\xfb\x53\xa0\x42\x9f\x8b\xaf\xb1\xea" +
+	"\x45\x8e\x9a\xe7\x52\xe0\xf5\xac\x50\x31\x6e\x5d\x32\xdd\x2b\x79\x4f\x2d\x1f\xc4\x26\x9d\xcb\x33\xfe\x69\x8b\xbb" +
+	"\x71\x4f\x5d\xcd\xe2\xd8\xca\xf3\x51\x4d\x54\x8a\x54\x52\x3e\x24\x0b\x95\x97\x05\xa8\x32\xc2\xbc\xd8\xb8\x5f\xd6" +
+	"\xf0\x8f\x2a\x94\x7c\x8b\x87\x36\x63\x64\x4a\x2d\xb2\xd7\x99\x3e\x97\xf1\x13\x3f\xb8\x92\x29\x66\xde\x8a\x28\xdb" +
+	"\x96\x9b\xae\xf2\x61\x61\xa6\x82\xc9\xdd\x04\xd0\x90\x1e\x0e\xf6\x07\x77\xc2\x78\x9e\x4a\x2c\xfb\xdd\xb0\x14\xf3" +
+	"\x40\xd2\xb1\x31\x62\x4b\x3c\xeb\xaf\xb7\x53\x12\xf3\x2a\x45\x84\x22\x40\xc8\x70\x49\x1a\xdc\x07\xb1\x0c\x39\xff" +
+	"\x4b\xd7\x80\x01\x20\x4c\x52\x2f\x17\x26\xd2\x47\x49\x9a\xa4\x49\xc4\xb5\x8a\xce\xc7\x12\xa9\x58\x90\x37\x48\x74" +
+	"\xac\x75\x70\x1f\xbf\x97\x3a\x3b\x8b\xb5\x4a\xb3\xb3\x13\x91\xcf\x08\x06\x4d\x85\x0b\x62\x2f\x65\x68\x12\x78\x30" +
+	"\x21\x37\x9a\x36\x81\x9e\x85\x78\x09\x17\xe8\x2d\x30\x3a\x3b\x21\x72\xcc\x0e\x1d\xc5\xd9\xc5\xe5\xe9\xc7\x2b\x91" +
+	"\xcc\x28\x36\xa1\xa4\x2b\xce\x2a\x90\xf3\xa3\xd0\x2d\xb8\x19\xfe\xcf\x75\xdf\x85\x82\x4e\x87\xbc\x43\xea\x53\xa8" +
+	"\xac\x33\xf2\x74\xc5\x24\x35\x59\xc0\x01\x38\xf2\xc3\x2f\xf4\x5f\xe2\x74\x56\x28\x06\x4b\x28\xf7\x99\xb8\x3d\x2a" +
+	"\x23\x97\xa2\x16\x0c\x1d\x62\x54\x25\x35\x45\x2b\x21\x7b\x63\x05\x50\xb2\x27\x27\x9e\x69\xa6\xd5\xa4\x6c\x92\x4e" +
+	"\x5c\xaa\xac\xe4\x5f\xf1\xd4\xca\xf6\xeb\xec\x50\x02\x03\x29\x66\xf6\x75\xd2\x46\x2b\x2f\x47\x49\xa6\x7a\x19\xeb" +
+	"\x20\x0b\xe6\xbc\x91\x61\x17\x35\x6c\xb7\x53\x33\x5e\x27\xa7\xa1\xbf\x92\x97\x4e\xf3\x45\xb2\xeb\x16\x36\xf2\xab" +
+	"\x81\xda\x96\xb9\x77\x9d\x9a\xd5\x3b\x28\xa3\x1c\x07\xcd\x57\x6b\xd7\xc9\x0d\x75\xbf\xad\x5d\x6d\x45\xf2\x9c\x56" +
+	"\x05\x78\x8b\xc2\xd1\xc6\x63\xe7\x72\x66\xfd\x5b\xaf\xdd\x08\x42\x95\x4e\xa4\xa7\x84\x79\x78\x4e\xbd\x41\x2a\x92" +
+	"\x38\x7c\x12\xe8\x10\xb3\x00\xb5\xe3\x69\xdb\x38\xaf\x58\x0c\xbd\x48\xec\x51\xca\x94\x54\x9b\xbd\xb4\x17\xb9\xe7" +
+	"\x89\x4f\x1d\x36\xaa\x66\xe3\x9e\xa1\xf6\x51\x49\xff\x38\x6c\x34\x32\x5e\xb4\x9a\x26\x6c\x89\x9f\x20\x4b\x61\xa6" +
+	"\xe1\xee\x73\xd6\x71\x6c\x0e\xb8\xa7\x69\x3a\x74\xca\xc5\x0c\x04\xba\x30\x1d\x17\xb1\xd4\xc0\x0b\x6f\x2a\x78\xc0" +
+	"\xc3\x13\xc3\x8f\x7a\x2c\x4f\x2c\xc4\xaa\x18\x54\x4e\x3d\xc0\x94\x18\xc0\x1c\x96\xcf\xa0\xbc\x76\x1c\x06\x78\x86" +
+	"\x6b\xa3\x01\x43\xf9\xc0\x4a\x70\xc8\x25\x1e\xe4\x5f\xae\x04\x51\xf0\xd5\x44\xe6\x61\x76\xd8\xdd\x30\xfc\x44\xdd" +
+	"\x44\xd5\x30\xb4\xfa\x85\x32\x59\x8c\x2a\x45\xbf\xc0\x2d\x08\xba\x05\xaf\x6a\x99\x37\xe2\xfa\x46\x87\x5e\x7a\x80" +
+	"\xd6\xdd\x5d\xba\xc1\x26\xa7\x0b\x45\x8f\xaa\x50\xc3\x80\x19\x86\xa2\xb6\xee\xa8\xe6\xe1\xb5\x3b\x85\x82\x96\xd3" +
+	"\x37\xe3\xf3\x3a\xc9\x28\xee\xeb\x1c\x04\x1a\xcd\x07\x3b\x06\xe5\x69\x4a\x25\xe2\xb9\xd2\x33\x22\x18\xe3\x4e\xfe" +
+	"\xb3\xa4\xb4\x11\x72\x92\xd1\x54\x25\x74\xee\x79\x4a\xeb\x49\x1e\x96\x85\x23\x15\xd7\x1f\x4e\x8e\xaf\x4e\x91\x2f" +
+	"\x74\xe9\x51\xa6\xbe\x06\x8a\x9e\x04\x29\x40\xc1\x22\x08\x43\x02\xb5\x97\xae\x78\x0f\x96\x34\x38\xb1\x08\x54\x50" +
+	"\x6b\x29\xea\x21\xef\x74\x53\x76\x89\x1c\x8c\x50\x98\x3c\x93\x92\x57\x10\x32\x46\x84\x10\x41\x3f\x98\x4c\x68\x14" +
+	"\x67\xe9\xf9\x25\x31\x56\x9e\xcc\x11\x92\xb3\x34\xc9\x12\x0f\xb3\x35\xa6\x1d\xdc\x41\x07\xa8\xb5\x24\x72\x25\x08" +
+	"\x98\x17\x36\x8c\xc7\xed\x34\x6f\xfa\xac\x59\xcc\xe1\xd5\x84\xb2\x66\x0f\xad\x6d\xe2\x96\x05\xff\xc8\xac\x30\x55" +
+	"\xfd\x64\x37\x59\xc1\xde\x5f\x8b\xa7\xd5\x2c\xe5\x25\xb3\x27\xde\xd2\x14\xc0\xbb\xc1\x57\xe4\x01\x0a\xad\x80\xa7" +
+	"\x59\x7a\xa2\x58\x2e\x31\x95\x66\xcb\x3b\x56\x2a\xb6\x6f\x6f\x8b\x69\x85\x48\x1d\x8a\xd2\x20\x56\x53\xa8\x6b\xf4" +
+	"\xe2\x80\xb6\x06\x29\xaf\x96\x6a\xc3\x02\x46\xef\x56\x8c\x94\x6b\x96\xcd\x2a\x74\x1a\x80\xc8\xc9\x0c\x93\x11\x35" +
+	"\x3a\x09\x5a\x54\xd3\x00\xd1\xeb\xfc\xaa\xc7\x7e\xdb\x5a\x7d\x2b\xd9\xb0\x58\xd0\x94\xb2\xd3\xe8\xa5\x5c\x13\x05" +
+	"\x3e\xc3\x12\x62\x81\xe7\x30\xab\x53\xe3\x51\x6b\x03\xc1\x60\x43\xc1\xab\x0b\xd7\x5a\xb1\x8c\xb4\x1a\x9d\x57\x5d" +
+	"\x79\x22\xb7\x95\xfe\x23\x5e\x4c\x35\xe8\xdf\x97\x93\x39\x29\x8d\xa4\x21\x62\x98\x5d\x00\x99\x82\x90\x69\xac\x04" +
+	"\xd2\xc4\x76\x88\xbe\x1f\x9a\x89\xc5\xbd\xe6\x7e\xf2\xcd\x13\x9a\x2f\x04\x15\x01\x9a\x39\x71\x8f\x7d\xbf\x2c\xa3" +
+	"\x31\xd1\xf2\x92\xf8\x0f\x98\x8e\xbb\x62\x26\x72\x8c\xaa\xa9\x65\x0c\x68\xf3\x92\x14\x3c\x2f\x1f\x82\x19\x4b\x69" +
+	"\x7a\xd4\xc2\xaf\x56\x86\x40\x9b\x1d\x01\xaf\xaf\x8b\xa4\xad\x71\x27\x92\x49\x9e\x59\xa5\xb4\x78\xa4\x95\x86\xae" +
+	"\xe9\xa9\xb7\x75\x65\xcb\x57\x98\xb1\xab\xc5\xcd\x73\xa1\x7c\xd3\xb1\x43\x6c\xec\x40\xeb\x7d\x2b\x7d\xb1\xb2\x6b" +
+	"\x44\xae\x76\xb5\x92\x58\x6d\x16\xcb\xc4\xa8\xe7\x05\x4d\xf9\xd0\x4a\x97\xfc\x4c\x35\x58\x6d\x4f\x77\x26\xf4\xea" +
+	"\xc6\x2e\x88\x74\xa2\x2a\x8a\x22\x78\xfa\x29\x87\x56\xae\x18\xf2\xae\x53\x0c\x94\x3b\x10\x3b\x13\xa7\xfc\x9d\x98" +
+	"\xdf\x76\x9c\x67\x01\x8e\x8a\x25\x09\xfd\x1a\x35\x0a\xfb\xba\x2a\x45\x37\xfb\xf5\x75\x2e\x79\xf4\x94\xf7\x3f\xe4" +
+	"\xe1\xc0\x7a\x8d\x7c\x6f\xa2\xbc\x8c\x7d\x5b\x8a\x92\xf1\x1f\x98\xd0\xe0\xce\x50\x27\xc2\x6c\x01\xa5\xad\x01\xeb" +
+	"\x7c\xca\xe4\x57\xa0\x49\xf0\xaa\xbd\x76\xb4\x58\xfe\x58\x6c\x56\x95\x9d\xa1\xcc\xf0\x56\x8e\x84\xcd\x89\xde\x2b" +
+	"\x9f\x98\xed\x78\x63\xac\x6f\x4e\xf5\x44\x0b\x26\x86\x22\x29\x7a\x36\xa1\xe5\x64\x75\xd1\xf5\x17\x0f\xf9\x0d\x3d" +
+	"\xca\x41\xbf\x77\x42\xd5\xa2\xfa\x5f\xaf\x77\x73\xdb\x30\xd3\x1d\x13\x1c\x10\x76\x8c\x92\x08\x59\x45\xb6\x1c\xdc" +
+	"\xf5\x7b\x6f\x14\xf4\x55\x55\x31\xee\x91\xed\x87\x66\x0d\x3b\x6a\x39\xc0\x34\xb5\x96\xd4\x3e\xbd\xcd\xa5\xbf\x56" +
+	"\xc9\xbf\xe8\x6d\xe3\x1b\xb4\x6b\x6d\xb5\x3c\xfe\x60\xcd\xcb\x45\xf5\x08\xe4\x09\xa8\x33\x0f\xfe\xa3\xfc\x9a\xaf" +
+	"\xd6\x00\xc1\x2a\x35\x1b\x30\x0d\x06\x3c\x86\x1e\xec\x21\xfe\xaf\x7e\x3d\xf9\x15\xe3\xa2\x92\xce\x21\x41\x23\x58" +
+	"\xde\x87\xc9\x18\x09\x34\xa3\x0a\x60\xbe\xb0\x11\x72\xa6\xea\x3e\xd0\xd4\x0b\x51\x74\x28\x89\x53\x76\x08\x22\x1a" +
+	"\x44\xc6\x6c\xc3\x03\xd3\x2c\x45\x72\x66\x31\x79\x52\x20\x25\x22\x3c\x9b\xca\xcc\xa0\xb7\x9a\x0b\x3f\x51\x06\xdf" +
+	"\x63\x05\x9d\xa0\x85\xe4\x99\x99\x7a\x44\xa6\x1c\x44\x08\xab\x6c\x1a\xc4\x0f\x14\x29\x41\xf6\x1d\x90\x30\x49\x11" +
+	"\x54\x68\xc3\xa6\x34\x87\xc9\x0c\x9c\x62\xb7\xdf\xdb\x3b\xe0\xc9\xb6\xac\xb1\x2d\x45\x29\xc3\x29\x32\x0e\x85\x5d" +
+	"\x7f\xd6\x3d\xc2\x6b\xd0\xbf\x3b\x23\xca\x70\xb8\xc2\xe4\x9c\xe7\xad\x58\xcb\x11\x1a\x16\x58\x3b\xeb\x8c\x9a\x69" +
+	"\x3a\x12\x81\xff\xd9\xae\xf1\x5b\x63\x90\xe7\xb6\x83\xad\xbe\xc2\xad\x36\xbd\x1d\x17\xe1\xa2\xcf\x23\xa1\x56\x17" +
+	"\xbf\xcf\x7c\x89\xb3\x0d\x7d\xfd\x63\x66\x63\x6e\x7b\xc1\x97\x3d\xa3\x49\x3b\xf0\xd7\x29\xd2\xba\xf7\x95\x7a\xac" +
+	"\x7c\x0e\xe9\x9e\x73\x1b\xfe\xa1\x09\xb7\x1c\x7c\xd7\x95\xcf\x4e\xb7\x6f\x39\xe1\xda\xf9\x31\xb2\xf3\xa3\x99\x8c" +
+	"\x7e\xc4\x6f\x3c\xe3\x41\x71\xfd\xc8\x3b\x5a\xf7\xec\x52\xf1\x8c\x67\x37\xd2\xaa\xda\x48\xc3\xa4\x0c\x75\xc5\x77" +
+	"\x92\xca\xd2\xcd\x20\xc5\x4b\xe5\xc7\xa4\xc0\xe9\xb0\xf7\x73\x06\x2f\x56\xc6\xab\x1a\xd0\x8c\x77\x68\xfc\xcc\xe3" +
+	"\x72\x8e\x6a\x76\x54\x7e\xbb\x29\x04\x3c\x2a\x44\xbd\x39\x7c\x75\x6b\x48\xf5\x58\x0f\x40\xda\xb0\x8e\x8b\xfd\xe7" +
+	"\xf5\x28\xd8\xbc\x28\x6c\x6a\x12\x15\x55\xde\x1c\x50\x1c\x76\xe9\x57\x05\x41\xcb\x13\xcd\xb5\xc1\xc6\xbd\x01\x3d" +
+	"\x5f\x6d\x66\xdc\xeb\x38\xf8\x94\xab\xff\xd1\x2a\x41\x30\xdb\xf6\x3e\x41\x93\x7a\xab\x5b\x26\x6d\x3e\xf1\x14\x72" +
+	"\x95\x0b\x05\x2a\x03\x62\x9d\xa4\x01\x5a\x17\xbf\x94\xb6\xd7\x43\xc2\xc5\x89\xa0\x6a\x61\xfa\x5a\xc9\x1d\x2d\x81" +
+	"\xb8\xf2\xff\xcf\xba\x88\xbd\x83\xaf\xdf\x90\x34\x6a\xe4\x86\x2d\x49\x91\x1b\x35\x5e\x2b\x9c\x2e\xf3\xd9\x2c\xa1" +
+	"\xbd\x73\x37\xb7\x6b\xfb\xe5\x97\xd0\xe2\x10\x4c\xaa\xbf\xcd\x89\x9a\x9f\x7e\x8b\xed\x07\x37\x9b\xcf\x84\x13\x77" +
+	"\x7a\x2b\xd6\xaa\xcd\xd7\xfc\x69\x92\x66\x1c\x93\x09\x9a\x6b\xaf\x3d\xa5\x76\xd5\xfe\x71\xd7\xfa\xbf\x8b\x32\x38" +
+	"\xb9\xd3\x02\xca\x2e\x17\x81\xa9\x80\x97\x56\x17\xb7\xf4\xd7\x5e\xab\xeb\x4e\xc4\x13\xbd\xd1\x1c\x46\x10\x15\xb6" +
+	"\x6e\xb7\x5f\xe1\xda\x4d\x53\xa9\xcd\x65\xc7\xa9\xff\xd5\xd5\x1a\xbc\x34\x14\x2d\x08\xe0\xc7\xa8\xbe\x5d\xad\xd6" +
+	"\x6d\x75\xeb\xe3\xff\xfa\xcb\x62\xba\xe9\x5a\x9f\x77\x25\xca\x3a\x3f\x8c\x55\x98\xc4\xf7\x6c\xf4\xda\x64\x7c\x57" +
+	"\xe5\xcb\x1d\xcf\xf3\xb5\x4f\xf8\x9b\x3d\xc6\x7b\xdb\x9c\x63\xa0\xfe\xe7\x89\x94\x96\xf6\x2f\x22\x89\xde\xd5\xb4" +
+	"\x7c\xfa\x18\xc0\x7a\x63\x9a\x48\x42\xd4\x43\xe5\x9b\xba\x18\xd3\x8a\x09\xff\x04\xdd\x77\x09\xb5\x6f\x2e\x7a\x7c" +
+	"\x9a\x00\x40\x37\x7d\xe2\x3f\xa1\x34\x2b\x31\xe5\xe5\xd9\xfa\x65\x42\x77\x68\x98\x10\xdd\x22\x40\xda\xc1\xf1\x85" +
+	"\x81\xb1\x21\x28\x6a\x9d\xee\x21\x74\x4c\x55\xae\x79\xb4\x81\xa6\x6c\x6c\x6a\x7b\xcd\x4e\x8d\xe6\x32\x19\xde\x63" +
+	"\x44\xcd\xa6\x11\xda\x08\x45\x26\xa7\xa1\x99\x46\x23\x98\x51\xa3\x07\x07\xa5\x62\x92\xb3\xa1\x65\xbf\xf7\x5f\x26" +
+	"\x91\xa2\xce\x95\xbc\x86\x38\x3c\xa0\x58\x0c\x93\x64\x26\xd2\x1c\x9e\x9c\x48\xee\x9e\xe1\x1c\xb2\x33\x19\x99\x9a" +
+	"\x57\x82\x2c\x3f\x9f\xbd\x9d\x2a\xef\x81\x62\x96\x95\xc2\xc3\x9b\xba\x5a\xb7\xb4\x8c\x59\x51\x6c\x73\xa8\xd3\xb7" +
+	"\xe5\x82\xf0\x8d\x89\x72\xcc\xcc\x11\x7f\xa7\x28\x72\xe8\xb6\xac\xa0\x9d\x59\xb1\x72\x9f\x91\xef\x79\xa2\xe5\xb7" +
+	"\xee\xe5\xfa\x44\xfa\x2f\xde\x70\xf5\xb4\x28\x2c\x00\x00")
 
 func bindataTpl20entitygotplBytes() ([]byte, error) {
 	return bindataRead(
@@ -216,10 +273,10 @@ func bindataTpl20entitygotpl() (*asset, error) {
 
 	info := bindataFileInfo{
 		name: "_tpl/20_entity.go.tpl",
-		size: 5640,
+		size: 11304,
 		md5checksum: "",
 		mode: os.FileMode(420),
-		modTime: time.Unix(1547844064, 0),
+		modTime: time.Unix(1792348849, 0),
 	}
 
 	a := &asset{bytes: bytes, info: info}
@@ -228,32 +285,33 @@ func bindataTpl20entitygotpl() (*asset, error) {
 }
 
 var _bindataTpl30collectionmethodsgotpl = []byte(
-	"\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x95\x41\x8f\xa3\x38\x10\x85\xcf\xf0\x2b\xde\x61\xb5\x82\x6d\x42\xb6" +
-	"\xaf\x99\xc9\x61\xb6\x67\x66\x35\xe7\xde\x3d\x45\xd1\xca\x31\x45\x70\x62\xec\xac\x31\x1d\x05\xc4\x7f\x1f\xd9\x40" +
-	"\x42\x3a\x91\x46\xad\x48\x7d\x80\x2e\x5c\xf5\xea\x7b\x55\xce\x7c\x8e\xef\x42\x5a\x32\xff\x14\xa2\x42\xee\x1f\x2b" +
-	"\xd8\x82\xc0\x6b\x63\x48\x59\x54\x52\x70\xc2\xe6\x84\x83\xa1\x4c\x70\x66\x09\x39\x8e\xc2\x16\xba\xb6\x28\xa9\xd4" +
-	"\xe6\x04\x26\xa5\xe6\xcc\x0a\xad\xd2\x70\x3e\xc7\x97\xda\x6a\x6c\x49\x91\x61\x96\x32\xbc\x09\x86\xac\x94\x5b\x52" +
-	"\x69\x98\xd7\x8a\x23\xe2\x1c\x7f\xb4\x6d\xfa\xa2\xa5\x24\xee\x8e\x75\x5d\x3c\xe8\x88\x72\xb8\x6f\x22\x17\xff\xa6" +
-	"\xac\xb0\x27\x17\xdb\x68\x2d\xe3\x9b\x33\x68\xc3\x60\x83\xc5\x12\x9c\xa7\x5f\x99\x65\xab\xc5\x9f\xeb\x30\xc8\xb5" +
-	"\xc1\x7f\x09\xc8\x05\x0c\x53\x5b\x1a\xc3\xee\xf3\x40\xe4\xc8\x23\x8a\xfd\x73\xb0\xc1\x12\xec\x70\x20\x95\x45\x9b" +
-	"\x04\x14\x87\x41\xd0\x85\xee\x6f\x3c\xb1\xc4\x26\x0c\x0c\xd9\xda\x28\x70\x1e\x76\xa1\x6b\xef\x1b\xe3\x05\x8e\x42" +
-	"\x4a\x98\x5a\x79\xb5\x4e\x0e\x72\x68\xe5\x48\x40\x58\x2a\x2b\x08\x85\xd5\x7a\xda\xc5\x03\x68\x5c\xc5\xbb\x60\xee" +
-	"\x33\x71\x04\xc4\xfd\xf6\xf3\x68\x64\x25\xd6\xb1\x6f\xf5\xaa\xbb\xb6\x1d\xce\xb8\x9c\x75\xa9\xaa\xf4\x5f\x25\xfe" +
-	"\xaf\x69\x78\xc3\xac\xeb\xae\x74\xfe\xf6\x4e\xe8\xab\x36\xf6\xaf\x53\xdb\xfe\xad\x5f\x58\x49\x12\xe9\x77\x41\x32" +
-	"\xeb\xba\xc8\x03\xaf\xb4\xb1\xe9\xab\x9b\xa7\x51\x45\xe2\x5b\x12\x09\x76\x10\xca\xf6\x3e\x7b\x9d\x67\x55\xa3\xd8" +
-	"\xf4\x36\x29\x3e\x9f\xe3\xbb\x7b\xf1\x30\xe8\xe2\xb0\x43\xdb\xce\x40\xca\xbd\x3b\x03\x5e\x6a\x3b\x78\x47\xa5\x7e" +
-	"\xa3\xd1\x2c\xd8\xc2\xe8\x7a\x5b\x60\x37\x7b\x7e\xc0\xa8\x97\xda\x4e\xba\xb9\xe7\x4d\x33\x99\x57\xcc\xe7\xe0\xfa" +
-	"\x70\x1a\x76\xac\x20\x96\x91\x09\x03\xf7\xaf\xa8\x59\x89\xc5\x3a\x41\xb3\xda\x2d\x9c\x51\xce\xd2\x7d\x02\xe5\x4e" +
-	"\x4b\x52\x51\x13\xcf\x76\x4f\x22\x19\x9e\x3f\x61\x8f\xcf\x50\x9f\xb0\x7f\x7a\xf2\xf8\x9a\xd5\x7e\x8d\x25\x94\x90" +
-	"\xae\x86\x75\xbb\x5d\x15\xba\x96\x19\xd8\x9b\x16\x99\x5f\xf0\x61\x77\x25\xb1\xbd\x1f\x84\x06\x4b\x34\xab\xc5\x25" +
-	"\xfb\x7a\xba\x07\xcd\xed\x1e\xbc\x1e\xd9\xa1\x67\x59\x31\x2b\xaa\xfc\xe4\xd3\x7a\x93\x7f\x28\x4b\x26\x67\x9c\x1e" +
-	"\x60\xe9\xd2\x4f\x60\xb6\x93\x61\x48\x26\xc6\x63\x39\x79\x49\x26\x1f\xa1\x57\xf9\x95\x24\x59\xba\xf2\x9c\x29\x6f" +
-	"\x3b\x72\xa3\xcb\x5e\xb3\xe3\xff\x80\xd4\xbe\x46\x24\x3e\x68\xfb\xb9\xf4\xd9\x7a\x52\xd9\xc5\x61\xcc\xf0\xec\x2d" +
-	"\x18\x49\x90\xca\xe2\xf7\xe3\x21\x9e\x9e\xfd\x80\x34\x2b\x52\xd9\xc7\x3c\x1f\x0c\x77\xe7\x7e\xe1\xf4\x0f\x55\x91" +
-	"\x19\xf6\xe6\x20\x19\x27\x30\x28\x3a\xf6\x14\x99\xc5\x41\x57\xc2\xdf\x81\xe2\x01\x88\x7d\x91\x48\x61\x7a\xc3\x25" +
-	"\x78\x1c\x6a\x73\xb9\xe5\x9b\x04\xbf\x4f\xb2\xb7\xdd\x84\xa7\x03\xe9\x89\x0e\x3c\x85\xa7\xf9\x0b\x32\x5f\x7c\xde" +
-	"\x9e\x0c\xcb\xb2\x77\x5c\x9c\x16\x17\xd6\xf9\x8d\xfe\x07\x40\xf5\x35\x23\x85\x34\x4d\xaf\x7f\x26\xef\x41\xba\xc8" +
-	"\x1f\x18\x8c\x57\x2f\x54\x9a\xa6\xf1\x75\x4b\x3f\x03\x00\x00\xff\xff\xd6\x0d\x0f\x8b\x0f\x08\x00\x00")
+	"\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa5\x95\x4d\x6f\xda\x40\x10\x86\xcf\xf8\x57\xcc\xa1\xaa\xec\x62\x4c\x73" +
+	"\xa5\xe5\x90\xe6\xa3\xca\xa5\xaa\x94\xf6\x64\xa1\x6a\xb1\xc7\x61\x61\xbd\x4b\xd7\x6b\x22\x6c\xf9\xbf\x77\x76\x6d" +
+	"\x83\x49\xa8\xa2\x08\x09\x05\xe3\xd9\xf9\x7a\xde\x99\xcd\x74\x0a\xf7\x5c\x18\xd4\xbf\x56\xbc\x80\xcc\x3d\x16\x60" +
+	"\x56\x08\x49\xa9\x35\x4a\x03\x85\xe0\x09\xc2\x72\x0f\x5b\x8d\x29\x4f\x98\x41\xc8\xe0\x99\x9b\x95\x2a\x0d\xe4\x98" +
+	"\x2b\xbd\x07\x26\x84\x22\x0b\x57\x32\xf2\xa6\x53\xb8\x2e\x8d\x82\x27\x94\xa8\xe9\x74\x0a\x3b\xce\x20\xcd\x05\xbd" +
+	"\x88\xbc\xac\x94\x09\xf8\x49\x02\x9f\xea\x3a\xba\x51\x42\x60\x62\xdd\x9a\x26\xe8\xea\xf0\x33\xb0\x67\x7c\x6b\xbf" +
+	"\x93\x86\x9b\xbd\xb5\x2d\x95\x12\xc1\x2b\x1f\xa8\xbd\xd1\x12\x66\x73\x48\x92\xe8\x96\x19\x16\xcf\x3e\x2f\xbc\x51" +
+	"\xa6\x34\xfc\x09\x01\xad\x41\x33\xf9\x84\xbd\xd9\x1e\x1f\x71\x8a\xef\x63\xe0\x9e\xc9\x79\x0e\x6c\xbb\x45\x99\xfa" +
+	"\x4b\xf2\x08\xe8\x5d\xe3\xd9\x4f\xef\x31\x87\xa5\x37\xd2\x68\x4a\x2d\x29\x8a\xd7\x78\xb6\xbd\x3b\x96\xac\x88\x80" +
+	"\x10\xa0\x4b\xe9\xaa\xb5\xe5\x10\x15\xfa\x43\x24\x80\x1b\xcc\x0b\xe0\x12\xe2\xc5\xb0\x8b\x0b\xd0\xd8\x8c\x67\xc1" +
+	"\x9c\x67\x62\x09\xf0\xf3\xed\x67\x7e\xcf\x8a\x2f\x02\xd7\xea\x49\x77\x75\xdd\xf9\xd8\x98\x65\x2e\x8b\xe8\xb7\xe4" +
+	"\x7f\x4b\xec\x7e\xc1\xa4\x69\x4e\xea\xfc\xf0\xa2\xd0\x47\xa5\xcd\xb7\x7d\x5d\x7f\x57\x37\x2c\x47\x01\xd1\x3d\x47" +
+	"\x91\x36\x8d\xef\x80\x17\x64\x8d\x1e\xed\x3c\xf5\x55\x84\xae\x25\x1e\xc2\x9a\x78\x99\x56\x67\x57\xe7\xa1\xaa\xbe" +
+	"\xd8\xc8\x06\xfd\xa9\x79\xce\x0d\xdf\xe1\x8f\x92\x38\x47\xd4\xed\xd7\xc3\x91\xf5\x7f\x8e\x50\x93\x01\xb5\x46\x9d" +
+	"\x91\xcc\x4d\xab\xe0\x0d\x8d\x6e\x2b\x20\xcd\xef\x0e\x7b\xc5\x68\xee\xb5\x2a\x9f\x56\xb0\x9e\x5c\x5d\xa0\x16\x45" +
+	"\x1f\xb4\x74\x4e\xa0\x6a\x30\xb4\x40\x79\x12\xb5\xdd\x77\x8b\xb6\x42\x96\xa2\xa6\x09\xa4\x57\x7e\x15\xf3\xd9\x22" +
+	"\x84\x2a\x5e\xcf\xac\x5a\x56\xd7\x4d\x08\xd2\x7a\x0b\x94\x7e\x15\x4c\xd6\x63\xca\xd4\x3e\x7f\x81\x0d\xe1\x90\xf4" +
+	"\x35\x1e\x3b\x86\x55\xbc\x59\xd0\x04\x4b\x2e\x6c\x0e\x63\x17\xbc\xa0\xad\x15\x29\xb0\x9d\xe2\xa9\xdb\xf2\x6e\x81" +
+	"\x05\xb2\x8d\x9b\x86\x8a\x1c\xaa\x78\x76\x8c\xbe\x18\x2e\x43\xf5\x7a\x19\x1e\x9f\xd9\xb6\x65\x59\xd0\x05\x50\x64" +
+	"\x7b\x17\xd6\x29\xfd\x20\x69\x9f\x33\x96\xe0\x05\x2c\x6d\xf8\x01\xcc\x7a\x30\x11\xe1\x40\x7a\x98\x0f\x7e\x84\x83" +
+	"\x43\xd0\x56\x79\x8b\x02\xe9\xe2\x1a\x6a\xce\xa4\x93\x1d\x32\xad\xf2\xb6\x66\xcb\xff\x82\x52\xdb\x1c\x3e\x7f\xa7" +
+	"\xec\x87\xd4\x07\xe9\x69\x4e\x8f\x0a\xc3\x04\xae\x9c\x04\x3d\x09\xb2\x06\x2f\xc7\x83\x8f\xaf\xdc\x80\x54\x31\x59" +
+	"\xdf\xa7\x79\x27\xb8\xf5\x7b\x43\xe9\x07\x59\xa0\xee\xf6\x66\x2b\x48\x56\x60\x20\xf1\xb9\xa5\xc8\x0c\x6c\x55\xc1" +
+	"\xdd\x45\xc8\x2f\x80\xd8\x26\xf1\x25\x0c\xaf\xb9\x10\x2e\x87\x5a\x1d\xaf\xfa\x2a\x84\x8f\x83\xe8\x75\x33\xe0\x69" +
+	"\x41\x3a\xa2\x1d\x4f\xee\x68\xbe\x41\xe6\xda\xc5\x6d\xc9\xb0\x34\x7d\xc1\xc5\xd6\x62\xcd\x2a\x7b\x55\xff\x05\xa0" +
+	"\xda\x9c\x04\x2a\x8a\xa2\xd3\xff\x95\xe7\x20\x1d\xcb\xef\x18\xf4\xf7\x2f\x48\xf2\x0f\x4e\x5b\xfa\x07\x70\x00\x43" +
+	"\x4f\x14\x08\x00\x00")
 
 func bindataTpl30collectionmethodsgotplBytes() ([]byte, error) {
 	return bindataRead(
@@ -272,10 +330,10 @@ func bindataTpl30collectionmethodsgotpl() (*asset, error) {
 
 	info := bindataFileInfo{
 		name: "_tpl/30_collection_methods.go.tpl",
-		size: 2063,
+		size: 2068,
 		md5checksum: "",
 		mode: os.FileMode(420),
-		modTime: time.Unix(1547575483, 0),
+		modTime: time.Unix(1792337754, 0),
 	}
 
 	a := &asset{bytes: bytes, info: info}
@@ -321,45 +379,245 @@ func bindataTpl40binarygotpl() (*asset, error) {
 	return a, nil
 }
 
+var _bindataTpl50servicegotpl = []byte(
+	"\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xdd\x58\x6b\x73\xdb\xb6\x12\xfd\x6c\xff\x0a\x44\x37\xf1\x50\xbe\x0a\xea" +
+	"\xdc\xdb\xe9\x07\xb5\xce\x4c\x62\x3b\x89\xa7\x8e\xe3\xf1\xa3\xf9\x90\xc9\x34\x30\x09\x49\xac\x29\x82\x01\x40\xcb" +
+	"\xae\xaa\xff\xde\xb3\x00\x49\x81\x7a\x38\x71\xd2\x69\x73\xef\x8c\x67\x64\xe2\xb1\x58\xec\x9e\x3d\xbb\x8b\xe9\xf4" +
+	"\x31\x7b\x58\x5c\x19\xd6\xdf\x65\x7c\x4f\x65\xe5\x38\x37\xfc\x44\xa7\x63\xa1\x6f\x7f\x96\xb7\x86\x3d\x9e\xcd\x36" +
+	"\xbf\xfb\x8e\xc5\x2a\x4f\x52\x9b\xaa\x9c\xc5\x23\x19\x63\xbd\x1d\x49\x36\x48\x33\x2b\x35\x13\x43\x91\xe6\xc6\xba" +
+	"\xa1\xd8\x8b\x60\x6a\xc0\xf6\x9f\x33\x2b\x2e\x33\x49\xdb\x3f\x4c\xa7\xfc\x9c\x3e\x8e\xc5\x58\xce\x66\x1f\x98\xc8" +
+	"\x13\x92\x79\x2d\xb5\x35\x2c\xb5\x2c\xcd\xad\x62\x82\xbd\x7d\x75\x70\x7a\x30\x3f\x8c\xb3\xb3\xb2\x28\x94\xb6\x32" +
+	"\x61\xaa\x90\x5a\x58\xa5\x0d\xc9\x13\x5a\xf6\xd9\x2e\x8b\x12\x39\x10\x65\x66\xbb\x3d\xf6\x60\xb7\xc7\x7e\xc2\x1f" +
+	"\x7e\x9e\xe2\x0f\x3f\x59\x7a\x25\x7b\x2c\x57\xb6\xfa\x2f\xcd\xfd\x97\xfb\x2d\xb3\xcc\xe9\x80\x01\x92\x47\xdf\x9c" +
+	"\x3d\x2b\xa1\xc4\x50\xe6\x74\x90\x4c\xf8\xe6\xa0\xcc\x63\x16\x0d\xd8\x36\xb4\x3f\xc8\x6d\x6a\x6f\x67\xb3\xa3\xd4" +
+	"\xd8\x17\xee\xde\xdd\xb9\x9e\x91\xbd\xcc\xd8\x76\x92\x64\xfe\x92\x5d\x16\x6d\x27\xe3\x0c\xf6\xac\xe6\x7b\x4c\x6a" +
+	"\xad\xb0\x63\xba\xb9\x91\x0e\xd8\x03\x2c\x6f\x8c\x8d\x45\x96\xec\x17\x0d\xaa\x21\xb7\x6a\x43\x4b\x5b\xea\x9c\xe5" +
+	"\x69\x56\x6d\x36\xfc\x58\xd9\x67\x59\xa6\x26\x50\xed\x58\x4e\x06\x51\xe7\x1d\xf4\x3a\x11\xf1\x95\x18\xc2\xa6\xef" +
+	"\xd9\x4a\x2d\x99\x17\xca\x1e\x7d\x74\x97\x17\x5e\x40\xa7\xc7\x9a\xe3\x36\x37\x66\x9b\x1b\x31\x01\xc0\xeb\x4c\x83" +
+	"\x51\x30\x6b\x26\xa9\x8d\x47\xb0\x3f\x2d\x31\x56\xa7\xf9\xd0\xf0\x73\x75\x04\x39\x1a\xeb\xde\x54\x7e\xe9\xfe\x48" +
+	"\x4b\xa0\x7a\x2c\x8c\x64\x1d\xb2\x68\xa7\x3f\xbf\x48\xcc\x8f\x31\x12\xc1\x53\xb8\x52\xb3\x08\x1a\xad\x58\xa8\xec" +
+	"\x8a\xb5\x69\x0e\xa5\x3b\xde\x81\x6e\x3d\x0c\x99\x49\xd2\xf4\x17\x91\x95\xd2\x74\xd9\xee\x2e\xdb\x71\xb6\x5b\x63" +
+	"\x3c\xac\x4b\xbf\xd0\x74\x5a\x7e\x2c\x53\x2d\x0d\x13\x40\x93\x14\x00\xbb\xca\x25\xbb\xa6\x83\xdb\xa6\x24\x5b\x92" +
+	"\x66\x30\x05\xd4\x21\xa5\x5b\x1a\xc5\xfc\x30\x8f\xba\xfc\xcc\x6a\xd3\x28\xce\x39\xaf\x6f\xea\x76\x87\x76\xb8\x73" +
+	"\xb5\xb7\x0b\x59\x65\xd7\x19\x24\x46\x44\xc4\xfc\xe0\x63\x29\x60\xbb\x7a\xfa\x41\x38\x07\x89\x0b\xd3\x3f\x05\xb3" +
+	"\x47\xd2\x98\x60\x66\x77\x61\xea\x8d\x5e\xd8\xfb\x34\x58\xf0\x52\x4b\xc4\x8c\x0e\x26\x77\x97\x67\x97\x24\x50\x60" +
+	"\x86\xa7\xe0\x73\x3e\x59\x47\x6e\x5b\xff\x7a\x4d\x15\xf9\xfd\xb5\xa1\xd2\x30\xc7\x7d\x3c\x5e\x83\xb9\x0e\x17\x53" +
+	"\x0b\x71\x5e\x6e\xa0\xee\x42\x66\x09\x7f\x0f\x76\xd9\x93\x3b\x62\xf7\xaf\x80\x9f\xbc\x11\xb1\xcd\x6e\xd7\xa1\x0f" +
+	"\x5a\x35\xe8\x01\x6a\x1a\xdd\xde\xed\xbc\xaf\x40\x33\xdb\x24\xba\x0b\xce\x3b\x93\x1a\x24\xcc\xd2\x71\x91\xc9\xb1" +
+	"\xcc\xad\x67\xf6\xbd\xd3\x8b\x7d\x66\x30\x95\xc6\xa0\x79\xd8\xa3\x26\xf3\x25\x26\x77\x6c\x6c\x18\xdc\x91\xe6\x60" +
+	"\xe9\x34\x77\xfb\x0b\xad\xac\x42\x2e\x60\x97\xe5\x60\x00\xf1\xb5\xa8\x85\x83\x31\xc4\xd9\x39\xd6\xd3\x05\xa5\x71" +
+	"\x54\x4c\xac\x8c\xab\x16\x2a\x07\x08\xec\x6d\x81\x5b\x0f\xa5\x9d\xd3\x32\x1b\x68\x35\x5e\x79\xc8\x18\x20\x85\x41" +
+	"\x0d\x67\x87\x96\xc5\x22\x27\x69\x97\x24\x7b\x08\x9b\x4a\x8d\xad\x60\xb2\x11\xb2\xcc\xf0\xf4\x64\xcf\xa9\x84\x4d" +
+	"\xb8\xdb\x44\x8b\xa2\xc0\xec\xe5\x2d\xe6\xce\x27\xa9\x2e\xaa\x49\xaf\x5b\x8e\x8b\x92\xa8\x24\xa5\x53\xcc\xfc\xfc" +
+	"\xfa\x52\x97\x32\x16\x25\x29\x8b\x31\xbf\xbd\xc8\xca\x21\x2c\x51\xeb\xec\x6c\xba\xc2\xee\xb4\x19\x89\x4f\xea\x81" +
+	"\x20\x43\x2c\xa6\x1f\xba\xfc\x0a\x57\x81\x82\xcb\xd8\x12\xd0\x9c\x1f\x4c\x90\x78\xcc\xe6\x06\x4e\x79\x2d\x6e\x4e" +
+	"\x60\x87\xb3\xf4\x77\x89\x00\x1a\xa7\x95\x4f\xb5\x9a\x18\xe6\xe1\xe1\x2f\x4b\x50\xe3\x6c\xdf\x07\x12\xd6\x28\xf6" +
+	"\x64\x67\x87\x6f\x6e\x84\xfb\x4b\xe8\xf7\xdf\xff\x10\x6c\x7c\x32\x34\xad\x64\xe8\x35\xea\xc2\x13\x75\xc1\x10\xc9" +
+	"\xd6\x82\x6e\xeb\x6b\x5e\x57\x90\xf6\x15\x52\xb7\x56\x2e\x98\xe2\xe2\x8f\x99\x16\xf9\x50\xba\xfa\x64\x46\xcc\x38" +
+	"\x9d\xbe\x54\x7b\xf0\x46\xc6\xf8\x8b\x54\x66\xc9\x6c\xd6\x67\x92\x37\xa3\xaf\xc5\xed\xa5\x84\x88\x6b\x98\xaf\x59" +
+	"\xd1\xdb\xdc\x20\x49\x32\x4f\x48\xc4\xec\x53\x37\x91\xee\x3b\x2a\xae\xd6\x28\xde\xbe\xd0\x9a\x6b\xdc\xad\xfc\x4a" +
+	"\x35\xfb\xac\xb8\xe2\xcb\xf7\x5b\xa1\x3d\x1c\xfc\x12\xf1\x90\x29\x91\x20\x1d\x31\x83\x74\x9c\x39\xe7\x92\x4b\xc9" +
+	"\xd7\x95\x33\xd8\x95\xbc\xe5\xec\xd4\x69\x47\x0b\xc1\x40\x2f\x54\x89\xe0\x72\x8c\xc4\xc0\x5e\x15\x28\x69\x6b\xa2" +
+	"\x80\x23\x22\x3c\x79\xe3\x40\xb1\xba\x12\x5a\x6d\x32\x68\x13\xc5\xf6\x86\xaa\x21\x2b\x6f\xac\x2b\x68\xf0\xdb\x63" +
+	"\x77\x18\x31\x0a\x27\xc2\xea\x08\x95\x91\xfb\x74\xb5\x46\x05\x6a\xff\x13\x35\xac\x13\xe2\xcb\xd1\x30\xad\x07\xf9" +
+	"\x82\xdf\xd6\xd1\xef\x5b\x04\xfe\x99\x05\xe1\x46\x18\xf0\x44\x29\xfd\x11\x8d\xc3\x31\x0a\x4b\xec\xc1\x44\xb6\xd1" +
+	"\x80\xea\xb4\x33\x99\xc9\xd8\x3e\xbf\x3d\xf9\x19\xa9\x98\xe4\x3c\xd3\x43\xa4\x49\x7e\x2a\x63\xa5\x93\x88\xf2\xaf" +
+	"\xec\xf2\x23\xb8\x83\xac\x40\x1f\x5f\xae\x13\xf6\xd5\x3a\xcc\xab\x99\xd5\xd9\xc4\xf9\xf2\x93\xd9\xc4\xfb\x88\xc3" +
+	"\x45\x7d\x76\x0a\x3f\x3f\xfa\xd7\xb5\x73\xf3\x80\x76\x77\xc8\x45\xad\xac\x21\xc3\x24\x41\xf4\xd0\xc0\xac\x80\x6c" +
+	"\x2a\xea\x1d\x89\xe0\xde\x8e\x4e\x01\x38\xcf\xc3\x0d\xe0\x6a\xf6\x46\x95\x18\xdb\x66\x05\x49\xf3\xbd\x02\xb8\x19" +
+	"\xd4\x92\xe6\xc2\xb5\x12\x20\x4d\xb3\x24\x41\x39\x60\xb2\x8c\x8a\x2c\x82\x66\x84\x41\x23\x5d\x6a\x28\x9a\xad\xc8" +
+	"\x66\x23\x99\xc7\x9e\x73\x9d\x6e\x56\x5d\xc9\x1c\xdc\x28\xd0\xb3\x5c\x53\xa6\x65\x93\x51\x9a\x55\xac\x47\x09\x04" +
+	"\x25\xb6\xf4\x8d\x84\x76\x6c\x0e\xb7\x12\xb4\xef\x05\x75\x32\xc9\x6a\xac\x23\x81\x2d\xb5\x09\xa7\x3e\xa9\x2d\xa0" +
+	"\xdd\xcf\xf8\xfc\xf6\xcf\x20\x7f\x1c\xf0\xbb\x3b\x2c\x20\x7c\x27\x35\x5c\xd0\xc0\xb0\x35\x48\x99\xc2\xc9\x2a\x02" +
+	"\x41\xb0\x01\x6f\xc9\x29\x5a\x42\xfe\xf8\x63\x3e\xf0\xb4\x75\x06\x89\x9f\xaf\x0d\xa7\xe8\x0c\x74\x20\x60\xc4\x56" +
+	"\x34\x46\x9d\xed\x4e\x97\xbf\x21\x18\x3e\xbf\x8d\xa6\xd3\x8a\x6a\xd3\x1e\x7b\xe8\x1a\x19\x4f\xba\xd3\x29\x74\x78" +
+	"\x98\x12\xc1\x4c\xa7\x8e\x42\x3b\x30\x60\xc5\xac\x9d\x6a\x08\xa1\x4b\x29\x32\xda\xe9\xb9\x5c\xf7\xc3\xf7\x51\xad" +
+	"\x49\xf7\xdf\x4f\x60\x2f\x2a\x7e\x7e\x45\x91\xe5\x2e\xe8\x8e\xa1\x6b\xfa\x0a\xcd\x38\xcd\xe3\xc6\x6d\x03\xde\xea" +
+	"\x08\xbb\xbe\x15\x58\xf0\xd1\xe7\x38\xc9\xf5\x01\xb8\x35\x7f\x3b\x42\xa0\x45\x71\xd7\xdb\xe1\x5a\x68\x1f\x17\x21" +
+	"\x9e\x3c\x6b\x54\xa6\x3f\x77\x51\x80\xd3\x3a\xbe\xef\x48\x84\x15\x8d\x7a\x97\x28\xab\x7f\xf8\x9e\x9f\x8a\xc9\xc5" +
+	"\xe9\xd1\x41\x1e\xab\x04\xb9\x83\xef\x83\xc7\x12\x79\xe6\xfa\xba\xa8\x25\xe7\xf3\xf5\x7f\x2e\x92\x46\x1e\x28\x89" +
+	"\x6e\x81\xae\xe4\x93\xb4\x44\xa1\xd0\x67\x87\xb9\x8f\xd7\x20\x8c\x1f\x7d\xec\xf4\xd8\x92\x2e\x64\x14\x32\x01\xf2" +
+	"\xca\xca\xb4\x32\x57\x17\x97\xfd\xcd\xa8\x9c\x5f\xe4\x98\x32\x23\xb4\x1c\xde\x10\x5b\xe0\xbb\x1f\xbf\xad\x0b\xcd" +
+	"\xbd\x4c\xaa\x50\x07\x7e\x22\x34\x92\xd2\x48\x9a\xd4\xa0\xe1\x40\x03\xd8\xa3\x99\xa0\xa2\x58\x80\xf9\xda\x7d\x0d" +
+	"\xfe\x11\x29\xf4\xe5\xf0\xbe\x28\xec\x37\x08\x2b\x16\x62\x26\xb3\x18\x77\x3b\x6b\xd9\xd5\xab\x00\x82\xe6\x61\xd1" +
+	"\x44\x50\xb7\xee\x35\xf9\x49\x86\x02\xf6\x95\xca\x12\xea\x00\x9b\x13\xdc\x79\xd5\xb1\x2b\x04\xc5\x81\xa0\xa6\x7d" +
+	"\x5c\x21\x6a\xe1\x6e\x7b\x99\x32\x72\xf1\x94\xbb\xd7\x91\xad\x5d\xdc\x04\x09\x7f\xab\x4a\x7d\x9b\x1b\xc2\x91\x20" +
+	"\xb9\xa1\x49\xee\xbe\xb9\xa3\x1d\x01\x4e\x04\xb6\x8b\x30\xed\xd3\x82\xea\xf5\xc4\xb9\x03\x40\x01\x24\x70\x43\xa2" +
+	"\x28\x70\xc0\x6c\x56\x49\xfa\xb5\x09\x41\x11\x14\x0a\x71\xbc\x8c\xc5\xcf\x23\x70\xea\x04\x21\x6c\x6b\x4d\x56\x99" +
+	"\xee\x03\xec\x7d\xc8\xe7\xf4\xcf\xbc\x53\xad\x06\xba\x60\x5f\x30\xdd\x9c\xe7\xaa\x93\x8d\x9b\xa5\x3e\xdb\xaf\x7b" +
+	"\xd7\xaf\x57\xbc\x5f\x64\x12\x17\x5c\xaf\xab\xd0\x32\x3c\x28\xff\x6b\x31\xef\xea\xbd\x8f\x9f\xbc\xef\x7e\x25\x11" +
+	"\x92\xcc\x63\x64\xd9\x39\xc1\xad\xe3\x32\xf7\x0f\xd6\x54\x6c\x46\x3a\xb7\xea\x1b\x48\x0a\x2b\x9c\x3d\x87\xba\xaa" +
+	"\x32\xa0\x2a\x27\x97\x13\x57\x73\xf8\x2a\xc6\x57\xce\xa9\xa5\xfe\xd6\xa0\xed\x9f\xb7\xb7\x24\x98\x34\x40\xf5\x90" +
+	"\x33\x81\x02\x82\xa4\xa5\x79\xac\x5d\x0b\xdd\x2a\x67\x50\x7a\x40\xb2\x31\xe9\x90\xba\x2e\x94\x1a\x1f\xe4\x87\xfb" +
+	"\x15\x1d\x5e\xcb\xd5\x65\xc7\x62\xb7\xf5\x0d\xd4\xd5\x2d\xbc\x53\xca\x3e\x74\xe6\xbd\xab\x78\x3e\xb8\x91\x71\x75" +
+	"\x25\xba\xe6\x97\x87\x85\x5b\x65\x78\xd5\x91\xf4\x58\x0b\x9a\x12\x38\x84\xdf\x1d\xc3\xf1\x57\xc2\xec\x8d\x88\x01" +
+	"\xcf\x35\x64\x00\x2c\xf5\x43\xf7\x45\x91\x10\x26\x62\x35\x2e\x04\xc5\x19\xdc\xe5\x9f\x0d\x5c\xc7\xef\x51\x00\x88" +
+	"\xf4\xd8\x44\xa7\xd4\xd9\xab\x3c\xf3\x55\x70\xec\xc4\xd1\x8b\x72\x5e\x03\x80\xe4\x11\x06\xea\x47\xf1\x10\x56\x55" +
+	"\x47\x7e\x17\xb6\x0e\x07\x54\xab\x8f\x48\xb9\x91\x70\xcf\xde\xd5\x19\xf4\x8e\xcd\x2e\x4e\xf6\x9f\x9d\x1f\x50\xc5" +
+	"\x6b\x3d\xea\x1c\xd2\x24\x4c\x59\xde\xbb\xae\xf5\x97\xfe\x9f\x81\x58\x5c\xea\xe0\xa4\xf5\xde\xfe\xe2\x03\xb4\x74" +
+	"\x84\xbe\x2d\x19\x8c\x1e\xa0\x93\x4d\x54\x99\x25\x55\x38\xcf\xdb\x14\x4f\x20\xec\x70\xbf\x47\x4d\x47\x3c\x62\xa9" +
+	"\x61\xbf\x4b\xad\x7a\x14\xef\x92\x3b\x79\x5c\xe9\x94\xba\x97\x8c\x08\xb6\xd4\xcd\x27\x6e\xa3\x32\xe3\xcb\xe7\x98" +
+	"\x7b\x4c\x26\x2e\x31\x36\x79\x88\xa8\x1b\x6b\xba\x4b\x2d\xa1\xb3\x03\xdd\xac\xc1\x2d\xb5\xa9\x0e\xae\xaa\xb4\x0d" +
+	"\xec\xbc\xd2\x0e\xaf\xf4\xfe\x5f\x8f\xbb\x9b\x96\x45\x52\xc7\xe9\x5c\x82\x3b\x8e\x1e\x9a\x9b\xf3\xb1\x0c\xa5\xb7" +
+	"\xdd\xcb\xe8\xa1\xeb\x13\x9a\xb8\x88\x68\x75\x74\x3a\x78\xbd\xab\x95\x1a\x89\xeb\x26\x66\x96\x79\x83\xce\x5b\x4d" +
+	"\x17\x5b\x30\xd3\xdf\xcb\x18\xa8\x60\x32\x23\x17\xf8\xa1\x8a\x7e\x32\x67\x10\xf0\xad\x68\x47\x23\x4b\xe4\xb1\x3a" +
+	"\xe8\x49\xd4\xda\x9c\xf2\xff\x1b\xb9\x7f\x75\x60\x2d\x25\x9b\x00\xc4\xdd\x6f\x08\x41\x75\x99\x4a\x6e\xdf\x77\x2f" +
+	"\x0f\xc0\xc4\x58\x5d\xcb\xcf\x78\xc2\xbb\x0f\x1c\xbc\xec\xaf\x7b\x8e\xf3\x32\xfe\xd9\x27\x0a\x57\xa8\x05\x6e\xf5" +
+	"\x3a\xdd\xe9\xd6\xf0\x29\x6f\xd9\xbd\x5f\xa1\x8a\x9a\x98\x67\x83\x81\xa4\xf7\xac\x46\x27\x2a\x49\x4f\x83\x89\xe8" +
+	"\xab\xee\xba\xf4\x80\xdc\x76\xc1\x34\x3c\xa9\x5f\x3f\x53\x84\x7a\x75\x67\x75\x59\xfb\x27\x74\xa2\xd3\xef\xd0\x1f" +
+	"\x00\x00")
+
+func bindataTpl50servicegotplBytes() ([]byte, error) {
+	return bindataRead(
+		_bindataTpl50servicegotpl,
+		"_tpl/50_service.go.tpl",
+	)
+}
+
+
+
+func bindataTpl50servicegotpl() (*asset, error) {
+	bytes, err := bindataTpl50servicegotplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{
+		name: "_tpl/50_service.go.tpl",
+		size: 8144,
+		md5checksum: "",
+		mode: os.FileMode(420),
+		modTime: time.Unix(1792348742, 0),
+	}
+
+	a := &asset{bytes: bytes, info: info}
+
+	return a, nil
+}
+
+var _bindataTpl60relationsgotpl = []byte(
+	"\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa5\x55\x6d\x6f\xda\x48\x10\xfe\x8c\x7f\xc5\x24\x3a\x9d\xec\x88\x6e\xe9" +
+	"\xd7\x54\x54\x4a\x5b\xaa\x46\x97\xa6\x55\xc2\x35\x1f\x10\xba\x6c\xec\x01\x56\x2c\x6b\x6e\x77\x69\x40\x96\xff\xfb" +
+	"\xcd\xec\x1a\xe3\x42\x74\x95\xee\xbe\x24\xde\x79\x79\xe6\xe5\x99\x19\xaa\xea\x15\x58\x69\xe6\x08\xe2\x0e\xb5\xf4" +
+	"\xaa\x34\xae\xae\x93\xd7\xaf\xe1\xa6\x94\x45\x55\x89\x5b\xb9\xc2\xba\x06\x4d\x2f\x07\x7e\x81\x40\xb2\x3f\x94\x29" +
+	"\x48\x66\x1b\x07\x38\x98\xcd\x6c\xb9\x82\x8f\xef\xc1\xcb\x27\x8d\x8c\xf2\x48\xba\xb1\xb4\x73\xf4\x63\x16\xd5\xf5" +
+	"\x23\xfc\x50\x12\xf2\x52\x6f\x56\x26\x68\x3f\x84\x4f\x52\x08\xb8\xda\xf8\x12\xe6\x68\xd0\x4a\x8f\x85\x48\x66\x1b" +
+	"\x93\x43\x8a\x70\x51\x55\xbf\x89\x91\xf1\xca\xef\xea\x3a\xfb\x39\xb3\x34\xf7\x5b\x82\x33\x1e\xb7\x9e\xb0\xc2\xff" +
+	"\x3e\xf8\x27\xed\xe0\xa2\x28\xb4\x08\x61\x5d\x06\x68\x6d\x69\xa1\x4a\x7a\x16\xfd\xc6\x1a\x48\x7f\x67\x50\x0a\xae" +
+	"\x31\xe7\x22\xea\xba\xfa\x28\xbd\xbc\x84\xc9\xf4\xa7\x70\x15\xc5\xc8\xc4\x49\xc8\x18\x22\x4b\xea\xe4\x3f\xf7\xaa" +
+	"\x9c\x81\xd4\x1a\x90\x03\x29\x74\xf0\xac\xfc\x82\xc1\xd8\xf1\xef\x0d\xda\x1d\x3c\xde\x8f\x6e\x46\x1f\xc6\x70\x01" +
+	"\x9f\xee\xbe\x7e\x81\xe3\x56\xc2\xc3\xe7\xd1\xdd\xe8\x20\xde\x77\x12\xae\x6f\x21\x15\x42\x64\x8f\x82\xf1\xc6\x2d" +
+	"\x9e\xdd\x18\x07\xa5\xc9\x11\xd6\x68\xe1\xcd\x60\x30\x80\x42\x39\xaf\x4c\xee\x61\x89\x3b\xca\xb9\x04\xad\x56\xca" +
+	"\x87\x1c\xcc\x66\xf5\x44\x66\x94\xe6\x5a\xcb\x3c\xb0\xb9\x28\x75\x81\xd6\x09\x18\xb5\x49\x2f\x4a\x87\x2f\xd2\x09" +
+	"\xca\xc1\xed\x9f\x37\x37\x44\xa8\x07\xb7\x54\xeb\x35\x51\x1a\x28\x66\xa4\x13\x96\xf3\x3c\xd2\xdc\x65\xe4\xff\x52" +
+	"\xad\x8a\x2d\x5c\x0e\x61\x25\x97\x98\xae\xe4\x7a\xc2\x74\xe0\x6e\xbc\x5b\x13\xd8\xf4\x88\xe7\x3e\x68\x34\x94\x85" +
+	"\xe0\x29\xc8\xb2\xa4\x47\x2d\x75\xad\xf7\x64\xda\xf5\xed\xc3\xe0\xc4\x7c\x46\x21\xff\xea\x03\xb2\x4b\x5c\xa8\x46" +
+	"\xc9\x89\xf4\x2a\x5a\x33\x35\x03\x71\xed\xbe\x48\x43\xd1\x48\x84\xe2\x30\x0a\x43\xb8\xc5\xe7\x2e\x8f\x6d\x0b\xd2" +
+	"\xac\xf1\x46\xed\xf0\x05\x3f\xa3\xf4\xde\x80\xe7\xac\x13\x8a\xb2\xfd\x2e\xb5\x8a\x42\x12\x9c\xc5\x0a\x1a\x59\xc8" +
+	"\xaa\xc7\xcd\x54\x66\x83\xf4\x5d\x1f\xc1\x2c\xb9\x90\xe8\xb2\x47\xa0\xf2\xca\x20\xa6\xbe\x4e\x96\xd3\xb7\x70\x46" +
+	"\xcf\x80\x13\x7a\x35\x04\x49\x1c\x9b\x22\xe5\x57\x1f\x96\x59\x83\x1a\xad\x0f\xea\xf8\xa6\x56\x91\x01\xe9\x09\x98" +
+	"\x5b\xc9\x4e\x19\x0c\x87\x30\x08\x90\xcd\x96\x86\xf2\x68\xc7\x7a\xc4\x72\x9f\x89\xe5\xf0\xcc\x78\x24\x3b\x0d\x7f" +
+	"\xb9\x19\x6d\xf3\xda\x33\x11\x90\xd9\xe3\x2c\x74\xa9\x8b\x1a\x06\xc4\x89\x07\x5a\xb8\x7b\x2f\xf3\x65\x4a\x82\x98" +
+	"\x0c\x35\xc4\x79\xa2\x7c\x7b\x15\x2b\xe2\x1d\x49\x7a\x0e\x75\x13\x57\xdc\x23\x53\x93\x9e\x5f\x9c\x67\xe2\x61\x81" +
+	"\x16\x53\x82\x2d\x56\xba\x99\xfb\xf4\xfc\x64\x1b\xc9\xf0\xda\xa4\x99\xf8\xc6\x4b\xf4\x39\x2c\x50\x9a\xf5\x93\x5e" +
+	"\x16\xe2\x73\x1c\x52\x8e\xb6\x6b\x69\x8a\x8e\x89\x63\xe2\x7f\x48\x0b\xf9\x42\xe9\xc2\xa2\x89\x77\xe9\xa8\xc8\x38" +
+	"\x76\x87\xf6\xbd\x6b\xba\x67\x38\xdd\x56\x1c\xd9\x33\xa4\xdd\x17\x16\x48\x33\x30\xdc\x0b\x1a\xa6\x68\x2f\xaf\x78" +
+	"\x97\x9b\xe9\x83\xc2\x96\x6b\x07\xca\x3b\x28\x78\x90\x9f\x17\x94\x06\x1f\x37\x65\xe6\x20\xe7\x52\x99\x3e\x90\x88" +
+	"\xce\x89\x04\x83\xcf\x74\x59\x30\x82\xf0\x75\xc9\x17\x1b\xb3\x14\xf4\x0e\x1f\x9c\xcf\xbf\x8e\x78\x1c\xaf\x86\x60" +
+	"\xea\x37\xfd\x1a\x39\xf4\xd4\x19\x72\xe1\x0c\x3f\xd1\x91\x20\x53\xae\x67\x72\x69\xa6\x7c\xdd\xc2\x51\x8e\xb7\x38" +
+	"\xc4\xc8\xde\x1e\xd3\xfd\x0b\xbe\x43\xcd\x6d\x7f\xdb\xf9\xdc\x4b\x1a\xd8\xb0\xc4\x1c\x2f\x39\xcc\x39\x67\x61\x2e" +
+	"\xa7\x61\x64\x9a\xc5\xcf\x3b\x8b\xbf\x87\xec\x6e\x7e\xac\xfb\xa5\xa5\x3c\xd6\xfc\x72\x35\x4f\x4f\x0d\xaf\x54\x17" +
+	"\x89\xce\x5b\x44\x79\xe1\xee\x74\x0f\x48\x3c\x50\x6d\xe5\xc7\x1a\xaa\x2a\xdb\xa3\xb4\xf7\xe7\xe8\x00\xe5\xad\x41" +
+	"\x93\x5d\x1d\xba\xd2\xd9\xdf\x3a\xa9\xaa\xa8\xfc\x07\x42\x50\x8f\xc3\x6d\x08\x00\x00")
+
+func bindataTpl60relationsgotplBytes() ([]byte, error) {
+	return bindataRead(
+		_bindataTpl60relationsgotpl,
+		"_tpl/60_relations.go.tpl",
+	)
+}
+
+
+
+func bindataTpl60relationsgotpl() (*asset, error) {
+	bytes, err := bindataTpl60relationsgotplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{
+		name: "_tpl/60_relations.go.tpl",
+		size: 2157,
+		md5checksum: "",
+		mode: os.FileMode(420),
+		modTime: time.Unix(1792349043, 0),
+	}
+
+	a := &asset{bytes: bytes, info: info}
+
+	return a, nil
+}
+
 var _bindataTpl90testgotpl = []byte(
-	"\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x56\xdd\x8e\xdb\x36\x13\xbd\xa6\x9e\x62\x3e\xe1\x4b\x20\x25\x0a\x37" +
-	"\x01\x8a\x00\x75\x9a\x8b\xac\xb5\x9b\x1a\xf5\xfe\x34\x5e\xa4\x05\x82\x20\xa0\xc5\xb1\x4d\x84\x26\x05\x72\xb4\xf6" +
-	"\x42\xd0\xbb\x17\xa4\xe4\x9f\x24\xbb\x41\x5a\xf4\xa2\x77\x36\x39\x73\x38\x73\xce\xe1\x50\x8b\xc6\x54\x70\x83\x9e" +
-	"\x2e\x71\x73\x23\xe6\x1a\x7d\x46\xf0\x84\xd0\x93\x32\x4b\x7e\x93\x43\x9b\x30\x39\x87\xd1\x6b\x90\x6b\x1d\x96\xf9" +
-	"\x45\xe3\x69\x6c\x8d\xc1\x8a\xca\xd3\x8c\xf2\x84\x49\x5c\xa0\xdb\xef\x8f\xb5\xf5\x98\x51\x01\x72\x9e\x27\x09\x6b" +
-	"\xdb\x8d\xa2\x15\xf0\x70\xc6\xec\xf7\x69\xd9\xac\xeb\xb7\xda\xce\xaf\x05\xad\xba\xee\xcb\xcc\x61\x7b\x6a\x85\x0c" +
-	"\xf9\x69\xdb\xf2\xae\x4b\x0b\x78\xfc\x55\xc0\x55\x4d\xca\x1a\xdf\x26\x8c\xcd\x3e\xab\xba\x3c\x1d\x6b\x14\xa6\xa9" +
-	"\x47\x40\xae\xc1\x22\x61\x5d\x9e\xe5\x6d\x8b\x46\x76\x5d\x92\xb0\x8a\xb6\xa1\xfe\xca\x1a\xc2\x2d\xf1\x9b\xab\xf2" +
-	"\x2a\xcb\x13\x46\x73\xed\x0b\x40\xe7\xc2\xe6\xa1\xfb\x8a\xb6\xa1\x74\x5e\x9e\xe6\x09\x13\xde\xa3\x23\x7e\x69\xcf" +
-	"\x9c\xb3\x2e\x14\x85\xce\x85\xae\x68\xae\x2f\xc5\x1a\x7d\xc8\x0d\x40\x7c\xc8\xce\x13\xe6\xad\x23\x3e\x23\xa7\xcc" +
-	"\xd2\x67\xbb\xb8\x03\xd6\xd9\x56\x54\xa4\xef\x02\xd6\x87\x8f\x3e\x86\xb5\xd0\xb6\xcf\xc0\x09\xb3\x44\xf8\x3f\x05" +
-	"\xa0\x00\x3b\x40\x42\xd7\xa5\x6d\x3b\xfc\x0b\x58\x81\x92\x10\x1f\xdb\xeb\x0a\x38\x1c\x91\xb0\xd0\xcd\x50\xd0\x7b" +
-	"\xa1\x95\x14\x84\xa1\xa1\x87\x3b\x61\xb7\xc2\x41\xed\xe1\x49\xed\xb1\x91\x96\xcf\xd0\xdd\xaa\x0a\x13\x56\x7b\x78" +
-	"\x0d\xc3\x62\x50\xfc\x12\x37\xc3\x5e\xf6\xbc\x80\xc7\xc3\xce\x4e\x89\xa9\x30\xcb\x11\xa4\x12\xd3\xe2\x5c\x5b\x41" +
-	"\x17\x62\x5b\x62\xa5\xd6\x42\xfb\xd1\xcb\xae\x48\x18\x1b\x12\xfe\x50\xb4\xba\x11\xcb\x73\xf1\x19\xcf\x1b\x53\x65" +
-	"\xe9\x06\xe7\x5e\x11\x7e\x52\x32\x2d\x20\x98\x31\x5b\x8b\xed\x14\x0d\x28\x43\x39\x64\xca\x10\xba\x85\xa8\xb0\xed" +
-	"\x62\xc5\xd6\x45\x47\x32\xe6\x90\x1a\x67\xe0\x45\x01\x46\xe9\x84\xb1\x2e\xff\xde\x29\x9e\xac\xfb\xb7\xce\x08\xdc" +
-	"\x8f\x1b\x4f\x76\x3d\xb6\x12\x21\xbd\x97\xa4\x81\x99\x14\x9e\x75\x5d\xc2\x82\x34\x27\x27\x10\x9c\x07\xae\x31\x40" +
-	"\x2b\xeb\x11\x82\xa5\x3d\x28\x03\xb5\x70\x42\x6b\xd4\x09\xfb\xbe\x0f\x12\x46\xfc\x5d\x63\xb2\xb4\x6d\xdf\xda\xb1" +
-	"\x58\xa3\xfe\xc2\x16\x9f\xce\x0c\x29\xba\xdb\xf5\xf8\xcd\x35\x66\x55\x25\xf7\x7e\x0d\xe5\xc6\xdc\x6c\x8f\x70\x3f" +
-	"\x6a\xa8\x9d\x29\x33\xa3\x35\xed\x6f\x4b\x55\x49\x3e\x31\xc1\x50\x59\xce\x4f\x1b\xa5\xe5\x7b\xa1\x9b\x60\x7f\x7e" +
-	"\xed\xb0\x16\xae\x77\x1d\x9c\x9c\x40\x69\xc1\x58\x82\xc6\x23\x4c\x96\xc6\x3a\xcc\x72\x20\x0b\xbe\xa9\x6b\x87\xde" +
-	"\x43\x79\xda\x53\xee\x79\xc2\xee\x37\x69\x01\xe9\xa3\xa7\xb7\xe9\xce\xb0\x4c\x19\xff\xc6\x91\xf2\xc2\x84\x52\xfa" +
-	"\xca\xa2\xe2\x6f\xdc\x32\x5e\xc0\x87\xe6\x51\x1f\x1a\xfb\xf1\xa8\x8f\x30\x42\x3b\x33\xd4\x58\xd1\xe9\xdd\xf5\x6f" +
-	"\x59\x7e\x84\xc6\xcf\xb6\xb5\x30\xf2\x5a\x8b\x0a\x7f\xb5\x5a\xa2\x0b\x47\x24\x8c\x2d\xac\x03\x15\x92\x9f\xbf\x02" +
-	"\x05\xbf\xc0\xcf\xaf\x40\x3d\x7d\xda\xfb\x06\xa3\x0e\x93\x88\x6d\x70\x93\x3d\x44\x2c\x63\x4c\x2d\x76\x9c\xd6\x9e" +
-	"\x07\xbf\x96\x82\x44\xb6\x03\xc8\x5f\xc5\xdd\xff\xbd\x0e\x06\xec\xb1\x19\xf1\x48\xce\x22\x4b\x27\xe5\x9f\x1f\x1e" +
-	"\xc9\x8f\x23\xe8\xe9\x51\x7b\x86\x76\xce\x0d\x3f\xc3\x00\x64\x4c\x4f\xca\xe3\x11\x3e\x5e\x61\xf5\x79\x2a\x3c\xf5" +
-	"\x22\x4e\xca\x38\x6e\x23\xee\xe8\xcb\xc7\x80\x7f\xdf\x6a\x79\x76\x50\x83\xbf\xc3\xca\x3a\x99\xa5\x41\xaa\x5d\x03" +
-	"\xfc\x6c\x8b\xd5\xb8\x9f\xbc\xd1\x13\x7d\xd7\xc7\x49\x1e\xa9\xa7\x74\xe0\xed\xaa\xa1\x1f\x20\xce\xd9\xcd\xd8\x36" +
-	"\xe6\xe0\xc9\x83\xa4\x7c\x62\xe8\xe5\x4f\x3e\xd3\x93\x32\xe7\xf1\x31\x89\x33\x7d\x8f\x1e\xf3\x7f\xc8\x6a\xf7\xcc" +
-	"\xec\x46\x05\xf0\xec\x45\x5e\xc0\xa1\x84\x20\xc5\x23\x39\x82\x77\xc3\x0a\x48\x25\xa3\xed\xd7\x82\xaa\x55\xd0\xa6" +
-	"\x6f\xf0\xe8\x7a\x57\x56\x87\xaa\xfb\x6b\xce\xc7\x56\x37\x6b\xd3\xdf\xf1\x18\xa7\x16\x31\x84\x4f\x7c\xff\x92\xf4" +
-	"\x73\x84\x7d\x53\xd2\x14\xcd\x92\x56\xa1\xb0\xb6\x8d\x09\xe3\x95\x70\x17\x71\xba\x2d\x69\xd5\x33\x11\x1e\x89\xc7" +
-	"\x3b\x45\x78\xdb\x0e\x67\x0e\xd4\x5e\x88\xbb\x39\x5e\x3b\x75\x2b\xa8\x2f\x8b\x9f\x2b\xd4\xf2\x28\xe9\xaa\xa1\xbf" +
-	"\x93\xb5\x23\xe3\x87\x53\xc0\xaf\x6c\xa3\xe5\x9e\xac\xa0\xdb\x40\xc3\x33\x40\xed\x11\xd4\x22\xb2\xb9\x63\xe4\xce" +
-	"\x13\xae\xdf\xa3\xf3\xca\x1a\x94\x0f\x51\x13\x35\xfd\x07\x5d\xff\x17\x9a\x8e\x5f\x2e\x5f\xfd\xe9\xc2\x77\x4d\x72" +
-	"\xb4\xd4\x25\x7f\x05\x00\x00\xff\xff\xe1\x47\x30\x9a\xbd\x09\x00\x00")
+	"\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcd\x58\xdf\x6f\xdb\x36\x10\x7e\x96\xff\x0a\x4e\x68\x0b\xa9\xf5\xd4\x64" +
+	"\x28\x8a\xd5\x5d\x1f\x1a\xbb\x49\x8d\xc6\x89\x17\x1b\xdd\x80\xa2\x68\x69\x89\xb6\xb9\xd0\xa4\x46\x52\x49\x3c\x41" +
+	"\xff\xfb\xee\x28\x59\x96\x63\x3b\x4d\x9b\x3d\xec\x25\x89\xf8\xe3\xbb\xbb\xef\x3e\x1e\x8f\x99\x66\x32\x26\x63\x66" +
+	"\xec\x19\xbb\x1e\xd3\x89\x60\x26\xb0\xe4\xa9\x85\x01\x2e\x67\xd1\x38\x24\x79\xcb\x4b\x26\xa4\xf3\x86\x24\x0b\x81" +
+	"\xc3\xd1\x20\x33\xb6\xab\xa4\x64\xb1\xed\x1d\x05\x36\x84\x79\x36\x65\xba\x9e\xef\x0a\x65\x58\x60\xdb\x24\x99\x84" +
+	"\xad\x96\x97\xe7\xd7\xdc\xce\x49\x84\x36\x46\xbf\x9f\xf6\xb2\x45\x7a\x22\xd4\x64\x48\xed\xbc\x28\x36\x77\x56\xd3" +
+	"\xa7\x8a\x26\xb8\xdf\xcf\xf3\xa8\x28\xfc\x36\x79\x72\x6b\xc1\x79\x6a\xb9\x92\x06\x3c\xf3\x46\x97\x3c\xed\x1d\x75" +
+	"\x05\xa3\x32\x4b\x3b\xc4\xea\x8c\xb5\x5b\x5e\x11\x46\x3d\x44\xd6\x2c\x09\xc2\x3c\x67\x32\x29\x0a\x70\x25\xb6\x37" +
+	"\x6d\x12\x53\x19\x33\x81\x01\xc5\x4a\x5a\x76\x63\xa3\x3f\xc0\xbf\x31\x5f\x30\x95\xd9\x60\x35\x76\x44\xe3\xcb\x99" +
+	"\x56\x99\x04\x80\x36\xb1\x30\x1b\x0d\xb8\xcc\x2c\x7b\xfa\x4b\x1d\x70\x89\x14\xc0\xb7\x9d\x08\xd3\x26\x60\x0f\x61" +
+	"\xd7\x44\x3a\x7b\x49\x22\x9c\x05\xa4\x6c\xa8\x94\x08\x80\x16\xd8\x42\x8d\x61\xda\x46\x67\xea\x9d\xd6\x4a\x63\xb8" +
+	"\xb0\x1d\xf9\x02\xac\x33\xba\x60\x06\xa1\x10\x37\xaa\xc0\x60\x8f\x51\xb0\x63\x64\x35\x64\x06\xb2\x54\xad\x5b\x63" +
+	"\xbd\xbb\xa1\xb1\x15\x4b\xc4\xfa\xf4\xd9\xb8\x65\x39\xc9\xf3\x9f\x89\xa6\x72\xc6\xc8\x23\x8b\x40\x08\x5b\x41\x12" +
+	"\x20\x37\xcf\xab\x2f\xc4\x42\xb2\x71\xbd\xe3\xab\x68\x93\xb5\x89\x96\x87\xc1\x55\x0e\x7d\xa4\x82\x27\xd4\x32\x8c" +
+	"\x6f\x7f\x24\xde\x15\xd5\x24\x35\xe4\x69\x6a\x58\x96\xa8\x68\xc4\xf4\x15\x8f\x59\xcb\x83\xb1\x37\xa4\x1a\x44\x2d" +
+	"\x01\x5f\xd5\x5c\x70\x00\xb9\xae\x66\x56\x39\x3e\x05\xdf\x3b\xc4\x4f\x98\xdf\x3e\x16\x8a\xda\x01\xbd\xe9\xb1\x98" +
+	"\x2f\xa8\x30\x9d\x97\x05\xe4\xda\xab\x36\xb8\x2c\xd2\xd9\x31\xbd\x64\xc7\xa0\xe9\xc0\xbf\x66\x13\xc3\x2d\xfb\xc2" +
+	"\x13\x90\xd0\x14\x87\x16\xf4\xe6\x94\x49\xc2\xa5\x0d\x49\x00\x3f\x99\x9e\xd2\x98\xe5\x85\xf3\x58\x69\xa7\x75\xcf" +
+	"\xd3\xcc\x66\x5a\x92\xc3\x36\x91\x5c\xc0\x40\x11\xde\x65\xc5\x58\xa5\xff\x2b\x1b\xc8\x7d\x17\x18\x51\x8b\xae\x4a" +
+	"\x18\xf1\x77\x92\x54\x31\xe3\x93\x9f\x41\xd3\x1e\xa6\xe6\xf9\x73\x32\x3e\xef\x9d\x13\x9d\x49\x62\xe7\x70\xf8\x08" +
+	"\x1e\x16\x03\x3e\x90\x94\x6a\x2a\x04\x13\x2d\xef\x6e\x1d\x80\xec\xa2\x8b\x4c\x06\xa0\x87\x13\xd5\x85\x9c\x8b\x0d" +
+	"\x59\x7c\x79\x27\x2d\xb7\xcb\x55\x8c\x5b\x05\xc2\x8b\xe3\xa4\xd6\x2b\xba\xeb\xf6\x06\x35\xc2\x6e\x54\xf4\xdd\xe3" +
+	"\x72\x64\x17\xb6\x3e\x3c\x00\x14\xf5\x25\x0a\x2a\x08\xa3\xa3\x8c\x8b\x04\xd4\x96\xa1\xfc\xa3\xa1\x66\x10\x4e\xa9" +
+	"\x3a\x02\x31\xf7\x14\x91\xca\x92\x0c\xe2\xed\xcf\x24\x64\x21\x08\x89\x55\xc4\x64\x69\xaa\x99\x31\xa4\x77\x54\x52" +
+	"\x6e\x22\x30\xb3\x4f\xa4\x60\xdf\xbc\xd5\x96\x1b\x2a\xd1\x7c\xe9\x8d\xcb\xf2\x5b\x3d\x73\x87\x6e\x5f\x75\x2b\x97" +
+	"\xba\x18\x0c\x13\x0d\x0c\x0c\x61\xc4\x04\x94\xc7\xa3\xe5\xf0\x03\x38\xbe\x46\x83\x23\x9a\x52\x99\x0c\x05\x48\xe2" +
+	"\xbd\x12\x09\xd3\x68\x02\x10\xa6\x4a\x13\x8e\x9b\x0f\x5e\xc3\xef\xdf\xc8\x2b\xf8\xf5\xec\x59\xa9\x15\xe6\xb8\xef" +
+	"\x3b\x6c\xc9\xae\x83\x7d\x64\xc2\x52\x3e\x5d\xf1\x98\x9a\x08\x35\xda\xa3\x96\x06\x2b\x80\xf0\xb5\x9b\xfd\xe9\x0d" +
+	"\x8a\xae\xc4\x86\xb4\x3b\x42\xa6\x81\xdf\xef\xfd\xf9\xe9\x71\xf2\xb9\x43\x1e\x3f\xbb\x82\x44\xf3\x9a\xa1\x95\x5a" +
+	"\xf1\xcf\x02\x7f\x34\xa4\x14\x2b\x57\x49\x4b\x49\x45\x5d\x25\xb2\x85\x2c\xf5\x54\xad\x03\x8f\x4a\x45\x8f\x97\x69" +
+	"\xb5\xbe\x28\xa0\xe8\xb8\x3b\x61\xe7\x0c\xc6\xf0\x37\x89\x3e\x70\x99\x10\x9f\xc9\x6c\xe1\x57\x78\xab\x30\xa2\x3c" +
+	"\xaf\xec\x55\x3c\x0c\xe8\x72\xc2\x86\x9a\x5f\x41\x4d\x72\x40\xd1\x31\x67\x02\x4a\x18\xd4\x19\xb8\x42\x4a\x01\x7d" +
+	"\xa4\xba\x28\x3e\x01\x2d\x7d\x69\x65\x20\x98\x0c\x36\xa7\xc2\xf0\x73\xed\x34\x13\xa0\xa9\x0d\x3f\x0c\xb3\x0f\x75" +
+	"\xe3\x44\x61\xa0\x45\x11\xac\x7c\x80\x31\xbc\x13\xf1\x6f\x28\x6a\xe8\xc1\xda\x01\xb0\xe9\xc8\x28\xff\xd8\xe5\x58" +
+	"\x50\xe2\x9d\x65\x42\x38\x63\x21\xf1\xff\x32\x4a\x46\x17\xf4\x7a\x00\xea\xa7\x33\xf6\x20\x87\x6f\x61\x05\x5f\x73" +
+	"\x1f\x6a\x5c\x87\x1c\x16\x5f\xc3\x2d\x77\x40\xbc\x01\xb8\xe4\x20\x50\x71\x2e\xa3\x7e\xaa\xa0\xfc\xf9\xe1\xae\xa9" +
+	"\x19\x53\x0b\x66\xf5\x12\x66\x1f\xe0\xa3\x84\xd8\xa3\x01\xa8\x7c\x88\x96\xf0\xf2\x98\xe2\x0d\xf1\xf2\x45\x4d\xf1" +
+	"\xe1\xaf\x07\x61\xb8\x3d\xfc\x0a\x46\xb7\xa3\x70\xe8\x7d\x33\x4a\xa9\xe5\x54\xd4\xce\x3e\xd4\xc1\x93\x2a\xd6\xbc" +
+	"\xb8\x95\xde\xd6\xe6\x17\x7e\x8a\x7e\xaf\xd9\x69\x75\xe7\x2c\xbe\x3c\xa5\xa8\x11\xac\x5e\xfd\x9e\xeb\x8a\xdc\x81" +
+	"\xed\x6c\xf6\x6c\xd1\xdd\x75\x3b\x0c\xd6\x65\x2e\xba\x60\xb1\xd2\x49\xe0\xc3\x21\xaf\x2b\x03\x14\x26\x16\x77\xcb" +
+	"\xde\xc7\x15\xd8\xb2\x9c\x34\x37\xc1\x11\x28\x6b\x55\xc5\xc5\x79\x66\xef\x51\x91\xb4\xba\xee\x42\x17\xb5\x2e\xf0" +
+	"\xeb\x5a\x89\xb9\x78\xf9\xc2\x04\x10\x74\x18\xb9\x9e\xcf\xf5\x4b\x35\xba\xdb\xbf\xb7\x6e\xef\x68\x7a\x32\x8e\x80" +
+	"\xc1\x21\x64\x7c\x6d\x16\xeb\xda\xe3\xa4\x43\x2e\xaa\x11\x92\xf0\xc4\xdd\x1b\x0b\x6a\xe3\x39\x16\xba\x32\xa8\xfb" +
+	"\x17\xb5\x86\x54\x5c\x8f\x55\x5e\xc4\xde\x96\x4b\xd0\x06\xcc\xec\x1c\x1d\x03\xcd\xe0\x86\xee\x9c\xea\x81\x6b\x0f" +
+	"\x60\xbc\x8c\x1e\xbb\xac\x27\xdf\xaf\xad\x7a\x13\xd0\xf4\x3d\xbb\x56\x64\xdc\x5f\xc4\x66\xae\x32\x91\xd4\x64\x61" +
+	"\xae\xb6\x0e\x0e\xb2\xb9\x62\x64\x69\x2c\x5b\x7c\x84\x7b\x0d\x5a\x14\x96\xec\xa3\xc6\xe5\xf1\x07\xa2\xfe\x3f\x04" +
+	"\xed\xde\x12\xbb\x3e\x50\x17\xa5\x81\xf7\xd4\x40\xae\x41\x4b\x63\x0d\xcf\x08\x94\x48\x75\xbe\x2b\x16\xc6\xf0\x4a" +
+	"\xd9\xa0\xa0\x6f\x7a\x5c\xdb\x25\x3e\x35\x56\xde\x96\x27\xd7\x71\x0b\xd5\x2b\x01\x2e\xa7\x5a\x2d\xa0\xbf\x63\x04" +
+	"\x7a\x6f\x3a\xa1\xc0\x7d\xe5\xe7\x04\x86\x70\x7b\xc3\xd5\xca\xd0\x31\x74\xc9\x0d\x4b\x48\xdc\x7e\x53\xdf\x32\x83" +
+	"\xae\xec\x37\x05\xaa\xde\x34\x54\x32\x90\x38\x4e\x0d\x9a\x3b\xf8\x21\x8b\x73\x7a\xc5\x48\x5c\x62\x91\xa9\x03\x6b" +
+	"\x18\xaf\xcc\xe9\x19\x1e\xd8\xb5\xed\x73\xcd\x67\x5c\x52\xf7\x36\x6b\xd4\x10\x7b\xc6\x45\xc3\x49\x3d\x5b\x7b\xb4" +
+	"\xda\xe1\x7c\x68\x10\x4b\xaf\x28\x17\x98\xd4\xed\x88\x6f\x83\x35\xac\xae\x71\xbb\x2a\x5d\x12\x35\x75\xe1\xa9\x5d" +
+	"\x36\xea\x18\xa1\x79\x5c\x2d\xd8\xb6\xf5\xad\xa7\x1d\x74\x6b\x8f\xe2\xed\xa2\x85\x3d\x04\xca\x92\xe3\x39\xa8\xde" +
+	"\xc1\xf8\xaa\xae\x84\xee\xbb\x21\x3c\xa4\xa4\x68\x06\xb2\x95\xba\x7b\x47\x03\x2d\x3a\xbc\x51\x09\xbc\x39\xb6\x73" +
+	"\xb5\x47\x93\x60\xcf\x5d\x2e\xa5\x51\x6c\x92\xf7\x4b\x74\x87\x12\x09\x9d\xc2\x1b\x8b\x34\x21\x1a\x36\xb7\x0e\x69" +
+	"\xf3\x09\x04\x76\x05\x75\xaf\xcc\xaa\xb8\xef\xb8\x6c\x6a\x49\xe1\x05\x05\xcc\x95\x57\x5b\x79\x53\xe1\x6b\xa7\xe1" +
+	"\xe3\xc6\x8a\x5b\x3e\x40\x12\x20\xac\x01\x95\xcb\xdb\xa6\x6e\x69\xd2\x95\xb6\x0a\xa3\x59\xba\xaa\xa1\x86\x32\xb9" +
+	"\xe4\xd8\xa7\xf0\x7f\x58\x72\x57\xc0\xab\x8f\x02\xff\x2b\xd2\x6a\x0c\x15\xad\x7f\x01\x65\xaf\x99\x39\xfb\x11\x00" +
+	"\x00")
 
 func bindataTpl90testgotplBytes() ([]byte, error) {
 	return bindataRead(
@@ -378,10 +636,10 @@ func bindataTpl90testgotpl() (*asset, error) {
 
 	info := bindataFileInfo{
 		name: "_tpl/90_test.go.tpl",
-		size: 2493,
+		size: 4603,
 		md5checksum: "",
 		mode: os.FileMode(420),
-		modTime: time.Unix(1547706026, 0),
+		modTime: time.Unix(1792348994, 0),
 	}
 
 	a := &asset{bytes: bytes, info: info}
@@ -545,6 +803,60 @@ func bindataTplProtobuf20messagegotpl() (*asset, error) {
 
 
 //
+var _bindataTplProtobuf30servicegotpl = []byte(
+	"\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb5\x55\xdf\x6f\xd3\x30\x10\x7e\x5e\xfe\x8a\x63\x4f\xad\x54\x32\xb1\x21" +
+	"\x1e\x18\x45\x1a\x2d\xdb\x03\x68\x54\xfb\xc1\x0b\x9a\x3a\x2f\xb9\x14\x6b\xae\x1d\xec\xcb\x46\x89\xf2\xbf\x73\x76" +
+	"\xd3\x2d\x45\x69\xd4\x6a\x42\x8a\x64\xc7\xf9\xbe\xfb\xf1\xdd\xf9\x12\x1d\x1c\x40\x59\xc6\x9f\x35\x49\x5a\x54\xd5" +
+	"\xc4\xca\xb9\xb0\x8b\x2f\xb8\x00\x99\x22\x1f\x66\x12\x1d\x08\x70\x52\xcf\x14\x82\x35\x8f\x60\x32\x18\x7f\x02\x12" +
+	"\x77\xfc\x7e\xcb\xd4\x2b\xbf\x3b\x17\x73\xac\xaa\xdb\x18\x4e\x0a\x32\x30\x43\x8d\x56\x10\xa6\x71\x34\x47\xe7\xc4" +
+	"\x0c\x37\xf8\x28\xa3\x3d\x93\x93\x34\x1a\x7a\x33\x33\x33\xb9\x35\x64\x62\x5a\xe4\x98\x62\xa2\xfa\x30\x04\xb2\x05" +
+	"\x1e\x47\x7b\x65\xf9\x1a\xac\xd0\x6c\x27\x1e\x19\x55\xcc\xb5\x8b\x9f\xad\xb8\xaa\xf2\x88\x4b\xb4\x52\x28\xf9\x07" +
+	"\xed\x15\x1b\x80\xb8\xaa\xbc\xd3\x53\x89\x2a\xe5\xed\xd0\xbf\x4c\x0c\x63\xe1\x47\xc3\x57\x52\x38\x32\x73\xcd\xd1" +
+	"\xf7\x87\xfb\x65\x79\x66\x46\xbc\x55\xb0\xa2\xed\x83\xf7\xfc\x6c\x79\x14\xe0\x2b\xfb\x37\x75\x64\xa8\x19\x1a\x55" +
+	"\x51\xb4\xae\xe5\x57\xe9\xe8\x54\x2a\x42\x0b\x16\x1d\x59\x99\x90\x03\xfa\x19\x54\x74\x7c\x44\x85\xd5\x98\xc2\xdd" +
+	"\xa2\x49\x62\x5f\x0f\x32\xc1\xd8\x93\x63\x6f\xf0\xb2\xc8\x73\x63\x59\x4b\x30\xb9\x17\xd5\x58\xae\x87\xc5\xf7\x9c" +
+	"\x51\x2f\xc5\x4c\x14\x8a\xfa\x03\x78\x35\x1c\xc0\x07\x7e\x78\xf9\xc8\x0f\x2f\x4a\xde\xe3\x00\xb4\xa1\x7a\x27\xf5" +
+	"\xc0\x9b\xf3\x07\xbc\x05\x5d\x28\x05\x42\xa7\xe1\xc0\xbf\x6c\x55\xba\x46\x4a\xdb\x96\xce\x27\xae\x67\x90\x84\xb2" +
+	"\xf1\xf1\x9b\xcd\xfa\x2f\x4b\xbb\x7f\xf3\xcc\x5a\xa5\xcc\xbc\xc3\xcd\xbc\x6f\x35\x2a\x30\x2d\xe6\xe8\xe3\x87\xda" +
+	"\xc4\x83\x50\x05\xb7\xf0\x10\x8e\x36\x1b\xf8\x1e\x30\x9e\xde\x5a\xc4\x0b\xfc\xc5\x9f\x89\x4b\x16\x56\x7f\x1f\x72" +
+	"\x2f\x0c\xdf\x84\x50\xca\xcc\x9a\xf9\x0b\xef\x44\xd3\xcd\xb6\xca\x3e\x65\xda\x5e\xa0\x2c\x2c\xae\x5b\xf2\x25\x36" +
+	"\xa4\xbe\x57\x48\x4d\x47\x87\x21\xb5\xa9\xe3\x6e\xef\x16\x7d\xc2\xb0\x4b\x46\x35\xcb\x15\xa8\x64\xee\x51\x77\xeb" +
+	"\xed\xb9\x57\x1e\xd6\x21\xb9\xcb\x8d\x76\xc8\x7d\xa3\x49\x48\xfd\xff\x34\xaf\xfd\xbc\x44\x74\x48\x05\x89\x6e\x9d" +
+	"\xc7\x8c\x68\x2a\xa5\xf1\x37\x4d\xd7\xe4\xea\x90\xfa\x9c\xc1\xdd\x92\x8d\x51\x21\xe1\x53\x32\x1c\x24\x8f\x8c\x7a" +
+	"\xd8\xa0\xe3\x11\xe1\x75\x6b\x99\x32\x4b\xde\x56\x6a\xfd\xe3\x62\x5b\xbd\x7c\x53\xbd\x7b\x1b\x6a\x36\x15\x59\x86" +
+	"\x89\xd7\xae\x53\xaa\x0b\x86\x9e\xd4\xc8\xd6\x64\xeb\xe0\x81\xb9\x0f\xfc\x93\x72\x30\xba\xb8\x1e\xd7\xa3\x82\x03" +
+	"\xe2\xde\xe0\x81\xb1\x43\x6b\xb8\xda\x5e\x8b\x0b\xce\xd2\xe6\x09\x9c\x21\xf5\x5a\x7f\x60\xfd\x7a\x8c\x3b\x68\x7e" +
+	"\xef\x1f\x2f\x69\xbe\xc1\x7a\xed\x97\xbc\x9d\xd8\xec\xc8\x95\x91\x91\xf5\xfd\xb6\x66\xbe\xd3\xe9\x75\x9e\xee\x84" +
+	"\x5f\xd6\x75\x97\xf4\xd6\x3b\xa1\xef\x2b\xf4\x17\x1c\x65\xec\xd1\x46\x08\x00\x00")
+
+func bindataTplProtobuf30servicegotplBytes() ([]byte, error) {
+	return bindataRead(
+		_bindataTplProtobuf30servicegotpl,
+		"_tpl/protobuf_30_service.go.tpl",
+	)
+}
+
+
+
+func bindataTplProtobuf30servicegotpl() (*asset, error) {
+	bytes, err := bindataTplProtobuf30servicegotplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{
+		name: "_tpl/protobuf_30_service.go.tpl",
+		size: 2118,
+		md5checksum: "",
+		mode: os.FileMode(420),
+		modTime: time.Unix(1792348776, 0),
+	}
+
+	a := &asset{bytes: bytes, info: info}
+
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -611,11 +923,14 @@ var _bindata = map[string]func() (*asset, error){
 	"_tpl/20_entity.go.tpl":             bindataTpl20entitygotpl,
 	"_tpl/30_collection_methods.go.tpl": bindataTpl30collectionmethodsgotpl,
 	"_tpl/40_binary.go.tpl":             bindataTpl40binarygotpl,
+	"_tpl/50_service.go.tpl":            bindataTpl50servicegotpl,
+	"_tpl/60_relations.go.tpl":          bindataTpl60relationsgotpl,
 	"_tpl/90_test.go.tpl":               bindataTpl90testgotpl,
 	"_tpl/fbs_10_header.go.tpl":         bindataTplFbs10headergotpl,
 	"_tpl/fbs_20_message.go.tpl":        bindataTplFbs20messagegotpl,
 	"_tpl/protobuf_10_header.go.tpl":    bindataTplProtobuf10headergotpl,
 	"_tpl/protobuf_20_message.go.tpl":   bindataTplProtobuf20messagegotpl,
+	"_tpl/protobuf_30_service.go.tpl":   bindataTplProtobuf30servicegotpl,
 }
 
 //
@@ -675,11 +990,14 @@ var _bintree = &bintree{Func: nil, Children: map[string]*bintree{
 		"20_entity.go.tpl": {Func: bindataTpl20entitygotpl, Children: map[string]*bintree{}},
 		"30_collection_methods.go.tpl": {Func: bindataTpl30collectionmethodsgotpl, Children: map[string]*bintree{}},
 		"40_binary.go.tpl": {Func: bindataTpl40binarygotpl, Children: map[string]*bintree{}},
+		"50_service.go.tpl": {Func: bindataTpl50servicegotpl, Children: map[string]*bintree{}},
+		"60_relations.go.tpl": {Func: bindataTpl60relationsgotpl, Children: map[string]*bintree{}},
 		"90_test.go.tpl": {Func: bindataTpl90testgotpl, Children: map[string]*bintree{}},
 		"fbs_10_header.go.tpl": {Func: bindataTplFbs10headergotpl, Children: map[string]*bintree{}},
 		"fbs_20_message.go.tpl": {Func: bindataTplFbs20messagegotpl, Children: map[string]*bintree{}},
 		"protobuf_10_header.go.tpl": {Func: bindataTplProtobuf10headergotpl, Children: map[string]*bintree{}},
 		"protobuf_20_message.go.tpl": {Func: bindataTplProtobuf20messagegotpl, Children: map[string]*bintree{}},
+		"protobuf_30_service.go.tpl": {Func: bindataTplProtobuf30servicegotpl, Children: map[string]*bintree{}},
 	}},
 }}
 
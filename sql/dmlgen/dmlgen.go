@@ -52,15 +52,6 @@ import (
 // Initial idea and prototyping for code generation.
 // TODO DML gen must take care of the types in myreplicator.RowsEvent.decodeValue (not possible)
 
-// TODO generate a hidden type which contains the original data to detect
-//  changes and store only changed fields. Investigate other implementations.
-//  With the e.g. new field "originalData" pointing to the struct we create a
-//  recursion, also we need to track which field needs to be updated after a
-//  change. Alternative implementation: a byte slice using protobuf encoding
-//  to store the current data and when saving to DB happens decode the data and
-//  compare the fields, then return the change field names, except autoinc
-//  fields.
-
 // Tables can generated Go source for for database tables once correctly
 // configured.
 type Tables struct {
@@ -95,6 +86,9 @@ type Tables struct {
 	// customCode injects custom code to manipulate testing and other generate
 	// code blocks.
 	customCode map[string]string
+	// customTypes contains the generated Go types for ENUM and SET columns
+	// and the Go types for JSON columns defined in TableConfig.JSONTypes.
+	customTypes map[*ddl.Column]*customType
 }
 
 // Option represents a sortable option for the NewTables function. Each option
@@ -136,13 +130,57 @@ type TableConfig struct {
 	ColumnAliases map[string][]string // key=column name value a list of aliases
 	// UniquifiedColumns specifies columns which are non primary/unique key one
 	// but should have a dedicated function to extract their unique primitive
-	// values as a slice. Not allowed are text, blob and binary.
+	// values as a slice. Not allowed are text, blob, binary, json and spatial types.
 	UniquifiedColumns []string
 	// PrivateFields list struct field names which should be private to avoid
 	// accidentally leaking through encoders. Appropriate getter/setter methods
 	// get generated.
 	PrivateFields []string
-	lastErr       error
+	// JSONTypes maps a JSON or text column to a Go type which gets used
+	// instead of json.RawMessage. The value gets encoded and decoded with
+	// package encoding/json. The Go type must be declared in the package of
+	// the generated code, for example "ProductAttributes" or
+	// "*ProductAttributes". For protocol buffers the Go type must implement
+	// the gogoproto customtype methods.
+	JSONTypes map[string]string // key=column name value=Go type
+	// Service generates a CRUD service for the table: Get by primary key,
+	// List with filters and keyset pagination, Create, Update and Delete. The
+	// protocol buffer service definition gets written by GenerateSerializer
+	// and the Go server implementation, backed by ddl.Tables and the
+	// generated collection, by GenerateGo. Client stubs for gRPC or Twirp
+	// can be created with GenerateProto. Requires the protobuf encoder, the
+	// serializer WithProtobuf and a primary key.
+	Service bool
+	// ChangeTracking generates a hidden field in the struct which contains
+	// the data as loaded from the database. The generated methods IsDirty,
+	// ChangedFields, Original and ResetChanges detect changes, for example to
+	// update only the changed columns with ddl.Table.UpdateByPK or to create
+	// audit events.
+	ChangeTracking bool
+	// Relations defines has-one, has-many and belongs-to relations to other
+	// tables. See also WithRelationsFromForeignKeys.
+	Relations []Relation
+	lastErr   error
+}
+
+// Relation defines how a table relates to another table. For each relation
+// the entity gets a struct field and a Load method. The collection gets a Load
+// method which loads the related rows of all entities with one
+// `SELECT ... WHERE target_column IN (...)` query per 1000 distinct keys.
+type Relation struct {
+	// Kind can be "hasOne", "hasMany" or "belongsTo". belongsTo is the reverse
+	// lookup of hasOne or hasMany and loads a single entity.
+	Kind string
+	// Name of the struct field and suffix of the Load method. Defaults to the
+	// Go type name of the target entity or for hasMany of the target
+	// collection.
+	Name string
+	// Column of the current table whose values match the values of
+	// TargetColumn. Only integer and string columns are supported. Both
+	// columns must be either signed or unsigned.
+	Column       string
+	TargetTable  string
+	TargetColumn string
 }
 
 func (to *TableConfig) applyEncoders(ts *Tables, t *table) {
@@ -287,13 +325,50 @@ func (to *TableConfig) applyColumnAliases(t *table) {
 	}
 }
 
+func (to *TableConfig) applyJSONTypes(ts *Tables, t *table) {
+	if to.lastErr != nil {
+		return
+	}
+	for colName, goType := range to.JSONTypes {
+		c := t.Columns.ByField(colName)
+		if c.Field == "" {
+			to.lastErr = errors.NotFound.Newf("[dmlgen] WithTableConfig:JSONTypes: For table %q the Column %q cannot be found.",
+				t.TableName, colName)
+			return
+		}
+		if dt := strings.ToLower(c.DataType); dt != "json" && !strings.Contains(dt, "text") {
+			to.lastErr = errors.NotSupported.Newf("[dmlgen] WithTableConfig:JSONTypes: For table %q the Column %q has the data type %q but only json and text are supported.",
+				t.TableName, colName, c.DataType)
+			return
+		}
+		ts.customTypes[c] = &customType{
+			Kind:   "json",
+			GoType: goType,
+		}
+	}
+}
+
+func (to *TableConfig) applyService(ts *Tables, t *table) {
+	if !to.Service || to.lastErr != nil {
+		return
+	}
+	switch {
+	case ts.Serializer != "protobuf" || !t.HasSerializer:
+		to.lastErr = errors.NotAcceptable.Newf("[dmlgen] WithTableConfig: Table %q Service requires the protobuf encoder and serializer", t.TableName)
+	case len(t.Columns.PrimaryKeys()) == 0:
+		to.lastErr = errors.NotAcceptable.Newf("[dmlgen] WithTableConfig: Table %q Service requires a primary key", t.TableName)
+	default:
+		t.HasService = true
+	}
+}
+
 // skips text and blob and varbinary and json and geo
 func (to *TableConfig) applyUniquifiedColumns(t *table) {
 	for i := 0; i < len(to.UniquifiedColumns) && to.lastErr == nil; i++ {
 		cn := to.UniquifiedColumns[i]
 		found := false
 		for _, c := range t.Columns {
-			if c.Field == cn && false == c.IsBlobDataType() {
+			if c.Field == cn && false == c.IsBlobDataType() && false == c.IsSpatialDataType() {
 				c.Uniquified = true
 				found = true
 			}
@@ -325,6 +400,10 @@ func WithTableConfig(tableName string, opt *TableConfig) (o Option) {
 		opt.applyComments(t)
 		opt.applyColumnAliases(t)
 		opt.applyUniquifiedColumns(t)
+		opt.applyJSONTypes(ts, t)
+		opt.applyService(ts, t)
+		t.HasChangeTracking = opt.ChangeTracking
+		t.relations = append(t.relations, opt.Relations...)
 		return opt.lastErr
 	}
 	return
@@ -409,6 +488,65 @@ func WithReferenceEntitiesByForeignKeys(ctx context.Context, db dml.Querier, str
 	return
 }
 
+// WithRelationsFromForeignKeys creates the relations between the tables by
+// analysing their foreign keys. For example store_group.website_id is a
+// foreign key to store_website.website_id hence the generated struct
+// StoreWebsite gets a has-many relation StoreGroupCollection and the struct
+// StoreGroup a belongs-to relation StoreWebsite. If the foreign key column is
+// unique, a has-one relation gets created. Foreign keys spanning multiple
+// columns or pointing to tables which are not generated get ignored.
+// Relations defined with TableConfig.Relations have precedence. Should not be
+// used together with WithReferenceEntitiesByForeignKeys.
+func WithRelationsFromForeignKeys(ctx context.Context, db dml.Querier) (opt Option) {
+	opt.sortOrder = 220 // must run after the table configurations
+	opt.fn = func(ts *Tables) error {
+		tblFks, err := ddl.LoadKeyColumnUsage(ctx, db, ts.sortedTableNames()...)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		// sorted to generate always the same order of the struct fields.
+		fkKeys := make([]string, 0, len(tblFks))
+		composite := map[string]bool{} // key=table.constraint
+		for k, kcuc := range tblFks {
+			fkKeys = append(fkKeys, k)
+			for _, kcu := range kcuc.Data {
+				if kcu.OrdinalPosition > 1 {
+					composite[kcu.TableName+"."+kcu.ConstraintName] = true
+				}
+			}
+		}
+		sort.Strings(fkKeys)
+
+		for _, k := range fkKeys {
+			for _, kcu := range tblFks[k].Data {
+				child, ok := ts.Tables[kcu.TableName]
+				parent, ok2 := ts.Tables[kcu.ReferencedTableName.String]
+				if !ok || !ok2 || composite[kcu.TableName+"."+kcu.ConstraintName] {
+					continue
+				}
+				kind := "hasMany"
+				if col := child.Columns.ByField(kcu.ColumnName); col.IsUnique() || (col.IsPK() && len(child.Columns.PrimaryKeys()) == 1) {
+					kind = "hasOne"
+				}
+				parent.addRelation(Relation{
+					Kind:         kind,
+					Column:       kcu.ReferencedColumnName.String,
+					TargetTable:  kcu.TableName,
+					TargetColumn: kcu.ColumnName,
+				})
+				child.addRelation(Relation{
+					Kind:         "belongsTo",
+					Column:       kcu.ColumnName,
+					TargetTable:  kcu.ReferencedTableName.String,
+					TargetColumn: kcu.ReferencedColumnName.String,
+				})
+			}
+		}
+		return nil
+	}
+	return
+}
+
 // WithTable sets a table and its columns. Allows to overwrite a table fetched
 // with function WithLoadColumns. Argument `actions` can only be set to "overwrite".
 func WithTable(tableName string, columns ddl.Columns, actions ...string) (opt Option) {
@@ -527,10 +665,14 @@ func NewTables(packageImportPath string, opts ...Option) (*Tables, error) {
 		Package:           pkg,
 		PackageImportPath: packageImportPath,
 		ImportPaths: []string{
+			"bytes",
 			"context",
 			"database/sql",
+			"encoding/base64",
 			"encoding/json",
+			"reflect",
 			"sort",
+			"strings",
 			"time",
 
 			"github.com/corestoreio/errors",
@@ -539,14 +681,20 @@ func NewTables(packageImportPath string, opts ...Option) (*Tables, error) {
 			"github.com/corestoreio/pkg/storage/null",
 		},
 		ImportPathsTesting: []string{
-			"testing",
 			"context",
+			"encoding/json",
 			"sort",
+			"testing",
+			"time",
+
+			"github.com/corestoreio/pkg/sql/ddl",
 			"github.com/corestoreio/pkg/sql/dmltest",
+			"github.com/corestoreio/pkg/storage/null",
 			"github.com/corestoreio/pkg/util/assert",
 			"github.com/corestoreio/pkg/util/pseudo",
 		},
-		FuncMap: make(template.FuncMap, 20),
+		FuncMap:     make(template.FuncMap, 20),
+		customTypes: make(map[*ddl.Column]*customType),
 	}
 
 	sort.Slice(opts, func(i, j int) bool {
@@ -559,6 +707,24 @@ func NewTables(packageImportPath string, opts ...Option) (*Tables, error) {
 		}
 	}
 
+	for _, t := range ts.Tables {
+		for _, c := range t.Columns {
+			if dt := strings.ToLower(c.DataType); dt == "enum" || dt == "set" {
+				ct, err := newCustomType(t.TableName, c)
+				if err != nil {
+					return nil, errors.WithStack(err)
+				}
+				ts.customTypes[c] = ct
+			}
+		}
+	}
+
+	for _, tblName := range ts.sortedTableNames() {
+		if err := ts.resolveRelations(ts.Tables[tblName]); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
 	ts.FuncMap["CustomCode"] = func(marker string) string { return ts.customCode[marker] }
 	ts.FuncMap["GoCamel"] = strs.ToGoCamelCase // net_http->NetHTTP entity_id->EntityID
 	ts.FuncMap["GoCamelMaybePrivate"] = func(s string) string { return s }
@@ -569,6 +735,17 @@ func NewTables(packageImportPath string, opts ...Option) (*Tables, error) {
 	ts.FuncMap["IsFieldPublic"] = func(string) bool { return false }
 	ts.FuncMap["IsFieldPrivate"] = func(string) bool { return false }
 	ts.FuncMap["GoPrimitiveNull"] = ts.toGoPrimitiveFromNull
+	ts.FuncMap["GoNotEqual"] = ts.toGoNotEqual
+	ts.FuncMap["CustomType"] = func(c *ddl.Column) *customType { return ts.customTypes[c] }
+	ts.FuncMap["EnumSetTypes"] = func(cols ddl.Columns) []*customType {
+		var cts []*customType
+		for _, c := range cols {
+			if ct, ok := ts.customTypes[c]; ok && ct.Kind != "json" {
+				cts = append(cts, ct)
+			}
+		}
+		return cts
+	}
 	ts.FuncMap["SerializerType"] = func(c *ddl.Column) string {
 		pt := ts.toSerializerType(c, true)
 		if strings.IndexByte(pt, '/') > 0 { // slash identifies an import path
@@ -577,6 +754,18 @@ func NewTables(packageImportPath string, opts ...Option) (*Tables, error) {
 		return pt
 	}
 	ts.FuncMap["SerializerCustomType"] = func(c *ddl.Column) string {
+		if ct, ok := ts.customTypes[c]; ok {
+			if ct.Kind == "json" && ct.GoType[0] == '*' {
+				return fmt.Sprintf(",(gogoproto.customtype)=%q", ct.GoType[1:])
+			}
+			if ct.Kind == "json" {
+				return fmt.Sprintf(",(gogoproto.customtype)=%q,(gogoproto.nullable)=false", ct.GoType)
+			}
+			return fmt.Sprintf(",(gogoproto.casttype)=%q", ct.GoType)
+		}
+		if ts.mySQLToGoType(c, true) == "json.RawMessage" {
+			return `,(gogoproto.casttype)="encoding/json.RawMessage"`
+		}
 		pt := ts.toSerializerType(c, true)
 		var buf strings.Builder
 		if pt == "google.protobuf.Timestamp" {
@@ -614,7 +803,7 @@ func NewTables(packageImportPath string, opts ...Option) (*Tables, error) {
 }
 
 // findUsedPackages checks for needed packages which we must import.
-func (ts *Tables) findUsedPackages(file []byte) ([]string, error) {
+func (ts *Tables) findUsedPackages(file []byte, importPaths []string) ([]string, error) {
 
 	af, err := goparser.ParseFile(token.NewFileSet(), "cs_virtual_file.go", append([]byte("package temporarily_main\n\n"), file...), 0)
 	if err != nil {
@@ -630,8 +819,8 @@ func (ts *Tables) findUsedPackages(file []byte) ([]string, error) {
 		return true
 	})
 
-	ret := make([]string, 0, len(ts.ImportPaths))
-	for _, path := range ts.ImportPaths {
+	ret := make([]string, 0, len(importPaths))
+	for _, path := range importPaths {
 		_, pkg := filepath.Split(path)
 		if _, ok := idents[pkg]; ok {
 			ret = append(ret, path)
@@ -670,6 +859,11 @@ func (ts *Tables) GenerateSerializer(w io.Writer, wTest io.Writer) error {
 				return errors.WriteFailed.New(err, "[dmlgen] For Table %q", t.TableName)
 			}
 		}
+		if t.HasService {
+			if err := t.writeTo(buf, ts.tpls.Lookup(ts.Serializer+"_30_service.go.tpl").Funcs(ts.FuncMap)); err != nil {
+				return errors.WriteFailed.New(err, "[dmlgen] For Table %q service", t.TableName)
+			}
+		}
 	}
 	_, err := buf.WriteTo(w)
 	return err
@@ -732,6 +926,12 @@ func (ts *Tables) GenerateGo(w io.Writer, wTest io.Writer) error {
 		if t.HasBinaryMarshaler {
 			ts.execTpl(buf, t, "40_binary.go.tpl")
 		}
+		if t.HasService {
+			ts.execTpl(buf, t, "50_service.go.tpl")
+		}
+		if len(t.Relations) > 0 {
+			ts.execTpl(buf, t, "60_relations.go.tpl")
+		}
 		if ts.lastError != nil {
 			return ts.lastError
 		}
@@ -741,7 +941,7 @@ func (ts *Tables) GenerateGo(w io.Writer, wTest io.Writer) error {
 		// now figure out all used package names in the buffer.
 		fmt.Fprintf(w, "// Auto generated via github.com/corestoreio/pkg/sql/dmlgen\n\npackage %s\n\nimport (\n", ts.Package)
 		// println(buf.String())
-		pkgs, err := ts.findUsedPackages(buf.Bytes())
+		pkgs, err := ts.findUsedPackages(buf.Bytes(), ts.ImportPaths)
 		if err != nil {
 			_, _ = w.Write(buf.Bytes()) // write malformed data for debugging reasons.
 			return errors.WithStack(err)
@@ -754,7 +954,12 @@ func (ts *Tables) GenerateGo(w io.Writer, wTest io.Writer) error {
 		// now figure out all used package names in the buffer.
 		fmt.Fprintf(wTest, "// Auto generated via github.com/corestoreio/pkg/sql/dmlgen\n\npackage %s\n\nimport (\n", ts.Package)
 
-		for _, path := range ts.ImportPathsTesting {
+		pkgs, err = ts.findUsedPackages(bufTest.Bytes(), ts.ImportPathsTesting)
+		if err != nil {
+			_, _ = wTest.Write(bufTest.Bytes()) // write malformed data for debugging reasons.
+			return errors.WithStack(err)
+		}
+		for _, path := range pkgs {
 			fmt.Fprintf(wTest, "\t%q\n", path)
 		}
 		fmt.Fprintf(wTest, "\n)\n")
@@ -793,9 +998,109 @@ type table struct {
 	HasEasyJsonMarshaler     bool
 	HasBinaryMarshaler       bool
 	HasSerializer            bool // writes the .proto file if true
+	HasService               bool // writes the CRUD service if true
+	HasChangeTracking        bool // writes the change tracking methods if true
 	DisableCollectionMethods bool
+	// Relations contains the resolved relations to other tables.
+	Relations []*relation
 	// PrivateFields key=snake case name of the DB column, value=true, the field must be private
 	privateFields map[string]bool
+	relations     []Relation
+}
+
+// relation contains a resolved Relation for the templates.
+type relation struct {
+	Relation
+	IsMany           bool
+	TargetEntity     string
+	TargetCollection string
+	// Key and TargetKey are Go expressions returning the value of Column for
+	// the entity variable `e` and of TargetColumn for the target entity
+	// variable `c`. KeyValid and TargetKeyValid are only set for nullable
+	// columns and report whether the value is not NULL.
+	Key, KeyValid             string
+	TargetKey, TargetKeyValid string
+	KeyType                   string // int64, uint64 or string
+	// ArgsFunc is the dml.Artisan method which adds a slice of keys as
+	// arguments.
+	ArgsFunc string
+}
+
+// resolveRelations checks the relations of table t and prepares them for the
+// templates.
+func (ts *Tables) resolveRelations(t *table) error {
+	names := make(map[string]bool, len(t.Columns)+len(t.ReferencedCollections))
+	for _, c := range t.Columns {
+		names[strs.ToGoCamelCase(c.Field)] = true
+	}
+	for _, rc := range t.ReferencedCollections {
+		names[strings.Fields(rc)[0]] = true
+	}
+	t.Relations = t.Relations[:0]
+	for _, r := range t.relations {
+		target, ok := ts.Tables[r.TargetTable]
+		if !ok {
+			return errors.NotFound.Newf("[dmlgen] Table %q Relation: Target table %q not found", t.TableName, r.TargetTable)
+		}
+		col := t.Columns.ByField(r.Column)
+		tCol := target.Columns.ByField(r.TargetColumn)
+		if col.Field == "" || tCol.Field == "" {
+			return errors.NotFound.Newf("[dmlgen] Table %q Relation: Column %q or target column %q.%q not found", t.TableName, r.Column, r.TargetTable, r.TargetColumn)
+		}
+
+		rel := &relation{
+			Relation:         r,
+			TargetEntity:     strs.ToGoCamelCase(r.TargetTable),
+			TargetCollection: strs.ToGoCamelCase(r.TargetTable) + "Collection",
+		}
+		switch r.Kind {
+		case "hasMany":
+			rel.IsMany = true
+		case "hasOne", "belongsTo":
+		default:
+			return errors.NotSupported.Newf("[dmlgen] Table %q Relation: Kind %q not supported", t.TableName, r.Kind)
+		}
+		if rel.Name == "" {
+			rel.Name = rel.TargetEntity
+			if rel.IsMany {
+				rel.Name = rel.TargetCollection
+			}
+			if names[rel.Name] {
+				rel.Name += "By" + strs.ToGoCamelCase(r.Column)
+			}
+		}
+		if names[rel.Name] {
+			return errors.NotAcceptable.Newf("[dmlgen] Table %q Relation: Name %q already in use. Please set a different name.", t.TableName, rel.Name)
+		}
+		names[rel.Name] = true
+
+		var targetKeyType string
+		rel.Key, rel.KeyValid, rel.KeyType = ts.toRelationKey(col, "e."+t.GoCamelMaybePrivate(col.Field))
+		rel.TargetKey, rel.TargetKeyValid, targetKeyType = ts.toRelationKey(tCol, "c."+target.GoCamelMaybePrivate(tCol.Field))
+		if rel.KeyType == "" || rel.KeyType != targetKeyType {
+			return errors.NotSupported.Newf("[dmlgen] Table %q Relation: Column %q and target column %q.%q must be both signed integer, unsigned integer or string types", t.TableName, r.Column, r.TargetTable, r.TargetColumn)
+		}
+		switch rel.KeyType {
+		case "int64":
+			rel.ArgsFunc = "Int64s"
+		case "uint64":
+			rel.ArgsFunc = "Uint64s"
+		case "string":
+			rel.ArgsFunc = "Strings"
+		}
+		t.Relations = append(t.Relations, rel)
+	}
+	return nil
+}
+
+// addRelation appends r if no relation with the same columns exists.
+func (t *table) addRelation(r Relation) {
+	for _, tr := range t.relations {
+		if tr.Column == r.Column && tr.TargetTable == r.TargetTable && tr.TargetColumn == r.TargetColumn {
+			return
+		}
+	}
+	t.relations = append(t.relations, r)
 }
 
 // WriteTo implements io.WriterTo and writes the generated source code into w.
@@ -831,8 +1136,11 @@ func (t *table) GoCamelMaybePrivate(s string) string {
 }
 
 // GenerateProto searches all *.proto files in the given path and calls protoc
-// to generate the Go source code.
-func GenerateProto(path string) error {
+// to generate the Go source code. Optional plugins generate additionally the
+// client stubs and server interfaces for the services defined via
+// TableConfig.Service. Supported plugins are "grpc" and "twirp". The twirp
+// plugin requires protoc-gen-twirp in the PATH.
+func GenerateProto(path string, plugins ...string) error {
 
 	path = filepath.Clean(path)
 	if ps := string(os.PathSeparator); !strings.HasSuffix(path, ps) {
@@ -846,10 +1154,23 @@ func GenerateProto(path string) error {
 
 	// To generate PHP Code replace `gogo_out` with `php_out`.
 	// Java bit similar. Java has ~15k LOC, Go ~3.7k
+	gogoOut := "Mgoogle/protobuf/timestamp.proto=github.com/gogo/protobuf/types:."
+	var pluginArgs []string
+	for _, p := range plugins {
+		switch p {
+		case "grpc":
+			gogoOut = "plugins=grpc," + gogoOut
+		case "twirp":
+			pluginArgs = append(pluginArgs, "--twirp_out", ".")
+		default:
+			return errors.NotSupported.Newf("[dmlgen] protoc plugin %q not supported", p)
+		}
+	}
 	args := []string{
-		"--gogo_out", "Mgoogle/protobuf/timestamp.proto=github.com/gogo/protobuf/types:.",
+		"--gogo_out", gogoOut,
 		"--proto_path", fmt.Sprintf("%s/src/:%s/src/github.com/gogo/protobuf/protobuf/:.", build.Default.GOPATH, build.Default.GOPATH),
 	}
+	args = append(args, pluginArgs...)
 	args = append(args, protoFiles...)
 
 	cmd := exec.Command("protoc", args...)
@@ -874,13 +1195,18 @@ func GenerateProto(path string) error {
 	if err != nil {
 		return errors.Wrapf(err, "[dmlgen] Can't access pb.go files in path %q", path)
 	}
-	removeImport := []byte("import null \"github.com/corestoreio/pkg/storage/null\"\n")
+	removeImports := [][]byte{
+		[]byte("import null \"github.com/corestoreio/pkg/storage/null\"\n"),
+		[]byte("\tnull \"github.com/corestoreio/pkg/storage/null\"\n"), // gogo/protobuf >= v1.3
+	}
 	for _, file := range pbGoFiles {
 		fContent, err := ioutil.ReadFile(file)
 		if err != nil {
 			return errors.WithStack(err)
 		}
-		fContent = bytes.Replace(fContent, removeImport, nil, -1)
+		for _, ri := range removeImports {
+			fContent = bytes.Replace(fContent, ri, nil, -1)
+		}
 		if err := ioutil.WriteFile(file, fContent, 0644); err != nil {
 			return errors.WithStack(err)
 		}
@@ -22,6 +22,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/pkg/sql/ddl"
 	"github.com/corestoreio/pkg/sql/dmlgen"
@@ -95,14 +96,22 @@ func TestGenerate_Tables_Protobuf_Json(t *testing.T) {
 		dmlgen.WithLoadColumns(ctx, db.DB, "dmlgen_types", "core_config_data", "customer_entity", "customer_address_entity"),
 		dmlgen.WithTableConfig(
 			"customer_entity", &dmlgen.TableConfig{
-				Encoders:      []string{"json", "protobuf"},
-				StructTags:    []string{"max_len"},
-				PrivateFields: []string{"password_hash"},
+				Encoders:       []string{"json", "protobuf"},
+				StructTags:     []string{"max_len"},
+				PrivateFields:  []string{"password_hash"},
+				Service:        true,
+				ChangeTracking: true,
+				Relations: []dmlgen.Relation{
+					{Kind: "hasMany", Column: "entity_id", TargetTable: "customer_address_entity", TargetColumn: "parent_id"},
+				},
 			}),
 		dmlgen.WithTableConfig(
 			"customer_address_entity", &dmlgen.TableConfig{
 				Encoders:   []string{"json", "protobuf"},
 				StructTags: []string{"max_len"},
+				Relations: []dmlgen.Relation{
+					{Kind: "belongsTo", Column: "parent_id", TargetTable: "customer_entity", TargetColumn: "entity_id"},
+				},
 			}),
 
 		dmlgen.WithTableConfig(
@@ -343,3 +352,409 @@ func TestWithUniquifiedColumns(t *testing.T) {
 		assert.ErrorIsKind(t, errors.NotFound, err)
 	})
 }
+
+func TestWithJSONTypes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("column not found", func(t *testing.T) {
+		tbls, err := dmlgen.NewTables("test",
+			dmlgen.WithTableConfig("catalog_product", &dmlgen.TableConfig{
+				JSONTypes: map[string]string{"attributes": "Attributes"},
+			}),
+			dmlgen.WithTable("catalog_product", ddl.Columns{
+				&ddl.Column{Field: "entity_id", DataType: "int"},
+			}),
+		)
+		assert.Nil(t, tbls)
+		assert.ErrorIsKind(t, errors.NotFound, err)
+	})
+
+	t.Run("data type not supported", func(t *testing.T) {
+		tbls, err := dmlgen.NewTables("test",
+			dmlgen.WithTableConfig("catalog_product", &dmlgen.TableConfig{
+				JSONTypes: map[string]string{"entity_id": "Attributes"},
+			}),
+			dmlgen.WithTable("catalog_product", ddl.Columns{
+				&ddl.Column{Field: "entity_id", DataType: "int"},
+			}),
+		)
+		assert.Nil(t, tbls)
+		assert.ErrorIsKind(t, errors.NotSupported, err)
+	})
+}
+
+func TestGenerate_CustomTypes(t *testing.T) {
+	t.Parallel()
+
+	ts, err := dmlgen.NewTables("github.com/corestoreio/pkg/sql/dmlgen/testdata",
+		dmlgen.WithTable("catalog_product", ddl.Columns{
+			&ddl.Column{Field: "entity_id", Pos: 1, Null: "NO", DataType: "int", ColumnType: "int(10) unsigned", Key: "PRI", Extra: "auto_increment"},
+			&ddl.Column{Field: "size", Pos: 2, Null: "YES", DataType: "enum", CharMaxLength: null.MakeInt64(7), ColumnType: "enum('small','x-large')"},
+			&ddl.Column{Field: "flags", Pos: 3, Null: "NO", DataType: "set", CharMaxLength: null.MakeInt64(5), ColumnType: "set('new','sale')"},
+			&ddl.Column{Field: "attributes", Pos: 4, Null: "YES", DataType: "json", ColumnType: "json"},
+			&ddl.Column{Field: "options", Pos: 5, Null: "YES", DataType: "json", ColumnType: "json"},
+			&ddl.Column{Field: "location", Pos: 6, Null: "YES", DataType: "point", ColumnType: "point"},
+		}),
+		dmlgen.WithTableConfig("catalog_product", &dmlgen.TableConfig{
+			Encoders:  []string{"protobuf"},
+			JSONTypes: map[string]string{"options": "*ProductOptions"},
+		}),
+		dmlgen.WithProtobuf(),
+	)
+	assert.NoError(t, err)
+
+	var bufGo, bufTest, bufProto strings.Builder
+	assert.NoError(t, ts.GenerateGo(&bufGo, &bufTest))
+	assert.NoError(t, ts.GenerateSerializer(&bufProto, nil))
+
+	for _, want := range []string{
+		"\t\"strings\"\n",
+		"type CatalogProductSize string",
+		"CatalogProductSizeXLarge CatalogProductSize = \"x-large\"",
+		"type CatalogProductFlags uint64",
+		"CatalogProductFlagsNew CatalogProductFlags = 1 << iota",
+		"const catalogProductFlagsMask CatalogProductFlags = 1<<2 - 1",
+		"Size       CatalogProductSize",
+		"Attributes json.RawMessage",
+		"Options    *ProductOptions",
+		"Location   null.Geometry",
+		".Text(&e.Size).Text(&e.Flags).JSON(&e.Attributes).JSON(&e.Options).Binary(&e.Location)",
+	} {
+		assert.Contains(t, bufGo.String(), want)
+	}
+	for _, want := range []string{
+		"\t\"encoding/json\"\n",
+		"\t\"github.com/corestoreio/pkg/storage/null\"\n",
+		"entityIn.Size = catalogProductSizeValues[ps.Intn(len(catalogProductSizeValues))]",
+		"entityIn.Flags = CatalogProductFlags(ps.Intn(1 << 2))",
+		"entityIn.Attributes = json.RawMessage(`{\"id\": 1}`)",
+		"entityIn.Location = null.MakePoint(0, float64(ps.Intn(180)), float64(ps.Intn(90)))",
+	} {
+		assert.Contains(t, bufTest.String(), want)
+	}
+	for _, want := range []string{
+		`string size = 2 [(gogoproto.customname)="Size",(gogoproto.casttype)="CatalogProductSize"];`,
+		`uint64 flags = 3 [(gogoproto.customname)="Flags",(gogoproto.casttype)="CatalogProductFlags"];`,
+		`bytes attributes = 4 [(gogoproto.customname)="Attributes",(gogoproto.casttype)="encoding/json.RawMessage"];`,
+		`bytes options = 5 [(gogoproto.customname)="Options",(gogoproto.customtype)="ProductOptions"];`,
+		`null.Geometry location = 6 [(gogoproto.customname)="Location",(gogoproto.nullable)=false];`,
+	} {
+		assert.Contains(t, bufProto.String(), want)
+	}
+}
+
+func TestGenerate_Service(t *testing.T) {
+	t.Parallel()
+
+	storeTag := ddl.Columns{
+		&ddl.Column{Field: "store_id", Pos: 1, Null: "NO", DataType: "smallint", ColumnType: "smallint(5) unsigned", Key: "PRI"},
+		&ddl.Column{Field: "tag", Pos: 2, Null: "NO", DataType: "varchar", CharMaxLength: null.MakeInt64(32), ColumnType: "varchar(32)", Key: "PRI"},
+		&ddl.Column{Field: "value", Pos: 3, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(255), ColumnType: "varchar(255)"},
+	}
+
+	t.Run("protobuf and Go", func(t *testing.T) {
+		ts, err := dmlgen.NewTables("github.com/corestoreio/pkg/sql/dmlgen/testdata",
+			dmlgen.WithTable("store_tag", storeTag),
+			dmlgen.WithTableConfig("store_tag", &dmlgen.TableConfig{
+				Encoders: []string{"protobuf"},
+				Service:  true,
+			}),
+			dmlgen.WithProtobuf(),
+		)
+		assert.NoError(t, err)
+
+		var bufGo, bufTest, bufProto strings.Builder
+		assert.NoError(t, ts.GenerateGo(&bufGo, &bufTest))
+		assert.NoError(t, ts.GenerateSerializer(&bufProto, nil))
+
+		for _, want := range []string{
+			"\t\"encoding/base64\"\n",
+			"func (s *StoreTagServer) Get(ctx context.Context, pk *StoreTagPrimaryKey) (*StoreTag, error) {",
+			"func (s *StoreTagServer) List(ctx context.Context, req *StoreTagListRequest) (*StoreTagListResponse, error) {",
+			"func (s *StoreTagServer) Create(ctx context.Context, e *StoreTag) (*StoreTag, error) {",
+			"func (s *StoreTagServer) Update(ctx context.Context, e *StoreTag) (*StoreTag, error) {",
+			"func (s *StoreTagServer) Delete(ctx context.Context, pk *StoreTagPrimaryKey) (*StoreTagDeleteResponse, error) {",
+			`sel := tbl.Select("*").OrderBy("store_id", "tag").Limit(0, uint64(pageSize)+1)`,
+			`			dml.ParenthesisOpen(),
+			dml.Column("store_id").Greater().PlaceHolder(),
+			dml.ParenthesisClose(),
+			dml.ParenthesisOpen().Or(),
+			dml.Column("store_id").Equal().PlaceHolder(),
+			dml.Column("tag").Greater().PlaceHolder(),
+			dml.ParenthesisClose(),`,
+		} {
+			assert.Contains(t, bufGo.String(), want)
+		}
+		// StoreTagService is the interface generated by the Twirp plugin and
+		// the messages get generated by gogo protobuf.
+		for _, notWant := range []string{
+			"type StoreTagService struct",
+			"type StoreTagPrimaryKey struct",
+			"type StoreTagListFilter struct",
+			"type StoreTagListRequest struct",
+			"type StoreTagListResponse struct",
+			"type StoreTagDeleteResponse struct",
+		} {
+			assert.NotContains(t, bufGo.String(), notWant)
+		}
+		for _, want := range []string{
+			"message StoreTagPrimaryKey {\n\toption (gogoproto.typedecl) = true;\n\tuint32 store_id = 1 [(gogoproto.customname)=\"StoreID\"];\n\tstring tag = 2 [(gogoproto.customname)=\"Tag\"];\n}",
+			"repeated StoreTagListFilter filters = 1",
+			"service StoreTagService {",
+			"rpc Get(StoreTagPrimaryKey) returns (StoreTag);",
+			"rpc List(StoreTagListRequest) returns (StoreTagListResponse);",
+			"rpc Delete(StoreTagPrimaryKey) returns (StoreTagDeleteResponse);",
+		} {
+			assert.Contains(t, bufProto.String(), want)
+		}
+	})
+
+	t.Run("change tracking updates changed columns", func(t *testing.T) {
+		ts, err := dmlgen.NewTables("github.com/corestoreio/pkg/sql/dmlgen/testdata",
+			dmlgen.WithTable("store_tag", storeTag),
+			dmlgen.WithTableConfig("store_tag", &dmlgen.TableConfig{
+				Encoders:       []string{"protobuf"},
+				Service:        true,
+				ChangeTracking: true,
+			}),
+			dmlgen.WithProtobuf(),
+		)
+		assert.NoError(t, err)
+
+		var bufGo, bufTest strings.Builder
+		assert.NoError(t, ts.GenerateGo(&bufGo, &bufTest))
+		for _, want := range []string{
+			"\trec.original = cur.original\n\tcols := rec.ChangedFields()\n\tif len(cols) == 0 {\n\t\treturn cur, nil",
+			"\tupd := tbl.UpdateByPK(cols...)\n\tif len(upd.SetClauses) == 0 {\n\t\treturn cur, nil",
+		} {
+			assert.Contains(t, bufGo.String(), want)
+		}
+		assert.NotContains(t, bufGo.String(), "tbl.UpdateByPK().WithArgs()")
+	})
+
+	t.Run("requires protobuf", func(t *testing.T) {
+		ts, err := dmlgen.NewTables("github.com/corestoreio/pkg/sql/dmlgen/testdata",
+			dmlgen.WithTable("store_tag", storeTag),
+			dmlgen.WithTableConfig("store_tag", &dmlgen.TableConfig{
+				Service: true,
+			}),
+		)
+		assert.Nil(t, ts)
+		assert.ErrorIsKind(t, errors.NotAcceptable, err)
+	})
+
+	t.Run("requires primary key", func(t *testing.T) {
+		ts, err := dmlgen.NewTables("github.com/corestoreio/pkg/sql/dmlgen/testdata",
+			dmlgen.WithTable("store_tag", ddl.Columns{storeTag[2]}),
+			dmlgen.WithTableConfig("store_tag", &dmlgen.TableConfig{
+				Encoders: []string{"protobuf"},
+				Service:  true,
+			}),
+			dmlgen.WithProtobuf(),
+		)
+		assert.Nil(t, ts)
+		assert.ErrorIsKind(t, errors.NotAcceptable, err)
+	})
+}
+
+func TestGenerateProto_PluginNotSupported(t *testing.T) {
+	err := dmlgen.GenerateProto("./testdata", "php")
+	assert.ErrorIsKind(t, errors.NotSupported, err)
+}
+
+func TestGenerate_ChangeTracking(t *testing.T) {
+	t.Parallel()
+
+	ts, err := dmlgen.NewTables("github.com/corestoreio/pkg/sql/dmlgen/testdata",
+		dmlgen.WithTable("store", ddl.Columns{
+			&ddl.Column{Field: "store_id", Pos: 1, Null: "NO", DataType: "smallint", ColumnType: "smallint(5) unsigned", Key: "PRI", Extra: "auto_increment"},
+			&ddl.Column{Field: "name", Pos: 2, Null: "NO", DataType: "varchar", CharMaxLength: null.MakeInt64(255), ColumnType: "varchar(255)"},
+			&ddl.Column{Field: "updated_at", Pos: 3, Null: "YES", DataType: "datetime", ColumnType: "datetime"},
+		}),
+		dmlgen.WithTableConfig("store", &dmlgen.TableConfig{
+			ChangeTracking: true,
+			PrivateFields:  []string{"name"},
+		}),
+	)
+	assert.NoError(t, err)
+
+	var bufGo, bufTest strings.Builder
+	assert.NoError(t, ts.GenerateGo(&bufGo, &bufTest))
+
+	for _, want := range []string{
+		"original *Store // data as loaded from the database",
+		"if cm.Mode() == dml.ColumnMapScan && cm.Err() == nil {\n\t\te.ResetChanges()\n\t}",
+		"func (e *Store) ResetChanges() *Store {",
+		"func (e *Store) Original() *Store {",
+		"func (e *Store) IsDirty() bool {",
+		"return []string{\"store_id\", \"name\", \"updated_at\"}",
+		"if e.name != o.name {\n\t\tcols = append(cols, \"name\")\n\t}",
+		"if e.UpdatedAt.Valid != o.UpdatedAt.Valid || !e.UpdatedAt.Time.Equal(o.UpdatedAt.Time) {",
+	} {
+		assert.Contains(t, bufGo.String(), want)
+	}
+}
+
+func TestGenerate_Relations(t *testing.T) {
+	t.Parallel()
+
+	storeWebsite := ddl.Columns{
+		&ddl.Column{Field: "website_id", Pos: 1, Null: "NO", DataType: "smallint", ColumnType: "smallint(5) unsigned", Key: "PRI", Extra: "auto_increment"},
+		&ddl.Column{Field: "code", Pos: 2, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(32), ColumnType: "varchar(32)", Key: "UNI"},
+	}
+	storeGroup := ddl.Columns{
+		&ddl.Column{Field: "group_id", Pos: 1, Null: "NO", DataType: "smallint", ColumnType: "smallint(5) unsigned", Key: "PRI", Extra: "auto_increment"},
+		&ddl.Column{Field: "website_id", Pos: 2, Null: "YES", DataType: "smallint", ColumnType: "smallint(5) unsigned", Key: "MUL"},
+		&ddl.Column{Field: "website_code", Pos: 3, Null: "NO", DataType: "varchar", CharMaxLength: null.MakeInt64(32), ColumnType: "varchar(32)"},
+	}
+	newTables := func(websiteRels, groupRels []dmlgen.Relation) (*dmlgen.Tables, error) {
+		return dmlgen.NewTables("github.com/corestoreio/pkg/sql/dmlgen/testdata",
+			dmlgen.WithTable("store_website", storeWebsite),
+			dmlgen.WithTable("store_group", storeGroup),
+			dmlgen.WithTableConfig("store_website", &dmlgen.TableConfig{Relations: websiteRels}),
+			dmlgen.WithTableConfig("store_group", &dmlgen.TableConfig{Relations: groupRels}),
+		)
+	}
+
+	t.Run("has many and belongs to", func(t *testing.T) {
+		ts, err := newTables(
+			[]dmlgen.Relation{
+				{Kind: "hasMany", Column: "website_id", TargetTable: "store_group", TargetColumn: "website_id"},
+			},
+			[]dmlgen.Relation{
+				{Kind: "belongsTo", Column: "website_id", TargetTable: "store_website", TargetColumn: "website_id"},
+				{Kind: "belongsTo", Name: "WebsiteByCode", Column: "website_code", TargetTable: "store_website", TargetColumn: "code"},
+			},
+		)
+		assert.NoError(t, err)
+
+		var bufGo, bufTest strings.Builder
+		assert.NoError(t, ts.GenerateGo(&bufGo, &bufTest))
+
+		for _, want := range []string{
+			"StoreGroupCollection *StoreGroupCollection `faker:\"-\"` // hasMany relation via website_id",
+			"StoreWebsite  *StoreWebsite `faker:\"-\"` // belongsTo relation via website_id",
+			"WebsiteByCode *StoreWebsite `faker:\"-\"` // belongsTo relation via website_code",
+			"func (e *StoreWebsite) LoadStoreGroupCollection(ctx context.Context, tbls *ddl.Tables) error {",
+			"func (cc *StoreWebsiteCollection) LoadStoreGroupCollection(ctx context.Context, tbls *ddl.Tables) error {",
+			"idx := make(map[uint64][]*StoreWebsite, len(cc.Data))",
+			"args := make([]uint64, 0, len(cc.Data))",
+			"e.StoreGroupCollection = NewStoreGroupCollection()\n\t\tk := uint64(e.WebsiteID)",
+			"e.StoreGroupCollection.Data = append(e.StoreGroupCollection.Data, c)",
+			"if !c.WebsiteID.Valid {\n\t\t\tcontinue\n\t\t}\n\t\tfor _, e := range idx[uint64(c.WebsiteID.Uint16)] {",
+			"if _, err := sel.Reset().Uint64s(args[:n]...).Load(ctx, chunk); err != nil {",
+			"func (cc *StoreGroupCollection) LoadWebsiteByCode(ctx context.Context, tbls *ddl.Tables) error {",
+			"idx := make(map[string][]*StoreGroup, len(cc.Data))",
+			"e.WebsiteByCode = nil\n\t\tk := e.WebsiteCode",
+			"if !e.WebsiteID.Valid {\n\t\t\tcontinue\n\t\t}\n\t\tk := uint64(e.WebsiteID.Uint16)",
+			"dml.Column(\"code\").In().PlaceHolder(),\n\t).WithArgs().ExpandPlaceHolders()",
+			"if _, err := sel.Reset().Strings(args[:n]...).Load(ctx, chunk); err != nil {",
+			"tbl, err := tbls.Table(TableNameStoreWebsite)",
+		} {
+			assert.Contains(t, bufGo.String(), want)
+		}
+	})
+
+	t.Run("target table not found", func(t *testing.T) {
+		_, err := newTables([]dmlgen.Relation{
+			{Kind: "hasMany", Column: "website_id", TargetTable: "store", TargetColumn: "website_id"},
+		}, nil)
+		assert.ErrorIsKind(t, errors.NotFound, err)
+	})
+
+	t.Run("kind not supported", func(t *testing.T) {
+		_, err := newTables([]dmlgen.Relation{
+			{Kind: "manyToMany", Column: "website_id", TargetTable: "store_group", TargetColumn: "website_id"},
+		}, nil)
+		assert.ErrorIsKind(t, errors.NotSupported, err)
+	})
+
+	t.Run("column types differ", func(t *testing.T) {
+		_, err := newTables(nil, []dmlgen.Relation{
+			{Kind: "belongsTo", Column: "website_code", TargetTable: "store_website", TargetColumn: "website_id"},
+		})
+		assert.ErrorIsKind(t, errors.NotSupported, err)
+	})
+
+	t.Run("signed and unsigned columns", func(t *testing.T) {
+		_, err := newTables(nil, []dmlgen.Relation{
+			{Kind: "belongsTo", Column: "group_id", TargetTable: "store_website", TargetColumn: "website_id"},
+		})
+		assert.NoError(t, err)
+
+		signedGroup := append(ddl.Columns{}, storeGroup...)
+		signedGroup[0] = &ddl.Column{Field: "group_id", Pos: 1, Null: "NO", DataType: "smallint", ColumnType: "smallint(5)", Key: "PRI", Extra: "auto_increment"}
+		_, err = dmlgen.NewTables("github.com/corestoreio/pkg/sql/dmlgen/testdata",
+			dmlgen.WithTable("store_website", storeWebsite),
+			dmlgen.WithTable("store_group", signedGroup),
+			dmlgen.WithTableConfig("store_group", &dmlgen.TableConfig{Relations: []dmlgen.Relation{
+				{Kind: "belongsTo", Column: "group_id", TargetTable: "store_website", TargetColumn: "website_id"},
+			}}),
+		)
+		assert.ErrorIsKind(t, errors.NotSupported, err)
+	})
+
+	t.Run("name already in use", func(t *testing.T) {
+		_, err := newTables(nil, []dmlgen.Relation{
+			{Kind: "belongsTo", Name: "WebsiteCode", Column: "website_code", TargetTable: "store_website", TargetColumn: "code"},
+		})
+		assert.ErrorIsKind(t, errors.NotAcceptable, err)
+	})
+}
+
+func TestWithRelationsFromForeignKeys(t *testing.T) {
+	t.Parallel()
+
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectQuery("SELECT.+FROM information_schema.KEY_COLUMN_USAGE").
+		WillReturnRows(sqlmock.NewRows([]string{"CONSTRAINT_CATALOG", "CONSTRAINT_SCHEMA", "CONSTRAINT_NAME", "TABLE_CATALOG", "TABLE_SCHEMA",
+			"TABLE_NAME", "COLUMN_NAME", "ORDINAL_POSITION", "POSITION_IN_UNIQUE_CONSTRAINT",
+			"REFERENCED_TABLE_SCHEMA", "REFERENCED_TABLE_NAME", "REFERENCED_COLUMN_NAME"}).
+			FromCSVString(`"def","test","STORE_GROUP_WEBSITE_ID","def","test","store_group","website_id",1,1,"test","store_website","website_id"
+"def","test","STORE_GROUP_CODE","def","test","store_group","website_code",1,1,"test","store_website","code"
+"def","test","STORE_GROUP_CODE","def","test","store_group","group_id",2,2,"test","store_website","website_id"
+"def","test","STORE_WEBSITE_DETAIL_WEBSITE_ID","def","test","store_website_detail","website_id",1,1,"test","store_website","website_id"
+"def","test","STORE_DETAIL_WEBSITE_ID","def","test","store_detail","website_id",1,1,"test","store_website","website_id"`))
+
+	ts, err := dmlgen.NewTables("github.com/corestoreio/pkg/sql/dmlgen/testdata",
+		dmlgen.WithTable("store_website", ddl.Columns{
+			&ddl.Column{Field: "website_id", Pos: 1, Null: "NO", DataType: "smallint", ColumnType: "smallint(5) unsigned", Key: "PRI", Extra: "auto_increment"},
+			&ddl.Column{Field: "code", Pos: 2, Null: "YES", DataType: "varchar", CharMaxLength: null.MakeInt64(32), ColumnType: "varchar(32)", Key: "UNI"},
+		}),
+		dmlgen.WithTable("store_group", ddl.Columns{
+			&ddl.Column{Field: "group_id", Pos: 1, Null: "NO", DataType: "smallint", ColumnType: "smallint(5) unsigned", Key: "PRI", Extra: "auto_increment"},
+			&ddl.Column{Field: "website_id", Pos: 2, Null: "YES", DataType: "smallint", ColumnType: "smallint(5) unsigned", Key: "MUL"},
+			&ddl.Column{Field: "website_code", Pos: 3, Null: "NO", DataType: "varchar", CharMaxLength: null.MakeInt64(32), ColumnType: "varchar(32)"},
+		}),
+		dmlgen.WithTable("store_website_detail", ddl.Columns{
+			&ddl.Column{Field: "website_id", Pos: 1, Null: "NO", DataType: "smallint", ColumnType: "smallint(5) unsigned", Key: "PRI"},
+			&ddl.Column{Field: "description", Pos: 2, Null: "YES", DataType: "text", ColumnType: "text"},
+		}),
+		dmlgen.WithRelationsFromForeignKeys(context.Background(), dbc.DB),
+	)
+	assert.NoError(t, err)
+
+	var bufGo, bufTest strings.Builder
+	assert.NoError(t, ts.GenerateGo(&bufGo, &bufTest))
+
+	for _, want := range []string{
+		// store_group.website_id is not unique
+		"StoreGroupCollection *StoreGroupCollection `faker:\"-\"` // hasMany relation via website_id",
+		"StoreWebsite *StoreWebsite `faker:\"-\"` // belongsTo relation via website_id",
+		// store_website_detail.website_id is the only primary key column
+		"StoreWebsiteDetail   *StoreWebsiteDetail   `faker:\"-\"` // hasOne relation via website_id",
+		"func (cc *StoreWebsiteCollection) LoadStoreGroupCollection(ctx context.Context, tbls *ddl.Tables) error {",
+		"func (cc *StoreWebsiteCollection) LoadStoreWebsiteDetail(ctx context.Context, tbls *ddl.Tables) error {",
+		"func (cc *StoreGroupCollection) LoadStoreWebsite(ctx context.Context, tbls *ddl.Tables) error {",
+		"func (cc *StoreWebsiteDetailCollection) LoadStoreWebsite(ctx context.Context, tbls *ddl.Tables) error {",
+	} {
+		assert.Contains(t, bufGo.String(), want)
+	}
+	// The composite foreign key STORE_GROUP_CODE and the foreign key of the
+	// not generated table store_detail get ignored.
+	assert.NotContains(t, bufGo.String(), "belongsTo relation via website_code")
+	assert.NotContains(t, bufGo.String(), "belongsTo relation via group_id")
+	assert.NotContains(t, bufGo.String(), "StoreDetail")
+}
@@ -18,5 +18,14 @@
 // To generated the protocol buffer file
 // $ protoc --gogo_out=Mgoogle/protobuf/timestamp.proto=github.com/gogo/protobuf/types:. --proto_path=/Users/kiri/GoPro/src/:/Users/kiri/GoPro/src/github.com/gogo/protobuf/protobuf/:. *.proto
 //
-// TODO: Generate also protobuf code for https://github.com/twitchtv/twirp/wiki
+// TableConfig.Service generates for a table a protocol buffer CRUD service
+// and its Go server implementation, for example StoreServer for the service
+// StoreService. The server can be registered with gRPC or
+// https://github.com/twitchtv/twirp/wiki. GenerateProto with the plugins
+// "grpc" or "twirp" creates the client stubs.
+//
+// TableConfig.Relations or the option WithRelationsFromForeignKeys generate
+// has-one, has-many and belongs-to relations. For example StoreWebsite gets
+// the method LoadStoreGroupCollection and StoreWebsiteCollection eager loads
+// the groups of all websites with a single `IN (...)` query.
 package dmlgen
@@ -4,11 +4,14 @@ package testdata
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"github.com/corestoreio/errors"
 	"github.com/corestoreio/pkg/sql/ddl"
 	"github.com/corestoreio/pkg/sql/dml"
 	"github.com/corestoreio/pkg/storage/null"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -39,6 +42,7 @@ func NewTables(ctx context.Context, opts ...ddl.TableOption) (tm *ddl.Tables, er
 
 // CoreConfigData represents a single row for DB table `core_config_data`.
 // Auto generated.
+//
 //easyjson:json
 type CoreConfigData struct {
 	ConfigID  uint32      `json:"config_id,omitempty" max_len:"10"`  // config_id int(10) unsigned NOT NULL PRI  auto_increment "Id"
@@ -92,6 +96,7 @@ func (e *CoreConfigData) Empty() *CoreConfigData { *e = CoreConfigData{}; return
 
 // CoreConfigDataCollection represents a collection type for DB table core_config_data
 // Not thread safe. Auto generated.
+//
 //easyjson:json
 type CoreConfigDataCollection struct {
 	Data             []*CoreConfigData                   `json:"data,omitempty"`
@@ -267,31 +272,32 @@ func (cc *CoreConfigDataCollection) Append(n ...*CoreConfigData) *CoreConfigData
 // CustomerAddressEntity represents a single row for DB table `customer_address_entity`.
 // Auto generated.
 type CustomerAddressEntity struct {
-	EntityID          uint32      `max_len:"10"` // entity_id int(10) unsigned NOT NULL PRI  auto_increment "Entity ID"
-	IncrementID       null.String `max_len:"50"` // increment_id varchar(50) NULL  DEFAULT 'NULL'  "Increment Id"
-	ParentID          null.Uint32 `max_len:"10"` // parent_id int(10) unsigned NULL MUL DEFAULT 'NULL'  "Parent ID"
-	CreatedAt         time.Time   // created_at timestamp NOT NULL  DEFAULT 'current_timestamp()'  "Created At"
-	UpdatedAt         time.Time   // updated_at timestamp NOT NULL  DEFAULT 'current_timestamp()' on update current_timestamp() "Updated At"
-	IsActive          bool        `max_len:"5"`     // is_active smallint(5) unsigned NOT NULL  DEFAULT '1'  "Is Active"
-	City              string      `max_len:"255"`   // city varchar(255) NOT NULL    "City"
-	Company           null.String `max_len:"255"`   // company varchar(255) NULL  DEFAULT 'NULL'  "Company"
-	CountryID         string      `max_len:"255"`   // country_id varchar(255) NOT NULL    "Country"
-	Fax               null.String `max_len:"255"`   // fax varchar(255) NULL  DEFAULT 'NULL'  "Fax"
-	Firstname         string      `max_len:"255"`   // firstname varchar(255) NOT NULL    "First Name"
-	Lastname          string      `max_len:"255"`   // lastname varchar(255) NOT NULL    "Last Name"
-	Middlename        null.String `max_len:"255"`   // middlename varchar(255) NULL  DEFAULT 'NULL'  "Middle Name"
-	Postcode          null.String `max_len:"255"`   // postcode varchar(255) NULL  DEFAULT 'NULL'  "Zip/Postal Code"
-	Prefix            null.String `max_len:"40"`    // prefix varchar(40) NULL  DEFAULT 'NULL'  "Name Prefix"
-	Region            null.String `max_len:"255"`   // region varchar(255) NULL  DEFAULT 'NULL'  "State/Province"
-	RegionID          null.Uint32 `max_len:"10"`    // region_id int(10) unsigned NULL  DEFAULT 'NULL'  "State/Province"
-	Street            string      `max_len:"65535"` // street text NOT NULL    "Street Address"
-	Suffix            null.String `max_len:"40"`    // suffix varchar(40) NULL  DEFAULT 'NULL'  "Name Suffix"
-	Telephone         string      `max_len:"255"`   // telephone varchar(255) NOT NULL    "Phone Number"
-	VatID             null.String `max_len:"255"`   // vat_id varchar(255) NULL  DEFAULT 'NULL'  "VAT number"
-	VatIsValid        null.Bool   `max_len:"10"`    // vat_is_valid int(10) unsigned NULL  DEFAULT 'NULL'  "VAT number validity"
-	VatRequestDate    null.String `max_len:"255"`   // vat_request_date varchar(255) NULL  DEFAULT 'NULL'  "VAT number validation request date"
-	VatRequestID      null.String `max_len:"255"`   // vat_request_id varchar(255) NULL  DEFAULT 'NULL'  "VAT number validation request ID"
-	VatRequestSuccess null.Uint32 `max_len:"10"`    // vat_request_success int(10) unsigned NULL  DEFAULT 'NULL'  "VAT number validation request success"
+	EntityID          uint32          `max_len:"10"` // entity_id int(10) unsigned NOT NULL PRI  auto_increment "Entity ID"
+	IncrementID       null.String     `max_len:"50"` // increment_id varchar(50) NULL  DEFAULT 'NULL'  "Increment Id"
+	ParentID          null.Uint32     `max_len:"10"` // parent_id int(10) unsigned NULL MUL DEFAULT 'NULL'  "Parent ID"
+	CreatedAt         time.Time       // created_at timestamp NOT NULL  DEFAULT 'current_timestamp()'  "Created At"
+	UpdatedAt         time.Time       // updated_at timestamp NOT NULL  DEFAULT 'current_timestamp()' on update current_timestamp() "Updated At"
+	IsActive          bool            `max_len:"5"`     // is_active smallint(5) unsigned NOT NULL  DEFAULT '1'  "Is Active"
+	City              string          `max_len:"255"`   // city varchar(255) NOT NULL    "City"
+	Company           null.String     `max_len:"255"`   // company varchar(255) NULL  DEFAULT 'NULL'  "Company"
+	CountryID         string          `max_len:"255"`   // country_id varchar(255) NOT NULL    "Country"
+	Fax               null.String     `max_len:"255"`   // fax varchar(255) NULL  DEFAULT 'NULL'  "Fax"
+	Firstname         string          `max_len:"255"`   // firstname varchar(255) NOT NULL    "First Name"
+	Lastname          string          `max_len:"255"`   // lastname varchar(255) NOT NULL    "Last Name"
+	Middlename        null.String     `max_len:"255"`   // middlename varchar(255) NULL  DEFAULT 'NULL'  "Middle Name"
+	Postcode          null.String     `max_len:"255"`   // postcode varchar(255) NULL  DEFAULT 'NULL'  "Zip/Postal Code"
+	Prefix            null.String     `max_len:"40"`    // prefix varchar(40) NULL  DEFAULT 'NULL'  "Name Prefix"
+	Region            null.String     `max_len:"255"`   // region varchar(255) NULL  DEFAULT 'NULL'  "State/Province"
+	RegionID          null.Uint32     `max_len:"10"`    // region_id int(10) unsigned NULL  DEFAULT 'NULL'  "State/Province"
+	Street            string          `max_len:"65535"` // street text NOT NULL    "Street Address"
+	Suffix            null.String     `max_len:"40"`    // suffix varchar(40) NULL  DEFAULT 'NULL'  "Name Suffix"
+	Telephone         string          `max_len:"255"`   // telephone varchar(255) NOT NULL    "Phone Number"
+	VatID             null.String     `max_len:"255"`   // vat_id varchar(255) NULL  DEFAULT 'NULL'  "VAT number"
+	VatIsValid        null.Bool       `max_len:"10"`    // vat_is_valid int(10) unsigned NULL  DEFAULT 'NULL'  "VAT number validity"
+	VatRequestDate    null.String     `max_len:"255"`   // vat_request_date varchar(255) NULL  DEFAULT 'NULL'  "VAT number validation request date"
+	VatRequestID      null.String     `max_len:"255"`   // vat_request_id varchar(255) NULL  DEFAULT 'NULL'  "VAT number validation request ID"
+	VatRequestSuccess null.Uint32     `max_len:"10"`    // vat_request_success int(10) unsigned NULL  DEFAULT 'NULL'  "VAT number validation request success"
+	CustomerEntity    *CustomerEntity `faker:"-"`       // belongsTo relation via parent_id
 }
 
 // AssignLastInsertID updates the increment ID field with the last inserted ID
@@ -365,7 +371,10 @@ func (e *CustomerAddressEntity) MapColumns(cm *dml.ColumnMap) error {
 }
 
 // Empty empties all the fields of the current object. Also known as Reset.
-func (e *CustomerAddressEntity) Empty() *CustomerAddressEntity { *e = CustomerAddressEntity{}; return e }
+func (e *CustomerAddressEntity) Empty() *CustomerAddressEntity {
+	*e = CustomerAddressEntity{}
+	return e
+}
 
 // CustomerAddressEntityCollection represents a collection type for DB table customer_address_entity
 // Not thread safe. Auto generated.
@@ -383,14 +392,18 @@ func NewCustomerAddressEntityCollection() *CustomerAddressEntityCollection {
 }
 
 func (cc *CustomerAddressEntityCollection) scanColumns(cm *dml.ColumnMap, e *CustomerAddressEntity, idx uint64) error {
-	if err := cc.BeforeMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+	if cc.BeforeMapColumns != nil {
+		if err := cc.BeforeMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	if err := e.MapColumns(cm); err != nil {
 		return errors.WithStack(err)
 	}
-	if err := cc.AfterMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+	if cc.AfterMapColumns != nil {
+		if err := cc.AfterMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	return nil
 }
@@ -519,38 +532,101 @@ func (cc *CustomerAddressEntityCollection) Append(n ...*CustomerAddressEntity) *
 	return cc
 }
 
+// LoadCustomerEntity loads the belongsTo relation CustomerEntity from DB table
+// `customer_entity` via column `parent_id`. Auto generated.
+func (e *CustomerAddressEntity) LoadCustomerEntity(ctx context.Context, tbls *ddl.Tables) error {
+	return (&CustomerAddressEntityCollection{Data: []*CustomerAddressEntity{e}}).LoadCustomerEntity(ctx, tbls)
+}
+
+// LoadCustomerEntity loads the belongsTo relation CustomerEntity of all entities with
+// the query `SELECT * FROM customer_entity WHERE entity_id IN (...)`.
+// The query runs once per 1000 distinct keys to limit the number of place
+// holders. Entities whose column `parent_id` is NULL get skipped. Auto
+// generated.
+func (cc *CustomerAddressEntityCollection) LoadCustomerEntity(ctx context.Context, tbls *ddl.Tables) error {
+	idx := make(map[uint64][]*CustomerAddressEntity, len(cc.Data))
+	args := make([]uint64, 0, len(cc.Data))
+	for _, e := range cc.Data {
+		e.CustomerEntity = nil
+		if !e.ParentID.Valid {
+			continue
+		}
+		k := uint64(e.ParentID.Uint32)
+		if _, ok := idx[k]; !ok {
+			args = append(args, k)
+		}
+		idx[k] = append(idx[k], e)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	tbl, err := tbls.Table(TableNameCustomerEntity)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	const maxArgs = 1000
+	sel := tbl.Select("*").Where(
+		dml.Column("entity_id").In().PlaceHolder(),
+	).WithArgs().ExpandPlaceHolders()
+	var children []*CustomerEntity
+	for len(args) > 0 {
+		n := len(args)
+		if n > maxArgs {
+			n = maxArgs
+		}
+		// A collection drops its data when loading again, hence a new one
+		// per chunk.
+		chunk := NewCustomerEntityCollection()
+		if _, err := sel.Reset().Uint64s(args[:n]...).Load(ctx, chunk); err != nil {
+			return errors.WithStack(err)
+		}
+		children = append(children, chunk.Data...)
+		args = args[n:]
+	}
+	for _, c := range children {
+		for _, e := range idx[uint64(c.EntityID)] {
+			e.CustomerEntity = c
+		}
+	}
+	return nil
+}
+
 // CustomerEntity represents a single row for DB table `customer_entity`.
 // Auto generated.
 type CustomerEntity struct {
-	EntityID               uint32      `max_len:"10"`  // entity_id int(10) unsigned NOT NULL PRI  auto_increment "Entity ID"
-	WebsiteID              null.Uint32 `max_len:"5"`   // website_id smallint(5) unsigned NULL MUL DEFAULT 'NULL'  "Website ID"
-	Email                  null.String `max_len:"255"` // email varchar(255) NULL MUL DEFAULT 'NULL'  "Email"
-	GroupID                uint32      `max_len:"5"`   // group_id smallint(5) unsigned NOT NULL  DEFAULT '0'  "Group ID"
-	IncrementID            null.String `max_len:"50"`  // increment_id varchar(50) NULL  DEFAULT 'NULL'  "Increment Id"
-	StoreID                null.Uint32 `max_len:"5"`   // store_id smallint(5) unsigned NULL MUL DEFAULT '0'  "Store ID"
-	CreatedAt              time.Time   // created_at timestamp NOT NULL  DEFAULT 'current_timestamp()'  "Created At"
-	UpdatedAt              time.Time   // updated_at timestamp NOT NULL  DEFAULT 'current_timestamp()' on update current_timestamp() "Updated At"
-	IsActive               bool        `max_len:"5"`   // is_active smallint(5) unsigned NOT NULL  DEFAULT '1'  "Is Active"
-	DisableAutoGroupChange uint32      `max_len:"5"`   // disable_auto_group_change smallint(5) unsigned NOT NULL  DEFAULT '0'  "Disable automatic group change based on VAT ID"
-	CreatedIn              null.String `max_len:"255"` // created_in varchar(255) NULL  DEFAULT 'NULL'  "Created From"
-	Prefix                 null.String `max_len:"40"`  // prefix varchar(40) NULL  DEFAULT 'NULL'  "Name Prefix"
-	Firstname              null.String `max_len:"255"` // firstname varchar(255) NULL MUL DEFAULT 'NULL'  "First Name"
-	Middlename             null.String `max_len:"255"` // middlename varchar(255) NULL  DEFAULT 'NULL'  "Middle Name/Initial"
-	Lastname               null.String `max_len:"255"` // lastname varchar(255) NULL MUL DEFAULT 'NULL'  "Last Name"
-	Suffix                 null.String `max_len:"40"`  // suffix varchar(40) NULL  DEFAULT 'NULL'  "Name Suffix"
-	Dob                    null.Time   // dob date NULL  DEFAULT 'NULL'  "Date of Birth"
-	passwordHash           null.String `max_len:"128"` // password_hash varchar(128) NULL  DEFAULT 'NULL'  "Password_hash"
-	RpToken                null.String `max_len:"128"` // rp_token varchar(128) NULL  DEFAULT 'NULL'  "Reset password token"
-	RpTokenCreatedAt       null.Time   // rp_token_created_at datetime NULL  DEFAULT 'NULL'  "Reset password token creation time"
-	DefaultBilling         null.Uint32 `max_len:"10"` // default_billing int(10) unsigned NULL  DEFAULT 'NULL'  "Default Billing Address"
-	DefaultShipping        null.Uint32 `max_len:"10"` // default_shipping int(10) unsigned NULL  DEFAULT 'NULL'  "Default Shipping Address"
-	Taxvat                 null.String `max_len:"50"` // taxvat varchar(50) NULL  DEFAULT 'NULL'  "Tax/VAT Number"
-	Confirmation           null.String `max_len:"64"` // confirmation varchar(64) NULL  DEFAULT 'NULL'  "Is Confirmed"
-	Gender                 null.Uint32 `max_len:"5"`  // gender smallint(5) unsigned NULL  DEFAULT 'NULL'  "Gender"
-	FailuresNum            null.Int32  `max_len:"5"`  // failures_num smallint(6) NULL  DEFAULT '0'  "Failure Number"
-	FirstFailure           null.Time   // first_failure timestamp NULL  DEFAULT 'NULL'  "First Failure"
-	LockExpires            null.Time   // lock_expires timestamp NULL  DEFAULT 'NULL'  "Lock Expiration Date"
-	Addresses              CustomerAddressEntityCollection
+	EntityID                        uint32      `max_len:"10"`  // entity_id int(10) unsigned NOT NULL PRI  auto_increment "Entity ID"
+	WebsiteID                       null.Uint32 `max_len:"5"`   // website_id smallint(5) unsigned NULL MUL DEFAULT 'NULL'  "Website ID"
+	Email                           null.String `max_len:"255"` // email varchar(255) NULL MUL DEFAULT 'NULL'  "Email"
+	GroupID                         uint32      `max_len:"5"`   // group_id smallint(5) unsigned NOT NULL  DEFAULT '0'  "Group ID"
+	IncrementID                     null.String `max_len:"50"`  // increment_id varchar(50) NULL  DEFAULT 'NULL'  "Increment Id"
+	StoreID                         null.Uint32 `max_len:"5"`   // store_id smallint(5) unsigned NULL MUL DEFAULT '0'  "Store ID"
+	CreatedAt                       time.Time   // created_at timestamp NOT NULL  DEFAULT 'current_timestamp()'  "Created At"
+	UpdatedAt                       time.Time   // updated_at timestamp NOT NULL  DEFAULT 'current_timestamp()' on update current_timestamp() "Updated At"
+	IsActive                        bool        `max_len:"5"`   // is_active smallint(5) unsigned NOT NULL  DEFAULT '1'  "Is Active"
+	DisableAutoGroupChange          uint32      `max_len:"5"`   // disable_auto_group_change smallint(5) unsigned NOT NULL  DEFAULT '0'  "Disable automatic group change based on VAT ID"
+	CreatedIn                       null.String `max_len:"255"` // created_in varchar(255) NULL  DEFAULT 'NULL'  "Created From"
+	Prefix                          null.String `max_len:"40"`  // prefix varchar(40) NULL  DEFAULT 'NULL'  "Name Prefix"
+	Firstname                       null.String `max_len:"255"` // firstname varchar(255) NULL MUL DEFAULT 'NULL'  "First Name"
+	Middlename                      null.String `max_len:"255"` // middlename varchar(255) NULL  DEFAULT 'NULL'  "Middle Name/Initial"
+	Lastname                        null.String `max_len:"255"` // lastname varchar(255) NULL MUL DEFAULT 'NULL'  "Last Name"
+	Suffix                          null.String `max_len:"40"`  // suffix varchar(40) NULL  DEFAULT 'NULL'  "Name Suffix"
+	Dob                             null.Time   // dob date NULL  DEFAULT 'NULL'  "Date of Birth"
+	passwordHash                    null.String `max_len:"128"` // password_hash varchar(128) NULL  DEFAULT 'NULL'  "Password_hash"
+	RpToken                         null.String `max_len:"128"` // rp_token varchar(128) NULL  DEFAULT 'NULL'  "Reset password token"
+	RpTokenCreatedAt                null.Time   // rp_token_created_at datetime NULL  DEFAULT 'NULL'  "Reset password token creation time"
+	DefaultBilling                  null.Uint32 `max_len:"10"` // default_billing int(10) unsigned NULL  DEFAULT 'NULL'  "Default Billing Address"
+	DefaultShipping                 null.Uint32 `max_len:"10"` // default_shipping int(10) unsigned NULL  DEFAULT 'NULL'  "Default Shipping Address"
+	Taxvat                          null.String `max_len:"50"` // taxvat varchar(50) NULL  DEFAULT 'NULL'  "Tax/VAT Number"
+	Confirmation                    null.String `max_len:"64"` // confirmation varchar(64) NULL  DEFAULT 'NULL'  "Is Confirmed"
+	Gender                          null.Uint32 `max_len:"5"`  // gender smallint(5) unsigned NULL  DEFAULT 'NULL'  "Gender"
+	FailuresNum                     null.Int32  `max_len:"5"`  // failures_num smallint(6) NULL  DEFAULT '0'  "Failure Number"
+	FirstFailure                    null.Time   // first_failure timestamp NULL  DEFAULT 'NULL'  "First Failure"
+	LockExpires                     null.Time   // lock_expires timestamp NULL  DEFAULT 'NULL'  "Lock Expiration Date"
+	Addresses                       CustomerAddressEntityCollection
+	CustomerAddressEntityCollection *CustomerAddressEntityCollection `faker:"-"` // hasMany relation via entity_id
+
+	original *CustomerEntity // data as loaded from the database
 }
 
 // AssignLastInsertID updates the increment ID field with the last inserted ID
@@ -639,9 +715,138 @@ func (e *CustomerEntity) MapColumns(cm *dml.ColumnMap) error {
 			return errors.NotFound.Newf("[testdata] CustomerEntity Column %q not found", c)
 		}
 	}
+	if cm.Mode() == dml.ColumnMapScan && cm.Err() == nil {
+		e.ResetChanges()
+	}
 	return errors.WithStack(cm.Err())
 }
 
+// ResetChanges marks the current data as loaded from the database, for
+// example after a successful INSERT or UPDATE. Afterwards IsDirty returns
+// false. Loading data via MapColumns calls ResetChanges automatically. The name
+// differs from Reset because protocol buffer messages implement Reset. Auto
+// generated.
+func (e *CustomerEntity) ResetChanges() *CustomerEntity {
+	o := *e
+	o.original = nil
+	e.original = &o
+	return e
+}
+
+// Original returns a copy of the data as loaded from the database or nil if
+// the entity has not been loaded. Auto generated.
+func (e *CustomerEntity) Original() *CustomerEntity {
+	if e.original == nil {
+		return nil
+	}
+	o := *e.original
+	return &o
+}
+
+// IsDirty returns true if the entity has not been loaded from the database or
+// at least one field has been changed. Auto generated.
+func (e *CustomerEntity) IsDirty() bool {
+	return len(e.ChangedFields()) > 0
+}
+
+// ChangedFields returns the column names of the changed fields. If the entity
+// has not been loaded from the database, all column names get returned. The
+// result can be used with ddl.Table.UpdateByPK or dml.Update.AddColumns in
+// conjunction with Artisan.Record. Skip the update if the result is empty,
+// because UpdateByPK without columns writes all columns. Auto generated.
+func (e *CustomerEntity) ChangedFields() []string {
+	if e.original == nil {
+		return []string{"entity_id", "website_id", "email", "group_id", "increment_id", "store_id", "created_at", "updated_at", "is_active", "disable_auto_group_change", "created_in", "prefix", "firstname", "middlename", "lastname", "suffix", "dob", "password_hash", "rp_token", "rp_token_created_at", "default_billing", "default_shipping", "taxvat", "confirmation", "gender", "failures_num", "first_failure", "lock_expires"}
+	}
+	o := e.original
+	var cols []string
+	if e.EntityID != o.EntityID {
+		cols = append(cols, "entity_id")
+	}
+	if e.WebsiteID != o.WebsiteID {
+		cols = append(cols, "website_id")
+	}
+	if e.Email != o.Email {
+		cols = append(cols, "email")
+	}
+	if e.GroupID != o.GroupID {
+		cols = append(cols, "group_id")
+	}
+	if e.IncrementID != o.IncrementID {
+		cols = append(cols, "increment_id")
+	}
+	if e.StoreID != o.StoreID {
+		cols = append(cols, "store_id")
+	}
+	if !e.CreatedAt.Equal(o.CreatedAt) {
+		cols = append(cols, "created_at")
+	}
+	if !e.UpdatedAt.Equal(o.UpdatedAt) {
+		cols = append(cols, "updated_at")
+	}
+	if e.IsActive != o.IsActive {
+		cols = append(cols, "is_active")
+	}
+	if e.DisableAutoGroupChange != o.DisableAutoGroupChange {
+		cols = append(cols, "disable_auto_group_change")
+	}
+	if e.CreatedIn != o.CreatedIn {
+		cols = append(cols, "created_in")
+	}
+	if e.Prefix != o.Prefix {
+		cols = append(cols, "prefix")
+	}
+	if e.Firstname != o.Firstname {
+		cols = append(cols, "firstname")
+	}
+	if e.Middlename != o.Middlename {
+		cols = append(cols, "middlename")
+	}
+	if e.Lastname != o.Lastname {
+		cols = append(cols, "lastname")
+	}
+	if e.Suffix != o.Suffix {
+		cols = append(cols, "suffix")
+	}
+	if e.Dob.Valid != o.Dob.Valid || !e.Dob.Time.Equal(o.Dob.Time) {
+		cols = append(cols, "dob")
+	}
+	if e.passwordHash != o.passwordHash {
+		cols = append(cols, "password_hash")
+	}
+	if e.RpToken != o.RpToken {
+		cols = append(cols, "rp_token")
+	}
+	if e.RpTokenCreatedAt.Valid != o.RpTokenCreatedAt.Valid || !e.RpTokenCreatedAt.Time.Equal(o.RpTokenCreatedAt.Time) {
+		cols = append(cols, "rp_token_created_at")
+	}
+	if e.DefaultBilling != o.DefaultBilling {
+		cols = append(cols, "default_billing")
+	}
+	if e.DefaultShipping != o.DefaultShipping {
+		cols = append(cols, "default_shipping")
+	}
+	if e.Taxvat != o.Taxvat {
+		cols = append(cols, "taxvat")
+	}
+	if e.Confirmation != o.Confirmation {
+		cols = append(cols, "confirmation")
+	}
+	if e.Gender != o.Gender {
+		cols = append(cols, "gender")
+	}
+	if e.FailuresNum != o.FailuresNum {
+		cols = append(cols, "failures_num")
+	}
+	if e.FirstFailure.Valid != o.FirstFailure.Valid || !e.FirstFailure.Time.Equal(o.FirstFailure.Time) {
+		cols = append(cols, "first_failure")
+	}
+	if e.LockExpires.Valid != o.LockExpires.Valid || !e.LockExpires.Time.Equal(o.LockExpires.Time) {
+		cols = append(cols, "lock_expires")
+	}
+	return cols
+}
+
 // Empty empties all the fields of the current object. Also known as Reset.
 func (e *CustomerEntity) Empty() *CustomerEntity { *e = CustomerEntity{}; return e }
 
@@ -661,14 +866,18 @@ func NewCustomerEntityCollection() *CustomerEntityCollection {
 }
 
 func (cc *CustomerEntityCollection) scanColumns(cm *dml.ColumnMap, e *CustomerEntity, idx uint64) error {
-	if err := cc.BeforeMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+	if cc.BeforeMapColumns != nil {
+		if err := cc.BeforeMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	if err := e.MapColumns(cm); err != nil {
 		return errors.WithStack(err)
 	}
-	if err := cc.AfterMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+	if cc.AfterMapColumns != nil {
+		if err := cc.AfterMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	return nil
 }
@@ -795,52 +1004,456 @@ func (cc *CustomerEntityCollection) Append(n ...*CustomerEntity) *CustomerEntity
 	return cc
 }
 
+// condition checks the filter against the columns of DB table
+// `customer_entity` and converts it into a WHERE condition. Supported operators
+// are: = (default), !=, <, <=, >, >=, like, not like, in, not in, null and not
+// null. Auto generated.
+func (f *CustomerEntityListFilter) condition(tbl *ddl.Table) (*dml.Condition, error) {
+	if !tbl.Columns.Contains(f.Column) {
+		return nil, errors.NotAllowed.Newf("[testdata] CustomerEntityListFilter Column %q not allowed", f.Column)
+	}
+	c := dml.Column(f.Column)
+	switch op := strings.ToLower(f.Operator); op {
+	case "null":
+		return c.Null(), nil
+	case "not null":
+		return c.NotNull(), nil
+	case "in", "not in":
+		if len(f.Values) == 0 {
+			return nil, errors.NotValid.Newf("[testdata] CustomerEntityListFilter Column %q requires at least one value", f.Column)
+		}
+		if op == "in" {
+			return c.In().Strs(f.Values...), nil
+		}
+		return c.NotIn().Strs(f.Values...), nil
+	case "", "=":
+		c = c.Equal()
+	case "!=":
+		c = c.NotEqual()
+	case "<":
+		c = c.Less()
+	case "<=":
+		c = c.LessOrEqual()
+	case ">":
+		c = c.Greater()
+	case ">=":
+		c = c.GreaterOrEqual()
+	case "like":
+		c = c.Like()
+	case "not like":
+		c = c.NotLike()
+	default:
+		return nil, errors.NotSupported.Newf("[testdata] CustomerEntityListFilter Operator %q not supported", f.Operator)
+	}
+	if len(f.Values) != 1 {
+		return nil, errors.NotValid.Newf("[testdata] CustomerEntityListFilter Column %q requires exactly one value", f.Column)
+	}
+	return c.Str(f.Values[0]), nil
+}
+
+// CustomerEntityServer implements the CRUD service for DB table `customer_entity`
+// as defined in the protocol buffer service CustomerEntityService. The request
+// and response types get generated from the protocol buffer messages. It can
+// be registered with a gRPC server or wrapped by a Twirp server. The name
+// differs from the service because the Twirp plugin generates the
+// CustomerEntityService interface. Auto generated.
+type CustomerEntityServer struct {
+	Tables *ddl.Tables
+	// MaxPageSize limits the rows returned by List. Defaults to 100.
+	MaxPageSize uint32
+}
+
+func (s *CustomerEntityServer) primaryKey(e *CustomerEntity) *CustomerEntityPrimaryKey {
+	return &CustomerEntityPrimaryKey{
+		EntityID: e.EntityID,
+	}
+}
+
+func (s *CustomerEntityServer) entity(pk *CustomerEntityPrimaryKey) *CustomerEntity {
+	return &CustomerEntity{
+		EntityID: pk.EntityID,
+	}
+}
+
+// Get loads a single row by its primary key. Returns a NotFound error if the
+// row does not exist. Auto generated.
+func (s *CustomerEntityServer) Get(ctx context.Context, pk *CustomerEntityPrimaryKey) (*CustomerEntity, error) {
+	tbl, err := s.Tables.Table(TableNameCustomerEntity)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	e := s.entity(pk)
+	rowCount, err := tbl.SelectByPK().WithArgs().Record("", e).Load(ctx, e)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if rowCount == 0 {
+		return nil, errors.NotFound.Newf("[testdata] CustomerEntityServer.Get: Row %#v not found", pk)
+	}
+	return e, nil
+}
+
+// List loads a page of rows ordered by the primary key and restricted by the
+// filters. Pagination uses the primary key of the last row (keyset
+// pagination), hence the page token stays valid while rows get inserted or
+// deleted. Auto generated.
+func (s *CustomerEntityServer) List(ctx context.Context, req *CustomerEntityListRequest) (*CustomerEntityListResponse, error) {
+	tbl, err := s.Tables.Table(TableNameCustomerEntity)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	maxPageSize := s.MaxPageSize
+	if maxPageSize == 0 {
+		maxPageSize = 100
+	}
+	pageSize := req.PageSize
+	if pageSize == 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	sel := tbl.Select("*").OrderBy("entity_id").Limit(0, uint64(pageSize)+1)
+	for _, f := range req.Filters {
+		c, err := f.condition(tbl)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		sel.Where(c)
+	}
+
+	var last *CustomerEntity
+	if req.PageToken != "" {
+		data, err := base64.RawURLEncoding.DecodeString(req.PageToken)
+		if err != nil {
+			return nil, errors.BadEncoding.New(err, "[testdata] CustomerEntityServer.List: Invalid page token %q", req.PageToken)
+		}
+		var pk CustomerEntityPrimaryKey
+		if err := json.Unmarshal(data, &pk); err != nil {
+			return nil, errors.BadEncoding.New(err, "[testdata] CustomerEntityServer.List: Invalid page token %q", req.PageToken)
+		}
+		sel.Where(
+			dml.ParenthesisOpen(),
+			dml.ParenthesisOpen(),
+			dml.Column("entity_id").Greater().PlaceHolder(),
+			dml.ParenthesisClose(),
+			dml.ParenthesisClose(),
+		)
+		last = s.entity(&pk)
+	}
+
+	a := sel.WithArgs()
+	if last != nil {
+		a = a.Record("", last)
+	}
+	cc := NewCustomerEntityCollection()
+	if _, err := a.Load(ctx, cc); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	res := &CustomerEntityListResponse{Data: cc.Data}
+	if len(cc.Data) > int(pageSize) {
+		res.Data = cc.Data[:pageSize]
+		data, err := json.Marshal(s.primaryKey(res.Data[pageSize-1]))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		res.NextPageToken = base64.RawURLEncoding.EncodeToString(data)
+	}
+	return res, nil
+}
+
+// Create inserts a new row and returns it as stored in the database. An auto
+// increment primary key gets assigned to `e`. Auto generated.
+func (s *CustomerEntityServer) Create(ctx context.Context, e *CustomerEntity) (*CustomerEntity, error) {
+	tbl, err := s.Tables.Table(TableNameCustomerEntity)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if _, err := tbl.Insert().WithArgs().Record("", e).ExecContext(ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return s.Get(ctx, s.primaryKey(e))
+}
+
+// Update compares `e` with the stored row, writes only the changed non primary
+// key columns and returns the row as stored in the database. If nothing has
+// changed, no UPDATE statement gets executed. Auto generated.
+func (s *CustomerEntityServer) Update(ctx context.Context, e *CustomerEntity) (*CustomerEntity, error) {
+	tbl, err := s.Tables.Table(TableNameCustomerEntity)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	cur, err := s.Get(ctx, s.primaryKey(e))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	rec := *e // ExecContext would assign the last insert ID, which is zero, to e.
+	rec.original = cur.original
+	cols := rec.ChangedFields()
+	if len(cols) == 0 {
+		return cur, nil // UpdateByPK without columns would write all columns
+	}
+	upd := tbl.UpdateByPK(cols...)
+	if len(upd.SetClauses) == 0 {
+		return cur, nil // only primary key or generated columns have changed
+	}
+	if _, err := upd.WithArgs().Record("", &rec).ExecContext(ctx); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return s.Get(ctx, s.primaryKey(e))
+}
+
+// Delete removes a single row by its primary key. Auto generated.
+func (s *CustomerEntityServer) Delete(ctx context.Context, pk *CustomerEntityPrimaryKey) (*CustomerEntityDeleteResponse, error) {
+	tbl, err := s.Tables.Table(TableNameCustomerEntity)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	res, err := tbl.DeleteByPK().WithArgs().Record("", s.entity(pk)).ExecContext(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &CustomerEntityDeleteResponse{RowsAffected: uint64(rowsAffected)}, nil
+}
+
+// LoadCustomerAddressEntityCollection loads the hasMany relation CustomerAddressEntityCollection from DB table
+// `customer_address_entity` via column `entity_id`. Auto generated.
+func (e *CustomerEntity) LoadCustomerAddressEntityCollection(ctx context.Context, tbls *ddl.Tables) error {
+	return (&CustomerEntityCollection{Data: []*CustomerEntity{e}}).LoadCustomerAddressEntityCollection(ctx, tbls)
+}
+
+// LoadCustomerAddressEntityCollection loads the hasMany relation CustomerAddressEntityCollection of all entities with
+// the query `SELECT * FROM customer_address_entity WHERE parent_id IN (...)`.
+// The query runs once per 1000 distinct keys to limit the number of place
+// holders. Entities whose column `entity_id` is NULL get skipped. Auto
+// generated.
+func (cc *CustomerEntityCollection) LoadCustomerAddressEntityCollection(ctx context.Context, tbls *ddl.Tables) error {
+	idx := make(map[uint64][]*CustomerEntity, len(cc.Data))
+	args := make([]uint64, 0, len(cc.Data))
+	for _, e := range cc.Data {
+		e.CustomerAddressEntityCollection = NewCustomerAddressEntityCollection()
+		k := uint64(e.EntityID)
+		if _, ok := idx[k]; !ok {
+			args = append(args, k)
+		}
+		idx[k] = append(idx[k], e)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	tbl, err := tbls.Table(TableNameCustomerAddressEntity)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	const maxArgs = 1000
+	sel := tbl.Select("*").Where(
+		dml.Column("parent_id").In().PlaceHolder(),
+	).WithArgs().ExpandPlaceHolders()
+	var children []*CustomerAddressEntity
+	for len(args) > 0 {
+		n := len(args)
+		if n > maxArgs {
+			n = maxArgs
+		}
+		// A collection drops its data when loading again, hence a new one
+		// per chunk.
+		chunk := NewCustomerAddressEntityCollection()
+		if _, err := sel.Reset().Uint64s(args[:n]...).Load(ctx, chunk); err != nil {
+			return errors.WithStack(err)
+		}
+		children = append(children, chunk.Data...)
+		args = args[n:]
+	}
+	for _, c := range children {
+		if !c.ParentID.Valid {
+			continue
+		}
+		for _, e := range idx[uint64(c.ParentID.Uint32)] {
+			e.CustomerAddressEntityCollection.Data = append(e.CustomerAddressEntityCollection.Data, c)
+		}
+	}
+	return nil
+}
+
+// DmlgenTypesColEnum represents the allowed values of an ENUM column. An empty value
+// gets treated as NULL. Auto generated.
+type DmlgenTypesColEnum string
+
+// Allowed values of type DmlgenTypesColEnum. Auto generated.
+const (
+	DmlgenTypesColEnumSmall  DmlgenTypesColEnum = "small"
+	DmlgenTypesColEnumMedium DmlgenTypesColEnum = "medium"
+	DmlgenTypesColEnumXLarge DmlgenTypesColEnum = "x-large"
+)
+
+var dmlgenTypesColEnumValues = [...]DmlgenTypesColEnum{DmlgenTypesColEnumSmall, DmlgenTypesColEnumMedium, DmlgenTypesColEnumXLarge}
+
+// Values returns all allowed values. Auto generated.
+func (DmlgenTypesColEnum) Values() []DmlgenTypesColEnum {
+	return append([]DmlgenTypesColEnum(nil), dmlgenTypesColEnumValues[:]...)
+}
+
+// Validate returns an error with Kind NotValid if the value is not allowed.
+// Auto generated.
+func (e DmlgenTypesColEnum) Validate() error {
+	if e == "" {
+		return nil
+	}
+	for _, v := range dmlgenTypesColEnumValues {
+		if v == e {
+			return nil
+		}
+	}
+	return errors.NotValid.Newf("[testdata] DmlgenTypesColEnum value %q not allowed", string(e))
+}
+
+// String implements fmt.Stringer. Auto generated.
+func (e DmlgenTypesColEnum) String() string { return string(e) }
+
+// MarshalText implements encoding.TextMarshaler. An empty value returns nil,
+// which represents NULL. Auto generated.
+func (e DmlgenTypesColEnum) MarshalText() ([]byte, error) {
+	if err := e.Validate(); err != nil || e == "" {
+		return nil, err
+	}
+	return []byte(e), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Auto generated.
+func (e *DmlgenTypesColEnum) UnmarshalText(text []byte) error {
+	v := DmlgenTypesColEnum(text)
+	if err := v.Validate(); err != nil {
+		return errors.WithStack(err)
+	}
+	*e = v
+	return nil
+}
+
+// DmlgenTypesColSet represents the members of a SET column as a bitset. Auto generated.
+type DmlgenTypesColSet uint64
+
+// Members of type DmlgenTypesColSet. Auto generated.
+const (
+	DmlgenTypesColSetNew DmlgenTypesColSet = 1 << iota
+	DmlgenTypesColSetSale
+	DmlgenTypesColSetOutlet
+)
+
+const dmlgenTypesColSetMask DmlgenTypesColSet = 1<<3 - 1
+
+var dmlgenTypesColSetValues = [...]string{"new", "sale", "outlet"}
+
+// Has returns true if all members of v are set. Auto generated.
+func (s DmlgenTypesColSet) Has(v DmlgenTypesColSet) bool { return s&v == v }
+
+// Validate returns an error with Kind NotValid if unknown bits are set. Auto
+// generated.
+func (s DmlgenTypesColSet) Validate() error {
+	if s&^dmlgenTypesColSetMask != 0 {
+		return errors.NotValid.Newf("[testdata] DmlgenTypesColSet value %d contains unknown members", uint64(s))
+	}
+	return nil
+}
+
+// String implements fmt.Stringer and returns the comma separated list of
+// members. Auto generated.
+func (s DmlgenTypesColSet) String() string {
+	var buf strings.Builder
+	for i, v := range dmlgenTypesColSetValues {
+		if s&(1<<uint(i)) != 0 {
+			if buf.Len() > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(v)
+		}
+	}
+	return buf.String()
+}
+
+// MarshalText implements encoding.TextMarshaler. An empty set returns a non-nil
+// empty slice. Auto generated.
+func (s DmlgenTypesColSet) MarshalText() ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return append(make([]byte, 0, 16), s.String()...), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Auto generated.
+func (s *DmlgenTypesColSet) UnmarshalText(text []byte) error {
+	var v DmlgenTypesColSet
+	if len(text) > 0 {
+		for _, member := range strings.Split(string(text), ",") {
+			found := false
+			for i, m := range dmlgenTypesColSetValues {
+				if m == member {
+					v |= 1 << uint(i)
+					found = true
+				}
+			}
+			if !found {
+				return errors.NotValid.Newf("[testdata] DmlgenTypesColSet member %q not allowed", member)
+			}
+		}
+	}
+	*s = v
+	return nil
+}
+
 // DmlgenTypes represents a single row for DB table `dmlgen_types`.
 // Auto generated.
 // Just another comment.
+//
 //easyjson:json
 type DmlgenTypes struct {
-	ID             int32        `json:"id,omitempty"  max_len:"10"`                     // id int(11) NOT NULL PRI  auto_increment ""
-	ColBigint1     null.Int64   `json:"col_bigint_1,omitempty"  max_len:"19"`           // col_bigint_1 bigint(20) NULL  DEFAULT 'NULL'  ""
-	ColBigint2     int64        `json:"col_bigint_2,omitempty"  max_len:"19"`           // col_bigint_2 bigint(20) NOT NULL  DEFAULT '0'  ""
-	ColBigint3     null.Uint64  `json:"col_bigint_3,omitempty"  max_len:"20"`           // col_bigint_3 bigint(20) unsigned NULL  DEFAULT 'NULL'  ""
-	ColBigint4     uint64       `json:"col_bigint_4,omitempty"  max_len:"20"`           // col_bigint_4 bigint(20) unsigned NOT NULL  DEFAULT '0'  ""
-	ColBlob        []byte       `json:"col_blob,omitempty"  max_len:"65535"`            // col_blob blob NULL  DEFAULT 'NULL'  ""
-	ColDate1       null.Time    `json:"col_date_1,omitempty"  `                         // col_date_1 date NULL  DEFAULT 'NULL'  ""
-	ColDate2       time.Time    `json:"col_date_2,omitempty"  `                         // col_date_2 date NOT NULL  DEFAULT ''0000-00-00''  ""
-	ColDatetime1   null.Time    `json:"col_datetime_1,omitempty"  `                     // col_datetime_1 datetime NULL  DEFAULT 'NULL'  ""
-	ColDatetime2   time.Time    `json:"col_datetime_2,omitempty"  `                     // col_datetime_2 datetime NOT NULL  DEFAULT ''0000-00-00 00:00:00''  ""
-	ColDecimal101  null.Decimal `json:"col_decimal_10_1,omitempty"  max_len:"10"`       // col_decimal_10_1 decimal(10,1) unsigned NULL  DEFAULT 'NULL'  ""
-	ColDecimal124  null.Decimal `json:"col_decimal_12_4,omitempty"  max_len:"12"`       // col_decimal_12_4 decimal(12,4) NULL  DEFAULT 'NULL'  ""
-	Price124a      null.Decimal `json:"price_12_4a,omitempty"  max_len:"12"`            // price_12_4a decimal(12,4) NULL  DEFAULT 'NULL'  ""
-	Price124b      null.Decimal `json:"price_12_4b,omitempty"  max_len:"12"`            // price_12_4b decimal(12,4) NOT NULL  DEFAULT '0.0000'  ""
-	ColDecimal123  null.Decimal `json:"col_decimal_12_3,omitempty"  max_len:"12"`       // col_decimal_12_3 decimal(12,3) NOT NULL  DEFAULT '0.000'  ""
-	ColDecimal206  null.Decimal `json:"col_decimal_20_6,omitempty"  max_len:"20"`       // col_decimal_20_6 decimal(20,6) NOT NULL  DEFAULT '0.000000'  ""
-	ColDecimal2412 null.Decimal `json:"col_decimal_24_12,omitempty"  max_len:"24"`      // col_decimal_24_12 decimal(24,12) NOT NULL  DEFAULT '0.000000000000'  ""
-	ColInt1        null.Int32   `json:"col_int_1,omitempty"  max_len:"10"`              // col_int_1 int(10) NULL  DEFAULT 'NULL'  ""
-	ColInt2        int32        `json:"col_int_2,omitempty"  max_len:"10"`              // col_int_2 int(10) NOT NULL  DEFAULT '0'  ""
-	ColInt3        null.Uint32  `json:"col_int_3,omitempty"  max_len:"10"`              // col_int_3 int(10) unsigned NULL  DEFAULT 'NULL'  ""
-	ColInt4        uint32       `json:"col_int_4,omitempty"  max_len:"10"`              // col_int_4 int(10) unsigned NOT NULL  DEFAULT '0'  ""
-	ColLongtext1   null.String  `json:"col_longtext_1,omitempty"  max_len:"4294967295"` // col_longtext_1 longtext NULL  DEFAULT 'NULL'  ""
-	ColLongtext2   string       `json:"col_longtext_2,omitempty"  max_len:"4294967295"` // col_longtext_2 longtext NOT NULL  DEFAULT ''''  ""
-	ColMediumblob  []byte       `json:"col_mediumblob,omitempty"  max_len:"16777215"`   // col_mediumblob mediumblob NULL  DEFAULT 'NULL'  ""
-	ColMediumtext1 null.String  `json:"col_mediumtext_1,omitempty"  max_len:"16777215"` // col_mediumtext_1 mediumtext NULL  DEFAULT 'NULL'  ""
-	ColMediumtext2 string       `json:"col_mediumtext_2,omitempty"  max_len:"16777215"` // col_mediumtext_2 mediumtext NOT NULL  DEFAULT ''''  ""
-	ColSmallint1   null.Int32   `json:"col_smallint_1,omitempty"  max_len:"5"`          // col_smallint_1 smallint(5) NULL  DEFAULT 'NULL'  ""
-	ColSmallint2   int32        `json:"col_smallint_2,omitempty"  max_len:"5"`          // col_smallint_2 smallint(5) NOT NULL  DEFAULT '0'  ""
-	ColSmallint3   null.Uint32  `json:"col_smallint_3,omitempty"  max_len:"5"`          // col_smallint_3 smallint(5) unsigned NULL  DEFAULT 'NULL'  ""
-	ColSmallint4   uint32       `json:"col_smallint_4,omitempty"  max_len:"5"`          // col_smallint_4 smallint(5) unsigned NOT NULL  DEFAULT '0'  ""
-	HasSmallint5   bool         `json:"has_smallint_5,omitempty"  max_len:"5"`          // has_smallint_5 smallint(5) unsigned NOT NULL  DEFAULT '0'  ""
-	IsSmallint5    null.Bool    `json:"is_smallint_5,omitempty"  max_len:"5"`           // is_smallint_5 smallint(5) NULL  DEFAULT 'NULL'  ""
-	ColText        null.String  `json:"col_text,omitempty"  max_len:"65535"`            // col_text text NULL  DEFAULT 'NULL'  ""
-	ColTimestamp1  time.Time    `json:"col_timestamp_1,omitempty"  `                    // col_timestamp_1 timestamp NOT NULL  DEFAULT 'current_timestamp()'  ""
-	ColTimestamp2  null.Time    `json:"col_timestamp_2,omitempty"  `                    // col_timestamp_2 timestamp NULL  DEFAULT 'NULL'  ""
-	ColTinyint1    int32        `json:"col_tinyint_1,omitempty"  max_len:"3"`           // col_tinyint_1 tinyint(1) NOT NULL  DEFAULT '0'  ""
-	ColVarchar1    string       `json:"col_varchar_1,omitempty"  max_len:"1"`           // col_varchar_1 varchar(1) NOT NULL  DEFAULT ''0''  ""
-	ColVarchar100  null.String  `json:"col_varchar_100,omitempty"  max_len:"100"`       // col_varchar_100 varchar(100) NULL  DEFAULT 'NULL'  ""
-	ColVarchar16   string       `json:"col_varchar_16,omitempty"  max_len:"16"`         // col_varchar_16 varchar(16) NOT NULL  DEFAULT ''de_DE''  ""
-	ColChar1       null.String  `json:"col_char_1,omitempty"  max_len:"21"`             // col_char_1 char(21) NULL  DEFAULT 'NULL'  ""
-	ColChar2       string       `json:"col_char_2,omitempty"  max_len:"17"`             // col_char_2 char(17) NOT NULL  DEFAULT ''xchar''  ""
+	ID             int32              `json:"id,omitempty"  max_len:"10"`                     // id int(11) NOT NULL PRI  auto_increment ""
+	ColBigint1     null.Int64         `json:"col_bigint_1,omitempty"  max_len:"19"`           // col_bigint_1 bigint(20) NULL  DEFAULT 'NULL'  ""
+	ColBigint2     int64              `json:"col_bigint_2,omitempty"  max_len:"19"`           // col_bigint_2 bigint(20) NOT NULL  DEFAULT '0'  ""
+	ColBigint3     null.Uint64        `json:"col_bigint_3,omitempty"  max_len:"20"`           // col_bigint_3 bigint(20) unsigned NULL  DEFAULT 'NULL'  ""
+	ColBigint4     uint64             `json:"col_bigint_4,omitempty"  max_len:"20"`           // col_bigint_4 bigint(20) unsigned NOT NULL  DEFAULT '0'  ""
+	ColBlob        []byte             `json:"col_blob,omitempty"  max_len:"65535"`            // col_blob blob NULL  DEFAULT 'NULL'  ""
+	ColDate1       null.Time          `json:"col_date_1,omitempty"  `                         // col_date_1 date NULL  DEFAULT 'NULL'  ""
+	ColDate2       time.Time          `json:"col_date_2,omitempty"  `                         // col_date_2 date NOT NULL  DEFAULT ''0000-00-00''  ""
+	ColDatetime1   null.Time          `json:"col_datetime_1,omitempty"  `                     // col_datetime_1 datetime NULL  DEFAULT 'NULL'  ""
+	ColDatetime2   time.Time          `json:"col_datetime_2,omitempty"  `                     // col_datetime_2 datetime NOT NULL  DEFAULT ''0000-00-00 00:00:00''  ""
+	ColDecimal101  null.Decimal       `json:"col_decimal_10_1,omitempty"  max_len:"10"`       // col_decimal_10_1 decimal(10,1) unsigned NULL  DEFAULT 'NULL'  ""
+	ColDecimal124  null.Decimal       `json:"col_decimal_12_4,omitempty"  max_len:"12"`       // col_decimal_12_4 decimal(12,4) NULL  DEFAULT 'NULL'  ""
+	Price124a      null.Decimal       `json:"price_12_4a,omitempty"  max_len:"12"`            // price_12_4a decimal(12,4) NULL  DEFAULT 'NULL'  ""
+	Price124b      null.Decimal       `json:"price_12_4b,omitempty"  max_len:"12"`            // price_12_4b decimal(12,4) NOT NULL  DEFAULT '0.0000'  ""
+	ColDecimal123  null.Decimal       `json:"col_decimal_12_3,omitempty"  max_len:"12"`       // col_decimal_12_3 decimal(12,3) NOT NULL  DEFAULT '0.000'  ""
+	ColDecimal206  null.Decimal       `json:"col_decimal_20_6,omitempty"  max_len:"20"`       // col_decimal_20_6 decimal(20,6) NOT NULL  DEFAULT '0.000000'  ""
+	ColDecimal2412 null.Decimal       `json:"col_decimal_24_12,omitempty"  max_len:"24"`      // col_decimal_24_12 decimal(24,12) NOT NULL  DEFAULT '0.000000000000'  ""
+	ColInt1        null.Int32         `json:"col_int_1,omitempty"  max_len:"10"`              // col_int_1 int(10) NULL  DEFAULT 'NULL'  ""
+	ColInt2        int32              `json:"col_int_2,omitempty"  max_len:"10"`              // col_int_2 int(10) NOT NULL  DEFAULT '0'  ""
+	ColInt3        null.Uint32        `json:"col_int_3,omitempty"  max_len:"10"`              // col_int_3 int(10) unsigned NULL  DEFAULT 'NULL'  ""
+	ColInt4        uint32             `json:"col_int_4,omitempty"  max_len:"10"`              // col_int_4 int(10) unsigned NOT NULL  DEFAULT '0'  ""
+	ColLongtext1   null.String        `json:"col_longtext_1,omitempty"  max_len:"4294967295"` // col_longtext_1 longtext NULL  DEFAULT 'NULL'  ""
+	ColLongtext2   string             `json:"col_longtext_2,omitempty"  max_len:"4294967295"` // col_longtext_2 longtext NOT NULL  DEFAULT ''''  ""
+	ColMediumblob  []byte             `json:"col_mediumblob,omitempty"  max_len:"16777215"`   // col_mediumblob mediumblob NULL  DEFAULT 'NULL'  ""
+	ColMediumtext1 null.String        `json:"col_mediumtext_1,omitempty"  max_len:"16777215"` // col_mediumtext_1 mediumtext NULL  DEFAULT 'NULL'  ""
+	ColMediumtext2 string             `json:"col_mediumtext_2,omitempty"  max_len:"16777215"` // col_mediumtext_2 mediumtext NOT NULL  DEFAULT ''''  ""
+	ColSmallint1   null.Int32         `json:"col_smallint_1,omitempty"  max_len:"5"`          // col_smallint_1 smallint(5) NULL  DEFAULT 'NULL'  ""
+	ColSmallint2   int32              `json:"col_smallint_2,omitempty"  max_len:"5"`          // col_smallint_2 smallint(5) NOT NULL  DEFAULT '0'  ""
+	ColSmallint3   null.Uint32        `json:"col_smallint_3,omitempty"  max_len:"5"`          // col_smallint_3 smallint(5) unsigned NULL  DEFAULT 'NULL'  ""
+	ColSmallint4   uint32             `json:"col_smallint_4,omitempty"  max_len:"5"`          // col_smallint_4 smallint(5) unsigned NOT NULL  DEFAULT '0'  ""
+	HasSmallint5   bool               `json:"has_smallint_5,omitempty"  max_len:"5"`          // has_smallint_5 smallint(5) unsigned NOT NULL  DEFAULT '0'  ""
+	IsSmallint5    null.Bool          `json:"is_smallint_5,omitempty"  max_len:"5"`           // is_smallint_5 smallint(5) NULL  DEFAULT 'NULL'  ""
+	ColText        null.String        `json:"col_text,omitempty"  max_len:"65535"`            // col_text text NULL  DEFAULT 'NULL'  ""
+	ColTimestamp1  time.Time          `json:"col_timestamp_1,omitempty"  `                    // col_timestamp_1 timestamp NOT NULL  DEFAULT 'current_timestamp()'  ""
+	ColTimestamp2  null.Time          `json:"col_timestamp_2,omitempty"  `                    // col_timestamp_2 timestamp NULL  DEFAULT 'NULL'  ""
+	ColTinyint1    int32              `json:"col_tinyint_1,omitempty"  max_len:"3"`           // col_tinyint_1 tinyint(1) NOT NULL  DEFAULT '0'  ""
+	ColVarchar1    string             `json:"col_varchar_1,omitempty"  max_len:"1"`           // col_varchar_1 varchar(1) NOT NULL  DEFAULT ''0''  ""
+	ColVarchar100  null.String        `json:"col_varchar_100,omitempty"  max_len:"100"`       // col_varchar_100 varchar(100) NULL  DEFAULT 'NULL'  ""
+	ColVarchar16   string             `json:"col_varchar_16,omitempty"  max_len:"16"`         // col_varchar_16 varchar(16) NOT NULL  DEFAULT ''de_DE''  ""
+	ColChar1       null.String        `json:"col_char_1,omitempty"  max_len:"21"`             // col_char_1 char(21) NULL  DEFAULT 'NULL'  ""
+	ColChar2       string             `json:"col_char_2,omitempty"  max_len:"17"`             // col_char_2 char(17) NOT NULL  DEFAULT ''xchar''  ""
+	ColEnum        DmlgenTypesColEnum `json:"col_enum,omitempty"  max_len:"7"`                // col_enum enum('small','medium','x-large') NULL  DEFAULT 'NULL'  ""
+	ColSet         DmlgenTypesColSet  `json:"col_set,omitempty"  max_len:"15"`                // col_set set('new','sale','outlet') NOT NULL  DEFAULT ''''  ""
+	ColJSON        null.String        `json:"col_json,omitempty"  max_len:"4294967295"`       // col_json longtext NULL  DEFAULT 'NULL'  ""
+	ColPoint       null.Geometry      `json:"col_point,omitempty"  `                          // col_point point NULL  DEFAULT 'NULL'  ""
 }
 
 // AssignLastInsertID updates the increment ID field with the last inserted ID
@@ -852,7 +1465,7 @@ func (e *DmlgenTypes) AssignLastInsertID(id int64) {
 // MapColumns implements interface ColumnMapper only partially. Auto generated.
 func (e *DmlgenTypes) MapColumns(cm *dml.ColumnMap) error {
 	if cm.Mode() == dml.ColumnMapEntityReadAll {
-		return cm.Int32(&e.ID).NullInt64(&e.ColBigint1).Int64(&e.ColBigint2).NullUint64(&e.ColBigint3).Uint64(&e.ColBigint4).Byte(&e.ColBlob).NullTime(&e.ColDate1).Time(&e.ColDate2).NullTime(&e.ColDatetime1).Time(&e.ColDatetime2).Decimal(&e.ColDecimal101).Decimal(&e.ColDecimal124).Decimal(&e.Price124a).Decimal(&e.Price124b).Decimal(&e.ColDecimal123).Decimal(&e.ColDecimal206).Decimal(&e.ColDecimal2412).NullInt32(&e.ColInt1).Int32(&e.ColInt2).NullUint32(&e.ColInt3).Uint32(&e.ColInt4).NullString(&e.ColLongtext1).String(&e.ColLongtext2).Byte(&e.ColMediumblob).NullString(&e.ColMediumtext1).String(&e.ColMediumtext2).NullInt32(&e.ColSmallint1).Int32(&e.ColSmallint2).NullUint32(&e.ColSmallint3).Uint32(&e.ColSmallint4).Bool(&e.HasSmallint5).NullBool(&e.IsSmallint5).NullString(&e.ColText).Time(&e.ColTimestamp1).NullTime(&e.ColTimestamp2).Int32(&e.ColTinyint1).String(&e.ColVarchar1).NullString(&e.ColVarchar100).String(&e.ColVarchar16).NullString(&e.ColChar1).String(&e.ColChar2).Err()
+		return cm.Int32(&e.ID).NullInt64(&e.ColBigint1).Int64(&e.ColBigint2).NullUint64(&e.ColBigint3).Uint64(&e.ColBigint4).Byte(&e.ColBlob).NullTime(&e.ColDate1).Time(&e.ColDate2).NullTime(&e.ColDatetime1).Time(&e.ColDatetime2).Decimal(&e.ColDecimal101).Decimal(&e.ColDecimal124).Decimal(&e.Price124a).Decimal(&e.Price124b).Decimal(&e.ColDecimal123).Decimal(&e.ColDecimal206).Decimal(&e.ColDecimal2412).NullInt32(&e.ColInt1).Int32(&e.ColInt2).NullUint32(&e.ColInt3).Uint32(&e.ColInt4).NullString(&e.ColLongtext1).String(&e.ColLongtext2).Byte(&e.ColMediumblob).NullString(&e.ColMediumtext1).String(&e.ColMediumtext2).NullInt32(&e.ColSmallint1).Int32(&e.ColSmallint2).NullUint32(&e.ColSmallint3).Uint32(&e.ColSmallint4).Bool(&e.HasSmallint5).NullBool(&e.IsSmallint5).NullString(&e.ColText).Time(&e.ColTimestamp1).NullTime(&e.ColTimestamp2).Int32(&e.ColTinyint1).String(&e.ColVarchar1).NullString(&e.ColVarchar100).String(&e.ColVarchar16).NullString(&e.ColChar1).String(&e.ColChar2).Text(&e.ColEnum).Text(&e.ColSet).NullString(&e.ColJSON).Binary(&e.ColPoint).Err()
 	}
 	for cm.Next() {
 		switch c := cm.Column(); c {
@@ -938,6 +1551,14 @@ func (e *DmlgenTypes) MapColumns(cm *dml.ColumnMap) error {
 			cm.NullString(&e.ColChar1)
 		case "col_char_2":
 			cm.String(&e.ColChar2)
+		case "col_enum":
+			cm.Text(&e.ColEnum)
+		case "col_set":
+			cm.Text(&e.ColSet)
+		case "col_json":
+			cm.NullString(&e.ColJSON)
+		case "col_point":
+			cm.Binary(&e.ColPoint)
 		default:
 			return errors.NotFound.Newf("[testdata] DmlgenTypes Column %q not found", c)
 		}
@@ -951,6 +1572,7 @@ func (e *DmlgenTypes) Empty() *DmlgenTypes { *e = DmlgenTypes{}; return e }
 // DmlgenTypesCollection represents a collection type for DB table dmlgen_types
 // Not thread safe. Auto generated.
 // Just another comment.
+//
 //easyjson:json
 type DmlgenTypesCollection struct {
 	Data             []*DmlgenTypes                   `json:"data,omitempty"`
@@ -966,14 +1588,18 @@ func NewDmlgenTypesCollection() *DmlgenTypesCollection {
 }
 
 func (cc *DmlgenTypesCollection) scanColumns(cm *dml.ColumnMap, e *DmlgenTypes, idx uint64) error {
-	if err := cc.BeforeMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+	if cc.BeforeMapColumns != nil {
+		if err := cc.BeforeMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	if err := e.MapColumns(cm); err != nil {
 		return errors.WithStack(err)
 	}
-	if err := cc.AfterMapColumns(idx, e); err != nil {
-		return errors.WithStack(err)
+	if cc.AfterMapColumns != nil {
+		if err := cc.AfterMapColumns(idx, e); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	return nil
 }
@@ -3,17 +3,17 @@
 
 package testdata
 
-import proto "github.com/gogo/protobuf/proto"
-import fmt "fmt"
-import math "math"
-import _ "github.com/gogo/protobuf/gogoproto"
-import _ "github.com/gogo/protobuf/types"
-
-import time "time"
-
-import github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
-
-import io "io"
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -25,13 +25,13 @@ var _ = time.Kitchen
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 func (m *CoreConfigData) Reset()         { *m = CoreConfigData{} }
 func (m *CoreConfigData) String() string { return proto.CompactTextString(m) }
 func (*CoreConfigData) ProtoMessage()    {}
 func (*CoreConfigData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_output_gen_4df98b8fb2fdac10, []int{0}
+	return fileDescriptor_e60a78c32da52458, []int{0}
 }
 func (m *CoreConfigData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -41,15 +41,15 @@ func (m *CoreConfigData) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return xxx_messageInfo_CoreConfigData.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *CoreConfigData) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CoreConfigData.Merge(dst, src)
+func (m *CoreConfigData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CoreConfigData.Merge(m, src)
 }
 func (m *CoreConfigData) XXX_Size() int {
 	return m.Size()
@@ -64,7 +64,7 @@ func (m *CoreConfigDataCollection) Reset()         { *m = CoreConfigDataCollecti
 func (m *CoreConfigDataCollection) String() string { return proto.CompactTextString(m) }
 func (*CoreConfigDataCollection) ProtoMessage()    {}
 func (*CoreConfigDataCollection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_output_gen_4df98b8fb2fdac10, []int{1}
+	return fileDescriptor_e60a78c32da52458, []int{1}
 }
 func (m *CoreConfigDataCollection) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -74,15 +74,15 @@ func (m *CoreConfigDataCollection) XXX_Marshal(b []byte, deterministic bool) ([]
 		return xxx_messageInfo_CoreConfigDataCollection.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *CoreConfigDataCollection) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CoreConfigDataCollection.Merge(dst, src)
+func (m *CoreConfigDataCollection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CoreConfigDataCollection.Merge(m, src)
 }
 func (m *CoreConfigDataCollection) XXX_Size() int {
 	return m.Size()
@@ -97,7 +97,7 @@ func (m *CustomerAddressEntity) Reset()         { *m = CustomerAddressEntity{} }
 func (m *CustomerAddressEntity) String() string { return proto.CompactTextString(m) }
 func (*CustomerAddressEntity) ProtoMessage()    {}
 func (*CustomerAddressEntity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_output_gen_4df98b8fb2fdac10, []int{2}
+	return fileDescriptor_e60a78c32da52458, []int{2}
 }
 func (m *CustomerAddressEntity) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -107,15 +107,15 @@ func (m *CustomerAddressEntity) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return xxx_messageInfo_CustomerAddressEntity.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *CustomerAddressEntity) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CustomerAddressEntity.Merge(dst, src)
+func (m *CustomerAddressEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerAddressEntity.Merge(m, src)
 }
 func (m *CustomerAddressEntity) XXX_Size() int {
 	return m.Size()
@@ -130,7 +130,7 @@ func (m *CustomerAddressEntityCollection) Reset()         { *m = CustomerAddress
 func (m *CustomerAddressEntityCollection) String() string { return proto.CompactTextString(m) }
 func (*CustomerAddressEntityCollection) ProtoMessage()    {}
 func (*CustomerAddressEntityCollection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_output_gen_4df98b8fb2fdac10, []int{3}
+	return fileDescriptor_e60a78c32da52458, []int{3}
 }
 func (m *CustomerAddressEntityCollection) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -140,15 +140,15 @@ func (m *CustomerAddressEntityCollection) XXX_Marshal(b []byte, deterministic bo
 		return xxx_messageInfo_CustomerAddressEntityCollection.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *CustomerAddressEntityCollection) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CustomerAddressEntityCollection.Merge(dst, src)
+func (m *CustomerAddressEntityCollection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerAddressEntityCollection.Merge(m, src)
 }
 func (m *CustomerAddressEntityCollection) XXX_Size() int {
 	return m.Size()
@@ -163,7 +163,7 @@ func (m *CustomerEntity) Reset()         { *m = CustomerEntity{} }
 func (m *CustomerEntity) String() string { return proto.CompactTextString(m) }
 func (*CustomerEntity) ProtoMessage()    {}
 func (*CustomerEntity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_output_gen_4df98b8fb2fdac10, []int{4}
+	return fileDescriptor_e60a78c32da52458, []int{4}
 }
 func (m *CustomerEntity) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -173,15 +173,15 @@ func (m *CustomerEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return xxx_messageInfo_CustomerEntity.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *CustomerEntity) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CustomerEntity.Merge(dst, src)
+func (m *CustomerEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerEntity.Merge(m, src)
 }
 func (m *CustomerEntity) XXX_Size() int {
 	return m.Size()
@@ -196,7 +196,7 @@ func (m *CustomerEntityCollection) Reset()         { *m = CustomerEntityCollecti
 func (m *CustomerEntityCollection) String() string { return proto.CompactTextString(m) }
 func (*CustomerEntityCollection) ProtoMessage()    {}
 func (*CustomerEntityCollection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_output_gen_4df98b8fb2fdac10, []int{5}
+	return fileDescriptor_e60a78c32da52458, []int{5}
 }
 func (m *CustomerEntityCollection) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -206,15 +206,15 @@ func (m *CustomerEntityCollection) XXX_Marshal(b []byte, deterministic bool) ([]
 		return xxx_messageInfo_CustomerEntityCollection.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *CustomerEntityCollection) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CustomerEntityCollection.Merge(dst, src)
+func (m *CustomerEntityCollection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerEntityCollection.Merge(m, src)
 }
 func (m *CustomerEntityCollection) XXX_Size() int {
 	return m.Size()
@@ -225,11 +225,218 @@ func (m *CustomerEntityCollection) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CustomerEntityCollection proto.InternalMessageInfo
 
+// CustomerEntityPrimaryKey identifies a single row of DB table `customer_entity`. Auto generated.
+type CustomerEntityPrimaryKey struct {
+	EntityID             uint32   `protobuf:"varint,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CustomerEntityPrimaryKey) Reset()         { *m = CustomerEntityPrimaryKey{} }
+func (m *CustomerEntityPrimaryKey) String() string { return proto.CompactTextString(m) }
+func (*CustomerEntityPrimaryKey) ProtoMessage()    {}
+func (*CustomerEntityPrimaryKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e60a78c32da52458, []int{6}
+}
+func (m *CustomerEntityPrimaryKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CustomerEntityPrimaryKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CustomerEntityPrimaryKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CustomerEntityPrimaryKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerEntityPrimaryKey.Merge(m, src)
+}
+func (m *CustomerEntityPrimaryKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *CustomerEntityPrimaryKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomerEntityPrimaryKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CustomerEntityPrimaryKey proto.InternalMessageInfo
+
+// CustomerEntityListFilter restricts the rows returned by CustomerEntityService.List.
+// Supported operators are: = (default), !=, <, <=, >, >=, like, not like, in,
+// not in, null and not null. Auto generated.
+type CustomerEntityListFilter struct {
+	Column               string   `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	Operator             string   `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	Values               []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CustomerEntityListFilter) Reset()         { *m = CustomerEntityListFilter{} }
+func (m *CustomerEntityListFilter) String() string { return proto.CompactTextString(m) }
+func (*CustomerEntityListFilter) ProtoMessage()    {}
+func (*CustomerEntityListFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e60a78c32da52458, []int{7}
+}
+func (m *CustomerEntityListFilter) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CustomerEntityListFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CustomerEntityListFilter.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CustomerEntityListFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerEntityListFilter.Merge(m, src)
+}
+func (m *CustomerEntityListFilter) XXX_Size() int {
+	return m.Size()
+}
+func (m *CustomerEntityListFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomerEntityListFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CustomerEntityListFilter proto.InternalMessageInfo
+
+// CustomerEntityListRequest requests a page of rows from DB table `customer_entity`. Auto generated.
+type CustomerEntityListRequest struct {
+	Filters              []*CustomerEntityListFilter `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	PageSize             uint32                      `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string                      `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *CustomerEntityListRequest) Reset()         { *m = CustomerEntityListRequest{} }
+func (m *CustomerEntityListRequest) String() string { return proto.CompactTextString(m) }
+func (*CustomerEntityListRequest) ProtoMessage()    {}
+func (*CustomerEntityListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e60a78c32da52458, []int{8}
+}
+func (m *CustomerEntityListRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CustomerEntityListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CustomerEntityListRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CustomerEntityListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerEntityListRequest.Merge(m, src)
+}
+func (m *CustomerEntityListRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CustomerEntityListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomerEntityListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CustomerEntityListRequest proto.InternalMessageInfo
+
+// CustomerEntityListResponse contains a page of rows from DB table `customer_entity`. Auto generated.
+type CustomerEntityListResponse struct {
+	Data                 []*CustomerEntity `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	NextPageToken        string            `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CustomerEntityListResponse) Reset()         { *m = CustomerEntityListResponse{} }
+func (m *CustomerEntityListResponse) String() string { return proto.CompactTextString(m) }
+func (*CustomerEntityListResponse) ProtoMessage()    {}
+func (*CustomerEntityListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e60a78c32da52458, []int{9}
+}
+func (m *CustomerEntityListResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CustomerEntityListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CustomerEntityListResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CustomerEntityListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerEntityListResponse.Merge(m, src)
+}
+func (m *CustomerEntityListResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CustomerEntityListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomerEntityListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CustomerEntityListResponse proto.InternalMessageInfo
+
+// CustomerEntityDeleteResponse reports the result of CustomerEntityService.Delete. Auto generated.
+type CustomerEntityDeleteResponse struct {
+	RowsAffected         uint64   `protobuf:"varint,1,opt,name=rows_affected,json=rowsAffected,proto3" json:"rows_affected,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CustomerEntityDeleteResponse) Reset()         { *m = CustomerEntityDeleteResponse{} }
+func (m *CustomerEntityDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*CustomerEntityDeleteResponse) ProtoMessage()    {}
+func (*CustomerEntityDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e60a78c32da52458, []int{10}
+}
+func (m *CustomerEntityDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CustomerEntityDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CustomerEntityDeleteResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CustomerEntityDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomerEntityDeleteResponse.Merge(m, src)
+}
+func (m *CustomerEntityDeleteResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CustomerEntityDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomerEntityDeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CustomerEntityDeleteResponse proto.InternalMessageInfo
+
 func (m *DmlgenTypes) Reset()         { *m = DmlgenTypes{} }
 func (m *DmlgenTypes) String() string { return proto.CompactTextString(m) }
 func (*DmlgenTypes) ProtoMessage()    {}
 func (*DmlgenTypes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_output_gen_4df98b8fb2fdac10, []int{6}
+	return fileDescriptor_e60a78c32da52458, []int{11}
 }
 func (m *DmlgenTypes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -239,15 +446,15 @@ func (m *DmlgenTypes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return xxx_messageInfo_DmlgenTypes.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *DmlgenTypes) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DmlgenTypes.Merge(dst, src)
+func (m *DmlgenTypes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DmlgenTypes.Merge(m, src)
 }
 func (m *DmlgenTypes) XXX_Size() int {
 	return m.Size()
@@ -262,7 +469,7 @@ func (m *DmlgenTypesCollection) Reset()         { *m = DmlgenTypesCollection{} }
 func (m *DmlgenTypesCollection) String() string { return proto.CompactTextString(m) }
 func (*DmlgenTypesCollection) ProtoMessage()    {}
 func (*DmlgenTypesCollection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_output_gen_4df98b8fb2fdac10, []int{7}
+	return fileDescriptor_e60a78c32da52458, []int{12}
 }
 func (m *DmlgenTypesCollection) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -272,15 +479,15 @@ func (m *DmlgenTypesCollection) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return xxx_messageInfo_DmlgenTypesCollection.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
 		return b[:n], nil
 	}
 }
-func (dst *DmlgenTypesCollection) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DmlgenTypesCollection.Merge(dst, src)
+func (m *DmlgenTypesCollection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DmlgenTypesCollection.Merge(m, src)
 }
 func (m *DmlgenTypesCollection) XXX_Size() int {
 	return m.Size()
@@ -298,13 +505,197 @@ func init() {
 	proto.RegisterType((*CustomerAddressEntityCollection)(nil), "testdata.CustomerAddressEntityCollection")
 	proto.RegisterType((*CustomerEntity)(nil), "testdata.CustomerEntity")
 	proto.RegisterType((*CustomerEntityCollection)(nil), "testdata.CustomerEntityCollection")
+	proto.RegisterType((*CustomerEntityPrimaryKey)(nil), "testdata.CustomerEntityPrimaryKey")
+	proto.RegisterType((*CustomerEntityListFilter)(nil), "testdata.CustomerEntityListFilter")
+	proto.RegisterType((*CustomerEntityListRequest)(nil), "testdata.CustomerEntityListRequest")
+	proto.RegisterType((*CustomerEntityListResponse)(nil), "testdata.CustomerEntityListResponse")
+	proto.RegisterType((*CustomerEntityDeleteResponse)(nil), "testdata.CustomerEntityDeleteResponse")
 	proto.RegisterType((*DmlgenTypes)(nil), "testdata.DmlgenTypes")
 	proto.RegisterType((*DmlgenTypesCollection)(nil), "testdata.DmlgenTypesCollection")
 }
+
+func init() { proto.RegisterFile("testdata/output_gen.proto", fileDescriptor_e60a78c32da52458) }
+
+var fileDescriptor_e60a78c32da52458 = []byte{
+	// 2749 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xcb, 0x72, 0x1b, 0xc7,
+	0xd5, 0x16, 0x04, 0x5e, 0x80, 0xc6, 0x85, 0x64, 0x4b, 0xa4, 0x9b, 0xb4, 0x7f, 0x82, 0xa6, 0xfd,
+	0xdb, 0x94, 0x2f, 0x14, 0x31, 0x80, 0x18, 0xd9, 0x51, 0x52, 0x16, 0x00, 0x5d, 0x60, 0x4b, 0x32,
+	0xd3, 0xa0, 0xe9, 0x2a, 0xa7, 0x52, 0x53, 0x83, 0x99, 0x06, 0x38, 0xd1, 0x60, 0x7a, 0x32, 0xd3,
+	0x80, 0x49, 0xef, 0xf2, 0x06, 0xae, 0x54, 0xe5, 0x01, 0xf2, 0x06, 0x5e, 0x64, 0x93, 0x7d, 0x52,
+	0xe5, 0x65, 0x9e, 0x80, 0x49, 0xe8, 0x17, 0xc8, 0xda, 0xab, 0x54, 0x5f, 0xe6, 0x06, 0x8c, 0x60,
+	0x4a, 0xe5, 0x8d, 0xd4, 0x97, 0xef, 0xfb, 0xba, 0xe7, 0xf4, 0x41, 0x9f, 0x73, 0x9a, 0x60, 0x93,
+	0x91, 0x80, 0x59, 0x06, 0x33, 0x6e, 0xd3, 0x31, 0xf3, 0xc6, 0x4c, 0x1f, 0x12, 0x77, 0xdf, 0xf3,
+	0x29, 0xa3, 0xb0, 0x10, 0x4e, 0x6d, 0x7d, 0x38, 0xb4, 0xd9, 0xe9, 0xb8, 0xbf, 0x6f, 0xd2, 0xd1,
+	0xed, 0x21, 0x1d, 0xd2, 0xdb, 0x02, 0xd0, 0x1f, 0x0f, 0x44, 0x4f, 0x74, 0x44, 0x4b, 0x12, 0xb7,
+	0x6a, 0x43, 0x4a, 0x87, 0x0e, 0x89, 0x51, 0xcc, 0x1e, 0x91, 0x80, 0x19, 0x23, 0x4f, 0x01, 0xb4,
+	0x84, 0x9e, 0x49, 0x7d, 0x12, 0x30, 0xea, 0x13, 0x9b, 0xde, 0xf6, 0x9e, 0x0f, 0x6f, 0xf3, 0xb6,
+	0x31, 0x24, 0xb7, 0xdd, 0xb1, 0xe3, 0x88, 0x7f, 0x24, 0x67, 0xf7, 0xbb, 0x3c, 0xa8, 0xb6, 0xa9,
+	0x4f, 0xda, 0xd4, 0x1d, 0xd8, 0xc3, 0x8e, 0xc1, 0x0c, 0x78, 0x0b, 0x14, 0x4d, 0xd1, 0xd3, 0x6d,
+	0x0b, 0xe5, 0x76, 0x72, 0x7b, 0x95, 0x56, 0xf9, 0xf2, 0xa2, 0x56, 0x90, 0x90, 0x6e, 0x07, 0x17,
+	0xe4, 0x74, 0xd7, 0x82, 0x35, 0xb0, 0x18, 0x98, 0xd4, 0x23, 0xe8, 0xfa, 0x4e, 0x6e, 0xaf, 0xd8,
+	0x2a, 0x5e, 0x5e, 0xd4, 0x16, 0x7b, 0x7c, 0x00, 0xcb, 0x71, 0xf8, 0x0e, 0x28, 0x88, 0x06, 0x97,
+	0xca, 0xef, 0xe4, 0xf6, 0x16, 0x5b, 0xa5, 0xcb, 0x8b, 0xda, 0xb2, 0xc0, 0x74, 0x3b, 0x78, 0x59,
+	0x4c, 0x76, 0x2d, 0x78, 0x07, 0x2c, 0x93, 0x33, 0xcf, 0xf6, 0x49, 0x80, 0x16, 0x76, 0x72, 0x7b,
+	0x25, 0x0d, 0xec, 0x8b, 0x4d, 0x1e, 0xdb, 0x23, 0xd2, 0x5a, 0xf9, 0xfe, 0xa2, 0x76, 0x8d, 0xd3,
+	0x1e, 0x48, 0x08, 0x0e, 0xb1, 0xf0, 0x0d, 0xb0, 0xe0, 0x19, 0xec, 0x14, 0x2d, 0x8a, 0xe5, 0x0b,
+	0x97, 0x17, 0xb5, 0x85, 0x23, 0x83, 0x9d, 0x62, 0x31, 0x0a, 0xeb, 0x60, 0x71, 0x62, 0x38, 0x63,
+	0x82, 0x96, 0x84, 0x64, 0x59, 0x4a, 0xf6, 0x98, 0x6f, 0xbb, 0xc3, 0x56, 0x45, 0x89, 0x2e, 0x9e,
+	0x70, 0x08, 0x96, 0x48, 0x78, 0x04, 0xc0, 0x84, 0xf8, 0x81, 0x4d, 0x5d, 0x9d, 0x05, 0x68, 0x59,
+	0xf0, 0xb6, 0xf6, 0xa5, 0xe1, 0xf7, 0x43, 0xc3, 0xef, 0x1f, 0x87, 0x86, 0x6f, 0xad, 0x2b, 0x95,
+	0xe2, 0x89, 0x64, 0x1d, 0x07, 0xdf, 0xfe, 0xab, 0x96, 0xc3, 0xc5, 0x49, 0xd8, 0x4d, 0x29, 0x12,
+	0x54, 0x78, 0x79, 0x45, 0x92, 0x56, 0x24, 0xbb, 0x8f, 0x01, 0x4a, 0x9f, 0x58, 0x9b, 0x3a, 0x0e,
+	0x31, 0x99, 0x4d, 0x5d, 0xf8, 0x01, 0x58, 0xe0, 0x23, 0x28, 0xb7, 0x93, 0xdf, 0x2b, 0x69, 0x68,
+	0x3f, 0xf4, 0xb5, 0xfd, 0x34, 0x03, 0x0b, 0xd4, 0xee, 0x5f, 0x4b, 0x60, 0xbd, 0x3d, 0x0e, 0x18,
+	0x1d, 0x11, 0xff, 0xbe, 0x65, 0xf9, 0x24, 0x08, 0x1e, 0xb8, 0xcc, 0x66, 0xe7, 0xdc, 0x07, 0x88,
+	0x68, 0x4d, 0xf9, 0x80, 0x9c, 0xe6, 0x3e, 0x20, 0xa7, 0xbb, 0x16, 0xec, 0x80, 0xb2, 0xed, 0x9a,
+	0x3e, 0x19, 0x11, 0x97, 0x71, 0xf4, 0xf5, 0x0c, 0x63, 0xdf, 0x50, 0x1f, 0x55, 0xea, 0x86, 0xc8,
+	0x6e, 0x07, 0x97, 0x22, 0x5a, 0xd7, 0x82, 0xbf, 0x04, 0x45, 0xcf, 0xf0, 0x95, 0x44, 0x3e, 0x29,
+	0xf1, 0x85, 0xed, 0xb2, 0x86, 0xd6, 0x5a, 0x55, 0x12, 0x85, 0x23, 0x01, 0xe3, 0x5b, 0x90, 0x84,
+	0xae, 0xc5, 0x6d, 0x6c, 0xfa, 0xc4, 0x60, 0xc4, 0xd2, 0x0d, 0x86, 0x16, 0xae, 0x6e, 0xe3, 0xb6,
+	0x64, 0xdd, 0x67, 0xd2, 0xc6, 0x66, 0xd8, 0xe5, 0x8a, 0x63, 0xcf, 0x0a, 0x15, 0x17, 0xaf, 0xae,
+	0xf8, 0x85, 0x67, 0x25, 0x15, 0xc7, 0x61, 0x97, 0x5b, 0xd4, 0x0e, 0x74, 0xc3, 0x64, 0xf6, 0x44,
+	0x3a, 0x64, 0x41, 0x5a, 0xb4, 0x1b, 0xdc, 0x17, 0x63, 0xb8, 0x60, 0xab, 0x16, 0xf7, 0x6a, 0xd3,
+	0x66, 0xe7, 0x68, 0x39, 0xf6, 0xea, 0xb6, 0xcd, 0xce, 0xb1, 0x18, 0x85, 0xbf, 0x00, 0xcb, 0x26,
+	0x1d, 0x79, 0x86, 0x7b, 0x8e, 0x0a, 0x49, 0x3b, 0x29, 0x53, 0x47, 0x3f, 0x96, 0xb6, 0x04, 0xe1,
+	0x10, 0x0d, 0x3f, 0x00, 0xc0, 0xa4, 0x63, 0x97, 0xf9, 0xe2, 0x50, 0x8b, 0x42, 0xbc, 0x22, 0xac,
+	0x20, 0x47, 0xbb, 0x1d, 0x5c, 0x54, 0x80, 0xae, 0x05, 0xdf, 0x07, 0xf9, 0x81, 0x71, 0x86, 0x40,
+	0xc6, 0x12, 0x25, 0xb5, 0x44, 0xfe, 0xa1, 0x71, 0x86, 0x39, 0x0a, 0xbe, 0x0f, 0x8a, 0x03, 0xdb,
+	0x0f, 0x98, 0x6b, 0x8c, 0x08, 0x2a, 0xc5, 0xca, 0x0f, 0xc3, 0x41, 0x1c, 0xcf, 0xc3, 0x3d, 0x50,
+	0x70, 0x0c, 0x85, 0x2d, 0x0b, 0xac, 0x30, 0xc4, 0x13, 0x35, 0x86, 0xa3, 0x59, 0xf8, 0x09, 0x00,
+	0x23, 0xdb, 0xb2, 0x1c, 0x22, 0xb0, 0x95, 0x8c, 0xad, 0x40, 0xb5, 0x15, 0xf0, 0x34, 0xc2, 0xe1,
+	0x04, 0x07, 0x7e, 0x0c, 0x0a, 0x1e, 0x0d, 0x98, 0x49, 0x2d, 0x82, 0xaa, 0x19, 0xfc, 0xd8, 0xab,
+	0x14, 0x0a, 0x47, 0x78, 0xd8, 0x04, 0x4b, 0x9e, 0x4f, 0x06, 0xf6, 0x19, 0x5a, 0xc9, 0x60, 0x56,
+	0x15, 0x73, 0xe9, 0x48, 0x60, 0xb0, 0xc2, 0x72, 0x96, 0x4f, 0x86, 0x36, 0x75, 0xd1, 0xea, 0x3c,
+	0x16, 0x16, 0x18, 0xac, 0xb0, 0xdc, 0xfd, 0x65, 0x8b, 0x1f, 0xcd, 0xda, 0x3c, 0xf7, 0x97, 0x44,
+	0xee, 0xfe, 0x92, 0xd0, 0xb5, 0xe0, 0x2e, 0x58, 0x0a, 0x98, 0x4f, 0x08, 0x43, 0x50, 0x98, 0x13,
+	0xf0, 0x05, 0x7a, 0x62, 0x04, 0xab, 0x19, 0xbe, 0xad, 0x60, 0x3c, 0xe0, 0x1f, 0x73, 0x63, 0xde,
+	0xb6, 0x7a, 0x02, 0x83, 0x15, 0x96, 0x9f, 0x2b, 0x23, 0x0e, 0xf1, 0x4e, 0xa9, 0x4b, 0xd0, 0xcd,
+	0xf8, 0x5c, 0x8f, 0xc3, 0x41, 0x1c, 0xcf, 0x43, 0x0d, 0x2c, 0x4d, 0x0c, 0xf1, 0xfb, 0x5d, 0x9f,
+	0x7f, 0xdf, 0xf2, 0x1f, 0xef, 0xe2, 0xc4, 0xe0, 0xbf, 0xdc, 0x4f, 0x40, 0x59, 0x70, 0x02, 0x7d,
+	0x62, 0x38, 0xb6, 0x85, 0x36, 0x92, 0x97, 0x7f, 0x8b, 0x52, 0x27, 0x3e, 0x61, 0xce, 0x0b, 0x4e,
+	0x38, 0x0a, 0x83, 0x49, 0xd4, 0x86, 0xcf, 0xc0, 0x2a, 0x57, 0xf0, 0xc9, 0x1f, 0xc6, 0x24, 0x60,
+	0x3a, 0xff, 0xb9, 0xa1, 0xd7, 0x32, 0xd6, 0xdf, 0x50, 0x3a, 0xd5, 0x13, 0x83, 0x61, 0x09, 0xee,
+	0x18, 0x8c, 0xe0, 0xea, 0x24, 0xd5, 0x87, 0x8f, 0x41, 0x35, 0xa9, 0x67, 0x5b, 0x08, 0x65, 0xa8,
+	0xdd, 0x54, 0x6a, 0xe5, 0x58, 0xad, 0xdb, 0xc1, 0xe5, 0x58, 0xab, 0x6b, 0xc1, 0x2f, 0xc1, 0x8d,
+	0xa4, 0x52, 0x30, 0x36, 0x4d, 0x12, 0x04, 0x68, 0x33, 0xe3, 0x74, 0x37, 0x95, 0xdc, 0x5a, 0x2c,
+	0xd7, 0x93, 0x70, 0xbc, 0x36, 0x99, 0x1e, 0xda, 0x3d, 0x01, 0xb5, 0xcc, 0x5b, 0x3b, 0x11, 0x07,
+	0x1a, 0xa9, 0x38, 0x50, 0x4b, 0xc4, 0x81, 0x2c, 0xa2, 0x0a, 0x07, 0x7f, 0xaf, 0x80, 0x6a, 0x38,
+	0xff, 0xf2, 0x71, 0xe0, 0xd7, 0x00, 0x7c, 0x4d, 0xfa, 0x81, 0xcd, 0xc8, 0x4c, 0x14, 0x50, 0x5f,
+	0xb9, 0x16, 0x5e, 0x92, 0x5f, 0x4a, 0x1c, 0xbf, 0x70, 0x14, 0xa5, 0x6b, 0xf1, 0x68, 0x4d, 0x46,
+	0x86, 0xed, 0xa0, 0x7c, 0x86, 0xbd, 0x23, 0xef, 0x79, 0xc0, 0x21, 0x58, 0x22, 0x79, 0x76, 0x31,
+	0xf4, 0xe9, 0xd8, 0xe3, 0x0b, 0x2e, 0x88, 0xcd, 0x89, 0xec, 0xe2, 0x11, 0x1f, 0xe3, 0xd9, 0x85,
+	0x98, 0xcc, 0x08, 0x51, 0x8b, 0xaf, 0x14, 0xa2, 0xee, 0x82, 0x82, 0xc8, 0xa8, 0xb8, 0xc2, 0x52,
+	0xc6, 0xe7, 0x45, 0x37, 0x6f, 0x8f, 0xa3, 0x44, 0x76, 0x23, 0x1a, 0xd3, 0xf1, 0x69, 0xf9, 0x67,
+	0x8f, 0x4f, 0x85, 0x9f, 0x3b, 0x3e, 0x15, 0xe7, 0xc6, 0xa7, 0x2f, 0xc0, 0xa6, 0x65, 0x07, 0x46,
+	0xdf, 0x21, 0xba, 0x31, 0x66, 0x54, 0x97, 0x67, 0x60, 0x9e, 0x1a, 0xee, 0x90, 0x88, 0x80, 0x51,
+	0x69, 0x6d, 0x5d, 0x5e, 0xd4, 0x36, 0x3a, 0x12, 0x74, 0x7f, 0xcc, 0xa8, 0x38, 0x92, 0xb6, 0x40,
+	0xe0, 0x0d, 0x2b, 0x73, 0x9c, 0x3b, 0x50, 0x68, 0x25, 0xdb, 0x45, 0xa5, 0xa4, 0x85, 0xd5, 0x19,
+	0xad, 0x4d, 0xd9, 0xa5, 0xeb, 0x46, 0x36, 0xe9, 0xba, 0x89, 0xfb, 0xba, 0xfc, 0x12, 0xf7, 0xf5,
+	0xaf, 0x92, 0xa1, 0xab, 0x32, 0x6f, 0xd1, 0xcc, 0x60, 0x96, 0x0e, 0x51, 0xd5, 0x57, 0x0b, 0x51,
+	0x51, 0x38, 0x5c, 0x99, 0x17, 0xa2, 0x32, 0x02, 0x64, 0x7c, 0xab, 0xaf, 0xbe, 0xc4, 0xad, 0x7e,
+	0x0b, 0xe4, 0x2d, 0xda, 0x47, 0x6b, 0xc9, 0xbb, 0x56, 0x24, 0xda, 0x51, 0x60, 0xef, 0xd0, 0x3e,
+	0xe6, 0x18, 0xee, 0xf3, 0xbe, 0xa7, 0x33, 0xfa, 0x9c, 0xb8, 0x99, 0x81, 0x23, 0xf2, 0x79, 0xec,
+	0x1d, 0x73, 0x10, 0x5e, 0xf6, 0x65, 0x03, 0xf6, 0xc0, 0x8d, 0x90, 0xa9, 0x27, 0x9c, 0xff, 0xe6,
+	0xcc, 0xa2, 0x48, 0x49, 0xac, 0x2a, 0x89, 0xc8, 0xe7, 0xf1, 0xaa, 0x3f, 0x35, 0x02, 0x9f, 0x82,
+	0x15, 0x8b, 0x0c, 0x8c, 0xb1, 0xc3, 0xf4, 0xbe, 0xed, 0x38, 0xb6, 0x3b, 0x44, 0xeb, 0x19, 0xbf,
+	0xc4, 0xe8, 0xae, 0xef, 0x48, 0x70, 0x4b, 0x62, 0x71, 0xd5, 0x4a, 0xf5, 0xe1, 0x11, 0x58, 0x0d,
+	0xe5, 0x82, 0x53, 0xdb, 0xf3, 0xb8, 0xde, 0x46, 0x86, 0xde, 0x6b, 0x4a, 0x6f, 0x45, 0xe9, 0xf5,
+	0x14, 0x18, 0xaf, 0x58, 0xe9, 0x01, 0x7e, 0x20, 0xcc, 0x38, 0x9b, 0x18, 0x0c, 0xbd, 0x36, 0xef,
+	0x40, 0x8e, 0x05, 0x06, 0x2b, 0x2c, 0x7c, 0x08, 0xca, 0xa2, 0xa4, 0xf2, 0x47, 0x06, 0xbf, 0xbd,
+	0xe7, 0x47, 0x9c, 0x76, 0x02, 0x89, 0x53, 0x3c, 0xbe, 0xfa, 0x90, 0xb8, 0x16, 0xf1, 0x33, 0x83,
+	0x4c, 0xb4, 0xfa, 0x23, 0x81, 0xc1, 0x0a, 0x0b, 0xdb, 0xa0, 0x3c, 0x30, 0x6c, 0x67, 0xec, 0x93,
+	0x40, 0x77, 0xc7, 0x23, 0xb4, 0x25, 0xb8, 0x25, 0xc9, 0xed, 0x0a, 0x6a, 0x74, 0x39, 0x3e, 0x54,
+	0xc0, 0x67, 0xe3, 0x11, 0x2e, 0x0d, 0xe2, 0x0e, 0x7c, 0x00, 0x2a, 0xe2, 0x47, 0xa1, 0xab, 0x41,
+	0xf4, 0xfa, 0xcc, 0x41, 0x47, 0x5f, 0x20, 0x7e, 0x48, 0x4a, 0x09, 0x97, 0x07, 0x89, 0x1e, 0x6c,
+	0x81, 0xb2, 0x43, 0xcd, 0xe7, 0x7a, 0x58, 0x0c, 0xbe, 0x31, 0xa3, 0x12, 0x6d, 0xe5, 0x09, 0x35,
+	0x9f, 0x87, 0x05, 0x61, 0xc9, 0x89, 0x3b, 0xa2, 0x3e, 0x4a, 0x45, 0xb1, 0x2b, 0xd5, 0x47, 0x29,
+	0x86, 0x0a, 0x88, 0x9f, 0x4d, 0x2b, 0x1d, 0xf9, 0xf6, 0xc8, 0xf0, 0xcf, 0x3f, 0x23, 0x2f, 0x13,
+	0x19, 0x3f, 0x5e, 0xf8, 0xef, 0x5f, 0x6a, 0xb9, 0xdd, 0x3f, 0xe5, 0xa6, 0xd5, 0x9e, 0xd8, 0x01,
+	0x7b, 0x68, 0x3b, 0x8c, 0xf8, 0x3c, 0x85, 0x33, 0xa9, 0x33, 0x1e, 0xb9, 0x28, 0x17, 0xa7, 0x70,
+	0x6d, 0x31, 0x82, 0xd5, 0x0c, 0xcf, 0x9b, 0xa9, 0x47, 0x7c, 0x83, 0x51, 0x5f, 0xd5, 0xdb, 0x62,
+	0xc1, 0xcf, 0xd5, 0x18, 0x8e, 0x66, 0xb9, 0x9a, 0x28, 0x67, 0x03, 0x94, 0xdf, 0xc9, 0x87, 0x6a,
+	0xa2, 0xce, 0x0d, 0xb0, 0x9a, 0x51, 0x9b, 0xfa, 0x47, 0x0e, 0x6c, 0xce, 0x6e, 0x4a, 0xe5, 0x1b,
+	0xb0, 0x0b, 0x96, 0x07, 0x62, 0x7f, 0x81, 0x32, 0xd8, 0xee, 0x8b, 0x0c, 0x16, 0x7f, 0x8a, 0x0c,
+	0xc1, 0xb2, 0x1d, 0xe0, 0x90, 0xcf, 0xcd, 0xe5, 0x19, 0x43, 0xa2, 0x07, 0xf6, 0x37, 0xf2, 0xb5,
+	0x40, 0x99, 0xeb, 0xc8, 0x18, 0x92, 0x9e, 0xfd, 0x0d, 0xcf, 0xbb, 0x55, 0x8b, 0xd7, 0x29, 0x02,
+	0x2a, 0x6f, 0x9d, 0x7c, 0x9c, 0x75, 0x72, 0xac, 0xbc, 0x65, 0x8a, 0x5e, 0xd8, 0x54, 0xdf, 0xf1,
+	0xe7, 0x1c, 0xd8, 0xca, 0xfa, 0x8e, 0xc0, 0xa3, 0x6e, 0x40, 0xe0, 0x21, 0x58, 0xb0, 0xae, 0x70,
+	0xec, 0xb2, 0xd6, 0x92, 0x05, 0x32, 0x9f, 0x86, 0x1f, 0x81, 0x15, 0x97, 0x9c, 0x31, 0x3d, 0xb1,
+	0x1f, 0x69, 0xf9, 0xb5, 0xcb, 0x8b, 0x5a, 0xe5, 0x19, 0x39, 0x63, 0xf1, 0x9e, 0x2a, 0x6e, 0xb2,
+	0xab, 0xf6, 0xf5, 0x5b, 0xf0, 0x46, 0x7a, 0x89, 0x0e, 0x71, 0x08, 0x23, 0xd1, 0xc6, 0xee, 0x80,
+	0x8a, 0x4f, 0xbf, 0x0e, 0x74, 0x63, 0x30, 0x20, 0x26, 0x23, 0xd2, 0x93, 0x16, 0x5a, 0xab, 0xfc,
+	0x67, 0x82, 0xe9, 0xd7, 0xc1, 0x7d, 0x35, 0x8e, 0xcb, 0x7e, 0xa2, 0xa7, 0xc4, 0xff, 0xb6, 0x01,
+	0x4a, 0x9d, 0x91, 0x33, 0x24, 0xee, 0xf1, 0xb9, 0x47, 0x02, 0xb8, 0x01, 0xae, 0x2b, 0x5f, 0x5c,
+	0x6c, 0x2d, 0x5d, 0x5e, 0xd4, 0xae, 0x77, 0x3b, 0xf8, 0xba, 0x6d, 0xc1, 0xfb, 0xfc, 0x7a, 0x71,
+	0xf4, 0xbe, 0x3d, 0xb4, 0x5d, 0xa6, 0xd7, 0xd1, 0xf5, 0xa9, 0x1f, 0xf8, 0x61, 0x33, 0x0e, 0x52,
+	0x6d, 0xea, 0xb4, 0x04, 0xae, 0x8e, 0x81, 0x19, 0xb5, 0xe1, 0x41, 0x4a, 0x42, 0x13, 0xa7, 0x92,
+	0x6f, 0x55, 0x53, 0x0c, 0x2d, 0xc1, 0xd0, 0x60, 0x2b, 0xc5, 0x68, 0xa0, 0x85, 0xe9, 0x1b, 0x29,
+	0x73, 0xd5, 0x46, 0x42, 0xa3, 0x31, 0xb5, 0x6a, 0x53, 0xe4, 0x6d, 0x0b, 0x53, 0xab, 0x36, 0x13,
+	0x8c, 0x26, 0xcf, 0x08, 0x05, 0xc3, 0xa1, 0x7d, 0x91, 0xa3, 0x95, 0xa5, 0x3b, 0x72, 0xb4, 0x43,
+	0xfb, 0xbc, 0x16, 0x16, 0x0d, 0xf8, 0x31, 0xaf, 0x85, 0x1d, 0x51, 0x2d, 0xe8, 0x75, 0xb4, 0x3c,
+	0x73, 0xcb, 0x44, 0x41, 0xb7, 0x4d, 0x1d, 0x5e, 0x16, 0xd4, 0xf9, 0xa3, 0x97, 0x6c, 0xc1, 0x67,
+	0x09, 0xae, 0x76, 0x85, 0xdc, 0xeb, 0xe6, 0x94, 0x96, 0x26, 0x52, 0xaf, 0x50, 0x4f, 0x83, 0x0f,
+	0x41, 0x35, 0xd4, 0xe3, 0x2f, 0x7a, 0x7a, 0x1d, 0x15, 0x67, 0xf6, 0x93, 0xb8, 0xfd, 0x9d, 0x8e,
+	0x02, 0xd6, 0xf9, 0xed, 0x1f, 0xf7, 0xe0, 0x57, 0x53, 0x3a, 0x1a, 0x02, 0x3f, 0xb9, 0x37, 0x94,
+	0xa1, 0x2b, 0xf7, 0x97, 0xd4, 0xd6, 0xe0, 0x53, 0xb0, 0x2a, 0xb4, 0x89, 0x69, 0x8f, 0x0c, 0x47,
+	0xaf, 0x1f, 0xe8, 0x75, 0x95, 0xa1, 0x55, 0xe4, 0x2e, 0x3b, 0x72, 0x26, 0x4a, 0x34, 0x2b, 0x5c,
+	0x50, 0x8e, 0xd5, 0x0f, 0xea, 0xb8, 0x62, 0x26, 0xbb, 0x33, 0x72, 0x9a, 0xde, 0x44, 0xe5, 0xab,
+	0xca, 0x69, 0xcd, 0x94, 0x9c, 0xd6, 0x84, 0x9f, 0x80, 0x92, 0xe7, 0xdb, 0x26, 0x11, 0x42, 0x06,
+	0xaa, 0x64, 0x29, 0x45, 0x69, 0xdc, 0x11, 0x47, 0xd6, 0xb5, 0xa6, 0x81, 0x8b, 0x5e, 0xd8, 0x4c,
+	0x2b, 0xf4, 0x51, 0xf5, 0x4a, 0x0a, 0xfd, 0x58, 0xa1, 0x9f, 0xf1, 0x49, 0x0d, 0xb4, 0x92, 0x25,
+	0x93, 0xf9, 0x49, 0x8d, 0xf4, 0x27, 0x35, 0xa6, 0xe5, 0xb4, 0x03, 0xfd, 0x10, 0xad, 0x5e, 0x51,
+	0x4e, 0x3b, 0x38, 0x4c, 0xca, 0x69, 0x07, 0x87, 0xf0, 0x08, 0xac, 0xa5, 0xe4, 0x9a, 0x7a, 0x5d,
+	0x43, 0x6b, 0x59, 0x7a, 0x51, 0xee, 0x94, 0xd0, 0x6b, 0xd6, 0x35, 0x5c, 0x35, 0x53, 0x7d, 0x78,
+	0x97, 0xbf, 0x12, 0x3b, 0xba, 0xbc, 0x51, 0xe0, 0x6c, 0xca, 0x90, 0x78, 0x87, 0x72, 0xba, 0xfc,
+	0x3a, 0x59, 0x36, 0x65, 0x03, 0xbe, 0x1b, 0x33, 0x35, 0x74, 0x23, 0x7e, 0x14, 0x96, 0x40, 0x2d,
+	0x04, 0x6a, 0xf0, 0xa3, 0x18, 0xd8, 0x40, 0x37, 0xa7, 0xef, 0x8f, 0xd9, 0x35, 0x1a, 0x21, 0xb5,
+	0x91, 0x5c, 0xa3, 0x89, 0xd6, 0xe3, 0xd2, 0x50, 0x02, 0x9b, 0x21, 0xb0, 0xc9, 0xcb, 0x7d, 0x0e,
+	0x74, 0xa8, 0x3b, 0x64, 0xfc, 0xa6, 0xaf, 0xa3, 0x8d, 0xf9, 0xc9, 0x97, 0xf3, 0x44, 0x41, 0xe5,
+	0xcf, 0x2f, 0xea, 0xc1, 0xc3, 0x29, 0x25, 0x4d, 0xa4, 0x80, 0xc5, 0xd6, 0xea, 0x14, 0x4f, 0x4b,
+	0xf1, 0xb8, 0x21, 0x05, 0x6f, 0x44, 0x2c, 0x7b, 0x3c, 0x12, 0x17, 0x17, 0x12, 0x17, 0xd7, 0x9a,
+	0x3a, 0xd4, 0xa7, 0xd1, 0x84, 0x38, 0xd4, 0xb8, 0xcb, 0x9f, 0x3e, 0x62, 0xa6, 0xda, 0xfd, 0xe6,
+	0xbc, 0xa7, 0x8f, 0x48, 0x4d, 0xee, 0xbf, 0x6a, 0xa6, 0xfa, 0xf0, 0xde, 0x8c, 0x9e, 0x26, 0x92,
+	0xc1, 0x62, 0x0b, 0xce, 0xb0, 0xb5, 0x29, 0xb6, 0x06, 0x1f, 0xc9, 0xef, 0x08, 0x46, 0x06, 0x4f,
+	0xae, 0xf9, 0x5e, 0x5e, 0x9f, 0xf5, 0x8a, 0xa4, 0x21, 0x7b, 0x0a, 0x29, 0x0d, 0x19, 0xf5, 0xe0,
+	0xe1, 0x94, 0x90, 0x26, 0xb2, 0xc0, 0xc5, 0xc8, 0x90, 0x21, 0x52, 0x4b, 0xf1, 0x34, 0xf8, 0x78,
+	0x8a, 0xd7, 0x40, 0xff, 0x97, 0xe1, 0x33, 0x59, 0x3b, 0x68, 0xa4, 0x94, 0x1a, 0x33, 0x3b, 0x68,
+	0xa2, 0x6d, 0xe1, 0x42, 0xd3, 0x3b, 0x68, 0xa6, 0x78, 0x4d, 0xce, 0x3b, 0x35, 0x82, 0x98, 0x77,
+	0x07, 0xd5, 0x44, 0x21, 0x2d, 0x78, 0x8f, 0x8d, 0x20, 0x44, 0xde, 0xc1, 0xe5, 0xd3, 0x44, 0x0f,
+	0xb6, 0x41, 0xc5, 0x4e, 0xd1, 0x76, 0x66, 0x9e, 0xc1, 0xe2, 0xe7, 0x89, 0x84, 0x4a, 0xc9, 0x4e,
+	0x88, 0xdc, 0x95, 0xa1, 0x8f, 0x1f, 0x06, 0x7a, 0x73, 0xfe, 0xc3, 0xb0, 0x73, 0x4c, 0xce, 0x98,
+	0xf8, 0x0d, 0xf0, 0x06, 0xfc, 0x1d, 0x58, 0x11, 0xcc, 0x30, 0x2a, 0xe8, 0x75, 0xb4, 0xfb, 0x93,
+	0x91, 0x63, 0x33, 0x71, 0xef, 0x44, 0xa3, 0x75, 0x11, 0x3a, 0x2a, 0x66, 0x72, 0x08, 0x76, 0xa7,
+	0xe5, 0x35, 0xf4, 0xd6, 0x4c, 0x80, 0x5b, 0xcf, 0x92, 0xd3, 0xd2, 0x52, 0x1a, 0x6c, 0x80, 0x8a,
+	0x94, 0x72, 0xcf, 0xa5, 0x8b, 0xbd, 0x2d, 0x3c, 0x63, 0x85, 0x1b, 0x46, 0x10, 0xc5, 0x78, 0x1d,
+	0x97, 0xcc, 0xb8, 0x13, 0x92, 0x26, 0x86, 0x6f, 0x9e, 0x1a, 0xbe, 0x5e, 0x47, 0xff, 0x2f, 0x7c,
+	0x3a, 0x24, 0x9d, 0xc8, 0x71, 0x49, 0x0a, 0x3b, 0xf0, 0x33, 0xb0, 0x92, 0x22, 0x1d, 0x1c, 0xa0,
+	0x77, 0x32, 0x8c, 0x9a, 0xdc, 0x76, 0xc8, 0x3d, 0x38, 0x10, 0xdb, 0x8e, 0xbb, 0xa1, 0x3f, 0x45,
+	0x62, 0x87, 0xe8, 0xdd, 0xd4, 0xd5, 0x10, 0x42, 0x0f, 0x85, 0x3f, 0x45, 0x3d, 0x78, 0x4f, 0x66,
+	0x1a, 0x6a, 0xdb, 0x7b, 0xf3, 0x1e, 0x07, 0xda, 0xd4, 0x69, 0x8b, 0xaf, 0x28, 0x98, 0xaa, 0x05,
+	0xdf, 0x4b, 0xb0, 0x35, 0x74, 0x2b, 0xae, 0x18, 0x14, 0x56, 0x8b, 0xb0, 0x1a, 0xbc, 0x27, 0x9d,
+	0x87, 0xf0, 0xfa, 0xef, 0x3d, 0x81, 0x7c, 0x53, 0xb9, 0xca, 0x03, 0x77, 0x3c, 0xfa, 0xf1, 0xa2,
+	0x06, 0x13, 0x89, 0xa6, 0x1a, 0x15, 0x0e, 0xc4, 0x1b, 0xf0, 0x2e, 0xff, 0x93, 0x84, 0xa3, 0x07,
+	0x84, 0xa1, 0xf7, 0x45, 0x8a, 0x56, 0x53, 0xe5, 0x4b, 0x8f, 0xb0, 0x1f, 0x2f, 0x6a, 0x6b, 0x69,
+	0x6e, 0x8f, 0x3f, 0x4b, 0x9b, 0xe2, 0xff, 0xd0, 0x69, 0x7f, 0x1f, 0x50, 0x17, 0x7d, 0xf0, 0x13,
+	0x4e, 0xfb, 0x69, 0xef, 0xf3, 0x67, 0x62, 0xcd, 0x4f, 0x03, 0xea, 0xf2, 0x77, 0x1b, 0xce, 0xf4,
+	0xa8, 0xed, 0x32, 0xf4, 0xa1, 0xa0, 0x56, 0x25, 0xf5, 0x11, 0xa1, 0x23, 0xc2, 0xfc, 0xf3, 0x94,
+	0x71, 0x8e, 0x38, 0x4e, 0x7c, 0xb0, 0x68, 0xed, 0xb6, 0xc0, 0x7a, 0x7a, 0x57, 0x61, 0x85, 0x78,
+	0x2b, 0x55, 0x21, 0xae, 0xc7, 0xa5, 0x42, 0x02, 0x2e, 0xcb, 0x43, 0xed, 0x8f, 0x79, 0xb0, 0x9e,
+	0xce, 0xee, 0x7b, 0xc4, 0x9f, 0xd8, 0x26, 0x81, 0x6d, 0x90, 0x7f, 0x44, 0x18, 0x7c, 0x61, 0xb9,
+	0x14, 0xd7, 0x91, 0x5b, 0x2f, 0x2c, 0x46, 0xe0, 0x6f, 0xc0, 0x02, 0x2f, 0x62, 0xe0, 0x5b, 0xf3,
+	0x8a, 0x2e, 0x55, 0xaa, 0x6d, 0xbd, 0x3d, 0x1f, 0xa4, 0xca, 0x8d, 0x7b, 0x60, 0x49, 0x3e, 0xa6,
+	0xc0, 0x17, 0x2e, 0x3b, 0x67, 0x43, 0xf7, 0xc0, 0x92, 0x7c, 0x3e, 0x7c, 0x25, 0xf6, 0x31, 0x58,
+	0x92, 0xc5, 0xcf, 0x95, 0xcc, 0xf2, 0xce, 0x8b, 0x30, 0xe9, 0x02, 0xaa, 0xb5, 0xfb, 0xfd, 0x7f,
+	0xb6, 0xaf, 0x7d, 0x7f, 0xb9, 0x9d, 0xfb, 0xe7, 0xe5, 0x76, 0xee, 0xdf, 0x97, 0xdb, 0xb9, 0x6f,
+	0x7f, 0xd8, 0xbe, 0xf6, 0xdd, 0x0f, 0xdb, 0xd7, 0xbe, 0x8a, 0xfe, 0xce, 0xde, 0x5f, 0x12, 0xd7,
+	0x57, 0xe3, 0x7f, 0x03, 0x00, 0xf1, 0x76, 0xd4, 0xb4, 0x95, 0x1f, 0x00, 0x00,
+}
+
 func (m *CoreConfigData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -312,71 +703,82 @@ func (m *CoreConfigData) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *CoreConfigData) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CoreConfigData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.ConfigID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.ConfigID))
-	}
-	if len(m.Scope) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Scope)))
-		i += copy(dAtA[i:], m.Scope)
+	n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.VersionTe, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.VersionTe):])
+	if err1 != nil {
+		return 0, err1
 	}
-	if m.ScopeID != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.ScopeID))
+	i -= n1
+	i = encodeVarintOutputGen(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x42
+	n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.VersionTs, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.VersionTs):])
+	if err2 != nil {
+		return 0, err2
 	}
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Expires.Size()))
-	n1, err := m.Expires.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i -= n2
+	i = encodeVarintOutputGen(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x3a
+	{
+		size, err := m.Value.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n1
+	i--
+	dAtA[i] = 0x32
 	if len(m.Path) > 0 {
-		dAtA[i] = 0x2a
-		i++
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
 		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Path)))
-		i += copy(dAtA[i:], m.Path)
+		i--
+		dAtA[i] = 0x2a
 	}
-	dAtA[i] = 0x32
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Value.Size()))
-	n2, err := m.Value.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.Expires.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n2
-	dAtA[i] = 0x3a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.VersionTs)))
-	n3, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.VersionTs, dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x22
+	if m.ScopeID != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.ScopeID))
+		i--
+		dAtA[i] = 0x18
 	}
-	i += n3
-	dAtA[i] = 0x42
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.VersionTe)))
-	n4, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.VersionTe, dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Scope) > 0 {
+		i -= len(m.Scope)
+		copy(dAtA[i:], m.Scope)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Scope)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ConfigID != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.ConfigID))
+		i--
+		dAtA[i] = 0x8
 	}
-	i += n4
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *CoreConfigDataCollection) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -384,29 +786,36 @@ func (m *CoreConfigDataCollection) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *CoreConfigDataCollection) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CoreConfigDataCollection) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
 	if len(m.Data) > 0 {
-		for _, msg := range m.Data {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintOutputGen(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+		for iNdEx := len(m.Data) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Data[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOutputGen(dAtA, i, uint64(size))
 			}
-			i += n
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *CustomerAddressEntity) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -414,224 +823,265 @@ func (m *CustomerAddressEntity) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *CustomerAddressEntity) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CustomerAddressEntity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.EntityID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.EntityID))
-	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.IncrementID.Size()))
-	n5, err := m.IncrementID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.VatRequestSuccess.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n5
-	dAtA[i] = 0x1a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ParentID.Size()))
-	n6, err := m.ParentID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xca
+	{
+		size, err := m.VatRequestID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n6
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.CreatedAt)))
-	n7, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.CreatedAt, dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xc2
+	{
+		size, err := m.VatRequestDate.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n7
-	dAtA[i] = 0x2a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt)))
-	n8, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xba
+	{
+		size, err := m.VatIsValid.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n8
-	if m.IsActive {
-		dAtA[i] = 0x30
-		i++
-		if m.IsActive {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xb2
+	{
+		size, err := m.VatID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
-		i++
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	if len(m.City) > 0 {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.City)))
-		i += copy(dAtA[i:], m.City)
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xaa
+	if len(m.Telephone) > 0 {
+		i -= len(m.Telephone)
+		copy(dAtA[i:], m.Telephone)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Telephone)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
 	}
-	dAtA[i] = 0x42
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Company.Size()))
-	n9, err := m.Company.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.Suffix.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n9
-	if len(m.CountryID) > 0 {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.CountryID)))
-		i += copy(dAtA[i:], m.CountryID)
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0x9a
+	if len(m.Street) > 0 {
+		i -= len(m.Street)
+		copy(dAtA[i:], m.Street)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Street)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
 	}
-	dAtA[i] = 0x52
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Fax.Size()))
-	n10, err := m.Fax.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.RegionID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n10
-	if len(m.Firstname) > 0 {
-		dAtA[i] = 0x5a
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Firstname)))
-		i += copy(dAtA[i:], m.Firstname)
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0x8a
+	{
+		size, err := m.Region.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	if len(m.Lastname) > 0 {
-		dAtA[i] = 0x62
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Lastname)))
-		i += copy(dAtA[i:], m.Lastname)
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0x82
+	{
+		size, err := m.Prefix.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	dAtA[i] = 0x6a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Middlename.Size()))
-	n11, err := m.Middlename.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x7a
+	{
+		size, err := m.Postcode.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n11
+	i--
 	dAtA[i] = 0x72
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Postcode.Size()))
-	n12, err := m.Postcode.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.Middlename.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n12
-	dAtA[i] = 0x7a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Prefix.Size()))
-	n13, err := m.Prefix.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x6a
+	if len(m.Lastname) > 0 {
+		i -= len(m.Lastname)
+		copy(dAtA[i:], m.Lastname)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Lastname)))
+		i--
+		dAtA[i] = 0x62
 	}
-	i += n13
-	dAtA[i] = 0x82
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Region.Size()))
-	n14, err := m.Region.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Firstname) > 0 {
+		i -= len(m.Firstname)
+		copy(dAtA[i:], m.Firstname)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Firstname)))
+		i--
+		dAtA[i] = 0x5a
 	}
-	i += n14
-	dAtA[i] = 0x8a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.RegionID.Size()))
-	n15, err := m.RegionID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.Fax.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n15
-	if len(m.Street) > 0 {
-		dAtA[i] = 0x92
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Street)))
-		i += copy(dAtA[i:], m.Street)
+	i--
+	dAtA[i] = 0x52
+	if len(m.CountryID) > 0 {
+		i -= len(m.CountryID)
+		copy(dAtA[i:], m.CountryID)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.CountryID)))
+		i--
+		dAtA[i] = 0x4a
 	}
-	dAtA[i] = 0x9a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Suffix.Size()))
-	n16, err := m.Suffix.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.Company.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n16
-	if len(m.Telephone) > 0 {
-		dAtA[i] = 0xa2
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Telephone)))
-		i += copy(dAtA[i:], m.Telephone)
+	i--
+	dAtA[i] = 0x42
+	if len(m.City) > 0 {
+		i -= len(m.City)
+		copy(dAtA[i:], m.City)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.City)))
+		i--
+		dAtA[i] = 0x3a
 	}
-	dAtA[i] = 0xaa
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.VatID.Size()))
-	n17, err := m.VatID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.IsActive {
+		i--
+		if m.IsActive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
 	}
-	i += n17
-	dAtA[i] = 0xb2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.VatIsValid.Size()))
-	n18, err := m.VatIsValid.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	n18, err18 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt):])
+	if err18 != nil {
+		return 0, err18
 	}
-	i += n18
-	dAtA[i] = 0xba
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.VatRequestDate.Size()))
-	n19, err := m.VatRequestDate.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i -= n18
+	i = encodeVarintOutputGen(dAtA, i, uint64(n18))
+	i--
+	dAtA[i] = 0x2a
+	n19, err19 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.CreatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.CreatedAt):])
+	if err19 != nil {
+		return 0, err19
 	}
-	i += n19
-	dAtA[i] = 0xc2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.VatRequestID.Size()))
-	n20, err := m.VatRequestID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i -= n19
+	i = encodeVarintOutputGen(dAtA, i, uint64(n19))
+	i--
+	dAtA[i] = 0x22
+	{
+		size, err := m.ParentID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n20
-	dAtA[i] = 0xca
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.VatRequestSuccess.Size()))
-	n21, err := m.VatRequestSuccess.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.IncrementID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n21
-	return i, nil
+	i--
+	dAtA[i] = 0x12
+	if m.EntityID != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.EntityID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
 func (m *CustomerAddressEntityCollection) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -639,29 +1089,36 @@ func (m *CustomerAddressEntityCollection) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *CustomerAddressEntityCollection) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CustomerAddressEntityCollection) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
 	if len(m.Data) > 0 {
-		for _, msg := range m.Data {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintOutputGen(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+		for iNdEx := len(m.Data) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Data[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOutputGen(dAtA, i, uint64(size))
 			}
-			i += n
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *CustomerEntity) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -669,642 +1126,987 @@ func (m *CustomerEntity) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *CustomerEntity) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CustomerEntity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.EntityID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.EntityID))
+	{
+		size, err := m.LockExpires.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.WebsiteID.Size()))
-	n22, err := m.WebsiteID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xe2
+	{
+		size, err := m.FirstFailure.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n22
-	dAtA[i] = 0x1a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Email.Size()))
-	n23, err := m.Email.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xda
+	{
+		size, err := m.FailuresNum.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n23
-	if m.GroupID != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.GroupID))
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xd2
+	{
+		size, err := m.Gender.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	dAtA[i] = 0x2a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.IncrementID.Size()))
-	n24, err := m.IncrementID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xca
+	{
+		size, err := m.Confirmation.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n24
-	dAtA[i] = 0x32
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.StoreID.Size()))
-	n25, err := m.StoreID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xc2
+	{
+		size, err := m.Taxvat.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n25
-	dAtA[i] = 0x3a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.CreatedAt)))
-	n26, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.CreatedAt, dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xba
+	{
+		size, err := m.DefaultShipping.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n26
-	dAtA[i] = 0x42
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt)))
-	n27, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xb2
+	{
+		size, err := m.DefaultBilling.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xaa
+	{
+		size, err := m.RpTokenCreatedAt.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xa2
+	{
+		size, err := m.RpToken.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0x9a
+	{
+		size, err := m.Dob.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0x8a
+	{
+		size, err := m.Suffix.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0x82
+	{
+		size, err := m.Lastname.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x7a
+	{
+		size, err := m.Middlename.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x72
+	{
+		size, err := m.Firstname.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x6a
+	{
+		size, err := m.Prefix.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x62
+	{
+		size, err := m.CreatedIn.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x5a
+	if m.DisableAutoGroupChange != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.DisableAutoGroupChange))
+		i--
+		dAtA[i] = 0x50
 	}
-	i += n27
 	if m.IsActive {
-		dAtA[i] = 0x48
-		i++
+		i--
 		if m.IsActive {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
-		i++
+		i--
+		dAtA[i] = 0x48
 	}
-	if m.DisableAutoGroupChange != 0 {
-		dAtA[i] = 0x50
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.DisableAutoGroupChange))
+	n39, err39 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt):])
+	if err39 != nil {
+		return 0, err39
 	}
-	dAtA[i] = 0x5a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.CreatedIn.Size()))
-	n28, err := m.CreatedIn.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i -= n39
+	i = encodeVarintOutputGen(dAtA, i, uint64(n39))
+	i--
+	dAtA[i] = 0x42
+	n40, err40 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.CreatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.CreatedAt):])
+	if err40 != nil {
+		return 0, err40
 	}
-	i += n28
-	dAtA[i] = 0x62
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Prefix.Size()))
-	n29, err := m.Prefix.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i -= n40
+	i = encodeVarintOutputGen(dAtA, i, uint64(n40))
+	i--
+	dAtA[i] = 0x3a
+	{
+		size, err := m.StoreID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n29
-	dAtA[i] = 0x6a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Firstname.Size()))
-	n30, err := m.Firstname.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x32
+	{
+		size, err := m.IncrementID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n30
-	dAtA[i] = 0x72
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Middlename.Size()))
-	n31, err := m.Middlename.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x2a
+	if m.GroupID != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x20
 	}
-	i += n31
-	dAtA[i] = 0x7a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Lastname.Size()))
-	n32, err := m.Lastname.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.Email.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n32
-	dAtA[i] = 0x82
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Suffix.Size()))
-	n33, err := m.Suffix.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.WebsiteID.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n33
-	dAtA[i] = 0x8a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Dob.Size()))
-	n34, err := m.Dob.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x12
+	if m.EntityID != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.EntityID))
+		i--
+		dAtA[i] = 0x8
 	}
-	i += n34
-	dAtA[i] = 0x9a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.RpToken.Size()))
-	n35, err := m.RpToken.MarshalTo(dAtA[i:])
+	return len(dAtA) - i, nil
+}
+
+func (m *CustomerEntityCollection) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	i += n35
-	dAtA[i] = 0xa2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.RpTokenCreatedAt.Size()))
-	n36, err := m.RpTokenCreatedAt.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return dAtA[:n], nil
+}
+
+func (m *CustomerEntityCollection) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CustomerEntityCollection) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		for iNdEx := len(m.Data) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Data[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOutputGen(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	i += n36
-	dAtA[i] = 0xaa
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.DefaultBilling.Size()))
-	n37, err := m.DefaultBilling.MarshalTo(dAtA[i:])
+	return len(dAtA) - i, nil
+}
+
+func (m *CustomerEntityPrimaryKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	i += n37
-	dAtA[i] = 0xb2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.DefaultShipping.Size()))
-	n38, err := m.DefaultShipping.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return dAtA[:n], nil
+}
+
+func (m *CustomerEntityPrimaryKey) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CustomerEntityPrimaryKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.EntityID != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.EntityID))
+		i--
+		dAtA[i] = 0x8
 	}
-	i += n38
-	dAtA[i] = 0xba
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Taxvat.Size()))
-	n39, err := m.Taxvat.MarshalTo(dAtA[i:])
+	return len(dAtA) - i, nil
+}
+
+func (m *CustomerEntityListFilter) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	i += n39
-	dAtA[i] = 0xc2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Confirmation.Size()))
-	n40, err := m.Confirmation.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return dAtA[:n], nil
+}
+
+func (m *CustomerEntityListFilter) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CustomerEntityListFilter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Values[iNdEx])
+			copy(dAtA[i:], m.Values[iNdEx])
+			i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Values[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	i += n40
-	dAtA[i] = 0xca
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Gender.Size()))
-	n41, err := m.Gender.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Operator) > 0 {
+		i -= len(m.Operator)
+		copy(dAtA[i:], m.Operator)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Operator)))
+		i--
+		dAtA[i] = 0x12
 	}
-	i += n41
-	dAtA[i] = 0xd2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.FailuresNum.Size()))
-	n42, err := m.FailuresNum.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Column) > 0 {
+		i -= len(m.Column)
+		copy(dAtA[i:], m.Column)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.Column)))
+		i--
+		dAtA[i] = 0xa
 	}
-	i += n42
-	dAtA[i] = 0xda
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.FirstFailure.Size()))
-	n43, err := m.FirstFailure.MarshalTo(dAtA[i:])
+	return len(dAtA) - i, nil
+}
+
+func (m *CustomerEntityListRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	i += n43
-	dAtA[i] = 0xe2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.LockExpires.Size()))
-	n44, err := m.LockExpires.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return dAtA[:n], nil
+}
+
+func (m *CustomerEntityListRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CustomerEntityListRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PageToken) > 0 {
+		i -= len(m.PageToken)
+		copy(dAtA[i:], m.PageToken)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.PageToken)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.PageSize != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.PageSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Filters) > 0 {
+		for iNdEx := len(m.Filters) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Filters[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOutputGen(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	i += n44
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
-func (m *CustomerEntityCollection) Marshal() (dAtA []byte, err error) {
+func (m *CustomerEntityListResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
 	return dAtA[:n], nil
 }
 
-func (m *CustomerEntityCollection) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+func (m *CustomerEntityListResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CustomerEntityListResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
+	if len(m.NextPageToken) > 0 {
+		i -= len(m.NextPageToken)
+		copy(dAtA[i:], m.NextPageToken)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.NextPageToken)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Data) > 0 {
-		for _, msg := range m.Data {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintOutputGen(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+		for iNdEx := len(m.Data) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Data[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOutputGen(dAtA, i, uint64(size))
 			}
-			i += n
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
-func (m *DmlgenTypes) Marshal() (dAtA []byte, err error) {
+func (m *CustomerEntityDeleteResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
 	return dAtA[:n], nil
 }
 
-func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+func (m *CustomerEntityDeleteResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CustomerEntityDeleteResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
+	if m.RowsAffected != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.RowsAffected))
+		i--
 		dAtA[i] = 0x8
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.ID))
 	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColBigint1.Size()))
-	n45, err := m.ColBigint1.MarshalTo(dAtA[i:])
+	return len(dAtA) - i, nil
+}
+
+func (m *DmlgenTypes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	i += n45
-	if m.ColBigint2 != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColBigint2))
+	return dAtA[:n], nil
+}
+
+func (m *DmlgenTypes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DmlgenTypes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.ColPoint.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColBigint3.Size()))
-	n46, err := m.ColBigint3.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x2
+	i--
+	dAtA[i] = 0xea
+	{
+		size, err := m.ColJSON.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n46
-	if m.ColBigint4 != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColBigint4))
+	i--
+	dAtA[i] = 0x2
+	i--
+	dAtA[i] = 0xe2
+	if m.ColSet != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColSet))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xd8
+	}
+	if len(m.ColEnum) > 0 {
+		i -= len(m.ColEnum)
+		copy(dAtA[i:], m.ColEnum)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColEnum)))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xd2
 	}
-	if len(m.ColBlob) > 0 {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColBlob)))
-		i += copy(dAtA[i:], m.ColBlob)
+	if len(m.ColChar2) > 0 {
+		i -= len(m.ColChar2)
+		copy(dAtA[i:], m.ColChar2)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColChar2)))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xca
 	}
-	dAtA[i] = 0x3a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDate1.Size()))
-	n47, err := m.ColDate1.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.ColChar1.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n47
-	dAtA[i] = 0x42
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.ColDate2)))
-	n48, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ColDate2, dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x2
+	i--
+	dAtA[i] = 0xc2
+	if len(m.ColVarchar16) > 0 {
+		i -= len(m.ColVarchar16)
+		copy(dAtA[i:], m.ColVarchar16)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColVarchar16)))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xba
 	}
-	i += n48
-	dAtA[i] = 0x4a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDatetime1.Size()))
-	n49, err := m.ColDatetime1.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.ColVarchar100.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n49
-	dAtA[i] = 0x52
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.ColDatetime2)))
-	n50, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ColDatetime2, dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x2
+	i--
+	dAtA[i] = 0xb2
+	if len(m.ColVarchar1) > 0 {
+		i -= len(m.ColVarchar1)
+		copy(dAtA[i:], m.ColVarchar1)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColVarchar1)))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xaa
 	}
-	i += n50
-	dAtA[i] = 0x5a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDecimal101.Size()))
-	n51, err := m.ColDecimal101.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.ColTinyint1 != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColTinyint1))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xa0
 	}
-	i += n51
-	dAtA[i] = 0x62
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDecimal124.Size()))
-	n52, err := m.ColDecimal124.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.ColTimestamp2.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n52
-	dAtA[i] = 0x6a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Price124a.Size()))
-	n53, err := m.Price124a.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x2
+	i--
+	dAtA[i] = 0x9a
+	n50, err50 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ColTimestamp1, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ColTimestamp1):])
+	if err50 != nil {
+		return 0, err50
 	}
-	i += n53
-	dAtA[i] = 0x72
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.Price124b.Size()))
-	n54, err := m.Price124b.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i -= n50
+	i = encodeVarintOutputGen(dAtA, i, uint64(n50))
+	i--
+	dAtA[i] = 0x2
+	i--
+	dAtA[i] = 0x92
+	{
+		size, err := m.ColText.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n54
-	dAtA[i] = 0x7a
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDecimal123.Size()))
-	n55, err := m.ColDecimal123.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x2
+	i--
+	dAtA[i] = 0x8a
+	{
+		size, err := m.IsSmallint5.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n55
+	i--
+	dAtA[i] = 0x2
+	i--
 	dAtA[i] = 0x82
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDecimal206.Size()))
-	n56, err := m.ColDecimal206.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.HasSmallint5 {
+		i--
+		if m.HasSmallint5 {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf8
 	}
-	i += n56
-	dAtA[i] = 0x8a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColDecimal2412.Size()))
-	n57, err := m.ColDecimal2412.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.ColSmallint4 != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColSmallint4))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf0
 	}
-	i += n57
-	dAtA[i] = 0x92
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColInt1.Size()))
-	n58, err := m.ColInt1.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.ColSmallint3.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n58
-	if m.ColInt2 != 0 {
-		dAtA[i] = 0x98
-		i++
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xea
+	if m.ColSmallint2 != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColSmallint2))
+		i--
 		dAtA[i] = 0x1
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColInt2))
+		i--
+		dAtA[i] = 0xe0
 	}
-	dAtA[i] = 0xa2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColInt3.Size()))
-	n59, err := m.ColInt3.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.ColSmallint1.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n59
-	if m.ColInt4 != 0 {
-		dAtA[i] = 0xa8
-		i++
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xda
+	if len(m.ColMediumtext2) > 0 {
+		i -= len(m.ColMediumtext2)
+		copy(dAtA[i:], m.ColMediumtext2)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColMediumtext2)))
+		i--
 		dAtA[i] = 0x1
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColInt4))
+		i--
+		dAtA[i] = 0xd2
 	}
-	dAtA[i] = 0xb2
-	i++
+	{
+		size, err := m.ColMediumtext1.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
 	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColLongtext1.Size()))
-	n60, err := m.ColLongtext1.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0xca
+	if len(m.ColMediumblob) > 0 {
+		i -= len(m.ColMediumblob)
+		copy(dAtA[i:], m.ColMediumblob)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColMediumblob)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc2
 	}
-	i += n60
 	if len(m.ColLongtext2) > 0 {
+		i -= len(m.ColLongtext2)
+		copy(dAtA[i:], m.ColLongtext2)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColLongtext2)))
+		i--
+		dAtA[i] = 0x1
+		i--
 		dAtA[i] = 0xba
-		i++
+	}
+	{
+		size, err := m.ColLongtext1.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xb2
+	if m.ColInt4 != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColInt4))
+		i--
 		dAtA[i] = 0x1
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColLongtext2)))
-		i += copy(dAtA[i:], m.ColLongtext2)
+		i--
+		dAtA[i] = 0xa8
+	}
+	{
+		size, err := m.ColInt3.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	if len(m.ColMediumblob) > 0 {
-		dAtA[i] = 0xc2
-		i++
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xa2
+	if m.ColInt2 != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColInt2))
+		i--
 		dAtA[i] = 0x1
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColMediumblob)))
-		i += copy(dAtA[i:], m.ColMediumblob)
+		i--
+		dAtA[i] = 0x98
 	}
-	dAtA[i] = 0xca
-	i++
+	{
+		size, err := m.ColInt1.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
+	}
+	i--
 	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColMediumtext1.Size()))
-	n61, err := m.ColMediumtext1.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x92
+	{
+		size, err := m.ColDecimal2412.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n61
-	if len(m.ColMediumtext2) > 0 {
-		dAtA[i] = 0xd2
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColMediumtext2)))
-		i += copy(dAtA[i:], m.ColMediumtext2)
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0x8a
+	{
+		size, err := m.ColDecimal206.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	dAtA[i] = 0xda
-	i++
+	i--
 	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColSmallint1.Size()))
-	n62, err := m.ColSmallint1.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x82
+	{
+		size, err := m.ColDecimal123.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n62
-	if m.ColSmallint2 != 0 {
-		dAtA[i] = 0xe0
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColSmallint2))
+	i--
+	dAtA[i] = 0x7a
+	{
+		size, err := m.Price124b.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	dAtA[i] = 0xea
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColSmallint3.Size()))
-	n63, err := m.ColSmallint3.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x72
+	{
+		size, err := m.Price124a.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n63
-	if m.ColSmallint4 != 0 {
-		dAtA[i] = 0xf0
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColSmallint4))
+	i--
+	dAtA[i] = 0x6a
+	{
+		size, err := m.ColDecimal124.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	if m.HasSmallint5 {
-		dAtA[i] = 0xf8
-		i++
-		dAtA[i] = 0x1
-		i++
-		if m.HasSmallint5 {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	i--
+	dAtA[i] = 0x62
+	{
+		size, err := m.ColDecimal101.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
-		i++
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	dAtA[i] = 0x82
-	i++
-	dAtA[i] = 0x2
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.IsSmallint5.Size()))
-	n64, err := m.IsSmallint5.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x5a
+	n66, err66 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ColDatetime2, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ColDatetime2):])
+	if err66 != nil {
+		return 0, err66
 	}
-	i += n64
-	dAtA[i] = 0x8a
-	i++
-	dAtA[i] = 0x2
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColText.Size()))
-	n65, err := m.ColText.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i -= n66
+	i = encodeVarintOutputGen(dAtA, i, uint64(n66))
+	i--
+	dAtA[i] = 0x52
+	{
+		size, err := m.ColDatetime1.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n65
-	dAtA[i] = 0x92
-	i++
-	dAtA[i] = 0x2
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.ColTimestamp1)))
-	n66, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ColTimestamp1, dAtA[i:])
-	if err != nil {
-		return 0, err
+	i--
+	dAtA[i] = 0x4a
+	n68, err68 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ColDate2, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ColDate2):])
+	if err68 != nil {
+		return 0, err68
 	}
-	i += n66
-	dAtA[i] = 0x9a
-	i++
-	dAtA[i] = 0x2
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColTimestamp2.Size()))
-	n67, err := m.ColTimestamp2.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	i -= n68
+	i = encodeVarintOutputGen(dAtA, i, uint64(n68))
+	i--
+	dAtA[i] = 0x42
+	{
+		size, err := m.ColDate1.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n67
-	if m.ColTinyint1 != 0 {
-		dAtA[i] = 0xa0
-		i++
-		dAtA[i] = 0x2
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColTinyint1))
+	i--
+	dAtA[i] = 0x3a
+	if len(m.ColBlob) > 0 {
+		i -= len(m.ColBlob)
+		copy(dAtA[i:], m.ColBlob)
+		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColBlob)))
+		i--
+		dAtA[i] = 0x32
 	}
-	if len(m.ColVarchar1) > 0 {
-		dAtA[i] = 0xaa
-		i++
-		dAtA[i] = 0x2
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColVarchar1)))
-		i += copy(dAtA[i:], m.ColVarchar1)
+	if m.ColBigint4 != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColBigint4))
+		i--
+		dAtA[i] = 0x28
 	}
-	dAtA[i] = 0xb2
-	i++
-	dAtA[i] = 0x2
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColVarchar100.Size()))
-	n68, err := m.ColVarchar100.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.ColBigint3.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n68
-	if len(m.ColVarchar16) > 0 {
-		dAtA[i] = 0xba
-		i++
-		dAtA[i] = 0x2
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColVarchar16)))
-		i += copy(dAtA[i:], m.ColVarchar16)
+	i--
+	dAtA[i] = 0x22
+	if m.ColBigint2 != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.ColBigint2))
+		i--
+		dAtA[i] = 0x18
 	}
-	dAtA[i] = 0xc2
-	i++
-	dAtA[i] = 0x2
-	i++
-	i = encodeVarintOutputGen(dAtA, i, uint64(m.ColChar1.Size()))
-	n69, err := m.ColChar1.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	{
+		size, err := m.ColBigint1.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOutputGen(dAtA, i, uint64(size))
 	}
-	i += n69
-	if len(m.ColChar2) > 0 {
-		dAtA[i] = 0xca
-		i++
-		dAtA[i] = 0x2
-		i++
-		i = encodeVarintOutputGen(dAtA, i, uint64(len(m.ColChar2)))
-		i += copy(dAtA[i:], m.ColChar2)
+	i--
+	dAtA[i] = 0x12
+	if m.ID != 0 {
+		i = encodeVarintOutputGen(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func (m *DmlgenTypesCollection) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
@@ -1312,33 +2114,42 @@ func (m *DmlgenTypesCollection) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *DmlgenTypesCollection) MarshalTo(dAtA []byte) (int, error) {
-	var i int
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DmlgenTypesCollection) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
 	if len(m.Data) > 0 {
-		for _, msg := range m.Data {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintOutputGen(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+		for iNdEx := len(m.Data) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Data[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOutputGen(dAtA, i, uint64(size))
 			}
-			i += n
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return i, nil
+	return len(dAtA) - i, nil
 }
 
 func encodeVarintOutputGen(dAtA []byte, offset int, v uint64) int {
+	offset -= sovOutputGen(v)
+	base := offset
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
 		v >>= 7
 		offset++
 	}
 	dAtA[offset] = uint8(v)
-	return offset + 1
+	return base
 }
 func (m *CoreConfigData) Size() (n int) {
 	if m == nil {
@@ -1556,6 +2367,94 @@ func (m *CustomerEntityCollection) Size() (n int) {
 	return n
 }
 
+func (m *CustomerEntityPrimaryKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EntityID != 0 {
+		n += 1 + sovOutputGen(uint64(m.EntityID))
+	}
+	return n
+}
+
+func (m *CustomerEntityListFilter) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Column)
+	if l > 0 {
+		n += 1 + l + sovOutputGen(uint64(l))
+	}
+	l = len(m.Operator)
+	if l > 0 {
+		n += 1 + l + sovOutputGen(uint64(l))
+	}
+	if len(m.Values) > 0 {
+		for _, s := range m.Values {
+			l = len(s)
+			n += 1 + l + sovOutputGen(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CustomerEntityListRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Filters) > 0 {
+		for _, e := range m.Filters {
+			l = e.Size()
+			n += 1 + l + sovOutputGen(uint64(l))
+		}
+	}
+	if m.PageSize != 0 {
+		n += 1 + sovOutputGen(uint64(m.PageSize))
+	}
+	l = len(m.PageToken)
+	if l > 0 {
+		n += 1 + l + sovOutputGen(uint64(l))
+	}
+	return n
+}
+
+func (m *CustomerEntityListResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		for _, e := range m.Data {
+			l = e.Size()
+			n += 1 + l + sovOutputGen(uint64(l))
+		}
+	}
+	l = len(m.NextPageToken)
+	if l > 0 {
+		n += 1 + l + sovOutputGen(uint64(l))
+	}
+	return n
+}
+
+func (m *CustomerEntityDeleteResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RowsAffected != 0 {
+		n += 1 + sovOutputGen(uint64(m.RowsAffected))
+	}
+	return n
+}
+
 func (m *DmlgenTypes) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1667,6 +2566,17 @@ func (m *DmlgenTypes) Size() (n int) {
 	if l > 0 {
 		n += 2 + l + sovOutputGen(uint64(l))
 	}
+	l = len(m.ColEnum)
+	if l > 0 {
+		n += 2 + l + sovOutputGen(uint64(l))
+	}
+	if m.ColSet != 0 {
+		n += 2 + sovOutputGen(uint64(m.ColSet))
+	}
+	l = m.ColJSON.Size()
+	n += 2 + l + sovOutputGen(uint64(l))
+	l = m.ColPoint.Size()
+	n += 2 + l + sovOutputGen(uint64(l))
 	return n
 }
 
@@ -1686,14 +2596,7 @@ func (m *DmlgenTypesCollection) Size() (n int) {
 }
 
 func sovOutputGen(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
-	}
-	return n
+	return (math_bits.Len64(x|1) + 6) / 7
 }
 func sozOutputGen(x uint64) (n int) {
 	return sovOutputGen(uint64((x << 1) ^ uint64((int64(x) >> 63))))
@@ -1713,7 +2616,7 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -1741,7 +2644,7 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ConfigID |= (uint32(b) & 0x7F) << shift
+				m.ConfigID |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1760,7 +2663,7 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1770,6 +2673,9 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1789,7 +2695,7 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ScopeID |= (int32(b) & 0x7F) << shift
+				m.ScopeID |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1808,7 +2714,7 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1817,6 +2723,9 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1838,7 +2747,7 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1848,6 +2757,9 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1867,7 +2779,7 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1876,6 +2788,9 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1897,7 +2812,7 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1906,6 +2821,9 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1927,7 +2845,7 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1936,6 +2854,9 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -1949,7 +2870,7 @@ func (m *CoreConfigData) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthOutputGen
 			}
 			if (iNdEx + skippy) > l {
@@ -1979,7 +2900,7 @@ func (m *CoreConfigDataCollection) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2007,7 +2928,7 @@ func (m *CoreConfigDataCollection) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2016,6 +2937,9 @@ func (m *CoreConfigDataCollection) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2030,7 +2954,7 @@ func (m *CoreConfigDataCollection) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthOutputGen
 			}
 			if (iNdEx + skippy) > l {
@@ -2060,7 +2984,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2088,7 +3012,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EntityID |= (uint32(b) & 0x7F) << shift
+				m.EntityID |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2107,7 +3031,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2116,6 +3040,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2137,7 +3064,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2146,6 +3073,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2167,7 +3097,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2176,6 +3106,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2197,7 +3130,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2206,6 +3139,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2227,7 +3163,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2247,7 +3183,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2257,6 +3193,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2276,7 +3215,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2285,6 +3224,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2306,7 +3248,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2316,6 +3258,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2335,7 +3280,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2344,6 +3289,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2365,7 +3313,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2375,6 +3323,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2394,7 +3345,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2404,6 +3355,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2423,7 +3377,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2432,6 +3386,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2453,7 +3410,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2462,6 +3419,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2483,7 +3443,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2492,6 +3452,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2513,7 +3476,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2522,6 +3485,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2543,7 +3509,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2552,6 +3518,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2573,7 +3542,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2583,6 +3552,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2602,7 +3574,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2611,6 +3583,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2632,7 +3607,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2642,6 +3617,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2661,7 +3639,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2670,6 +3648,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2691,7 +3672,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2700,6 +3681,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2721,7 +3705,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2730,6 +3714,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2751,7 +3738,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2760,6 +3747,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2781,7 +3771,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2790,6 +3780,9 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2803,7 +3796,7 @@ func (m *CustomerAddressEntity) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthOutputGen
 			}
 			if (iNdEx + skippy) > l {
@@ -2833,7 +3826,7 @@ func (m *CustomerAddressEntityCollection) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2861,7 +3854,7 @@ func (m *CustomerAddressEntityCollection) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2870,6 +3863,9 @@ func (m *CustomerAddressEntityCollection) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2884,7 +3880,7 @@ func (m *CustomerAddressEntityCollection) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthOutputGen
 			}
 			if (iNdEx + skippy) > l {
@@ -2914,7 +3910,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -2930,9 +3926,265 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EntityID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EntityID", wireType)
+			}
+			m.EntityID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EntityID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WebsiteID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.WebsiteID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Email.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncrementID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.IncrementID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.StoreID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.CreatedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.UpdatedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsActive", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsActive = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisableAutoGroupChange", wireType)
 			}
-			m.EntityID = 0
+			m.DisableAutoGroupChange = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOutputGen
@@ -2942,14 +4194,14 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EntityID |= (uint32(b) & 0x7F) << shift
+				m.DisableAutoGroupChange |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WebsiteID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedIn", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2961,7 +4213,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2970,16 +4222,19 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.WebsiteID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CreatedIn.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2991,7 +4246,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3000,18 +4255,21 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Email.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Prefix.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Firstname", wireType)
 			}
-			m.GroupID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOutputGen
@@ -3021,14 +4279,28 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupID |= (uint32(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+			if msglen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Firstname.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IncrementID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Middlename", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3040,7 +4312,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3049,16 +4321,19 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.IncrementID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Middlename.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Lastname", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3070,7 +4345,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3079,16 +4354,19 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.StoreID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Lastname.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Suffix", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3100,7 +4378,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3109,16 +4387,19 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.CreatedAt, dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Suffix.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 17:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Dob", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3130,7 +4411,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3139,18 +4420,21 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.UpdatedAt, dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Dob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsActive", wireType)
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RpToken", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOutputGen
@@ -3160,17 +4444,30 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IsActive = bool(v != 0)
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DisableAutoGroupChange", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthOutputGen
 			}
-			m.DisableAutoGroupChange = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RpToken.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RpTokenCreatedAt", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOutputGen
@@ -3180,14 +4477,28 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DisableAutoGroupChange |= (uint32(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 11:
+			if msglen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RpTokenCreatedAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedIn", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultBilling", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3199,7 +4510,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3208,16 +4519,19 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreatedIn.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.DefaultBilling.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 12:
+		case 22:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultShipping", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3229,7 +4543,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3238,16 +4552,19 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Prefix.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.DefaultShipping.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 13:
+		case 23:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Firstname", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Taxvat", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3259,7 +4576,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3268,16 +4585,19 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Firstname.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Taxvat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 14:
+		case 24:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Middlename", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Confirmation", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3289,7 +4609,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3298,16 +4618,19 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Middlename.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Confirmation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 15:
+		case 25:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lastname", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Gender", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3319,7 +4642,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3328,16 +4651,19 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Lastname.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Gender.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 16:
+		case 26:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Suffix", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FailuresNum", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3349,7 +4675,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3358,16 +4684,19 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Suffix.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.FailuresNum.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 17:
+		case 27:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Dob", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstFailure", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3379,7 +4708,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3388,16 +4717,102 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Dob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.FirstFailure.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 19:
+		case 28:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockExpires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.LockExpires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOutputGen(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CustomerEntityCollection) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOutputGen
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CustomerEntityCollection: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CustomerEntityCollection: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RpToken", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3409,7 +4824,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3418,18 +4833,72 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RpToken.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Data = append(m.Data, &CustomerEntity{})
+			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 20:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RpTokenCreatedAt", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOutputGen(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CustomerEntityPrimaryKey) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOutputGen
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CustomerEntityPrimaryKey: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CustomerEntityPrimaryKey: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EntityID", wireType)
+			}
+			m.EntityID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOutputGen
@@ -3439,27 +4908,66 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.EntityID |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOutputGen(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthOutputGen
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RpTokenCreatedAt.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CustomerEntityListFilter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOutputGen
 			}
-			iNdEx = postIndex
-		case 21:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CustomerEntityListFilter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CustomerEntityListFilter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DefaultBilling", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Column", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOutputGen
@@ -3469,27 +4977,29 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthOutputGen
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.DefaultBilling.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Column = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 22:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DefaultShipping", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Operator", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOutputGen
@@ -3499,27 +5009,29 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthOutputGen
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.DefaultShipping.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Operator = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 23:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Taxvat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOutputGen
@@ -3529,25 +5041,77 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthOutputGen
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Taxvat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOutputGen(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 24:
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CustomerEntityListRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOutputGen
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CustomerEntityListRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CustomerEntityListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Confirmation", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Filters", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3559,7 +5123,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3568,18 +5132,22 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Confirmation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Filters = append(m.Filters, &CustomerEntityListFilter{})
+			if err := m.Filters[len(m.Filters)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 25:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Gender", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PageSize", wireType)
 			}
-			var msglen int
+			m.PageSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOutputGen
@@ -3589,27 +5157,16 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.PageSize |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthOutputGen
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Gender.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 26:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FailuresNum", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PageToken", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOutputGen
@@ -3619,25 +5176,77 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PageToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOutputGen(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthOutputGen
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CustomerEntityListResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOutputGen
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.FailuresNum.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 27:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CustomerEntityListResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CustomerEntityListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FirstFailure", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3649,7 +5258,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3658,18 +5267,22 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.FirstFailure.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Data = append(m.Data, &CustomerEntity{})
+			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 28:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LockExpires", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NextPageToken", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOutputGen
@@ -3679,21 +5292,23 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthOutputGen
 			}
-			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.LockExpires.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NextPageToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3701,7 +5316,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthOutputGen
 			}
 			if (iNdEx + skippy) > l {
@@ -3716,7 +5331,7 @@ func (m *CustomerEntity) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CustomerEntityCollection) Unmarshal(dAtA []byte) error {
+func (m *CustomerEntityDeleteResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3731,7 +5346,7 @@ func (m *CustomerEntityCollection) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -3739,17 +5354,17 @@ func (m *CustomerEntityCollection) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CustomerEntityCollection: wiretype end group for non-group")
+			return fmt.Errorf("proto: CustomerEntityDeleteResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CustomerEntityCollection: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CustomerEntityDeleteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowsAffected", wireType)
 			}
-			var msglen int
+			m.RowsAffected = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOutputGen
@@ -3759,30 +5374,18 @@ func (m *CustomerEntityCollection) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.RowsAffected |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthOutputGen
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data, &CustomerEntity{})
-			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOutputGen(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthOutputGen
 			}
 			if (iNdEx + skippy) > l {
@@ -3812,7 +5415,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -3840,7 +5443,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= (int32(b) & 0x7F) << shift
+				m.ID |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3859,7 +5462,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3868,6 +5471,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -3889,7 +5495,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ColBigint2 |= (int64(b) & 0x7F) << shift
+				m.ColBigint2 |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3908,7 +5514,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3917,6 +5523,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -3938,7 +5547,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ColBigint4 |= (uint64(b) & 0x7F) << shift
+				m.ColBigint4 |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3957,7 +5566,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3966,6 +5575,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -3988,7 +5600,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3997,6 +5609,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4018,7 +5633,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4027,6 +5642,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4048,7 +5666,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4057,6 +5675,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4078,7 +5699,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4087,6 +5708,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4108,7 +5732,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4117,6 +5741,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4138,7 +5765,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4147,6 +5774,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4168,7 +5798,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4177,6 +5807,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4198,7 +5831,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4207,6 +5840,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4228,7 +5864,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4237,6 +5873,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4258,7 +5897,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4267,6 +5906,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4288,7 +5930,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4297,6 +5939,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4318,7 +5963,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4327,6 +5972,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4348,7 +5996,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ColInt2 |= (int32(b) & 0x7F) << shift
+				m.ColInt2 |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4367,7 +6015,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4376,6 +6024,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4397,7 +6048,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ColInt4 |= (uint32(b) & 0x7F) << shift
+				m.ColInt4 |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4416,7 +6067,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4425,6 +6076,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4446,7 +6100,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4456,6 +6110,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4475,7 +6132,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4484,6 +6141,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4506,7 +6166,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4515,6 +6175,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4536,7 +6199,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4546,6 +6209,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4565,7 +6231,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4574,6 +6240,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4595,7 +6264,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ColSmallint2 |= (int32(b) & 0x7F) << shift
+				m.ColSmallint2 |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4614,7 +6283,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4623,6 +6292,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4644,7 +6316,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ColSmallint4 |= (uint32(b) & 0x7F) << shift
+				m.ColSmallint4 |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4663,7 +6335,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4683,7 +6355,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4692,6 +6364,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4713,7 +6388,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4722,6 +6397,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4743,7 +6421,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4752,6 +6430,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4773,7 +6454,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4782,6 +6463,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4803,7 +6487,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ColTinyint1 |= (int32(b) & 0x7F) << shift
+				m.ColTinyint1 |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4822,7 +6506,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4832,6 +6516,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4851,7 +6538,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4860,6 +6547,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4881,7 +6571,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4891,6 +6581,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4910,7 +6603,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4919,6 +6612,9 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -4940,7 +6636,7 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4950,18 +6646,138 @@ func (m *DmlgenTypes) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
 			m.ColChar2 = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 42:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColEnum", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ColEnum = DmlgenTypesColEnum(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 43:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColSet", wireType)
+			}
+			m.ColSet = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ColSet |= DmlgenTypesColSet(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 44:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColJSON", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ColJSON.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 45:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColPoint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOutputGen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ColPoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOutputGen(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthOutputGen
 			}
 			if (iNdEx + skippy) > l {
@@ -4991,7 +6807,7 @@ func (m *DmlgenTypesCollection) Unmarshal(dAtA []byte) error {
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
+			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
 			}
@@ -5019,7 +6835,7 @@ func (m *DmlgenTypesCollection) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5028,6 +6844,9 @@ func (m *DmlgenTypesCollection) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthOutputGen
 			}
 			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOutputGen
+			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
@@ -5042,7 +6861,7 @@ func (m *DmlgenTypesCollection) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthOutputGen
 			}
 			if (iNdEx + skippy) > l {
@@ -5060,6 +6879,7 @@ func (m *DmlgenTypesCollection) Unmarshal(dAtA []byte) error {
 func skipOutputGen(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -5091,10 +6911,8 @@ func skipOutputGen(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -5111,205 +6929,34 @@ func skipOutputGen(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			iNdEx += length
 			if length < 0 {
 				return 0, ErrInvalidLengthOutputGen
 			}
-			return iNdEx, nil
+			iNdEx += length
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowOutputGen
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipOutputGen(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupOutputGen
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthOutputGen
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthOutputGen = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowOutputGen   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthOutputGen        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowOutputGen          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupOutputGen = fmt.Errorf("proto: unexpected end of group")
 )
-
-func init() {
-	proto.RegisterFile("testdata/output_gen.proto", fileDescriptor_output_gen_4df98b8fb2fdac10)
-}
-
-var fileDescriptor_output_gen_4df98b8fb2fdac10 = []byte{
-	// 2293 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xdd, 0x72, 0x1b, 0xb7,
-	0xd9, 0xb6, 0x2c, 0x51, 0x22, 0xc1, 0x1f, 0x49, 0xb0, 0xa5, 0xac, 0x9c, 0x7c, 0xa2, 0x3e, 0xb5,
-	0x4d, 0xe4, 0x26, 0x95, 0xc4, 0x25, 0xad, 0xba, 0x69, 0xda, 0xb1, 0x49, 0xfa, 0x67, 0xa7, 0xb6,
-	0x47, 0x03, 0xc9, 0xca, 0x4c, 0x66, 0x3a, 0x3b, 0xcb, 0x5d, 0x88, 0xc2, 0x78, 0xb9, 0xcb, 0xee,
-	0x62, 0x59, 0xe9, 0x2e, 0x72, 0x29, 0x39, 0xe8, 0x25, 0xf4, 0xc0, 0x87, 0xbd, 0x02, 0xb5, 0x55,
-	0x6e, 0xa4, 0x83, 0x17, 0xd8, 0x3f, 0x72, 0x43, 0xcb, 0x99, 0x9c, 0x68, 0xf0, 0xf3, 0x3c, 0x0f,
-	0xb0, 0x2f, 0x5e, 0xe0, 0x01, 0x28, 0xb4, 0xc5, 0x69, 0xc8, 0x1d, 0x8b, 0x5b, 0x07, 0x7e, 0xc4,
-	0xc7, 0x11, 0x37, 0x87, 0xd4, 0xdb, 0x1f, 0x07, 0x3e, 0xf7, 0x71, 0x39, 0xee, 0x7a, 0xf0, 0xbb,
-	0x21, 0xe3, 0x17, 0xd1, 0x60, 0xdf, 0xf6, 0x47, 0x07, 0x43, 0x7f, 0xe8, 0x1f, 0x00, 0x60, 0x10,
-	0x9d, 0x43, 0x0d, 0x2a, 0x50, 0x92, 0xc4, 0x07, 0xcd, 0xa1, 0xef, 0x0f, 0x5d, 0x9a, 0xa2, 0x38,
-	0x1b, 0xd1, 0x90, 0x5b, 0xa3, 0xb1, 0x02, 0xe8, 0x19, 0x3d, 0xdb, 0x0f, 0x68, 0xc8, 0xfd, 0x80,
-	0x32, 0xff, 0x60, 0xfc, 0x6e, 0x78, 0x20, 0xca, 0xd6, 0x90, 0x1e, 0x78, 0x91, 0xeb, 0xc2, 0x1f,
-	0xc9, 0xd9, 0xfd, 0x61, 0x11, 0x35, 0x7a, 0x7e, 0x40, 0x7b, 0xbe, 0x77, 0xce, 0x86, 0x7d, 0x8b,
-	0x5b, 0xf8, 0x21, 0xaa, 0xd8, 0x50, 0x33, 0x99, 0xa3, 0x2d, 0xec, 0x2c, 0xec, 0xd5, 0xbb, 0xb5,
-	0x9b, 0xeb, 0x66, 0x59, 0x42, 0x8c, 0x3e, 0x29, 0xcb, 0x6e, 0xc3, 0xc1, 0x4d, 0x54, 0x0a, 0x6d,
-	0x7f, 0x4c, 0xb5, 0xbb, 0x3b, 0x0b, 0x7b, 0x95, 0x6e, 0xe5, 0xe6, 0xba, 0x59, 0x3a, 0x11, 0x0d,
-	0x44, 0xb6, 0xe3, 0xcf, 0x51, 0x19, 0x0a, 0x42, 0x6a, 0x71, 0x67, 0x61, 0xaf, 0xd4, 0xad, 0xde,
-	0x5c, 0x37, 0x57, 0x00, 0x63, 0xf4, 0xc9, 0x0a, 0x74, 0x1a, 0x0e, 0x7e, 0x84, 0x56, 0xe8, 0xe5,
-	0x98, 0x05, 0x34, 0xd4, 0x96, 0x76, 0x16, 0xf6, 0xaa, 0x3a, 0xda, 0x87, 0x49, 0x9e, 0xb2, 0x11,
-	0xed, 0xae, 0xbe, 0xbf, 0x6e, 0xde, 0x11, 0xb4, 0x67, 0x12, 0x42, 0x62, 0x2c, 0xfe, 0x0c, 0x2d,
-	0x8d, 0x2d, 0x7e, 0xa1, 0x95, 0x60, 0xf8, 0xf2, 0xcd, 0x75, 0x73, 0xe9, 0xd8, 0xe2, 0x17, 0x04,
-	0x5a, 0x71, 0x0b, 0x95, 0x26, 0x96, 0x1b, 0x51, 0x6d, 0x19, 0x24, 0x6b, 0x52, 0xf2, 0x84, 0x07,
-	0xcc, 0x1b, 0x76, 0xeb, 0x4a, 0xb4, 0x74, 0x26, 0x20, 0x44, 0x22, 0xf1, 0x31, 0x42, 0x13, 0x1a,
-	0x84, 0xcc, 0xf7, 0x4c, 0x1e, 0x6a, 0x2b, 0xc0, 0x7b, 0xb0, 0x2f, 0x03, 0xbf, 0x1f, 0x07, 0x1e,
-	0x66, 0x05, 0x81, 0xef, 0x6e, 0x28, 0x95, 0xca, 0x99, 0x64, 0x9d, 0x86, 0xdf, 0xff, 0xbb, 0xb9,
-	0x40, 0x2a, 0x93, 0xb8, 0x9a, 0x53, 0xa4, 0x5a, 0xf9, 0xe3, 0x15, 0x69, 0x5e, 0x91, 0xee, 0xbe,
-	0x44, 0x5a, 0x7e, 0xc5, 0x7a, 0xbe, 0xeb, 0x52, 0x9b, 0x33, 0xdf, 0xc3, 0x5f, 0xa1, 0x25, 0xd1,
-	0xa2, 0x2d, 0xec, 0x2c, 0xee, 0x55, 0x75, 0x6d, 0x3f, 0xce, 0xb5, 0xfd, 0x3c, 0x83, 0x00, 0x6a,
-	0xf7, 0x1f, 0x55, 0xb4, 0xd1, 0x8b, 0x42, 0xee, 0x8f, 0x68, 0xf0, 0xd4, 0x71, 0x02, 0x1a, 0x86,
-	0xcf, 0x3c, 0xce, 0xf8, 0x95, 0xc8, 0x01, 0x0a, 0xa5, 0xa9, 0x1c, 0x90, 0xdd, 0x22, 0x07, 0x64,
-	0xb7, 0xe1, 0xe0, 0x3e, 0xaa, 0x31, 0xcf, 0x0e, 0xe8, 0x88, 0x7a, 0x5c, 0xa0, 0xef, 0x16, 0x04,
-	0xfb, 0x9e, 0xfa, 0xa8, 0xaa, 0x11, 0x23, 0x8d, 0x3e, 0xa9, 0x26, 0x34, 0xc3, 0xc1, 0x7f, 0x44,
-	0x95, 0xb1, 0x15, 0x28, 0x89, 0xc5, 0xac, 0xc4, 0x5b, 0xe6, 0xf1, 0xb6, 0xde, 0x5d, 0x53, 0x12,
-	0xe5, 0x63, 0x80, 0x89, 0x29, 0x48, 0x82, 0xe1, 0x88, 0x18, 0xdb, 0x01, 0xb5, 0x38, 0x75, 0x4c,
-	0x8b, 0xab, 0x04, 0xba, 0x55, 0x8c, 0x7b, 0x92, 0xf5, 0x94, 0xcb, 0x18, 0xdb, 0x71, 0x55, 0x28,
-	0x46, 0x63, 0x27, 0x56, 0x2c, 0xdd, 0x5e, 0xf1, 0xad, 0x64, 0xc5, 0x8a, 0x51, 0x5c, 0x15, 0x11,
-	0x65, 0xa1, 0x69, 0xd9, 0x9c, 0x4d, 0x64, 0x42, 0x96, 0x65, 0x44, 0x8d, 0xf0, 0x29, 0xb4, 0x91,
-	0x32, 0x53, 0x25, 0x91, 0xd5, 0x36, 0xe3, 0x57, 0x90, 0x7e, 0x2a, 0xab, 0x7b, 0x8c, 0x5f, 0x11,
-	0x68, 0xc5, 0xbf, 0x47, 0x2b, 0xb6, 0x3f, 0x1a, 0x5b, 0xde, 0x95, 0xca, 0xa6, 0x7c, 0xa8, 0x93,
-	0xcd, 0xd2, 0x93, 0x20, 0x12, 0xa3, 0xf1, 0x57, 0x08, 0xd9, 0x7e, 0xe4, 0xf1, 0x00, 0x16, 0xb5,
-	0x02, 0xe2, 0x75, 0x88, 0x82, 0x6c, 0x35, 0xfa, 0xa4, 0xa2, 0x00, 0x86, 0x83, 0xbf, 0x44, 0x8b,
-	0xe7, 0xd6, 0xa5, 0x86, 0x0a, 0x86, 0xa8, 0xaa, 0x21, 0x16, 0x9f, 0x5b, 0x97, 0x44, 0xa0, 0xf0,
-	0x97, 0xa8, 0x72, 0xce, 0x82, 0x90, 0x7b, 0xd6, 0x88, 0x6a, 0xd5, 0x54, 0xf9, 0x79, 0xdc, 0x48,
-	0xd2, 0x7e, 0xbc, 0x87, 0xca, 0xae, 0xa5, 0xb0, 0x35, 0xc0, 0x42, 0x20, 0x5e, 0xa9, 0x36, 0x92,
-	0xf4, 0xe2, 0x27, 0x08, 0x8d, 0x98, 0xe3, 0xb8, 0x14, 0xb0, 0xf5, 0x82, 0xa9, 0x60, 0x35, 0x15,
-	0xf4, 0x3a, 0xc1, 0x91, 0x0c, 0x07, 0x7f, 0x8d, 0xca, 0x63, 0x3f, 0xe4, 0xb6, 0xef, 0x50, 0xad,
-	0x51, 0xc0, 0x4f, 0xb3, 0x4a, 0xa1, 0x48, 0x82, 0xc7, 0x1d, 0xb4, 0x3c, 0x0e, 0xe8, 0x39, 0xbb,
-	0xd4, 0x56, 0x0b, 0x98, 0x0d, 0xc5, 0x5c, 0x3e, 0x06, 0x0c, 0x51, 0x58, 0xc1, 0x0a, 0xe8, 0x90,
-	0xf9, 0x9e, 0xb6, 0x36, 0x8f, 0x45, 0x00, 0x43, 0x14, 0x56, 0xa4, 0xbf, 0x2c, 0x89, 0xa5, 0x59,
-	0x9f, 0x97, 0xfe, 0x92, 0x28, 0xd2, 0x5f, 0x12, 0x0c, 0x07, 0xef, 0xa2, 0xe5, 0x90, 0x07, 0x94,
-	0x72, 0x0d, 0x43, 0x38, 0x91, 0x18, 0xe0, 0x04, 0x5a, 0x88, 0xea, 0x11, 0xd3, 0x0a, 0xa3, 0x73,
-	0xf1, 0x31, 0xf7, 0xe6, 0x4d, 0xeb, 0x04, 0x30, 0x44, 0x61, 0xc5, 0xba, 0x72, 0xea, 0xd2, 0xf1,
-	0x85, 0xef, 0x51, 0xed, 0x7e, 0xba, 0xae, 0xa7, 0x71, 0x23, 0x49, 0xfb, 0xb1, 0x8e, 0x96, 0x27,
-	0x16, 0xec, 0xdf, 0x8d, 0xf9, 0xe7, 0xad, 0xd8, 0xbc, 0xa5, 0x89, 0x25, 0x76, 0xee, 0x13, 0x54,
-	0x03, 0x4e, 0x68, 0x4e, 0x2c, 0x97, 0x39, 0xda, 0x66, 0xf6, 0xf0, 0xef, 0xfa, 0xbe, 0x9b, 0xae,
-	0xb0, 0xe0, 0x85, 0x67, 0x02, 0x45, 0xd0, 0x24, 0x29, 0xe3, 0x37, 0x68, 0x4d, 0x28, 0x04, 0xf4,
-	0x6f, 0x11, 0x0d, 0xb9, 0x29, 0xb6, 0x9b, 0xf6, 0x49, 0xc1, 0xf8, 0x9b, 0x4a, 0xa7, 0x71, 0x66,
-	0x71, 0x22, 0xc1, 0x7d, 0x8b, 0x53, 0xd2, 0x98, 0xe4, 0xea, 0xf8, 0x25, 0x6a, 0x64, 0xf5, 0x98,
-	0xa3, 0x69, 0x05, 0x6a, 0xf7, 0x95, 0x5a, 0x2d, 0x55, 0x33, 0xfa, 0xa4, 0x96, 0x6a, 0x19, 0x0e,
-	0xfe, 0x16, 0xdd, 0xcb, 0x2a, 0x85, 0x91, 0x6d, 0xd3, 0x30, 0xd4, 0xb6, 0x0a, 0x56, 0x77, 0x4b,
-	0xc9, 0xad, 0xa7, 0x72, 0x27, 0x12, 0x4e, 0xd6, 0x27, 0xd3, 0x4d, 0xbb, 0x67, 0xa8, 0x59, 0x78,
-	0x6a, 0x67, 0x7c, 0xa0, 0x9d, 0xf3, 0x81, 0x66, 0xc6, 0x07, 0x8a, 0x88, 0xca, 0x0e, 0xfe, 0x59,
-	0x47, 0x8d, 0xb8, 0xff, 0xe3, 0x7d, 0xe0, 0xcf, 0x08, 0xfd, 0x9d, 0x0e, 0x42, 0xc6, 0xe9, 0x8c,
-	0x0b, 0xa8, 0xaf, 0x5c, 0x8f, 0x0f, 0xc9, 0x6f, 0x25, 0x4e, 0x1c, 0x38, 0x8a, 0x62, 0x38, 0xc2,
-	0xad, 0xe9, 0xc8, 0x62, 0x6e, 0xfe, 0xf4, 0x9f, 0xce, 0x9e, 0x67, 0x02, 0x42, 0x24, 0x52, 0xdc,
-	0x2e, 0x86, 0x81, 0x1f, 0x8d, 0xc5, 0x80, 0x4b, 0x30, 0x39, 0xb8, 0x5d, 0xbc, 0x10, 0x6d, 0xe2,
-	0x76, 0x01, 0x9d, 0x05, 0x16, 0x55, 0xfa, 0x59, 0x16, 0xf5, 0x18, 0x95, 0xe1, 0x46, 0x25, 0x14,
-	0x96, 0x0b, 0x3e, 0x2f, 0x39, 0x79, 0x4f, 0x04, 0x0a, 0x6e, 0x37, 0x50, 0x98, 0xf6, 0xa7, 0x95,
-	0x5f, 0xdc, 0x9f, 0xca, 0xbf, 0xb4, 0x3f, 0x55, 0xe6, 0xfa, 0xd3, 0x5b, 0xb4, 0xe5, 0xb0, 0xd0,
-	0x1a, 0xb8, 0xd4, 0xb4, 0x22, 0xee, 0x9b, 0x72, 0x0d, 0xec, 0x0b, 0xcb, 0x1b, 0x52, 0x30, 0x8c,
-	0x7a, 0xf7, 0xc1, 0xcd, 0x75, 0x73, 0xb3, 0x2f, 0x41, 0x4f, 0x23, 0xee, 0xc3, 0x92, 0xf4, 0x00,
-	0x41, 0x36, 0x9d, 0xc2, 0x76, 0x91, 0x40, 0x71, 0x94, 0x98, 0x07, 0x2e, 0x32, 0xbd, 0x46, 0xeb,
-	0x53, 0x71, 0x31, 0xbc, 0x24, 0x26, 0x86, 0x97, 0x39, 0xaf, 0x6b, 0x1f, 0x71, 0x5e, 0xff, 0x29,
-	0x6b, 0x5d, 0xf5, 0x79, 0x83, 0x16, 0x9a, 0x59, 0xde, 0xa2, 0x1a, 0x3f, 0xcf, 0xa2, 0x12, 0x3b,
-	0x5c, 0x9d, 0x67, 0x51, 0x05, 0x06, 0x99, 0x9e, 0xea, 0x6b, 0x1f, 0x71, 0xaa, 0x3f, 0x44, 0x8b,
-	0x8e, 0x3f, 0x50, 0x36, 0x93, 0xbd, 0x68, 0x27, 0xc6, 0xde, 0xf7, 0x07, 0x44, 0x60, 0x44, 0xce,
-	0x07, 0x63, 0x93, 0xfb, 0xef, 0xa8, 0x57, 0x68, 0x1c, 0x49, 0xce, 0x93, 0xf1, 0xa9, 0x00, 0x91,
-	0x95, 0x40, 0x16, 0xf0, 0x09, 0xba, 0x17, 0x33, 0xcd, 0x4c, 0xf2, 0xdf, 0x9f, 0x19, 0x54, 0x53,
-	0x12, 0x6b, 0x4a, 0x22, 0xc9, 0x79, 0xb2, 0x16, 0x4c, 0xb5, 0xe0, 0xd7, 0x68, 0xd5, 0xa1, 0xe7,
-	0x56, 0xe4, 0x72, 0x73, 0xc0, 0x5c, 0x97, 0x79, 0xc3, 0xbc, 0xd7, 0xa8, 0x9d, 0x98, 0x9c, 0xf5,
-	0x7d, 0x09, 0xee, 0x4a, 0x2c, 0x69, 0x38, 0xb9, 0x3a, 0x3e, 0x46, 0x6b, 0xb1, 0x5c, 0x78, 0xc1,
-	0xc6, 0x63, 0xa1, 0xb7, 0x59, 0xa0, 0xf7, 0x89, 0xd2, 0x5b, 0x55, 0x7a, 0x27, 0x0a, 0x4c, 0xe2,
-	0xd9, 0xc4, 0x0d, 0x62, 0x41, 0xb8, 0x75, 0x39, 0xb1, 0x78, 0xa1, 0x07, 0x25, 0x0b, 0x72, 0x0a,
-	0x18, 0xa2, 0xb0, 0xf8, 0x39, 0xaa, 0xc1, 0x93, 0x2a, 0x18, 0x59, 0xe2, 0xf4, 0x9e, 0xef, 0x38,
-	0xbd, 0x0c, 0x92, 0xe4, 0x78, 0x62, 0xf4, 0x21, 0xf5, 0x1c, 0x1a, 0x14, 0x9a, 0x4c, 0x32, 0xfa,
-	0x0b, 0xc0, 0x10, 0x85, 0xc5, 0x3d, 0x54, 0x3b, 0xb7, 0x98, 0x1b, 0x05, 0x34, 0x34, 0xbd, 0x68,
-	0xa4, 0x3d, 0x00, 0x6e, 0x55, 0x72, 0x0d, 0xa0, 0x26, 0x87, 0xe3, 0x73, 0x05, 0x7c, 0x13, 0x8d,
-	0x48, 0xf5, 0x3c, 0xad, 0xe0, 0x67, 0xa8, 0x0e, 0x9b, 0xc2, 0x54, 0x8d, 0xda, 0xa7, 0x33, 0x0b,
-	0x9d, 0x7c, 0x01, 0x6c, 0x24, 0xa5, 0x44, 0x6a, 0xe7, 0x99, 0x1a, 0xee, 0xa2, 0x9a, 0xeb, 0xdb,
-	0xef, 0xcc, 0xf8, 0x31, 0xf8, 0xd9, 0x8c, 0x4a, 0x32, 0x95, 0x57, 0xbe, 0xfd, 0x2e, 0x7e, 0x10,
-	0x56, 0xdd, 0xb4, 0x02, 0xef, 0xa3, 0x9c, 0x8b, 0xdd, 0xea, 0x7d, 0x94, 0x63, 0x28, 0x43, 0x7c,
-	0x7f, 0x1f, 0x55, 0xfb, 0x23, 0x77, 0x48, 0xbd, 0xd3, 0xab, 0x31, 0x0d, 0xf1, 0x26, 0xba, 0xab,
-	0x6c, 0xb0, 0xd4, 0x5d, 0xbe, 0xb9, 0x6e, 0xde, 0x35, 0xfa, 0xe4, 0x2e, 0x73, 0xf0, 0x53, 0xb1,
-	0x7e, 0xae, 0x39, 0x60, 0x43, 0xe6, 0x71, 0xb3, 0xa5, 0xcc, 0x2f, 0x8d, 0xe0, 0x51, 0x27, 0x3d,
-	0x05, 0x7a, 0xbe, 0xdb, 0x05, 0x5c, 0x8b, 0x20, 0x3b, 0x29, 0xe3, 0xc3, 0x9c, 0x84, 0x0e, 0x26,
-	0xb8, 0xd8, 0x6d, 0xe4, 0x18, 0x7a, 0x86, 0xa1, 0x8b, 0x50, 0x65, 0x18, 0x6d, 0xf5, 0xec, 0xc9,
-	0x2c, 0x79, 0xe1, 0xa8, 0xed, 0x8c, 0x46, 0x7b, 0x6a, 0xd4, 0x0e, 0x18, 0xe3, 0xd2, 0xd4, 0xa8,
-	0x9d, 0x0c, 0xa3, 0x23, 0x2c, 0x17, 0x18, 0xae, 0x3f, 0x00, 0x13, 0xac, 0x49, 0xcb, 0x15, 0x68,
-	0xd7, 0x1f, 0x88, 0xc7, 0x06, 0x14, 0xf0, 0xd7, 0xe2, 0xb1, 0xe1, 0xc2, 0x75, 0xcc, 0x6c, 0x29,
-	0xcb, 0xcb, 0x2e, 0x63, 0x72, 0xaa, 0xf5, 0x7c, 0x57, 0xdc, 0xbb, 0x5a, 0x44, 0xe8, 0x42, 0x09,
-	0xbf, 0xc9, 0x70, 0xf5, 0x5b, 0x98, 0xdb, 0xfd, 0x29, 0x2d, 0x1d, 0xbc, 0x2d, 0xd6, 0xd3, 0xf1,
-	0x73, 0xd4, 0x88, 0xf5, 0x38, 0x1b, 0x89, 0xf9, 0x54, 0x7e, 0x3a, 0x39, 0x95, 0x86, 0x00, 0xb6,
-	0xc4, 0xf6, 0x4a, 0x6b, 0xf8, 0xbb, 0x29, 0x1d, 0x5d, 0xbd, 0x8e, 0xe6, 0xcd, 0x4d, 0x2b, 0xd0,
-	0x95, 0xf3, 0xcb, 0x6a, 0xeb, 0xf8, 0x35, 0x5a, 0x03, 0x6d, 0x6a, 0xb3, 0x91, 0xe5, 0x9a, 0xad,
-	0x43, 0xb3, 0xa5, 0x2c, 0xb0, 0x2e, 0x67, 0xd9, 0x97, 0x3d, 0x89, 0x93, 0xd7, 0x85, 0xa0, 0x6c,
-	0x6b, 0x1d, 0xb6, 0x48, 0xdd, 0xce, 0x56, 0x67, 0xe4, 0x74, 0xb3, 0xa3, 0x5c, 0xf1, 0x16, 0x72,
-	0x7a, 0x27, 0x27, 0xa7, 0x77, 0xf0, 0x13, 0x54, 0x1d, 0x07, 0xcc, 0xa6, 0x20, 0x64, 0x29, 0x9b,
-	0x9c, 0x52, 0x4a, 0x7c, 0xf2, 0x58, 0x20, 0x5b, 0x7a, 0xc7, 0x22, 0x95, 0x71, 0x5c, 0xcc, 0x2b,
-	0x0c, 0x94, 0x51, 0x7e, 0x48, 0x61, 0x90, 0x2a, 0x0c, 0x0a, 0x3e, 0xa9, 0xad, 0xfc, 0xf2, 0x36,
-	0x9f, 0xd4, 0xce, 0x7f, 0x52, 0x7b, 0x5a, 0x4e, 0x3f, 0x34, 0x8f, 0x94, 0x89, 0x7e, 0x58, 0x4e,
-	0x3f, 0x3c, 0xca, 0xca, 0xe9, 0x87, 0x47, 0xf8, 0x18, 0xad, 0xe7, 0xe4, 0x3a, 0x66, 0x4b, 0x57,
-	0x0e, 0x3b, 0xa5, 0x97, 0x98, 0x53, 0x46, 0xaf, 0xd3, 0xd2, 0x49, 0xc3, 0xce, 0xd5, 0xf1, 0x63,
-	0x54, 0x11, 0x8a, 0xf2, 0x44, 0xc1, 0xb3, 0x67, 0x72, 0xe6, 0xa1, 0xef, 0x1a, 0xe2, 0x38, 0x11,
-	0x7b, 0x4f, 0x14, 0xf0, 0x17, 0x29, 0x53, 0x07, 0xd7, 0x2e, 0x25, 0x9b, 0xd4, 0x10, 0xa7, 0x88,
-	0x02, 0xea, 0xf8, 0x0f, 0x29, 0xb0, 0xad, 0x9c, 0xf9, 0x27, 0xae, 0xb4, 0x92, 0xda, 0x8e, 0xa9,
-	0xed, 0xec, 0x18, 0x1d, 0xf0, 0xe0, 0x7a, 0x76, 0x8c, 0x4e, 0x0c, 0xec, 0x88, 0xf7, 0x94, 0x00,
-	0xba, 0xbe, 0x37, 0xe4, 0xf4, 0x52, 0x7c, 0xcb, 0xe6, 0x7c, 0x77, 0x73, 0x5f, 0x29, 0xa8, 0xdc,
-	0x7e, 0x49, 0x0d, 0x1f, 0x4d, 0x29, 0xe9, 0xe0, 0xb1, 0x95, 0xee, 0xda, 0x14, 0x4f, 0xcf, 0xf1,
-	0x44, 0x20, 0x81, 0x37, 0xa2, 0x0e, 0x8b, 0x46, 0x70, 0x70, 0x69, 0x70, 0x70, 0xad, 0xab, 0x45,
-	0x7d, 0x9d, 0x74, 0xc0, 0xa2, 0xa6, 0x55, 0xf1, 0xb6, 0x4c, 0x99, 0x6a, 0xf6, 0x5b, 0xf3, 0xde,
-	0x96, 0x89, 0x9a, 0x9c, 0x7f, 0xc3, 0xce, 0xd5, 0xf1, 0x37, 0x33, 0x7a, 0x3a, 0xb8, 0x6d, 0xa5,
-	0x8b, 0x67, 0xd8, 0xfa, 0x14, 0x5b, 0xc7, 0x2f, 0xe4, 0x77, 0x84, 0x23, 0x4b, 0xdc, 0x5e, 0xc4,
-	0x5c, 0x3e, 0x9d, 0xcd, 0x8a, 0x6c, 0x20, 0x4f, 0x14, 0x52, 0x06, 0x32, 0xa9, 0xc5, 0x81, 0x4c,
-	0x84, 0x74, 0xb0, 0xd9, 0x52, 0x12, 0xc8, 0x18, 0xa9, 0xe7, 0x78, 0x7a, 0xbc, 0x94, 0x09, 0xaf,
-	0xad, 0xfd, 0x5f, 0x41, 0xce, 0x14, 0xcd, 0xa0, 0x9d, 0x53, 0x6a, 0xcf, 0xcc, 0xa0, 0xa3, 0x6d,
-	0x43, 0x0a, 0x4d, 0xcf, 0xa0, 0x93, 0xe3, 0x75, 0x04, 0xef, 0xc2, 0x0a, 0x53, 0xde, 0x23, 0xad,
-	0x09, 0x2f, 0x15, 0xe0, 0xbd, 0xb4, 0xc2, 0x18, 0xf9, 0x88, 0xd4, 0x2e, 0x32, 0x35, 0xdc, 0x43,
-	0x75, 0x96, 0xa3, 0xed, 0xcc, 0xfc, 0xce, 0x90, 0xbe, 0xff, 0x32, 0x2a, 0x55, 0x96, 0x11, 0x79,
-	0x2c, 0xad, 0x4f, 0x2c, 0x86, 0xf6, 0xff, 0xf3, 0x7f, 0x79, 0x73, 0x4f, 0xe9, 0x25, 0x87, 0x3d,
-	0x20, 0x0a, 0xf8, 0xaf, 0x68, 0x15, 0x98, 0xb1, 0x2b, 0x98, 0x2d, 0x6d, 0xf7, 0x83, 0xce, 0xb1,
-	0x95, 0x39, 0x77, 0x92, 0xd6, 0x16, 0x58, 0x87, 0x48, 0xd3, 0xb4, 0x09, 0x1b, 0xd3, 0xf2, 0xba,
-	0xf6, 0xab, 0x19, 0x83, 0xdb, 0x28, 0x92, 0xd3, 0xf3, 0x52, 0x3a, 0x6e, 0xa3, 0xba, 0x94, 0xf2,
-	0xae, 0x64, 0x8a, 0xfd, 0x1a, 0x32, 0x63, 0x55, 0x04, 0x06, 0x88, 0xd0, 0xde, 0x22, 0x55, 0x3b,
-	0xad, 0xc4, 0xa4, 0x89, 0x15, 0xd8, 0x17, 0x56, 0x60, 0xb6, 0xb4, 0xdf, 0x40, 0x4e, 0xc7, 0xa4,
-	0x33, 0xd9, 0x2e, 0x49, 0x71, 0x05, 0xff, 0x45, 0x4e, 0x3a, 0x21, 0x1d, 0x1e, 0x6a, 0x9f, 0x17,
-	0x04, 0x35, 0x3b, 0xed, 0x98, 0x7b, 0x78, 0x08, 0xd3, 0x4e, 0xab, 0x71, 0x3e, 0x25, 0x62, 0x47,
-	0xda, 0x17, 0xb9, 0xa3, 0x21, 0x86, 0x1e, 0x41, 0x3e, 0x25, 0x35, 0xfc, 0x8d, 0xbc, 0x69, 0xa8,
-	0x69, 0xef, 0xcd, 0x7b, 0x7d, 0xf5, 0x7c, 0xb7, 0x07, 0x5f, 0x21, 0x92, 0x00, 0x4a, 0xf8, 0xb7,
-	0x19, 0xb6, 0xae, 0x3d, 0x4c, 0x7f, 0xca, 0x54, 0x58, 0x3d, 0xc1, 0xea, 0xbb, 0x5d, 0xb4, 0x91,
-	0xb9, 0x49, 0x66, 0x6e, 0xa4, 0x0f, 0x73, 0x37, 0xd2, 0x8d, 0xf4, 0x46, 0x9a, 0x81, 0xcb, 0xeb,
-	0x68, 0x77, 0xf7, 0xfd, 0x7f, 0xb7, 0xef, 0xbc, 0xbf, 0xd9, 0x5e, 0xf8, 0xd7, 0xcd, 0xf6, 0xc2,
-	0x7f, 0x6e, 0xb6, 0x17, 0xbe, 0xff, 0x71, 0xfb, 0xce, 0x0f, 0x3f, 0x6e, 0xdf, 0xf9, 0x2e, 0xf9,
-	0x9f, 0xd2, 0x60, 0x19, 0x32, 0xa9, 0xfd, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x0a, 0x12, 0x4e,
-	0x85, 0x81, 0x1a, 0x00, 0x00,
-}
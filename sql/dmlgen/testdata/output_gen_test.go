@@ -5,11 +5,14 @@ package testdata
 import (
 	"context"
 	"fmt"
+	"github.com/corestoreio/pkg/sql/ddl"
 	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/storage/null"
 	"github.com/corestoreio/pkg/util/assert"
 	"github.com/corestoreio/pkg/util/pseudo"
 	"sort"
 	"testing"
+	"time"
 )
 
 func TestNewTables(t *testing.T) {
@@ -18,10 +21,11 @@ func TestNewTables(t *testing.T) {
 
 	defer dmltest.SQLDumpLoad(t, "test_*_tables.sql", &dmltest.SQLDumpOptions{
 		SkipDBCleanup: true,
-	})()
+	}).Deferred()
 
-	ctx := context.TODO()
-	tbls, err := NewTables(ctx, db.DB)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*2)
+	defer cancel()
+	tbls, err := NewTables(ctx, ddl.WithConnPool(db))
 	assert.NoError(t, err)
 
 	tblNames := tbls.Tables()
@@ -77,7 +81,7 @@ func TestNewTables(t *testing.T) {
 		ccd := tbls.MustTable(TableNameCoreConfigData)
 
 		inStmt, err := ccd.Insert().BuildValues().Prepare(ctx) // Do not use Ignore() to suppress DB errors.
-		assert.NoError(t, err, "%+v", err)
+		assert.NoError(t, err)
 		insArtisan := inStmt.WithArgs()
 		defer dmltest.Close(t, inStmt)
 
@@ -95,7 +99,7 @@ func TestNewTables(t *testing.T) {
 
 			entityOut := new(CoreConfigData)
 			rowCount, err := selArtisan.Int64s(lID).Load(ctx, entityOut)
-			assert.NoError(t, err, "%+v", err)
+			assert.NoError(t, err)
 			assert.Exactly(t, uint64(1), rowCount, "IDX%d: RowCount did not match", i)
 			assert.Exactly(t, entityIn.ConfigID, entityOut.ConfigID, "IDX%d: ConfigID should match", lID)
 			assert.ExactlyLength(t, 8, &entityIn.Scope, &entityOut.Scope, "IDX%d: Scope should match", lID)
@@ -110,7 +114,7 @@ func TestNewTables(t *testing.T) {
 		ccd := tbls.MustTable(TableNameCustomerAddressEntity)
 
 		inStmt, err := ccd.Insert().BuildValues().Prepare(ctx) // Do not use Ignore() to suppress DB errors.
-		assert.NoError(t, err, "%+v", err)
+		assert.NoError(t, err)
 		insArtisan := inStmt.WithArgs()
 		defer dmltest.Close(t, inStmt)
 
@@ -128,7 +132,7 @@ func TestNewTables(t *testing.T) {
 
 			entityOut := new(CustomerAddressEntity)
 			rowCount, err := selArtisan.Int64s(lID).Load(ctx, entityOut)
-			assert.NoError(t, err, "%+v", err)
+			assert.NoError(t, err)
 			assert.Exactly(t, uint64(1), rowCount, "IDX%d: RowCount did not match", i)
 			assert.Exactly(t, entityIn.EntityID, entityOut.EntityID, "IDX%d: EntityID should match", lID)
 			assert.ExactlyLength(t, 50, &entityIn.IncrementID, &entityOut.IncrementID, "IDX%d: IncrementID should match", lID)
@@ -155,13 +159,14 @@ func TestNewTables(t *testing.T) {
 			assert.ExactlyLength(t, 255, &entityIn.VatRequestDate, &entityOut.VatRequestDate, "IDX%d: VatRequestDate should match", lID)
 			assert.ExactlyLength(t, 255, &entityIn.VatRequestID, &entityOut.VatRequestID, "IDX%d: VatRequestID should match", lID)
 			assert.Exactly(t, entityIn.VatRequestSuccess, entityOut.VatRequestSuccess, "IDX%d: VatRequestSuccess should match", lID)
+			assert.NoError(t, entityOut.LoadCustomerEntity(ctx, tbls), "IDX%d: LoadCustomerEntity", lID)
 		}
 	})
 	t.Run("CustomerEntity_Entity", func(t *testing.T) {
 		ccd := tbls.MustTable(TableNameCustomerEntity)
 
 		inStmt, err := ccd.Insert().BuildValues().Prepare(ctx) // Do not use Ignore() to suppress DB errors.
-		assert.NoError(t, err, "%+v", err)
+		assert.NoError(t, err)
 		insArtisan := inStmt.WithArgs()
 		defer dmltest.Close(t, inStmt)
 
@@ -179,7 +184,7 @@ func TestNewTables(t *testing.T) {
 
 			entityOut := new(CustomerEntity)
 			rowCount, err := selArtisan.Int64s(lID).Load(ctx, entityOut)
-			assert.NoError(t, err, "%+v", err)
+			assert.NoError(t, err)
 			assert.Exactly(t, uint64(1), rowCount, "IDX%d: RowCount did not match", i)
 			assert.Exactly(t, entityIn.EntityID, entityOut.EntityID, "IDX%d: EntityID should match", lID)
 			assert.Exactly(t, entityIn.WebsiteID, entityOut.WebsiteID, "IDX%d: WebsiteID should match", lID)
@@ -209,13 +214,24 @@ func TestNewTables(t *testing.T) {
 			assert.Exactly(t, entityIn.FailuresNum, entityOut.FailuresNum, "IDX%d: FailuresNum should match", lID)
 			assert.Exactly(t, entityIn.FirstFailure, entityOut.FirstFailure, "IDX%d: FirstFailure should match", lID)
 			assert.Exactly(t, entityIn.LockExpires, entityOut.LockExpires, "IDX%d: LockExpires should match", lID)
+
+			assert.True(t, entityIn.IsDirty(), "IDX%d: Entity not loaded from the database should be dirty", lID)
+			assert.False(t, entityOut.IsDirty(), "IDX%d: Entity loaded from the database should not be dirty", lID)
+			assert.Len(t, entityOut.ChangedFields(), 0, "IDX%d: Entity loaded from the database should not have changed fields", lID)
+			entityOrg := entityOut.Original()
+			assert.NotNil(t, entityOrg, "IDX%d: Original data should be available", lID)
+			assert.Nil(t, entityOrg.Original(), "IDX%d: Copy of the original data should not have an original", lID)
+			assert.Exactly(t, []string{"entity_id", "website_id", "email", "group_id", "increment_id", "store_id", "created_at", "updated_at", "is_active", "disable_auto_group_change", "created_in", "prefix", "firstname", "middlename", "lastname", "suffix", "dob", "password_hash", "rp_token", "rp_token_created_at", "default_billing", "default_shipping", "taxvat", "confirmation", "gender", "failures_num", "first_failure", "lock_expires"}, entityOrg.ChangedFields(), "IDX%d: Copy of the original data should report all fields", lID)
+			assert.False(t, entityOrg.ResetChanges().IsDirty(), "IDX%d: Entity should not be dirty after ResetChanges", lID)
+			assert.NoError(t, entityOut.LoadCustomerAddressEntityCollection(ctx, tbls), "IDX%d: LoadCustomerAddressEntityCollection", lID)
+			assert.NotNil(t, entityOut.CustomerAddressEntityCollection, "IDX%d: CustomerAddressEntityCollection should be initialized", lID)
 		}
 	})
 	t.Run("DmlgenTypes_Entity", func(t *testing.T) {
 		ccd := tbls.MustTable(TableNameDmlgenTypes)
 
 		inStmt, err := ccd.Insert().BuildValues().Prepare(ctx) // Do not use Ignore() to suppress DB errors.
-		assert.NoError(t, err, "%+v", err)
+		assert.NoError(t, err)
 		insArtisan := inStmt.WithArgs()
 		defer dmltest.Close(t, inStmt)
 
@@ -227,13 +243,16 @@ func TestNewTables(t *testing.T) {
 				t.Errorf("IDX[%d]: %+v", i, err)
 				return
 			}
+			entityIn.ColEnum = dmlgenTypesColEnumValues[ps.Intn(len(dmlgenTypesColEnumValues))]
+			entityIn.ColSet = DmlgenTypesColSet(ps.Intn(1 << 3))
+			entityIn.ColPoint = null.MakePoint(0, float64(ps.Intn(180)), float64(ps.Intn(90)))
 
 			lID := dmltest.CheckLastInsertID(t, "Error: TestNewTables.DmlgenTypes_Entity")(insArtisan.Record("", entityIn).ExecContext(ctx))
 			insArtisan.Reset()
 
 			entityOut := new(DmlgenTypes)
 			rowCount, err := selArtisan.Int64s(lID).Load(ctx, entityOut)
-			assert.NoError(t, err, "%+v", err)
+			assert.NoError(t, err)
 			assert.Exactly(t, uint64(1), rowCount, "IDX%d: RowCount did not match", i)
 			assert.Exactly(t, entityIn.ID, entityOut.ID, "IDX%d: ID should match", lID)
 			assert.Exactly(t, entityIn.ColBigint1, entityOut.ColBigint1, "IDX%d: ColBigint1 should match", lID)
@@ -276,6 +295,10 @@ func TestNewTables(t *testing.T) {
 			assert.ExactlyLength(t, 16, &entityIn.ColVarchar16, &entityOut.ColVarchar16, "IDX%d: ColVarchar16 should match", lID)
 			assert.ExactlyLength(t, 21, &entityIn.ColChar1, &entityOut.ColChar1, "IDX%d: ColChar1 should match", lID)
 			assert.ExactlyLength(t, 17, &entityIn.ColChar2, &entityOut.ColChar2, "IDX%d: ColChar2 should match", lID)
+			assert.ExactlyLength(t, 7, &entityIn.ColEnum, &entityOut.ColEnum, "IDX%d: ColEnum should match", lID)
+			assert.ExactlyLength(t, 15, &entityIn.ColSet, &entityOut.ColSet, "IDX%d: ColSet should match", lID)
+			assert.ExactlyLength(t, 4294967295, &entityIn.ColJSON, &entityOut.ColJSON, "IDX%d: ColJSON should match", lID)
+			assert.Exactly(t, entityIn.ColPoint, entityOut.ColPoint, "IDX%d: ColPoint should match", lID)
 		}
 	})
 }
@@ -51,6 +51,10 @@ CREATE TABLE `dmlgen_types` (
   col_varchar_16            VARCHAR(16)          NOT NULL           DEFAULT 'de_DE',
   col_char_1                char(21)                                DEFAULT NULL,
   col_char_2                char(17)             NOT NULL DEFAULT 'xchar',
+  col_enum                  ENUM('small','medium','x-large') NULL,
+  col_set                   SET('new','sale','outlet') NOT NULL DEFAULT '',
+  col_json                  JSON                                    DEFAULT NULL,
+  col_point                 POINT                                   DEFAULT NULL,
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
@@ -28,7 +28,7 @@ func easyjsonA96ca39cDecodeGithubComCorestoreioPkgSqlDmlgenTestdata(in *jlexer.L
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -83,12 +83,8 @@ func easyjsonA96ca39cEncodeGithubComCorestoreioPkgSqlDmlgenTestdata(out *jwriter
 	_ = first
 	if len(in.Data) != 0 {
 		const prefix string = ",\"data\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		first = false
+		out.RawString(prefix[1:])
 		{
 			out.RawByte('[')
 			for v2, v3 := range in.Data {
@@ -141,7 +137,7 @@ func easyjsonA96ca39cDecodeGithubComCorestoreioPkgSqlDmlgenTestdata1(in *jlexer.
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -291,6 +287,22 @@ func easyjsonA96ca39cDecodeGithubComCorestoreioPkgSqlDmlgenTestdata1(in *jlexer.
 			}
 		case "col_char_2":
 			out.ColChar2 = string(in.String())
+		case "col_enum":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ColEnum).UnmarshalText(data))
+			}
+		case "col_set":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ColSet).UnmarshalText(data))
+			}
+		case "col_json":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ColJSON).UnmarshalJSON(data))
+			}
+		case "col_point":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ColPoint).UnmarshalJSON(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -307,12 +319,8 @@ func easyjsonA96ca39cEncodeGithubComCorestoreioPkgSqlDmlgenTestdata1(out *jwrite
 	_ = first
 	if in.ID != 0 {
 		const prefix string = ",\"id\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		first = false
+		out.RawString(prefix[1:])
 		out.Int32(int32(in.ID))
 	}
 	if true {
@@ -715,6 +723,46 @@ func easyjsonA96ca39cEncodeGithubComCorestoreioPkgSqlDmlgenTestdata1(out *jwrite
 		}
 		out.String(string(in.ColChar2))
 	}
+	if in.ColEnum != "" {
+		const prefix string = ",\"col_enum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.RawText((in.ColEnum).MarshalText())
+	}
+	if in.ColSet != 0 {
+		const prefix string = ",\"col_set\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.RawText((in.ColSet).MarshalText())
+	}
+	if true {
+		const prefix string = ",\"col_json\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.ColJSON).MarshalJSON())
+	}
+	if true {
+		const prefix string = ",\"col_point\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.ColPoint).MarshalJSON())
+	}
 	out.RawByte('}')
 }
 
@@ -752,7 +800,7 @@ func easyjsonA96ca39cDecodeGithubComCorestoreioPkgSqlDmlgenTestdata2(in *jlexer.
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -807,12 +855,8 @@ func easyjsonA96ca39cEncodeGithubComCorestoreioPkgSqlDmlgenTestdata2(out *jwrite
 	_ = first
 	if len(in.Data) != 0 {
 		const prefix string = ",\"data\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		first = false
+		out.RawString(prefix[1:])
 		{
 			out.RawByte('[')
 			for v11, v12 := range in.Data {
@@ -865,7 +909,7 @@ func easyjsonA96ca39cDecodeGithubComCorestoreioPkgSqlDmlgenTestdata3(in *jlexer.
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -913,12 +957,8 @@ func easyjsonA96ca39cEncodeGithubComCorestoreioPkgSqlDmlgenTestdata3(out *jwrite
 	_ = first
 	if in.ConfigID != 0 {
 		const prefix string = ",\"config_id\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		first = false
+		out.RawString(prefix[1:])
 		out.Uint32(uint32(in.ConfigID))
 	}
 	if in.Scope != "" {
@@ -333,6 +333,66 @@ var typeMap = map[string]map[string]*TypeDef{ // immutable
 			SerializerNull:     "[ubyte]",
 			SerializerNotNull:  "[ubyte]"},
 	},
+	// json uses a raw message because the structure of the document is
+	// unknown. A custom Go type can be set with TableConfig.JSONTypes.
+	"json": {
+		"default": &TypeDef{
+			GoUNull:    "json.RawMessage",
+			GoUNotNull: "json.RawMessage",
+			GoNull:     "json.RawMessage",
+			GoNotNull:  "json.RawMessage",
+		},
+		"protobuf": {
+			GoUNull:            "json.RawMessage",
+			GoUNotNull:         "json.RawMessage",
+			GoNull:             "json.RawMessage",
+			GoNotNull:          "json.RawMessage",
+			SerializerUNull:    "bytes",
+			SerializerUNotNull: "bytes",
+			SerializerNull:     "bytes",
+			SerializerNotNull:  "bytes",
+		},
+		"fbs": {
+			GoUNull:            "json.RawMessage",
+			GoUNotNull:         "json.RawMessage",
+			GoNull:             "json.RawMessage",
+			GoNotNull:          "json.RawMessage",
+			SerializerUNull:    "[ubyte]",
+			SerializerUNotNull: "[ubyte]",
+			SerializerNull:     "[ubyte]",
+			SerializerNotNull:  "[ubyte]",
+		},
+	},
+	// geometry covers all spatial types and uses the MySQL internal format:
+	// SRID and WKB.
+	"geometry": {
+		"default": &TypeDef{
+			GoUNull:    "null.Geometry",
+			GoUNotNull: "null.Geometry",
+			GoNull:     "null.Geometry",
+			GoNotNull:  "null.Geometry",
+		},
+		"protobuf": {
+			GoUNull:            "null.Geometry",
+			GoUNotNull:         "null.Geometry",
+			GoNull:             "null.Geometry",
+			GoNotNull:          "null.Geometry",
+			SerializerUNull:    "null.Geometry",
+			SerializerUNotNull: "null.Geometry",
+			SerializerNull:     "null.Geometry",
+			SerializerNotNull:  "null.Geometry",
+		},
+		"fbs": {
+			GoUNull:            "null.Geometry",
+			GoUNotNull:         "null.Geometry",
+			GoNull:             "null.Geometry",
+			GoNotNull:          "null.Geometry",
+			SerializerUNull:    "null.Geometry",
+			SerializerUNotNull: "null.Geometry",
+			SerializerNull:     "null.Geometry",
+			SerializerNotNull:  "null.Geometry",
+		},
+	},
 }
 
 func mustTMK(key string) map[string]*TypeDef {
@@ -373,7 +433,17 @@ var mysqlTypeToGo = map[string]map[string]*TypeDef{ // immutable
 	"binary":     mustTMK("byte"),
 	"varbinary":  mustTMK("byte"),
 	"bit":        mustTMK("bool"),
-	// TODO add more MySQL types like JSON or GEO
+	"json":       mustTMK("json"),
+	// spatial types
+	"geometry":           mustTMK("geometry"),
+	"point":              mustTMK("geometry"),
+	"linestring":         mustTMK("geometry"),
+	"polygon":            mustTMK("geometry"),
+	"multipoint":         mustTMK("geometry"),
+	"multilinestring":    mustTMK("geometry"),
+	"multipolygon":       mustTMK("geometry"),
+	"geometrycollection": mustTMK("geometry"),
+	"geomcollection":     mustTMK("geometry"),
 }
 
 func mustGetTypeDef(mysqlDataType, serializer string) *TypeDef {
@@ -409,8 +479,12 @@ func (ts *Tables) findType(c *ddl.Column) *TypeDef {
 
 // mySQLToGoType calculates the data type of the field DataType. For example
 // bigint, smallint, tinyint will result in "int". If withNull is true the
-// returned type can store a null value.
+// returned type can store a null value. ENUM, SET and configured JSON columns
+// return their custom Go type.
 func (ts *Tables) mySQLToGoType(c *ddl.Column, withNull bool) string {
+	if ct, ok := ts.customTypes[c]; ok {
+		return ct.GoType
+	}
 
 	goType := ts.findType(c)
 
@@ -442,12 +516,79 @@ func (ts *Tables) toGoPrimitiveFromNull(c *ddl.Column) string {
 	return t
 }
 
+// toGoNotEqual returns a Go expression which reports whether the values `a`
+// and `b` of column c differ. Byte slices, times, geometries and JSON types
+// need a dedicated comparison.
+func (ts *Tables) toGoNotEqual(c *ddl.Column, a, b string) string {
+	if ct, ok := ts.customTypes[c]; ok && ct.Kind == "json" {
+		return fmt.Sprintf("!reflect.DeepEqual(%s, %s)", a, b)
+	}
+	switch ts.mySQLToGoType(c, true) {
+	case "[]byte", "json.RawMessage":
+		return fmt.Sprintf("!bytes.Equal(%s, %s)", a, b)
+	case "time.Time":
+		return fmt.Sprintf("!%s.Equal(%s)", a, b)
+	case "null.Time":
+		return fmt.Sprintf("%s.Valid != %s.Valid || !%s.Time.Equal(%s.Time)", a, b, a, b)
+	case "null.Geometry":
+		return fmt.Sprintf("%s.Valid != %s.Valid || %s.SRID != %s.SRID || !bytes.Equal(%s.WKB, %s.WKB)", a, b, a, b, a, b)
+	}
+	return fmt.Sprintf("%s != %s", a, b)
+}
+
+// toRelationKey returns a Go expression which converts the value of column c,
+// accessed via expr, into a map key of type int64, uint64 or string. Return
+// argument valid contains for nullable types the expression which reports
+// whether the value is not NULL. An empty keyType indicates an unsupported
+// column type.
+func (ts *Tables) toRelationKey(c *ddl.Column, expr string) (key, valid, keyType string) {
+	if ct, ok := ts.customTypes[c]; ok {
+		if ct.Kind == "enum" {
+			return "string(" + expr + ")", "", "string"
+		}
+		return "", "", ""
+	}
+	switch gt := ts.mySQLToGoType(c, true); gt {
+	case "string":
+		return expr, "", "string"
+	case "int64":
+		return expr, "", "int64"
+	case "uint64":
+		return expr, "", "uint64"
+	case "int", "int8", "int16", "int32":
+		return "int64(" + expr + ")", "", "int64"
+	case "uint", "uint8", "uint16", "uint32":
+		return "uint64(" + expr + ")", "", "uint64"
+	case "null.String":
+		return expr + ".String", expr + ".Valid", "string"
+	case "null.Int64":
+		return expr + ".Int64", expr + ".Valid", "int64"
+	case "null.Uint64":
+		return expr + ".Uint64", expr + ".Valid", "uint64"
+	case "null.Int8", "null.Int16", "null.Int32":
+		return "int64(" + expr + "." + gt[5:] + ")", expr + ".Valid", "int64" // 5 == len("null.")
+	case "null.Uint8", "null.Uint16", "null.Uint32":
+		return "uint64(" + expr + "." + gt[5:] + ")", expr + ".Valid", "uint64"
+	}
+	return "", "", ""
+}
+
 func (ts *Tables) mySQLToGoDmlColumnMap(c *ddl.Column, withNull bool) string {
+	if ct, ok := ts.customTypes[c]; ok {
+		if ct.Kind == "json" {
+			return "JSON"
+		}
+		return "Text" // enum and set implement encoding.TextMarshaler
+	}
 
 	gt := ts.mySQLToGoType(c, withNull)
 	switch gt {
 	case "[]byte":
 		return "Byte"
+	case "json.RawMessage":
+		return "JSON"
+	case "null.Geometry":
+		return "Binary"
 	}
 
 	if dot := strings.IndexByte(gt, '.'); dot > 0 {
@@ -464,6 +605,21 @@ func (ts *Tables) mySQLToGoDmlColumnMap(c *ddl.Column, withNull bool) string {
 }
 
 func (ts *Tables) toSerializerType(c *ddl.Column, withNull bool) string {
+	if ct, ok := ts.customTypes[c]; ok {
+		isFBS := ts.Serializer == "fbs"
+		switch {
+		case ct.Kind == "enum":
+			return "string"
+		case ct.Kind == "set" && isFBS:
+			return "ulong"
+		case ct.Kind == "set":
+			return "uint64"
+		case isFBS:
+			return "[ubyte]"
+		default:
+			return "bytes"
+		}
+	}
 
 	goType := ts.findType(c)
 
@@ -481,3 +637,111 @@ func (ts *Tables) toSerializerType(c *ddl.Column, withNull bool) string {
 
 	return t
 }
+
+// customType describes a Go type which gets generated for ENUM and SET columns
+// or which has been provided via TableConfig.JSONTypes for JSON columns.
+type customType struct {
+	Kind   string   // enum, set or json
+	GoType string   // Name of the Go type
+	Values []string // Allowed values of an ENUM or SET column
+	Consts []string // Go constant names, same order as Values
+}
+
+// newCustomType parses the allowed values of an ENUM or SET column and creates
+// the Go constant names. The Go type name gets prefixed with the table name to
+// avoid collisions between tables.
+func newCustomType(tableName string, c *ddl.Column) (*customType, error) {
+	kind := strings.ToLower(c.DataType)
+	values, err := parseEnumValues(c.ColumnType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[dmlgen] Table %q Column %q", tableName, c.Field)
+	}
+	if kind == "set" && len(values) > 64 {
+		return nil, errors.NotSupported.Newf("[dmlgen] Table %q Column %q: SET supports max 64 members, got %d", tableName, c.Field, len(values))
+	}
+	ct := &customType{
+		Kind:   kind,
+		GoType: strs.ToGoCamelCase(tableName) + strs.ToGoCamelCase(c.Field),
+		Values: values,
+		Consts: make([]string, len(values)),
+	}
+	seen := make(map[string]bool, len(values))
+	for i, v := range values {
+		name := strs.ToGoCamelCase(v)
+		if r, _ := utf8.DecodeRuneInString(name); name == "" || !unicode.IsLetter(r) {
+			name = fmt.Sprintf("Value%d", i)
+		}
+		name = ct.GoType + name
+		if seen[name] {
+			name = fmt.Sprintf("%s%d", name, i)
+		}
+		seen[name] = true
+		ct.Consts[i] = name
+	}
+	return ct, nil
+}
+
+// ValuesVar returns the name of the private variable containing all allowed
+// values.
+func (ct *customType) ValuesVar() string {
+	r, n := utf8.DecodeRuneInString(ct.GoType)
+	return string(unicode.ToLower(r)) + ct.GoType[n:] + "Values"
+}
+
+// MaskConst returns the name of the private constant of a SET type which has
+// all bits set.
+func (ct *customType) MaskConst() string {
+	r, n := utf8.DecodeRuneInString(ct.GoType)
+	return string(unicode.ToLower(r)) + ct.GoType[n:] + "Mask"
+}
+
+// TestIntnMax returns the argument for pseudo.Service.Intn to create a random
+// SET value in the generated tests.
+func (ct *customType) TestIntnMax() string {
+	if len(ct.Values) > 30 {
+		return "1 << 30"
+	}
+	return fmt.Sprintf("1 << %d", len(ct.Values))
+}
+
+// parseEnumValues extracts the values of a column type definition like
+// enum('a','b') or set('a','b'). Quotes within a value can be escaped by
+// doubling them or with a backslash.
+func parseEnumValues(columnType string) ([]string, error) {
+	start := strings.IndexByte(columnType, '(')
+	end := strings.LastIndexByte(columnType, ')')
+	if start < 0 || end < start {
+		return nil, errors.NotValid.Newf("[dmlgen] Invalid ENUM or SET column type: %q", columnType)
+	}
+	def := columnType[start+1 : end]
+
+	var values []string
+	var buf strings.Builder
+	inQuote := false
+	for i := 0; i < len(def); i++ {
+		switch ch := def[i]; {
+		case !inQuote && ch == '\'':
+			inQuote = true
+		case !inQuote && (ch == ',' || ch == ' '):
+			// separator
+		case !inQuote:
+			return nil, errors.NotValid.Newf("[dmlgen] Invalid character %q at position %d in ENUM or SET column type: %q", ch, i, columnType)
+		case ch == '\\' && i+1 < len(def):
+			i++
+			buf.WriteByte(def[i])
+		case ch == '\'' && i+1 < len(def) && def[i+1] == '\'':
+			i++
+			buf.WriteByte('\'')
+		case ch == '\'':
+			inQuote = false
+			values = append(values, buf.String())
+			buf.Reset()
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	if inQuote {
+		return nil, errors.NotValid.Newf("[dmlgen] Unterminated value in ENUM or SET column type: %q", columnType)
+	}
+	return values, nil
+}
@@ -81,6 +81,10 @@ func TestToGoTypeNull(t *testing.T) {
 		{&ddl.Column{Field: `description_002`, DataType: `varchar`, Null: "NO"}, "string"},
 		{&ddl.Column{Field: `description_003`, DataType: `char`, Null: "YES"}, "null.String"},
 		{&ddl.Column{Field: `description_004`, DataType: `char`, Null: "NO"}, "string"},
+		{&ddl.Column{Field: `attributes_001`, DataType: `json`, Null: "YES"}, "json.RawMessage"},
+		{&ddl.Column{Field: `attributes_002`, DataType: `json`, Null: "NO"}, "json.RawMessage"},
+		{&ddl.Column{Field: `location_001`, DataType: `point`, Null: "YES"}, "null.Geometry"},
+		{&ddl.Column{Field: `location_002`, DataType: `multipolygon`, Null: "NO"}, "null.Geometry"},
 	}
 	ts := new(Tables)
 	for _, test := range tests {
@@ -150,6 +154,9 @@ func TestMySQLToGoDmlColumnMap(t *testing.T) {
 		{&ddl.Column{Field: `description_002`, DataType: `varchar`, Null: "NO"}, "String"},
 		{&ddl.Column{Field: `description_003`, DataType: `char`, Null: "YES"}, "NullString"},
 		{&ddl.Column{Field: `description_004`, DataType: `char`, Null: "NO"}, "String"},
+		{&ddl.Column{Field: `attributes_001`, DataType: `json`, Null: "YES"}, "JSON"},
+		{&ddl.Column{Field: `location_001`, DataType: `point`, Null: "YES"}, "Binary"},
+		{&ddl.Column{Field: `location_002`, DataType: `geometry`, Null: "NO"}, "Binary"},
 	}
 	ts := new(Tables)
 	for i, test := range tests {
@@ -186,3 +193,131 @@ func TestToGoPrimitive(t *testing.T) {
 		assert.Exactly(t, test.want, have, "IDX:%d %#v", i, test.c)
 	}
 }
+
+func TestToGoNotEqual(t *testing.T) {
+	t.Parallel()
+	colJSONType := &ddl.Column{Field: "options", DataType: "json"}
+	tests := []struct {
+		want string
+		c    *ddl.Column
+	}{
+		{"a != b", &ddl.Column{Field: "id", DataType: "int", ColumnType: "int(10) unsigned"}},
+		{"a != b", &ddl.Column{Field: "code", Null: "YES", DataType: "varchar", ColumnType: "varchar(32)"}},
+		{"a != b", &ddl.Column{Field: "price", Null: "YES", DataType: "decimal", ColumnType: "decimal(12,4)"}},
+		{"!bytes.Equal(a, b)", &ddl.Column{Field: "col_blob", Null: "YES", DataType: "blob", ColumnType: "blob"}},
+		{"!bytes.Equal(a, b)", &ddl.Column{Field: "attributes", Null: "YES", DataType: "json", ColumnType: "json"}},
+		{"!a.Equal(b)", &ddl.Column{Field: "created_at", Null: "NO", DataType: "datetime", ColumnType: "datetime"}},
+		{"a.Valid != b.Valid || !a.Time.Equal(b.Time)", &ddl.Column{Field: "updated_at", Null: "YES", DataType: "datetime", ColumnType: "datetime"}},
+		{"a.Valid != b.Valid || a.SRID != b.SRID || !bytes.Equal(a.WKB, b.WKB)", &ddl.Column{Field: "location", Null: "YES", DataType: "point", ColumnType: "point"}},
+		{"!reflect.DeepEqual(a, b)", colJSONType},
+	}
+	ts := &Tables{
+		customTypes: map[*ddl.Column]*customType{
+			colJSONType: {Kind: "json", GoType: "*Options"},
+		},
+	}
+	for i, test := range tests {
+		assert.Exactly(t, test.want, ts.toGoNotEqual(test.c, "a", "b"), "IDX:%d %#v", i, test.c)
+	}
+}
+
+func TestToRelationKey(t *testing.T) {
+	t.Parallel()
+	colEnum := &ddl.Column{Field: "size", DataType: "enum", ColumnType: "enum('s','m')"}
+	colSet := &ddl.Column{Field: "flags", DataType: "set", ColumnType: "set('a','b')"}
+	tests := []struct {
+		c                      *ddl.Column
+		wantKey, wantValid, wt string
+	}{
+		{&ddl.Column{Field: "id", DataType: "bigint", ColumnType: "bigint(20)"}, "e.ID", "", "int64"},
+		{&ddl.Column{Field: "id", DataType: "smallint", ColumnType: "smallint(5)"}, "int64(e.ID)", "", "int64"},
+		{&ddl.Column{Field: "id", DataType: "smallint", ColumnType: "smallint(5) unsigned"}, "uint64(e.ID)", "", "uint64"},
+		{&ddl.Column{Field: "id", DataType: "bigint", ColumnType: "bigint(20) unsigned"}, "e.ID", "", "uint64"},
+		{&ddl.Column{Field: "id", Null: "YES", DataType: "bigint", ColumnType: "bigint(20)"}, "e.ID.Int64", "e.ID.Valid", "int64"},
+		{&ddl.Column{Field: "id", Null: "YES", DataType: "int", ColumnType: "int(10)"}, "int64(e.ID.Int32)", "e.ID.Valid", "int64"},
+		{&ddl.Column{Field: "id", Null: "YES", DataType: "int", ColumnType: "int(10) unsigned"}, "uint64(e.ID.Uint32)", "e.ID.Valid", "uint64"},
+		{&ddl.Column{Field: "id", Null: "YES", DataType: "bigint", ColumnType: "bigint(20) unsigned"}, "e.ID.Uint64", "e.ID.Valid", "uint64"},
+		{&ddl.Column{Field: "id", DataType: "varchar", ColumnType: "varchar(32)"}, "e.ID", "", "string"},
+		{&ddl.Column{Field: "id", Null: "YES", DataType: "varchar", ColumnType: "varchar(32)"}, "e.ID.String", "e.ID.Valid", "string"},
+		{&ddl.Column{Field: "id", DataType: "datetime", ColumnType: "datetime"}, "", "", ""},
+		{colEnum, "string(e.ID)", "", "string"},
+		{colSet, "", "", ""},
+	}
+	ts := &Tables{
+		customTypes: map[*ddl.Column]*customType{
+			colEnum: {Kind: "enum", GoType: "CatalogProductSize"},
+			colSet:  {Kind: "set", GoType: "CatalogProductFlags"},
+		},
+	}
+	for i, test := range tests {
+		key, valid, keyType := ts.toRelationKey(test.c, "e.ID")
+		assert.Exactly(t, test.wantKey, key, "IDX:%d %#v", i, test.c)
+		assert.Exactly(t, test.wantValid, valid, "IDX:%d %#v", i, test.c)
+		assert.Exactly(t, test.wt, keyType, "IDX:%d %#v", i, test.c)
+	}
+}
+
+func TestCustomTypes(t *testing.T) {
+	t.Parallel()
+
+	colEnum := &ddl.Column{Field: "size", DataType: "enum", ColumnType: "enum('small','x-large','','2xl','small ')", Null: "YES"}
+	colSet := &ddl.Column{Field: "flags", DataType: "set", ColumnType: "set('a','b')"}
+	colJSON := &ddl.Column{Field: "attributes", DataType: "json"}
+
+	ctEnum, err := newCustomType("catalog_product", colEnum)
+	assert.NoError(t, err)
+	assert.Exactly(t, "CatalogProductSize", ctEnum.GoType)
+	assert.Exactly(t, []string{"small", "x-large", "", "2xl", "small "}, ctEnum.Values)
+	assert.Exactly(t, []string{"CatalogProductSizeSmall", "CatalogProductSizeXLarge", "CatalogProductSizeValue2", "CatalogProductSizeValue3", "CatalogProductSizeSmall4"}, ctEnum.Consts)
+	assert.Exactly(t, "catalogProductSizeValues", ctEnum.ValuesVar())
+
+	ctSet, err := newCustomType("catalog_product", colSet)
+	assert.NoError(t, err)
+	assert.Exactly(t, "set", ctSet.Kind)
+	assert.Exactly(t, "catalogProductFlagsMask", ctSet.MaskConst())
+	assert.Exactly(t, "1 << 2", ctSet.TestIntnMax())
+
+	ts := &Tables{
+		customTypes: map[*ddl.Column]*customType{
+			colEnum: ctEnum,
+			colSet:  ctSet,
+			colJSON: {Kind: "json", GoType: "*Attributes"},
+		},
+	}
+	assert.Exactly(t, "CatalogProductSize", ts.mySQLToGoType(colEnum, true))
+	assert.Exactly(t, "Text", ts.mySQLToGoDmlColumnMap(colEnum, true))
+	assert.Exactly(t, "string", ts.toSerializerType(colEnum, true))
+	assert.Exactly(t, "Text", ts.mySQLToGoDmlColumnMap(colSet, true))
+	assert.Exactly(t, "uint64", ts.toSerializerType(colSet, true))
+	assert.Exactly(t, "*Attributes", ts.mySQLToGoType(colJSON, true))
+	assert.Exactly(t, "JSON", ts.mySQLToGoDmlColumnMap(colJSON, true))
+	assert.Exactly(t, "bytes", ts.toSerializerType(colJSON, true))
+	ts.Serializer = "fbs"
+	assert.Exactly(t, "ulong", ts.toSerializerType(colSet, true))
+}
+
+func TestParseEnumValues(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		columnType string
+		want       []string
+		wantErr    bool
+	}{
+		{"enum('a','b')", []string{"a", "b"}, false},
+		{"set('a', 'b,c')", []string{"a", "b,c"}, false},
+		{`enum('it''s','back\\slash','quo\'te')`, []string{"it's", `back\slash`, "quo'te"}, false},
+		{"enum('')", []string{""}, false},
+		{"enum", nil, true},
+		{"enum('a)", nil, true},
+		{"enum('a',b)", nil, true},
+	}
+	for _, test := range tests {
+		have, err := parseEnumValues(test.columnType)
+		if test.wantErr {
+			assert.Error(t, err, "%q", test.columnType)
+			continue
+		}
+		assert.NoError(t, err, "%q", test.columnType)
+		assert.Exactly(t, test.want, have, "%q", test.columnType)
+	}
+}
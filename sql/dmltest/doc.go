@@ -13,4 +13,7 @@
 // limitations under the License.
 
 // Package dmltest provides functions for testing the dml package.
+//
+// FakeDB provides an in-memory database for tests which cannot connect to a
+// MySQL server. It gets seeded from CSV files or SQL dump files.
 package dmltest
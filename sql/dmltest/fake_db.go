@@ -0,0 +1,1164 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmltest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+)
+
+// FakeDB is an in-memory database which executes the subset of MySQL emitted
+// by the dml query builders: SELECT on a single table with WHERE, GROUP BY,
+// HAVING, ORDER BY and LIMIT; INSERT with multiple rows, IGNORE and ON
+// DUPLICATE KEY UPDATE; REPLACE, UPDATE, DELETE, CREATE TABLE, DROP TABLE and
+// TRUNCATE. Primary and unique keys and auto increment columns are enforced.
+// Errors are of type *mysql.MySQLError with the error numbers of a real
+// server. JOINs, sub queries and UNIONs return a NotSupported error.
+// Transactions are supported but not isolated: a rollback reverts the rows
+// changed by the transaction and keeps the changes of other connections.
+// CREATE TABLE, DROP TABLE and TRUNCATE commit the transaction implicitly,
+// just like MySQL does.
+//
+// FakeDB implements driver.Connector and driver.Driver. Use it with
+// sql.OpenDB or call MustConnectFakeDB.
+type FakeDB struct {
+	mu     sync.Mutex
+	tables map[string]*fakeTable // key is the lower case table name
+	// lastInsertID gets shared between all connections.
+	lastInsertID int64
+	nowFn        func() time.Time
+	// undo contains the undo log of the transaction of the currently running
+	// statement. Nil if the statement runs outside of a transaction.
+	undo *fakeUndoLog
+}
+
+// NewFakeDB creates a new empty in-memory database.
+func NewFakeDB() *FakeDB {
+	return &FakeDB{
+		tables: make(map[string]*fakeTable),
+		nowFn:  time.Now,
+	}
+}
+
+// MustConnectFakeDB creates a new in-memory FakeDB and a connection pool
+// for it. Fatals on error. Argument t can be nil in Example functions.
+func MustConnectFakeDB(t testing.TB, opts ...dml.ConnPoolOption) (*dml.ConnPool, *FakeDB) {
+	if t != nil {
+		t.Helper()
+	}
+	fdb := NewFakeDB()
+	cfg := []dml.ConnPoolOption{dml.WithDB(sql.OpenDB(fdb))}
+	dbc, err := dml.NewConnPool(append(cfg, opts...)...)
+	FatalIfError(t, err)
+	return dbc, fdb
+}
+
+// Connect implements driver.Connector.
+func (db *FakeDB) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{db: db}, nil
+}
+
+// Driver implements driver.Connector.
+func (db *FakeDB) Driver() driver.Driver { return db }
+
+// Open implements driver.Driver. The name gets ignored.
+func (db *FakeDB) Open(string) (driver.Conn, error) {
+	return &fakeConn{db: db}, nil
+}
+
+// Exec executes one or more statements separated by a semicolon, for example
+// to create the tables of a test.
+func (db *FakeDB) Exec(query string, args ...interface{}) error {
+	_, _, err := db.run(query, args, nil)
+	return err
+}
+
+// LoadCSV loads a CSV file, see function LoadCSV, into a table. The first
+// line of the file contains the column names. If the table does not exist,
+// it gets created with nullable text columns.
+func (db *FakeDB) LoadCSV(table string, opts ...csvOptions) error {
+	cols, rows, err := LoadCSV(opts...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	t, ok := db.tables[strings.ToLower(table)]
+	if !ok {
+		t = &fakeTable{name: table, autoInc: 1}
+		for _, c := range cols {
+			t.columns = append(t.columns, &fakeColumn{name: c, typ: "text"})
+		}
+		db.tables[strings.ToLower(table)] = t
+	}
+
+	ins := &fakeInsert{table: table, columns: cols}
+	for _, row := range rows {
+		exprs := make([]fakeExpr, len(row))
+		for i, v := range row {
+			exprs[i] = &fakeLiteral{v: v}
+		}
+		ins.rows = append(ins.rows, exprs)
+	}
+	_, _, err = ins.exec(db)
+	return errors.WithStack(err)
+}
+
+// SQLDumpLoad executes all files recognized by `globPattern` argument in the
+// FakeDB. It behaves like the function SQLDumpLoad: files whose names contain
+// the string "cleanup" run in the returned Deferred function, unless
+// SQLDumpOptions.SkipDBCleanup has been set. All other fields of
+// SQLDumpOptions get ignored. Argument o can be nil.
+func (db *FakeDB) SQLDumpLoad(t testing.TB, globPattern string, o *SQLDumpOptions) struct{ Deferred func() } {
+	if o == nil {
+		o = &SQLDumpOptions{}
+	}
+
+	matches, err := filepath.Glob(globPattern)
+	FatalIfError(t, err)
+	if len(matches) == 0 {
+		FatalIfError(t, errors.NotFound.Newf("No files found for glob pattern: %q", globPattern))
+	}
+
+	runExec := func(file string) {
+		data, err := ioutil.ReadFile(file)
+		FatalIfError(t, err)
+		if err := db.Exec(string(data)); err != nil {
+			FatalIfError(t, errors.Wrapf(err, "[dmltest] FakeDB.SQLDumpLoad file %q", file))
+		}
+	}
+
+	var cleanUpFiles []string
+	for _, file := range matches {
+		if strings.Contains(file, "cleanup") {
+			cleanUpFiles = append(cleanUpFiles, file)
+		} else {
+			runExec(file)
+		}
+	}
+
+	return struct {
+		Deferred func()
+	}{
+		Deferred: func() {
+			if !o.SkipDBCleanup {
+				for _, file := range cleanUpFiles {
+					runExec(file)
+				}
+			}
+		},
+	}
+}
+
+// run parses and executes the statements. It returns the rows of the last
+// statement which has returned rows. Argument undo records the changes of a
+// transaction and can be nil.
+func (db *FakeDB) run(query string, args []interface{}, undo *fakeUndoLog) (*fakeRows, fakeResult, error) {
+	stmts, err := fakeParse(query, args)
+	if err != nil {
+		return nil, fakeResult{}, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.undo = undo
+	defer func() { db.undo = nil }()
+
+	var (
+		rows *fakeRows
+		res  fakeResult
+	)
+	for _, s := range stmts {
+		switch s.(type) {
+		case *fakeCreateTable, *fakeDropTable, *fakeTruncate:
+			if undo != nil {
+				*undo = (*undo)[:0] // implicit commit
+			}
+		}
+		r, sr, err := s.exec(db)
+		if err != nil {
+			return nil, fakeResult{}, err
+		}
+		if r != nil {
+			rows = r
+		}
+		res.rowsAffected += sr.rowsAffected
+		if sr.lastInsertID > 0 {
+			res.lastInsertID = sr.lastInsertID
+			db.lastInsertID = sr.lastInsertID
+		}
+	}
+	return rows, res, nil
+}
+
+func (db *FakeDB) newEnv() *fakeEnv {
+	return &fakeEnv{now: db.nowFn(), lastInsertID: db.lastInsertID, undo: db.undo}
+}
+
+func (db *FakeDB) table(name string) (*fakeTable, error) {
+	t, ok := db.tables[strings.ToLower(name)]
+	if !ok {
+		return nil, fakeMySQLError(fakeErrNoSuchTable, "Table '%s' doesn't exist", name)
+	}
+	return t, nil
+}
+
+// fakeUndo describes the change of a single row. An UPDATE removes the old
+// row and adds the new one.
+type fakeUndo struct {
+	tbl     *fakeTable
+	removed []interface{}
+	added   []interface{}
+}
+
+// fakeUndoLog records the changed rows of a transaction. A nil log records
+// nothing.
+type fakeUndoLog []fakeUndo
+
+func (l *fakeUndoLog) log(t *fakeTable, removed, added []interface{}) {
+	if l != nil {
+		*l = append(*l, fakeUndo{tbl: t, removed: removed, added: added})
+	}
+}
+
+// rollback reverts the changes in reverse order. Rows get identified by
+// their backing array, because each change creates a new row slice.
+func (l *fakeUndoLog) rollback() {
+	for i := len(*l) - 1; i >= 0; i-- {
+		u := (*l)[i]
+		if u.added != nil {
+			for ri, r := range u.tbl.rows {
+				if &r[0] == &u.added[0] {
+					u.tbl.rows = append(u.tbl.rows[:ri], u.tbl.rows[ri+1:]...)
+					break
+				}
+			}
+		}
+		if u.removed != nil {
+			u.tbl.rows = append(u.tbl.rows, u.removed)
+		}
+		u.tbl.unique = nil
+	}
+	*l = (*l)[:0]
+}
+
+type fakeColumn struct {
+	name          string
+	typ           string
+	unsigned      bool
+	notNull       bool
+	autoIncrement bool
+	onUpdateNow   bool
+	defaultExpr   fakeExpr
+}
+
+// kind returns the storage class of the column: i for integers, f for
+// floating point numbers, d for decimals, t for date and time and s for all
+// others.
+func (c *fakeColumn) kind() byte {
+	switch c.typ {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint", "bit", "bool", "boolean", "year":
+		return 'i'
+	case "float", "double", "real":
+		return 'f'
+	case "decimal", "numeric", "dec", "fixed":
+		return 'd'
+	case "date", "datetime", "timestamp":
+		return 't'
+	}
+	return 's'
+}
+
+type fakeIndex struct {
+	name    string
+	columns []int
+}
+
+type fakeTable struct {
+	name    string
+	columns []*fakeColumn
+	keys    []fakeIndex // primary key first
+	rows    [][]interface{}
+	autoInc int64
+	// unique contains for each key a map from the key values to the row
+	// index. A nil map gets rebuilt on the next lookup.
+	unique []map[string]int
+}
+
+func (t *fakeTable) colIndex(name string) int {
+	for i, c := range t.columns {
+		if strings.EqualFold(c.name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t *fakeTable) errBadField(name string) error {
+	return fakeMySQLError(fakeErrBadField, "Unknown column '%s' in 'field list'", name)
+}
+
+// coerce converts a value into the storage type of the column.
+func (t *fakeTable) coerce(ci int, v interface{}, rowNum int) (interface{}, error) {
+	c := t.columns[ci]
+	v = fakeNormalize(v)
+	if v == nil {
+		if c.notNull && !c.autoIncrement {
+			return nil, fakeMySQLError(fakeErrBadNull, "Column '%s' cannot be null", c.name)
+		}
+		return nil, nil
+	}
+
+	switch c.kind() {
+	case 'i':
+		i, ok := fakeToInt(v)
+		if _, isStr := v.(string); isStr && !ok {
+			return nil, fakeMySQLError(fakeErrTruncatedValue, "Incorrect integer value: '%s' for column '%s' at row %d", v, c.name, rowNum)
+		}
+		return i, nil
+	case 'f':
+		return fakeToFloat(v), nil
+	case 'd':
+		return fakeToString(v), nil
+	case 't':
+		tv, ok := v.(time.Time)
+		if !ok {
+			if tv, ok = fakeParseTime(fakeToString(v)); !ok {
+				return nil, fakeMySQLError(fakeErrTruncatedValue, "Incorrect %s value: '%s' for column '%s' at row %d", c.typ, fakeToString(v), c.name, rowNum)
+			}
+		}
+		if c.typ == "date" && !tv.IsZero() {
+			y, m, d := tv.Date()
+			tv = time.Date(y, m, d, 0, 0, 0, 0, tv.Location())
+		}
+		return tv, nil
+	}
+	return fakeToString(v), nil
+}
+
+// defaultValue returns the default value of a column if no value has been
+// provided.
+func (t *fakeTable) defaultValue(env *fakeEnv, ci int) (interface{}, error) {
+	c := t.columns[ci]
+	switch {
+	case c.defaultExpr != nil:
+		return c.defaultExpr.eval(env)
+	case c.autoIncrement, !c.notNull:
+		return nil, nil
+	}
+	return nil, fakeMySQLError(fakeErrNoDefault, "Field '%s' doesn't have a default value", c.name)
+}
+
+// scan returns the indexes of all rows ordered by the primary key.
+func (t *fakeTable) scan() []int {
+	idx := make([]int, len(t.rows))
+	for i := range idx {
+		idx[i] = i
+	}
+	if len(t.keys) > 0 && t.keys[0].name == "PRIMARY" {
+		pk := t.keys[0].columns
+		sort.SliceStable(idx, func(i, j int) bool {
+			a, b := t.rows[idx[i]], t.rows[idx[j]]
+			for _, ci := range pk {
+				if c := fakeCompareNull(a[ci], b[ci]); c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+	}
+	return idx
+}
+
+// keyString returns the lookup key of a row for a unique key. Returns false
+// if the key contains a NULL value, which never conflicts.
+func (t *fakeTable) keyString(k fakeIndex, row []interface{}) (string, bool) {
+	var buf strings.Builder
+	for _, ci := range k.columns {
+		if row[ci] == nil {
+			return "", false
+		}
+		buf.WriteString(fakeKey(row[ci]))
+		buf.WriteByte(0)
+	}
+	return buf.String(), true
+}
+
+// uniqueIndex returns the lookup map of the unique key with index ki and
+// builds it, if it has been invalidated.
+func (t *fakeTable) uniqueIndex(ki int) map[string]int {
+	if t.unique == nil {
+		t.unique = make([]map[string]int, len(t.keys))
+	}
+	if t.unique[ki] == nil {
+		t.unique[ki] = make(map[string]int, len(t.rows))
+		for i, r := range t.rows {
+			if ks, ok := t.keyString(t.keys[ki], r); ok {
+				t.unique[ki][ks] = i
+			}
+		}
+	}
+	return t.unique[ki]
+}
+
+// conflict returns the index of the first row which has the same unique key
+// as the row, skipping the row with index skip. Returns -1 if there is no
+// conflict.
+func (t *fakeTable) conflict(row []interface{}, skip int) (int, error) {
+	for ki, k := range t.keys {
+		ks, ok := t.keyString(k, row)
+		if !ok {
+			continue
+		}
+		if i, ok := t.uniqueIndex(ki)[ks]; ok && i != skip {
+			entry := make([]string, len(k.columns))
+			for j, ci := range k.columns {
+				entry[j] = fakeToString(row[ci])
+			}
+			return i, fakeMySQLError(fakeErrDupEntry, "Duplicate entry '%s' for key '%s'", strings.Join(entry, "-"), k.name)
+		}
+	}
+	return -1, nil
+}
+
+func (t *fakeTable) appendRow(row []interface{}) {
+	t.rows = append(t.rows, row)
+	for ki, k := range t.keys {
+		if t.unique == nil || t.unique[ki] == nil {
+			continue
+		}
+		if ks, ok := t.keyString(k, row); ok {
+			t.unique[ki][ks] = len(t.rows) - 1
+		}
+	}
+}
+
+func (t *fakeTable) deleteRow(i int) {
+	t.rows = append(t.rows[:i], t.rows[i+1:]...)
+	t.unique = nil
+}
+
+// fakeCompareNull compares two values where NULL is the smallest value.
+func fakeCompareNull(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return fakeCompare(a, b)
+}
+
+// fakeMatch reports whether a row matches the WHERE condition.
+func fakeMatch(env *fakeEnv, where fakeExpr) (bool, error) {
+	if where == nil {
+		return true, nil
+	}
+	v, err := where.eval(env)
+	if err != nil {
+		return false, err
+	}
+	return v != nil && fakeTruth(v), nil
+}
+
+// filterRows returns the indexes of the rows of the table matching the WHERE
+// condition, sorted by the ORDER BY and restricted by the LIMIT of UPDATE and
+// DELETE statements.
+func (t *fakeTable) filterRows(env *fakeEnv, where fakeExpr, orderBy []fakeOrder, limit int64) ([]int, error) {
+	var idx []int
+	for _, i := range t.scan() {
+		env.row = t.rows[i]
+		ok, err := fakeMatch(env, where)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			idx = append(idx, i)
+		}
+	}
+	if len(orderBy) > 0 {
+		keys := make(map[int][]interface{}, len(idx))
+		for _, i := range idx {
+			env.row = t.rows[i]
+			for _, o := range orderBy {
+				v, err := o.expr.eval(env)
+				if err != nil {
+					return nil, err
+				}
+				keys[i] = append(keys[i], v)
+			}
+		}
+		sort.SliceStable(idx, func(a, b int) bool {
+			return fakeLessOrder(orderBy, keys[idx[a]], keys[idx[b]])
+		})
+	}
+	if limit >= 0 && int64(len(idx)) > limit {
+		idx = idx[:limit]
+	}
+	return idx, nil
+}
+
+func fakeLessOrder(orderBy []fakeOrder, a, b []interface{}) bool {
+	for i, o := range orderBy {
+		c := fakeCompareNull(a[i], b[i])
+		if o.desc {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+	}
+	return false
+}
+
+type fakeResult struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r fakeResult) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r fakeResult) RowsAffected() (int64, error) { return r.rowsAffected, nil }
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	pos     int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+// fakeDriverValue converts an internal value into the type which the MySQL
+// driver returns with the option parseTime=true.
+func fakeDriverValue(v interface{}) driver.Value {
+	if s, ok := v.(string); ok {
+		return []byte(s)
+	}
+	return v
+}
+
+func (s *fakeSelect) exec(db *FakeDB) (*fakeRows, fakeResult, error) {
+	env := db.newEnv()
+	rows := [][]interface{}{nil}
+	if s.table != "" {
+		t, err := db.table(s.table)
+		if err != nil {
+			return nil, fakeResult{}, err
+		}
+		env.tbl, env.alias = t, s.alias
+		rows = rows[:0]
+		for _, i := range t.scan() {
+			rows = append(rows, t.rows[i])
+		}
+	}
+
+	var (
+		names []string
+		exprs []fakeExpr
+	)
+	for _, se := range s.exprs {
+		if !se.star {
+			names = append(names, se.name)
+			exprs = append(exprs, se.expr)
+			continue
+		}
+		if env.tbl == nil {
+			return nil, fakeResult{}, fakeMySQLError(fakeErrNoTablesUsed, "No tables used")
+		}
+		if q := se.qualifier; q != "" && !strings.EqualFold(q, env.alias) && !strings.EqualFold(q, env.tbl.name) {
+			return nil, fakeResult{}, fakeMySQLError(fakeErrBadTable, "Unknown table '%s'", q)
+		}
+		for _, c := range env.tbl.columns {
+			names = append(names, c.name)
+			exprs = append(exprs, &fakeColumnRef{name: c.name})
+		}
+	}
+
+	var matched [][]interface{}
+	for _, r := range rows {
+		env.row = r
+		ok, err := fakeMatch(env, s.where)
+		if err != nil {
+			return nil, fakeResult{}, err
+		}
+		if ok {
+			matched = append(matched, r)
+		}
+	}
+
+	aggregate := len(s.groupBy) > 0 || (s.having != nil && fakeHasAggregate(s.having))
+	for _, e := range exprs {
+		aggregate = aggregate || fakeHasAggregate(e)
+	}
+	for _, o := range s.orderBy {
+		aggregate = aggregate || fakeHasAggregate(o.expr)
+	}
+
+	var groups [][][]interface{}
+	switch {
+	case !aggregate:
+		for _, r := range matched {
+			groups = append(groups, [][]interface{}{r})
+		}
+	case len(s.groupBy) == 0:
+		groups = [][][]interface{}{matched}
+	default:
+		pos := map[string]int{}
+		for _, r := range matched {
+			env.row = r
+			var key strings.Builder
+			for _, g := range s.groupBy {
+				v, err := g.eval(env)
+				if err != nil {
+					return nil, fakeResult{}, err
+				}
+				key.WriteString(fakeKey(v))
+				key.WriteByte(0)
+			}
+			i, ok := pos[key.String()]
+			if !ok {
+				i = len(groups)
+				pos[key.String()] = i
+				groups = append(groups, nil)
+			}
+			groups[i] = append(groups[i], r)
+		}
+	}
+
+	type outRow struct {
+		vals  []interface{}
+		order []interface{}
+	}
+	out := make([]outRow, 0, len(groups))
+	for _, g := range groups {
+		env.row = nil
+		if len(g) > 0 {
+			env.row = g[0]
+		}
+		env.group, env.grouped = g, aggregate
+
+		or := outRow{vals: make([]interface{}, len(exprs))}
+		for i, e := range exprs {
+			v, err := e.eval(env)
+			if err != nil {
+				return nil, fakeResult{}, err
+			}
+			or.vals[i] = v
+		}
+
+		env.output = make(map[string]interface{}, len(names))
+		for i, n := range names {
+			env.output[strings.ToLower(n)] = or.vals[i]
+		}
+		ok, err := fakeMatch(env, s.having)
+		if err != nil {
+			return nil, fakeResult{}, err
+		}
+		for _, o := range s.orderBy {
+			var v interface{}
+			if l, isLit := o.expr.(*fakeLiteral); isLit {
+				if n, isInt := l.v.(int64); isInt && n >= 1 && int(n) <= len(or.vals) {
+					v = or.vals[n-1] // ORDER BY column position
+				}
+			} else if v, err = o.expr.eval(env); err != nil {
+				return nil, fakeResult{}, err
+			}
+			or.order = append(or.order, v)
+		}
+		env.output = nil
+		if ok {
+			out = append(out, or)
+		}
+	}
+
+	if len(s.orderBy) > 0 {
+		sort.SliceStable(out, func(i, j int) bool {
+			return fakeLessOrder(s.orderBy, out[i].order, out[j].order)
+		})
+	}
+
+	res := &fakeRows{columns: names}
+	seen := map[string]bool{}
+	var offset int64
+	for _, or := range out {
+		if s.distinct {
+			var key strings.Builder
+			for _, v := range or.vals {
+				key.WriteString(fakeKey(v))
+				key.WriteByte(0)
+			}
+			if seen[key.String()] {
+				continue
+			}
+			seen[key.String()] = true
+		}
+		if offset < s.offset {
+			offset++
+			continue
+		}
+		if s.limit >= 0 && int64(len(res.rows)) >= s.limit {
+			break
+		}
+		dv := make([]driver.Value, len(or.vals))
+		for j, v := range or.vals {
+			dv[j] = fakeDriverValue(v)
+		}
+		res.rows = append(res.rows, dv)
+	}
+	return res, fakeResult{}, nil
+}
+
+func (s *fakeInsert) exec(db *FakeDB) (*fakeRows, fakeResult, error) {
+	t, err := db.table(s.table)
+	if err != nil {
+		return nil, fakeResult{}, err
+	}
+	env := db.newEnv()
+	env.tbl = t
+
+	cols := make([]int, len(s.columns))
+	for i, c := range s.columns {
+		if cols[i] = t.colIndex(c); cols[i] < 0 {
+			return nil, fakeResult{}, t.errBadField(c)
+		}
+	}
+	if len(s.columns) == 0 {
+		cols = make([]int, len(t.columns))
+		for i := range cols {
+			cols[i] = i
+		}
+	}
+
+	var res fakeResult
+	for rowNum, exprs := range s.rows {
+		rowNum++
+		if len(exprs) != len(cols) {
+			return nil, fakeResult{}, fakeMySQLError(fakeErrValueCount, "Column count doesn't match value count at row %d", rowNum)
+		}
+
+		row := make([]interface{}, len(t.columns))
+		set := make([]bool, len(t.columns))
+		for i, e := range exprs {
+			var v interface{}
+			if _, isDefault := e.(fakeDefault); isDefault {
+				v, err = t.defaultValue(env, cols[i])
+			} else {
+				v, err = e.eval(env)
+			}
+			if err != nil {
+				return nil, fakeResult{}, err
+			}
+			row[cols[i]], set[cols[i]] = v, true
+		}
+		for ci := range t.columns {
+			if !set[ci] {
+				if row[ci], err = t.defaultValue(env, ci); err != nil {
+					return nil, fakeResult{}, err
+				}
+			}
+		}
+
+		var generatedID int64
+		for ci, c := range t.columns {
+			if c.autoIncrement {
+				if id, _ := fakeToInt(fakeNormalize(row[ci])); row[ci] == nil || id == 0 {
+					generatedID = t.autoInc
+					row[ci] = generatedID
+				} else if id >= t.autoInc {
+					t.autoInc = id + 1
+				}
+			}
+			if row[ci], err = t.coerce(ci, row[ci], rowNum); err != nil {
+				return nil, fakeResult{}, err
+			}
+		}
+
+		idx, errDup := t.conflict(row, -1)
+		switch {
+		case errDup == nil:
+		case s.replace:
+			for ; idx >= 0; idx, _ = t.conflict(row, -1) {
+				db.undo.log(t, t.rows[idx], nil)
+				t.deleteRow(idx)
+				res.rowsAffected++
+			}
+		case s.onDupKey != nil:
+			changed, err := t.update(env, idx, s.onDupKey, row)
+			if err != nil {
+				return nil, fakeResult{}, err
+			}
+			if changed {
+				res.rowsAffected += 2
+			}
+			continue
+		case s.ignore:
+			continue
+		default:
+			return nil, fakeResult{}, errDup
+		}
+
+		if generatedID > 0 {
+			t.autoInc = generatedID + 1
+			if res.lastInsertID == 0 {
+				res.lastInsertID = generatedID
+			}
+		}
+		t.appendRow(row)
+		db.undo.log(t, nil, row)
+		res.rowsAffected++
+	}
+	return nil, res, nil
+}
+
+// update applies the assignments to the row with index i and reports whether
+// the row has been changed. Argument values contains the row for the VALUES()
+// function.
+func (t *fakeTable) update(env *fakeEnv, i int, set []fakeAssignment, values []interface{}) (bool, error) {
+	old := t.rows[i]
+	row := append([]interface{}(nil), old...)
+	env.row, env.values = row, values
+	defer func() { env.values = nil }()
+
+	assigned := make([]bool, len(t.columns))
+	for _, a := range set {
+		ci := t.colIndex(a.column)
+		if ci < 0 {
+			return false, t.errBadField(a.column)
+		}
+		var (
+			v   interface{}
+			err error
+		)
+		if _, isDefault := a.expr.(fakeDefault); isDefault {
+			v, err = t.defaultValue(env, ci)
+		} else {
+			v, err = a.expr.eval(env)
+		}
+		if err != nil {
+			return false, err
+		}
+		if row[ci], err = t.coerce(ci, v, i+1); err != nil {
+			return false, err
+		}
+		assigned[ci] = true
+	}
+
+	changed := false
+	for ci := range row {
+		if (row[ci] == nil) != (old[ci] == nil) || fakeToString(row[ci]) != fakeToString(old[ci]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+	for ci, c := range t.columns {
+		if c.onUpdateNow && !assigned[ci] {
+			row[ci], _ = t.coerce(ci, env.now, i+1)
+		}
+	}
+	if _, err := t.conflict(row, i); err != nil {
+		return false, err
+	}
+	t.rows[i] = row
+	t.unique = nil
+	env.undo.log(t, old, row)
+	return true, nil
+}
+
+func (s *fakeUpdate) exec(db *FakeDB) (*fakeRows, fakeResult, error) {
+	t, err := db.table(s.table)
+	if err != nil {
+		return nil, fakeResult{}, err
+	}
+	env := db.newEnv()
+	env.tbl, env.alias = t, s.alias
+
+	idx, err := t.filterRows(env, s.where, s.orderBy, s.limit)
+	if err != nil {
+		return nil, fakeResult{}, err
+	}
+	var res fakeResult
+	for _, i := range idx {
+		changed, err := t.update(env, i, s.set, nil)
+		if err != nil {
+			return nil, fakeResult{}, err
+		}
+		if changed {
+			res.rowsAffected++
+		}
+	}
+	return nil, res, nil
+}
+
+func (s *fakeDelete) exec(db *FakeDB) (*fakeRows, fakeResult, error) {
+	t, err := db.table(s.table)
+	if err != nil {
+		return nil, fakeResult{}, err
+	}
+	env := db.newEnv()
+	env.tbl, env.alias = t, s.alias
+
+	idx, err := t.filterRows(env, s.where, s.orderBy, s.limit)
+	if err != nil {
+		return nil, fakeResult{}, err
+	}
+	deleted := make(map[int]bool, len(idx))
+	for _, i := range idx {
+		deleted[i] = true
+	}
+	rows := t.rows[:0]
+	for i, r := range t.rows {
+		if deleted[i] {
+			db.undo.log(t, r, nil)
+		} else {
+			rows = append(rows, r)
+		}
+	}
+	t.rows = rows
+	t.unique = nil
+	return nil, fakeResult{rowsAffected: int64(len(idx))}, nil
+}
+
+func (s *fakeCreateTable) exec(db *FakeDB) (*fakeRows, fakeResult, error) {
+	if _, ok := db.tables[strings.ToLower(s.table)]; ok {
+		if s.ifNotExists {
+			return nil, fakeResult{}, nil
+		}
+		return nil, fakeResult{}, fakeMySQLError(fakeErrTableExists, "Table '%s' already exists", s.table)
+	}
+
+	if s.like != "" {
+		src, err := db.table(s.like)
+		if err != nil {
+			return nil, fakeResult{}, err
+		}
+		db.tables[strings.ToLower(s.table)] = &fakeTable{name: s.table, columns: src.columns, keys: src.keys, autoInc: 1}
+		return nil, fakeResult{}, nil
+	}
+
+	t := &fakeTable{name: s.table, columns: s.columns, autoInc: s.autoInc}
+	addKey := func(name string, columns []string) error {
+		k := fakeIndex{name: name}
+		for _, c := range columns {
+			ci := t.colIndex(c)
+			if ci < 0 {
+				return fakeMySQLError(fakeErrBadField, "Key column '%s' doesn't exist in table", c)
+			}
+			if name == "PRIMARY" {
+				t.columns[ci].notNull = true
+			}
+			k.columns = append(k.columns, ci)
+		}
+		t.keys = append(t.keys, k)
+		return nil
+	}
+	if len(s.primary) > 0 {
+		if err := addKey("PRIMARY", s.primary); err != nil {
+			return nil, fakeResult{}, err
+		}
+	}
+	for _, u := range s.uniques {
+		if err := addKey(u.name, u.columns); err != nil {
+			return nil, fakeResult{}, err
+		}
+	}
+	db.tables[strings.ToLower(s.table)] = t
+	return nil, fakeResult{}, nil
+}
+
+func (s *fakeDropTable) exec(db *FakeDB) (*fakeRows, fakeResult, error) {
+	for _, tbl := range s.tables {
+		if _, ok := db.tables[strings.ToLower(tbl)]; !ok && !s.ifExists {
+			return nil, fakeResult{}, fakeMySQLError(fakeErrBadTable, "Unknown table '%s'", tbl)
+		}
+		delete(db.tables, strings.ToLower(tbl))
+	}
+	return nil, fakeResult{}, nil
+}
+
+func (s *fakeTruncate) exec(db *FakeDB) (*fakeRows, fakeResult, error) {
+	t, err := db.table(s.table)
+	if err != nil {
+		return nil, fakeResult{}, err
+	}
+	t.rows = nil
+	t.unique = nil
+	t.autoInc = 1
+	return nil, fakeResult{}, nil
+}
+
+func (fakeNoop) exec(*FakeDB) (*fakeRows, fakeResult, error) {
+	return nil, fakeResult{}, nil
+}
+
+// fakeConn implements the driver interfaces for a connection. Statements get
+// parsed for each execution because the arguments get inserted while parsing.
+type fakeConn struct {
+	db   *FakeDB
+	undo *fakeUndoLog // non-nil during a transaction
+}
+
+func fakeArgs(named []driver.NamedValue) ([]interface{}, error) {
+	args := make([]interface{}, len(named))
+	for i, nv := range named {
+		if nv.Name != "" {
+			return nil, errors.NotSupported.Newf("[dmltest] FakeDB does not support named arguments: %q", nv.Name)
+		}
+		args[i] = nv.Value
+	}
+	return args, nil
+}
+
+func fakeNamedValues(vals []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(vals))
+	for i, v := range vals {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeDriverStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) PrepareContext(_ context.Context, query string) (driver.Stmt, error) {
+	return c.Prepare(query)
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	if c.undo != nil {
+		return nil, errors.NotAllowed.Newf("[dmltest] FakeDB: Transaction already started")
+	}
+	c.undo = &fakeUndoLog{}
+	return fakeTx{conn: c}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, named []driver.NamedValue) (driver.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	args, err := fakeArgs(named)
+	if err != nil {
+		return nil, err
+	}
+	_, res, err := c.db.run(query, args, c.undo)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, named []driver.NamedValue) (driver.Rows, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	args, err := fakeArgs(named)
+	if err != nil {
+		return nil, err
+	}
+	rows, _, err := c.db.run(query, args, c.undo)
+	if err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		rows = &fakeRows{}
+	}
+	return rows, nil
+}
+
+type fakeTx struct {
+	conn *fakeConn
+}
+
+func (tx fakeTx) Commit() error {
+	tx.conn.undo = nil
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	db := tx.conn.db
+	db.mu.Lock()
+	tx.conn.undo.rollback()
+	db.mu.Unlock()
+	tx.conn.undo = nil
+	return nil
+}
+
+type fakeDriverStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeDriverStmt) Close() error  { return nil }
+func (s *fakeDriverStmt) NumInput() int { return -1 }
+
+func (s *fakeDriverStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), s.query, fakeNamedValues(args))
+}
+
+func (s *fakeDriverStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.QueryContext(context.Background(), s.query, fakeNamedValues(args))
+}
+
+func (s *fakeDriverStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+func (s *fakeDriverStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+var (
+	_ driver.Connector          = (*FakeDB)(nil)
+	_ driver.Driver             = (*FakeDB)(nil)
+	_ driver.ConnBeginTx        = (*fakeConn)(nil)
+	_ driver.ConnPrepareContext = (*fakeConn)(nil)
+	_ driver.ExecerContext      = (*fakeConn)(nil)
+	_ driver.QueryerContext     = (*fakeConn)(nil)
+	_ driver.StmtExecContext    = (*fakeDriverStmt)(nil)
+	_ driver.StmtQueryContext   = (*fakeDriverStmt)(nil)
+)
@@ -0,0 +1,237 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmltest_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/go-sql-driver/mysql"
+)
+
+const fakeCreateCustomer = "CREATE TABLE `customer_entity` (\n" +
+	"  `entity_id` int(10) unsigned NOT NULL AUTO_INCREMENT,\n" +
+	"  `email` varchar(255) DEFAULT NULL,\n" +
+	"  `group_id` smallint(5) unsigned NOT NULL DEFAULT '0',\n" +
+	"  `balance` decimal(12,4) DEFAULT NULL,\n" +
+	"  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+	"  PRIMARY KEY (`entity_id`),\n" +
+	"  UNIQUE KEY `CUSTOMER_ENTITY_EMAIL` (`email`)\n" +
+	") ENGINE=InnoDB AUTO_INCREMENT=10 DEFAULT CHARSET=utf8 COMMENT='Customer Entity';"
+
+func assertMySQLError(t *testing.T, number uint16, err error) {
+	t.Helper()
+	myErr, ok := errors.Cause(err).(*mysql.MySQLError)
+	assert.True(t, ok, "%+v", err)
+	if ok {
+		assert.Exactly(t, number, myErr.Number, "%+v", err)
+	}
+}
+
+func fakeQueryStrings(t *testing.T, db *sql.DB, query string, args ...interface{}) []string {
+	t.Helper()
+	rows, err := db.Query(query, args...)
+	assert.NoError(t, err)
+	defer rows.Close()
+	var ret []string
+	for rows.Next() {
+		var s sql.NullString
+		assert.NoError(t, rows.Scan(&s))
+		ret = append(ret, s.String)
+	}
+	assert.NoError(t, rows.Err())
+	return ret
+}
+
+func TestFakeDB_CRUD(t *testing.T) {
+	dbc, fdb := dmltest.MustConnectFakeDB(t)
+	defer dmltest.Close(t, dbc)
+	assert.NoError(t, fdb.Exec(fakeCreateCustomer))
+
+	res, err := dbc.DB.Exec("INSERT INTO `customer_entity` (`email`,`group_id`,`balance`) VALUES (?,?,?),(?,?,?),(?,?,?)",
+		"a@example.com", 1, 10.5, "b@example.com", 2, nil, "c@example.com", 1, "3.25")
+	assert.NoError(t, err)
+	assert.Exactly(t, int64(10), dmltest.CheckLastInsertID(t)(res, err))
+	ra, err := res.RowsAffected()
+	assert.NoError(t, err)
+	assert.Exactly(t, int64(3), ra)
+
+	t.Run("select where in order by limit", func(t *testing.T) {
+		emails := fakeQueryStrings(t, dbc.DB,
+			"SELECT `ce`.`email` FROM `customer_entity` AS `ce` WHERE (`ce`.`group_id` IN (?,?)) AND (`ce`.`email` LIKE 'a%' OR `balance` IS NULL) ORDER BY `ce`.`email` DESC LIMIT 0,5", 1, 2)
+		assert.Exactly(t, []string{"b@example.com", "a@example.com"}, emails)
+	})
+
+	t.Run("select column types", func(t *testing.T) {
+		var (
+			id        int64
+			balance   sql.NullString
+			createdAt time.Time
+		)
+		err := dbc.DB.QueryRow("SELECT entity_id, balance, created_at FROM customer_entity WHERE email = 'C@EXAMPLE.COM'").Scan(&id, &balance, &createdAt)
+		assert.NoError(t, err)
+		assert.Exactly(t, int64(12), id)
+		assert.Exactly(t, "3.25", balance.String)
+		assert.False(t, createdAt.IsZero())
+	})
+
+	t.Run("duplicate entry", func(t *testing.T) {
+		_, err := dbc.DB.Exec("INSERT INTO customer_entity (email) VALUES ('a@example.com')")
+		assertMySQLError(t, 1062, err)
+
+		res, err := dbc.DB.Exec("INSERT IGNORE INTO customer_entity (email) VALUES ('a@example.com')")
+		assert.NoError(t, err)
+		ra, _ := res.RowsAffected()
+		assert.Exactly(t, int64(0), ra)
+	})
+
+	t.Run("on duplicate key update", func(t *testing.T) {
+		res, err := dbc.DB.Exec("INSERT INTO customer_entity (email,group_id) VALUES (?,?) ON DUPLICATE KEY UPDATE `group_id`=VALUES(`group_id`)", "b@example.com", 4)
+		assert.NoError(t, err)
+		ra, _ := res.RowsAffected()
+		assert.Exactly(t, int64(2), ra)
+		assert.Exactly(t, []string{"4"}, fakeQueryStrings(t, dbc.DB, "SELECT group_id FROM customer_entity WHERE entity_id=11"))
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+		res, err := dbc.DB.Exec("UPDATE `customer_entity` SET `group_id`=`group_id`+1 WHERE (`group_id` = ?)", 1)
+		assert.NoError(t, err)
+		ra, _ := res.RowsAffected()
+		assert.Exactly(t, int64(2), ra)
+
+		res, err = dbc.DB.Exec("DELETE FROM `customer_entity` WHERE (`entity_id` >= ?) ORDER BY `entity_id` LIMIT 1", 11)
+		assert.NoError(t, err)
+		ra, _ = res.RowsAffected()
+		assert.Exactly(t, int64(1), ra)
+		assert.Exactly(t, []string{"10", "12"}, fakeQueryStrings(t, dbc.DB, "SELECT entity_id FROM customer_entity"))
+	})
+
+	t.Run("unknown column and table", func(t *testing.T) {
+		_, err := dbc.DB.Query("SELECT `nope` FROM customer_entity")
+		assertMySQLError(t, 1054, err)
+		_, err = dbc.DB.Query("SELECT * FROM customer_nope")
+		assertMySQLError(t, 1146, err)
+	})
+
+	t.Run("JOIN not supported", func(t *testing.T) {
+		_, err := dbc.DB.Query("SELECT * FROM customer_entity ce JOIN store s ON ce.store_id = s.store_id")
+		assert.ErrorIsKind(t, errors.NotSupported, err)
+	})
+}
+
+func TestFakeDB_Aggregate(t *testing.T) {
+	dbc, fdb := dmltest.MustConnectFakeDB(t)
+	defer dmltest.Close(t, dbc)
+	assert.NoError(t, fdb.LoadCSV("core_config_data", dmltest.WithFile("testdata", "core_config_data1.csv")))
+
+	scopes := fakeQueryStrings(t, dbc.DB,
+		"SELECT CONCAT(`scope`,':',COUNT(*)) AS `cnt` FROM `core_config_data` WHERE `path` LIKE 'general/%' GROUP BY `scope` ORDER BY `scope`")
+	assert.Exactly(t, []string{"default:2", "stores:1"}, scopes)
+
+	scopes = fakeQueryStrings(t, dbc.DB,
+		"SELECT CONCAT(`scope`,':',COUNT(*)) AS `cnt` FROM `core_config_data` WHERE `path` LIKE 'web/%' GROUP BY `scope` HAVING COUNT(*) > 1")
+	assert.Exactly(t, []string{"default:15"}, scopes)
+
+	var count, maxID int64
+	assert.NoError(t, dbc.DB.QueryRow("SELECT COUNT(DISTINCT scope), MAX(config_id + 0) FROM core_config_data").Scan(&count, &maxID))
+	assert.Exactly(t, int64(3), count)
+	assert.Exactly(t, int64(20), maxID)
+}
+
+func TestFakeDB_SQLDumpLoad(t *testing.T) {
+	dbc, fdb := dmltest.MustConnectFakeDB(t)
+	defer dmltest.Close(t, dbc)
+
+	deferred := fdb.SQLDumpLoad(t, "../dml/testdata/person_ffaker*.sql", nil)
+
+	var count int64
+	assert.NoError(t, dbc.DB.QueryRow("SELECT COUNT(*) FROM `dml_fake_person` WHERE `sex` IN ('male','female')").Scan(&count))
+	assert.Exactly(t, int64(10000), count)
+
+	var birthDate time.Time
+	assert.NoError(t, dbc.DB.QueryRow("SELECT `birth_date` FROM `dml_fake_person` WHERE `id` = ?", 1).Scan(&birthDate))
+	assert.Exactly(t, "2004-06-13", birthDate.Format("2006-01-02"))
+
+	deferred.Deferred()
+	_, err := dbc.DB.Query("SELECT * FROM `dml_fake_person`")
+	assertMySQLError(t, 1146, err)
+}
+
+func TestFakeDB_Transaction(t *testing.T) {
+	dbc, fdb := dmltest.MustConnectFakeDB(t)
+	defer dmltest.Close(t, dbc)
+	assert.NoError(t, fdb.Exec(fakeCreateCustomer))
+
+	tx, err := dbc.DB.Begin()
+	assert.NoError(t, err)
+	_, err = tx.Exec("INSERT INTO customer_entity (email) VALUES ('tx@example.com')")
+	assert.NoError(t, err)
+	assert.Exactly(t, []string{"tx@example.com"}, fakeQueryStrings(t, dbc.DB, "SELECT email FROM customer_entity"))
+	assert.NoError(t, tx.Rollback())
+
+	assert.Len(t, fakeQueryStrings(t, dbc.DB, "SELECT email FROM customer_entity"), 0)
+}
+
+func TestFakeDB_Transaction_Rollback(t *testing.T) {
+	dbc, fdb := dmltest.MustConnectFakeDB(t)
+	defer dmltest.Close(t, dbc)
+	assert.NoError(t, fdb.Exec(fakeCreateCustomer))
+	assert.NoError(t, fdb.Exec("INSERT INTO customer_entity (email) VALUES ('a@example.com'),('b@example.com'),('c@example.com')"))
+
+	tx, err := dbc.DB.Begin()
+	assert.NoError(t, err)
+	_, err = tx.Exec("UPDATE customer_entity SET email='a2@example.com' WHERE email='a@example.com'")
+	assert.NoError(t, err)
+	_, err = tx.Exec("DELETE FROM customer_entity WHERE email='b@example.com'")
+	assert.NoError(t, err)
+	_, err = tx.Exec("REPLACE INTO customer_entity (entity_id,email) VALUES (12,'c2@example.com')")
+	assert.NoError(t, err)
+	_, err = tx.Exec("INSERT INTO customer_entity (email) VALUES ('tx@example.com')")
+	assert.NoError(t, err)
+
+	// Another connection commits its change while the transaction runs.
+	_, err = dbc.DB.Exec("INSERT INTO customer_entity (email) VALUES ('other@example.com')")
+	assert.NoError(t, err)
+
+	assert.NoError(t, tx.Rollback())
+	assert.Exactly(t,
+		[]string{"a@example.com", "b@example.com", "c@example.com", "other@example.com"},
+		fakeQueryStrings(t, dbc.DB, "SELECT email FROM customer_entity ORDER BY entity_id"))
+
+	// The unique key index gets rebuilt after the rollback.
+	_, err = dbc.DB.Exec("INSERT INTO customer_entity (email) VALUES ('b@example.com')")
+	assertMySQLError(t, 1062, err)
+}
+
+func TestFakeDB_Transaction_ImplicitCommit(t *testing.T) {
+	dbc, fdb := dmltest.MustConnectFakeDB(t)
+	defer dmltest.Close(t, dbc)
+	assert.NoError(t, fdb.Exec(fakeCreateCustomer))
+
+	tx, err := dbc.DB.Begin()
+	assert.NoError(t, err)
+	_, err = tx.Exec("INSERT INTO customer_entity (email) VALUES ('tx@example.com')")
+	assert.NoError(t, err)
+	_, err = tx.Exec("CREATE TABLE tx_table (id int NOT NULL)")
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Rollback())
+
+	assert.Exactly(t, []string{"tx@example.com"}, fakeQueryStrings(t, dbc.DB, "SELECT email FROM customer_entity"))
+	assert.Len(t, fakeQueryStrings(t, dbc.DB, "SELECT id FROM tx_table"), 0)
+}
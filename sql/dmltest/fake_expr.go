@@ -0,0 +1,735 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmltest
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL error numbers returned by the FakeDB. They equal the numbers of a real
+// server so that error handling code can be tested.
+const (
+	fakeErrDupEntry        = 1062
+	fakeErrBadNull         = 1048
+	fakeErrNoDefault       = 1364
+	fakeErrTableExists     = 1050
+	fakeErrBadTable        = 1051
+	fakeErrBadField        = 1054
+	fakeErrNoSuchTable     = 1146
+	fakeErrParse           = 1064
+	fakeErrValueCount      = 1136
+	fakeErrInvalidGroupUse = 1111
+	fakeErrNoTablesUsed    = 1096
+	fakeErrTruncatedValue  = 1366
+)
+
+func fakeMySQLError(number uint16, format string, args ...interface{}) error {
+	return &mysql.MySQLError{Number: number, Message: fmt.Sprintf(format, args...)}
+}
+
+func fakeSyntaxError(q string, pos int) error {
+	near := q[pos:]
+	if len(near) > 80 {
+		near = near[:80]
+	}
+	return fakeMySQLError(fakeErrParse, "You have an error in your SQL syntax; check the manual that corresponds to your MySQL server version for the right syntax to use near '%s'", near)
+}
+
+// fakeEnv provides the context to evaluate an expression.
+type fakeEnv struct {
+	tbl   *fakeTable
+	alias string
+	row   []interface{}
+	// group contains all rows of the current group when aggregate functions
+	// are allowed.
+	group   [][]interface{}
+	grouped bool
+	// output contains the values of the select expressions, accessible by
+	// their lower case name in HAVING and ORDER BY.
+	output map[string]interface{}
+	// values contains the new row for the VALUES() function in ON DUPLICATE
+	// KEY UPDATE.
+	values       []interface{}
+	now          time.Time
+	lastInsertID int64
+	// undo records the changed rows if the statement runs in a transaction.
+	undo *fakeUndoLog
+}
+
+// column resolves a column reference to its index in the current table.
+func (env *fakeEnv) column(qualifier, name string) (int, error) {
+	if env.tbl != nil && (qualifier == "" || strings.EqualFold(qualifier, env.alias) || strings.EqualFold(qualifier, env.tbl.name)) {
+		if i := env.tbl.colIndex(name); i >= 0 {
+			return i, nil
+		}
+	}
+	if qualifier != "" {
+		name = qualifier + "." + name
+	}
+	return -1, fakeMySQLError(fakeErrBadField, "Unknown column '%s' in 'field list'", name)
+}
+
+type fakeExpr interface {
+	eval(env *fakeEnv) (interface{}, error)
+}
+
+type fakeLiteral struct {
+	v interface{}
+}
+
+func (e *fakeLiteral) eval(*fakeEnv) (interface{}, error) { return fakeNormalize(e.v), nil }
+
+// fakeDefault represents the keyword DEFAULT in INSERT and UPDATE statements.
+type fakeDefault struct{}
+
+func (fakeDefault) eval(*fakeEnv) (interface{}, error) {
+	return nil, fakeMySQLError(fakeErrParse, "DEFAULT is only allowed as a column value")
+}
+
+type fakeColumnRef struct {
+	qualifier string
+	name      string
+}
+
+func (e *fakeColumnRef) eval(env *fakeEnv) (interface{}, error) {
+	if env.output != nil && e.qualifier == "" {
+		if v, ok := env.output[strings.ToLower(e.name)]; ok {
+			return v, nil
+		}
+	}
+	i, err := env.column(e.qualifier, e.name)
+	if err != nil {
+		return nil, err
+	}
+	if env.row == nil { // aggregate over an empty set
+		return nil, nil
+	}
+	return env.row[i], nil
+}
+
+type fakeUnary struct {
+	op string
+	x  fakeExpr
+}
+
+func (e *fakeUnary) eval(env *fakeEnv) (interface{}, error) {
+	v, err := e.x.eval(env)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return fakeBool(!fakeTruth(v)), nil
+}
+
+type fakeBinary struct {
+	op   string
+	l, r fakeExpr
+}
+
+func (e *fakeBinary) eval(env *fakeEnv) (interface{}, error) {
+	l, err := e.l.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch e.op {
+	case "AND":
+		if l != nil && !fakeTruth(l) {
+			return fakeBool(false), nil
+		}
+	case "OR":
+		if l != nil && fakeTruth(l) {
+			return fakeBool(true), nil
+		}
+	}
+	r, err := e.r.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "AND":
+		switch {
+		case r != nil && !fakeTruth(r):
+			return fakeBool(false), nil
+		case l == nil || r == nil:
+			return nil, nil
+		}
+		return fakeBool(true), nil
+	case "OR":
+		switch {
+		case r != nil && fakeTruth(r):
+			return fakeBool(true), nil
+		case l == nil || r == nil:
+			return nil, nil
+		}
+		return fakeBool(false), nil
+	case "<=>":
+		if l == nil || r == nil {
+			return fakeBool(l == nil && r == nil), nil
+		}
+		return fakeBool(fakeCompare(l, r) == 0), nil
+	}
+
+	if l == nil || r == nil {
+		return nil, nil
+	}
+	switch e.op {
+	case "=":
+		return fakeBool(fakeCompare(l, r) == 0), nil
+	case "!=":
+		return fakeBool(fakeCompare(l, r) != 0), nil
+	case "<":
+		return fakeBool(fakeCompare(l, r) < 0), nil
+	case "<=":
+		return fakeBool(fakeCompare(l, r) <= 0), nil
+	case ">":
+		return fakeBool(fakeCompare(l, r) > 0), nil
+	case ">=":
+		return fakeBool(fakeCompare(l, r) >= 0), nil
+	case "LIKE":
+		return fakeBool(fakeLike(fakeToString(l), fakeToString(r))), nil
+	}
+	return fakeArithmetic(e.op, l, r), nil
+}
+
+type fakeIsNull struct {
+	x   fakeExpr
+	not bool
+}
+
+func (e *fakeIsNull) eval(env *fakeEnv) (interface{}, error) {
+	v, err := e.x.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	return fakeBool((v == nil) != e.not), nil
+}
+
+type fakeIn struct {
+	x    fakeExpr
+	list []fakeExpr
+	not  bool
+}
+
+func (e *fakeIn) eval(env *fakeEnv) (interface{}, error) {
+	v, err := e.x.eval(env)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	var hasNull bool
+	for _, le := range e.list {
+		lv, err := le.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		if lv == nil {
+			hasNull = true
+			continue
+		}
+		if fakeCompare(v, lv) == 0 {
+			return fakeBool(!e.not), nil
+		}
+	}
+	if hasNull {
+		return nil, nil
+	}
+	return fakeBool(e.not), nil
+}
+
+type fakeBetween struct {
+	x, lo, hi fakeExpr
+	not       bool
+}
+
+func (e *fakeBetween) eval(env *fakeEnv) (interface{}, error) {
+	var vals [3]interface{}
+	for i, x := range [...]fakeExpr{e.x, e.lo, e.hi} {
+		v, err := x.eval(env)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		vals[i] = v
+	}
+	in := fakeCompare(vals[0], vals[1]) >= 0 && fakeCompare(vals[0], vals[2]) <= 0
+	return fakeBool(in != e.not), nil
+}
+
+type fakeFunc struct {
+	name     string
+	args     []fakeExpr
+	star     bool
+	distinct bool
+}
+
+func (e *fakeFunc) eval(env *fakeEnv) (interface{}, error) {
+	if fakeFuncs[e.name] {
+		return e.aggregate(env)
+	}
+
+	switch e.name {
+	case "NOW", "CURRENT_TIMESTAMP", "LOCALTIMESTAMP", "LOCALTIME":
+		return env.now, nil
+	case "UTC_TIMESTAMP":
+		return env.now.UTC(), nil
+	case "CURRENT_DATE", "CURDATE":
+		y, m, d := env.now.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, env.now.Location()), nil
+	case "LAST_INSERT_ID":
+		return env.lastInsertID, nil
+	case "VALUES":
+		cr, ok := e.arg(0).(*fakeColumnRef)
+		if !ok || len(e.args) != 1 {
+			return nil, fakeMySQLError(fakeErrParse, "VALUES() requires exactly one column")
+		}
+		i, err := env.column(cr.qualifier, cr.name)
+		if err != nil || env.values == nil {
+			return nil, err
+		}
+		return env.values[i], nil
+	}
+
+	args := make([]interface{}, len(e.args))
+	for i, a := range e.args {
+		v, err := a.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	switch e.name {
+	case "IFNULL", "COALESCE":
+		for _, a := range args {
+			if a != nil {
+				return a, nil
+			}
+		}
+		return nil, nil
+	case "CONCAT":
+		var buf strings.Builder
+		for _, a := range args {
+			if a == nil {
+				return nil, nil
+			}
+			buf.WriteString(fakeToString(a))
+		}
+		return buf.String(), nil
+	}
+
+	if len(args) != 1 {
+		return nil, fakeMySQLError(fakeErrParse, "Incorrect parameter count in the call to native function '%s'", e.name)
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+	s := fakeToString(args[0])
+	switch e.name {
+	case "LOWER":
+		return strings.ToLower(s), nil
+	case "UPPER":
+		return strings.ToUpper(s), nil
+	case "LENGTH":
+		return int64(len(s)), nil
+	default: // CHAR_LENGTH
+		return int64(utf8.RuneCountInString(s)), nil
+	}
+}
+
+func (e *fakeFunc) arg(i int) fakeExpr {
+	if i < len(e.args) {
+		return e.args[i]
+	}
+	return nil
+}
+
+func (e *fakeFunc) aggregate(env *fakeEnv) (interface{}, error) {
+	if !env.grouped {
+		return nil, fakeMySQLError(fakeErrInvalidGroupUse, "Invalid use of group function")
+	}
+	if e.star {
+		return int64(len(env.group)), nil
+	}
+	if len(e.args) != 1 {
+		return nil, fakeMySQLError(fakeErrParse, "Incorrect parameter count in the call to native function '%s'", e.name)
+	}
+
+	sub := *env
+	sub.grouped = false
+	sub.group = nil
+	var seen map[string]bool
+	if e.distinct {
+		seen = map[string]bool{}
+	}
+	var (
+		count  int64
+		result interface{}
+		sum    float64
+		allInt = true
+		intSum int64
+	)
+	for _, row := range env.group {
+		sub.row = row
+		v, err := e.args[0].eval(&sub)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			continue
+		}
+		if seen != nil {
+			k := fakeKey(v)
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+		}
+		count++
+		switch e.name {
+		case "MIN":
+			if result == nil || fakeCompare(v, result) < 0 {
+				result = v
+			}
+		case "MAX":
+			if result == nil || fakeCompare(v, result) > 0 {
+				result = v
+			}
+		case "SUM", "AVG":
+			if i, ok := v.(int64); ok {
+				intSum += i
+			} else {
+				allInt = false
+			}
+			sum += fakeToFloat(v)
+		}
+	}
+
+	switch e.name {
+	case "COUNT":
+		return count, nil
+	case "MIN", "MAX":
+		return result, nil
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	if e.name == "AVG" {
+		return sum / float64(count), nil
+	}
+	if allInt {
+		return intSum, nil
+	}
+	return sum, nil
+}
+
+// fakeHasAggregate reports whether an expression contains an aggregate
+// function.
+func fakeHasAggregate(e fakeExpr) bool {
+	switch e := e.(type) {
+	case *fakeFunc:
+		if fakeFuncs[e.name] {
+			return true
+		}
+		for _, a := range e.args {
+			if fakeHasAggregate(a) {
+				return true
+			}
+		}
+	case *fakeUnary:
+		return fakeHasAggregate(e.x)
+	case *fakeBinary:
+		return fakeHasAggregate(e.l) || fakeHasAggregate(e.r)
+	case *fakeIsNull:
+		return fakeHasAggregate(e.x)
+	case *fakeBetween:
+		return fakeHasAggregate(e.x) || fakeHasAggregate(e.lo) || fakeHasAggregate(e.hi)
+	case *fakeIn:
+		if fakeHasAggregate(e.x) {
+			return true
+		}
+		for _, a := range e.list {
+			if fakeHasAggregate(a) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fakeNormalize converts a value into one of the internal types: nil, int64,
+// float64, string or time.Time.
+func fakeNormalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, int64, float64, string, time.Time:
+		return v
+	case []byte:
+		return string(v)
+	case bool:
+		return fakeBool(v)
+	case int:
+		return int64(v)
+	case uint64:
+		if v <= math.MaxInt64 {
+			return int64(v)
+		}
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return fmt.Sprint(v)
+}
+
+func fakeBool(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func fakeTruth(v interface{}) bool {
+	switch v := v.(type) {
+	case int64:
+		return v != 0
+	case time.Time:
+		return !v.IsZero()
+	}
+	return fakeToFloat(v) != 0
+}
+
+// fakeNumericPrefix returns the leading number of a string like MySQL does
+// when it converts a string into a number.
+func fakeNumericPrefix(s string) string {
+	s = strings.TrimSpace(s)
+	i := 0
+	if i < len(s) && (s[i] == '-' || s[i] == '+') {
+		i++
+	}
+	for i < len(s) && (isFakeDigit(s[i]) || s[i] == '.') {
+		i++
+	}
+	if i+1 < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if s[j] == '-' || s[j] == '+' {
+			j++
+		}
+		if j < len(s) && isFakeDigit(s[j]) {
+			for i = j; i < len(s) && isFakeDigit(s[i]); i++ {
+			}
+		}
+	}
+	return s[:i]
+}
+
+func fakeToFloat(v interface{}) float64 {
+	switch v := v.(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	case string:
+		f, _ := strconv.ParseFloat(fakeNumericPrefix(v), 64)
+		return f
+	case time.Time:
+		f, _ := strconv.ParseFloat(v.Format("20060102150405"), 64)
+		return f
+	}
+	return 0
+}
+
+// fakeToInt converts a value into an integer and reports whether the
+// conversion has been lossless.
+func fakeToInt(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return v, true
+	case string:
+		s := strings.TrimSpace(v)
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, true
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		return int64(math.Round(f)), err == nil
+	}
+	return int64(math.Round(fakeToFloat(v))), false
+}
+
+const fakeTimeLayout = "2006-01-02 15:04:05"
+
+func fakeToString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		if v.Nanosecond() > 0 {
+			return v.Format(fakeTimeLayout + ".999999")
+		}
+		return v.Format(fakeTimeLayout)
+	}
+	return fmt.Sprint(v)
+}
+
+var fakeTimeLayouts = [...]string{fakeTimeLayout, "2006-01-02 15:04:05.999999999", "2006-01-02", time.RFC3339Nano}
+
+func fakeParseTime(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "0000-00-00") {
+		return time.Time{}, true
+	}
+	for _, l := range fakeTimeLayouts {
+		if t, err := time.ParseInLocation(l, s, time.UTC); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// fakeCompare compares two non-NULL values. Numbers get compared numerically
+// and strings case insensitive, like the default collation of MySQL.
+func fakeCompare(a, b interface{}) int {
+	ta, aIsTime := a.(time.Time)
+	tb, bIsTime := b.(time.Time)
+	if aIsTime || bIsTime {
+		if !aIsTime {
+			ta, aIsTime = fakeParseTime(fakeToString(a))
+		}
+		if !bIsTime {
+			tb, bIsTime = fakeParseTime(fakeToString(b))
+		}
+		if aIsTime && bIsTime {
+			switch {
+			case ta.Before(tb):
+				return -1
+			case ta.After(tb):
+				return 1
+			}
+			return 0
+		}
+		return strings.Compare(fakeToString(a), fakeToString(b))
+	}
+
+	_, aIsStr := a.(string)
+	_, bIsStr := b.(string)
+	if aIsStr && bIsStr {
+		return strings.Compare(strings.ToLower(a.(string)), strings.ToLower(b.(string)))
+	}
+	ia, aIsInt := a.(int64)
+	ib, bIsInt := b.(int64)
+	if aIsInt && bIsInt {
+		switch {
+		case ia < ib:
+			return -1
+		case ia > ib:
+			return 1
+		}
+		return 0
+	}
+	fa, fb := fakeToFloat(a), fakeToFloat(b)
+	switch {
+	case fa < fb:
+		return -1
+	case fa > fb:
+		return 1
+	}
+	return 0
+}
+
+// fakeKey returns a string which identifies a value in GROUP BY, DISTINCT
+// and unique keys.
+func fakeKey(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "\x00NULL"
+	case int64, float64:
+		return strconv.FormatFloat(fakeToFloat(v), 'g', -1, 64)
+	case string:
+		return strings.ToLower(v)
+	}
+	return fakeToString(v)
+}
+
+func fakeArithmetic(op string, l, r interface{}) interface{} {
+	il, lok := fakeToInt(l)
+	ir, rok := fakeToInt(r)
+	if _, isFloat := l.(float64); isFloat {
+		lok = false
+	}
+	if _, isFloat := r.(float64); isFloat {
+		rok = false
+	}
+	if lok && rok {
+		switch op {
+		case "+":
+			return il + ir
+		case "-":
+			return il - ir
+		case "*":
+			return il * ir
+		case "%":
+			if ir == 0 {
+				return nil
+			}
+			return il % ir
+		}
+	}
+	fl, fr := fakeToFloat(l), fakeToFloat(r)
+	switch op {
+	case "+":
+		return fl + fr
+	case "-":
+		return fl - fr
+	case "*":
+		return fl * fr
+	case "%":
+		if fr == 0 {
+			return nil
+		}
+		return math.Mod(fl, fr)
+	}
+	if fr == 0 {
+		return nil
+	}
+	return fl / fr
+}
+
+// fakeLike matches a string against a LIKE pattern, case insensitive.
+func fakeLike(s, pattern string) bool {
+	var buf strings.Builder
+	buf.WriteString(`(?is)^`)
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			buf.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case c == '%':
+			buf.WriteString(`.*`)
+		case c == '_':
+			buf.WriteByte('.')
+		default:
+			buf.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	buf.WriteByte('$')
+	re, err := regexp.Compile(buf.String())
+	return err == nil && re.MatchString(s)
+}
@@ -0,0 +1,263 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmltest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+// fakeEvalExpr parses and evaluates a single expression against a row with
+// the columns i=5, s='Hello', n=NULL and ts='2019-03-04 05:06:07'.
+func fakeEvalExpr(t *testing.T, expr string, args ...interface{}) (interface{}, error) {
+	t.Helper()
+	toks, err := fakeLex(expr)
+	assert.NoError(t, err)
+	p := &fakeParser{q: expr, toks: toks, args: args}
+	e, err := p.parseExpr()
+	assert.NoError(t, err)
+	assert.Exactly(t, fakeTokEOF, p.peek().kind, "expression not fully parsed: %q", expr)
+
+	now := time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC)
+	env := &fakeEnv{
+		tbl: &fakeTable{name: "t", columns: []*fakeColumn{
+			{name: "i", typ: "int"}, {name: "s", typ: "varchar"}, {name: "n", typ: "int"}, {name: "ts", typ: "datetime"},
+		}},
+		alias:        "m",
+		row:          []interface{}{int64(5), "Hello", nil, now},
+		now:          now,
+		lastInsertID: 42,
+	}
+	return e.eval(env)
+}
+
+func TestFakeExpr_Eval(t *testing.T) {
+	tests := []struct {
+		expr string
+		args []interface{}
+		want interface{}
+	}{
+		// arithmetic and precedence
+		{"1 + 2 * 3", nil, int64(7)},
+		{"(1 + 2) * 3", nil, int64(9)},
+		{"7 % 3", nil, int64(1)},
+		{"7 / 2", nil, 3.5},
+		{"1 / 0", nil, nil},
+		{"-i + 1", nil, int64(-4)},
+		{"i * 1.5", nil, 7.5},
+		{"'3abc' + 1", nil, 4.0},
+		{"n + 1", nil, nil},
+		// comparison
+		{"i = 5", nil, int64(1)},
+		{"i <> 5", nil, int64(0)},
+		{"s = 'hello'", nil, int64(1)}, // case insensitive collation
+		{"'10' > 9", nil, int64(1)},
+		{"ts >= '2019-03-04'", nil, int64(1)},
+		{"n = NULL", nil, nil},
+		{"n <=> NULL", nil, int64(1)},
+		{"i <=> NULL", nil, int64(0)},
+		{"n IS NULL", nil, int64(1)},
+		{"i IS NOT NULL", nil, int64(1)},
+		// logical operators with three-valued logic
+		{"NOT i = 5", nil, int64(0)},
+		{"!0", nil, int64(1)},
+		{"n AND 0", nil, int64(0)},
+		{"n AND 1", nil, nil},
+		{"n OR 1", nil, int64(1)},
+		{"n OR 0", nil, nil},
+		{"i = 4 || i = 5 && TRUE", nil, int64(1)},
+		// IN, BETWEEN, LIKE
+		{"i IN (1, 5)", nil, int64(1)},
+		{"i NOT IN (1, 5)", nil, int64(0)},
+		{"i IN (1, NULL)", nil, nil},
+		{"i BETWEEN 1 AND 5", nil, int64(1)},
+		{"i NOT BETWEEN 1 AND 5", nil, int64(0)},
+		{"s LIKE 'h_l%'", nil, int64(1)},
+		{"s NOT LIKE '%x%'", nil, int64(1)},
+		{`'50%' LIKE '50\%'`, nil, int64(1)},
+		{`'50x' LIKE '50\%'`, nil, int64(0)},
+		// column references and placeholders
+		{"m.i", nil, int64(5)},
+		{"t.`s`", nil, "Hello"},
+		{"? + i", []interface{}{uint64(1)}, int64(6)},
+		{"?", []interface{}{[]byte("raw")}, "raw"},
+		// functions
+		{"IFNULL(n, s)", nil, "Hello"},
+		{"COALESCE(n, NULL, 3)", nil, int64(3)},
+		{"CONCAT(s, '-', i)", nil, "Hello-5"},
+		{"CONCAT(s, n)", nil, nil},
+		{"LOWER(s)", nil, "hello"},
+		{"UPPER(s)", nil, "HELLO"},
+		{"LENGTH('ü')", nil, int64(2)},
+		{"CHAR_LENGTH('ü')", nil, int64(1)},
+		{"LAST_INSERT_ID()", nil, int64(42)},
+		{"NOW()", nil, time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC)},
+		{"CURRENT_DATE", nil, time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"x'41' = 'a'", nil, int64(1)},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			have, err := fakeEvalExpr(t, test.expr, test.args...)
+			assert.NoError(t, err)
+			assert.Exactly(t, test.want, have)
+		})
+	}
+}
+
+func TestFakeExpr_Eval_Error(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantNum uint16
+	}{
+		{"unknown + 1", fakeErrBadField},
+		{"x.i", fakeErrBadField},
+		{"LOWER(s, s)", fakeErrParse},
+		{"VALUES(1)", fakeErrParse},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			have, err := fakeEvalExpr(t, test.expr)
+			assert.Nil(t, have)
+			assertFakeMySQLError(t, test.wantNum, err)
+		})
+	}
+}
+
+func TestFakeCompare(t *testing.T) {
+	ts := time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC)
+	tests := []struct {
+		a, b interface{}
+		want int
+	}{
+		{int64(1), int64(2), -1},
+		{int64(2), 1.5, 1},
+		{"abc", "ABC", 0},
+		{"b", "a", 1},
+		{"10", "9", -1}, // strings compare as strings
+		{"10", int64(9), 1},
+		{ts, "2019-03-04 05:06:07", 0},
+		{ts, "2020-01-01", -1},
+		{ts, "not a date", -1},
+	}
+	for _, test := range tests {
+		assert.Exactly(t, test.want, fakeCompare(test.a, test.b), "%#v %#v", test.a, test.b)
+	}
+}
+
+func TestFakeConversions(t *testing.T) {
+	t.Run("fakeNormalize", func(t *testing.T) {
+		tests := []struct {
+			in, want interface{}
+		}{
+			{nil, nil},
+			{[]byte("a"), "a"},
+			{true, int64(1)},
+			{3, int64(3)},
+			{uint64(math.MaxUint64), float64(math.MaxUint64)},
+			{float32(0.5), 0.5},
+			{int32(7), "7"},
+		}
+		for _, test := range tests {
+			assert.Exactly(t, test.want, fakeNormalize(test.in), "%#v", test.in)
+		}
+	})
+
+	t.Run("fakeToInt", func(t *testing.T) {
+		tests := []struct {
+			in       interface{}
+			want     int64
+			lossless bool
+		}{
+			{int64(3), 3, true},
+			{" 12 ", 12, true},
+			{"1.6", 2, true},
+			{"abc", 0, false},
+			{2.4, 2, false},
+		}
+		for _, test := range tests {
+			have, ok := fakeToInt(test.in)
+			assert.Exactly(t, test.want, have, "%#v", test.in)
+			assert.Exactly(t, test.lossless, ok, "%#v", test.in)
+		}
+	})
+
+	t.Run("fakeNumericPrefix", func(t *testing.T) {
+		tests := []struct {
+			in, want string
+		}{
+			{"12abc", "12"},
+			{" -1.5e3x", "-1.5e3"},
+			{"1e", "1"},
+			{"abc", ""},
+		}
+		for _, test := range tests {
+			assert.Exactly(t, test.want, fakeNumericPrefix(test.in), "%q", test.in)
+		}
+	})
+
+	t.Run("fakeToString", func(t *testing.T) {
+		tests := []struct {
+			in   interface{}
+			want string
+		}{
+			{int64(-3), "-3"},
+			{0.25, "0.25"},
+			{time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC), "2019-03-04 05:06:07"},
+			{time.Date(2019, 3, 4, 5, 6, 7, 500000000, time.UTC), "2019-03-04 05:06:07.5"},
+		}
+		for _, test := range tests {
+			assert.Exactly(t, test.want, fakeToString(test.in), "%#v", test.in)
+		}
+	})
+
+	t.Run("fakeParseTime", func(t *testing.T) {
+		tests := []struct {
+			in   string
+			want time.Time
+			ok   bool
+		}{
+			{"2019-03-04", time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC), true},
+			{"2019-03-04 05:06:07.123", time.Date(2019, 3, 4, 5, 6, 7, 123000000, time.UTC), true},
+			{"0000-00-00 00:00:00", time.Time{}, true},
+			{"2019-13-01", time.Time{}, false},
+		}
+		for _, test := range tests {
+			have, ok := fakeParseTime(test.in)
+			assert.Exactly(t, test.ok, ok, "%q", test.in)
+			assert.True(t, test.want.Equal(have), "%q: %s", test.in, have)
+		}
+	})
+}
+
+func TestFakeLike(t *testing.T) {
+	tests := []struct {
+		s, pattern string
+		want       bool
+	}{
+		{"Hello", "h%", true},
+		{"Hello", "_ello", true},
+		{"Hello", "hell", false},
+		{"a.c", "a.c", true},
+		{"abc", "a.c", false},
+		{"50%", `50\%`, true},
+		{"line\nbreak", "line%", true},
+	}
+	for _, test := range tests {
+		assert.Exactly(t, test.want, fakeLike(test.s, test.pattern), "%q LIKE %q", test.s, test.pattern)
+	}
+}
@@ -0,0 +1,1329 @@
+// Copyright 2015-2016, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmltest
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/corestoreio/errors"
+)
+
+type fakeTokenKind uint8
+
+const (
+	fakeTokEOF fakeTokenKind = iota
+	fakeTokIdent
+	fakeTokQuotedIdent
+	fakeTokString
+	fakeTokNumber
+	fakeTokPlaceholder
+	fakeTokPunct
+)
+
+type fakeToken struct {
+	kind       fakeTokenKind
+	val        string
+	start, end int // position in the query
+}
+
+func isFakeIdentChar(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isFakeDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isFakeHex(c byte) bool {
+	return isFakeDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// fakeLex splits a query into tokens. Comments get skipped, string and hex
+// literals get decoded. MySQL executable comments /*! ... */ get skipped too.
+func fakeLex(q string) ([]fakeToken, error) {
+	toks := make([]fakeToken, 0, len(q)/4)
+	for i := 0; i < len(q); {
+		c := q[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v':
+			i++
+
+		case c == '#' || (c == '-' && strings.HasPrefix(q[i:], "--") && (i+2 == len(q) || strings.IndexByte(" \t\r\n", q[i+2]) >= 0)):
+			for i < len(q) && q[i] != '\n' {
+				i++
+			}
+
+		case c == '/' && strings.HasPrefix(q[i:], "/*"):
+			end := strings.Index(q[i+2:], "*/")
+			if end < 0 {
+				return nil, fakeSyntaxError(q, i)
+			}
+			i += end + 4
+
+		case c == '`':
+			var buf strings.Builder
+			j := i + 1
+			for ; ; j++ {
+				if j >= len(q) {
+					return nil, fakeSyntaxError(q, i)
+				}
+				if q[j] == '`' {
+					if j+1 < len(q) && q[j+1] == '`' {
+						buf.WriteByte('`')
+						j++
+						continue
+					}
+					break
+				}
+				buf.WriteByte(q[j])
+			}
+			toks = append(toks, fakeToken{kind: fakeTokQuotedIdent, val: buf.String(), start: i, end: j + 1})
+			i = j + 1
+
+		case c == '\'' || c == '"':
+			s, j, err := fakeLexString(q, i)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, fakeToken{kind: fakeTokString, val: s, start: i, end: j})
+			i = j
+
+		case (c == 'x' || c == 'X') && i+1 < len(q) && q[i+1] == '\'':
+			j := strings.IndexByte(q[i+2:], '\'')
+			if j < 0 {
+				return nil, fakeSyntaxError(q, i)
+			}
+			b, err := hex.DecodeString(q[i+2 : i+2+j])
+			if err != nil {
+				return nil, fakeSyntaxError(q, i)
+			}
+			toks = append(toks, fakeToken{kind: fakeTokString, val: string(b), start: i, end: i + j + 3})
+			i += j + 3
+
+		case c == '0' && i+2 < len(q) && (q[i+1] == 'x' || q[i+1] == 'X') && isFakeHex(q[i+2]):
+			j := i + 2
+			for j < len(q) && isFakeHex(q[j]) {
+				j++
+			}
+			h := q[i+2 : j]
+			if len(h)%2 == 1 {
+				h = "0" + h
+			}
+			b, err := hex.DecodeString(h)
+			if err != nil {
+				return nil, fakeSyntaxError(q, i)
+			}
+			toks = append(toks, fakeToken{kind: fakeTokString, val: string(b), start: i, end: j})
+			i = j
+
+		case isFakeDigit(c) || (c == '.' && i+1 < len(q) && isFakeDigit(q[i+1])):
+			j := i
+			for j < len(q) && isFakeDigit(q[j]) {
+				j++
+			}
+			if j < len(q) && q[j] == '.' {
+				j++
+				for j < len(q) && isFakeDigit(q[j]) {
+					j++
+				}
+			}
+			if j+1 < len(q) && (q[j] == 'e' || q[j] == 'E') && (isFakeDigit(q[j+1]) || ((q[j+1] == '-' || q[j+1] == '+') && j+2 < len(q) && isFakeDigit(q[j+2]))) {
+				j += 2
+				for j < len(q) && isFakeDigit(q[j]) {
+					j++
+				}
+			}
+			toks = append(toks, fakeToken{kind: fakeTokNumber, val: q[i:j], start: i, end: j})
+			i = j
+
+		case isFakeIdentChar(c):
+			j := i
+			for j < len(q) && isFakeIdentChar(q[j]) {
+				j++
+			}
+			toks = append(toks, fakeToken{kind: fakeTokIdent, val: q[i:j], start: i, end: j})
+			i = j
+
+		case c == '?':
+			toks = append(toks, fakeToken{kind: fakeTokPlaceholder, val: "?", start: i, end: i + 1})
+			i++
+
+		default:
+			l := 1
+			for _, op := range [...]string{"<=>", "<=", ">=", "<>", "!=", "||", "&&", ":="} {
+				if strings.HasPrefix(q[i:], op) {
+					l = len(op)
+					break
+				}
+			}
+			toks = append(toks, fakeToken{kind: fakeTokPunct, val: q[i : i+l], start: i, end: i + l})
+			i += l
+		}
+	}
+	return append(toks, fakeToken{kind: fakeTokEOF, start: len(q), end: len(q)}), nil
+}
+
+// fakeLexString decodes a quoted string starting at position i and returns
+// the position after the closing quote.
+func fakeLexString(q string, i int) (string, int, error) {
+	quote := q[i]
+	var buf strings.Builder
+	for j := i + 1; j < len(q); j++ {
+		switch c := q[j]; {
+		case c == '\\' && j+1 < len(q):
+			j++
+			switch e := q[j]; e {
+			case '0':
+				buf.WriteByte(0)
+			case 'b':
+				buf.WriteByte('\b')
+			case 'n':
+				buf.WriteByte('\n')
+			case 'r':
+				buf.WriteByte('\r')
+			case 't':
+				buf.WriteByte('\t')
+			case 'Z':
+				buf.WriteByte(0x1a)
+			case '%', '_': // kept for the LIKE operator
+				buf.WriteByte('\\')
+				buf.WriteByte(e)
+			default:
+				buf.WriteByte(e)
+			}
+		case c == quote:
+			if j+1 < len(q) && q[j+1] == quote {
+				buf.WriteByte(quote)
+				j++
+				continue
+			}
+			return buf.String(), j + 1, nil
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return "", 0, fakeSyntaxError(q, i)
+}
+
+// fakeReserved contains the keywords which cannot be used as an alias without
+// the keyword AS.
+var fakeReserved = map[string]bool{
+	"WHERE": true, "ORDER": true, "LIMIT": true, "GROUP": true, "HAVING": true, "SET": true,
+	"JOIN": true, "LEFT": true, "RIGHT": true, "INNER": true, "OUTER": true, "CROSS": true,
+	"STRAIGHT_JOIN": true, "NATURAL": true, "ON": true, "USING": true, "FOR": true, "LOCK": true,
+	"UNION": true, "FROM": true, "VALUES": true, "VALUE": true, "INTO": true, "OFFSET": true,
+	"ASC": true, "DESC": true, "AND": true, "OR": true, "NOT": true, "IS": true, "IN": true,
+	"LIKE": true, "BETWEEN": true, "WINDOW": true,
+}
+
+// fakeFuncs contains the supported functions. True marks aggregate functions.
+var fakeFuncs = map[string]bool{
+	"COUNT": true, "SUM": true, "MIN": true, "MAX": true, "AVG": true,
+	"NOW": false, "CURRENT_TIMESTAMP": false, "LOCALTIMESTAMP": false, "LOCALTIME": false,
+	"CURRENT_DATE": false, "CURDATE": false, "UTC_TIMESTAMP": false,
+	"VALUES": false, "IFNULL": false, "COALESCE": false, "CONCAT": false,
+	"LOWER": false, "UPPER": false, "LENGTH": false, "CHAR_LENGTH": false,
+	"LAST_INSERT_ID": false,
+}
+
+// fakeStmt gets implemented by all parsed statements.
+type fakeStmt interface {
+	exec(db *FakeDB) (*fakeRows, fakeResult, error)
+}
+
+type fakeSelectExpr struct {
+	expr      fakeExpr
+	name      string // column name in the result set
+	alias     string
+	star      bool
+	qualifier string // of a star
+}
+
+type fakeOrder struct {
+	expr fakeExpr
+	desc bool
+}
+
+type fakeAssignment struct {
+	column string
+	expr   fakeExpr
+}
+
+type fakeSelect struct {
+	distinct bool
+	exprs    []fakeSelectExpr
+	table    string
+	alias    string
+	where    fakeExpr
+	groupBy  []fakeExpr
+	having   fakeExpr
+	orderBy  []fakeOrder
+	limit    int64 // -1 for no limit
+	offset   int64
+}
+
+type fakeInsert struct {
+	table    string
+	columns  []string
+	rows     [][]fakeExpr
+	ignore   bool
+	replace  bool
+	onDupKey []fakeAssignment
+}
+
+type fakeUpdate struct {
+	table   string
+	alias   string
+	set     []fakeAssignment
+	where   fakeExpr
+	orderBy []fakeOrder
+	limit   int64
+}
+
+type fakeDelete struct {
+	table   string
+	alias   string
+	where   fakeExpr
+	orderBy []fakeOrder
+	limit   int64
+}
+
+type fakeKeyDef struct {
+	name    string
+	columns []string
+}
+
+type fakeCreateTable struct {
+	table       string
+	ifNotExists bool
+	like        string
+	columns     []*fakeColumn
+	primary     []string
+	uniques     []fakeKeyDef
+	autoInc     int64
+}
+
+type fakeDropTable struct {
+	tables   []string
+	ifExists bool
+}
+
+type fakeTruncate struct {
+	table string
+}
+
+// fakeNoop represents statements like SET, LOCK TABLES or COMMIT which do not
+// have any effect.
+type fakeNoop struct{}
+
+type fakeParser struct {
+	q    string
+	toks []fakeToken
+	pos  int
+	args []interface{}
+	argc int
+}
+
+// fakeParse parses one or more statements separated by a semicolon.
+// Placeholders get replaced by the arguments.
+func fakeParse(q string, args []interface{}) ([]fakeStmt, error) {
+	toks, err := fakeLex(q)
+	if err != nil {
+		return nil, err
+	}
+	p := &fakeParser{q: q, toks: toks, args: args}
+	var stmts []fakeStmt
+	for {
+		for p.acceptPunct(";") {
+		}
+		if p.peek().kind == fakeTokEOF {
+			break
+		}
+		s, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, s)
+		if !p.acceptPunct(";") && p.peek().kind != fakeTokEOF {
+			return nil, p.errSyntax()
+		}
+	}
+	if p.argc != len(args) {
+		return nil, errors.NotValid.Newf("[dmltest] FakeDB: Query contains %d placeholders but got %d arguments: %q", p.argc, len(args), q)
+	}
+	return stmts, nil
+}
+
+func (p *fakeParser) peek() fakeToken { return p.toks[p.pos] }
+
+func (p *fakeParser) next() fakeToken {
+	t := p.toks[p.pos]
+	if t.kind != fakeTokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword reports whether the next tokens are the keywords kws.
+func (p *fakeParser) isKeyword(kws ...string) bool {
+	for i, kw := range kws {
+		if p.pos+i >= len(p.toks) {
+			return false
+		}
+		if t := p.toks[p.pos+i]; t.kind != fakeTokIdent || !strings.EqualFold(t.val, kw) {
+			return false
+		}
+	}
+	return true
+}
+
+// acceptKeyword consumes the keywords kws if all of them match.
+func (p *fakeParser) acceptKeyword(kws ...string) bool {
+	if !p.isKeyword(kws...) {
+		return false
+	}
+	p.pos += len(kws)
+	return true
+}
+
+func (p *fakeParser) expectKeyword(kws ...string) error {
+	if !p.acceptKeyword(kws...) {
+		return p.errSyntax()
+	}
+	return nil
+}
+
+func (p *fakeParser) isPunct(s string) bool {
+	t := p.peek()
+	return t.kind == fakeTokPunct && t.val == s
+}
+
+func (p *fakeParser) acceptPunct(s string) bool {
+	if p.isPunct(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *fakeParser) expectPunct(s string) error {
+	if !p.acceptPunct(s) {
+		return p.errSyntax()
+	}
+	return nil
+}
+
+func (p *fakeParser) errSyntax() error {
+	return fakeSyntaxError(p.q, p.peek().start)
+}
+
+func (p *fakeParser) errNotSupported(what string) error {
+	return errors.NotSupported.Newf("[dmltest] FakeDB does not support %s in query: %q", what, p.q)
+}
+
+// ident returns an identifier. A qualified name like `db`.`table` returns the
+// last part.
+func (p *fakeParser) ident() (string, error) {
+	t := p.next()
+	if t.kind != fakeTokIdent && t.kind != fakeTokQuotedIdent {
+		p.pos--
+		return "", p.errSyntax()
+	}
+	if p.isPunct(".") && p.toks[p.pos+1].kind != fakeTokPunct {
+		p.pos++
+		return p.ident()
+	}
+	return t.val, nil
+}
+
+// optionalAlias parses an alias with or without the keyword AS.
+func (p *fakeParser) optionalAlias() (string, error) {
+	if p.acceptKeyword("AS") {
+		if t := p.peek(); t.kind == fakeTokString {
+			p.next()
+			return t.val, nil
+		}
+		return p.ident()
+	}
+	switch t := p.peek(); {
+	case t.kind == fakeTokQuotedIdent, t.kind == fakeTokString:
+		p.next()
+		return t.val, nil
+	case t.kind == fakeTokIdent && !fakeReserved[strings.ToUpper(t.val)]:
+		p.next()
+		return t.val, nil
+	}
+	return "", nil
+}
+
+// skipUntilClose skips all tokens until the parenthesis, which has been opened
+// before, gets closed.
+func (p *fakeParser) skipUntilClose() error {
+	for depth := 1; depth > 0; {
+		t := p.next()
+		switch {
+		case t.kind == fakeTokEOF:
+			return p.errSyntax()
+		case t.kind == fakeTokPunct && t.val == "(":
+			depth++
+		case t.kind == fakeTokPunct && t.val == ")":
+			depth--
+		}
+	}
+	return nil
+}
+
+// skipStatement skips all tokens until the end of the current statement.
+func (p *fakeParser) skipStatement() {
+	for t := p.peek(); t.kind != fakeTokEOF && !(t.kind == fakeTokPunct && t.val == ";"); t = p.peek() {
+		p.next()
+	}
+}
+
+func (p *fakeParser) parseStatement() (fakeStmt, error) {
+	t := p.peek()
+	if t.kind != fakeTokIdent {
+		return nil, p.errNotSupported("the statement")
+	}
+	switch kw := strings.ToUpper(t.val); kw {
+	case "SELECT":
+		return p.parseSelect()
+	case "INSERT", "REPLACE":
+		return p.parseInsert()
+	case "UPDATE":
+		return p.parseUpdate()
+	case "DELETE":
+		return p.parseDelete()
+	case "CREATE":
+		return p.parseCreateTable()
+	case "DROP":
+		return p.parseDropTable()
+	case "TRUNCATE":
+		p.next()
+		p.acceptKeyword("TABLE")
+		tbl, err := p.ident()
+		return &fakeTruncate{table: tbl}, err
+	case "SET", "LOCK", "UNLOCK", "USE", "BEGIN", "START", "COMMIT", "ROLLBACK":
+		p.skipStatement()
+		return fakeNoop{}, nil
+	default:
+		return nil, p.errNotSupported(kw)
+	}
+}
+
+func (p *fakeParser) parseSelect() (_ *fakeSelect, err error) {
+	p.next() // SELECT
+	s := &fakeSelect{limit: -1}
+	for {
+		if p.acceptKeyword("DISTINCT") || p.acceptKeyword("DISTINCTROW") {
+			s.distinct = true
+		} else if !p.acceptKeyword("ALL") && !p.acceptKeyword("SQL_NO_CACHE") && !p.acceptKeyword("SQL_CACHE") &&
+			!p.acceptKeyword("HIGH_PRIORITY") && !p.acceptKeyword("STRAIGHT_JOIN") {
+			break
+		}
+	}
+	if s.exprs, err = p.parseSelectExprs(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("FROM") {
+		if s.table, s.alias, err = p.parseTableRef(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("WHERE") {
+		if s.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("GROUP", "BY") {
+		for {
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			p.acceptKeyword("ASC")
+			p.acceptKeyword("DESC")
+			s.groupBy = append(s.groupBy, e)
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+	}
+	if p.acceptKeyword("HAVING") {
+		if s.having, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if s.orderBy, err = p.parseOrderBy(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("LIMIT") {
+		first, err := p.parseLimitValue()
+		if err != nil {
+			return nil, err
+		}
+		s.limit = first
+		switch {
+		case p.acceptPunct(","):
+			s.offset = first
+			s.limit, err = p.parseLimitValue()
+		case p.acceptKeyword("OFFSET"):
+			s.offset, err = p.parseLimitValue()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case p.acceptKeyword("FOR", "UPDATE"), p.acceptKeyword("LOCK", "IN", "SHARE", "MODE"):
+	case p.isKeyword("UNION"):
+		return nil, p.errNotSupported("UNION")
+	}
+	return s, nil
+}
+
+func (p *fakeParser) parseSelectExprs() ([]fakeSelectExpr, error) {
+	var exprs []fakeSelectExpr
+	for {
+		switch t := p.peek(); {
+		case t.kind == fakeTokPunct && t.val == "*":
+			p.next()
+			exprs = append(exprs, fakeSelectExpr{star: true})
+
+		case (t.kind == fakeTokIdent || t.kind == fakeTokQuotedIdent) && p.toks[p.pos+1].val == "." &&
+			p.toks[p.pos+2].kind == fakeTokPunct && p.toks[p.pos+2].val == "*":
+			p.pos += 3
+			exprs = append(exprs, fakeSelectExpr{star: true, qualifier: t.val})
+
+		default:
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			se := fakeSelectExpr{expr: e, name: p.q[t.start:p.toks[p.pos-1].end]}
+			if cr, ok := e.(*fakeColumnRef); ok {
+				se.name = cr.name
+			}
+			if se.alias, err = p.optionalAlias(); err != nil {
+				return nil, err
+			}
+			if se.alias != "" {
+				se.name = se.alias
+			}
+			exprs = append(exprs, se)
+		}
+		if !p.acceptPunct(",") {
+			return exprs, nil
+		}
+	}
+}
+
+func (p *fakeParser) parseTableRef() (table, alias string, err error) {
+	if p.isPunct("(") {
+		return "", "", p.errNotSupported("derived tables")
+	}
+	if table, err = p.ident(); err != nil {
+		return "", "", err
+	}
+	if alias, err = p.optionalAlias(); err != nil {
+		return "", "", err
+	}
+	if p.isPunct(",") || p.isKeyword("JOIN") || p.isKeyword("LEFT") || p.isKeyword("RIGHT") ||
+		p.isKeyword("INNER") || p.isKeyword("CROSS") || p.isKeyword("STRAIGHT_JOIN") || p.isKeyword("NATURAL") {
+		return "", "", p.errNotSupported("JOIN")
+	}
+	return table, alias, nil
+}
+
+func (p *fakeParser) parseOrderBy() ([]fakeOrder, error) {
+	if !p.acceptKeyword("ORDER", "BY") {
+		return nil, nil
+	}
+	var orders []fakeOrder
+	for {
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		o := fakeOrder{expr: e}
+		if p.acceptKeyword("DESC") {
+			o.desc = true
+		} else {
+			p.acceptKeyword("ASC")
+		}
+		orders = append(orders, o)
+		if !p.acceptPunct(",") {
+			return orders, nil
+		}
+	}
+}
+
+func (p *fakeParser) parseLimitValue() (int64, error) {
+	e, err := p.parsePrimary()
+	if err != nil {
+		return 0, err
+	}
+	l, ok := e.(*fakeLiteral)
+	if !ok {
+		return 0, p.errSyntax()
+	}
+	switch v := l.v.(type) {
+	case int64:
+		return v, nil
+	case uint64: // 18446744073709551615 gets used as "no limit"
+		return -1, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	}
+	return 0, p.errSyntax()
+}
+
+// parseLimit parses the single LIMIT value of UPDATE and DELETE statements.
+func (p *fakeParser) parseLimit() (int64, error) {
+	if !p.acceptKeyword("LIMIT") {
+		return -1, nil
+	}
+	return p.parseLimitValue()
+}
+
+func (p *fakeParser) parseAssignments() ([]fakeAssignment, error) {
+	var as []fakeAssignment
+	for {
+		col, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptPunct("=") && !p.acceptPunct(":=") {
+			return nil, p.errSyntax()
+		}
+		a := fakeAssignment{column: col}
+		if p.acceptKeyword("DEFAULT") {
+			a.expr = fakeDefault{}
+		} else if a.expr, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		as = append(as, a)
+		if !p.acceptPunct(",") {
+			return as, nil
+		}
+	}
+}
+
+func (p *fakeParser) parseInsert() (_ *fakeInsert, err error) {
+	s := &fakeInsert{replace: strings.EqualFold(p.next().val, "REPLACE")}
+	p.acceptKeyword("LOW_PRIORITY")
+	p.acceptKeyword("DELAYED")
+	p.acceptKeyword("HIGH_PRIORITY")
+	s.ignore = p.acceptKeyword("IGNORE")
+	p.acceptKeyword("INTO")
+	if s.table, err = p.ident(); err != nil {
+		return nil, err
+	}
+	if p.acceptPunct("(") {
+		for !p.acceptPunct(")") {
+			col, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			s.columns = append(s.columns, col)
+			if !p.acceptPunct(",") && !p.isPunct(")") {
+				return nil, p.errSyntax()
+			}
+		}
+	}
+
+	switch {
+	case p.acceptKeyword("VALUES"), p.acceptKeyword("VALUE"):
+		for {
+			if err := p.expectPunct("("); err != nil {
+				return nil, err
+			}
+			var row []fakeExpr
+			for !p.acceptPunct(")") {
+				var e fakeExpr = fakeDefault{}
+				if !p.acceptKeyword("DEFAULT") {
+					if e, err = p.parseExpr(); err != nil {
+						return nil, err
+					}
+				}
+				row = append(row, e)
+				if !p.acceptPunct(",") && !p.isPunct(")") {
+					return nil, p.errSyntax()
+				}
+			}
+			s.rows = append(s.rows, row)
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+	case p.acceptKeyword("SET"):
+		as, err := p.parseAssignments()
+		if err != nil {
+			return nil, err
+		}
+		row := make([]fakeExpr, 0, len(as))
+		for _, a := range as {
+			s.columns = append(s.columns, a.column)
+			row = append(row, a.expr)
+		}
+		s.rows = append(s.rows, row)
+	case p.isKeyword("SELECT"), p.isPunct("("):
+		return nil, p.errNotSupported("INSERT ... SELECT")
+	default:
+		return nil, p.errSyntax()
+	}
+
+	if p.acceptKeyword("ON", "DUPLICATE", "KEY", "UPDATE") {
+		if s.onDupKey, err = p.parseAssignments(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (p *fakeParser) parseUpdate() (_ *fakeUpdate, err error) {
+	p.next() // UPDATE
+	p.acceptKeyword("LOW_PRIORITY")
+	p.acceptKeyword("IGNORE")
+	s := &fakeUpdate{}
+	if s.table, s.alias, err = p.parseTableRef(); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("SET"); err != nil {
+		return nil, err
+	}
+	if s.set, err = p.parseAssignments(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("WHERE") {
+		if s.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if s.orderBy, err = p.parseOrderBy(); err != nil {
+		return nil, err
+	}
+	s.limit, err = p.parseLimit()
+	return s, err
+}
+
+func (p *fakeParser) parseDelete() (_ *fakeDelete, err error) {
+	p.next() // DELETE
+	p.acceptKeyword("LOW_PRIORITY")
+	p.acceptKeyword("QUICK")
+	p.acceptKeyword("IGNORE")
+	if !p.acceptKeyword("FROM") {
+		return nil, p.errNotSupported("multi table DELETE")
+	}
+	s := &fakeDelete{}
+	if s.table, s.alias, err = p.parseTableRef(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("WHERE") {
+		if s.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if s.orderBy, err = p.parseOrderBy(); err != nil {
+		return nil, err
+	}
+	s.limit, err = p.parseLimit()
+	return s, err
+}
+
+func (p *fakeParser) parseKeyColumns() ([]string, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	var cols []string
+	for !p.acceptPunct(")") {
+		col, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+		if p.acceptPunct("(") { // prefix length
+			if err := p.skipUntilClose(); err != nil {
+				return nil, err
+			}
+		}
+		p.acceptKeyword("ASC")
+		p.acceptKeyword("DESC")
+		if !p.acceptPunct(",") && !p.isPunct(")") {
+			return nil, p.errSyntax()
+		}
+	}
+	return cols, nil
+}
+
+// skipDefinition skips a table definition until the next comma or the closing
+// parenthesis.
+func (p *fakeParser) skipDefinition() error {
+	for !p.isPunct(",") && !p.isPunct(")") {
+		if p.peek().kind == fakeTokEOF {
+			return p.errSyntax()
+		}
+		if p.next().val == "(" {
+			if err := p.skipUntilClose(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *fakeParser) parseCreateTable() (_ *fakeCreateTable, err error) {
+	p.next() // CREATE
+	p.acceptKeyword("TEMPORARY")
+	if !p.acceptKeyword("TABLE") {
+		return nil, p.errNotSupported("CREATE statements other than CREATE TABLE")
+	}
+	s := &fakeCreateTable{ifNotExists: p.acceptKeyword("IF", "NOT", "EXISTS"), autoInc: 1}
+	if s.table, err = p.ident(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("LIKE") {
+		s.like, err = p.ident()
+		return s, err
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+
+	for !p.acceptPunct(")") {
+		if p.acceptKeyword("CONSTRAINT") && !p.isKeyword("PRIMARY") && !p.isKeyword("UNIQUE") &&
+			!p.isKeyword("FOREIGN") && !p.isKeyword("CHECK") {
+			if _, err := p.ident(); err != nil { // constraint name
+				return nil, err
+			}
+		}
+		switch {
+		case p.acceptKeyword("PRIMARY", "KEY"):
+			if !p.isPunct("(") {
+				p.next() // index name or USING
+			}
+			if s.primary, err = p.parseKeyColumns(); err != nil {
+				return nil, err
+			}
+			err = p.skipDefinition()
+		case p.acceptKeyword("UNIQUE"):
+			_ = p.acceptKeyword("KEY") || p.acceptKeyword("INDEX")
+			kd := fakeKeyDef{}
+			if !p.isPunct("(") {
+				if kd.name, err = p.ident(); err != nil {
+					return nil, err
+				}
+			}
+			if kd.columns, err = p.parseKeyColumns(); err != nil {
+				return nil, err
+			}
+			if kd.name == "" {
+				kd.name = kd.columns[0]
+			}
+			s.uniques = append(s.uniques, kd)
+			err = p.skipDefinition()
+		case p.isKeyword("KEY"), p.isKeyword("INDEX"), p.isKeyword("FULLTEXT"), p.isKeyword("SPATIAL"),
+			p.isKeyword("FOREIGN"), p.isKeyword("CHECK"):
+			err = p.skipDefinition()
+		default:
+			err = p.parseColumnDef(s)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptPunct(",") && !p.isPunct(")") {
+			return nil, p.errSyntax()
+		}
+	}
+
+	for t := p.peek(); t.kind != fakeTokEOF && !(t.kind == fakeTokPunct && t.val == ";"); t = p.peek() {
+		if p.acceptKeyword("AUTO_INCREMENT") {
+			p.acceptPunct("=")
+			if s.autoInc, err = p.parseLimitValue(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		p.next()
+	}
+	return s, nil
+}
+
+func (p *fakeParser) parseColumnDef(s *fakeCreateTable) error {
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+	t := p.next()
+	if t.kind != fakeTokIdent {
+		return p.errSyntax()
+	}
+	c := &fakeColumn{name: name, typ: strings.ToLower(t.val)}
+	if p.acceptPunct("(") {
+		if err := p.skipUntilClose(); err != nil {
+			return err
+		}
+	}
+	for !p.isPunct(",") && !p.isPunct(")") {
+		switch {
+		case p.peek().kind == fakeTokEOF:
+			return p.errSyntax()
+		case p.acceptKeyword("UNSIGNED"):
+			c.unsigned = true
+		case p.acceptKeyword("NOT", "NULL"):
+			c.notNull = true
+		case p.acceptKeyword("AUTO_INCREMENT"):
+			c.autoIncrement = true
+		case p.acceptKeyword("PRIMARY", "KEY"), p.acceptKeyword("KEY"):
+			c.notNull = true
+			s.primary = []string{name}
+		case p.acceptKeyword("UNIQUE"):
+			p.acceptKeyword("KEY")
+			s.uniques = append(s.uniques, fakeKeyDef{name: name, columns: []string{name}})
+		case p.acceptKeyword("DEFAULT"):
+			if c.defaultExpr, err = p.parseUnary(); err != nil {
+				return err
+			}
+		case p.acceptKeyword("ON", "UPDATE"):
+			if _, err = p.parseUnary(); err != nil {
+				return err
+			}
+			c.onUpdateNow = true
+		case p.acceptKeyword("CHARACTER", "SET"), p.acceptKeyword("CHARSET"), p.acceptKeyword("COLLATE"),
+			p.acceptKeyword("COMMENT"):
+			p.next()
+		case p.acceptPunct("("):
+			if err := p.skipUntilClose(); err != nil {
+				return err
+			}
+		default:
+			p.next()
+		}
+	}
+	s.columns = append(s.columns, c)
+	return nil
+}
+
+func (p *fakeParser) parseDropTable() (*fakeDropTable, error) {
+	p.next() // DROP
+	p.acceptKeyword("TEMPORARY")
+	if !p.acceptKeyword("TABLE") && !p.acceptKeyword("TABLES") {
+		return nil, p.errNotSupported("DROP statements other than DROP TABLE")
+	}
+	s := &fakeDropTable{ifExists: p.acceptKeyword("IF", "EXISTS")}
+	for {
+		tbl, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		s.tables = append(s.tables, tbl)
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+	p.skipStatement() // RESTRICT or CASCADE
+	return s, nil
+}
+
+// The expression parser follows the operator precedence of MySQL: OR, AND,
+// NOT, comparison, addition, multiplication, unary minus.
+
+func (p *fakeParser) parseExpr() (fakeExpr, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("OR") || p.acceptPunct("||") {
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = &fakeBinary{op: "OR", l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *fakeParser) parseAnd() (fakeExpr, error) {
+	l, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("AND") || p.acceptPunct("&&") {
+		r, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l = &fakeBinary{op: "AND", l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *fakeParser) parseNot() (fakeExpr, error) {
+	if p.acceptKeyword("NOT") {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &fakeUnary{op: "NOT", x: x}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *fakeParser) parseComparison() (fakeExpr, error) {
+	l, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if t := p.peek(); t.kind == fakeTokPunct {
+			switch t.val {
+			case "=", "!=", "<>", "<", "<=", ">", ">=", "<=>":
+				p.next()
+				r, err := p.parseAdditive()
+				if err != nil {
+					return nil, err
+				}
+				op := t.val
+				if op == "<>" {
+					op = "!="
+				}
+				l = &fakeBinary{op: op, l: l, r: r}
+				continue
+			}
+			return l, nil
+		}
+
+		if p.acceptKeyword("IS") {
+			not := p.acceptKeyword("NOT")
+			if err := p.expectKeyword("NULL"); err != nil {
+				return nil, err
+			}
+			l = &fakeIsNull{x: l, not: not}
+			continue
+		}
+
+		not := p.isKeyword("NOT", "IN") || p.isKeyword("NOT", "LIKE") || p.isKeyword("NOT", "BETWEEN")
+		if not {
+			p.next()
+		}
+		switch {
+		case p.acceptKeyword("IN"):
+			if err := p.expectPunct("("); err != nil {
+				return nil, err
+			}
+			if p.isKeyword("SELECT") {
+				return nil, p.errNotSupported("sub queries")
+			}
+			in := &fakeIn{x: l, not: not}
+			for !p.acceptPunct(")") {
+				e, err := p.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				in.list = append(in.list, e)
+				if !p.acceptPunct(",") && !p.isPunct(")") {
+					return nil, p.errSyntax()
+				}
+			}
+			l = in
+		case p.acceptKeyword("LIKE"):
+			r, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			l = &fakeBinary{op: "LIKE", l: l, r: r}
+			if not {
+				l = &fakeUnary{op: "NOT", x: l}
+			}
+		case p.acceptKeyword("BETWEEN"):
+			lo, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectKeyword("AND"); err != nil {
+				return nil, err
+			}
+			hi, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			l = &fakeBetween{x: l, lo: lo, hi: hi, not: not}
+		default:
+			return l, nil
+		}
+	}
+}
+
+func (p *fakeParser) parseAdditive() (fakeExpr, error) {
+	l, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("+") || p.isPunct("-") {
+		op := p.next().val
+		r, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		l = &fakeBinary{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *fakeParser) parseMultiplicative() (fakeExpr, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("*") || p.isPunct("/") || p.isPunct("%") {
+		op := p.next().val
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = &fakeBinary{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *fakeParser) parseUnary() (fakeExpr, error) {
+	switch {
+	case p.acceptPunct("-"):
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if l, ok := x.(*fakeLiteral); ok {
+			switch v := l.v.(type) {
+			case int64:
+				return &fakeLiteral{v: -v}, nil
+			case float64:
+				return &fakeLiteral{v: -v}, nil
+			}
+		}
+		return &fakeBinary{op: "-", l: &fakeLiteral{v: int64(0)}, r: x}, nil
+	case p.acceptPunct("+"):
+		return p.parseUnary()
+	case p.acceptPunct("!"):
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &fakeUnary{op: "NOT", x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *fakeParser) parsePrimary() (fakeExpr, error) {
+	t := p.next()
+	switch t.kind {
+	case fakeTokPlaceholder:
+		if p.argc >= len(p.args) {
+			return nil, errors.NotValid.Newf("[dmltest] FakeDB: Query contains more placeholders than the %d arguments: %q", len(p.args), p.q)
+		}
+		p.argc++
+		return &fakeLiteral{v: p.args[p.argc-1]}, nil
+
+	case fakeTokString:
+		return &fakeLiteral{v: t.val}, nil
+
+	case fakeTokNumber:
+		if i, err := strconv.ParseInt(t.val, 10, 64); err == nil {
+			return &fakeLiteral{v: i}, nil
+		}
+		if u, err := strconv.ParseUint(t.val, 10, 64); err == nil {
+			return &fakeLiteral{v: u}, nil
+		}
+		f, err := strconv.ParseFloat(t.val, 64)
+		if err != nil {
+			return nil, fakeSyntaxError(p.q, t.start)
+		}
+		return &fakeLiteral{v: f}, nil
+
+	case fakeTokPunct:
+		if t.val != "(" {
+			break
+		}
+		if p.isKeyword("SELECT") {
+			return nil, p.errNotSupported("sub queries")
+		}
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.isPunct(",") {
+			return nil, p.errNotSupported("row constructors")
+		}
+		return e, p.expectPunct(")")
+
+	case fakeTokIdent, fakeTokQuotedIdent:
+		name := strings.ToUpper(t.val)
+		if t.kind == fakeTokIdent {
+			switch name {
+			case "NULL":
+				return &fakeLiteral{}, nil
+			case "TRUE":
+				return &fakeLiteral{v: int64(1)}, nil
+			case "FALSE":
+				return &fakeLiteral{v: int64(0)}, nil
+			case "CURRENT_TIMESTAMP", "LOCALTIMESTAMP", "LOCALTIME", "CURRENT_DATE":
+				if p.acceptPunct("(") {
+					if err := p.skipUntilClose(); err != nil {
+						return nil, err
+					}
+				}
+				return &fakeFunc{name: name}, nil
+			}
+			if p.isPunct("(") {
+				return p.parseFunc(name)
+			}
+		}
+		cr := &fakeColumnRef{name: t.val}
+		for p.isPunct(".") {
+			p.next()
+			n := p.next()
+			if n.kind != fakeTokIdent && n.kind != fakeTokQuotedIdent {
+				return nil, fakeSyntaxError(p.q, n.start)
+			}
+			cr.qualifier, cr.name = cr.name, n.val
+		}
+		return cr, nil
+	}
+	return nil, fakeSyntaxError(p.q, t.start)
+}
+
+func (p *fakeParser) parseFunc(name string) (fakeExpr, error) {
+	if _, ok := fakeFuncs[name]; !ok {
+		return nil, p.errNotSupported("function " + name)
+	}
+	p.next() // (
+	f := &fakeFunc{name: name}
+	if name == "COUNT" && p.acceptPunct("*") {
+		f.star = true
+		return f, p.expectPunct(")")
+	}
+	f.distinct = p.acceptKeyword("DISTINCT")
+	for !p.acceptPunct(")") {
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		f.args = append(f.args, e)
+		if !p.acceptPunct(",") && !p.isPunct(")") {
+			return nil, p.errSyntax()
+		}
+	}
+	return f, nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dmltest
+
+import (
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/go-sql-driver/mysql"
+)
+
+func assertFakeMySQLError(t *testing.T, number uint16, err error) {
+	t.Helper()
+	myErr, ok := errors.Cause(err).(*mysql.MySQLError)
+	assert.True(t, ok, "%+v", err)
+	if ok {
+		assert.Exactly(t, number, myErr.Number, "%+v", err)
+	}
+}
+
+func TestFakeLex(t *testing.T) {
+	type tok struct {
+		kind fakeTokenKind
+		val  string
+	}
+	tests := []struct {
+		query string
+		want  []tok
+	}{
+		{"SELECT a, `b``c` FROM t", []tok{
+			{fakeTokIdent, "SELECT"}, {fakeTokIdent, "a"}, {fakeTokPunct, ","},
+			{fakeTokQuotedIdent, "b`c"}, {fakeTokIdent, "FROM"}, {fakeTokIdent, "t"},
+		}},
+		{`'it''s' "a\nb" 'x\%y'`, []tok{
+			{fakeTokString, "it's"}, {fakeTokString, "a\nb"}, {fakeTokString, `x\%y`},
+		}},
+		{"x'4142' 0x434 12 3.5 1e3 .5", []tok{
+			{fakeTokString, "AB"}, {fakeTokString, "\x04\x34"}, {fakeTokNumber, "12"},
+			{fakeTokNumber, "3.5"}, {fakeTokNumber, "1e3"}, {fakeTokNumber, ".5"},
+		}},
+		{"a<=>b <= >= <> != || && := ?", []tok{
+			{fakeTokIdent, "a"}, {fakeTokPunct, "<=>"}, {fakeTokIdent, "b"}, {fakeTokPunct, "<="},
+			{fakeTokPunct, ">="}, {fakeTokPunct, "<>"}, {fakeTokPunct, "!="}, {fakeTokPunct, "||"},
+			{fakeTokPunct, "&&"}, {fakeTokPunct, ":="}, {fakeTokPlaceholder, "?"},
+		}},
+		{"a # comment\n-- comment\n/* comment */ /*!40101 SET */ b--c", []tok{
+			{fakeTokIdent, "a"}, {fakeTokIdent, "b"}, {fakeTokPunct, "-"}, {fakeTokPunct, "-"}, {fakeTokIdent, "c"},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			toks, err := fakeLex(test.query)
+			assert.NoError(t, err)
+			assert.Exactly(t, fakeTokEOF, toks[len(toks)-1].kind)
+			have := make([]tok, 0, len(toks))
+			for _, tk := range toks[:len(toks)-1] {
+				have = append(have, tok{kind: tk.kind, val: tk.val})
+			}
+			assert.Exactly(t, test.want, have)
+		})
+	}
+}
+
+func TestFakeLex_Error(t *testing.T) {
+	for _, query := range []string{
+		"SELECT 'unterminated",
+		"SELECT `unterminated",
+		"SELECT /* unterminated",
+		"SELECT x'4g'",
+	} {
+		t.Run(query, func(t *testing.T) {
+			_, err := fakeLex(query)
+			assertFakeMySQLError(t, fakeErrParse, err)
+		})
+	}
+}
+
+func TestFakeParse(t *testing.T) {
+	a := &fakeColumnRef{name: "a"}
+	b := &fakeColumnRef{name: "b"}
+	lit := func(v interface{}) *fakeLiteral { return &fakeLiteral{v: v} }
+
+	tests := []struct {
+		name  string
+		query string
+		args  []interface{}
+		want  []fakeStmt
+	}{
+		{
+			"select", "SELECT DISTINCT `m`.*, a AS x, COUNT(*) FROM `db`.`t` AS `m` WHERE a = ? AND b IS NOT NULL GROUP BY a HAVING x > 1 ORDER BY a DESC, b LIMIT 5, 10 FOR UPDATE",
+			[]interface{}{int64(3)},
+			[]fakeStmt{&fakeSelect{
+				distinct: true,
+				exprs: []fakeSelectExpr{
+					{star: true, qualifier: "m"},
+					{expr: a, name: "x", alias: "x"},
+					{expr: &fakeFunc{name: "COUNT", star: true}, name: "COUNT(*)"},
+				},
+				table: "t",
+				alias: "m",
+				where: &fakeBinary{op: "AND",
+					l: &fakeBinary{op: "=", l: a, r: lit(int64(3))},
+					r: &fakeIsNull{x: b, not: true},
+				},
+				groupBy: []fakeExpr{a},
+				having:  &fakeBinary{op: ">", l: &fakeColumnRef{name: "x"}, r: lit(int64(1))},
+				orderBy: []fakeOrder{{expr: a, desc: true}, {expr: b}},
+				limit:   10,
+				offset:  5,
+			}},
+		},
+		{
+			"select without table and max limit", "SELECT 1+2*3 LIMIT 18446744073709551615", nil,
+			[]fakeStmt{&fakeSelect{
+				exprs: []fakeSelectExpr{{
+					expr: &fakeBinary{op: "+", l: lit(int64(1)), r: &fakeBinary{op: "*", l: lit(int64(2)), r: lit(int64(3))}},
+					name: "1+2*3",
+				}},
+				limit: -1,
+			}},
+		},
+		{
+			"insert", "INSERT IGNORE INTO t (a,b) VALUES (?,DEFAULT),(-1,'x') ON DUPLICATE KEY UPDATE b=VALUES(b)", []interface{}{"v"},
+			[]fakeStmt{&fakeInsert{
+				table:   "t",
+				columns: []string{"a", "b"},
+				rows:    [][]fakeExpr{{lit("v"), fakeDefault{}}, {lit(int64(-1)), lit("x")}},
+				ignore:  true,
+				onDupKey: []fakeAssignment{
+					{column: "b", expr: &fakeFunc{name: "VALUES", args: []fakeExpr{b}}},
+				},
+			}},
+		},
+		{
+			"replace set", "REPLACE t SET a=1, b=DEFAULT", nil,
+			[]fakeStmt{&fakeInsert{
+				table:   "t",
+				columns: []string{"a", "b"},
+				rows:    [][]fakeExpr{{lit(int64(1)), fakeDefault{}}},
+				replace: true,
+			}},
+		},
+		{
+			"update", "UPDATE t SET a=a+1 WHERE b IN (1,2) ORDER BY a LIMIT 3", nil,
+			[]fakeStmt{&fakeUpdate{
+				table:   "t",
+				set:     []fakeAssignment{{column: "a", expr: &fakeBinary{op: "+", l: a, r: lit(int64(1))}}},
+				where:   &fakeIn{x: b, list: []fakeExpr{lit(int64(1)), lit(int64(2))}},
+				orderBy: []fakeOrder{{expr: a}},
+				limit:   3,
+			}},
+		},
+		{
+			"delete", "DELETE FROM t WHERE NOT a BETWEEN 1 AND 2", nil,
+			[]fakeStmt{&fakeDelete{
+				table: "t",
+				where: &fakeUnary{op: "NOT", x: &fakeBetween{x: a, lo: lit(int64(1)), hi: lit(int64(2))}},
+				limit: -1,
+			}},
+		},
+		{
+			"create table", "CREATE TABLE IF NOT EXISTS `t` (\n" +
+				"`id` int(10) unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"`email` varchar(255) DEFAULT NULL COMMENT 'mail',\n" +
+				"`ts` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+				"PRIMARY KEY (`id`),\n" +
+				"UNIQUE KEY `T_EMAIL` (`email`(10)),\n" +
+				"KEY `T_TS` (`ts`),\n" +
+				"CONSTRAINT `FK` FOREIGN KEY (`id`) REFERENCES `x` (`id`) ON DELETE CASCADE\n" +
+				") ENGINE=InnoDB AUTO_INCREMENT=7", nil,
+			[]fakeStmt{&fakeCreateTable{
+				table:       "t",
+				ifNotExists: true,
+				columns: []*fakeColumn{
+					{name: "id", typ: "int", unsigned: true, notNull: true, autoIncrement: true},
+					{name: "email", typ: "varchar", defaultExpr: lit(nil)},
+					{name: "ts", typ: "timestamp", notNull: true, onUpdateNow: true, defaultExpr: &fakeFunc{name: "CURRENT_TIMESTAMP"}},
+				},
+				primary: []string{"id"},
+				uniques: []fakeKeyDef{{name: "T_EMAIL", columns: []string{"email"}}},
+				autoInc: 7,
+			}},
+		},
+		{
+			"create table like", "CREATE TABLE t2 LIKE t", nil,
+			[]fakeStmt{&fakeCreateTable{table: "t2", like: "t", autoInc: 1}},
+		},
+		{
+			"multiple statements", "SET NAMES utf8;; DROP TABLE IF EXISTS t, t2 CASCADE; TRUNCATE TABLE t3;", nil,
+			[]fakeStmt{fakeNoop{}, &fakeDropTable{tables: []string{"t", "t2"}, ifExists: true}, &fakeTruncate{table: "t3"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stmts, err := fakeParse(test.query, test.args)
+			assert.NoError(t, err)
+			assert.Exactly(t, test.want, stmts)
+		})
+	}
+}
+
+func TestFakeParse_Error(t *testing.T) {
+	tests := []struct {
+		query    string
+		args     []interface{}
+		wantKind errors.Kind
+		wantNum  uint16
+	}{
+		{"SELECT a FROM t JOIN t2", nil, errors.NotSupported, 0},
+		{"SELECT a FROM t WHERE a IN (SELECT 1)", nil, errors.NotSupported, 0},
+		{"SELECT a FROM (SELECT 1) x", nil, errors.NotSupported, 0},
+		{"SELECT a FROM t UNION SELECT b FROM t2", nil, errors.NotSupported, 0},
+		{"SELECT a FROM t WHERE (a,b) = (1,2)", nil, errors.NotSupported, 0},
+		{"SELECT SUBSTR(a,1) FROM t", nil, errors.NotSupported, 0},
+		{"INSERT INTO t SELECT * FROM t2", nil, errors.NotSupported, 0},
+		{"DELETE t FROM t", nil, errors.NotSupported, 0},
+		{"CREATE VIEW v AS SELECT 1", nil, errors.NotSupported, 0},
+		{"ALTER TABLE t ADD b int", nil, errors.NotSupported, 0},
+		{"SELECT a FROM t WHERE a = ?", nil, errors.NotValid, 0},
+		{"SELECT a FROM t", []interface{}{1}, errors.NotValid, 0},
+		{"SELECT a FROM t WHERE", nil, 0, fakeErrParse},
+		{"SELECT a FROM t LIMIT a", nil, 0, fakeErrParse},
+		{"UPDATE t a = 1", nil, 0, fakeErrParse},
+		{"INSERT INTO t (a VALUES (1)", nil, 0, fakeErrParse},
+		{"SELECT 1 SELECT 2", nil, 0, fakeErrParse},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			stmts, err := fakeParse(test.query, test.args)
+			assert.Nil(t, stmts)
+			if test.wantNum > 0 {
+				assertFakeMySQLError(t, test.wantNum, err)
+			} else {
+				assert.ErrorIsKind(t, test.wantKind, err)
+			}
+		})
+	}
+}
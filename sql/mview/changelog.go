@@ -0,0 +1,92 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview
+
+import (
+	"context"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+)
+
+// DefaultChangelogTable defines the default name of the changelog table.
+const DefaultChangelogTable = "mview_changelog"
+
+// ChangelogEntry represents a row in the changelog table. Each entry contains
+// the binlog position of a row event which could not be applied to a view.
+type ChangelogEntry struct {
+	ID             uint64
+	ViewName       string
+	BinlogFile     string
+	BinlogPosition uint64
+	Error          string
+	CreatedAt      time.Time
+}
+
+// CreateChangelogTable creates the changelog table if it does not exist. An
+// empty tableName uses DefaultChangelogTable.
+func CreateChangelogTable(ctx context.Context, db dml.Execer, tableName string) error {
+	if tableName == "" {
+		tableName = DefaultChangelogTable
+	}
+	_, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+dml.Quoter.Name(tableName)+` (
+  id bigint unsigned NOT NULL AUTO_INCREMENT,
+  view_name varchar(64) NOT NULL,
+  binlog_file varchar(255) NOT NULL DEFAULT '',
+  binlog_position bigint unsigned NOT NULL DEFAULT 0,
+  error text,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY MVIEW_CHANGELOG_VIEW_NAME (view_name)
+)`)
+	return errors.Wrapf(err, "[mview] CreateChangelogTable %q", tableName)
+}
+
+// writeChangelog records the current binlog position as missed.
+func (v *View) writeChangelog(ctx context.Context, cause error) error {
+	_, err := v.db.DB.ExecContext(ctx,
+		"INSERT INTO "+dml.Quoter.Name(v.opts.ChangelogTable)+" (`view_name`,`binlog_file`,`binlog_position`,`error`) VALUES (?,?,?,?)",
+		v.name, v.status.Position.File, uint64(v.status.Position.Position), cause.Error())
+	return errors.WithStack(err)
+}
+
+// Changelog returns the missed binlog positions of the view, ordered by their
+// occurrence.
+func (v *View) Changelog(ctx context.Context) (_ []ChangelogEntry, err error) {
+	rows, err := v.db.DB.QueryContext(ctx,
+		"SELECT `id`,`view_name`,`binlog_file`,`binlog_position`,`error`,`created_at` FROM "+
+			dml.Quoter.Name(v.opts.ChangelogTable)+" WHERE `view_name`=? ORDER BY `id`", v.name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[mview] View %q Changelog QueryContext", v.name)
+	}
+	defer func() {
+		if err2 := rows.Close(); err2 != nil && err == nil {
+			err = errors.Wrap(err2, "[mview] Changelog.Rows.Close")
+		}
+	}()
+
+	var ret []ChangelogEntry
+	for rows.Next() {
+		var ce ChangelogEntry
+		var errMsg []byte
+		if err = rows.Scan(&ce.ID, &ce.ViewName, &ce.BinlogFile, &ce.BinlogPosition, &errMsg, &ce.CreatedAt); err != nil {
+			return nil, errors.Wrapf(err, "[mview] View %q Changelog Scan", v.name)
+		}
+		ce.Error = string(errMsg)
+		ret = append(ret, ce)
+	}
+	return ret, errors.WithStack(rows.Err())
+}
@@ -14,6 +14,24 @@
 
 // Package mview adds materialized views via events on the MySQL binary log.
 //
+// A View gets defined by a dml.Select containing a GROUP BY clause and
+// aggregate functions. FullRefresh materializes the result into a table. To
+// refresh the table incrementally, register the View as a RowsEventHandler at
+// a binlogsync.Canal for all tables returned by View.Tables:
+//
+//		v, err := mview.NewView(dbc, "mv_sales_by_store", dml.NewSelect("store_id").
+//			AddColumnsConditions(
+//				dml.Expr("COUNT(*)").Alias("orders"),
+//				dml.Expr("SUM(grand_total)").Alias("revenue"),
+//			).From("sales_order").GroupBy("store_id"),
+//			mview.Options{Position: canal.SyncedPosition})
+//		for _, tn := range v.Tables() {
+//			canal.RegisterRowsEventHandler(tn, v)
+//		}
+//
+// Row events which can't be applied get recorded in a changelog table, see
+// CreateChangelogTable, and mark the view as stale until the next full
+// refresh.
 //
 // https://de.slideshare.net/MySQLGeek/flexviews-materialized-views-for-my-sql
 // https://github.com/greenlion/swanhart-tools
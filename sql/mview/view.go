@@ -0,0 +1,802 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/util/bufferpool"
+	"github.com/corestoreio/pkg/util/conv"
+)
+
+// Action constants are equal to the actions sent by package binlogsync.
+const (
+	actionUpdate = "update"
+	actionDelete = "delete"
+)
+
+// DefaultJoinRefreshInterval defines the minimum duration between two full
+// refreshes triggered by row events of joined tables.
+const DefaultJoinRefreshInterval = time.Minute
+
+// Refresh modes used by a View to apply row events of the base table.
+const (
+	// ModeDelta applies the differences of COUNT and SUM aggregates directly to
+	// the materialized table without querying the base table.
+	ModeDelta = "delta"
+	// ModeGroups recalculates only the groups affected by a row event.
+	ModeGroups = "groups"
+)
+
+// Options sets optional fields of a View.
+type Options struct {
+	Log log.Logger
+	// ChangelogTable defines the name of the table which stores the binlog
+	// positions of the events which could not be applied to a view. Defaults to
+	// DefaultChangelogTable.
+	ChangelogTable string
+	// Position returns the current position of the binary log. Should be set
+	// to function binlogsync.Canal.SyncedPosition, which returns the position
+	// of the last committed transaction before the current row event.
+	// Optional. If set, a full refresh in ModeDelta records the binlog
+	// position of its snapshot and skips all row events already contained in
+	// the snapshot.
+	Position func() ddl.MasterStatus
+	// JoinRefreshInterval defines the minimum duration between two full
+	// refreshes triggered by row events of joined tables. Row events within
+	// the interval mark the view as pending and the next row event after the
+	// interval, Refresh or Complete run the full refresh. Defaults to
+	// DefaultJoinRefreshInterval. A negative value runs a full refresh for
+	// each row event.
+	JoinRefreshInterval time.Duration
+}
+
+// Status describes the current state of a View.
+type Status struct {
+	Name string
+	// Mode either ModeDelta or ModeGroups.
+	Mode string
+	// Stale is true when a row event could not be applied and the view waits
+	// for a full refresh.
+	Stale bool
+	// RefreshPending is true when a row event of a joined table has been
+	// received and the full refresh has been delayed. See
+	// Options.JoinRefreshInterval.
+	RefreshPending bool
+	// Events counts the processed row events, including the failed ones.
+	Events uint64
+	// Errors counts the failed row events and refreshes.
+	Errors    uint64
+	LastError string
+	// LastFullRefresh contains the time of the last successful full refresh.
+	LastFullRefresh time.Time
+	// LastIncremental contains the time of the last successful applied row
+	// event.
+	LastIncremental time.Time
+	// Position contains the binlog position of the last processed row event.
+	Position ddl.MasterStatus
+}
+
+type aggregate struct {
+	function string // COUNT or SUM
+	column   string // source column, empty for COUNT(*)
+	alias    string // target column
+}
+
+// View defines a materialized view. The result of the SELECT query gets stored
+// in a table with the same name as the view. The table gets refreshed
+// incrementally from the row events of the binary log. A View implements the
+// interface binlogsync.RowsEventHandler and must be registered for every table
+// returned by function Tables. Row events of the base table, the FROM table
+// of the SELECT, get applied incrementally to the affected groups.
+//
+// Row events of joined tables trigger a full refresh, which runs the whole
+// SELECT query synchronously within the binlog syncer and blocks all further
+// events. To limit the cost, at most one full refresh per
+// Options.JoinRefreshInterval gets triggered by joined tables. Views with
+// frequently changing joined tables should call Refresh periodically, so the
+// delayed refreshes do not wait for the next row event.
+//
+// The SELECT query must contain a GROUP BY clause and all GROUP BY columns
+// must be selected without an alias. The GROUP BY columns form the primary key
+// of the materialized table. A view refreshes in ModeDelta when the query
+// contains only the GROUP BY columns and aliased COUNT(*), COUNT(column) or
+// SUM(column) aggregates, including at least one COUNT(*), and has no WHERE,
+// HAVING and JOIN clauses. In all other cases ModeGroups gets used. SUM deltas
+// are calculated as exact decimal numbers and passed as strings to the
+// database, so they do not drift against DECIMAL columns. Float values get
+// converted by their shortest decimal representation.
+type View struct {
+	opts      Options
+	db        *dml.ConnPool
+	name      string
+	baseTable string
+	// groupBy contains the column names of the GROUP BY clause.
+	groupBy []string
+	// joinTables contains the names of the joined tables.
+	joinTables []string
+	aggregates []aggregate
+	// countStar contains the alias of the COUNT(*) aggregate, only set in delta
+	// mode.
+	countStar string
+	sel       *dml.Select
+	// selectSQL contains the rendered SELECT query.
+	selectSQL string
+	// emptySelectSQL contains the SELECT query returning no rows, only set in
+	// delta mode to create the table before taking the snapshot.
+	emptySelectSQL string
+
+	mu     sync.Mutex // protects all fields below and serializes the refreshes
+	status Status
+	// snapshot contains the binlog position of the last full refresh in delta
+	// mode. Row events before that position are part of the snapshot.
+	snapshot ddl.MasterStatus
+}
+
+var reAggregate = regexp.MustCompile("(?i)^\\s*(COUNT|SUM)\\(\\s*(\\*|`?[a-z0-9_$]+`?)\\s*\\)\\s*$")
+
+// unqualify removes the table qualifier and the quotes from a column name.
+func unqualify(name string) string {
+	if pos := strings.LastIndexByte(name, '.'); pos >= 0 {
+		name = name[pos+1:]
+	}
+	return strings.Trim(name, "`")
+}
+
+// NewView creates a new materialized view. Argument name defines the name of
+// the table which stores the result of the SELECT query. The query gets
+// validated and the refresh mode gets detected. The query must not contain
+// place holders.
+func NewView(db *dml.ConnPool, name string, sel *dml.Select, opt Options) (*View, error) {
+	if db == nil || name == "" || sel == nil {
+		return nil, errors.Empty.Newf("[mview] NewView requires a connection, a name and a SELECT query")
+	}
+	if opt.Log == nil {
+		opt.Log = log.BlackHole{}
+	}
+	if opt.ChangelogTable == "" {
+		opt.ChangelogTable = DefaultChangelogTable
+	}
+	if opt.JoinRefreshInterval == 0 {
+		opt.JoinRefreshInterval = DefaultJoinRefreshInterval
+	}
+
+	v := &View{
+		opts:      opt,
+		db:        db,
+		name:      name,
+		baseTable: unqualify(sel.Table.Name),
+		sel:       sel.Clone(),
+	}
+	if v.baseTable == "" {
+		return nil, errors.NotValid.Newf("[mview] View %q: SELECT query requires a FROM table", name)
+	}
+	if len(sel.GroupBys) == 0 {
+		return nil, errors.NotValid.Newf("[mview] View %q: SELECT query requires a GROUP BY clause", name)
+	}
+	for _, j := range sel.Joins {
+		v.joinTables = append(v.joinTables, unqualify(j.Table.Name))
+	}
+
+	selected := make(map[string]bool, len(sel.Columns))
+	for _, c := range sel.Columns {
+		if c.Expression == "" && c.Aliased == "" {
+			selected[unqualify(c.Name)] = true
+		}
+	}
+	for _, g := range sel.GroupBys {
+		gn := unqualify(g.Name)
+		if g.Expression != "" || !selected[gn] {
+			return nil, errors.NotValid.Newf("[mview] View %q: GROUP BY column %q must be a selected column without alias", name, gn)
+		}
+		v.groupBy = append(v.groupBy, gn)
+	}
+
+	isDelta := len(sel.Joins) == 0 && len(sel.Wheres) == 0 && len(sel.Havings) == 0 && !sel.IsDistinct
+	for _, c := range sel.Columns {
+		if c.Expression == "" && c.Aliased == "" {
+			isDelta = isDelta && isGroupColumn(v.groupBy, unqualify(c.Name))
+			continue
+		}
+		m := reAggregate.FindStringSubmatch(c.Expression)
+		if m == nil || c.Aliased == "" {
+			isDelta = false
+			continue
+		}
+		a := aggregate{
+			function: strings.ToUpper(m[1]),
+			alias:    c.Aliased,
+		}
+		if m[2] != "*" {
+			a.column = strings.Trim(m[2], "`")
+		} else if a.function == "COUNT" && v.countStar == "" {
+			v.countStar = a.alias
+		}
+		if a.function == "SUM" && a.column == "" {
+			isDelta = false
+		}
+		v.aggregates = append(v.aggregates, a)
+	}
+
+	v.status.Name = name
+	v.status.Mode = ModeGroups
+	if isDelta && v.countStar != "" {
+		v.status.Mode = ModeDelta
+	} else {
+		v.countStar = ""
+	}
+
+	var err error
+	var args []interface{}
+	if v.selectSQL, args, err = v.sel.Clone().ToSQL(); err != nil {
+		return nil, errors.Wrapf(err, "[mview] View %q: failed to render the SELECT query", name)
+	}
+	if len(args) > 0 {
+		return nil, errors.NotSupported.Newf("[mview] View %q: SELECT query must not contain arguments", name)
+	}
+	if v.status.Mode == ModeDelta {
+		// A view in delta mode has no WHERE clause.
+		if v.emptySelectSQL, _, err = v.sel.Clone().Where(dml.Expr("1=0")).ToSQL(); err != nil {
+			return nil, errors.Wrapf(err, "[mview] View %q: failed to render the SELECT query", name)
+		}
+	}
+	return v, nil
+}
+
+func isGroupColumn(groupBy []string, column string) bool {
+	for _, g := range groupBy {
+		if g == column {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the name of the view. Implements fmt.Stringer and
+// binlogsync.RowsEventHandler.
+func (v *View) String() string {
+	return "mview." + v.name
+}
+
+// Name returns the name of the materialized table.
+func (v *View) Name() string { return v.name }
+
+// Tables returns the base table and the joined tables. The View must be
+// registered as RowsEventHandler for all those tables.
+func (v *View) Tables() []string {
+	return append([]string{v.baseTable}, v.joinTables...)
+}
+
+// Status returns a copy of the current state.
+func (v *View) Status() Status {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.status
+}
+
+// createTableSQL returns the statement to create the materialized table from
+// the SELECT query. The GROUP BY columns form the primary key.
+func (v *View) createTableSQL(tableName, selectSQL string) string {
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+	buf.WriteString("CREATE TABLE ")
+	buf.WriteString(dml.Quoter.Name(tableName))
+	buf.WriteString(" (PRIMARY KEY (")
+	for i, g := range v.groupBy {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(dml.Quoter.Name(g))
+	}
+	buf.WriteString(")) ")
+	buf.WriteString(selectSQL)
+	return buf.String()
+}
+
+// FullRefresh recreates the materialized table from the SELECT query. The new
+// table gets created next to the old one and both tables get swapped
+// atomically. A successful refresh resets the stale state and removes the
+// entries of the view from the changelog table. In ModeDelta with
+// Options.Position set, the rows get copied in a transaction which also
+// queries the binlog position of the snapshot, see View.Do.
+func (v *View) FullRefresh(ctx context.Context) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if err := v.fullRefresh(ctx); err != nil {
+		v.status.Errors++
+		v.status.LastError = err.Error()
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (v *View) fullRefresh(ctx context.Context) error {
+	defer log.WhenDone(v.opts.Log).Info("mview.View.FullRefresh", log.String("view", v.name))
+
+	newName := v.name + "_new"
+	oldName := v.name + "_old"
+	withSnapshot := v.status.Mode == ModeDelta && v.opts.Position != nil
+
+	createSQL := v.createTableSQL(newName, v.selectSQL)
+	if withSnapshot {
+		createSQL = v.createTableSQL(newName, v.emptySelectSQL)
+	}
+	if err := v.exec(ctx, "DROP TABLE IF EXISTS "+dml.Quoter.Name(newName), createSQL); err != nil {
+		return errors.WithStack(err)
+	}
+	var snapshot ddl.MasterStatus
+	if withSnapshot {
+		var err error
+		if snapshot, err = v.takeSnapshot(ctx, newName); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if err := v.exec(ctx,
+		"DROP TABLE IF EXISTS "+dml.Quoter.Name(oldName),
+		"CREATE TABLE IF NOT EXISTS "+dml.Quoter.Name(v.name)+" LIKE "+dml.Quoter.Name(newName),
+		"RENAME TABLE "+dml.Quoter.Name(v.name)+" TO "+dml.Quoter.Name(oldName)+", "+dml.Quoter.Name(newName)+" TO "+dml.Quoter.Name(v.name),
+		"DROP TABLE "+dml.Quoter.Name(oldName),
+	); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := v.db.DB.ExecContext(ctx, "DELETE FROM "+dml.Quoter.Name(v.opts.ChangelogTable)+" WHERE `view_name`=?", v.name); err != nil {
+		return errors.Wrapf(err, "[mview] View %q FullRefresh failed to clear the changelog", v.name)
+	}
+	v.snapshot = snapshot
+	v.status.Stale = false
+	v.status.RefreshPending = false
+	v.status.LastFullRefresh = now()
+	return nil
+}
+
+func (v *View) exec(ctx context.Context, stmts ...string) error {
+	for _, s := range stmts {
+		if _, err := v.db.DB.ExecContext(ctx, s); err != nil {
+			return errors.Wrapf(err, "[mview] View %q FullRefresh failed to execute: %q", v.name, s)
+		}
+	}
+	return nil
+}
+
+// takeSnapshot copies the result of the SELECT query into the table and
+// returns the binlog position of the copy. INSERT ... SELECT holds shared
+// next-key locks on the rows of the base table until the commit in the
+// REPEATABLE READ isolation level, so no row event of the base table can get
+// written to the binlog between the copy and SHOW MASTER STATUS.
+func (v *View) takeSnapshot(ctx context.Context, tableName string) (ms ddl.MasterStatus, err error) {
+	err = v.db.Transaction(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead}, func(tx *dml.Tx) error {
+		if _, err := tx.DB.ExecContext(ctx, "INSERT INTO "+dml.Quoter.Name(tableName)+" "+v.selectSQL); err != nil {
+			return errors.Wrapf(err, "[mview] View %q FullRefresh failed to copy the rows", v.name)
+		}
+		_, err := tx.WithQueryBuilder(&ms).Load(ctx, &ms)
+		return errors.Wrapf(err, "[mview] View %q FullRefresh failed to query the master status", v.name)
+	})
+	return ms, errors.WithStack(err)
+}
+
+// inSnapshot returns true if the current row event has already been contained
+// in the snapshot of the last full refresh. Options.Position returns the end
+// position of the last committed transaction before the row event, which is
+// lower than the snapshot position for all transactions committed before the
+// snapshot.
+func (v *View) inSnapshot() bool {
+	return v.snapshot.File != "" && v.opts.Position != nil && v.status.Position.Compare(v.snapshot) < 0
+}
+
+var ratOne = big.NewRat(1, 1)
+
+// now gets overwritten in the tests.
+var now = time.Now
+
+// Refresh runs a full refresh if the view is stale, has a pending refresh or
+// if the changelog table contains entries for this view. Refresh should be
+// called periodically or after the binlog syncer has been started.
+func (v *View) Refresh(ctx context.Context) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	stale := v.status.Stale || v.status.RefreshPending
+	if !stale {
+		var cnt int64
+		if err := v.db.DB.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM "+dml.Quoter.Name(v.opts.ChangelogTable)+" WHERE `view_name`=?", v.name).Scan(&cnt); err != nil {
+			return errors.Wrapf(err, "[mview] View %q Refresh failed to query the changelog", v.name)
+		}
+		stale = cnt > 0
+	}
+	if !stale {
+		return nil
+	}
+	if err := v.fullRefresh(ctx); err != nil {
+		v.status.Errors++
+		v.status.LastError = err.Error()
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Do applies a row event to the materialized table. Implements
+// binlogsync.RowsEventHandler. A failed row event gets written to the
+// changelog table and marks the view as stale. Row events of a stale view get
+// skipped until the next full refresh. In ModeDelta, row events already
+// contained in the snapshot of the last full refresh get skipped.
+func (v *View) Do(ctx context.Context, action string, t *ddl.Table, rows [][]interface{}) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.status.Events++
+	if v.opts.Position != nil {
+		v.status.Position = v.opts.Position()
+	}
+	if v.status.Stale || (t.Name == v.baseTable && v.inSnapshot()) {
+		return nil
+	}
+
+	var err error
+	switch {
+	case t.Name != v.baseTable:
+		v.status.RefreshPending = true
+		if now().Sub(v.status.LastFullRefresh) < v.opts.JoinRefreshInterval {
+			return nil
+		}
+		err = v.fullRefresh(ctx)
+	case v.status.Mode == ModeDelta:
+		err = v.applyDelta(ctx, action, t, rows)
+	default:
+		err = v.refreshGroups(ctx, t, rows)
+	}
+	if err == nil {
+		v.status.LastIncremental = now()
+		return nil
+	}
+
+	v.status.Errors++
+	v.status.LastError = err.Error()
+	v.status.Stale = true
+	v.opts.Log.Info("mview.View.Do.error", log.Err(err), log.String("view", v.name),
+		log.String("action", action), log.String("table", t.Name))
+	if err2 := v.writeChangelog(ctx, err); err2 != nil {
+		return errors.Wrapf(err2, "[mview] View %q failed to write changelog after error: %s", v.name, err)
+	}
+	return errors.WithStack(err)
+}
+
+// Complete runs a full refresh if the view is stale or has a pending refresh.
+// Implements binlogsync.RowsEventHandler.
+func (v *View) Complete(ctx context.Context) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.status.Stale && !v.status.RefreshPending {
+		return nil
+	}
+	if err := v.fullRefresh(ctx); err != nil {
+		v.status.Errors++
+		v.status.LastError = err.Error()
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// columnIndexes returns the position of each column in the row of a row event.
+func columnIndexes(t *ddl.Table, columns ...string) ([]int, error) {
+	idx := make([]int, len(columns))
+	for i, c := range columns {
+		idx[i] = -1
+		for j, tc := range t.Columns {
+			if tc.Field == c {
+				idx[i] = j
+				break
+			}
+		}
+		if idx[i] < 0 {
+			return nil, errors.NotFound.Newf("[mview] Column %q not found in table %q", c, t.Name)
+		}
+	}
+	return idx, nil
+}
+
+// groupKey creates a unique key for the values of the GROUP BY columns.
+func groupKey(buf *bytes.Buffer, row []interface{}, groupIdx []int) (string, []interface{}, error) {
+	buf.Reset()
+	vals := make([]interface{}, len(groupIdx))
+	for i, gi := range groupIdx {
+		if gi >= len(row) {
+			return "", nil, errors.NotValid.Newf("[mview] Row contains %d columns, but column index %d requested", len(row), gi)
+		}
+		vals[i] = row[gi]
+		fmt.Fprintf(buf, "%v\x00", row[gi])
+	}
+	return buf.String(), vals, nil
+}
+
+// isNegative returns true if a row gets subtracted from the aggregates. For
+// updates the rows alternate between the before and after image.
+func isNegative(action string, rowIdx int) bool {
+	return action == actionDelete || action == actionUpdate && rowIdx%2 == 0
+}
+
+// toDecimal converts a column value into an exact decimal number and returns
+// the number of digits after the decimal point. A nil number represents NULL.
+func toDecimal(val interface{}) (*big.Rat, int, error) {
+	var str string
+	switch vt := val.(type) {
+	case nil:
+		return nil, 0, nil
+	case null.Decimal:
+		if !vt.Valid {
+			return nil, 0, nil
+		}
+		str = vt.String()
+	case string:
+		str = vt
+	case []byte:
+		str = string(vt)
+	case float64:
+		str = strconv.FormatFloat(vt, 'f', -1, 64)
+	case float32:
+		str = strconv.FormatFloat(float64(vt), 'f', -1, 32)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		str = fmt.Sprint(vt)
+	default:
+		f, err := conv.ToFloat64E(val)
+		if err != nil {
+			return nil, 0, errors.WithStack(err)
+		}
+		str = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	str = strings.TrimSpace(str)
+	r, ok := new(big.Rat).SetString(str)
+	if !ok {
+		return nil, 0, errors.NotValid.Newf("[mview] Invalid decimal number %q", str)
+	}
+	scale := 0
+	if pos := strings.IndexByte(str, '.'); pos >= 0 {
+		scale = len(str) - pos - 1
+	}
+	return r, scale, nil
+}
+
+type groupDelta struct {
+	vals   []interface{}
+	deltas []big.Rat
+	// scales contains the maximum number of digits after the decimal point of
+	// the summed values.
+	scales []int
+}
+
+// applyDelta sums the differences of the aggregates per group and upserts
+// them into the materialized table. Groups with a row count of zero get
+// deleted.
+func (v *View) applyDelta(ctx context.Context, action string, t *ddl.Table, rows [][]interface{}) error {
+	groupIdx, err := columnIndexes(t, v.groupBy...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	aggIdx := make([]int, len(v.aggregates))
+	for i, a := range v.aggregates {
+		aggIdx[i] = -1
+		if a.column != "" {
+			idx, err := columnIndexes(t, a.column)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			aggIdx[i] = idx[0]
+		}
+	}
+
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	var keys []string
+	groups := map[string]*groupDelta{}
+	for ri, row := range rows {
+		key, vals, err := groupKey(buf, row, groupIdx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		gd, ok := groups[key]
+		if !ok {
+			gd = &groupDelta{
+				vals:   vals,
+				deltas: make([]big.Rat, len(v.aggregates)),
+				scales: make([]int, len(v.aggregates)),
+			}
+			groups[key] = gd
+			keys = append(keys, key)
+		}
+		negative := isNegative(action, ri)
+		for i, a := range v.aggregates {
+			d := ratOne
+			if a.column != "" {
+				r, scale, err := toDecimal(row[aggIdx[i]])
+				if err != nil {
+					return errors.Wrapf(err, "[mview] View %q column %q", v.name, a.column)
+				}
+				if r == nil {
+					continue // NULL values get ignored by COUNT and SUM
+				}
+				if a.function == "SUM" {
+					d = r
+					if scale > gd.scales[i] {
+						gd.scales[i] = scale
+					}
+				}
+			}
+			if negative {
+				gd.deltas[i].Sub(&gd.deltas[i], d)
+			} else {
+				gd.deltas[i].Add(&gd.deltas[i], d)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	buf.Reset()
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(dml.Quoter.Name(v.name))
+	buf.WriteString(" (")
+	for i, g := range v.groupBy {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(dml.Quoter.Name(g))
+	}
+	for _, a := range v.aggregates {
+		buf.WriteByte(',')
+		buf.WriteString(dml.Quoter.Name(a.alias))
+	}
+	buf.WriteString(") VALUES ")
+	args := make([]interface{}, 0, len(keys)*(len(v.groupBy)+len(v.aggregates)))
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writePlaceHolders(buf, len(v.groupBy)+len(v.aggregates))
+		gd := groups[key]
+		args = append(args, gd.vals...)
+		for j, a := range v.aggregates {
+			if a.function == "COUNT" {
+				args = append(args, gd.deltas[j].Num().Int64())
+			} else {
+				args = append(args, gd.deltas[j].FloatString(gd.scales[j]))
+			}
+		}
+	}
+	buf.WriteString(" ON DUPLICATE KEY UPDATE ")
+	for i, a := range v.aggregates {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		qa := dml.Quoter.Name(a.alias)
+		buf.WriteString(qa)
+		buf.WriteString("=COALESCE(")
+		buf.WriteString(qa)
+		buf.WriteString(",0)+VALUES(")
+		buf.WriteString(qa)
+		buf.WriteByte(')')
+	}
+
+	return errors.WithStack(v.db.Transaction(ctx, nil, func(tx *dml.Tx) error {
+		if _, err := tx.DB.ExecContext(ctx, buf.String(), args...); err != nil {
+			return errors.Wrapf(err, "[mview] View %q failed to apply deltas", v.name)
+		}
+		_, err := tx.DB.ExecContext(ctx, "DELETE FROM "+dml.Quoter.Name(v.name)+" WHERE "+dml.Quoter.Name(v.countStar)+"<=0")
+		return errors.Wrapf(err, "[mview] View %q failed to delete empty groups", v.name)
+	}))
+}
+
+// refreshGroups deletes the affected groups from the materialized table and
+// inserts them again by running the SELECT query restricted to those groups.
+func (v *View) refreshGroups(ctx context.Context, t *ddl.Table, rows [][]interface{}) error {
+	groupIdx, err := columnIndexes(t, v.groupBy...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	var args []interface{}
+	seen := map[string]bool{}
+	for _, row := range rows {
+		key, vals, err := groupKey(buf, row, groupIdx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if !seen[key] {
+			seen[key] = true
+			args = append(args, vals...)
+		}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+
+	// the qualified GROUP BY columns for the WHERE clause of the SELECT
+	buf.Reset()
+	buf.WriteByte('(')
+	for i, g := range v.sel.GroupBys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		dml.Quoter.WriteIdentifier(buf, g.Name)
+	}
+	buf.WriteString(") IN (")
+	for i := 0; i < len(seen); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writePlaceHolders(buf, len(v.groupBy))
+	}
+	buf.WriteByte(')')
+	selCond := buf.String()
+
+	// the cache key avoids to reuse an already rendered SQL string
+	sel := v.sel.Clone().WithCacheKey("mview_groups_%d", len(seen))
+	sel.Wheres = append(sel.Wheres, dml.Expr(selCond))
+	selSQL, _, err := sel.ToSQL()
+	if err != nil {
+		return errors.Wrapf(err, "[mview] View %q failed to render the SELECT query", v.name)
+	}
+
+	buf.Reset()
+	buf.WriteString("DELETE FROM ")
+	buf.WriteString(dml.Quoter.Name(v.name))
+	buf.WriteString(" WHERE (")
+	for i, g := range v.groupBy {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(dml.Quoter.Name(g))
+	}
+	buf.WriteString(")")
+	buf.WriteString(selCond[strings.Index(selCond, " IN ("):])
+	delSQL := buf.String()
+
+	return errors.WithStack(v.db.Transaction(ctx, nil, func(tx *dml.Tx) error {
+		if _, err := tx.DB.ExecContext(ctx, delSQL, args...); err != nil {
+			return errors.Wrapf(err, "[mview] View %q failed to delete groups", v.name)
+		}
+		_, err := tx.DB.ExecContext(ctx, "INSERT INTO "+dml.Quoter.Name(v.name)+" "+selSQL, args...)
+		return errors.Wrapf(err, "[mview] View %q failed to insert groups", v.name)
+	}))
+}
+
+func writePlaceHolders(buf *bytes.Buffer, count int) {
+	buf.WriteByte('(')
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('?')
+	}
+	buf.WriteByte(')')
+}
@@ -0,0 +1,355 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/sql/mview"
+	"github.com/corestoreio/pkg/storage/null"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+var salesOrder = ddl.NewTable("sales_order",
+	&ddl.Column{Field: "entity_id"},
+	&ddl.Column{Field: "store_id"},
+	&ddl.Column{Field: "status"},
+	&ddl.Column{Field: "grand_total"},
+)
+
+func newDeltaSelect() *dml.Select {
+	return dml.NewSelect("store_id").
+		AddColumnsConditions(
+			dml.Expr("COUNT(*)").Alias("orders"),
+			dml.Expr("SUM(grand_total)").Alias("revenue"),
+		).
+		From("sales_order").GroupBy("store_id")
+}
+
+func TestNewView(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	t.Run("delta mode", func(t *testing.T) {
+		v, err := mview.NewView(dbc, "mv_sales", newDeltaSelect(), mview.Options{})
+		assert.NoError(t, err)
+		assert.Exactly(t, mview.ModeDelta, v.Status().Mode)
+		assert.Exactly(t, []string{"sales_order"}, v.Tables())
+		assert.Exactly(t, "mview.mv_sales", v.String())
+	})
+
+	t.Run("groups mode", func(t *testing.T) {
+		sel := dml.NewSelect("so.store_id").
+			AddColumnsConditions(dml.Expr("MAX(so.grand_total)").Alias("max_total")).
+			FromAlias("sales_order", "so").
+			Join(dml.MakeIdentifier("store").Alias("s"), dml.Column("s.store_id").Equal().Column("so.store_id")).
+			Where(dml.Column("so.status").Str("complete")).
+			GroupBy("so.store_id")
+		v, err := mview.NewView(dbc, "mv_sales", sel, mview.Options{})
+		assert.NoError(t, err)
+		assert.Exactly(t, mview.ModeGroups, v.Status().Mode)
+		assert.Exactly(t, []string{"sales_order", "store"}, v.Tables())
+	})
+
+	t.Run("missing GROUP BY", func(t *testing.T) {
+		v, err := mview.NewView(dbc, "mv_sales", dml.NewSelect("store_id").From("sales_order"), mview.Options{})
+		assert.Nil(t, v)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+
+	t.Run("GROUP BY column not selected", func(t *testing.T) {
+		sel := dml.NewSelect().AddColumnsConditions(dml.Expr("COUNT(*)").Alias("orders")).
+			From("sales_order").GroupBy("store_id")
+		v, err := mview.NewView(dbc, "mv_sales", sel, mview.Options{})
+		assert.Nil(t, v)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+
+	t.Run("missing arguments", func(t *testing.T) {
+		v, err := mview.NewView(nil, "mv_sales", newDeltaSelect(), mview.Options{})
+		assert.Nil(t, v)
+		assert.True(t, errors.Empty.Match(err), "%+v", err)
+	})
+}
+
+func TestView_FullRefresh(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	v, err := mview.NewView(dbc, "mv_sales", newDeltaSelect(), mview.Options{})
+	assert.NoError(t, err)
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE IF EXISTS `mv_sales_new`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE `mv_sales_new` (PRIMARY KEY (`store_id`)) SELECT `store_id`, COUNT(*) AS `orders`, SUM(grand_total) AS `revenue` FROM `sales_order` GROUP BY `store_id`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE IF EXISTS `mv_sales_old`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE IF NOT EXISTS `mv_sales` LIKE `mv_sales_new`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("RENAME TABLE `mv_sales` TO `mv_sales_old`, `mv_sales_new` TO `mv_sales`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE `mv_sales_old`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `mview_changelog` WHERE `view_name`=?")).
+		WithArgs("mv_sales").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, v.FullRefresh(context.Background()))
+	st := v.Status()
+	assert.False(t, st.LastFullRefresh.IsZero())
+	assert.False(t, st.Stale)
+}
+
+func TestView_FullRefresh_Snapshot(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	pos := ddl.MasterStatus{File: "mysql-bin.000003", Position: 4711}
+	v, err := mview.NewView(dbc, "mv_sales", newDeltaSelect(), mview.Options{
+		Position: func() ddl.MasterStatus { return pos },
+	})
+	assert.NoError(t, err)
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE IF EXISTS `mv_sales_new`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE `mv_sales_new` (PRIMARY KEY (`store_id`)) SELECT `store_id`, COUNT(*) AS `orders`, SUM(grand_total) AS `revenue` FROM `sales_order` WHERE (1=0) GROUP BY `store_id`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `mv_sales_new` SELECT `store_id`, COUNT(*) AS `orders`, SUM(grand_total) AS `revenue` FROM `sales_order` GROUP BY `store_id`")).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SHOW MASTER STATUS")).
+		WillReturnRows(sqlmock.NewRows([]string{"File", "Position"}).AddRow("mysql-bin.000003", 5000))
+	dbMock.ExpectCommit()
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE IF EXISTS `mv_sales_old`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE IF NOT EXISTS `mv_sales` LIKE `mv_sales_new`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("RENAME TABLE `mv_sales` TO `mv_sales_old`, `mv_sales_new` TO `mv_sales`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE `mv_sales_old`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `mview_changelog` WHERE `view_name`=?")).
+		WithArgs("mv_sales").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, v.FullRefresh(context.Background()))
+
+	row := [][]interface{}{{int64(33), int64(1), "pending", "10.50"}}
+
+	// The transaction of the event committed before the snapshot, so the
+	// event gets skipped without touching the database.
+	assert.NoError(t, v.Do(context.Background(), "insert", salesOrder, row))
+
+	pos.Position = 5000 // the first transaction after the snapshot
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `mv_sales`")).WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `mv_sales` WHERE `orders`<=0")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectCommit()
+	assert.NoError(t, v.Do(context.Background(), "insert", salesOrder, row))
+
+	st := v.Status()
+	assert.Exactly(t, uint64(2), st.Events)
+	assert.Exactly(t, uint64(0), st.Errors)
+}
+
+func TestView_Do(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delta update", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		v, err := mview.NewView(dbc, "mv_sales", newDeltaSelect(), mview.Options{
+			Position: func() ddl.MasterStatus { return ddl.MasterStatus{File: "mysql-bin.000003", Position: 4711} },
+		})
+		assert.NoError(t, err)
+
+		dbMock.ExpectBegin()
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `mv_sales` (`store_id`,`orders`,`revenue`) VALUES (?,?,?),(?,?,?) "+
+			"ON DUPLICATE KEY UPDATE `orders`=COALESCE(`orders`,0)+VALUES(`orders`),`revenue`=COALESCE(`revenue`,0)+VALUES(`revenue`)")).
+			WithArgs(int64(1), int64(-1), "-10.50", int64(2), int64(1), "12.50").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `mv_sales` WHERE `orders`<=0")).WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectCommit()
+
+		// moves an order from store 1 to store 2 and changes the grand total.
+		err = v.Do(context.Background(), "update", salesOrder, [][]interface{}{
+			{int64(33), int64(1), "pending", "10.50"},
+			{int64(33), int64(2), "pending", "12.50"},
+		})
+		assert.NoError(t, err, "%+v", err)
+		st := v.Status()
+		assert.Exactly(t, uint64(1), st.Events)
+		assert.Exactly(t, uint(4711), st.Position.Position)
+		assert.False(t, st.LastIncremental.IsZero())
+	})
+
+	t.Run("delta sums exact decimals", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		v, err := mview.NewView(dbc, "mv_sales", newDeltaSelect(), mview.Options{})
+		assert.NoError(t, err)
+
+		dbMock.ExpectBegin()
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `mv_sales` (`store_id`,`orders`,`revenue`) VALUES (?,?,?) ")).
+			WithArgs(int64(1), int64(4), "0.3").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `mv_sales` WHERE `orders`<=0")).WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectCommit()
+
+		err = v.Do(context.Background(), "insert", salesOrder, [][]interface{}{
+			{int64(33), int64(1), "pending", null.MakeDecimalInt64(1000, 4)},
+			{int64(34), int64(1), "pending", float64(0.1)},
+			{int64(35), int64(1), "pending", []byte("0.1")},
+			{int64(36), int64(1), "pending", nil},
+		})
+		assert.NoError(t, err, "%+v", err)
+	})
+
+	t.Run("groups delete", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		sel := dml.NewSelect("store_id").
+			AddColumnsConditions(dml.Expr("MAX(grand_total)").Alias("max_total")).
+			From("sales_order").GroupBy("store_id")
+		v, err := mview.NewView(dbc, "mv_sales", sel, mview.Options{})
+		assert.NoError(t, err)
+
+		dbMock.ExpectBegin()
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `mv_sales` WHERE (`store_id`) IN ((?),(?))")).
+			WithArgs(int64(1), int64(3)).WillReturnResult(sqlmock.NewResult(0, 2))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `mv_sales` SELECT `store_id`, MAX(grand_total) AS `max_total` FROM `sales_order` WHERE ((`store_id`) IN ((?),(?))) GROUP BY `store_id`")).
+			WithArgs(int64(1), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectCommit()
+
+		err = v.Do(context.Background(), "delete", salesOrder, [][]interface{}{
+			{int64(33), int64(1), "pending", "10.50"},
+			{int64(34), int64(3), "pending", "1.50"},
+			{int64(35), int64(1), "complete", "2.50"},
+		})
+		assert.NoError(t, err, "%+v", err)
+	})
+
+	t.Run("failed event gets written to the changelog", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		v, err := mview.NewView(dbc, "mv_sales", newDeltaSelect(), mview.Options{
+			Position: func() ddl.MasterStatus { return ddl.MasterStatus{File: "mysql-bin.000003", Position: 4711} },
+		})
+		assert.NoError(t, err)
+
+		dbMock.ExpectBegin()
+		dbMock.ExpectExec("INSERT INTO `mv_sales`").WillReturnError(errors.ConnectionFailed.Newf("Upsss"))
+		dbMock.ExpectRollback()
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `mview_changelog` (`view_name`,`binlog_file`,`binlog_position`,`error`) VALUES (?,?,?,?)")).
+			WithArgs("mv_sales", "mysql-bin.000003", uint64(4711), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err = v.Do(context.Background(), "insert", salesOrder, [][]interface{}{
+			{int64(33), int64(1), "pending", "10.50"},
+		})
+		assert.True(t, errors.ConnectionFailed.Match(err), "%+v", err)
+		st := v.Status()
+		assert.True(t, st.Stale)
+		assert.Exactly(t, uint64(1), st.Errors)
+
+		// stale views skip further events
+		err = v.Do(context.Background(), "insert", salesOrder, [][]interface{}{
+			{int64(34), int64(1), "pending", "10.50"},
+		})
+		assert.NoError(t, err)
+		assert.Exactly(t, uint64(2), v.Status().Events)
+	})
+
+	t.Run("joined table triggers full refresh", func(t *testing.T) {
+		dbc, dbMock := dmltest.MockDB(t)
+		defer dmltest.MockClose(t, dbc, dbMock)
+
+		sel := newDeltaSelect().Join(dml.MakeIdentifier("store"), dml.Columns("store_id"))
+		v, err := mview.NewView(dbc, "mv_sales", sel, mview.Options{})
+		assert.NoError(t, err)
+
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE IF EXISTS `mv_sales_new`")).WillReturnError(errors.AlreadyClosed.Newf("Upsss"))
+		dbMock.ExpectExec("INSERT INTO `mview_changelog`").WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err = v.Do(context.Background(), "insert", ddl.NewTable("store", &ddl.Column{Field: "store_id"}), [][]interface{}{
+			{int64(5)},
+		})
+		assert.True(t, errors.AlreadyClosed.Match(err), "%+v", err)
+	})
+}
+
+func expectFullRefresh(dbMock sqlmock.Sqlmock) {
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE IF EXISTS `mv_sales_new`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE `mv_sales_new`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE IF EXISTS `mv_sales_old`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE IF NOT EXISTS `mv_sales` LIKE `mv_sales_new`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("RENAME TABLE")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DROP TABLE `mv_sales_old`")).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `mview_changelog`")).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestView_Do_JoinRefreshInterval(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	sel := newDeltaSelect().Join(dml.MakeIdentifier("store"), dml.Columns("store_id"))
+	v, err := mview.NewView(dbc, "mv_sales", sel, mview.Options{})
+	assert.NoError(t, err)
+
+	store := ddl.NewTable("store", &ddl.Column{Field: "store_id"})
+	rows := [][]interface{}{{int64(5)}}
+
+	expectFullRefresh(dbMock)
+	assert.NoError(t, v.Do(context.Background(), "insert", store, rows))
+	assert.False(t, v.Status().RefreshPending)
+
+	// Further events within the interval only mark the view as pending.
+	assert.NoError(t, v.Do(context.Background(), "update", store, rows))
+	assert.NoError(t, v.Do(context.Background(), "delete", store, rows))
+	st := v.Status()
+	assert.True(t, st.RefreshPending)
+	assert.Exactly(t, uint64(3), st.Events)
+
+	expectFullRefresh(dbMock)
+	assert.NoError(t, v.Refresh(context.Background()))
+	assert.False(t, v.Status().RefreshPending)
+}
+
+func TestView_Refresh(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	v, err := mview.NewView(dbc, "mv_sales", newDeltaSelect(), mview.Options{ChangelogTable: "mv_log"})
+	assert.NoError(t, err)
+
+	dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT COUNT(*) FROM `mv_log` WHERE `view_name`=?")).
+		WithArgs("mv_sales").WillReturnRows(sqlmock.NewRows([]string{"cnt"}).AddRow(0))
+	assert.NoError(t, v.Refresh(context.Background()))
+	assert.True(t, v.Status().LastFullRefresh.IsZero())
+}
+
+func TestCreateChangelogTable(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("CREATE TABLE IF NOT EXISTS `mview_changelog` (")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.NoError(t, mview.CreateChangelogTable(context.Background(), dbc.DB, ""))
+}
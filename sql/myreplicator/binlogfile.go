@@ -0,0 +1,141 @@
+package myreplicator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+)
+
+// BinlogFiles returns the binlog files of a directory sorted by their
+// sequence number. Argument baseName restricts the result to files named
+// baseName.NNNNNN, for example "mysql-bin". An empty baseName returns all
+// files with a numeric extension.
+func BinlogFiles(dir, baseName string) ([]string, error) {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var files []string
+	for _, fi := range fis {
+		if fi.IsDir() {
+			continue
+		}
+		name := fi.Name()
+		ext := filepath.Ext(name)
+		if len(ext) < 2 || (baseName != "" && strings.TrimSuffix(name, ext) != baseName) {
+			continue
+		}
+		if _, err := strconv.ParseUint(ext[1:], 10, 64); err != nil {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// BinlogFilter restricts the events passed to the callback of ParseFiles. The
+// zero value passes all events. Position and time filters work like the
+// options of the mysqlbinlog command line tool.
+type BinlogFilter struct {
+	// Tables restricts table map and rows events to the listed tables. A table
+	// can be written as "schema.table" or just "table". Other events, like
+	// query events, are not affected.
+	Tables []string
+	// Start defines the first event to pass. The file name gets compared with
+	// the base name of the parsed files. Start.Position must point to the
+	// beginning of an event.
+	Start ddl.MasterStatus
+	// Stop defines the position at which parsing stops. Events which start at
+	// or after the stop position do not get passed.
+	Stop ddl.MasterStatus
+	// StartTime skips all events with a timestamp before StartTime.
+	StartTime time.Time
+	// StopTime stops parsing at the first event with a timestamp equal to or
+	// after StopTime.
+	StopTime time.Time
+}
+
+// matchesTable reports whether the table passes the Tables filter.
+func (bf BinlogFilter) matchesTable(schema, table []byte) bool {
+	if len(bf.Tables) == 0 {
+		return true
+	}
+	for _, t := range bf.Tables {
+		if i := strings.IndexByte(t, '.'); i >= 0 {
+			if t[:i] == string(schema) && t[i+1:] == string(table) {
+				return true
+			}
+		} else if t == string(table) {
+			return true
+		}
+	}
+	return false
+}
+
+// OnFileEventFunc gets called by ParseFiles for each event. Argument file
+// contains the base name of the currently parsed file.
+type OnFileEventFunc func(file string, e *BinlogEvent) error
+
+// ParseFiles parses binlog files from disk in the given order, for example as
+// returned by BinlogFiles, and calls onEvent for each event which passes the
+// filter. The format description event of each file gets always passed.
+func (p *BinlogParser) ParseFiles(files []string, bf BinlogFilter, onEvent OnFileEventFunc) error {
+	defer p.Resume()
+
+	var stopped bool
+	for _, name := range files {
+		base := filepath.Base(name)
+		if bf.Start.File != "" && base < bf.Start.File {
+			continue
+		}
+		if bf.Stop.File != "" && base > bf.Stop.File {
+			return nil
+		}
+		var offset int64
+		if base == bf.Start.File {
+			offset = int64(bf.Start.Position)
+		}
+
+		err := p.ParseFile(name, offset, func(e *BinlogEvent) error {
+			if _, ok := e.Event.(*FormatDescriptionEvent); ok {
+				return onEvent(base, e)
+			}
+			evStart := e.Header.LogPos - e.Header.EventSize
+			ts := time.Unix(int64(e.Header.Timestamp), 0)
+			if (base == bf.Stop.File && uint(evStart) >= bf.Stop.Position) ||
+				(!bf.StopTime.IsZero() && !ts.Before(bf.StopTime)) {
+				stopped = true
+				p.Stop()
+				return nil
+			}
+			if !bf.StartTime.IsZero() && ts.Before(bf.StartTime) {
+				return nil
+			}
+			switch ev := e.Event.(type) {
+			case *TableMapEvent:
+				if !bf.matchesTable(ev.Schema, ev.Table) {
+					return nil
+				}
+			case *RowsEvent:
+				if ev.Table != nil && !bf.matchesTable(ev.Table.Schema, ev.Table.Table) {
+					return nil
+				}
+			}
+			return onEvent(base, e)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "[myreplicator] ParseFiles %q", name)
+		}
+		if stopped {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+// binlogreader parses MySQL binary log files from disk. It prints the events,
+// filters them by table, position or time and writes them as SQL statements
+// which can be replayed on another server.
+//
+// Example usage:
+// Prints all events of the binlog files in a directory:
+//
+//	binlogreader -dir /var/lib/mysql -base mysql-bin
+//
+// Replays the changes of one table between two positions:
+//
+//	binlogreader -sql -tables shop.sales_order -start-file mysql-bin.000001 -start-position 4 \
+//	    -stop-file mysql-bin.000002 -stop-position 303 -dsn "user:pw@/shop?parseTime=true" \
+//	    mysql-bin.000001 mysql-bin.000002 | mysql shop
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/ddl"
+	"github.com/corestoreio/pkg/sql/myreplicator"
+	"github.com/go-sql-driver/mysql"
+)
+
+const timeLayout = "2006-01-02 15:04:05"
+
+var (
+	flagDir       = flag.String("dir", "", "directory containing the binlog files, if no files are provided as arguments")
+	flagBase      = flag.String("base", "", "base name of the binlog files in -dir, e.g. mysql-bin")
+	flagTables    = flag.String("tables", "", "comma separated list of tables to include, written as schema.table or table")
+	flagStartFile = flag.String("start-file", "", "binlog file name to start reading from")
+	flagStartPos  = flag.Uint("start-position", 0, "position in -start-file to start reading from")
+	flagStopFile  = flag.String("stop-file", "", "binlog file name to stop reading at")
+	flagStopPos   = flag.Uint("stop-position", 0, "position in -stop-file to stop reading at")
+	flagStartDT   = flag.String("start-datetime", "", "start reading at the first event with a timestamp equal to or after the argument, format "+timeLayout)
+	flagStopDT    = flag.String("stop-datetime", "", "stop reading at the first event with a timestamp equal to or after the argument, format "+timeLayout)
+	flagLocal     = flag.Bool("local", false, "interpret -start-datetime and -stop-datetime in the local time zone instead of UTC")
+	flagSQL       = flag.Bool("sql", false, "write the events as SQL statements instead of dumping them")
+	flagDSN       = flag.String("dsn", "", "optional DSN to load the column names of the tables, required to replay UPDATE and DELETE statements")
+	flagChecksum  = flag.Bool("verify-checksum", false, "verify the CRC32 checksum of each event")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s: [flags] [binlog files...]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  Either the flag -dir or a list of binlog files is required\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := start(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %+v\n", err)
+		os.Exit(1)
+	}
+}
+
+func start() error {
+	files := flag.Args()
+	if len(files) == 0 {
+		if *flagDir == "" {
+			flag.Usage()
+			return errors.Empty.Newf("Neither -dir nor binlog files have been provided")
+		}
+		var err error
+		if files, err = myreplicator.BinlogFiles(*flagDir, *flagBase); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	bf := myreplicator.BinlogFilter{
+		Start: ddl.MasterStatus{File: *flagStartFile, Position: *flagStartPos},
+		Stop:  ddl.MasterStatus{File: *flagStopFile, Position: *flagStopPos},
+	}
+	if *flagTables != "" {
+		bf.Tables = strings.Split(*flagTables, ",")
+	}
+	var err error
+	if bf.StartTime, err = parseTime(*flagStartDT); err != nil {
+		return errors.WithStack(err)
+	}
+	if bf.StopTime, err = parseTime(*flagStopDT); err != nil {
+		return errors.WithStack(err)
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	onEvent := func(file string, e *myreplicator.BinlogEvent) error {
+		fmt.Fprintf(w, "# %s:%d\n", file, e.Header.LogPos-e.Header.EventSize)
+		e.Dump(w)
+		return nil
+	}
+	if *flagSQL {
+		sw := myreplicator.NewSQLWriter(w)
+		if *flagDSN != "" {
+			cn, err := newColumnNames(*flagDSN)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			defer cn.db.Close()
+			sw.ColumnNames = cn.load
+		}
+		onEvent = func(file string, e *myreplicator.BinlogEvent) error {
+			return sw.WriteEvent(e)
+		}
+	}
+
+	p := myreplicator.NewBinlogParser()
+	p.SetVerifyChecksum(*flagChecksum)
+	if err := p.ParseFiles(files, bf, onEvent); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(w.Flush())
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	loc := time.UTC
+	if *flagLocal {
+		loc = time.Local
+	}
+	t, err := time.ParseInLocation(timeLayout, s, loc)
+	if err != nil {
+		return time.Time{}, errors.NotValid.New(err, "Invalid datetime %q", s)
+	}
+	return t, nil
+}
+
+// columnNames loads and caches the column names of the tables in the database
+// of the DSN.
+type columnNames struct {
+	db     *sql.DB
+	schema string
+	mu     sync.Mutex
+	cache  map[string][]string
+}
+
+func newColumnNames(dsn string) (*columnNames, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &columnNames{
+		db:     db,
+		schema: cfg.DBName,
+		cache:  make(map[string][]string),
+	}, nil
+}
+
+// load returns nil names for tables of another schema, which lets the
+// SQLWriter fall back to positional column names.
+func (cn *columnNames) load(schema, table string) ([]string, error) {
+	if schema != cn.schema {
+		return nil, nil
+	}
+	cn.mu.Lock()
+	defer cn.mu.Unlock()
+	if names, ok := cn.cache[table]; ok {
+		return names, nil
+	}
+	tc, err := ddl.LoadColumns(context.Background(), cn.db, table)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	names := tc[table].FieldNames()
+	cn.cache[table] = names
+	return names, nil
+}
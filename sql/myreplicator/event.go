@@ -321,6 +321,13 @@ func (e *GTIDEvent) decode(data []byte) error {
 	return nil
 }
 
+// GTIDNext returns the GTID in the format `source_id:transaction_id`, as used
+// by variable gtid_next.
+func (e *GTIDEvent) GTIDNext() string {
+	u, _ := uuid.FromBytes(e.SID)
+	return fmt.Sprintf("%s:%d", u.String(), e.GNO)
+}
+
 func (e *GTIDEvent) Dump(w io.Writer) {
 	fmt.Fprintf(w, "Commit flag: %d\n", e.CommitFlag)
 	u, _ := uuid.FromBytes(e.SID)
@@ -440,13 +447,21 @@ func (e *MariadbBinlogCheckPointEvent) Dump(w io.Writer) {
 	fmt.Fprintln(w)
 }
 
+// MariadbGTIDFlagStandalone gets set in a MariadbGTIDEvent when the event
+// group contains no transaction, like a DDL statement.
+const MariadbGTIDFlagStandalone uint8 = 1
+
 type MariadbGTIDEvent struct {
-	GTID mysql.MariadbGTID
+	GTID  mysql.MariadbGTID
+	Flags uint8
 }
 
 func (e *MariadbGTIDEvent) decode(data []byte) error {
 	e.GTID.SequenceNumber = binary.LittleEndian.Uint64(data)
 	e.GTID.DomainID = binary.LittleEndian.Uint32(data[8:])
+	if len(data) > 12 {
+		e.Flags = data[12]
+	}
 
 	// we don't care commit id now, maybe later
 
@@ -82,8 +82,9 @@ func (t *testSyncerSuite) testSync(tst *testing.T, s *BinlogStreamer) {
 	}
 
 	tables, err := ddl.NewTables(
-		ddl.WithDropTable(context.Background(), t.con.DB, "test_json", "test_json_v2", "test_geo"),
-		ddl.WithCreateTable(context.Background(), t.con.DB,
+		ddl.WithConnPool(t.con),
+		ddl.WithDropTable(context.Background(), "", "test_json", "test_json_v2", "test_geo"),
+		ddl.WithCreateTable(context.Background(),
 			"test_json", `CREATE TABLE IF NOT EXISTS `+"`test_json`"+` (
 			id BIGINT(64) UNSIGNED  NOT NULL AUTO_INCREMENT,
 			c1 `+tblJSONColumnType+`,
@@ -30,6 +30,12 @@ type TableMapEvent struct {
 
 	//len = (ColumnCount + 7) / 8
 	NullBitmap []byte
+
+	// SignednessBitmap contains one bit per numeric column, in the order of
+	// the numeric columns and the most significant bit first. A set bit marks
+	// an UNSIGNED column. Only MySQL 8.0.1 and newer log it as optional
+	// metadata.
+	SignednessBitmap []byte
 }
 
 func (e *TableMapEvent) decode(data []byte) error {
@@ -77,15 +83,72 @@ func (e *TableMapEvent) decode(data []byte) error {
 
 	pos += n
 
-	if len(data[pos:]) != bitmapByteSize(int(e.ColumnCount)) {
+	nullBitmapSize := bitmapByteSize(int(e.ColumnCount))
+	if len(data[pos:]) < nullBitmapSize {
 		return io.EOF
 	}
 
-	e.NullBitmap = data[pos:]
+	e.NullBitmap = data[pos : pos+nullBitmapSize]
+	pos += nullBitmapSize
+
+	return e.decodeOptionalMeta(data[pos:])
+}
+
+// tableMapOptMetaSignedness defines the type of the optional metadata field
+// which contains the signedness of the numeric columns. See enum
+// Optional_metadata_field_type in MySQL libbinlogevents/include/rows_event.h
+const tableMapOptMetaSignedness = 1
+
+// decodeOptionalMeta decodes the optional metadata fields written since MySQL
+// 8.0.1. Each field consists of the type, the length encoded length and the
+// value. Unknown types get skipped.
+func (e *TableMapEvent) decodeOptionalMeta(data []byte) error {
+	pos := 0
+	for pos < len(data) {
+		t := data[pos]
+		pos++
+		if pos >= len(data) {
+			return io.EOF
+		}
+		l, _, n := mysql.LengthEncodedInt(data[pos:])
+		pos += n
+		if len(data[pos:]) < int(l) {
+			return io.EOF
+		}
+		v := data[pos : pos+int(l)]
+		pos += int(l)
 
+		if t == tableMapOptMetaSignedness {
+			e.SignednessBitmap = v
+		}
+	}
 	return nil
 }
 
+func isNumericColumn(t byte) bool {
+	switch t {
+	case mysql.MYSQL_TYPE_TINY, mysql.MYSQL_TYPE_SHORT, mysql.MYSQL_TYPE_INT24, mysql.MYSQL_TYPE_LONG,
+		mysql.MYSQL_TYPE_LONGLONG, mysql.MYSQL_TYPE_NEWDECIMAL, mysql.MYSQL_TYPE_FLOAT, mysql.MYSQL_TYPE_DOUBLE:
+		return true
+	}
+	return false
+}
+
+// IsUnsigned reports whether the column at index i is an UNSIGNED numeric
+// column. Returns false if the server has not logged the signedness.
+func (e *TableMapEvent) IsUnsigned(i int) bool {
+	if len(e.SignednessBitmap) == 0 || i < 0 || i >= len(e.ColumnType) || !isNumericColumn(e.ColumnType[i]) {
+		return false
+	}
+	p := 0 // position of the column within the numeric columns
+	for _, t := range e.ColumnType[:i] {
+		if isNumericColumn(t) {
+			p++
+		}
+	}
+	return p/8 < len(e.SignednessBitmap) && e.SignednessBitmap[p/8]&(1<<uint(7-p%8)) != 0
+}
+
 func bitmapByteSize(columnCount int) int {
 	return int(columnCount+7) / 8
 }
@@ -188,6 +251,7 @@ func (e *TableMapEvent) Dump(w io.Writer) {
 	fmt.Fprintf(w, "Column count: %d\n", e.ColumnCount)
 	fmt.Fprintf(w, "Column type: \n%s", hex.Dump(e.ColumnType))
 	fmt.Fprintf(w, "NULL bitmap: \n%s", hex.Dump(e.NullBitmap))
+	fmt.Fprintf(w, "Signedness bitmap: \n%s", hex.Dump(e.SignednessBitmap))
 	fmt.Fprintln(w)
 }
 
@@ -602,3 +602,42 @@ func TestJsonNull(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Exactly(t, []byte(``), rows.Rows[0][3])
 }
+
+func TestTableMapEvent_Signedness(t *testing.T) {
+	// CREATE TABLE t (a tinyint unsigned, b mediumint unsigned, c bigint unsigned, d int)
+	// The optional metadata contains the signedness (type 1) and the column
+	// names (type 4), which get skipped.
+	tableMapEventData := []byte("\x01\x00\x00\x00\x00\x00\x01\x00\x04shop\x00\x01t\x00\x04\x01\x09\x08\x03\x00\x0f" +
+		"\x01\x01\xe0" + "\x04\x08\x01a\x01b\x01c\x01d")
+
+	tableMapEvent := new(TableMapEvent)
+	tableMapEvent.tableIDSize = 6
+	assert.NoError(t, tableMapEvent.decode(tableMapEventData))
+	assert.Exactly(t, []byte{0x0f}, tableMapEvent.NullBitmap)
+	assert.Exactly(t, []byte{0xe0}, tableMapEvent.SignednessBitmap)
+	for i, want := range []bool{true, true, true, false, false} {
+		assert.Exactly(t, want, tableMapEvent.IsUnsigned(i), "column %d", i)
+	}
+
+	rows := new(RowsEvent)
+	rows.tableIDSize = 6
+	rows.tables = map[uint64]*TableMapEvent{tableMapEvent.TableID: tableMapEvent}
+	rows.Version = 2
+	assert.NoError(t, rows.decode([]byte("\x01\x00\x00\x00\x00\x00\x01\x00\x02\x00\x04\x0f\x00"+
+		"\xff"+"\xff\xff\xff"+"\xff\xff\xff\xff\xff\xff\xff\xff"+"\xff\xff\xff\xff")))
+	assert.Exactly(t, [][]interface{}{{int8(-1), int32(-1), int64(-1), int32(-1)}}, rows.Rows)
+
+	var buf strings.Builder
+	sw := NewSQLWriter(&buf)
+	assert.NoError(t, sw.WriteEvent(&BinlogEvent{
+		Header: &EventHeader{EventType: WRITE_ROWS_EVENTv2},
+		Event:  rows,
+	}))
+	assert.Exactly(t, "INSERT INTO `shop`.`t` VALUES (255,16777215,18446744073709551615,-1);\n", buf.String())
+
+	t.Run("truncated optional metadata", func(t *testing.T) {
+		tme := new(TableMapEvent)
+		tme.tableIDSize = 6
+		assert.Error(t, tme.decode(tableMapEventData[:len(tableMapEventData)-2]))
+	})
+}
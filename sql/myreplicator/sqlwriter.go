@@ -0,0 +1,234 @@
+package myreplicator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/siddontang/go-mysql/mysql"
+)
+
+// SQLWriter converts binlog events into SQL statements which can be replayed
+// on another server, similar to the output of the mysqlbinlog command line
+// tool. Query events get written as they are, XID events as COMMIT and rows
+// events as INSERT, UPDATE and DELETE statements.
+type SQLWriter struct {
+	w io.Writer
+	// ColumnNames returns the column names of a table in ordinal order. The
+	// binary log contains only the column positions. Without the names,
+	// INSERT statements get written without a column list and UPDATE and
+	// DELETE statements get written as comments with positional @N column
+	// names, like mysqlbinlog --verbose does. Returning nil names for a table
+	// applies the same behaviour. Optional.
+	ColumnNames func(schema, table string) ([]string, error)
+
+	schema string
+	buf    bytes.Buffer
+}
+
+// NewSQLWriter creates a new SQL writer.
+func NewSQLWriter(w io.Writer) *SQLWriter {
+	return &SQLWriter{w: w}
+}
+
+// WriteEvent writes the SQL statements of an event. Events without a SQL
+// representation get ignored.
+func (sw *SQLWriter) WriteEvent(e *BinlogEvent) error {
+	sw.buf.Reset()
+	switch ev := e.Event.(type) {
+	case *QueryEvent:
+		if len(ev.Schema) > 0 && string(ev.Schema) != sw.schema {
+			sw.schema = string(ev.Schema)
+			sw.buf.WriteString("USE ")
+			sw.buf.WriteString(dml.Quoter.Name(sw.schema))
+			sw.buf.WriteString(";\n")
+		}
+		sw.buf.Write(bytes.TrimRight(ev.Query, "; \n"))
+		sw.buf.WriteString(";\n")
+	case *XIDEvent:
+		sw.buf.WriteString("COMMIT;\n")
+	case *RowsEvent:
+		if err := sw.writeRows(e.Header.EventType, ev); err != nil {
+			return errors.WithStack(err)
+		}
+	default:
+		return nil
+	}
+	_, err := sw.w.Write(sw.buf.Bytes())
+	return errors.WithStack(err)
+}
+
+func (sw *SQLWriter) writeRows(et EventType, ev *RowsEvent) error {
+	if ev.Table == nil {
+		return errors.NotFound.Newf("[myreplicator] SQLWriter: RowsEvent for table ID %d without TableMapEvent", ev.TableID)
+	}
+	schema, table := string(ev.Table.Schema), string(ev.Table.Table)
+
+	var cols []string
+	if sw.ColumnNames != nil {
+		var err error
+		if cols, err = sw.ColumnNames(schema, table); err != nil {
+			return errors.Wrapf(err, "[myreplicator] SQLWriter: Failed to load column names of %q.%q", schema, table)
+		}
+		if cols != nil && len(cols) < int(ev.ColumnCount) {
+			return errors.Mismatch.Newf("[myreplicator] SQLWriter: Table %q.%q has %d columns, but the rows event %d", schema, table, len(cols), ev.ColumnCount)
+		}
+	}
+	colName := func(i int) string {
+		if cols == nil {
+			return fmt.Sprintf("@%d", i+1)
+		}
+		return dml.Quoter.Name(cols[i])
+	}
+
+	qualified := dml.Quoter.QualifierName(schema, table)
+	switch et {
+	case WRITE_ROWS_EVENTv0, WRITE_ROWS_EVENTv1, WRITE_ROWS_EVENTv2:
+		for _, row := range ev.Rows {
+			sw.buf.WriteString("INSERT INTO ")
+			sw.buf.WriteString(qualified)
+			if cols != nil {
+				sw.buf.WriteString(" (")
+				_ = sw.writeColumns(ev.ColumnBitmap1, row, ",", func(i int, _ interface{}) error {
+					sw.buf.WriteString(colName(i))
+					return nil
+				})
+				sw.buf.WriteByte(')')
+			}
+			sw.buf.WriteString(" VALUES (")
+			if err := sw.writeColumns(ev.ColumnBitmap1, row, ",", func(i int, v interface{}) error {
+				return sw.writeValue(unsignedValue(ev.Table, i, v))
+			}); err != nil {
+				return errors.WithStack(err)
+			}
+			sw.buf.WriteString(");\n")
+		}
+
+	case DELETE_ROWS_EVENTv0, DELETE_ROWS_EVENTv1, DELETE_ROWS_EVENTv2:
+		for _, row := range ev.Rows {
+			sw.writeCommentPrefix(cols)
+			sw.buf.WriteString("DELETE FROM ")
+			sw.buf.WriteString(qualified)
+			sw.buf.WriteString(" WHERE ")
+			if err := sw.writeConditions(ev.Table, ev.ColumnBitmap1, row, colName); err != nil {
+				return errors.WithStack(err)
+			}
+			sw.buf.WriteString(" LIMIT 1;\n")
+		}
+
+	case UPDATE_ROWS_EVENTv0, UPDATE_ROWS_EVENTv1, UPDATE_ROWS_EVENTv2:
+		if len(ev.Rows)%2 != 0 {
+			return errors.NotValid.Newf("[myreplicator] SQLWriter: Update rows event of %q.%q contains an odd number of rows", schema, table)
+		}
+		for i := 0; i < len(ev.Rows); i += 2 {
+			sw.writeCommentPrefix(cols)
+			sw.buf.WriteString("UPDATE ")
+			sw.buf.WriteString(qualified)
+			sw.buf.WriteString(" SET ")
+			if err := sw.writeColumns(ev.ColumnBitmap2, ev.Rows[i+1], ",", func(i int, v interface{}) error {
+				sw.buf.WriteString(colName(i))
+				sw.buf.WriteByte('=')
+				return sw.writeValue(unsignedValue(ev.Table, i, v))
+			}); err != nil {
+				return errors.WithStack(err)
+			}
+			sw.buf.WriteString(" WHERE ")
+			if err := sw.writeConditions(ev.Table, ev.ColumnBitmap1, ev.Rows[i], colName); err != nil {
+				return errors.WithStack(err)
+			}
+			sw.buf.WriteString(" LIMIT 1;\n")
+		}
+
+	default:
+		return errors.NotSupported.Newf("[myreplicator] SQLWriter: EventType %s not supported", et)
+	}
+	return nil
+}
+
+// writeCommentPrefix comments out statements which cannot be replayed
+// without column names.
+func (sw *SQLWriter) writeCommentPrefix(cols []string) {
+	if cols == nil {
+		sw.buf.WriteString("-- ")
+	}
+}
+
+// writeColumns calls fn for each column which is present in the bitmap. With
+// a minimal row image not all columns are logged.
+func (sw *SQLWriter) writeColumns(bitmap []byte, row []interface{}, sep string, fn func(i int, v interface{}) error) error {
+	first := true
+	for i, v := range row {
+		if !isBitSet(bitmap, i) {
+			continue
+		}
+		if !first {
+			sw.buf.WriteString(sep)
+		}
+		first = false
+		if err := fn(i, v); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func (sw *SQLWriter) writeConditions(tm *TableMapEvent, bitmap []byte, row []interface{}, colName func(int) string) error {
+	return sw.writeColumns(bitmap, row, " AND ", func(i int, v interface{}) error {
+		sw.buf.WriteString(colName(i))
+		if v == nil {
+			sw.buf.WriteString(" IS NULL")
+			return nil
+		}
+		sw.buf.WriteByte('=')
+		return sw.writeValue(unsignedValue(tm, i, v))
+	})
+}
+
+// unsignedValue converts the value of an UNSIGNED integer column, which the
+// rows event decodes as a signed integer. Without the signedness metadata in
+// the TableMapEvent the value gets returned unchanged.
+func unsignedValue(tm *TableMapEvent, i int, v interface{}) interface{} {
+	if !tm.IsUnsigned(i) {
+		return v
+	}
+	switch vt := v.(type) {
+	case int8:
+		return uint8(vt)
+	case int16:
+		return uint16(vt)
+	case int32:
+		if tm.ColumnType[i] == mysql.MYSQL_TYPE_INT24 {
+			return uint32(vt) & 0xffffff
+		}
+		return uint32(vt)
+	case int64:
+		return uint64(vt)
+	}
+	return v
+}
+
+// writeValue writes a value as a SQL literal.
+func (sw *SQLWriter) writeValue(v interface{}) error {
+	switch vt := v.(type) {
+	case int8:
+		v = int64(vt)
+	case int16:
+		v = int64(vt)
+	case int32:
+		v = int64(vt)
+	case float32:
+		v = float64(vt)
+	case nil, int, int64, uint, uint8, uint16, uint32, uint64, float64, string, []byte, bool:
+	default:
+		v = fmt.Sprint(vt)
+	}
+	str, _, err := dml.Interpolate("?").Unsafe(v).ToSQL()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	sw.buf.WriteString(strings.TrimSpace(str))
+	return nil
+}
@@ -44,6 +44,10 @@ type LRUCache struct {
 	size      int64
 	capacity  int64
 	evictions int64
+	hits      int64
+	misses    int64
+
+	onEvict func(key string, value Value)
 }
 
 // Value is the interface values that go into LRUCache need to satisfy
@@ -83,8 +87,10 @@ func (lru *LRUCache) Get(key string) (v Value, ok bool) {
 
 	element, ok := lru.table[key]
 	if element == nil {
+		lru.misses++
 		return nil, false
 	}
+	lru.hits++
 	lru.moveToFront(element)
 	return element.Value.(*entry).value, true
 }
@@ -152,6 +158,15 @@ func (lru *LRUCache) Clear() {
 	lru.size = 0
 }
 
+// SetOnEvict sets a function which gets called for each entry evicted because
+// the cache exceeds its capacity. The function gets called while the cache is
+// locked, so it must not access the cache.
+func (lru *LRUCache) SetOnEvict(fn func(key string, value Value)) {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	lru.onEvict = fn
+}
+
 // SetCapacity will set the capacity of the cache. If the capacity is
 // smaller, and the current cache size exceed that capacity, the cache
 // will be shrank.
@@ -179,7 +194,8 @@ func (lru *LRUCache) StatsJSON() string {
 		return "{}"
 	}
 	l, s, c, e, o := lru.Stats()
-	return fmt.Sprintf("{\"Length\": %v, \"Size\": %v, \"Capacity\": %v, \"Evictions\": %v, \"OldestAccess\": \"%v\"}", l, s, c, e, o)
+	h, m := lru.Hits(), lru.Misses()
+	return fmt.Sprintf("{\"Length\": %v, \"Size\": %v, \"Capacity\": %v, \"Evictions\": %v, \"Hits\": %v, \"Misses\": %v, \"OldestAccess\": \"%v\"}", l, s, c, e, h, m, o)
 }
 
 // Length returns how many elements are in the cache
@@ -210,6 +226,20 @@ func (lru *LRUCache) Evictions() int64 {
 	return lru.evictions
 }
 
+// Hits returns the number of successful lookups via Get.
+func (lru *LRUCache) Hits() int64 {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	return lru.hits
+}
+
+// Misses returns the number of failed lookups via Get.
+func (lru *LRUCache) Misses() int64 {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	return lru.misses
+}
+
 // Oldest returns the insertion time of the oldest element in the cache,
 // or a IsZero() time if cache is empty.
 func (lru *LRUCache) Oldest() (oldest time.Time) {
@@ -281,5 +311,8 @@ func (lru *LRUCache) checkCapacity() {
 		delete(lru.table, delValue.key)
 		lru.size -= delValue.size
 		lru.evictions++
+		if lru.onEvict != nil {
+			lru.onEvict(delValue.key, delValue.value)
+		}
 	}
 }
@@ -301,3 +301,38 @@ func TestLRUIsEvicted(t *testing.T) {
 		t.Errorf("evictions: %d, want: %d", e, want)
 	}
 }
+
+func TestOnEvict(t *testing.T) {
+	cache := NewLRUCache(2)
+	var evicted []string
+	cache.SetOnEvict(func(key string, value Value) {
+		evicted = append(evicted, key)
+	})
+
+	cache.Set("key1", &CacheValue{1})
+	cache.Set("key2", &CacheValue{1})
+	cache.Delete("key2")
+	cache.Set("key3", &CacheValue{1})
+	cache.Set("key4", &CacheValue{1})
+	cache.SetCapacity(1)
+
+	if len(evicted) != 2 || evicted[0] != "key1" || evicted[1] != "key3" {
+		t.Errorf("evicted: %v, want: [key1 key3]", evicted)
+	}
+}
+
+func TestHitsMisses(t *testing.T) {
+	cache := NewLRUCache(10)
+	cache.Set("key1", &CacheValue{1})
+	cache.Get("key1")
+	cache.Get("key1")
+	cache.Get("key2")
+	cache.Peek("key2")
+
+	if h, want := cache.Hits(), int64(2); h != want {
+		t.Errorf("hits: %d, want: %d", h, want)
+	}
+	if m, want := cache.Misses(), int64(1); m != want {
+		t.Errorf("misses: %d, want: %d", m, want)
+	}
+}
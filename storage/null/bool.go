@@ -220,6 +220,12 @@ func (a Bool) MarshalTo(data []byte) (n int, err error) {
 	return 1, nil
 }
 
+// MarshalToSizedBuffer binary encoder for protocol buffers which writes to the
+// end of data. Required by code generated with gogo/protobuf >= v1.3.
+func (a Bool) MarshalToSizedBuffer(data []byte) (int, error) {
+	return marshalToSizedBuffer(a, data)
+}
+
 // Unmarshal binary decoder for protocol buffers. Implements proto.Unmarshaler.
 func (a *Bool) Unmarshal(data []byte) error {
 	if len(data) != 1 {
@@ -483,6 +483,12 @@ func (d Decimal) MarshalTo(data []byte) (n int, err error) {
 	return 14, nil
 }
 
+// MarshalToSizedBuffer binary encoder for protocol buffers which writes to the
+// end of data. Required by code generated with gogo/protobuf >= v1.3.
+func (d Decimal) MarshalToSizedBuffer(data []byte) (int, error) {
+	return marshalToSizedBuffer(d, data)
+}
+
 // Unmarshal binary decoder for protocol buffers. Implements proto.Unmarshaler.
 func (d *Decimal) Unmarshal(data []byte) error {
 	return d.UnmarshalBinary(data)
@@ -26,3 +26,21 @@ var (
 	JSONMarshalFn   func(v interface{}) ([]byte, error)
 	JSONUnMarshalFn func(data []byte, v interface{}) error
 )
+
+// marshalToSizedBuffer writes the protocol buffers encoding of m to the end of
+// data and returns the number of written bytes.
+func marshalToSizedBuffer(m interface {
+	Size() int
+	MarshalTo([]byte) (int, error)
+}, data []byte) (int, error) {
+	start := len(data) - m.Size()
+	n, err := m.MarshalTo(data[start:])
+	if err != nil {
+		return 0, err
+	}
+	if offset := len(data) - start - n; offset > 0 {
+		// fewer bytes written than announced by Size, e.g. for a NULL value.
+		copy(data[start+offset:], data[start:start+n])
+	}
+	return n, nil
+}
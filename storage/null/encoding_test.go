@@ -16,7 +16,10 @@ package null
 
 import (
 	"encoding/json"
+	"testing"
 	"time"
+
+	"github.com/corestoreio/pkg/util/assert"
 )
 
 type protoMarshalToer interface {
@@ -37,3 +40,39 @@ func maybePanic(err error) {
 var now = func() time.Time {
 	return time.Date(2006, 1, 2, 15, 4, 5, 02, time.FixedZone("hardcoded", 0))
 }
+
+func TestMarshalToSizedBuffer(t *testing.T) {
+	type sizedMarshaler interface {
+		MarshalTo(data []byte) (int, error)
+		MarshalToSizedBuffer(data []byte) (int, error)
+		Size() int
+	}
+	tests := []sizedMarshaler{
+		MakeBool(true), Bool{},
+		MakeFloat64(3.1415), Float64{},
+		MakeInt8(-8), Int8{},
+		MakeInt16(-16), Int16{},
+		MakeInt32(-32), Int32{},
+		MakeInt64(-64), Int64{},
+		MakeUint8(8), Uint8{},
+		MakeUint16(16), Uint16{},
+		MakeUint32(32), Uint32{},
+		MakeUint64(64), Uint64{},
+		MakeString("gopher"), String{},
+		MakeTime(now()), Time{},
+		MakeDecimalInt64(12345, 2), Decimal{},
+		MakePoint(4326, 1.5, 2.5), Geometry{},
+	}
+	for _, m := range tests {
+		want := make([]byte, m.Size())
+		n, err := m.MarshalTo(want)
+		assert.NoError(t, err)
+		want = want[:n]
+
+		buf := make([]byte, m.Size()+3)
+		n, err = m.MarshalToSizedBuffer(buf)
+		assert.NoError(t, err)
+		assert.Exactly(t, len(want), n, "%T %#v", m, m)
+		assert.Exactly(t, string(want), string(buf[len(buf)-n:]), "%T %#v", m, m)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/corestoreio/errors"
+)
+
+// wkbPoint defines the WKB geometry type of a point.
+const wkbPoint = 1
+
+// Geometry is a nullable spatial value as used by the MySQL data types
+// GEOMETRY, POINT, LINESTRING, POLYGON and their multi and collection types.
+// MySQL stores spatial values in its internal format: a four byte little
+// endian SRID followed by the WKB (well-known binary) representation of the
+// geometry. Geometry scans and writes this internal format.
+type Geometry struct {
+	// SRID spatial reference system identifier.
+	SRID uint32
+	// WKB well-known binary representation of the geometry.
+	WKB   []byte
+	Valid bool // Valid is true if Geometry is not NULL
+}
+
+// MakeGeometry creates a new valid Geometry from a SRID and a WKB encoded
+// geometry.
+func MakeGeometry(srid uint32, wkb []byte) Geometry {
+	return Geometry{
+		SRID:  srid,
+		WKB:   wkb,
+		Valid: true,
+	}
+}
+
+// MakePoint creates a new valid Geometry containing a point with the
+// coordinates x and y.
+func MakePoint(srid uint32, x, y float64) Geometry {
+	wkb := make([]byte, 21)
+	wkb[0] = 1 // little endian
+	binary.LittleEndian.PutUint32(wkb[1:], wkbPoint)
+	binary.LittleEndian.PutUint64(wkb[5:], math.Float64bits(x))
+	binary.LittleEndian.PutUint64(wkb[13:], math.Float64bits(y))
+	return MakeGeometry(srid, wkb)
+}
+
+// Point returns the coordinates of a point. Returns an error with Kind NotValid
+// if the geometry is NULL or not a point.
+func (a Geometry) Point() (x, y float64, err error) {
+	if !a.Valid || len(a.WKB) != 21 {
+		return 0, 0, errors.NotValid.Newf("[null] Geometry does not contain a point: %#v", a)
+	}
+	var bo binary.ByteOrder = binary.LittleEndian
+	if a.WKB[0] == 0 {
+		bo = binary.BigEndian
+	}
+	if typ := bo.Uint32(a.WKB[1:]); typ != wkbPoint {
+		return 0, 0, errors.NotValid.Newf("[null] Geometry does not contain a point but WKB type %d", typ)
+	}
+	x = math.Float64frombits(bo.Uint64(a.WKB[5:]))
+	y = math.Float64frombits(bo.Uint64(a.WKB[13:]))
+	return x, y, nil
+}
+
+// Scan implements the Scanner interface. It expects the MySQL internal
+// geometry format.
+func (a *Geometry) Scan(value interface{}) error {
+	if value == nil {
+		*a = Geometry{}
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		return a.UnmarshalBinary(v)
+	case string:
+		return a.UnmarshalBinary([]byte(v))
+	}
+	return errors.NotSupported.Newf("[null] Type %T not supported in Geometry.Scan", value)
+}
+
+// Value implements the driver Valuer interface. It returns the MySQL internal
+// geometry format.
+func (a Geometry) Value() (driver.Value, error) {
+	if !a.Valid {
+		return nil, nil
+	}
+	return a.MarshalBinary()
+}
+
+// GoString prints an optimized Go representation.
+func (a Geometry) GoString() string {
+	if !a.Valid {
+		return "null.Geometry{}"
+	}
+	return fmt.Sprintf("null.MakeGeometry(%d, %#v)", a.SRID, a.WKB)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It expects an object with the
+// fields srid and wkb, where wkb is base64 encoded, or null.
+func (a *Geometry) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, bTextNullLC) {
+		*a = Geometry{}
+		return nil
+	}
+	var dto struct {
+		SRID uint32 `json:"srid"`
+		WKB  []byte `json:"wkb"`
+	}
+	if err := JSONUnMarshalFn(data, &dto); err != nil {
+		return errors.NotValid.New(err, "[null] Geometry.UnmarshalJSON")
+	}
+	*a = MakeGeometry(dto.SRID, dto.WKB)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. It will encode null if this Geometry
+// is null.
+func (a Geometry) MarshalJSON() ([]byte, error) {
+	if !a.Valid {
+		return bTextNullLC, nil
+	}
+	return JSONMarshalFn(struct {
+		SRID uint32 `json:"srid"`
+		WKB  []byte `json:"wkb"`
+	}{
+		SRID: a.SRID,
+		WKB:  a.WKB,
+	})
+}
+
+// SetValid changes this Geometry's value and also sets it to be non-null.
+func (a Geometry) SetValid(srid uint32, wkb []byte) Geometry {
+	return MakeGeometry(srid, wkb)
+}
+
+// SetNull sets the value to Go's default value and Valid to false.
+func (a Geometry) SetNull() Geometry { return Geometry{} }
+
+// IsZero returns true for null geometries, for potential future omitempty
+// support.
+func (a Geometry) IsZero() bool {
+	return !a.Valid
+}
+
+// GobEncode implements the gob.GobEncoder interface for gob serialization.
+func (a Geometry) GobEncode() ([]byte, error) {
+	return a.Marshal()
+}
+
+// GobDecode implements the gob.GobDecoder interface for gob serialization.
+func (a *Geometry) GobDecode(data []byte) error {
+	return a.Unmarshal(data)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// expects the MySQL internal geometry format. Empty data results in a NULL
+// value.
+func (a *Geometry) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		*a = Geometry{}
+		return nil
+	}
+	if len(data) < 5 {
+		return errors.NotValid.Newf("[null] Geometry.UnmarshalBinary: data too short, got %d bytes", len(data))
+	}
+	a.SRID = binary.LittleEndian.Uint32(data)
+	a.WKB = append(a.WKB[:0], data[4:]...) // must be copied
+	a.Valid = true
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. It returns
+// the MySQL internal geometry format or nil if this Geometry is null.
+func (a Geometry) MarshalBinary() (data []byte, err error) {
+	if !a.Valid {
+		return nil, nil
+	}
+	data = make([]byte, a.Size())
+	_, err = a.MarshalTo(data)
+	return data, err
+}
+
+// Marshal binary encoder for protocol buffers. Implements proto.Marshaler.
+func (a Geometry) Marshal() ([]byte, error) {
+	return a.MarshalBinary()
+}
+
+// MarshalTo binary encoder for protocol buffers which writes into data.
+func (a Geometry) MarshalTo(data []byte) (n int, err error) {
+	if !a.Valid {
+		return 0, nil
+	}
+	if len(data) < a.Size() {
+		return 0, errors.NotValid.Newf("[null] Geometry.MarshalTo: data too short, want %d bytes got %d", a.Size(), len(data))
+	}
+	binary.LittleEndian.PutUint32(data, a.SRID)
+	n = 4 + copy(data[4:], a.WKB)
+	return n, nil
+}
+
+// MarshalToSizedBuffer binary encoder for protocol buffers which writes to the
+// end of data. Required by code generated with gogo/protobuf >= v1.3.
+func (a Geometry) MarshalToSizedBuffer(data []byte) (int, error) {
+	return marshalToSizedBuffer(a, data)
+}
+
+// Unmarshal binary decoder for protocol buffers. Implements proto.Unmarshaler.
+func (a *Geometry) Unmarshal(data []byte) error {
+	return a.UnmarshalBinary(data)
+}
+
+// Size returns the size of the underlying type. If not valid, the size will be
+// 0. Implements proto.Sizer.
+func (a Geometry) Size() (s int) {
+	if !a.Valid {
+		return 0
+	}
+	return 4 + len(a.WKB)
+}
+
+// WriteTo uses a special dialect to encode the value and write it into w. w
+// cannot be replaced by io.Writer and shall not be replaced by an interface
+// because of inlining features of the compiler.
+func (a Geometry) WriteTo(d Dialecter, w *bytes.Buffer) (err error) {
+	if !a.Valid {
+		_, err = w.WriteString(sqlStrNullUC)
+		return err
+	}
+	data, err := a.MarshalBinary()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	d.EscapeBinary(w, data)
+	return nil
+}
+
+// Append appends the value or its nil type to the interface slice.
+func (a Geometry) Append(args []interface{}) []interface{} {
+	if a.Valid {
+		data, _ := a.MarshalBinary()
+		return append(args, data)
+	}
+	return append(args, nil)
+}
@@ -0,0 +1,166 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package null
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/gogo/protobuf/proto"
+)
+
+var (
+	_ fmt.GoStringer             = (*Geometry)(nil)
+	_ json.Marshaler             = (*Geometry)(nil)
+	_ json.Unmarshaler           = (*Geometry)(nil)
+	_ encoding.BinaryMarshaler   = (*Geometry)(nil)
+	_ encoding.BinaryUnmarshaler = (*Geometry)(nil)
+	_ gob.GobEncoder             = (*Geometry)(nil)
+	_ gob.GobDecoder             = (*Geometry)(nil)
+	_ driver.Valuer              = (*Geometry)(nil)
+	_ proto.Marshaler            = (*Geometry)(nil)
+	_ proto.Unmarshaler          = (*Geometry)(nil)
+	_ proto.Sizer                = (*Geometry)(nil)
+	_ protoMarshalToer           = (*Geometry)(nil)
+)
+
+// geometryPointMySQL contains POINT(1 -2) with SRID 4326 as returned by MySQL.
+var geometryPointMySQL = []byte{
+	0xe6, 0x10, 0x00, 0x00, // SRID
+	0x01,                   // little endian
+	0x01, 0x00, 0x00, 0x00, // point
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f, // 1
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc0, // -2
+}
+
+func TestMakePoint(t *testing.T) {
+	g := MakePoint(4326, 1, -2)
+	assert.True(t, g.Valid)
+	assert.Exactly(t, uint32(4326), g.SRID)
+	assert.Exactly(t, 25, g.Size())
+
+	data, err := g.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Exactly(t, geometryPointMySQL, data)
+
+	x, y, err := g.Point()
+	assert.NoError(t, err)
+	assert.Exactly(t, 1.0, x)
+	assert.Exactly(t, -2.0, y)
+
+	t.Run("big endian", func(t *testing.T) {
+		g := MakeGeometry(0, []byte{
+			0x00,
+			0x00, 0x00, 0x00, 0x01,
+			0x3f, 0xf0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		})
+		x, y, err := g.Point()
+		assert.NoError(t, err)
+		assert.Exactly(t, 1.0, x)
+		assert.Exactly(t, -2.0, y)
+	})
+	t.Run("not a point", func(t *testing.T) {
+		_, _, err := Geometry{}.Point()
+		assert.ErrorIsKind(t, errors.NotValid, err)
+		wkb := append([]byte{}, g.WKB...)
+		wkb[1] = 2 // linestring
+		_, _, err = MakeGeometry(0, wkb).Point()
+		assert.ErrorIsKind(t, errors.NotValid, err)
+	})
+}
+
+func TestGeometry_Scan(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		nv := MakePoint(0, 1, 2)
+		assert.NoError(t, nv.Scan(nil))
+		assert.Exactly(t, Geometry{}, nv)
+	})
+	t.Run("[]byte", func(t *testing.T) {
+		var nv Geometry
+		assert.NoError(t, nv.Scan(geometryPointMySQL))
+		assert.Exactly(t, MakePoint(4326, 1, -2), nv)
+	})
+	t.Run("too short", func(t *testing.T) {
+		var nv Geometry
+		assert.ErrorIsKind(t, errors.NotValid, nv.Scan([]byte{1, 2, 3}))
+	})
+	t.Run("int unsupported", func(t *testing.T) {
+		var nv Geometry
+		assert.ErrorIsKind(t, errors.NotSupported, nv.Scan(3))
+	})
+}
+
+func TestGeometry_Value(t *testing.T) {
+	v, err := MakePoint(4326, 1, -2).Value()
+	assert.NoError(t, err)
+	assert.Exactly(t, geometryPointMySQL, v)
+
+	v, err = Geometry{}.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestGeometry_JSON(t *testing.T) {
+	data, err := json.Marshal(MakePoint(4326, 1, -2))
+	assert.NoError(t, err)
+	assert.Exactly(t, `{"srid":4326,"wkb":"AQEAAAAAAAAAAADwPwAAAAAAAADA"}`, string(data))
+
+	var g Geometry
+	assert.NoError(t, json.Unmarshal(data, &g))
+	assert.Exactly(t, MakePoint(4326, 1, -2), g)
+
+	data, err = json.Marshal(Geometry{})
+	assert.NoError(t, err)
+	assert.Exactly(t, "null", string(data))
+	assert.NoError(t, json.Unmarshal(data, &g))
+	assert.Exactly(t, Geometry{}, g)
+
+	assert.ErrorIsKind(t, errors.NotValid, g.UnmarshalJSON(invalidJSON))
+}
+
+func TestGeometry_BinaryEncoding(t *testing.T) {
+	runner := func(g Geometry, want []byte) func(*testing.T) {
+		return func(t *testing.T) {
+			data, err := g.GobEncode()
+			assert.NoError(t, err)
+			assert.Exactly(t, want, data, t.Name()+": GobEncode")
+			data, err = g.Marshal()
+			assert.NoError(t, err)
+			assert.Exactly(t, want, data, t.Name()+": Marshal")
+
+			var decoded Geometry
+			assert.NoError(t, decoded.Unmarshal(data), "Unmarshal")
+			assert.Exactly(t, g, decoded)
+		}
+	}
+	t.Run("point", runner(MakePoint(4326, 1, -2), geometryPointMySQL))
+	t.Run("null", runner(Geometry{}, nil))
+
+	var buf [4]byte
+	_, err := MakePoint(0, 1, 2).MarshalTo(buf[:])
+	assert.ErrorIsKind(t, errors.NotValid, err)
+}
+
+func TestGeometry_GoString(t *testing.T) {
+	assert.Exactly(t, "null.MakeGeometry(3, []byte{0x1, 0x2})", MakeGeometry(3, []byte{1, 2}).GoString())
+	assert.Exactly(t, "null.Geometry{}", MakeGeometry(3, nil).SetNull().GoString())
+}
@@ -223,6 +223,12 @@ func (a Int64) MarshalTo(data []byte) (n int, err error) {
 	return 8, nil
 }
 
+// MarshalToSizedBuffer binary encoder for protocol buffers which writes to the
+// end of data. Required by code generated with gogo/protobuf >= v1.3.
+func (a Int64) MarshalToSizedBuffer(data []byte) (int, error) {
+	return marshalToSizedBuffer(a, data)
+}
+
 // Unmarshal binary decoder for protocol buffers. Implements proto.Unmarshaler.
 func (a *Int64) Unmarshal(data []byte) error {
 	if len(data) < 8 {
@@ -203,6 +203,12 @@ func (a String) MarshalTo(data []byte) (n int, err error) {
 	return
 }
 
+// MarshalToSizedBuffer binary encoder for protocol buffers which writes to the
+// end of data. Required by code generated with gogo/protobuf >= v1.3.
+func (a String) MarshalToSizedBuffer(data []byte) (int, error) {
+	return marshalToSizedBuffer(a, data)
+}
+
 // Unmarshal binary decoder for protocol buffers. Implements proto.Unmarshaler.
 func (a *String) Unmarshal(data []byte) error {
 	return a.UnmarshalText(data)
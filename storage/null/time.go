@@ -179,6 +179,12 @@ func (nt Time) MarshalTo(data []byte) (int, error) {
 	return copy(data, raw), err
 }
 
+// MarshalToSizedBuffer binary encoder for protocol buffers which writes to the
+// end of data. Required by code generated with gogo/protobuf >= v1.3.
+func (nt Time) MarshalToSizedBuffer(data []byte) (int, error) {
+	return marshalToSizedBuffer(nt, data)
+}
+
 // Unmarshal binary decoder for protocol buffers. Implements proto.Unmarshaler.
 func (nt *Time) Unmarshal(data []byte) error {
 	return nt.UnmarshalBinary(data)
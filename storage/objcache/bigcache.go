@@ -18,6 +18,7 @@ package objcache
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/allegro/bigcache"
@@ -44,19 +45,71 @@ func NewBigCacheClient(c bigcache.Config) NewStorageFn {
 		if c.Shards > 0 {
 			def = c
 		}
+		removed := &bigCacheRemovedKeys{}
+		onRemove := def.OnRemove
+		def.OnRemove = func(key string, entry []byte) {
+			removed.add(key)
+			if onRemove != nil {
+				onRemove(key, entry)
+			}
+		}
 		bc, err := bigcache.NewBigCache(def)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
-		return bigCacheWrapper{bc}, nil
+		return bigCacheWrapper{BigCache: bc, tags: &tagIndex{}, removed: removed}, nil
 	}
 }
 
+// bigCacheRemovedKeys collects the keys passed to the OnRemove callback of
+// bigcache. The callback runs while a shard of bigcache is locked, so the keys
+// get removed from the tag index after the Set call.
+type bigCacheRemovedKeys struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+// add copies the key because bigcache passes a key which refers to the memory
+// of its queue.
+func (rk *bigCacheRemovedKeys) add(key string) {
+	key = string(append([]byte(nil), key...))
+	rk.mu.Lock()
+	rk.keys = append(rk.keys, key)
+	rk.mu.Unlock()
+}
+
+func (rk *bigCacheRemovedKeys) take() (keys []string) {
+	rk.mu.Lock()
+	keys, rk.keys = rk.keys, nil
+	rk.mu.Unlock()
+	return keys
+}
+
+// bigCacheWrapper keeps the tags in an in-memory index. Keys which bigcache
+// removes after their life window or because of a full shard get removed from
+// the index.
 type bigCacheWrapper struct {
 	*bigcache.BigCache
+	tags    *tagIndex
+	removed *bigCacheRemovedKeys
 }
 
-func (w bigCacheWrapper) Set(_ context.Context, keys []string, values [][]byte, _ []time.Duration) (err error) {
+// pruneTags removes the keys removed by bigcache from the tag index. bigcache
+// reports a replaced entry like a removed one, so a key only gets pruned if it
+// does not exist anymore.
+func (w bigCacheWrapper) pruneTags() {
+	keys := w.removed.take()
+	if len(keys) == 0 {
+		return
+	}
+	w.tags.prune(keys, func(key string) bool {
+		_, err := w.BigCache.Get(key)
+		_, ok := err.(*bigcache.EntryNotFoundError)
+		return ok
+	})
+}
+
+func (w bigCacheWrapper) set(keys []string, values [][]byte) error {
 	for i, key := range keys {
 		if err := w.BigCache.Set(key, values[i]); err != nil {
 			// This error construct save some unneeded allocations.
@@ -66,6 +119,32 @@ func (w bigCacheWrapper) Set(_ context.Context, keys []string, values [][]byte,
 	return nil
 }
 
+func (w bigCacheWrapper) Set(_ context.Context, keys []string, values [][]byte, _ []time.Duration) (err error) {
+	err = w.set(keys, values)
+	w.pruneTags()
+	return err
+}
+
+func (w bigCacheWrapper) SetWithTags(_ context.Context, keys []string, values [][]byte, _ []time.Duration, tags [][]string) (err error) {
+	if err = w.set(keys, values); err != nil {
+		return errors.WithStack(err)
+	}
+	w.tags.add(keys, tags)
+	w.pruneTags()
+	return nil
+}
+
+func (w bigCacheWrapper) InvalidateTags(_ context.Context, tags []string) error {
+	for _, key := range w.tags.invalidate(tags) {
+		if err := w.BigCache.Delete(key); err != nil {
+			if _, ok := err.(*bigcache.EntryNotFoundError); !ok {
+				return errors.Wrapf(err, "[objcache] With key %q", key)
+			}
+		}
+	}
+	return nil
+}
+
 func (w bigCacheWrapper) Get(_ context.Context, keys []string) (values [][]byte, err error) {
 	for _, key := range keys {
 		v, err := w.BigCache.Get(key)
@@ -85,13 +164,21 @@ func (w bigCacheWrapper) Delete(_ context.Context, keys []string) (err error) {
 	for i := 0; i < len(keys) && err == nil; i++ {
 		err = w.BigCache.Delete(keys[i])
 	}
+	w.tags.remove(keys)
 	return
 }
 
 func (w bigCacheWrapper) Truncate(ctx context.Context) (err error) {
+	w.tags.reset()
 	return w.BigCache.Reset()
 }
 
+// BackendStats reports the number of entries and the bytes allocated by the
+// queues of the shards.
+func (w bigCacheWrapper) BackendStats() BackendStats {
+	return BackendStats{Length: int64(w.BigCache.Len()), Size: int64(w.BigCache.Capacity())}
+}
+
 func (w bigCacheWrapper) Close() error {
 	return nil
 }
@@ -0,0 +1,71 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build bigcache csall
+
+package objcache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/allegro/bigcache"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestBigCache_RemovedTags(t *testing.T) {
+	ctx := context.TODO()
+	var removed int
+	s, err := NewBigCacheClient(bigcache.Config{
+		Shards:             1,
+		LifeWindow:         time.Hour,
+		MaxEntriesInWindow: 10,
+		MaxEntrySize:       1 << 16,
+		HardMaxCacheSize:   1, // MB
+		OnRemove:           func(string, []byte) { removed++ },
+	})()
+	assert.NoError(t, err)
+	w := s.(bigCacheWrapper)
+
+	const keyCount = 20
+	value := make([]byte, 1<<16)
+	for i := 0; i < keyCount; i++ {
+		key := fmt.Sprintf("k%02d", i)
+		assert.NoError(t, w.SetWithTags(ctx, []string{key}, [][]byte{value}, nil, [][]string{{"t1"}}))
+		if i == 10 {
+			// The old entry of k00 gets removed later, but k00 keeps its tags.
+			assert.NoError(t, w.SetWithTags(ctx, []string{"k00"}, [][]byte{value}, nil, [][]string{{"t1"}}))
+		}
+	}
+	assert.NoError(t, w.Set(ctx, []string{"other"}, [][]byte{{1}}, nil))
+
+	var live []string
+	for i := 0; i < keyCount; i++ {
+		key := fmt.Sprintf("k%02d", i)
+		if v, err := w.BigCache.Get(key); err == nil && v != nil {
+			live = append(live, key)
+		}
+	}
+	assert.True(t, removed > 0, "the user defined OnRemove callback gets called")
+	assert.True(t, len(live) < keyCount, "keys must have been removed: %v", live)
+	assert.Exactly(t, "k00", live[0])
+	assert.NotEqual(t, "k01", live[1], "the old entry of k00 has been removed before k01")
+	assert.Len(t, w.tags.keyTags, len(live))
+	for _, key := range live {
+		_, ok := w.tags.keyTags[key]
+		assert.True(t, ok, "live key %q must keep its tags", key)
+	}
+}
@@ -55,6 +55,10 @@ func TestBigCache_ServiceComplexParallel(t *testing.T) {
 	newServiceComplexParallelTest(t, objcache.NewBigCacheClient(bigcache.Config{}), nil)
 }
 
+func TestBigCache_Tags(t *testing.T) {
+	newTestServiceTags(t, objcache.NewBigCacheClient(bigcache.Config{}))
+}
+
 func TestWithBigCache_DecoderError(t *testing.T) {
 	p, err := objcache.NewService(objcache.NewBlackHoleClient(nil), objcache.NewBigCacheClient(bigcache.Config{}), newSrvOpt(gobCodec{}))
 	if err != nil {
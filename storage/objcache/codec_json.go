@@ -0,0 +1,38 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import (
+	"encoding/json"
+	"io"
+)
+
+var _ Codecer = JSONCodec{}
+
+// JSONCodec encodes and decodes using package encoding/json.
+type JSONCodec struct{}
+
+// NewEncoder returns a new JSON encoder which writes to w.
+func (c JSONCodec) NewEncoder(w io.Writer) Encoder {
+	return json.NewEncoder(w)
+}
+
+// NewDecoder returns a new JSON decoder which reads from r.
+func (c JSONCodec) NewDecoder(r io.Reader) Decoder {
+	return json.NewDecoder(r)
+}
+
+// CodecID returns the ID used in the envelope.
+func (c JSONCodec) CodecID() uint8 { return CodecIDJSON }
@@ -0,0 +1,54 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build msgpack csall
+
+package objcache
+
+import (
+	"io"
+
+	"github.com/ugorji/go/codec"
+)
+
+var _ Codecer = MsgPackCodec{}
+
+var msgPackHandle codec.MsgpackHandle
+
+// MsgPackCodec encodes and decodes using the MessagePack format of package
+// github.com/ugorji/go/codec. The Handle can be nil to use the default handle.
+// Changing a handle while it gets used is not safe.
+type MsgPackCodec struct {
+	Handle *codec.MsgpackHandle
+}
+
+func (c MsgPackCodec) handle() *codec.MsgpackHandle {
+	if c.Handle != nil {
+		return c.Handle
+	}
+	return &msgPackHandle
+}
+
+// NewEncoder returns a new MessagePack encoder which writes to w.
+func (c MsgPackCodec) NewEncoder(w io.Writer) Encoder {
+	return codec.NewEncoder(w, c.handle())
+}
+
+// NewDecoder returns a new MessagePack decoder which reads from r.
+func (c MsgPackCodec) NewDecoder(r io.Reader) Decoder {
+	return codec.NewDecoder(r, c.handle())
+}
+
+// CodecID returns the ID used in the envelope.
+func (c MsgPackCodec) CodecID() uint8 { return CodecIDMsgPack }
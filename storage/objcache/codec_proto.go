@@ -0,0 +1,80 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build proto csall
+
+package objcache
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/corestoreio/errors"
+	"github.com/gogo/protobuf/proto"
+)
+
+var _ Codecer = ProtoCodec{}
+
+// ProtoCodec encodes and decodes types generated by gogo-protobuf, for example
+// the types generated by dmlgen with option WithProtobuf. The Service already
+// calls the Marshal and Unmarshal functions of generated types without any
+// codec. ProtoCodec additionally supports all types implementing
+// proto.Message and writes the codec ID into the envelope.
+type ProtoCodec struct{}
+
+// NewEncoder returns a new protobuf encoder which writes to w.
+func (c ProtoCodec) NewEncoder(w io.Writer) Encoder {
+	return protoEncoder{w: w}
+}
+
+// NewDecoder returns a new protobuf decoder which reads from r.
+func (c ProtoCodec) NewDecoder(r io.Reader) Decoder {
+	return protoDecoder{r: r}
+}
+
+// CodecID returns the ID used in the envelope.
+func (c ProtoCodec) CodecID() uint8 { return CodecIDProto }
+
+type protoEncoder struct {
+	w io.Writer
+}
+
+func (e protoEncoder) Encode(src interface{}) error {
+	m, ok := src.(proto.Message)
+	if !ok {
+		return errors.NotSupported.Newf("[objcache] ProtoCodec: Type %T does not implement proto.Message", src)
+	}
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = e.w.Write(data)
+	return errors.WithStack(err)
+}
+
+type protoDecoder struct {
+	r io.Reader
+}
+
+func (d protoDecoder) Decode(dst interface{}) error {
+	m, ok := dst.(proto.Message)
+	if !ok {
+		return errors.NotSupported.Newf("[objcache] ProtoCodec: Type %T does not implement proto.Message", dst)
+	}
+	data, err := ioutil.ReadAll(d.r)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(proto.Unmarshal(data, m))
+}
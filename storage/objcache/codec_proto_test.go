@@ -0,0 +1,43 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build proto csall
+
+package objcache
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/gogo/protobuf/types"
+)
+
+func TestProtoCodec(t *testing.T) {
+	t.Parallel()
+	var c ProtoCodec
+	var buf bytes.Buffer
+
+	assert.NoError(t, c.NewEncoder(&buf).Encode(&types.StringValue{Value: "Gopher"}))
+	var have types.StringValue
+	assert.NoError(t, c.NewDecoder(&buf).Decode(&have))
+	assert.Exactly(t, "Gopher", have.Value)
+
+	err := c.NewEncoder(&buf).Encode("Gopher")
+	assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	var haveStr string
+	err = c.NewDecoder(&buf).Decode(&haveStr)
+	assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+}
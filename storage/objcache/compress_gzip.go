@@ -0,0 +1,54 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/gzippool"
+)
+
+var _ Compressor = GzipCompressor{}
+
+// GzipCompressor compresses with gzip using the pooled writers of package
+// util/gzippool.
+type GzipCompressor struct{}
+
+// CompressorID returns the ID used in the envelope.
+func (GzipCompressor) CompressorID() uint8 { return CompressorIDGzip }
+
+// Compress writes the compressed src into dst.
+func (GzipCompressor) Compress(dst *bytes.Buffer, src []byte) error {
+	zw := gzippool.GetWriter(dst)
+	_, err := zw.Write(src)
+	gzippool.PutWriter(zw) // flushes
+	return errors.WithStack(err)
+}
+
+// Decompress writes the decompressed src into dst.
+func (GzipCompressor) Decompress(dst *bytes.Buffer, src []byte) error {
+	// gzippool.GetReader cannot be used because it ignores the error of a
+	// broken header and might return a nil reader.
+	zr, err := gzip.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return errors.NotValid.New(err, "[objcache] GzipCompressor: Invalid gzip header")
+	}
+	defer zr.Close()
+	_, err = io.Copy(dst, zr)
+	return errors.WithStack(err)
+}
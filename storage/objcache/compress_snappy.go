@@ -0,0 +1,49 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build snappy csall
+
+package objcache
+
+import (
+	"bytes"
+
+	"github.com/corestoreio/errors"
+	"github.com/golang/snappy"
+)
+
+var _ Compressor = SnappyCompressor{}
+
+// SnappyCompressor compresses with the fast snappy block format. It trades a
+// lower compression ratio for speed compared to the GzipCompressor.
+type SnappyCompressor struct{}
+
+// CompressorID returns the ID used in the envelope.
+func (SnappyCompressor) CompressorID() uint8 { return CompressorIDSnappy }
+
+// Compress writes the compressed src into dst.
+func (SnappyCompressor) Compress(dst *bytes.Buffer, src []byte) error {
+	_, err := dst.Write(snappy.Encode(nil, src))
+	return errors.WithStack(err)
+}
+
+// Decompress writes the decompressed src into dst.
+func (SnappyCompressor) Decompress(dst *bytes.Buffer, src []byte) error {
+	data, err := snappy.Decode(nil, src)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = dst.Write(data)
+	return errors.WithStack(err)
+}
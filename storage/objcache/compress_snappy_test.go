@@ -0,0 +1,48 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build snappy csall
+
+package objcache
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestSnappyCompressor(t *testing.T) {
+	t.Parallel()
+	p, err := NewService(nil, NewCacheSimpleInmemory, &ServiceOptions{Codec: JSONCodec{}, Compressor: SnappyCompressor{}})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+
+	ctx := context.TODO()
+	want := strings.Repeat("Gopher ", 100)
+	assert.NoError(t, p.Set(ctx, "k1", want, 0))
+
+	var have string
+	assert.NoError(t, p.Get(ctx, "k1", &have))
+	assert.Exactly(t, want, have)
+
+	raw, err := p.level2.Get(ctx, []string{"k1"})
+	assert.NoError(t, err)
+	assert.Exactly(t, CompressorIDSnappy, raw[0][3])
+
+	var dst bytes.Buffer
+	assert.Error(t, SnappyCompressor{}.Decompress(&dst, []byte("\xff\xff\xff")))
+}
@@ -17,9 +17,313 @@
 package objcache
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/corestoreio/pkg/sql/ddl"
 	"github.com/corestoreio/pkg/sql/dml"
+	"github.com/corestoreio/pkg/storage/null"
+)
+
+const (
+	dbDefaultTableName = "objcache"
+	dbDefaultBatchSize = 100
+	// dbMaxKeyLength must match the length of the column cache_key.
+	dbMaxKeyLength = 255
 )
 
-func NewDBClient(db dml.QueryExecPreparer) NewStorageFn {
-	panic("TODO")
+// DBOption applies several options for the database client.
+type DBOption struct {
+	// TableName of the key/value table. Defaults to "objcache".
+	TableName string
+	// SkipCreateTable disables the creation of the table, if it does not
+	// exists, when the client gets initialized.
+	SkipCreateTable bool
+	// BatchSize defines the maximum number of rows in one multi-row
+	// statement. Defaults to 100.
+	BatchSize int
+	// PurgeInterval runs a background goroutine which deletes all expired
+	// entries. Zero disables the purge. Expired entries are never returned by
+	// Get, but they still occupy space until they get purged or overwritten.
+	PurgeInterval time.Duration
+	// Log optional logger to report errors of the background purge.
+	Log log.Logger
+}
+
+// NewDBTable returns the definition of the key/value table used by the
+// database client. It can be used to create the table in a migration.
+func NewDBTable(tableName string) *ddl.Table {
+	if tableName == "" {
+		tableName = dbDefaultTableName
+	}
+	t := ddl.NewTable(tableName,
+		&ddl.Column{Field: "cache_key", ColumnType: fmt.Sprintf("varbinary(%d)", dbMaxKeyLength), Null: "NO", Key: "PRI"},
+		&ddl.Column{Field: "cache_value", ColumnType: "longblob", Null: "NO"},
+		&ddl.Column{Field: "expires_at", ColumnType: "bigint(20) unsigned", Null: "NO", Default: null.MakeString("0"),
+			Comment: "Unix time in milliseconds, 0 never expires"},
+	)
+	t.Indexes = ddl.Indexes{
+		{Name: ddl.IndexPrimary, Unique: true, Columns: []string{"cache_key"}},
+		{Name: strings.ToUpper(tableName) + "_EXPIRES_AT", Columns: []string{"expires_at"}},
+	}
+	return t
+}
+
+// NewDBClient uses a MySQL/MariaDB table as persistent cache. Set, Get and
+// Delete run batched multi-row statements. Argument `db` can be a
+// connection pool or a single connection but should not be a transaction. The
+// table gets created if it does not exists. Close stops the background purge
+// but does not close `db`.
+func NewDBClient(db dml.QueryExecPreparer, o *DBOption) NewStorageFn {
+	return func() (Storager, error) {
+		var opt DBOption
+		if o != nil {
+			opt = *o
+		}
+		if opt.TableName == "" {
+			opt.TableName = dbDefaultTableName
+		}
+		if opt.BatchSize < 1 {
+			opt.BatchSize = dbDefaultBatchSize
+		}
+		if err := dml.IsValidIdentifier(opt.TableName); err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		w := &dbWrapper{
+			db:        db,
+			o:         opt,
+			tableName: dml.Quoter.Name(opt.TableName),
+			stop:      make(chan struct{}),
+		}
+
+		if !opt.SkipCreateTable {
+			stmts := ddl.DiffTable(NewDBTable(opt.TableName), nil).Statements()
+			qry := strings.Replace(stmts[0], "CREATE TABLE ", "CREATE TABLE IF NOT EXISTS ", 1)
+			if _, err := db.ExecContext(context.Background(), qry); err != nil {
+				return nil, errors.Wrapf(err, "[objcache] Failed to create table %q", opt.TableName)
+			}
+		}
+
+		if opt.PurgeInterval > 0 {
+			w.wg.Add(1)
+			go w.purgeLoop()
+		}
+		return w, nil
+	}
+}
+
+type dbWrapper struct {
+	db        dml.QueryExecPreparer
+	o         DBOption
+	tableName string // quoted
+	stop      chan struct{}
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+}
+
+// dbExpiresAt converts a duration into a Unix time in milliseconds. Zero means
+// the entry never expires.
+func dbExpiresAt(n time.Time, d time.Duration) uint64 {
+	if d <= 0 {
+		return 0
+	}
+	return uint64(n.Add(d).UnixNano() / int64(time.Millisecond))
+}
+
+func dbNowMillis() uint64 {
+	return uint64(now().UnixNano() / int64(time.Millisecond))
+}
+
+func writePlaceholders(buf *bytes.Buffer, count int) {
+	buf.WriteByte('(')
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('?')
+	}
+	buf.WriteByte(')')
+}
+
+// dbValidateKeys checks that no key exceeds the length of the column
+// cache_key. Otherwise MySQL would truncate the key in non-strict mode and two
+// different keys could overwrite each other.
+func dbValidateKeys(keys []string) error {
+	for _, k := range keys {
+		if len(k) > dbMaxKeyLength {
+			return errors.NotValid.Newf("[objcache] DB key %q with length %d exceeds the maximum length of %d bytes", k, len(k), dbMaxKeyLength)
+		}
+	}
+	return nil
+}
+
+func (w *dbWrapper) Set(ctx context.Context, keys []string, values [][]byte, expirations []time.Duration) error {
+	if err := dbValidateKeys(keys); err != nil {
+		return errors.WithStack(err)
+	}
+	hasExp := len(expirations) > 0
+	n := now()
+	var buf bytes.Buffer
+	args := make([]interface{}, 0, 3*minInt(len(keys), w.o.BatchSize))
+	for start := 0; start < len(keys); start += w.o.BatchSize {
+		end := minInt(start+w.o.BatchSize, len(keys))
+		buf.Reset()
+		buf.WriteString("INSERT INTO ")
+		buf.WriteString(w.tableName)
+		buf.WriteString(" (`cache_key`,`cache_value`,`expires_at`) VALUES ")
+		args = args[:0]
+		for i := start; i < end; i++ {
+			if i > start {
+				buf.WriteByte(',')
+			}
+			writePlaceholders(&buf, 3)
+			var e time.Duration
+			if hasExp {
+				e = expirations[i]
+			}
+			val := values[i]
+			if val == nil {
+				val = []byte{} // column is NOT NULL
+			}
+			args = append(args, keys[i], val, dbExpiresAt(n, e))
+		}
+		buf.WriteString(" ON DUPLICATE KEY UPDATE `cache_value`=VALUES(`cache_value`), `expires_at`=VALUES(`expires_at`)")
+		if _, err := w.db.ExecContext(ctx, buf.String(), args...); err != nil {
+			return errors.Wrapf(err, "[objcache] DB Set with keys %v", keys[start:end])
+		}
+	}
+	return nil
+}
+
+func (w *dbWrapper) Get(ctx context.Context, keys []string) (values [][]byte, err error) {
+	if err := dbValidateKeys(keys); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	values = make([][]byte, len(keys))
+	positions := make(map[string][]int, len(keys))
+	for i, k := range keys {
+		positions[k] = append(positions[k], i)
+	}
+
+	nowMS := dbNowMillis()
+	var buf bytes.Buffer
+	args := make([]interface{}, 0, minInt(len(keys), w.o.BatchSize)+1)
+	for start := 0; start < len(keys); start += w.o.BatchSize {
+		end := minInt(start+w.o.BatchSize, len(keys))
+		buf.Reset()
+		buf.WriteString("SELECT `cache_key`,`cache_value` FROM ")
+		buf.WriteString(w.tableName)
+		buf.WriteString(" WHERE `cache_key` IN ")
+		writePlaceholders(&buf, end-start)
+		buf.WriteString(" AND (`expires_at`=0 OR `expires_at`>?)")
+		args = args[:0]
+		for _, k := range keys[start:end] {
+			args = append(args, k)
+		}
+		args = append(args, nowMS)
+
+		if err := w.query(ctx, buf.String(), args, positions, values); err != nil {
+			return nil, errors.Wrapf(err, "[objcache] DB Get with keys %v", keys[start:end])
+		}
+	}
+	return values, nil
+}
+
+func (w *dbWrapper) query(ctx context.Context, qry string, args []interface{}, positions map[string][]int, values [][]byte) (err error) {
+	rows, err := w.db.QueryContext(ctx, qry, args...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if errC := rows.Close(); err == nil && errC != nil {
+			err = errors.WithStack(errC)
+		}
+	}()
+	for rows.Next() {
+		var key, val []byte
+		if err = rows.Scan(&key, &val); err != nil {
+			return errors.WithStack(err)
+		}
+		if val == nil {
+			val = []byte{} // found but empty, nil would mean not found
+		}
+		for _, pos := range positions[string(key)] {
+			values[pos] = val
+		}
+	}
+	return errors.WithStack(rows.Err())
+}
+
+func (w *dbWrapper) Delete(ctx context.Context, keys []string) error {
+	if err := dbValidateKeys(keys); err != nil {
+		return errors.WithStack(err)
+	}
+	var buf bytes.Buffer
+	args := make([]interface{}, 0, minInt(len(keys), w.o.BatchSize))
+	for start := 0; start < len(keys); start += w.o.BatchSize {
+		end := minInt(start+w.o.BatchSize, len(keys))
+		buf.Reset()
+		buf.WriteString("DELETE FROM ")
+		buf.WriteString(w.tableName)
+		buf.WriteString(" WHERE `cache_key` IN ")
+		writePlaceholders(&buf, end-start)
+		args = args[:0]
+		for _, k := range keys[start:end] {
+			args = append(args, k)
+		}
+		if _, err := w.db.ExecContext(ctx, buf.String(), args...); err != nil {
+			return errors.Wrapf(err, "[objcache] DB Delete with keys %v", keys[start:end])
+		}
+	}
+	return nil
+}
+
+func (w *dbWrapper) Truncate(ctx context.Context) error {
+	if _, err := w.db.ExecContext(ctx, "TRUNCATE TABLE "+w.tableName); err != nil {
+		return errors.Wrapf(err, "[objcache] DB Truncate table %s", w.tableName)
+	}
+	return nil
+}
+
+// purge deletes all expired entries.
+func (w *dbWrapper) purge(ctx context.Context) error {
+	_, err := w.db.ExecContext(ctx, "DELETE FROM "+w.tableName+" WHERE `expires_at`>0 AND `expires_at`<=?", dbNowMillis())
+	return errors.Wrapf(err, "[objcache] DB purge table %s", w.tableName)
+}
+
+func (w *dbWrapper) purgeLoop() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.o.PurgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if err := w.purge(context.Background()); err != nil && w.o.Log != nil && w.o.Log.IsInfo() {
+				w.o.Log.Info("objcache.dbWrapper.purge.error", log.Err(err), log.String("table", w.o.TableName))
+			}
+		}
+	}
+}
+
+// Close stops the background purge. The database connection stays open.
+func (w *dbWrapper) Close() error {
+	w.stopOnce.Do(func() { close(w.stop) })
+	w.wg.Wait()
+	return nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
 }
@@ -0,0 +1,140 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build db csall
+
+package objcache
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sql/dmltest"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+var _ Storager = (*dbWrapper)(nil)
+
+const dbCreateTable = "CREATE TABLE IF NOT EXISTS `objcache` (\n" +
+	"  `cache_key` varbinary(255) NOT NULL,\n" +
+	"  `cache_value` longblob NOT NULL,\n" +
+	"  `expires_at` bigint(20) unsigned NOT NULL DEFAULT 0 COMMENT 'Unix time in milliseconds, 0 never expires',\n" +
+	"  PRIMARY KEY (`cache_key`),\n" +
+	"  KEY `OBJCACHE_EXPIRES_AT` (`expires_at`)\n)"
+
+func TestNewDBClient(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta(dbCreateTable)).WillReturnResult(sqlmock.NewResult(0, 0))
+	s, err := NewDBClient(dbc.DB, &DBOption{BatchSize: 2})()
+	assert.NoError(t, err)
+	ctx := context.TODO()
+
+	t.Run("Set batched", func(t *testing.T) {
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `objcache` (`cache_key`,`cache_value`,`expires_at`) VALUES (?,?,?),(?,?,?) ON DUPLICATE KEY UPDATE `cache_value`=VALUES(`cache_value`), `expires_at`=VALUES(`expires_at`)")).
+			WithArgs("k1", []byte("v1"), 0, "k2", []byte("v2"), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("INSERT INTO `objcache` (`cache_key`,`cache_value`,`expires_at`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `cache_value`=VALUES(`cache_value`), `expires_at`=VALUES(`expires_at`)")).
+			WithArgs("k3", []byte{}, 0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, s.Set(ctx, []string{"k1", "k2", "k3"}, [][]byte{[]byte("v1"), []byte("v2"), nil}, []time.Duration{0, time.Minute, 0}))
+	})
+
+	t.Run("Get batched", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `cache_key`,`cache_value` FROM `objcache` WHERE `cache_key` IN (?,?) AND (`expires_at`=0 OR `expires_at`>?)")).
+			WithArgs("k1", "kX", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"cache_key", "cache_value"}).AddRow([]byte("k1"), []byte("v1")))
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `cache_key`,`cache_value` FROM `objcache` WHERE `cache_key` IN (?) AND (`expires_at`=0 OR `expires_at`>?)")).
+			WithArgs("k1", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"cache_key", "cache_value"}).AddRow([]byte("k1"), []byte("v1")))
+
+		vals, err := s.Get(ctx, []string{"k1", "kX", "k1"})
+		assert.NoError(t, err)
+		assert.Exactly(t, [][]byte{[]byte("v1"), nil, []byte("v1")}, vals)
+	})
+
+	t.Run("Get error", func(t *testing.T) {
+		dbMock.ExpectQuery(dmltest.SQLMockQuoteMeta("SELECT `cache_key`,`cache_value` FROM `objcache` WHERE `cache_key` IN (?) AND (`expires_at`=0 OR `expires_at`>?)")).
+			WillReturnError(errors.ConnectionFailed.Newf("ups"))
+		vals, err := s.Get(ctx, []string{"k1"})
+		assert.ErrorIsKind(t, errors.ConnectionFailed, err)
+		assert.Nil(t, vals)
+	})
+
+	t.Run("key too long", func(t *testing.T) {
+		long := strings.Repeat("k", dbMaxKeyLength+1)
+		err := s.Set(ctx, []string{"k1", long}, [][]byte{[]byte("v1"), []byte("v2")}, nil)
+		assert.ErrorIsKind(t, errors.NotValid, err)
+		vals, err := s.Get(ctx, []string{long})
+		assert.ErrorIsKind(t, errors.NotValid, err)
+		assert.Nil(t, vals)
+		assert.ErrorIsKind(t, errors.NotValid, s.Delete(ctx, []string{long}))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `objcache` WHERE `cache_key` IN (?,?)")).
+			WithArgs("k1", "k2").WillReturnResult(sqlmock.NewResult(0, 2))
+		assert.NoError(t, s.Delete(ctx, []string{"k1", "k2"}))
+	})
+
+	t.Run("Truncate", func(t *testing.T) {
+		dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("TRUNCATE TABLE `objcache`")).WillReturnResult(sqlmock.NewResult(0, 0))
+		assert.NoError(t, s.Truncate(ctx))
+	})
+
+	assert.NoError(t, s.Close())
+}
+
+func TestNewDBClient_Purge(t *testing.T) {
+	t.Parallel()
+	dbc, dbMock := dmltest.MockDB(t)
+	defer dmltest.MockClose(t, dbc, dbMock)
+
+	purged := make(chan struct{})
+	dbMock.ExpectExec(dmltest.SQLMockQuoteMeta("DELETE FROM `sessions` WHERE `expires_at`>0 AND `expires_at`<=?")).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+
+	s, err := NewDBClient(dbc.DB, &DBOption{
+		TableName:       "sessions",
+		SkipCreateTable: true,
+		PurgeInterval:   5 * time.Millisecond,
+	})()
+	assert.NoError(t, err)
+	go func() {
+		for dbMock.ExpectationsWereMet() != nil {
+			time.Sleep(time.Millisecond)
+		}
+		close(purged)
+	}()
+	select {
+	case <-purged:
+	case <-time.After(time.Second):
+		t.Fatal("purge has not been called")
+	}
+	assert.NoError(t, s.Close())
+}
+
+func TestNewDBClient_InvalidTableName(t *testing.T) {
+	t.Parallel()
+	_, err := NewDBClient(nil, &DBOption{TableName: "a-b"})()
+	assert.ErrorIsKind(t, errors.NotValid, err)
+}
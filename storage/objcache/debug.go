@@ -0,0 +1,100 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// DebugHandler returns an http.Handler to inspect the cache. It must not be
+// reachable from the public because it returns the raw cached values.
+//
+//	GET    without key parameter returns the statistics, see Stats.
+//	GET    ?key=xyz returns the size and the raw bytes of the key per level.
+//	DELETE ?key=xyz deletes the key in all levels.
+//
+// The lookups of the handler are not part of the statistics.
+func (tr *Service) DebugHandler() http.Handler {
+	return http.HandlerFunc(tr.serveDebug)
+}
+
+type debugLevel struct {
+	Found bool
+	Size  int
+	Value []byte `json:",omitempty"` // base64 encoded
+}
+
+type debugKey struct {
+	Key    string
+	Level1 *debugLevel `json:",omitempty"`
+	Level2 debugLevel
+}
+
+func (tr *Service) serveDebug(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("key")
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		writeDebugJSON(w, tr.Stats())
+	case r.Method == http.MethodGet:
+		dk := debugKey{Key: key}
+		keys := []string{key}
+		if tr.level1 != nil {
+			dk.Level1 = new(debugLevel)
+			if err := debugLookup(r, unwrapStats(tr.level1), keys, dk.Level1); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		if err := debugLookup(r, unwrapStats(tr.level2), keys, &dk.Level2); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeDebugJSON(w, dk)
+	case r.Method == http.MethodDelete && key != "":
+		if err := tr.Delete(r.Context(), key); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		http.Error(w, "missing key parameter", http.StatusBadRequest)
+	default:
+		w.Header().Set("Allow", "GET, DELETE")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func debugLookup(r *http.Request, s Storager, keys []string, dl *debugLevel) error {
+	vals, err := s.Get(r.Context(), keys)
+	if err != nil {
+		return err
+	}
+	if len(vals) > 0 && vals[0] != nil {
+		dl.Found = true
+		dl.Size = len(vals[0])
+		dl.Value = vals[0]
+	}
+	return nil
+}
+
+func writeDebugJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -16,8 +16,32 @@
 // a cache reducing GC.
 //
 // A Cache can be either in memory or a persistent one. Cache adapters are
-// available for bigcache or Redis. To enable the cache adapter use build tags
-// "bigcache" or "redis" or "csall". More cache adapters might follow.
+// available for bigcache, Redis or a MySQL/MariaDB table. To enable the cache
+// adapter use build tags "bigcache", "redis", "db" or "csall". More cache
+// adapters might follow.
+//
+// NewRedisShardedClient distributes the keys with Redis Cluster hash slots and
+// rendezvous hashing across several Redis instances. A failed instance results
+// in cache misses for its keys.
+//
+// Tags group several keys, for example all products of a category. Set the
+// tags with Service.SetWithTags and delete all keys of a tag with
+// Service.InvalidateTags. The in-memory map, LRU, bigcache, file and Redis
+// adapters support tags.
+//
+// Codecs are available for JSON, gob, msgpack and gogo-protobuf, the last three
+// via build tags "gob", "msgpack" and "proto". ServiceOptions.Compressor
+// compresses the values with gzip or, via build tag "snappy", with snappy.
+// ServiceOptions.Envelope stores the codec and compressor IDs with each value,
+// so a changed codec results in cache misses instead of decoding errors.
+//
+// Service.GetOrLoad calls a loader function on a cache miss, deduplicates
+// concurrent loads of the same key, serves stale values while refreshing them
+// in the background and caches NotFound results of the loader.
+//
+// ServiceOptions.CollectStats enables hit, miss, size and latency statistics
+// per cache level. Service.StatsVar publishes them via package expvar and
+// Service.DebugHandler allows to inspect and delete single keys.
 //
 // Use case: Caching millions of Go types as a byte slice reduces the pressure
 // to the GC.
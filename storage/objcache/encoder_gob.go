@@ -38,3 +38,6 @@ func (c GobCodec) NewEncoder(w io.Writer) Encoder {
 func (c GobCodec) NewDecoder(r io.Reader) Decoder {
 	return gob.NewDecoder(r)
 }
+
+// CodecID returns the ID used in the envelope.
+func (c GobCodec) CodecID() uint8 { return CodecIDGob }
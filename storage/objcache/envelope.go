@@ -0,0 +1,151 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import (
+	"bytes"
+	"encoding"
+
+	"github.com/corestoreio/errors"
+)
+
+// IDs of the codecs and compressors of this package. Custom implementations
+// should use IDs greater than 127.
+const (
+	CodecIDGob     uint8 = 1
+	CodecIDJSON    uint8 = 2
+	CodecIDMsgPack uint8 = 3
+	CodecIDProto   uint8 = 4
+
+	CompressorIDGzip   uint8 = 1
+	CompressorIDSnappy uint8 = 2
+)
+
+const (
+	envelopeMagic      = 0xC5
+	envelopeVersion    = 1
+	envelopeHeaderSize = 4 // magic, version, codec ID, compressor ID
+	// envelopeCodecSelf identifies values encoded by their own Marshal
+	// function.
+	envelopeCodecSelf = 0xFF
+)
+
+// CodecIDer gets implemented by a Codecer to identify its format in the
+// envelope of a cached value. A Codecer without this interface has the ID
+// zero.
+type CodecIDer interface {
+	CodecID() uint8
+}
+
+// Compressor compresses the encoded values before they get written to a
+// storage backend. The ID gets stored in the envelope of a cached value and
+// must not be zero. A Compressor must be safe for concurrent use.
+type Compressor interface {
+	CompressorID() uint8
+	Compress(dst *bytes.Buffer, src []byte) error
+	Decompress(dst *bytes.Buffer, src []byte) error
+}
+
+func isSelfMarshaler(src interface{}) bool {
+	switch src.(type) {
+	case marshaler, encoding.TextMarshaler, encoding.BinaryMarshaler:
+		return true
+	}
+	return false
+}
+
+func isSelfUnmarshaler(dst interface{}) bool {
+	switch dst.(type) {
+	case unmarshaler, encoding.TextUnmarshaler, encoding.BinaryUnmarshaler:
+		return true
+	}
+	return false
+}
+
+func envelopeCodecID(c Codecer, self bool) uint8 {
+	if self {
+		return envelopeCodecSelf
+	}
+	if pc, ok := c.(*pooledCodec); ok {
+		c = pc.codec
+	}
+	if ci, ok := c.(CodecIDer); ok {
+		return ci.CodecID()
+	}
+	return 0
+}
+
+func (tr *Service) useEnvelope() bool {
+	return tr.so.Envelope || tr.so.Compressor != nil
+}
+
+// encodeValue encodes src into buf. If enabled, the value gets compressed and
+// wrapped into the envelope.
+func (tr *Service) encodeValue(buf *bytes.Buffer, key string, src interface{}) error {
+	if !tr.useEnvelope() {
+		return encodeOne(tr.so.Codec, buf, key, src)
+	}
+
+	start := buf.Len()
+	buf.Write([]byte{envelopeMagic, envelopeVersion, envelopeCodecID(tr.so.Codec, isSelfMarshaler(src)), 0})
+	if err := encodeOne(tr.so.Codec, buf, key, src); err != nil {
+		return errors.WithStack(err)
+	}
+
+	c := tr.so.Compressor
+	payload := buf.Bytes()[start+envelopeHeaderSize:]
+	if c == nil || len(payload) < tr.so.CompressThreshold {
+		return nil
+	}
+	var cBuf bytes.Buffer
+	if err := c.Compress(&cBuf, payload); err != nil {
+		return errors.Wrapf(err, "[objcache] Compress with key %q", key)
+	}
+	if cBuf.Len() >= len(payload) {
+		return nil // not worth it
+	}
+	buf.Truncate(start + envelopeHeaderSize)
+	buf.Bytes()[start+envelopeHeaderSize-1] = c.CompressorID()
+	_, _ = buf.Write(cBuf.Bytes())
+	return nil
+}
+
+// decodeValue decodes data into dst. If the envelope is enabled and the data
+// has been written by another codec or compressor or without an envelope,
+// decodeValue returns false and dst stays untouched. Such a value gets treated
+// like a cache miss.
+func (tr *Service) decodeValue(data []byte, key string, dst interface{}) (bool, error) {
+	if !tr.useEnvelope() || data == nil {
+		return data != nil, decodeOne(tr.so.Codec, data, key, dst)
+	}
+
+	if len(data) < envelopeHeaderSize || data[0] != envelopeMagic || data[1] != envelopeVersion ||
+		data[2] != envelopeCodecID(tr.so.Codec, isSelfUnmarshaler(dst)) {
+		return false, nil
+	}
+	payload := data[envelopeHeaderSize:]
+	if cID := data[3]; cID != 0 {
+		c := tr.so.Compressor
+		if c == nil || c.CompressorID() != cID {
+			return false, nil
+		}
+		var buf bytes.Buffer
+		if err := c.Decompress(&buf, payload); err != nil {
+			return false, errors.Wrapf(err, "[objcache] Decompress with key %q", key)
+		}
+		payload = buf.Bytes()
+	}
+	return true, decodeOne(tr.so.Codec, payload, key, dst)
+}
@@ -0,0 +1,150 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+// otherJSONCodec simulates a changed codec.
+type otherJSONCodec struct {
+	JSONCodec
+}
+
+func (otherJSONCodec) CodecID() uint8 { return 200 }
+
+type envelopeTestProduct struct {
+	SKU  string
+	Name string
+}
+
+func newEnvelopeTestService(t *testing.T, mc *mapCache, so *ServiceOptions) *Service {
+	p, err := NewService(nil, func() (Storager, error) { return mc, nil }, so)
+	assert.NoError(t, err)
+	return p
+}
+
+func TestService_Envelope(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+	want := envelopeTestProduct{SKU: "SKU-1", Name: strings.Repeat("Gopher ", 100)}
+
+	t.Run("roundtrip with compression", func(t *testing.T) {
+		mc := &mapCache{}
+		p := newEnvelopeTestService(t, mc, &ServiceOptions{Codec: JSONCodec{}, Compressor: GzipCompressor{}, CompressThreshold: 100})
+		assert.NoError(t, p.Set(ctx, "large", want, 0))
+		assert.NoError(t, p.Set(ctx, "small", "Gopher", 0))
+
+		raw, err := mc.Get(ctx, []string{"large", "small"})
+		assert.NoError(t, err)
+		assert.Exactly(t, []byte{envelopeMagic, envelopeVersion, CodecIDJSON, CompressorIDGzip}, raw[0][:envelopeHeaderSize])
+		assert.True(t, len(raw[0]) < len(want.Name), "value should be compressed, has %d bytes", len(raw[0]))
+		assert.Exactly(t, "\xc5\x01\x02\x00\"Gopher\"\n", string(raw[1]))
+
+		var have envelopeTestProduct
+		assert.NoError(t, p.Get(ctx, "large", &have))
+		assert.Exactly(t, want, have)
+		var haveStr string
+		assert.NoError(t, p.Get(ctx, "small", &haveStr))
+		assert.Exactly(t, "Gopher", haveStr)
+	})
+
+	t.Run("self marshaler", func(t *testing.T) {
+		p := newEnvelopeTestService(t, &mapCache{}, &ServiceOptions{Codec: JSONCodec{}, Envelope: true})
+		assert.NoError(t, p.Set(ctx, "kt", encodingText("Hello"), 0))
+		var have encodingText
+		assert.NoError(t, p.Get(ctx, "kt", &have))
+		assert.Exactly(t, encodingText("Hello"), have)
+	})
+
+	t.Run("changed codec is a miss", func(t *testing.T) {
+		mc := &mapCache{}
+		p1 := newEnvelopeTestService(t, mc, &ServiceOptions{Codec: JSONCodec{}, Envelope: true})
+		assert.NoError(t, p1.Set(ctx, "k1", want, 0))
+
+		p2 := newEnvelopeTestService(t, mc, &ServiceOptions{Codec: otherJSONCodec{}, Envelope: true})
+		var have envelopeTestProduct
+		assert.NoError(t, p2.Get(ctx, "k1", &have))
+		assert.Exactly(t, envelopeTestProduct{}, have)
+	})
+
+	t.Run("changed compressor is a miss", func(t *testing.T) {
+		mc := &mapCache{}
+		p1 := newEnvelopeTestService(t, mc, &ServiceOptions{Codec: JSONCodec{}, Compressor: GzipCompressor{}})
+		assert.NoError(t, p1.Set(ctx, "k1", want, 0))
+
+		p2 := newEnvelopeTestService(t, mc, &ServiceOptions{Codec: JSONCodec{}, Envelope: true})
+		var have envelopeTestProduct
+		assert.NoError(t, p2.Get(ctx, "k1", &have))
+		assert.Exactly(t, envelopeTestProduct{}, have)
+	})
+
+	t.Run("value without envelope is a miss", func(t *testing.T) {
+		mc := &mapCache{}
+		p1 := newEnvelopeTestService(t, mc, &ServiceOptions{Codec: JSONCodec{}})
+		assert.NoError(t, p1.Set(ctx, "k1", want, 0))
+
+		p2 := newEnvelopeTestService(t, mc, &ServiceOptions{Codec: JSONCodec{}, Envelope: true})
+		var have envelopeTestProduct
+		assert.NoError(t, p2.Get(ctx, "k1", &have))
+		assert.Exactly(t, envelopeTestProduct{}, have)
+	})
+
+	t.Run("GetOrLoad reloads after codec change", func(t *testing.T) {
+		mc := &mapCache{}
+		loader := func(_ context.Context, _ string) (interface{}, error) { return want, nil }
+		var have envelopeTestProduct
+
+		p1 := newEnvelopeTestService(t, mc, &ServiceOptions{Codec: JSONCodec{}, Envelope: true})
+		assert.NoError(t, p1.GetOrLoad(ctx, "k1", &have, loader, time.Minute))
+		assert.Exactly(t, want, have)
+
+		p2 := newEnvelopeTestService(t, mc, &ServiceOptions{Codec: otherJSONCodec{}, Envelope: true})
+		have = envelopeTestProduct{}
+		assert.NoError(t, p2.GetOrLoad(ctx, "k1", &have, loader, time.Minute))
+		assert.Exactly(t, want, have)
+	})
+}
+
+func TestGzipCompressor(t *testing.T) {
+	t.Parallel()
+	src := bytes.Repeat([]byte("Gopher "), 100)
+	var c GzipCompressor
+	var buf bytes.Buffer
+	assert.NoError(t, c.Compress(&buf, src))
+	assert.True(t, buf.Len() < len(src), "compressed size %d", buf.Len())
+
+	var dst bytes.Buffer
+	assert.NoError(t, c.Decompress(&dst, buf.Bytes()))
+	assert.Exactly(t, src, dst.Bytes())
+
+	err := c.Decompress(&dst, []byte("no gzip"))
+	assert.True(t, errors.NotValid.Match(err), "%+v", err)
+
+	// Valid magic bytes but an unknown compression method.
+	corrupt := append([]byte{0x1f, 0x8b, 0x07}, make([]byte, 20)...)
+	err = c.Decompress(&dst, corrupt)
+	assert.True(t, errors.NotValid.Match(err), "%+v", err)
+
+	err = c.Decompress(&dst, buf.Bytes()[:buf.Len()-10])
+	assert.Error(t, err)
+}
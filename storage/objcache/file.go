@@ -21,8 +21,10 @@ import (
 	"context"
 	"crypto/sha1"
 	"encoding/hex"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -114,6 +116,10 @@ func (fs *fileStorage) writeFileContent(fileName string, data []byte, expires ti
 func (fs *fileStorage) Set(_ context.Context, keys []string, values [][]byte, expires []time.Duration) (err error) {
 	fs.mu.Lock()
 	defer fs.mu.Unlock()
+	return fs.set(keys, values, expires)
+}
+
+func (fs *fileStorage) set(keys []string, values [][]byte, expires []time.Duration) error {
 	for i, key := range keys {
 		kfn, err := fs.getCacheFileName(key)
 		if err != nil {
@@ -130,6 +136,119 @@ func (fs *fileStorage) Set(_ context.Context, keys []string, values [][]byte, ex
 	return nil
 }
 
+// SetWithTags writes the values like Set and adds the cache file names to one
+// file per tag in the directory "tags" below the Path. Each write rewrites the
+// tag file without duplicates and without the names of removed cache files. A
+// key keeps its previous tags until they get invalidated.
+func (fs *fileStorage) SetWithTags(_ context.Context, keys []string, values [][]byte, expires []time.Duration, tags [][]string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if err := fs.set(keys, values, expires); err != nil {
+		return errors.WithStack(err)
+	}
+
+	tagFiles := make(map[string][]string) // tag => cache file names
+	for i, key := range keys {
+		if i >= len(tags) || len(tags[i]) == 0 {
+			continue
+		}
+		kfn, err := fs.getCacheFileName(key)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, tag := range tags[i] {
+			tagFiles[tag] = append(tagFiles[tag], kfn)
+		}
+	}
+	if len(tagFiles) == 0 {
+		return nil
+	}
+
+	tagPath := filepath.Join(fs.cfg.Path, "tags")
+	if err := os.MkdirAll(tagPath, fs.cfg.DirectoryMode); err != nil {
+		return errors.Wrapf(err, "[objcache] FileStorage TagPath %q", tagPath)
+	}
+	for tag, fileNames := range tagFiles {
+		if err := fs.writeTagFile(fs.tagFileName(tag), fileNames); err != nil {
+			return errors.Wrapf(err, "[objcache] FileStorage with tag %q", tag)
+		}
+	}
+	return nil
+}
+
+// writeTagFile merges the file names into the tag file. The tag file gets
+// written to a temporary file and renamed afterwards.
+func (fs *fileStorage) writeTagFile(tagFileName string, fileNames []string) (err error) {
+	data, err := ioutil.ReadFile(tagFileName)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+	seen := make(map[string]bool, len(fileNames))
+	var buf bytes.Buffer
+	for _, fn := range fileNames {
+		if !seen[fn] {
+			seen[fn] = true
+			buf.WriteString(fn)
+			buf.WriteByte('\n')
+		}
+	}
+	for _, fn := range strings.Split(string(data), "\n") {
+		if fn == "" || seen[fn] {
+			continue
+		}
+		seen[fn] = true
+		ok, err := fileExists(fn)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if ok {
+			buf.WriteString(fn)
+			buf.WriteByte('\n')
+		}
+	}
+
+	tmpFileName := tagFileName + ".tmp"
+	if err = ioutil.WriteFile(tmpFileName, buf.Bytes(), fs.cfg.FileMode); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(tmpFileName, tagFileName))
+}
+
+// InvalidateTags removes all cache files listed in the tag files and the tag
+// files itself.
+func (fs *fileStorage) InvalidateTags(_ context.Context, tags []string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	for _, tag := range tags {
+		tfn := fs.tagFileName(tag)
+		data, err := ioutil.ReadFile(tfn)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "[objcache] FileStorage with tag %q", tag)
+		}
+		for _, fn := range strings.Split(string(data), "\n") {
+			if fn == "" {
+				continue
+			}
+			if err := os.Remove(fn); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "[objcache] FileStorage with tag %q", tag)
+			}
+		}
+		if err := os.Remove(tfn); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "[objcache] FileStorage with tag %q", tag)
+		}
+	}
+	return nil
+}
+
+func (fs *fileStorage) tagFileName(tag string) string {
+	m := sha1.New()
+	m.Write([]byte(tag))
+	return filepath.Join(fs.cfg.Path, "tags", hex.EncodeToString(m.Sum(nil))+".tag")
+}
+
 func (fs *fileStorage) lookupFileContent(fileName string, values [][]byte) ([][]byte, error) {
 	if ok, err := fileExists(fileName); !ok {
 		return append(values, nil), err
@@ -17,16 +17,67 @@
 package objcache_test
 
 import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/corestoreio/pkg/storage/objcache"
+	"github.com/corestoreio/pkg/util/assert"
 )
 
 func TestNewFileSystemClient_Delete(t *testing.T) {
 	newTestServiceDelete(t, objcache.NewFileSystemClient(nil))
 }
 
+func TestNewFileSystemClient_Tags(t *testing.T) {
+	newTestServiceTags(t, objcache.NewFileSystemClient(&objcache.FileSystemConfig{
+		Path:         "testdata/fs_tags",
+		CleanOnClose: true,
+	}))
+}
+
+func TestNewFileSystemClient_TagFile(t *testing.T) {
+	ctx := context.TODO()
+	const path = "testdata/fs_tagfile"
+	st, err := objcache.NewFileSystemClient(&objcache.FileSystemConfig{
+		Path:         path,
+		CleanOnClose: true,
+	})()
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, st.Close()) }()
+	s := st.(interface {
+		objcache.Storager
+		objcache.TagStorager
+	})
+
+	m := sha1.Sum([]byte("t1"))
+	tagFileName := filepath.Join(path, "tags", hex.EncodeToString(m[:])+".tag")
+	tagFileLines := func() []string {
+		data, err := ioutil.ReadFile(tagFileName)
+		assert.NoError(t, err)
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+
+	assert.NoError(t, s.SetWithTags(ctx, []string{"k1", "k2"}, [][]byte{{1}, {2}}, nil, [][]string{{"t1", "t1"}, {"t1"}}))
+	assert.Len(t, tagFileLines(), 2)
+	assert.NoError(t, s.SetWithTags(ctx, []string{"k1"}, [][]byte{{1}}, nil, [][]string{{"t1"}}))
+	assert.Len(t, tagFileLines(), 2, "k1 must not be duplicated")
+
+	assert.NoError(t, s.Delete(ctx, []string{"k2"}))
+	assert.NoError(t, s.SetWithTags(ctx, []string{"k3"}, [][]byte{{3}}, nil, [][]string{{"t1"}}))
+	assert.Len(t, tagFileLines(), 2, "the deleted k2 gets removed")
+
+	assert.NoError(t, s.InvalidateTags(ctx, []string{"t1"}))
+	vals, err := s.Get(ctx, []string{"k1", "k3"})
+	assert.NoError(t, err)
+	assert.Exactly(t, [][]byte{nil, nil}, vals)
+}
+
 func TestNewFileSystemClient_Expires(t *testing.T) {
 	testExpiration(t, func() {
 		time.Sleep(time.Second * 2)
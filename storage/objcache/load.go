@@ -0,0 +1,295 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import (
+	"bytes"
+	"context"
+	encbin "encoding/binary"
+	"math/rand"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+)
+
+// LoaderFn loads the value of a key from its source, for example a database,
+// when the key cannot be found in the cache. The returned value gets encoded
+// like in Service.Set. A returned error with kind NotFound gets cached if
+// ServiceOptions.NegativeTTL has been set.
+type LoaderFn func(ctx context.Context, key string) (interface{}, error)
+
+const (
+	loadEntryVersion    = 1
+	loadEntryHeaderSize = 18 // version, flags, soft and hard expiration
+	loadFlagNotFound    = 1 << 0
+)
+
+// loadEntry gets written by GetOrLoad. The expirations are Unix timestamps in
+// nano seconds, zero means that the entry does not expire.
+type loadEntry struct {
+	flags       byte
+	softExpires int64
+	hardExpires int64
+	payload     []byte
+}
+
+func (e *loadEntry) marshal() []byte {
+	data := make([]byte, loadEntryHeaderSize, loadEntryHeaderSize+len(e.payload))
+	data[0] = loadEntryVersion
+	data[1] = e.flags
+	encbin.BigEndian.PutUint64(data[2:10], uint64(e.softExpires))
+	encbin.BigEndian.PutUint64(data[10:18], uint64(e.hardExpires))
+	return append(data, e.payload...)
+}
+
+func (e *loadEntry) unmarshal(data []byte) error {
+	if len(data) < loadEntryHeaderSize || data[0] != loadEntryVersion {
+		return errors.NotValid.Newf("[objcache] GetOrLoad entry has an invalid header. Has the key been written with Set?")
+	}
+	e.flags = data[1]
+	e.softExpires = int64(encbin.BigEndian.Uint64(data[2:10]))
+	e.hardExpires = int64(encbin.BigEndian.Uint64(data[10:18]))
+	e.payload = data[loadEntryHeaderSize:]
+	return nil
+}
+
+func (e *loadEntry) isStale(nowNano int64) bool {
+	return e.softExpires > 0 && e.softExpires <= nowNano
+}
+
+func (e *loadEntry) isExpired(nowNano int64) bool {
+	return e.hardExpires > 0 && e.hardExpires <= nowNano
+}
+
+// jitterTTL reduces the ttl by a random fraction between zero and jitter.
+func jitterTTL(ttl time.Duration, jitter float64) time.Duration {
+	if ttl <= 0 || jitter <= 0 {
+		return ttl
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	return ttl - time.Duration(rand.Float64()*jitter*float64(ttl))
+}
+
+// GetOrLoad looks up the key and decodes the value into `dst`. On a cache miss
+// the loader gets called and its value gets written to all cache levels with
+// the `ttl`. Concurrent calls for the same key trigger only one loader call and
+// all callers share its result. The loader runs detached from the context of
+// the callers, limited by ServiceOptions.LoadTimeout, while each caller stops
+// waiting once its own context gets cancelled. A value found only in level2
+// gets copied into level1.
+//
+// Once the `ttl` has passed and ServiceOptions.StaleTTL has been set, the
+// expired value still gets returned for the duration of StaleTTL while one
+// background goroutine refreshes it. If the loader returns an error with kind
+// NotFound, GetOrLoad returns a NotFound error and, if
+// ServiceOptions.NegativeTTL has been set, caches this result. The TTLs get
+// reduced by ServiceOptions.ExpiresJitter to spread the expirations.
+//
+// GetOrLoad stores additional meta data together with the value, so a key
+// written by GetOrLoad must not be read with Get and vice versa.
+func (tr *Service) GetOrLoad(ctx context.Context, key string, dst interface{}, loader LoaderFn, ttl time.Duration) error {
+	if ttl == 0 {
+		ttl = tr.defaultExpiration
+	}
+	e, err := tr.getEntry(ctx, key)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if e != nil {
+		if e.isStale(now().UnixNano()) {
+			tr.refresh(key, loader, ttl)
+		}
+		if ok, err := tr.decodeEntry(key, e, dst); ok || err != nil {
+			return err
+		}
+		// written with another codec or compressor, load it again.
+	}
+
+	ch := tr.sf.DoChan(key, func() (interface{}, error) {
+		if !tr.addBackground() {
+			return nil, errors.AlreadyClosed.Newf("[objcache] GetOrLoad Service has been closed, key %q", key)
+		}
+		defer tr.wg.Done()
+		lctx, cancel := tr.loadContext(ctx)
+		defer cancel()
+		return tr.load(lctx, key, loader, ttl)
+	})
+	select {
+	case <-ctx.Done():
+		return errors.WithStack(ctx.Err())
+	case res := <-ch:
+		if res.Err != nil {
+			return errors.WithStack(res.Err)
+		}
+		_, err = tr.decodeEntry(key, res.Val.(*loadEntry), dst)
+		return err
+	}
+}
+
+// detachedContext contains the values of its parent but never gets cancelled.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) { return }
+func (detachedContext) Done() <-chan struct{}                   { return nil }
+func (detachedContext) Err() error                              { return nil }
+func (c detachedContext) Value(key interface{}) interface{}     { return c.parent.Value(key) }
+
+// loadContext returns the context for a loader call.
+func (tr *Service) loadContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx := context.Context(detachedContext{parent: parent})
+	if tr.so.LoadTimeout > 0 {
+		return context.WithTimeout(ctx, tr.so.LoadTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// addBackground registers a goroutine, which must call tr.wg.Done, so that
+// Close waits for it. Returns false if the Service has already been closed.
+func (tr *Service) addBackground() bool {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if tr.closed {
+		return false
+	}
+	tr.wg.Add(1)
+	return true
+}
+
+func (tr *Service) decodeEntry(key string, e *loadEntry, dst interface{}) (bool, error) {
+	if e.flags&loadFlagNotFound != 0 {
+		return true, errors.NotFound.Newf("[objcache] GetOrLoad key %q not found", key)
+	}
+	ok, err := tr.decodeValue(e.payload, key, dst)
+	return ok, errors.WithStack(err)
+}
+
+// getEntry returns nil if the key cannot be found or has been expired.
+func (tr *Service) getEntry(ctx context.Context, key string) (*loadEntry, error) {
+	keys := [1]string{key}
+	var vals [][]byte
+	var err error
+	if tr.level1 != nil {
+		if vals, err = tr.level1.Get(ctx, keys[:]); err != nil {
+			return nil, errors.Wrapf(err, "[objcache] Level1 with key %q", key)
+		}
+	}
+	fromLevel2 := false
+	if len(vals) == 0 || vals[0] == nil {
+		if vals, err = tr.level2.Get(ctx, keys[:]); err != nil {
+			return nil, errors.Wrapf(err, "[objcache] Level2 with key %q", key)
+		}
+		fromLevel2 = true
+	}
+	if len(vals) == 0 || vals[0] == nil {
+		return nil, nil
+	}
+
+	e := new(loadEntry)
+	if err := e.unmarshal(vals[0]); err != nil {
+		return nil, errors.Wrapf(err, "[objcache] With key %q", key)
+	}
+	n := now().UnixNano()
+	if e.isExpired(n) { // backends like the LRU do not support expirations
+		return nil, nil
+	}
+	if fromLevel2 && tr.level1 != nil {
+		var exp [1]time.Duration
+		if e.hardExpires > 0 {
+			exp[0] = time.Duration(e.hardExpires - n)
+		}
+		if err := tr.level1.Set(ctx, keys[:], vals[:1], exp[:]); err != nil {
+			return nil, errors.Wrapf(err, "[objcache] Level1 with key %q", key)
+		}
+	}
+	return e, nil
+}
+
+// load calls the loader and writes the result to all cache levels.
+func (tr *Service) load(ctx context.Context, key string, loader LoaderFn, ttl time.Duration) (*loadEntry, error) {
+	v, err := loader(ctx, key)
+	e := new(loadEntry)
+	switch {
+	case err != nil && errors.NotFound.Match(err) && tr.so.NegativeTTL > 0:
+		e.flags = loadFlagNotFound
+		ttl = tr.so.NegativeTTL
+	case err != nil:
+		return nil, errors.WithStack(err)
+	default:
+		var buf bytes.Buffer
+		if err := tr.encodeValue(&buf, key, v); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		e.payload = buf.Bytes()
+	}
+
+	ttl = jitterTTL(ttl, tr.so.ExpiresJitter)
+	var storageTTL time.Duration
+	if ttl > 0 {
+		n := now()
+		storageTTL = ttl
+		if e.flags&loadFlagNotFound == 0 {
+			storageTTL += tr.so.StaleTTL
+		}
+		e.softExpires = n.Add(ttl).UnixNano()
+		e.hardExpires = n.Add(storageTTL).UnixNano()
+	}
+
+	keys := [1]string{key}
+	vals := [1][]byte{e.marshal()}
+	exps := [1]time.Duration{storageTTL}
+	if tr.level1 != nil {
+		if err := tr.level1.Set(ctx, keys[:], vals[:], exps[:]); err != nil {
+			return nil, errors.Wrapf(err, "[objcache] Level1 with key %q", key)
+		}
+	}
+	if err := tr.level2.Set(ctx, keys[:], vals[:], exps[:]); err != nil {
+		return nil, errors.Wrapf(err, "[objcache] Level2 with key %q", key)
+	}
+	return e, nil
+}
+
+// refresh reloads a stale key in the background. Only one goroutine per key
+// runs at a time. If the loader cannot find the key anymore and negative
+// caching is disabled, the stale value gets deleted.
+func (tr *Service) refresh(key string, loader LoaderFn, ttl time.Duration) {
+	if _, loaded := tr.refreshing.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+	if !tr.addBackground() {
+		tr.refreshing.Delete(key)
+		return
+	}
+	go func() {
+		defer tr.wg.Done()
+		defer tr.refreshing.Delete(key)
+
+		ctx, cancel := tr.loadContext(context.Background())
+		defer cancel()
+		_, err, _ := tr.sf.Do(key, func() (interface{}, error) {
+			return tr.load(ctx, key, loader, ttl)
+		})
+		if err != nil && errors.NotFound.Match(err) {
+			err = tr.Delete(ctx, key)
+		}
+		if err != nil && tr.so.Log != nil && tr.so.Log.IsInfo() {
+			tr.so.Log.Info("objcache.Service.GetOrLoad.refresh.error", log.Err(err), log.String("key", key))
+		}
+	}()
+}
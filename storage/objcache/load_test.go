@@ -0,0 +1,289 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+// countingLoader returns the current value of `val` and counts the calls.
+type countingLoader struct {
+	calls int32
+	val   atomic.Value
+	err   error
+	sleep time.Duration
+}
+
+func (cl *countingLoader) load(_ context.Context, _ string) (interface{}, error) {
+	atomic.AddInt32(&cl.calls, 1)
+	time.Sleep(cl.sleep)
+	if cl.err != nil {
+		return nil, cl.err
+	}
+	return cl.val.Load(), nil
+}
+
+func (cl *countingLoader) count() int32 { return atomic.LoadInt32(&cl.calls) }
+
+func newLoadTestService(t *testing.T, so *ServiceOptions) *Service {
+	if so == nil {
+		so = &ServiceOptions{}
+	}
+	so.Codec = JSONCodec{}
+	p, err := NewService(NewLRU(nil), NewCacheSimpleInmemory, so)
+	assert.NoError(t, err)
+	return p
+}
+
+func TestService_GetOrLoad(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	t.Run("miss and hit", func(t *testing.T) {
+		p := newLoadTestService(t, nil)
+		defer func() { assert.NoError(t, p.Close()) }()
+		cl := &countingLoader{}
+		cl.val.Store("Gopher")
+
+		for i := 0; i < 3; i++ {
+			var have string
+			err := p.GetOrLoad(ctx, "k1", &have, cl.load, time.Minute)
+			assert.NoError(t, err, "%+v", err)
+			assert.Exactly(t, "Gopher", have)
+		}
+		assert.Exactly(t, int32(1), cl.count())
+	})
+
+	t.Run("level2 hit fills level1", func(t *testing.T) {
+		p := newLoadTestService(t, nil)
+		defer func() { assert.NoError(t, p.Close()) }()
+		cl := &countingLoader{}
+		cl.val.Store("Gopher")
+
+		var have string
+		assert.NoError(t, p.GetOrLoad(ctx, "k1", &have, cl.load, time.Minute))
+		assert.NoError(t, p.level1.Delete(ctx, []string{"k1"}))
+
+		have = ""
+		assert.NoError(t, p.GetOrLoad(ctx, "k1", &have, cl.load, time.Minute))
+		assert.Exactly(t, "Gopher", have)
+		assert.Exactly(t, int32(1), cl.count())
+
+		vals, err := p.level1.Get(ctx, []string{"k1"})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, vals[0])
+	})
+
+	t.Run("singleflight", func(t *testing.T) {
+		p := newLoadTestService(t, nil)
+		defer func() { assert.NoError(t, p.Close()) }()
+		cl := &countingLoader{sleep: 50 * time.Millisecond}
+		cl.val.Store("Gopher")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var have string
+				err := p.GetOrLoad(ctx, "k1", &have, cl.load, time.Minute)
+				assert.NoError(t, err, "%+v", err)
+				assert.Exactly(t, "Gopher", have)
+			}()
+		}
+		wg.Wait()
+		assert.Exactly(t, int32(1), cl.count())
+	})
+
+	t.Run("loader error not cached", func(t *testing.T) {
+		p := newLoadTestService(t, nil)
+		defer func() { assert.NoError(t, p.Close()) }()
+		cl := &countingLoader{err: errors.ConnectionFailed.Newf("DB down")}
+
+		var have string
+		for i := 0; i < 2; i++ {
+			err := p.GetOrLoad(ctx, "k1", &have, cl.load, time.Minute)
+			assert.True(t, errors.ConnectionFailed.Match(err), "%+v", err)
+		}
+		assert.Exactly(t, int32(2), cl.count())
+	})
+
+	t.Run("negative caching", func(t *testing.T) {
+		p := newLoadTestService(t, &ServiceOptions{NegativeTTL: time.Minute})
+		defer func() { assert.NoError(t, p.Close()) }()
+		cl := &countingLoader{err: errors.NotFound.Newf("Product not found")}
+
+		var have string
+		for i := 0; i < 3; i++ {
+			err := p.GetOrLoad(ctx, "k1", &have, cl.load, time.Minute)
+			assert.True(t, errors.NotFound.Match(err), "%+v", err)
+		}
+		assert.Exactly(t, int32(1), cl.count())
+	})
+
+	t.Run("without negative caching", func(t *testing.T) {
+		p := newLoadTestService(t, nil)
+		defer func() { assert.NoError(t, p.Close()) }()
+		cl := &countingLoader{err: errors.NotFound.Newf("Product not found")}
+
+		var have string
+		for i := 0; i < 2; i++ {
+			err := p.GetOrLoad(ctx, "k1", &have, cl.load, time.Minute)
+			assert.True(t, errors.NotFound.Match(err), "%+v", err)
+		}
+		assert.Exactly(t, int32(2), cl.count())
+	})
+
+	t.Run("expired without stale", func(t *testing.T) {
+		p := newLoadTestService(t, nil)
+		defer func() { assert.NoError(t, p.Close()) }()
+		cl := &countingLoader{}
+		cl.val.Store("v1")
+
+		var have string
+		assert.NoError(t, p.GetOrLoad(ctx, "k1", &have, cl.load, 20*time.Millisecond))
+		time.Sleep(40 * time.Millisecond)
+		cl.val.Store("v2")
+		assert.NoError(t, p.GetOrLoad(ctx, "k1", &have, cl.load, 20*time.Millisecond))
+		assert.Exactly(t, "v2", have)
+		assert.Exactly(t, int32(2), cl.count())
+	})
+
+	t.Run("stale while revalidate", func(t *testing.T) {
+		p := newLoadTestService(t, &ServiceOptions{StaleTTL: time.Minute})
+		defer func() { assert.NoError(t, p.Close()) }()
+		cl := &countingLoader{}
+		cl.val.Store("v1")
+
+		var have string
+		assert.NoError(t, p.GetOrLoad(ctx, "k1", &have, cl.load, 20*time.Millisecond))
+		time.Sleep(40 * time.Millisecond)
+		cl.val.Store("v2")
+
+		assert.NoError(t, p.GetOrLoad(ctx, "k1", &have, cl.load, 20*time.Millisecond))
+		assert.Exactly(t, "v1", have, "stale value expected")
+		p.wg.Wait()
+
+		assert.NoError(t, p.GetOrLoad(ctx, "k1", &have, cl.load, time.Minute))
+		assert.Exactly(t, "v2", have, "refreshed value expected")
+		assert.Exactly(t, int32(2), cl.count())
+	})
+
+	t.Run("stale value deleted when not found", func(t *testing.T) {
+		p := newLoadTestService(t, &ServiceOptions{StaleTTL: time.Minute})
+		defer func() { assert.NoError(t, p.Close()) }()
+		cl := &countingLoader{}
+		cl.val.Store("v1")
+
+		var have string
+		assert.NoError(t, p.GetOrLoad(ctx, "k1", &have, cl.load, 20*time.Millisecond))
+		time.Sleep(40 * time.Millisecond)
+		cl.err = errors.NotFound.Newf("Product deleted")
+		assert.NoError(t, p.GetOrLoad(ctx, "k1", &have, cl.load, 20*time.Millisecond))
+		p.wg.Wait()
+
+		err := p.GetOrLoad(ctx, "k1", &have, cl.load, 20*time.Millisecond)
+		assert.True(t, errors.NotFound.Match(err), "%+v", err)
+	})
+
+	t.Run("cancelled caller does not cancel the shared loader", func(t *testing.T) {
+		p := newLoadTestService(t, nil)
+		defer func() { assert.NoError(t, p.Close()) }()
+
+		type ctxKey struct{}
+		started := make(chan struct{})
+		release := make(chan struct{})
+		var loaderErr error
+		var loaderVal interface{}
+		loader := func(ctx context.Context, _ string) (interface{}, error) {
+			close(started)
+			<-release
+			loaderErr = ctx.Err()
+			loaderVal = ctx.Value(ctxKey{})
+			return "Gopher", nil
+		}
+
+		ctx1, cancel := context.WithCancel(context.WithValue(ctx, ctxKey{}, "trace"))
+		errc := make(chan error, 1)
+		go func() {
+			var have string
+			errc <- p.GetOrLoad(ctx1, "k1", &have, loader, time.Minute)
+		}()
+		<-started
+
+		done := make(chan string, 1)
+		go func() {
+			var have string
+			assert.NoError(t, p.GetOrLoad(ctx, "k1", &have, loader, time.Minute))
+			done <- have
+		}()
+
+		cancel()
+		assert.Exactly(t, context.Canceled, errors.Cause(<-errc))
+		close(release)
+		assert.Exactly(t, "Gopher", <-done)
+		assert.NoError(t, loaderErr)
+		assert.Exactly(t, "trace", loaderVal)
+	})
+
+	t.Run("load timeout", func(t *testing.T) {
+		p := newLoadTestService(t, &ServiceOptions{LoadTimeout: 10 * time.Millisecond})
+		defer func() { assert.NoError(t, p.Close()) }()
+		loader := func(ctx context.Context, _ string) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		var have string
+		err := p.GetOrLoad(ctx, "k1", &have, loader, time.Minute)
+		assert.Exactly(t, context.DeadlineExceeded, errors.Cause(err), "%+v", err)
+	})
+
+	t.Run("no refresh after Close", func(t *testing.T) {
+		p := newLoadTestService(t, nil)
+		cl := &countingLoader{}
+		assert.NoError(t, p.Close())
+		p.refresh("k1", cl.load, time.Minute)
+		p.wg.Wait()
+		assert.Exactly(t, int32(0), cl.count())
+		_, loaded := p.refreshing.Load("k1")
+		assert.False(t, loaded)
+	})
+
+	t.Run("key written by Set", func(t *testing.T) {
+		p := newLoadTestService(t, nil)
+		defer func() { assert.NoError(t, p.Close()) }()
+		assert.NoError(t, p.Set(ctx, "k1", 1, 0))
+		var have int
+		err := p.GetOrLoad(ctx, "k1", &have, (&countingLoader{}).load, time.Minute)
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+}
+
+func TestJitterTTL(t *testing.T) {
+	t.Parallel()
+	assert.Exactly(t, time.Minute, jitterTTL(time.Minute, 0))
+	assert.Exactly(t, time.Duration(0), jitterTTL(0, 0.5))
+	for i := 0; i < 100; i++ {
+		ttl := jitterTTL(time.Minute, 0.1)
+		assert.True(t, ttl > 54*time.Second && ttl <= time.Minute, "TTL %s", ttl)
+	}
+}
@@ -31,9 +31,11 @@ type LRUOptions struct {
 	LRUCache           *lru.LRUCache
 }
 
-// lruCache is an LRU cache. It is safe for concurrent access.
+// lruCache is an LRU cache. It is safe for concurrent access. Evicted keys get
+// removed from the tag index.
 type lruCache struct {
-	opt LRUOptions
+	opt  LRUOptions
+	tags *tagIndex
 }
 
 // NewLRU creates a new LRU Storage. Expirations are not supported. Argument `o`
@@ -59,9 +61,14 @@ func NewLRU(o *LRUOptions) NewStorageFn {
 		o.LRUCache = lru.NewLRUCache(o.Capacity)
 	}
 	return func() (Storager, error) {
-		return lruCache{
-			opt: *o,
-		}, nil
+		c := lruCache{
+			opt:  *o,
+			tags: &tagIndex{},
+		}
+		c.opt.LRUCache.SetOnEvict(func(key string, _ lru.Value) {
+			c.tags.remove([]string{key})
+		})
+		return c, nil
 	}
 }
 
@@ -73,17 +80,39 @@ type itemByCount []byte
 
 func (li itemByCount) Size() int { return 1 }
 
+func (c lruCache) set(key string, value []byte) {
+	var v lru.Value = itemByCount(value)
+	if c.opt.TrackBySize {
+		v = itemBySize(value)
+	}
+	c.opt.LRUCache.Set(key, v)
+}
+
 func (c lruCache) Set(_ context.Context, keys []string, values [][]byte, _ []time.Duration) (err error) {
 	for i, key := range keys {
-		var v lru.Value = itemByCount(values[i])
-		if c.opt.TrackBySize {
-			v = itemBySize(values[i])
+		c.set(key, values[i])
+	}
+	return nil
+}
+
+// SetWithTags adds the tags of each key right after setting the key, so a key
+// evicted by a later key gets removed from the tag index.
+func (c lruCache) SetWithTags(_ context.Context, keys []string, values [][]byte, _ []time.Duration, tags [][]string) (err error) {
+	for i, key := range keys {
+		c.set(key, values[i])
+		var kt [][]string
+		if i < len(tags) {
+			kt = tags[i : i+1]
 		}
-		c.opt.LRUCache.Set(key, v)
+		c.tags.add(keys[i:i+1], kt)
 	}
 	return nil
 }
 
+func (c lruCache) InvalidateTags(ctx context.Context, tags []string) (err error) {
+	return c.Delete(ctx, c.tags.invalidate(tags))
+}
+
 // Get looks up a key's value from the cache.
 func (c lruCache) Get(_ context.Context, keys []string) (values [][]byte, err error) {
 	for _, key := range keys {
@@ -103,6 +132,7 @@ func (c lruCache) Get(_ context.Context, keys []string) (values [][]byte, err er
 
 func (c lruCache) Truncate(_ context.Context) (err error) {
 	c.opt.LRUCache.Clear()
+	c.tags.reset()
 	return nil
 }
 
@@ -110,10 +140,17 @@ func (c lruCache) Delete(_ context.Context, keys []string) (err error) {
 	for _, key := range keys {
 		c.opt.LRUCache.Delete(key)
 	}
+	c.tags.remove(keys)
 	return nil
 }
 
+func (c lruCache) BackendStats() BackendStats {
+	l, s, cp, e, _ := c.opt.LRUCache.Stats()
+	return BackendStats{Length: l, Size: s, Capacity: cp, Evictions: e}
+}
+
 func (c lruCache) Close() error {
 	c.opt.LRUCache.Clear()
+	c.tags.reset()
 	return nil
 }
@@ -24,6 +24,10 @@ func TestNewCacheLRU_Delete(t *testing.T) {
 	newTestServiceDelete(t, objcache.NewLRU(nil))
 }
 
+func TestNewCacheLRU_Tags(t *testing.T) {
+	newTestServiceTags(t, objcache.NewLRU(nil))
+}
+
 func TestNewCacheLRU_ComplexParallel(t *testing.T) {
 	t.Run("gob", func(t *testing.T) {
 		newServiceComplexParallelTest(t, objcache.NewLRU(nil), nil)
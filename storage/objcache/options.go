@@ -18,6 +18,8 @@ import (
 	"context"
 	"sync"
 	"time"
+
+	"github.com/corestoreio/log"
 )
 
 var now = time.Now
@@ -35,6 +37,39 @@ type ServiceOptions struct {
 	// information in the cache.
 	PrimeObjects   []interface{}
 	DefaultExpires time.Duration
+	// StaleTTL defines how long GetOrLoad returns an expired value while the
+	// value gets refreshed in the background. Zero disables serving stale
+	// values.
+	StaleTTL time.Duration
+	// NegativeTTL defines how long GetOrLoad caches a NotFound error returned
+	// by a loader. Zero disables negative caching.
+	NegativeTTL time.Duration
+	// ExpiresJitter reduces the TTL of each value written by GetOrLoad by a
+	// random fraction between zero and ExpiresJitter, for example 0.1 for up
+	// to 10%, to spread the expirations. Must be between 0 and 1.
+	ExpiresJitter float64
+	// LoadTimeout limits the duration of each loader call of GetOrLoad, also of
+	// the background refreshes. The loader does not run with the context of
+	// the caller because concurrent callers share its result, it gets only the
+	// values of that context. Zero means no limit.
+	LoadTimeout time.Duration
+	// Log optional logger to report errors of background refreshes.
+	Log log.Logger
+	// Envelope prefixes each value with a header containing a version, the ID
+	// of the codec and of the compressor. A value written with another codec or
+	// compressor or without the header gets treated as a cache miss. This
+	// allows to change the codec without corrupting existing cache entries.
+	// Enabling the envelope treats all existing entries as a miss.
+	Envelope bool
+	// Compressor optional compresses the encoded values. Setting a Compressor
+	// enables the Envelope.
+	Compressor Compressor
+	// CompressThreshold defines the minimum size in bytes of an encoded value
+	// to get compressed. Zero compresses all values.
+	CompressThreshold int
+	// CollectStats enables the statistics of each storage level. See
+	// Service.Stats.
+	CollectStats bool
 }
 
 // NewCacheSimpleInmemory creates an in-memory map map[string]string as cache
@@ -49,8 +84,11 @@ type mapCacheItem struct {
 	expiration time.Time
 }
 
+// mapCache keeps expired items until they get overwritten or deleted. Expired
+// keys get swept from the tag index.
 type mapCache struct {
 	items sync.Map
+	tags  tagIndex
 }
 
 func (mc *mapCache) Set(_ context.Context, keys []string, values [][]byte, expirations []time.Duration) (err error) {
@@ -68,6 +106,24 @@ func (mc *mapCache) Set(_ context.Context, keys []string, values [][]byte, expir
 	return nil
 }
 
+func (mc *mapCache) SetWithTags(ctx context.Context, keys []string, values [][]byte, expirations []time.Duration, tags [][]string) (err error) {
+	if err = mc.Set(ctx, keys, values, expirations); err != nil {
+		return err
+	}
+	mc.tags.add(keys, tags)
+	n := now()
+	mc.tags.sweep(func(key string) bool {
+		val, ok := mc.items.Load(key)
+		v, ok2 := val.(*mapCacheItem)
+		return !ok || !ok2 || (!v.expiration.IsZero() && !v.expiration.After(n))
+	})
+	return nil
+}
+
+func (mc *mapCache) InvalidateTags(ctx context.Context, tags []string) (err error) {
+	return mc.Delete(ctx, mc.tags.invalidate(tags))
+}
+
 func (mc *mapCache) Get(_ context.Context, keys []string) (values [][]byte, err error) {
 	n := now()
 	for _, key := range keys {
@@ -85,6 +141,7 @@ func (mc *mapCache) Delete(_ context.Context, keys []string) (err error) {
 	for _, key := range keys {
 		mc.items.Delete(key)
 	}
+	mc.tags.remove(keys)
 	return nil
 }
 func (mc *mapCache) Truncate(ctx context.Context) (err error) {
@@ -94,10 +151,24 @@ func (mc *mapCache) Truncate(ctx context.Context) (err error) {
 		return true
 	})
 	mc.items = sync.Map{}
+	mc.tags.reset()
 	return nil
 }
 func (mc *mapCache) Close() error { return nil }
 
+// BackendStats counts the entries and their size in bytes, including the
+// expired entries.
+func (mc *mapCache) BackendStats() (bs BackendStats) {
+	mc.items.Range(func(_, value interface{}) bool {
+		bs.Length++
+		if v, ok := value.(*mapCacheItem); ok {
+			bs.Size += int64(len(v.value))
+		}
+		return true
+	})
+	return bs
+}
+
 // NewBlackHoleClient creates a black hole client for testing with the ability
 // to return errors.
 func NewBlackHoleClient(optionalTestErr error) NewStorageFn {
@@ -112,6 +183,12 @@ func (mc blackHole) Set(_ context.Context, keys []string, values [][]byte, expir
 	return mc.err
 }
 
+func (mc blackHole) SetWithTags(_ context.Context, keys []string, values [][]byte, expirations []time.Duration, tags [][]string) (err error) {
+	return mc.err
+}
+
+func (mc blackHole) InvalidateTags(_ context.Context, tags []string) (err error) { return mc.err }
+
 func (mc blackHole) Get(_ context.Context, keys []string) (values [][]byte, err error) {
 	return nil, mc.err
 }
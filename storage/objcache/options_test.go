@@ -16,26 +16,12 @@ package objcache
 
 import (
 	"context"
-	"encoding/json"
-	"io"
 	"testing"
 	"time"
 
 	"github.com/corestoreio/pkg/util/assert"
 )
 
-var _ Codecer = (*JSONCodec)(nil)
-
-type JSONCodec struct{}
-
-func (c JSONCodec) NewEncoder(w io.Writer) Encoder {
-	return json.NewEncoder(w)
-}
-
-func (c JSONCodec) NewDecoder(r io.Reader) Decoder {
-	return json.NewDecoder(r)
-}
-
 func TestWithSimpleSlowCacheMap_Expires(t *testing.T) {
 	t.Parallel()
 
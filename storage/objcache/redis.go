@@ -20,6 +20,7 @@ import (
 	"context"
 	gourl "net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/corestoreio/errors"
@@ -29,7 +30,13 @@ import (
 
 // RedisOption applies several options for the Redis client.
 type RedisOption struct {
+	// KeyPrefix gets prepended to all keys. If set, Truncate deletes only the
+	// keys with this prefix.
 	KeyPrefix string
+	// FlushDB allows Truncate to flush the whole database, including the keys
+	// of other applications, if no KeyPrefix has been set. Otherwise Truncate
+	// returns a NotSupported error without a KeyPrefix.
+	FlushDB bool
 }
 
 // NewRedisClient connects to the Redis server and does a ping to check if the
@@ -82,6 +89,7 @@ func parseInt(keys []string, ds []*int, params gourl.Values) (err error) {
 // For example:
 // 		redis://localhost:6379/?db=3
 // 		redis://localhost:6379/?max_active=50&max_idle=5&idle_timeout=10s&max_conn_lifetime=1m&key_prefix=xcache_
+// 		redis://localhost:6379/?db=3&flush_db=1
 func NewRedisByURLClient(rawURL string) NewStorageFn {
 	return func() (Storager, error) {
 		addr, _, password, params, err := url.ParseConnection(rawURL)
@@ -137,6 +145,7 @@ func NewRedisByURLClient(rawURL string) NewStorageFn {
 
 		return NewRedisClient(pool, &RedisOption{
 			KeyPrefix: params.Get("key_prefix"),
+			FlushDB:   params.Get("flush_db") == "1",
 		})()
 	}
 }
@@ -168,13 +177,60 @@ func makeRedisWrapper(rp *redis.Pool, ro *RedisOption) redisWrapper {
 		// 	},
 		// },
 		keyPrefix: ro.KeyPrefix,
+		flushDB:   ro.FlushDB,
 	}
 }
 
+// redisInternalMarker starts, after the key prefix, the keys of the tag sets
+// and of the key tags sets. prefixKey doubles the marker of a key starting with
+// it, so a key can never collide with an internal key.
+const redisInternalMarker = "~"
+
+// redisTagPrefix gets prepended, after the key prefix, to the name of a tag.
+// The set stored with the resulting key contains all keys of the tag.
+const redisTagPrefix = redisInternalMarker + "tag:"
+
+// redisKeyTagsPrefix gets prepended, after the key prefix, to a key. The set
+// stored with the resulting key contains the tag sets of the key, so that
+// Delete can remove the key from its tag sets.
+const redisKeyTagsPrefix = redisInternalMarker + "keytags:"
+
+// redisMaxTxAttempts defines how often a transaction gets retried when a
+// watched key has been modified by another client.
+const redisMaxTxAttempts = 10
+
+func (w redisWrapper) prefixKey(key string) string {
+	if strings.HasPrefix(key, redisInternalMarker) {
+		return w.keyPrefix + redisInternalMarker + key
+	}
+	return w.keyPrefix + key
+}
+
+// tagKey returns the key of the set containing all keys of the tag.
+func (w redisWrapper) tagKey(tag string) string {
+	return w.keyPrefix + redisTagPrefix + tag
+}
+
+// keyTagsKey returns the key of the set containing the tag sets of the already
+// prefixed key.
+func (w redisWrapper) keyTagsKey(prefixedKey string) string {
+	return w.keyPrefix + redisKeyTagsPrefix + prefixedKey[len(w.keyPrefix):]
+}
+
+// redisSeconds converts the expiration into whole seconds. Zero means that
+// the key does not expire.
+func redisSeconds(d time.Duration) int64 {
+	if d < time.Second {
+		return 0
+	}
+	return int64((d + time.Second - 1) / time.Second)
+}
+
 type redisWrapper struct {
 	*redis.Pool
 	ping      bool
 	keyPrefix string
+	flushDB   bool
 	// ifp  *sync.Pool
 }
 
@@ -186,27 +242,43 @@ func (w redisWrapper) Set(_ context.Context, keys []string, values [][]byte, exp
 		}
 	}()
 
-	args := make([]interface{}, 0, len(keys)*3)
+	// All commands get pipelined and sent with one round trip.
+	if err = w.sendSet(conn, keys, values, expirations); err != nil {
+		return errors.WithStack(err)
+	}
+	replies, err := redis.Values(conn.Do("")) // flushes and receives all replies
+	if err == nil {
+		err = redisFirstError(replies)
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "[objcache] With keys %v", keys)
+	}
+	return err
+}
+
+// sendSet sends the commands to write the values without flushing the
+// connection.
+func (w redisWrapper) sendSet(conn redis.Conn, keys []string, values [][]byte, expirations []time.Duration) error {
+	args := make([]interface{}, 0, len(keys)*2)
 	for i, key := range keys {
-		e := expirations[i].Seconds() // e = expires in x seconds
-		if e < 1 {
-			args = append(args, key, values[i])
-		} else {
-			if _, err2 := conn.Do("SETEX", key, e, values[i]); err2 != nil {
-				err = errors.Wrapf(err2, "[objcache] With key %q", key)
-				return
-			}
+		var e int64 // e = expires in x seconds
+		if i < len(expirations) {
+			e = redisSeconds(expirations[i])
+		}
+		if e == 0 {
+			args = append(args, w.prefixKey(key), values[i])
+			continue
+		}
+		if err := conn.Send("SETEX", w.prefixKey(key), e, values[i]); err != nil {
+			return errors.Wrapf(err, "[objcache] With key %q", key)
 		}
 	}
-
-	if la := len(args); la > 0 && la%2 == 0 {
-		if _, err2 := conn.Do("MSET", args...); err2 != nil {
-			err = errors.Wrapf(err2, "[objcache] With keys %v", keys)
-			return
+	if len(args) > 0 {
+		if err := conn.Send("MSET", args...); err != nil {
+			return errors.Wrapf(err, "[objcache] With keys %v", keys)
 		}
 	}
-
-	return err
+	return nil
 }
 
 func (w redisWrapper) Get(_ context.Context, keys []string) (values [][]byte, err error) {
@@ -219,7 +291,7 @@ func (w redisWrapper) Get(_ context.Context, keys []string) (values [][]byte, er
 
 	if len(keys) == 1 {
 		var val []byte
-		val, err = redis.Bytes(conn.Do("GET", keys[0]))
+		val, err = redis.Bytes(conn.Do("GET", w.prefixKey(keys[0])))
 		if err != nil {
 			if err == redis.ErrNil {
 				err = nil
@@ -232,7 +304,7 @@ func (w redisWrapper) Get(_ context.Context, keys []string) (values [][]byte, er
 		return
 	}
 
-	values, err = redis.ByteSlices(conn.Do("MGET", strSliceToIFaces(nil, keys)...))
+	values, err = redis.ByteSlices(conn.Do("MGET", w.keysToIFaces(nil, keys)...))
 	if err != nil {
 		err = errors.Wrapf(err, "[objcache] With keys %v", keys)
 		return
@@ -254,6 +326,18 @@ func strSliceToIFaces(ret []interface{}, sl []string) []interface{} {
 	return ret
 }
 
+// keysToIFaces same as strSliceToIFaces but prepends the key prefix.
+func (w redisWrapper) keysToIFaces(ret []interface{}, keys []string) []interface{} {
+	if ret == nil {
+		ret = make([]interface{}, 0, len(keys))
+	}
+	for _, k := range keys {
+		ret = append(ret, w.prefixKey(k))
+	}
+	return ret
+}
+
+// Delete deletes the keys and removes them from their tag sets.
 func (w redisWrapper) Delete(_ context.Context, keys []string) (err error) {
 	conn := w.Pool.Get()
 	defer func() {
@@ -261,17 +345,315 @@ func (w redisWrapper) Delete(_ context.Context, keys []string) (err error) {
 			err = err2
 		}
 	}()
-	if _, err = conn.Do("DEL", strSliceToIFaces(nil, keys)...); err != nil {
+	prefixedKeys := make([]string, len(keys))
+	for i, k := range keys {
+		prefixedKeys[i] = w.prefixKey(k)
+	}
+	if err = w.delete(conn, prefixedKeys); err != nil {
 		err = errors.Wrapf(err, "[objcache] With keys %v", keys)
 	}
 	return
 }
 
-func (w redisWrapper) Truncate(ctx context.Context) (err error) {
-	// TODO flush redis by key prefix
+// delete deletes the already prefixed keys, their key tags sets and removes
+// the keys from their tag sets in a transaction.
+func (w redisWrapper) delete(conn redis.Conn, prefixedKeys []string) error {
+	if len(prefixedKeys) == 0 {
+		return nil
+	}
+	keyTagsKeys := make([]interface{}, len(prefixedKeys))
+	for i, pk := range prefixedKeys {
+		keyTagsKeys[i] = w.keyTagsKey(pk)
+	}
+
+	return redisRetryTx(conn, keyTagsKeys, func(conn redis.Conn) error {
+		for _, ktk := range keyTagsKeys {
+			if err := conn.Send("SMEMBERS", ktk); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		replies, err := redis.Values(conn.Do(""))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if err := conn.Send("MULTI"); err != nil {
+			return errors.WithStack(err)
+		}
+		delArgs := make([]interface{}, 0, 2*len(prefixedKeys))
+		for i, pk := range prefixedKeys {
+			tagKeys, err := redis.Strings(replies[i], nil)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			for _, tk := range tagKeys {
+				if err := conn.Send("SREM", tk, pk); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+			delArgs = append(delArgs, pk, keyTagsKeys[i])
+		}
+		return errors.WithStack(conn.Send("DEL", delArgs...))
+	})
+}
+
+// redisRetryTx watches the keys and calls fn, which must read the watched keys
+// and start a transaction with MULTI. redisRetryTx executes the transaction and
+// retries it if a watched key has been modified in the meantime.
+func redisRetryTx(conn redis.Conn, watchKeys []interface{}, fn func(conn redis.Conn) error) error {
+	for attempt := 0; attempt < redisMaxTxAttempts; attempt++ {
+		if len(watchKeys) > 0 {
+			if _, err := conn.Do("WATCH", watchKeys...); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		if err := fn(conn); err != nil {
+			if _, err2 := conn.Do("DISCARD"); err2 != nil {
+				_, _ = conn.Do("UNWATCH")
+			}
+			return errors.WithStack(err)
+		}
+		replies, err := redis.Values(conn.Do("EXEC"))
+		if err == redis.ErrNil {
+			continue // a watched key has been modified
+		}
+		if err == nil {
+			err = redisFirstError(replies)
+		}
+		return errors.WithStack(err)
+	}
+	return errors.Aborted.Newf("[objcache] Redis transaction aborted after %d attempts because the watched keys %v have been modified", redisMaxTxAttempts, watchKeys)
+}
+
+// redisSet contains the new members of a Redis set and the minimum time to
+// live of the set.
+type redisSet struct {
+	key     string
+	expires int64 // in seconds, 0 never expires
+	members []interface{}
+}
+
+func (rs *redisSet) add(member string, expires int64) {
+	if len(rs.members) == 0 {
+		rs.expires = expires
+	} else if rs.expires > 0 && (expires == 0 || expires > rs.expires) {
+		rs.expires = expires
+	}
+	rs.members = append(rs.members, member)
+}
+
+// SetWithTags writes the values like Set and adds the keys to one Redis set
+// per tag. Each tag set lives at least as long as its keys. Each key remembers
+// its tag sets, so that Delete can remove the key from the tag sets. All
+// commands run in one transaction.
+func (w redisWrapper) SetWithTags(_ context.Context, keys []string, values [][]byte, expirations []time.Duration, tags [][]string) (err error) {
+	conn := w.Pool.Get()
+	defer func() {
+		if err2 := conn.Close(); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	var sets []*redisSet // tag sets and key tags sets
+	setIdx := make(map[string]int)
+	addToSet := func(setKey, member string, expires int64) {
+		i, ok := setIdx[setKey]
+		if !ok {
+			i = len(sets)
+			setIdx[setKey] = i
+			sets = append(sets, &redisSet{key: setKey})
+		}
+		sets[i].add(member, expires)
+	}
+	for i, key := range keys {
+		if i >= len(tags) {
+			break
+		}
+		var e int64
+		if i < len(expirations) {
+			e = redisSeconds(expirations[i])
+		}
+		for _, tag := range tags[i] {
+			tk := w.tagKey(tag)
+			pk := w.prefixKey(key)
+			addToSet(tk, pk, e)
+			addToSet(w.keyTagsKey(pk), tk, e)
+		}
+	}
+	watchKeys := make([]interface{}, len(sets))
+	for i, set := range sets {
+		watchKeys[i] = set.key
+	}
+
+	err = redisRetryTx(conn, watchKeys, func(conn redis.Conn) error {
+		var ttls []int64
+		if len(sets) > 0 {
+			for _, set := range sets {
+				if err := conn.Send("TTL", set.key); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+			var err error
+			if ttls, err = redis.Int64s(conn.Do("")); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+
+		if err := conn.Send("MULTI"); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := w.sendSet(conn, keys, values, expirations); err != nil {
+			return errors.WithStack(err)
+		}
+		for i, set := range sets {
+			args := make([]interface{}, 0, len(set.members)+1)
+			args = append(args, set.key)
+			args = append(args, set.members...)
+			if err := conn.Send("SADD", args...); err != nil {
+				return errors.WithStack(err)
+			}
+			// TTL returns -2 if the set does not exist and -1 if the set
+			// does not expire. A longer TTL never gets shortened.
+			var errTTL error
+			switch ttl := ttls[i]; {
+			case set.expires == 0 && ttl > 0:
+				errTTL = conn.Send("PERSIST", set.key)
+			case set.expires > 0 && (ttl == -2 || (ttl >= 0 && ttl < set.expires)):
+				errTTL = conn.Send("EXPIRE", set.key, set.expires)
+			}
+			if errTTL != nil {
+				return errors.WithStack(errTTL)
+			}
+		}
+		return nil
+	})
+	return errors.Wrapf(err, "[objcache] With keys %v", keys)
+}
+
+// redisFirstError returns the first error of the replies of a pipeline or of
+// a transaction. Redis reports failed commands as an element of the replies
+// and not as the error of the call.
+func redisFirstError(replies []interface{}) error {
+	for _, r := range replies {
+		if err, ok := r.(redis.Error); ok {
+			return err
+		}
+	}
 	return nil
 }
 
+// InvalidateTags reads and deletes the tag sets in a transaction and
+// afterwards deletes all keys of the tags, like Delete.
+func (w redisWrapper) InvalidateTags(_ context.Context, tags []string) (err error) {
+	conn := w.Pool.Get()
+	defer func() {
+		if err2 := conn.Close(); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	for _, tag := range tags {
+		tagKey := w.tagKey(tag)
+		if err = conn.Send("MULTI"); err != nil {
+			return errors.Wrapf(err, "[objcache] With tag %q", tag)
+		}
+		if err = conn.Send("SMEMBERS", tagKey); err != nil {
+			return errors.Wrapf(err, "[objcache] With tag %q", tag)
+		}
+		if err = conn.Send("DEL", tagKey); err != nil {
+			return errors.Wrapf(err, "[objcache] With tag %q", tag)
+		}
+		replies, err := redis.Values(conn.Do("EXEC"))
+		if err != nil {
+			return errors.Wrapf(err, "[objcache] With tag %q", tag)
+		}
+		if len(replies) == 0 {
+			continue
+		}
+		members, err := redis.Strings(replies[0], nil)
+		if err != nil {
+			return errors.Wrapf(err, "[objcache] With tag %q", tag)
+		}
+		if err = w.delete(conn, members); err != nil {
+			return errors.Wrapf(err, "[objcache] With tag %q", tag)
+		}
+	}
+	return nil
+}
+
+// redisTruncateBatchSize defines the COUNT hint for SCAN and the maximum
+// number of keys per DEL command.
+const redisTruncateBatchSize = 1000
+
+// Truncate deletes only the keys, including the tag sets, with the key prefix by
+// iterating with SCAN. Without a key prefix it flushes the current database if
+// RedisOption.FlushDB has been set, otherwise it returns a NotSupported error.
+func (w redisWrapper) Truncate(ctx context.Context) (err error) {
+	if w.keyPrefix == "" && !w.flushDB {
+		return errors.NotSupported.Newf("[objcache] Truncate without a key prefix requires RedisOption.FlushDB")
+	}
+	conn := w.Pool.Get()
+	defer func() {
+		if err2 := conn.Close(); err == nil && err2 != nil {
+			err = err2
+		}
+	}()
+
+	if w.keyPrefix == "" {
+		if _, err = conn.Do("FLUSHDB"); err != nil {
+			err = errors.WithStack(err)
+		}
+		return
+	}
+
+	pattern := redisEscapeGlob(w.keyPrefix) + "*"
+	cursor := int64(0)
+	for {
+		if err = ctx.Err(); err != nil {
+			return errors.WithStack(err)
+		}
+		reply, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", pattern, "COUNT", redisTruncateBatchSize))
+		if err != nil {
+			return errors.Wrapf(err, "[objcache] Truncate with key prefix %q", w.keyPrefix)
+		}
+		if len(reply) != 2 {
+			return errors.Mismatch.Newf("[objcache] Truncate SCAN returned %d instead of 2 values", len(reply))
+		}
+		if cursor, err = redis.Int64(reply[0], nil); err != nil {
+			return errors.Wrapf(err, "[objcache] Truncate with key prefix %q", w.keyPrefix)
+		}
+		keys, err := redis.Values(reply[1], nil)
+		if err != nil {
+			return errors.Wrapf(err, "[objcache] Truncate with key prefix %q", w.keyPrefix)
+		}
+		if len(keys) > 0 {
+			if _, err = conn.Do("DEL", keys...); err != nil {
+				return errors.Wrapf(err, "[objcache] Truncate with key prefix %q", w.keyPrefix)
+			}
+		}
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// redisEscapeGlob escapes the special characters of the glob-style pattern
+// used by SCAN MATCH.
+func redisEscapeGlob(s string) string {
+	if !strings.ContainsAny(s, `*?[]\^`) {
+		return s
+	}
+	var buf strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\', '^':
+			buf.WriteByte('\\')
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
 func (w redisWrapper) Close() error {
 	return w.Pool.Close()
 }
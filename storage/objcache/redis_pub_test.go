@@ -148,3 +148,67 @@ func TestWithRedisURLReal_Delete(t *testing.T) {
 	redConURL := lookupRedisEnv(t)
 	newTestServiceDelete(t, objcache.NewRedisByURLClient(redConURL))
 }
+
+func TestWithRedisURLMock_Tags(t *testing.T) {
+	mr := miniredis.NewMiniRedis()
+	assert.NoError(t, mr.Start())
+	defer mr.Close()
+	redConURL := fmt.Sprintf("redis://%s/?db=2&key_prefix=tags_", mr.Addr())
+	newTestServiceTags(t, objcache.NewRedisByURLClient(redConURL))
+}
+
+func TestWithRedisURLMock_Truncate(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("with key prefix", func(t *testing.T) {
+		mr := miniredis.NewMiniRedis()
+		assert.NoError(t, mr.Start())
+		defer mr.Close()
+		assert.NoError(t, mr.Set("other_key", "1"))
+		assert.NoError(t, mr.Set("xcache*_key", "1"))
+
+		p, err := objcache.NewService(nil, objcache.NewRedisByURLClient(fmt.Sprintf("redis://%s/?key_prefix=xcache_", mr.Addr())), newSrvOpt(JSONCodec{}))
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, p.Close()) }()
+
+		assert.NoError(t, p.Set(ctx, "key1", 1, 0))
+		assert.NoError(t, p.SetWithTags(ctx, "key2", 2, 0, "tag1"))
+		assert.Exactly(t, []string{"other_key", "xcache*_key", "xcache_key1", "xcache_key2", "xcache_~keytags:key2", "xcache_~tag:tag1"}, mr.Keys())
+
+		err = p.Truncate(ctx)
+		assert.NoError(t, err, "%+v", err)
+		assert.Exactly(t, []string{"other_key", "xcache*_key"}, mr.Keys())
+	})
+
+	t.Run("without key prefix not supported", func(t *testing.T) {
+		mr := miniredis.NewMiniRedis()
+		assert.NoError(t, mr.Start())
+		defer mr.Close()
+		assert.NoError(t, mr.Set("other_key", "1"))
+
+		p, err := objcache.NewService(nil, objcache.NewRedisByURLClient("redis://"+mr.Addr()), newSrvOpt(JSONCodec{}))
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, p.Close()) }()
+
+		assert.NoError(t, p.Set(ctx, "key1", 1, 0))
+		err = p.Truncate(ctx)
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+		assert.Exactly(t, []string{"key1", "other_key"}, mr.Keys())
+	})
+
+	t.Run("without key prefix flush db", func(t *testing.T) {
+		mr := miniredis.NewMiniRedis()
+		assert.NoError(t, mr.Start())
+		defer mr.Close()
+		assert.NoError(t, mr.Set("other_key", "1"))
+
+		p, err := objcache.NewService(nil, objcache.NewRedisByURLClient("redis://"+mr.Addr()+"/?flush_db=1"), newSrvOpt(JSONCodec{}))
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, p.Close()) }()
+
+		assert.NoError(t, p.Set(ctx, "key1", 1, 0))
+		err = p.Truncate(ctx)
+		assert.NoError(t, err, "%+v", err)
+		assert.Empty(t, mr.Keys())
+	})
+}
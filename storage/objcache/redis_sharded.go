@@ -0,0 +1,322 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build redis csall
+
+package objcache
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/log"
+	"github.com/gomodule/redigo/redis"
+)
+
+// redisSlotCount equals the number of hash slots of a Redis Cluster.
+const redisSlotCount = 16384
+
+// RedisShard defines one Redis instance of a sharded client.
+type RedisShard struct {
+	// Name identifies the shard in the consistent hashing, for example the
+	// address. Changing the name moves the keys of the shard to other shards.
+	// Defaults to "shard" plus the index.
+	Name string
+	Pool *redis.Pool
+	// Slots optional pins Redis Cluster hash slots to this shard, for example
+	// to mirror the slot assignment of an existing cluster. All other slots
+	// get distributed with rendezvous hashing.
+	Slots []RedisSlotRange
+}
+
+// RedisSlotRange defines an inclusive range of hash slots between 0 and 16383.
+type RedisSlotRange struct {
+	From uint16
+	To   uint16
+}
+
+// RedisShardedOption applies several options for the sharded Redis client.
+type RedisShardedOption struct {
+	// KeyPrefix gets prepended to all keys on all shards. See RedisOption.
+	KeyPrefix string
+	// FlushDB allows Truncate to flush the databases of all shards if no
+	// KeyPrefix has been set. See RedisOption.
+	FlushDB bool
+	// Log optional logger to report the errors of failed shards which get
+	// treated as a cache miss.
+	Log log.Logger
+}
+
+// NewRedisShardedClient distributes the keys across several Redis instances.
+// A key gets mapped like in a Redis Cluster to one of the 16384 hash slots by
+// CRC16 of the key, including the KeyPrefix, or of its hash tag, the part
+// between the first "{" and the following "}". The slots get assigned to the shards with rendezvous hashing
+// unless they are pinned to a shard. Adding or removing a shard moves only the
+// keys of its slots. Keys with the same hash tag end up on the same shard.
+//
+// Set, Get and Delete group the keys per shard and run one pipelined round
+// trip per shard concurrently. If a shard fails during Get, its keys get
+// treated as a cache miss and the error gets logged. All other operations
+// return the error. Tags get stored on the shard of their keys,
+// InvalidateTags and Truncate run on all shards. Each shard gets pinged
+// during creation.
+func NewRedisShardedClient(shards []RedisShard, o *RedisShardedOption) NewStorageFn {
+	return func() (Storager, error) {
+		var opt RedisShardedOption
+		if o != nil {
+			opt = *o
+		}
+		w, err := newRedisSharded(shards, opt)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		for i, s := range w.shards {
+			s.ping = true
+			if err := doPing(s); err != nil {
+				return nil, errors.Wrapf(err, "[objcache] Redis shard %q", w.names[i])
+			}
+		}
+		return w, nil
+	}
+}
+
+func newRedisSharded(shards []RedisShard, opt RedisShardedOption) (*redisSharded, error) {
+	if len(shards) == 0 {
+		return nil, errors.NotValid.Newf("[objcache] NewRedisShardedClient requires at least one shard")
+	}
+	w := &redisSharded{
+		shards: make([]redisWrapper, len(shards)),
+		names:  make([]string, len(shards)),
+		log:    opt.Log,
+	}
+	pinned := make([]bool, redisSlotCount)
+	for i, s := range shards {
+		if s.Pool == nil {
+			return nil, errors.NotValid.Newf("[objcache] Redis shard %d has no pool", i)
+		}
+		w.shards[i] = makeRedisWrapper(s.Pool, &RedisOption{KeyPrefix: opt.KeyPrefix, FlushDB: opt.FlushDB})
+		w.names[i] = s.Name
+		if w.names[i] == "" {
+			w.names[i] = "shard" + strconv.Itoa(i)
+		}
+		for _, r := range s.Slots {
+			if r.From > r.To || r.To >= redisSlotCount {
+				return nil, errors.NotValid.Newf("[objcache] Redis shard %q has an invalid slot range %d-%d", w.names[i], r.From, r.To)
+			}
+			for slot := int(r.From); slot <= int(r.To); slot++ {
+				if pinned[slot] {
+					return nil, errors.NotValid.Newf("[objcache] Redis shard %q: Slot %d has already been assigned", w.names[i], slot)
+				}
+				pinned[slot] = true
+				w.slots[slot] = uint16(i)
+			}
+		}
+	}
+	for slot := range w.slots {
+		if !pinned[slot] {
+			w.slots[slot] = uint16(rendezvousShard(w.names, slot))
+		}
+	}
+	return w, nil
+}
+
+// rendezvousShard returns the index of the shard with the highest hash of its
+// name and the slot.
+func rendezvousShard(names []string, slot int) (idx int) {
+	var buf [32]byte
+	var max uint64
+	for i, name := range names {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(name))
+		_, _ = h.Write(strconv.AppendInt(buf[:0], int64(slot), 10))
+		if sum := h.Sum64(); i == 0 || sum > max {
+			max = sum
+			idx = i
+		}
+	}
+	return idx
+}
+
+// redisSlot returns the Redis Cluster hash slot of a key.
+func redisSlot(key string) uint16 {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return crc16(key) % redisSlotCount
+}
+
+// crc16 implements CRC16-CCITT (XMODEM) as used by Redis Cluster.
+func crc16(s string) (crc uint16) {
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+type redisSharded struct {
+	shards []redisWrapper
+	names  []string
+	slots  [redisSlotCount]uint16 // slot => index of shard
+	log    log.Logger
+}
+
+// group returns for each shard the positions of its keys. The slot gets
+// calculated from the key as stored in Redis, including the key prefix, so the
+// slots match the slots of a Redis Cluster. A shard without keys has a nil
+// slice.
+func (w *redisSharded) group(keys []string) [][]int {
+	groups := make([][]int, len(w.shards))
+	for i, key := range keys {
+		s := w.slots[redisSlot(w.shards[0].prefixKey(key))]
+		groups[s] = append(groups[s], i)
+	}
+	return groups
+}
+
+// all returns a group for each shard.
+func (w *redisSharded) all() [][]int {
+	groups := make([][]int, len(w.shards))
+	for i := range groups {
+		groups[i] = []int{}
+	}
+	return groups
+}
+
+// each calls fn concurrently for each shard with a non-nil group and returns
+// the first error.
+func (w *redisSharded) each(groups [][]int, fn func(s redisWrapper, name string, pos []int) error) error {
+	errs := make([]error, len(groups))
+	var wg sync.WaitGroup
+	for i, pos := range groups {
+		if pos == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, pos []int) {
+			defer wg.Done()
+			if err := fn(w.shards[i], w.names[i], pos); err != nil {
+				errs[i] = errors.Wrapf(err, "[objcache] Redis shard %q", w.names[i])
+			}
+		}(i, pos)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pickKeys(keys []string, pos []int) []string {
+	ret := make([]string, len(pos))
+	for i, p := range pos {
+		ret[i] = keys[p]
+	}
+	return ret
+}
+
+func pickValues(values [][]byte, pos []int) [][]byte {
+	ret := make([][]byte, len(pos))
+	for i, p := range pos {
+		ret[i] = values[p]
+	}
+	return ret
+}
+
+func pickExpirations(expirations []time.Duration, pos []int) []time.Duration {
+	ret := make([]time.Duration, len(pos))
+	if len(expirations) > 0 {
+		for i, p := range pos {
+			ret[i] = expirations[p]
+		}
+	}
+	return ret
+}
+
+func (w *redisSharded) Set(ctx context.Context, keys []string, values [][]byte, expirations []time.Duration) error {
+	return w.each(w.group(keys), func(s redisWrapper, _ string, pos []int) error {
+		return s.Set(ctx, pickKeys(keys, pos), pickValues(values, pos), pickExpirations(expirations, pos))
+	})
+}
+
+func (w *redisSharded) SetWithTags(ctx context.Context, keys []string, values [][]byte, expirations []time.Duration, tags [][]string) error {
+	return w.each(w.group(keys), func(s redisWrapper, _ string, pos []int) error {
+		shardTags := make([][]string, len(pos))
+		for i, p := range pos {
+			if p < len(tags) {
+				shardTags[i] = tags[p]
+			}
+		}
+		return s.SetWithTags(ctx, pickKeys(keys, pos), pickValues(values, pos), pickExpirations(expirations, pos), shardTags)
+	})
+}
+
+// Get treats the keys of a failed shard as not found.
+func (w *redisSharded) Get(ctx context.Context, keys []string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	groups := w.group(keys)
+	_ = w.each(groups, func(s redisWrapper, name string, pos []int) error {
+		vals, err := s.Get(ctx, pickKeys(keys, pos))
+		if err != nil {
+			if w.log != nil && w.log.IsInfo() {
+				w.log.Info("objcache.redisSharded.Get.error", log.Err(err), log.String("shard", name))
+			}
+			return nil
+		}
+		for i, p := range pos {
+			values[p] = vals[i] // each shard writes different positions
+		}
+		return nil
+	})
+	return values, nil
+}
+
+func (w *redisSharded) Delete(ctx context.Context, keys []string) error {
+	return w.each(w.group(keys), func(s redisWrapper, _ string, pos []int) error {
+		return s.Delete(ctx, pickKeys(keys, pos))
+	})
+}
+
+func (w *redisSharded) InvalidateTags(ctx context.Context, tags []string) error {
+	return w.each(w.all(), func(s redisWrapper, _ string, _ []int) error {
+		return s.InvalidateTags(ctx, tags)
+	})
+}
+
+func (w *redisSharded) Truncate(ctx context.Context) error {
+	return w.each(w.all(), func(s redisWrapper, _ string, _ []int) error {
+		return s.Truncate(ctx)
+	})
+}
+
+func (w *redisSharded) Close() error {
+	return w.each(w.all(), func(s redisWrapper, _ string, _ []int) error {
+		return s.Close()
+	})
+}
@@ -0,0 +1,317 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build redis csall
+
+package objcache
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/gomodule/redigo/redis"
+)
+
+var (
+	_ Storager    = (*redisSharded)(nil)
+	_ TagStorager = (*redisSharded)(nil)
+)
+
+func TestRedisSlot(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, uint16(0x31C3), crc16("123456789"))
+	assert.Exactly(t, uint16(0), crc16(""))
+
+	tests := []struct {
+		key  string
+		want uint16
+	}{
+		{"foo", 12182},
+		{"bar", 5061},
+		{"{foo}.bar", 12182},
+		{"baz{foo}", 12182},
+		{"{bar}{foo}", 5061},
+		{"foo{", crc16("foo{") % redisSlotCount},
+		{"foo{}", crc16("foo{}") % redisSlotCount},
+	}
+	for _, test := range tests {
+		assert.Exactly(t, test.want, redisSlot(test.key), "Key %q", test.key)
+	}
+	assert.NotEqual(t, redisSlot("foo"), redisSlot("{}foo"))
+}
+
+func newTestRedisShards(names ...string) []RedisShard {
+	shards := make([]RedisShard, len(names))
+	for i, n := range names {
+		shards[i] = RedisShard{Name: n, Pool: &redis.Pool{}}
+	}
+	return shards
+}
+
+func TestNewRedisSharded_Slots(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no shards", func(t *testing.T) {
+		_, err := newRedisSharded(nil, RedisShardedOption{})
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+	t.Run("no pool", func(t *testing.T) {
+		_, err := newRedisSharded([]RedisShard{{Name: "a"}}, RedisShardedOption{})
+		assert.True(t, errors.NotValid.Match(err), "%+v", err)
+	})
+	t.Run("invalid ranges", func(t *testing.T) {
+		for i, sr := range [][]RedisSlotRange{
+			{{From: 10, To: 9}},
+			{{From: 0, To: redisSlotCount}},
+			{{From: 0, To: 100}, {From: 100, To: 200}},
+		} {
+			shards := newTestRedisShards("a", "b")
+			shards[0].Slots = sr[:1]
+			shards[1].Slots = sr[1:]
+			_, err := newRedisSharded(shards, RedisShardedOption{})
+			assert.True(t, errors.NotValid.Match(err), "Index %d: %+v", i, err)
+		}
+	})
+	t.Run("pinned ranges", func(t *testing.T) {
+		shards := newTestRedisShards("a", "b", "c")
+		shards[0].Slots = []RedisSlotRange{{From: 0, To: 5460}}
+		shards[1].Slots = []RedisSlotRange{{From: 5461, To: 10922}}
+		shards[2].Slots = []RedisSlotRange{{From: 10923, To: redisSlotCount - 1}}
+		w, err := newRedisSharded(shards, RedisShardedOption{})
+		assert.NoError(t, err)
+
+		assert.Exactly(t, [][]int{{1, 3}, {}, {0, 2}}, normalizeGroups(w.group([]string{"foo", "bar", "{foo}x", "{bar}y"})))
+		assert.Exactly(t, uint16(0), w.slots[0])
+		assert.Exactly(t, uint16(1), w.slots[5461])
+		assert.Exactly(t, uint16(2), w.slots[redisSlotCount-1])
+	})
+}
+
+// normalizeGroups replaces nil with empty slices to ease the comparison.
+func normalizeGroups(groups [][]int) [][]int {
+	for i, g := range groups {
+		if g == nil {
+			groups[i] = []int{}
+		}
+	}
+	return groups
+}
+
+func TestNewRedisSharded_Rendezvous(t *testing.T) {
+	t.Parallel()
+
+	w3, err := newRedisSharded(newTestRedisShards("redis-a:6379", "redis-b:6379", "redis-c:6379"), RedisShardedOption{})
+	assert.NoError(t, err)
+
+	var counts [3]int
+	for _, s := range w3.slots {
+		counts[s]++
+	}
+	for i, c := range counts {
+		assert.True(t, c > redisSlotCount/4, "Shard %d has only %d slots", i, c)
+	}
+
+	// Removing a shard moves only its own slots.
+	w2, err := newRedisSharded(newTestRedisShards("redis-a:6379", "redis-c:6379"), RedisShardedOption{})
+	assert.NoError(t, err)
+	for slot, s := range w3.slots {
+		switch s {
+		case 0:
+			assert.Exactly(t, uint16(0), w2.slots[slot], "Slot %d", slot)
+		case 2:
+			assert.Exactly(t, uint16(1), w2.slots[slot], "Slot %d", slot)
+		}
+	}
+}
+
+func newTestMiniRedisShards(t *testing.T, count int) ([]*miniredis.Miniredis, []RedisShard) {
+	mrs := make([]*miniredis.Miniredis, count)
+	shards := make([]RedisShard, count)
+	for i := range mrs {
+		mr := miniredis.NewMiniRedis()
+		assert.NoError(t, mr.Start())
+		addr := mr.Addr()
+		mrs[i] = mr
+		shards[i] = RedisShard{
+			Name: "shard" + strconv.Itoa(i),
+			Pool: &redis.Pool{
+				Dial: func() (redis.Conn, error) { return redis.Dial("tcp", addr) },
+			},
+		}
+	}
+	return mrs, shards
+}
+
+func newTestRedisShardedKeys(count int) ([]string, [][]byte) {
+	keys := make([]string, count)
+	values := make([][]byte, count)
+	for i := range keys {
+		keys[i] = "key" + strconv.Itoa(i)
+		values[i] = []byte("value" + strconv.Itoa(i))
+	}
+	return keys, values
+}
+
+func TestRedisSharded_SetGetDelete(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	mrs, shards := newTestMiniRedisShards(t, 3)
+	for _, mr := range mrs {
+		defer mr.Close()
+	}
+	st, err := NewRedisShardedClient(shards, &RedisShardedOption{KeyPrefix: "sh_"})()
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, st.Close()) }()
+	w := st.(*redisSharded)
+
+	keys, values := newTestRedisShardedKeys(30)
+	assert.NoError(t, st.Set(ctx, keys, values, nil))
+
+	var total int
+	for i, mr := range mrs {
+		var want []string
+		for _, key := range keys {
+			if w.slots[redisSlot("sh_"+key)] == uint16(i) {
+				want = append(want, "sh_"+key)
+			}
+		}
+		sort.Strings(want)
+		assert.Exactly(t, want, mr.Keys(), "Shard %d", i)
+		assert.True(t, len(want) > 0, "Shard %d has no keys", i)
+		total += len(want)
+	}
+	assert.Exactly(t, len(keys), total)
+
+	have, err := st.Get(ctx, append(keys, "missing"))
+	assert.NoError(t, err)
+	assert.Exactly(t, append(values, nil), have)
+
+	assert.NoError(t, st.Delete(ctx, keys[:10]))
+	have, err = st.Get(ctx, keys)
+	assert.NoError(t, err)
+	assert.Exactly(t, make([][]byte, 10), have[:10])
+	assert.Exactly(t, values[10:], have[10:])
+
+	t.Run("same hash tag on same shard", func(t *testing.T) {
+		tagged := []string{"{user1}.name", "{user1}.email", "{user1}.address"}
+		groups := w.group(tagged)
+		for i, g := range groups {
+			if g != nil {
+				assert.Exactly(t, []int{0, 1, 2}, g)
+				assert.Exactly(t, w.slots[redisSlot("user1")], uint16(i))
+			}
+		}
+	})
+}
+
+func TestRedisSharded_ShardFailureIsMiss(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	mrs, shards := newTestMiniRedisShards(t, 3)
+	for _, mr := range mrs[1:] {
+		defer mr.Close()
+	}
+	st, err := NewRedisShardedClient(shards, nil)()
+	assert.NoError(t, err)
+	defer func() { _ = st.Close() }()
+	w := st.(*redisSharded)
+
+	keys, values := newTestRedisShardedKeys(30)
+	assert.NoError(t, st.Set(ctx, keys, values, nil))
+	mrs[0].Close()
+
+	have, err := st.Get(ctx, keys)
+	assert.NoError(t, err)
+	for i, key := range keys {
+		if w.slots[redisSlot(key)] == 0 {
+			assert.Nil(t, have[i], "Key %q", key)
+		} else {
+			assert.Exactly(t, values[i], have[i], "Key %q", key)
+		}
+	}
+
+	err = st.Set(ctx, keys, values, nil)
+	assert.Error(t, err)
+}
+
+func TestRedisSharded_TagsTruncate(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	mrs, shards := newTestMiniRedisShards(t, 3)
+	for _, mr := range mrs {
+		defer mr.Close()
+	}
+	st, err := NewRedisShardedClient(shards, &RedisShardedOption{KeyPrefix: "sh_"})()
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, st.Close()) }()
+	ts := st.(TagStorager)
+
+	keys, values := newTestRedisShardedKeys(30)
+	tags := make([][]string, len(keys))
+	for i := range tags {
+		tags[i] = []string{"all", "mod" + strconv.Itoa(i%2)}
+	}
+	assert.NoError(t, ts.SetWithTags(ctx, keys, values, nil, tags))
+
+	assert.NoError(t, ts.InvalidateTags(ctx, []string{"mod0"}))
+	have, err := st.Get(ctx, keys)
+	assert.NoError(t, err)
+	for i := range keys {
+		if i%2 == 0 {
+			assert.Nil(t, have[i], "Index %d", i)
+		} else {
+			assert.Exactly(t, values[i], have[i], "Index %d", i)
+		}
+	}
+
+	assert.NoError(t, mrs[1].Set("other_key", "1"))
+	assert.NoError(t, st.Truncate(ctx))
+	for i, mr := range mrs {
+		if i == 1 {
+			assert.Exactly(t, []string{"other_key"}, mr.Keys())
+		} else {
+			assert.Empty(t, mr.Keys(), "Shard %d", i)
+		}
+	}
+}
+
+func TestRedisSharded_Service(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	mrs, shards := newTestMiniRedisShards(t, 2)
+	for _, mr := range mrs {
+		defer mr.Close()
+	}
+	p, err := NewService(nil, NewRedisShardedClient(shards, nil), &ServiceOptions{Codec: JSONCodec{}})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+
+	keys := []string{"a", "b", "c", "d", "e", "f"}
+	src := []interface{}{1, 2, 3, 4, 5, 6}
+	assert.NoError(t, p.SetMulti(ctx, keys, src, nil))
+
+	var a, b, c, d, e, f int
+	assert.NoError(t, p.GetMulti(ctx, keys, []interface{}{&a, &b, &c, &d, &e, &f}))
+	assert.Exactly(t, []int{1, 2, 3, 4, 5, 6}, []int{a, b, c, d, e, f})
+}
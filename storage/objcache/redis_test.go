@@ -16,4 +16,113 @@
 
 package objcache
 
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/corestoreio/pkg/util/assert"
+	"github.com/gomodule/redigo/redis"
+)
+
 var _ Storager = (*redisWrapper)(nil)
+
+func newTestRedisWrapper(t *testing.T) (*miniredis.Miniredis, redisWrapper) {
+	mr := miniredis.NewMiniRedis()
+	assert.NoError(t, mr.Start())
+	addr := mr.Addr()
+	return mr, makeRedisWrapper(&redis.Pool{
+		Dial: func() (redis.Conn, error) { return redis.Dial("tcp", addr) },
+	}, &RedisOption{KeyPrefix: "p_"})
+}
+
+func TestRedisWrapper_Set_ReplyError(t *testing.T) {
+	mr, w := newTestRedisWrapper(t)
+	defer mr.Close()
+	ctx := context.TODO()
+
+	// The connection does not authenticate, so each command fails with an
+	// error reply.
+	mr.RequireAuth("secret")
+	err := w.Set(ctx, []string{"k1", "k2"}, [][]byte{[]byte("v1"), []byte("v2")}, []time.Duration{time.Minute, 0})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "NOAUTH")
+	assert.Empty(t, mr.Keys())
+}
+
+func TestRedisWrapper_SetWithTags_Expiration(t *testing.T) {
+	mr, w := newTestRedisWrapper(t)
+	defer mr.Close()
+	ctx := context.TODO()
+
+	assert.NoError(t, w.SetWithTags(ctx, []string{"k1", "k2"}, [][]byte{[]byte("v1"), []byte("v2")},
+		[]time.Duration{time.Minute, 90 * time.Second}, [][]string{{"t1"}, {"t1", "t2"}}))
+	assert.Exactly(t, 90*time.Second, mr.TTL("p_~tag:t1"))
+	assert.Exactly(t, 90*time.Second, mr.TTL("p_~tag:t2"))
+	assert.Exactly(t, time.Minute, mr.TTL("p_~keytags:k1"))
+	assert.Exactly(t, 90*time.Second, mr.TTL("p_~keytags:k2"))
+
+	// A shorter expiration does not shorten the TTL of the tag set.
+	assert.NoError(t, w.SetWithTags(ctx, []string{"k3"}, [][]byte{[]byte("v3")}, []time.Duration{time.Second}, [][]string{{"t1"}}))
+	assert.Exactly(t, 90*time.Second, mr.TTL("p_~tag:t1"))
+	assert.Exactly(t, time.Second, mr.TTL("p_k3"))
+
+	// A key without expiration makes the tag set persistent.
+	assert.NoError(t, w.SetWithTags(ctx, []string{"k4"}, [][]byte{[]byte("v4")}, nil, [][]string{{"t1"}}))
+	assert.Exactly(t, time.Duration(0), mr.TTL("p_~tag:t1"))
+	assert.Exactly(t, time.Duration(0), mr.TTL("p_k4"))
+	// and stays persistent.
+	assert.NoError(t, w.SetWithTags(ctx, []string{"k5"}, [][]byte{[]byte("v5")}, []time.Duration{time.Hour}, [][]string{{"t1"}}))
+	assert.Exactly(t, time.Duration(0), mr.TTL("p_~tag:t1"))
+
+	members, err := mr.Members("p_~tag:t1")
+	assert.NoError(t, err)
+	assert.Exactly(t, []string{"p_k1", "p_k2", "p_k3", "p_k4", "p_k5"}, members)
+}
+
+func TestRedisWrapper_Delete_Tags(t *testing.T) {
+	mr, w := newTestRedisWrapper(t)
+	defer mr.Close()
+	ctx := context.TODO()
+
+	assert.NoError(t, w.SetWithTags(ctx, []string{"k1", "k2"}, [][]byte{[]byte("v1"), []byte("v2")},
+		nil, [][]string{{"t1", "t2"}, {"t1"}}))
+	assert.NoError(t, w.Set(ctx, []string{"k3"}, [][]byte{[]byte("v3")}, nil))
+
+	assert.NoError(t, w.Delete(ctx, []string{"k1", "k3"}))
+	assert.Exactly(t, []string{"p_k2", "p_~keytags:k2", "p_~tag:t1"}, mr.Keys())
+	members, err := mr.Members("p_~tag:t1")
+	assert.NoError(t, err)
+	assert.Exactly(t, []string{"p_k2"}, members)
+
+	// Invalidating a tag removes its keys from the other tags.
+	assert.NoError(t, w.SetWithTags(ctx, []string{"k1"}, [][]byte{[]byte("v1")}, nil, [][]string{{"t1", "t2"}}))
+	assert.NoError(t, w.InvalidateTags(ctx, []string{"t2"}))
+	members, err = mr.Members("p_~tag:t1")
+	assert.NoError(t, err)
+	assert.Exactly(t, []string{"p_k2"}, members)
+}
+
+func TestRedisWrapper_Tags_KeyNamespace(t *testing.T) {
+	mr, w := newTestRedisWrapper(t)
+	defer mr.Close()
+	ctx := context.TODO()
+
+	// Keys which look like the internal tag keys never overwrite them.
+	assert.NoError(t, w.SetWithTags(ctx, []string{"k1"}, [][]byte{[]byte("v1")}, nil, [][]string{{"t1"}}))
+	assert.NoError(t, w.Set(ctx, []string{"~tag:t1", "~keytags:k1", "tag:t1"}, [][]byte{[]byte("x"), []byte("y"), []byte("z")}, nil))
+	assert.Exactly(t, []string{"p_k1", "p_tag:t1", "p_~keytags:k1", "p_~tag:t1", "p_~~keytags:k1", "p_~~tag:t1"}, mr.Keys())
+
+	vals, err := w.Get(ctx, []string{"~tag:t1"})
+	assert.NoError(t, err)
+	assert.Exactly(t, [][]byte{[]byte("x")}, vals)
+
+	assert.NoError(t, w.SetWithTags(ctx, []string{"~k2"}, [][]byte{[]byte("v2")}, nil, [][]string{{"t1"}}))
+	members, err := mr.Members("p_~tag:t1")
+	assert.NoError(t, err)
+	assert.Exactly(t, []string{"p_k1", "p_~~k2"}, members)
+
+	assert.NoError(t, w.InvalidateTags(ctx, []string{"t1"}))
+	assert.Exactly(t, []string{"p_tag:t1", "p_~~keytags:k1", "p_~~tag:t1"}, mr.Keys())
+}
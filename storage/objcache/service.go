@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/corestoreio/errors"
+	"github.com/corestoreio/pkg/sync/singleflight"
 	"github.com/corestoreio/pkg/util/bufferpool"
 )
 
@@ -44,6 +45,19 @@ type Storager interface {
 	Close() error
 }
 
+// TagStorager gets implemented by a Storager which supports tag based
+// invalidation. A tag groups several keys, for example all products of a
+// category, and allows to delete them at once.
+type TagStorager interface {
+	// SetWithTags same as Set but associates each key with the tags at the
+	// same index. A backend might keep the previous tags of a key, which leads
+	// at most to an unneeded deletion of the key.
+	SetWithTags(ctx context.Context, keys []string, values [][]byte, expirations []time.Duration, tags [][]string) error
+	// InvalidateTags deletes all keys associated with at least one of the
+	// tags.
+	InvalidateTags(ctx context.Context, tags []string) error
+}
+
 type NewStorageFn func() (Storager, error)
 
 // Codecer defines the functions needed to create a new Encoder or Decoder
@@ -149,17 +163,17 @@ func decodeOne(c Codecer, data []byte, key string, dst interface{}) (err error)
 // Encode encodes all items to their byte slice representation. Returns two
 // slices whose indexes match to the other. The data might be appended to the
 // optional arguments `keys` and `values`.
-func encodeAll(codec Codecer, ri *rawItems, defaultExpire time.Duration, keys []string, src []interface{}, expires []time.Duration) (_ *rawItems, err error) {
+func (tr *Service) encodeAll(ri *rawItems, keys []string, src []interface{}, expires []time.Duration) (_ *rawItems, err error) {
 	lenExpires := len(expires)
 	for i, key := range keys {
 		ri.keys = append(ri.keys, key)
 		var buf bytes.Buffer // TODO a buffer pool can be used because of the append
-		if err := encodeOne(codec, &buf, key, src[i]); err != nil {
+		if err := tr.encodeValue(&buf, key, src[i]); err != nil {
 			return nil, errors.WithStack(err)
 		}
 		ri.values = append(ri.values, buf.Bytes())
 
-		e := defaultExpire
+		e := tr.defaultExpiration
 		if lenExpires > 0 && expires[i] != 0 {
 			e = expires[i]
 		}
@@ -168,9 +182,9 @@ func encodeAll(codec Codecer, ri *rawItems, defaultExpire time.Duration, keys []
 	return ri, nil
 }
 
-func decodeAll(codec Codecer, values [][]byte, keys []string, dst []interface{}) error {
+func (tr *Service) decodeAll(values [][]byte, keys []string, dst []interface{}) error {
 	for i, key := range keys {
-		if err := decodeOne(codec, values[i], key, dst[i]); err != nil {
+		if _, err := tr.decodeValue(values[i], key, dst[i]); err != nil {
 			return errors.WithStack(err)
 		}
 	}
@@ -190,6 +204,15 @@ type Service struct {
 	level2            Storager
 	defaultExpiration time.Duration // in seconds
 	rawItemsPool      sync.Pool
+	// sf deduplicates the loader calls of GetOrLoad.
+	sf singleflight.Group
+	// refreshing contains the keys which get refreshed in the background.
+	refreshing sync.Map
+	// mu protects closed. wg.Add must not be called after Close has started
+	// to wait.
+	mu     sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
 }
 
 func (tr *Service) poolGetRawItems() *rawItems {
@@ -234,6 +257,12 @@ func NewService(level1, level2 NewStorageFn, so *ServiceOptions) (_ *Service, er
 	if s.level2, err = level2(); err != nil {
 		return nil, errors.WithStack(err)
 	}
+	if s.so.CollectStats {
+		if s.level1 != nil {
+			s.level1 = &statsStorage{s: s.level1}
+		}
+		s.level2 = &statsStorage{s: s.level2}
+	}
 
 	if so != nil && len(so.PrimeObjects) > 0 {
 		so.Codec = newPooledCodec(so.Codec, so.PrimeObjects...)
@@ -264,7 +293,7 @@ func (tr *Service) Set(ctx context.Context, key string, src interface{}, expires
 	}
 
 	var buf bytes.Buffer
-	if err := encodeOne(tr.so.Codec, &buf, key, src); err != nil {
+	if err := tr.encodeValue(&buf, key, src); err != nil {
 		return errors.WithStack(err)
 	}
 	ri.keys = append(ri.keys, key)
@@ -293,7 +322,7 @@ func (tr *Service) SetMulti(ctx context.Context, keys []string, src []interface{
 	ri := tr.poolGetRawItems()
 	defer tr.poolPutRawItems(ri)
 
-	ri, err := encodeAll(tr.so.Codec, ri, tr.defaultExpiration, keys, src, expires)
+	ri, err := tr.encodeAll(ri, keys, src, expires)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -309,6 +338,87 @@ func (tr *Service) SetMulti(ctx context.Context, keys []string, src []interface{
 	return nil
 }
 
+// SetWithTags same as Set but associates the key with the tags. Calling
+// InvalidateTags with one of the tags deletes the key. A plain Set does not
+// change the tags of a key, which might lead at most to a deleted key which
+// does not belong anymore to a tag. Returns a NotSupported error if a storage
+// backend does not implement TagStorager.
+func (tr *Service) SetWithTags(ctx context.Context, key string, src interface{}, expires time.Duration, tags ...string) error {
+	keys := [1]string{key}
+	srcs := [1]interface{}{src}
+	exps := [1]time.Duration{expires}
+	tgs := [1][]string{tags}
+	return tr.SetMultiWithTags(ctx, keys[:], srcs[:], exps[:], tgs[:])
+}
+
+// SetMultiWithTags same as SetMulti but associates each key with the tags at
+// the same index.
+func (tr *Service) SetMultiWithTags(ctx context.Context, keys []string, src []interface{}, expires []time.Duration, tags [][]string) error {
+	if lk, ld, lt := len(keys), len(src), len(tags); lk != ld || lk != lt {
+		return errors.Mismatch.Newf("[objcache] Length of keys (%d) vs length of src (%d) vs length of tags (%d) must be equal", lk, ld, lt)
+	}
+	level1, level2, err := tr.tagStoragers()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	ri := tr.poolGetRawItems()
+	defer tr.poolPutRawItems(ri)
+
+	ri, err = tr.encodeAll(ri, keys, src, expires)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if level1 != nil {
+		if err := level1.SetWithTags(ctx, ri.keys, ri.values, ri.expires, tags); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if err := level2.SetWithTags(ctx, ri.keys, ri.values, ri.expires, tags); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// InvalidateTags deletes in all caches the keys which are associated with at
+// least one of the tags. Returns a NotSupported error if a storage backend does
+// not implement TagStorager.
+func (tr *Service) InvalidateTags(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	level1, level2, err := tr.tagStoragers()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if level1 != nil {
+		if err := level1.InvalidateTags(ctx, tags); err != nil {
+			return errors.Wrapf(err, "[objcache] Level1 with tags %v", tags)
+		}
+	}
+	if err := level2.InvalidateTags(ctx, tags); err != nil {
+		return errors.Wrapf(err, "[objcache] Level2 with tags %v", tags)
+	}
+	return nil
+}
+
+// tagStoragers checks the original storages because the statistics wrapper
+// always implements TagStorager. The returned TagStoragers still collect the
+// statistics.
+func (tr *Service) tagStoragers() (level1, level2 TagStorager, err error) {
+	if tr.level1 != nil {
+		if _, ok := unwrapStats(tr.level1).(TagStorager); !ok {
+			return nil, nil, errors.NotSupported.Newf("[objcache] Level1 storage %T does not support tags", unwrapStats(tr.level1))
+		}
+		level1 = tr.level1.(TagStorager)
+	}
+	if _, ok := unwrapStats(tr.level2).(TagStorager); !ok {
+		return nil, nil, errors.NotSupported.Newf("[objcache] Level2 storage %T does not support tags", unwrapStats(tr.level2))
+	}
+	return level1, tr.level2.(TagStorager), nil
+}
+
 // unmarshaler is the interface representing objects that can
 // unmarshal themselves.  The argument points to data that may be
 // overwritten, so implementations should not keep references to the
@@ -350,7 +460,7 @@ func (tr *Service) Get(ctx context.Context, key string, dst interface{}) (err er
 	}
 	if err == nil {
 		idst := [1]interface{}{dst}
-		if err2 := decodeAll(tr.so.Codec, vals, ri.keys, idst[:]); err2 != nil {
+		if err2 := tr.decodeAll(vals, ri.keys, idst[:]); err2 != nil {
 			return errors.WithStack(err2)
 		}
 	}
@@ -380,7 +490,7 @@ func (tr *Service) GetMulti(ctx context.Context, keys []string, dst []interface{
 		return errors.WithStack(err)
 	}
 
-	if err := decodeAll(tr.so.Codec, vals, keys, dst); err != nil {
+	if err := tr.decodeAll(vals, keys, dst); err != nil {
 		return errors.WithStack(err)
 	}
 
@@ -414,8 +524,13 @@ func (tr *Service) Delete(ctx context.Context, key ...string) error {
 	return nil
 }
 
-// Close closes the underlying storage engines.
+// Close waits for the background refreshes of GetOrLoad and closes the
+// underlying storage engines.
 func (tr *Service) Close() error {
+	tr.mu.Lock()
+	tr.closed = true
+	tr.mu.Unlock()
+	tr.wg.Wait()
 	if tr.level1 != nil {
 		if err := tr.level1.Close(); err != nil {
 			return errors.WithStack(err)
@@ -376,6 +376,41 @@ func newTestServiceDelete(t *testing.T, level2 objcache.NewStorageFn) {
 	})
 }
 
+func newTestServiceTags(t *testing.T, level2 objcache.NewStorageFn) {
+	p, err := objcache.NewService(objcache.NewBlackHoleClient(nil), level2, newSrvOpt(JSONCodec{}))
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+
+	ctx := context.TODO()
+	assertValues := func(t *testing.T, want map[string]int) {
+		for key, w := range want {
+			var have int
+			err := p.Get(ctx, key, &have)
+			assert.NoError(t, err, "%+v", err)
+			assert.Exactly(t, w, have, "Key %q", key)
+		}
+	}
+
+	assert.NoError(t, p.SetWithTags(ctx, "tag_product1", 1, 0, "cat_1", "cat_2"))
+	assert.NoError(t, p.SetMultiWithTags(ctx,
+		[]string{"tag_product2", "tag_product3"}, []interface{}{2, 3}, nil,
+		[][]string{{"cat_2"}, {"cat_3"}},
+	))
+	assert.NoError(t, p.Set(ctx, "tag_product4", 4, 0))
+
+	err = p.InvalidateTags(ctx, "cat_2")
+	assert.NoError(t, err, "%+v", err)
+	assertValues(t, map[string]int{"tag_product1": 0, "tag_product2": 0, "tag_product3": 3, "tag_product4": 4})
+
+	err = p.InvalidateTags(ctx, "cat_1", "cat_3", "cat_unknown")
+	assert.NoError(t, err, "%+v", err)
+	assertValues(t, map[string]int{"tag_product3": 0, "tag_product4": 4})
+}
+
+func TestNewCacheSimpleInmemory_Tags(t *testing.T) {
+	newTestServiceTags(t, objcache.NewCacheSimpleInmemory)
+}
+
 func testExpiration(t *testing.T, cb func(), level2 objcache.NewStorageFn, so *objcache.ServiceOptions) {
 	p, err := objcache.NewService(nil, level2, so)
 	if err != nil {
@@ -101,3 +101,54 @@ func TestEncoding_Text_Binary(t *testing.T) {
 		assert.NotEmpty(t, obj2)
 	})
 }
+
+// noTagStorage hides the TagStorager interface of the embedded Storager.
+type noTagStorage struct {
+	Storager
+}
+
+func TestService_Tags_Errors(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	t.Run("level1 NotSupported", func(t *testing.T) {
+		p, err := NewService(func() (Storager, error) { return noTagStorage{&mapCache{}}, nil }, NewCacheSimpleInmemory, &ServiceOptions{Codec: JSONCodec{}})
+		assert.NoError(t, err)
+		err = p.SetWithTags(ctx, "k1", 1, 0, "t1")
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+		err = p.InvalidateTags(ctx, "t1")
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+
+	t.Run("level2 NotSupported", func(t *testing.T) {
+		p, err := NewService(nil, func() (Storager, error) { return noTagStorage{&mapCache{}}, nil }, &ServiceOptions{Codec: JSONCodec{}})
+		assert.NoError(t, err)
+		err = p.SetWithTags(ctx, "k1", 1, 0, "t1")
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+	})
+
+	t.Run("level2 NotSupported with stats", func(t *testing.T) {
+		p, err := NewService(NewCacheSimpleInmemory, func() (Storager, error) { return noTagStorage{&mapCache{}}, nil },
+			&ServiceOptions{Codec: JSONCodec{}, CollectStats: true})
+		assert.NoError(t, err)
+		err = p.SetWithTags(ctx, "k1", 1, 0, "t1")
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+		vals, err := p.level1.Get(ctx, []string{"k1"})
+		assert.NoError(t, err)
+		assert.Nil(t, vals[0], "level1 must not be written")
+
+		assert.NoError(t, p.level1.Set(ctx, []string{"k1"}, [][]byte{[]byte("1")}, nil))
+		err = p.InvalidateTags(ctx, "t1")
+		assert.True(t, errors.NotSupported.Match(err), "%+v", err)
+		vals, err = p.level1.Get(ctx, []string{"k1"})
+		assert.NoError(t, err)
+		assert.Exactly(t, []byte("1"), vals[0], "level1 must not be invalidated")
+	})
+
+	t.Run("length mismatch", func(t *testing.T) {
+		p, err := NewService(nil, NewCacheSimpleInmemory, &ServiceOptions{Codec: JSONCodec{}})
+		assert.NoError(t, err)
+		err = p.SetMultiWithTags(ctx, []string{"k1", "k2"}, []interface{}{1, 2}, nil, [][]string{{"t1"}})
+		assert.True(t, errors.Mismatch.Match(err), "%+v", err)
+	})
+}
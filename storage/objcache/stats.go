@@ -0,0 +1,298 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import (
+	"context"
+	"expvar"
+	"sync/atomic"
+	"time"
+
+	"github.com/corestoreio/errors"
+)
+
+// latencyBuckets defines the upper bounds of the latency histogram. The last
+// bucket of a histogram counts all durations greater than the last bound.
+var latencyBuckets = [...]time.Duration{
+	time.Microsecond, 5 * time.Microsecond, 10 * time.Microsecond, 50 * time.Microsecond,
+	100 * time.Microsecond, 500 * time.Microsecond, time.Millisecond, 5 * time.Millisecond,
+	10 * time.Millisecond, 50 * time.Millisecond, 100 * time.Millisecond, 500 * time.Millisecond,
+	time.Second,
+}
+
+type histogram struct {
+	counts [len(latencyBuckets) + 1]uint64
+	sum    uint64 // nano seconds
+}
+
+func (h *histogram) observe(d time.Duration) {
+	i := 0
+	for ; i < len(latencyBuckets) && d > latencyBuckets[i]; i++ {
+	}
+	atomic.AddUint64(&h.counts[i], 1)
+	atomic.AddUint64(&h.sum, uint64(d))
+}
+
+func (h *histogram) stats() HistogramStats {
+	hs := HistogramStats{
+		Sum:     time.Duration(atomic.LoadUint64(&h.sum)),
+		Buckets: make([]HistogramBucket, len(h.counts)),
+	}
+	for i := range h.counts {
+		c := atomic.LoadUint64(&h.counts[i])
+		hs.Count += c
+		hs.Buckets[i].Count = c
+		if i < len(latencyBuckets) {
+			hs.Buckets[i].LE = latencyBuckets[i].String()
+		} else {
+			hs.Buckets[i].LE = "+Inf"
+		}
+	}
+	return hs
+}
+
+// HistogramStats contains the latencies of the calls to a storage backend.
+type HistogramStats struct {
+	Count   uint64
+	Sum     time.Duration
+	Buckets []HistogramBucket
+}
+
+// HistogramBucket counts the durations between the previous bound and LE.
+type HistogramBucket struct {
+	// LE defines the upper bound, including, of the bucket. "+Inf" for the
+	// last bucket.
+	LE    string
+	Count uint64
+}
+
+// BackendStats contains the statistics reported by the storage backend itself.
+type BackendStats struct {
+	// Length number of entries.
+	Length int64
+	// Size in bytes or in objects, depending on the backend.
+	Size int64
+	// Capacity maximum size, zero if unlimited.
+	Capacity  int64
+	Evictions int64
+}
+
+// BackendStatser gets implemented by a Storager which reports its own
+// statistics.
+type BackendStatser interface {
+	BackendStats() BackendStats
+}
+
+// StorageStats contains the statistics of one storage level. The counters of
+// hits, misses, sets and deletes count the keys while the latencies get
+// measured per call.
+type StorageStats struct {
+	Hits          uint64
+	Misses        uint64
+	Sets          uint64
+	Deletes       uint64
+	Truncates     uint64
+	Errors        uint64
+	BytesRead     uint64
+	BytesWritten  uint64
+	GetLatency    HistogramStats
+	SetLatency    HistogramStats
+	DeleteLatency HistogramStats
+	Backend       *BackendStats `json:",omitempty"`
+}
+
+// ServiceStats contains the statistics of a Service. The ratios describe which
+// share of the looked up keys has been found in level1, in level2 or in none
+// of them.
+type ServiceStats struct {
+	Level1         *StorageStats `json:",omitempty"`
+	Level2         *StorageStats `json:",omitempty"`
+	Level1HitRatio float64
+	Level2HitRatio float64
+	MissRatio      float64
+}
+
+// statsStorage wraps a Storager and collects the statistics. The counters come
+// first to guarantee the 64-bit alignment of the atomic operations.
+type statsStorage struct {
+	hits          uint64
+	misses        uint64
+	sets          uint64
+	deletes       uint64
+	truncates     uint64
+	errors        uint64
+	bytesRead     uint64
+	bytesWritten  uint64
+	getLatency    histogram
+	setLatency    histogram
+	deleteLatency histogram
+	s             Storager
+}
+
+// unwrapStats returns the original storage.
+func unwrapStats(s Storager) Storager {
+	if ss, ok := s.(*statsStorage); ok {
+		return ss.s
+	}
+	return s
+}
+
+func (ss *statsStorage) countErr(err error) error {
+	if err != nil {
+		atomic.AddUint64(&ss.errors, 1)
+	}
+	return err
+}
+
+func (ss *statsStorage) countSet(start time.Time, values [][]byte) {
+	ss.setLatency.observe(now().Sub(start))
+	var size int
+	for _, v := range values {
+		size += len(v)
+	}
+	atomic.AddUint64(&ss.sets, uint64(len(values)))
+	atomic.AddUint64(&ss.bytesWritten, uint64(size))
+}
+
+func (ss *statsStorage) Set(ctx context.Context, keys []string, values [][]byte, expirations []time.Duration) error {
+	start := now()
+	if err := ss.s.Set(ctx, keys, values, expirations); err != nil {
+		return ss.countErr(err)
+	}
+	ss.countSet(start, values)
+	return nil
+}
+
+func (ss *statsStorage) SetWithTags(ctx context.Context, keys []string, values [][]byte, expirations []time.Duration, tags [][]string) error {
+	ts, ok := ss.s.(TagStorager)
+	if !ok {
+		return errors.NotSupported.Newf("[objcache] Storage %T does not support tags", ss.s)
+	}
+	start := now()
+	if err := ts.SetWithTags(ctx, keys, values, expirations, tags); err != nil {
+		return ss.countErr(err)
+	}
+	ss.countSet(start, values)
+	return nil
+}
+
+func (ss *statsStorage) InvalidateTags(ctx context.Context, tags []string) error {
+	ts, ok := ss.s.(TagStorager)
+	if !ok {
+		return errors.NotSupported.Newf("[objcache] Storage %T does not support tags", ss.s)
+	}
+	return ss.countErr(ts.InvalidateTags(ctx, tags))
+}
+
+func (ss *statsStorage) Get(ctx context.Context, keys []string) ([][]byte, error) {
+	start := now()
+	values, err := ss.s.Get(ctx, keys)
+	ss.getLatency.observe(now().Sub(start))
+	if err != nil {
+		return nil, ss.countErr(err)
+	}
+	var hits, size int
+	for _, v := range values {
+		if v != nil {
+			hits++
+			size += len(v)
+		}
+	}
+	atomic.AddUint64(&ss.hits, uint64(hits))
+	atomic.AddUint64(&ss.misses, uint64(len(keys)-hits))
+	atomic.AddUint64(&ss.bytesRead, uint64(size))
+	return values, nil
+}
+
+func (ss *statsStorage) Delete(ctx context.Context, keys []string) error {
+	start := now()
+	err := ss.s.Delete(ctx, keys)
+	ss.deleteLatency.observe(now().Sub(start))
+	if err != nil {
+		return ss.countErr(err)
+	}
+	atomic.AddUint64(&ss.deletes, uint64(len(keys)))
+	return nil
+}
+
+func (ss *statsStorage) Truncate(ctx context.Context) error {
+	if err := ss.s.Truncate(ctx); err != nil {
+		return ss.countErr(err)
+	}
+	atomic.AddUint64(&ss.truncates, 1)
+	return nil
+}
+
+func (ss *statsStorage) Close() error {
+	return ss.s.Close()
+}
+
+func (ss *statsStorage) stats() *StorageStats {
+	st := &StorageStats{
+		Hits:          atomic.LoadUint64(&ss.hits),
+		Misses:        atomic.LoadUint64(&ss.misses),
+		Sets:          atomic.LoadUint64(&ss.sets),
+		Deletes:       atomic.LoadUint64(&ss.deletes),
+		Truncates:     atomic.LoadUint64(&ss.truncates),
+		Errors:        atomic.LoadUint64(&ss.errors),
+		BytesRead:     atomic.LoadUint64(&ss.bytesRead),
+		BytesWritten:  atomic.LoadUint64(&ss.bytesWritten),
+		GetLatency:    ss.getLatency.stats(),
+		SetLatency:    ss.setLatency.stats(),
+		DeleteLatency: ss.deleteLatency.stats(),
+	}
+	if bs, ok := ss.s.(BackendStatser); ok {
+		b := bs.BackendStats()
+		st.Backend = &b
+	}
+	return st
+}
+
+// Stats returns a snapshot of the statistics. The snapshot is empty if
+// ServiceOptions.CollectStats has not been set.
+func (tr *Service) Stats() ServiceStats {
+	var st ServiceStats
+	if ss, ok := tr.level1.(*statsStorage); ok {
+		st.Level1 = ss.stats()
+	}
+	if ss, ok := tr.level2.(*statsStorage); ok {
+		st.Level2 = ss.stats()
+	}
+	if st.Level2 == nil {
+		return st
+	}
+
+	lookups := st.Level2.Hits + st.Level2.Misses
+	var l1Hits uint64
+	if st.Level1 != nil {
+		lookups = st.Level1.Hits + st.Level1.Misses
+		l1Hits = st.Level1.Hits
+	}
+	if lookups > 0 {
+		st.Level1HitRatio = float64(l1Hits) / float64(lookups)
+		st.Level2HitRatio = float64(st.Level2.Hits) / float64(lookups)
+		if st.MissRatio = 1 - st.Level1HitRatio - st.Level2HitRatio; st.MissRatio < 0 {
+			st.MissRatio = 0
+		}
+	}
+	return st
+}
+
+// StatsVar returns the statistics as an expvar.Var. For example:
+//
+//	expvar.Publish("objcache_products", srv.StatsVar())
+func (tr *Service) StatsVar() expvar.Var {
+	return expvar.Func(func() interface{} { return tr.Stats() })
+}
@@ -0,0 +1,145 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+	var h histogram
+	h.observe(500 * time.Nanosecond)
+	h.observe(time.Microsecond)
+	h.observe(2 * time.Millisecond)
+	h.observe(time.Minute)
+
+	hs := h.stats()
+	assert.Exactly(t, uint64(4), hs.Count)
+	assert.Exactly(t, time.Minute+2*time.Millisecond+1500*time.Nanosecond, hs.Sum)
+	assert.Len(t, hs.Buckets, len(latencyBuckets)+1)
+	assert.Exactly(t, HistogramBucket{LE: "1µs", Count: 2}, hs.Buckets[0])
+	assert.Exactly(t, HistogramBucket{LE: "5ms", Count: 1}, hs.Buckets[7])
+	assert.Exactly(t, HistogramBucket{LE: "+Inf", Count: 1}, hs.Buckets[len(latencyBuckets)])
+}
+
+func TestService_Stats(t *testing.T) {
+	t.Parallel()
+	ctx := context.TODO()
+
+	t.Run("disabled", func(t *testing.T) {
+		p, err := NewService(nil, NewCacheSimpleInmemory, &ServiceOptions{Codec: JSONCodec{}})
+		assert.NoError(t, err)
+		assert.Exactly(t, ServiceStats{}, p.Stats())
+	})
+
+	t.Run("level1 hits", func(t *testing.T) {
+		p, err := NewService(NewLRU(nil), NewCacheSimpleInmemory, &ServiceOptions{Codec: JSONCodec{}, CollectStats: true})
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, p.Close()) }()
+
+		assert.NoError(t, p.Set(ctx, "k1", "Gopher", 0))
+		var have string
+		assert.NoError(t, p.Get(ctx, "k1", &have))
+		assert.NoError(t, p.Get(ctx, "k2", &have))
+		assert.NoError(t, p.Delete(ctx, "k1"))
+
+		st := p.Stats()
+		assert.Exactly(t, uint64(1), st.Level1.Hits)
+		assert.Exactly(t, uint64(1), st.Level1.Misses)
+		assert.Exactly(t, uint64(1), st.Level1.Sets)
+		assert.Exactly(t, uint64(1), st.Level1.Deletes)
+		assert.Exactly(t, uint64(len(`"Gopher"`)+1), st.Level1.BytesWritten)
+		assert.Exactly(t, uint64(len(`"Gopher"`)+1), st.Level1.BytesRead)
+		assert.Exactly(t, uint64(2), st.Level1.GetLatency.Count)
+		assert.Exactly(t, uint64(1), st.Level2.Sets)
+		assert.Exactly(t, int64(0), st.Level1.Backend.Length)
+		assert.Exactly(t, int64(5000), st.Level1.Backend.Capacity)
+		assert.Exactly(t, 0.5, st.Level1HitRatio)
+		assert.Exactly(t, 0.0, st.Level2HitRatio)
+		assert.Exactly(t, 0.5, st.MissRatio)
+	})
+
+	t.Run("level2 hits", func(t *testing.T) {
+		p, err := NewService(NewBlackHoleClient(nil), NewCacheSimpleInmemory, &ServiceOptions{Codec: JSONCodec{}, CollectStats: true})
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, p.Close()) }()
+
+		assert.NoError(t, p.SetWithTags(ctx, "k1", "Gopher", 0, "t1"))
+		var have string
+		assert.NoError(t, p.Get(ctx, "k1", &have))
+		assert.Exactly(t, "Gopher", have)
+
+		st := p.Stats()
+		assert.Nil(t, st.Level1.Backend)
+		assert.Exactly(t, int64(1), st.Level2.Backend.Length)
+		assert.Exactly(t, 0.0, st.Level1HitRatio)
+		assert.Exactly(t, 1.0, st.Level2HitRatio)
+		assert.Exactly(t, 0.0, st.MissRatio)
+
+		assert.NoError(t, p.InvalidateTags(ctx, "t1"))
+		assert.Exactly(t, int64(0), p.Stats().Level2.Backend.Length)
+
+		var data map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(p.StatsVar().String()), &data))
+		assert.Exactly(t, 1.0, data["Level2HitRatio"])
+	})
+}
+
+func TestService_DebugHandler(t *testing.T) {
+	t.Parallel()
+	p, err := NewService(NewLRU(nil), NewCacheSimpleInmemory, &ServiceOptions{Codec: JSONCodec{}, CollectStats: true})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+	assert.NoError(t, p.Set(context.TODO(), "k1", "Gopher", 0))
+	h := p.DebugHandler()
+
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+		return rec
+	}
+
+	rec := serve(http.MethodGet, "/")
+	assert.Exactly(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"Level1HitRatio"`)
+
+	rec = serve(http.MethodGet, "/?key=k1")
+	assert.Exactly(t, http.StatusOK, rec.Code)
+	var dk debugKey
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dk))
+	assert.Exactly(t, "k1", dk.Key)
+	assert.True(t, dk.Level1.Found)
+	assert.Exactly(t, "\"Gopher\"\n", string(dk.Level2.Value))
+	assert.Exactly(t, uint64(0), p.Stats().Level1.Hits, "debug lookups must not be counted")
+
+	rec = serve(http.MethodDelete, "/?key=k1")
+	assert.Exactly(t, http.StatusNoContent, rec.Code)
+	rec = serve(http.MethodGet, "/?key=k1")
+	assert.True(t, strings.Contains(rec.Body.String(), `"Found": false`), rec.Body.String())
+
+	rec = serve(http.MethodDelete, "/")
+	assert.Exactly(t, http.StatusBadRequest, rec.Code)
+	rec = serve(http.MethodPost, "/?key=k1")
+	assert.Exactly(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,136 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import "sync"
+
+// tagIndex maps tags to keys and keys to tags for the in-memory backends. It
+// is safe for concurrent use. The zero value is ready to use.
+type tagIndex struct {
+	mu      sync.Mutex
+	tagKeys map[string]map[string]struct{}
+	keyTags map[string][]string
+	adds    int // keys added since the last sweep
+	kept    int // keys kept by the last sweep
+}
+
+// add associates each key with its tags. Previous associations of a key get
+// replaced. A key without tags gets removed from the index.
+func (ti *tagIndex) add(keys []string, tags [][]string) {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	if ti.tagKeys == nil {
+		ti.tagKeys = make(map[string]map[string]struct{})
+		ti.keyTags = make(map[string][]string)
+	}
+	for i, key := range keys {
+		ti.removeKey(key)
+		if i >= len(tags) || len(tags[i]) == 0 {
+			continue
+		}
+		kt := make([]string, 0, len(tags[i]))
+		for _, tag := range tags[i] {
+			tk, ok := ti.tagKeys[tag]
+			if !ok {
+				tk = make(map[string]struct{})
+				ti.tagKeys[tag] = tk
+			}
+			if _, ok := tk[key]; !ok {
+				tk[key] = struct{}{}
+				kt = append(kt, tag)
+			}
+		}
+		ti.keyTags[key] = kt
+		ti.adds++
+	}
+}
+
+// removeKey must be called with a locked mutex.
+func (ti *tagIndex) removeKey(key string) {
+	for _, tag := range ti.keyTags[key] {
+		tk := ti.tagKeys[tag]
+		delete(tk, key)
+		if len(tk) == 0 {
+			delete(ti.tagKeys, tag)
+		}
+	}
+	delete(ti.keyTags, key)
+}
+
+// remove deletes the keys and their tags from the index.
+func (ti *tagIndex) remove(keys []string) {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	for _, key := range keys {
+		ti.removeKey(key)
+	}
+}
+
+// prune removes the keys for which gone returns true. A backend calls prune
+// with the keys it has removed on its own, for example after an eviction. gone
+// gets called while the index is locked, so a key which has been set again and
+// gets added concurrently stays in the index.
+func (ti *tagIndex) prune(keys []string, gone func(key string) bool) {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	for _, key := range keys {
+		if _, ok := ti.keyTags[key]; ok && gone(key) {
+			ti.removeKey(key)
+		}
+	}
+}
+
+// sweep removes all keys for which gone returns true, once the number of keys
+// added since the last sweep reaches the number of keys kept by the last sweep.
+// This keeps the costs of the sweeps per added key constant. gone gets called
+// while the index is locked, see prune.
+func (ti *tagIndex) sweep(gone func(key string) bool) {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	if ti.adds == 0 || ti.adds < ti.kept {
+		return
+	}
+	for key := range ti.keyTags {
+		if gone(key) {
+			ti.removeKey(key)
+		}
+	}
+	ti.adds = 0
+	ti.kept = len(ti.keyTags)
+}
+
+// invalidate returns all keys associated with at least one of the tags and
+// removes those keys from the index. The returned keys are unique.
+func (ti *tagIndex) invalidate(tags []string) (keys []string) {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	for _, tag := range tags {
+		for key := range ti.tagKeys[tag] {
+			keys = append(keys, key)
+			ti.removeKey(key)
+		}
+	}
+	return keys
+}
+
+// reset clears the whole index.
+func (ti *tagIndex) reset() {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	ti.tagKeys = nil
+	ti.keyTags = nil
+	ti.adds = 0
+	ti.kept = 0
+}
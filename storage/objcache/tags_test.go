@@ -0,0 +1,134 @@
+// Copyright 2015-present, Cyrill @ Schumacher.fm and the CoreStore contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objcache
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/corestoreio/pkg/util/assert"
+)
+
+func TestTagIndex(t *testing.T) {
+	t.Parallel()
+
+	invalidate := func(ti *tagIndex, tags ...string) []string {
+		keys := ti.invalidate(tags)
+		sort.Strings(keys)
+		return keys
+	}
+
+	t.Run("add and invalidate", func(t *testing.T) {
+		var ti tagIndex
+		ti.add([]string{"k1", "k2", "k3"}, [][]string{{"t1", "t2"}, {"t2"}, nil})
+		assert.Exactly(t, []string{"k1", "k2"}, invalidate(&ti, "t2"))
+		assert.Empty(t, invalidate(&ti, "t1"), "k1 has been removed from all tags")
+		assert.Empty(t, invalidate(&ti, "t3"))
+		assert.Len(t, ti.keyTags, 0)
+		assert.Len(t, ti.tagKeys, 0)
+	})
+
+	t.Run("duplicate tags and keys", func(t *testing.T) {
+		var ti tagIndex
+		ti.add([]string{"k1"}, [][]string{{"t1", "t1"}})
+		assert.Exactly(t, []string{"k1"}, invalidate(&ti, "t1", "t1"))
+	})
+
+	t.Run("replace tags", func(t *testing.T) {
+		var ti tagIndex
+		ti.add([]string{"k1"}, [][]string{{"t1"}})
+		ti.add([]string{"k1"}, [][]string{{"t2"}})
+		assert.Empty(t, invalidate(&ti, "t1"))
+		assert.Exactly(t, []string{"k1"}, invalidate(&ti, "t2"))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		var ti tagIndex
+		ti.add([]string{"k1", "k2"}, [][]string{{"t1"}, {"t1"}})
+		ti.remove([]string{"k1", "k3"})
+		assert.Exactly(t, []string{"k2"}, invalidate(&ti, "t1"))
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		var ti tagIndex
+		ti.add([]string{"k1", "k2", "k3"}, [][]string{{"t1"}, {"t1"}, {"t2"}})
+		var checked []string
+		ti.prune([]string{"k1", "k2", "k4"}, func(key string) bool {
+			checked = append(checked, key)
+			return key == "k1"
+		})
+		assert.Exactly(t, []string{"k1", "k2"}, checked, "k4 is not in the index")
+		assert.Exactly(t, []string{"k2"}, invalidate(&ti, "t1"))
+		assert.Exactly(t, []string{"k3"}, invalidate(&ti, "t2"))
+	})
+
+	t.Run("sweep", func(t *testing.T) {
+		var ti tagIndex
+		live := map[string]bool{"k1": true, "k2": true, "k3": true, "k4": true}
+		gone := func(key string) bool { return !live[key] }
+		ti.add([]string{"k1", "k2", "k3"}, [][]string{{"t1"}, {"t1"}, {"t1"}})
+		ti.sweep(gone)
+		assert.Len(t, ti.keyTags, 3)
+
+		delete(live, "k1")
+		delete(live, "k2")
+		ti.add([]string{"k4"}, [][]string{{"t1"}})
+		ti.sweep(gone)
+		assert.Len(t, ti.keyTags, 4, "sweep not yet due")
+
+		ti.add([]string{"k5", "k6"}, [][]string{{"t1"}, {"t1"}})
+		ti.sweep(gone)
+		assert.Exactly(t, []string{"k3", "k4"}, invalidate(&ti, "t1"))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		var ti tagIndex
+		ti.add([]string{"k1"}, [][]string{{"t1"}})
+		ti.reset()
+		assert.Empty(t, invalidate(&ti, "t1"))
+		ti.add([]string{"k1"}, [][]string{{"t1"}})
+		assert.Exactly(t, []string{"k1"}, invalidate(&ti, "t1"))
+	})
+}
+
+func TestLRUCache_EvictedTags(t *testing.T) {
+	ctx := context.TODO()
+	s, err := NewLRU(&LRUOptions{Capacity: 2, TrackByObjectCount: true})()
+	assert.NoError(t, err)
+	c := s.(lruCache)
+
+	assert.NoError(t, c.SetWithTags(ctx, []string{"k1", "k2", "k3"}, [][]byte{{1}, {2}, {3}}, nil, [][]string{{"t1"}, {"t1"}, {"t2"}}))
+	assert.Len(t, c.tags.keyTags, 2, "k1 has been evicted")
+	assert.Exactly(t, []string{"k2"}, c.tags.invalidate([]string{"t1"}))
+}
+
+func TestMapCache_ExpiredTags(t *testing.T) {
+	defer func() { now = time.Now }()
+	ctx := context.TODO()
+	n := time.Date(2019, 3, 15, 10, 0, 0, 0, time.UTC)
+	now = func() time.Time { return n }
+
+	mc := &mapCache{}
+	assert.NoError(t, mc.SetWithTags(ctx, []string{"k1", "k2"}, [][]byte{{1}, {2}},
+		[]time.Duration{time.Minute, 0}, [][]string{{"t1"}, {"t1"}}))
+	n = n.Add(2 * time.Minute)
+	assert.NoError(t, mc.SetWithTags(ctx, []string{"k3", "k4"}, [][]byte{{3}, {4}},
+		[]time.Duration{time.Minute, time.Minute}, [][]string{{"t1"}, {"t1"}}))
+	assert.Len(t, mc.tags.keyTags, 3, "k1 has been swept after its expiration")
+	_, ok := mc.tags.keyTags["k1"]
+	assert.False(t, ok)
+}
@@ -374,6 +374,19 @@ func NewService(seed uint64, o *Options, opts ...optionFn) (*Service, error) {
 	return s, nil
 }
 
+// FakeFuncByTag returns the function which generates the fake data for a tag
+// or field name like `email` or `first_name`. Aliases get resolved. The
+// returned bool is false if neither a function nor an alias exists.
+func (s *Service) FakeFuncByTag(tag string) (FakeFunc, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if fnAlias, ok := s.funcsAliases[tag]; ok && fnAlias != "" {
+		tag = fnAlias
+	}
+	fn, ok := s.funcs[tag]
+	return fn, ok
+}
+
 // GetLangs returns a slice of available languages
 func (s *Service) GetLangs() []string {
 	lng, _ := AssetDir("data")
@@ -345,6 +345,24 @@ func TestExtend(t *testing.T) {
 	assert.Exactly(t, "test", a.ID)
 }
 
+func TestService_FakeFuncByTag(t *testing.T) {
+	s := MustNewService(0, nil,
+		WithTagFakeFunc("test", func(maxLen int) (interface{}, error) { return "test", nil }),
+		WithTagFakeFuncAlias("test_alias", "test"),
+	)
+
+	fn, ok := s.FakeFuncByTag("test_alias")
+	assert.True(t, ok)
+	v, err := fn(0)
+	assert.NoError(t, err)
+	assert.Exactly(t, "test", v)
+
+	_, ok = s.FakeFuncByTag("email")
+	assert.True(t, ok)
+	_, ok = s.FakeFuncByTag("not_existent")
+	assert.False(t, ok)
+}
+
 func TestTagAlreadyExists(t *testing.T) {
 	s := MustNewService(0, nil, WithTagFakeFunc("email", func(maxLen int) (interface{}, error) { return "", nil }))
 	assert.NotNil(t, s)